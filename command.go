@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	backupUC "github.com/jekiapp/topic-master/internal/usecase/backup"
+	pkgdb "github.com/jekiapp/topic-master/pkg/db"
+	handlerPkg "github.com/jekiapp/topic-master/pkg/handler"
+)
+
+const (
+	tokenEnv      = "TOPIC_MASTER_TOKEN"
+	defaultServer = "http://localhost:4181"
+)
+
+// isCommand reports whether the arguments start with a subcommand rather than the server flags.
+func isCommand(args []string) bool {
+	return len(args) > 0 && !strings.HasPrefix(args[0], "-")
+}
+
+// runCommand runs the subcommand name and returns the exit code. backup, export and import
+// call the API of a running server as root, with the token read from TOPIC_MASTER_TOKEN.
+// restore works on the data directory and needs the server stopped.
+func runCommand(name string, args []string) int {
+	var err error
+	switch name {
+	case "backup":
+		err = backupCommand(args)
+	case "export":
+		err = exportCommand(args)
+	case "import":
+		err = importCommand(args)
+	case "restore":
+		err = restoreCommand(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q, want backup, export, import or restore\n", name)
+		return 2
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// backupCommand takes a snapshot on the server, and downloads it with its checksum when -out is set.
+func backupCommand(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	server := fs.String("server", defaultServer, "URL of the topic-master server, the root token is read from "+tokenEnv)
+	out := fs.String("out", "", "Directory the snapshot and its checksum are downloaded to and verified, kept on the server only when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var info backupUC.SnapshotInfo
+	if err := callAPI(*server, http.MethodPost, "/api/backup/create", strings.NewReader("{}"), &info); err != nil {
+		return err
+	}
+	fmt.Printf("snapshot %s taken on the server, %d bytes, sha256 %s\n", info.File, info.Size, info.Checksum)
+	if *out == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(*out, 0o700); err != nil {
+		return err
+	}
+	path := filepath.Join(*out, info.File)
+	for _, name := range []string{info.File, info.File + pkgdb.ChecksumSuffix} {
+		if err := downloadAPI(*server, "/api/backup/download?file="+url.QueryEscape(name), filepath.Join(*out, name)); err != nil {
+			return err
+		}
+	}
+	if _, err := pkgdb.VerifyBackupFile(path); err != nil {
+		return fmt.Errorf("downloaded snapshot is damaged: %w", err)
+	}
+	fmt.Printf("downloaded to %s, checksum verified\n", path)
+	return nil
+}
+
+// exportCommand writes the logical export of the server state to a JSON file.
+func exportCommand(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	server := fs.String("server", defaultServer, "URL of the topic-master server, the root token is read from "+tokenEnv)
+	out := fs.String("out", "", "JSON file the state is written to (required), it holds password hashes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("-out is required")
+	}
+
+	var state json.RawMessage
+	if err := callAPI(*server, http.MethodGet, "/api/backup/export", nil, &state); err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, state, "", "  "); err != nil {
+		return err
+	}
+	if err := os.WriteFile(*out, indented.Bytes(), 0o600); err != nil {
+		return err
+	}
+	fmt.Printf("state exported to %s\n", *out)
+	return nil
+}
+
+// importCommand loads a JSON file written by export into the server.
+func importCommand(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	server := fs.String("server", defaultServer, "URL of the topic-master server, the root token is read from "+tokenEnv)
+	in := fs.String("in", "", "JSON file written by the export command (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return errors.New("-in is required")
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var resp backupUC.ImportStateResponse
+	if err := callAPI(*server, http.MethodPost, "/api/backup/import", f, &resp); err != nil {
+		return err
+	}
+	fmt.Printf("imported %d users, %d groups, %d memberships, %d entities (%d updated), %d grants and %d tickets\n",
+		resp.Users, resp.Groups, resp.Memberships, resp.Entities, resp.EntitiesUpdated, resp.Grants, resp.Tickets)
+	return nil
+}
+
+// restoreCommand replaces the data file of a stopped server with a backup.
+func restoreCommand(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	dataPath := fs.String("data_path", "", "Path to topic-master data directory (required), the server must be stopped")
+	from := fs.String("from", "", "Snapshot or backup file to restore (required), checked against its .sha256 file when there is one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dataPath == "" || *from == "" {
+		return errors.New("-data_path and -from are required")
+	}
+
+	dataFile := filepath.Join(*dataPath, dataFilename)
+	lock, err := pkgdb.LockDataFile(dataFile)
+	if errors.Is(err, pkgdb.ErrDataFileLocked) {
+		return fmt.Errorf("%s is in use, stop the server before restoring", dataFile)
+	}
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	previous, err := pkgdb.RestoreFromFile(*from, dataFile)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s restored to %s\n", *from, *dataPath)
+	if previous != "" {
+		fmt.Printf("the replaced data is kept in %s\n", previous)
+	}
+	return nil
+}
+
+var apiClient = &http.Client{Timeout: 5 * time.Minute}
+
+func newAPIRequest(server, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, strings.TrimSuffix(server, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("%s is not set, put the token of a root user there", tokenEnv)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// callAPI calls an endpoint answering with handlerPkg.Response and decodes its data into out.
+func callAPI[O any](server, method, path string, body io.Reader, out *O) error {
+	req, err := newAPIRequest(server, method, path, body)
+	if err != nil {
+		return err
+	}
+	resp, err := apiClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var decoded handlerPkg.Response[O]
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK || decoded.Status != handlerPkg.StatusSuccess {
+		return fmt.Errorf("%s %s: %s %s", method, path, resp.Status, decoded.Message)
+	}
+	*out = decoded.Data
+	return nil
+}
+
+// downloadAPI saves the body of a GET endpoint to dest.
+func downloadAPI(server, path, dest string) error {
+	req, err := newAPIRequest(server, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := apiClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("GET %s: %s %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	f, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
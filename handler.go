@@ -6,13 +6,19 @@ import (
 	"github.com/jekiapp/topic-master/internal/config"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	aclAuth "github.com/jekiapp/topic-master/internal/usecase/acl/auth"
+	aclDelegation "github.com/jekiapp/topic-master/internal/usecase/acl/delegation"
+	aclGrant "github.com/jekiapp/topic-master/internal/usecase/acl/grant"
 	aclGroup "github.com/jekiapp/topic-master/internal/usecase/acl/group"
 	aclUser "github.com/jekiapp/topic-master/internal/usecase/acl/user"
 	aclUserGroup "github.com/jekiapp/topic-master/internal/usecase/acl/usergroup"
+	backupUC "github.com/jekiapp/topic-master/internal/usecase/backup"
 	entityUC "github.com/jekiapp/topic-master/internal/usecase/entity"
+	notificationUC "github.com/jekiapp/topic-master/internal/usecase/notification"
 	"github.com/jekiapp/topic-master/internal/usecase/tickets"
 	"github.com/jekiapp/topic-master/internal/usecase/tickets/action"
 	ticketsform "github.com/jekiapp/topic-master/internal/usecase/tickets/form"
+	ticketspolicy "github.com/jekiapp/topic-master/internal/usecase/tickets/policy"
+	ticketssla "github.com/jekiapp/topic-master/internal/usecase/tickets/sla"
 	submit "github.com/jekiapp/topic-master/internal/usecase/tickets/submit"
 	topicUC "github.com/jekiapp/topic-master/internal/usecase/topic"
 	topicDetailUC "github.com/jekiapp/topic-master/internal/usecase/topic/detail"
@@ -26,11 +32,20 @@ type Handler struct {
 	createUserUC            aclUser.CreateUserUsecase
 	updateUserUC            aclUser.UpdateUserUsecase
 	loginUC                 aclAuth.LoginUsecase
+	oidcLoginUC             aclAuth.OIDCLoginUsecase
 	logoutUC                aclAuth.LogoutUsecase
 	assignUserToGroupUC     aclUserGroup.AssignUserToGroupUsecase
+	applyGroupMembershipUC  aclUserGroup.ApplyGroupMembershipUsecase
+	groupMemberUC           aclUserGroup.GroupMemberUsecase
+	customRoleUC            aclUserGroup.CustomRoleUsecase
 	deleteUserUC            aclUser.DeleteUserUsecase
+	userLifecycleUC         aclUser.UserLifecycleUsecase
+	userLifecycleJanitor    aclUser.UserLifecycleJanitor
+	sessionUC               aclAuth.SessionUsecase
 	createGroupUC           aclGroup.CreateGroupUsecase
 	changePasswordUC        aclUser.ChangePasswordUsecase
+	twoFactorUC             aclUser.TwoFactorUsecase
+	securityUC              aclUser.SecurityUsecase
 	syncTopicsUC            topicUC.SyncTopicsUsecase
 	webUC                   *webUC.WebUsecase
 	getGroupListUC          aclGroup.GetGroupListUsecase
@@ -40,11 +55,15 @@ type Handler struct {
 	updateGroupByIDUC       aclGroup.UpdateGroupByIDUsecase
 	deleteGroupUC           aclGroup.DeleteGroupUsecase
 	resetPasswordUC         aclAuth.ResetPasswordUsecase
+	forgotPasswordUC        aclAuth.ForgotPasswordUsecase
+	invitationUC            aclUser.InvitationUsecase
+	expiredTokenJanitor     aclAuth.ExpiredTokenJanitor
 	signupUC                aclUser.SignupUsecase
 	viewSignupApplicationUC aclAuth.ViewSignupApplicationUsecase
 	listMyAssignmentUC      tickets.ListMyAssignmentUsecase
 	listMyApplicationsUC    tickets.ListMyApplicationsUsecase
 	ticketDetailUC          tickets.TicketDetailUsecase
+	ticketConversationUC    tickets.TicketConversationUsecase
 	actionCoordinatorUC     *action.ActionCoordinator
 	getUsernameUC           aclUser.GetUsernameUsecase
 	getTopicDetailUC        topicDetailUC.NsqTopicDetailUsecase
@@ -57,10 +76,42 @@ type Handler struct {
 	nsqChannelListUC        topicDetailUC.NsqChannelListUsecase
 	nsqChannelOpsUC         topicDetailUC.NsqChannelOpsUsecase
 	deleteChannelUC         topicDetailUC.DeleteChannelUsecase
+	topicDecoderUC          topicDetailUC.TopicDecoderUsecase
+	topicMaskingUC          topicDetailUC.TopicMaskingUsecase
 	claimEntityUC           entityUC.ClaimEntityUsecase
+	transferOwnershipUC     entityUC.TransferOwnershipUsecase
+	defaultPermissionUC     entityUC.DefaultPermissionUsecase
 	checkActionAuthUC       aclAuth.CheckActionAuthUsecase
 	newApplicationUC        ticketsform.NewApplicationUsecase
 	submitApplicationUC     submit.SubmitApplicationUsecase
+	listMyGrantsUC          aclGrant.ListMyGrantsUsecase
+	listEntityGrantsUC      aclGrant.ListEntityGrantsUsecase
+	revokeGrantUC           aclGrant.RevokeGrantUsecase
+	listUserGrantsUC        aclGrant.ListUserGrantsUsecase
+	expiredGrantJanitor     aclGrant.ExpiredGrantJanitor
+	listApprovalPolicyUC    ticketspolicy.ListApprovalPolicyUsecase
+	saveApprovalPolicyUC    ticketspolicy.SaveApprovalPolicyUsecase
+	deleteApprovalPolicyUC  ticketspolicy.DeleteApprovalPolicyUsecase
+	listTicketSLAUC         ticketssla.ListTicketSLAUsecase
+	saveTicketSLAUC         ticketssla.SaveTicketSLAUsecase
+	deleteTicketSLAUC       ticketssla.DeleteTicketSLAUsecase
+	ticketSLAJanitor        ticketssla.TicketSLAJanitor
+	reassignTicketUC        tickets.ReassignTicketUsecase
+	searchTicketsUC         tickets.SearchTicketsUsecase
+
+	getMyDelegationUC aclDelegation.GetMyDelegationUsecase
+	setDelegationUC   aclDelegation.SetDelegationUsecase
+	clearDelegationUC aclDelegation.ClearDelegationUsecase
+
+	listMyNotificationsUC        notificationUC.ListMyNotificationsUsecase
+	markNotificationsReadUC      notificationUC.MarkNotificationsReadUsecase
+	getNotificationPreferenceUC  notificationUC.GetNotificationPreferenceUsecase
+	saveNotificationPreferenceUC notificationUC.SaveNotificationPreferenceUsecase
+
+	snapshotUC    backupUC.SnapshotUsecase
+	backupJanitor backupUC.BackupJanitor
+	exportStateUC backupUC.ExportStateUsecase
+	importStateUC backupUC.ImportStateUsecase
 }
 
 func initHandler(db *buntdb.DB, cfg *config.Config) Handler {
@@ -68,14 +119,23 @@ func initHandler(db *buntdb.DB, cfg *config.Config) Handler {
 
 	return Handler{
 		config:                  cfg,
-		createUserUC:            aclUser.NewCreateUserUsecase(db),
+		createUserUC:            aclUser.NewCreateUserUsecase(db, cfg),
 		updateUserUC:            aclUser.NewUpdateUserUsecase(db),
 		loginUC:                 aclAuth.NewLoginUsecase(db, cfg),
+		oidcLoginUC:             aclAuth.NewOIDCLoginUsecase(db, cfg),
 		logoutUC:                aclAuth.NewLogoutUsecase(),
 		assignUserToGroupUC:     aclUserGroup.NewAssignUserToGroupUsecase(db),
+		applyGroupMembershipUC:  aclUserGroup.NewApplyGroupMembershipUsecase(db),
+		groupMemberUC:           aclUserGroup.NewGroupMemberUsecase(db),
+		customRoleUC:            aclUserGroup.NewCustomRoleUsecase(db),
 		deleteUserUC:            aclUser.NewDeleteUserUsecase(db),
+		userLifecycleUC:         aclUser.NewUserLifecycleUsecase(db),
+		userLifecycleJanitor:    aclUser.NewUserLifecycleJanitor(db, cfg),
+		sessionUC:               aclAuth.NewSessionUsecase(db),
 		createGroupUC:           aclGroup.NewCreateGroupUsecase(db),
 		changePasswordUC:        aclUser.NewChangePasswordUsecase(db),
+		twoFactorUC:             aclUser.NewTwoFactorUsecase(db, cfg),
+		securityUC:              aclUser.NewSecurityUsecase(db, cfg),
 		syncTopicsUC:            topicUC.NewSyncTopicsUsecase(db),
 		webUC:                   webUsecase,
 		getGroupListUC:          aclGroup.NewGetGroupListUsecase(db),
@@ -85,16 +145,20 @@ func initHandler(db *buntdb.DB, cfg *config.Config) Handler {
 		updateGroupByIDUC:       aclGroup.NewUpdateGroupByIDUsecase(db),
 		deleteGroupUC:           aclGroup.NewDeleteGroupUsecase(db),
 		resetPasswordUC:         aclAuth.NewResetPasswordUsecase(db),
+		forgotPasswordUC:        aclAuth.NewForgotPasswordUsecase(db, cfg),
+		invitationUC:            aclUser.NewInvitationUsecase(db, cfg),
+		expiredTokenJanitor:     aclAuth.NewExpiredTokenJanitor(db),
 		signupUC:                aclUser.NewSignupUsecase(db),
 		viewSignupApplicationUC: aclAuth.NewViewSignupApplicationUsecase(db),
 		listMyAssignmentUC:      tickets.NewListMyAssignmentUsecase(db),
 		listMyApplicationsUC:    tickets.NewListMyApplicationsUsecase(db),
 		ticketDetailUC:          tickets.NewTicketDetailUsecase(db),
+		ticketConversationUC:    tickets.NewTicketConversationUsecase(db),
 		actionCoordinatorUC:     action.NewActionCoordinator(db),
 		getUsernameUC:           aclUser.NewGetUsernameUsecase(db),
 		getTopicDetailUC:        topicDetailUC.NewNsqTopicDetailUsecase(cfg, db),
 		getTopicStatsUC:         topicDetailUC.NewNsqTopicStatsUsecase(cfg),
-		tailMessageUC:           topicDetailUC.NewTailMessageUsecase(),
+		tailMessageUC:           topicDetailUC.NewTailMessageUsecase(cfg, db),
 		updateDescriptionUC:     entityUC.NewSaveDescriptionUsecase(db),
 		toggleBookmarkUC:        entityUC.NewToggleBookmarkUsecase(db),
 		deleteTopicUC:           topicDetailUC.NewDeleteTopicUsecase(cfg, db),
@@ -102,24 +166,76 @@ func initHandler(db *buntdb.DB, cfg *config.Config) Handler {
 		nsqChannelListUC:        topicDetailUC.NewNsqChannelListUsecase(db),
 		nsqChannelOpsUC:         topicDetailUC.NewNsqChannelOpsUsecase(cfg, db),
 		deleteChannelUC:         topicDetailUC.NewDeleteChannelUsecase(cfg, db),
+		topicDecoderUC:          topicDetailUC.NewTopicDecoderUsecase(db),
+		topicMaskingUC:          topicDetailUC.NewTopicMaskingUsecase(db),
 		claimEntityUC:           entityUC.NewClaimEntityUsecase(db),
+		transferOwnershipUC:     entityUC.NewTransferOwnershipUsecase(db),
+		defaultPermissionUC:     entityUC.NewDefaultPermissionUsecase(db),
 		checkActionAuthUC:       aclAuth.NewCheckActionAuthUsecase(db),
 		newApplicationUC:        ticketsform.NewNewApplicationUsecase(db),
 		submitApplicationUC:     submit.NewSubmitApplicationUsecase(db),
+		listMyGrantsUC:          aclGrant.NewListMyGrantsUsecase(db),
+		listEntityGrantsUC:      aclGrant.NewListEntityGrantsUsecase(db),
+		revokeGrantUC:           aclGrant.NewRevokeGrantUsecase(db),
+		listUserGrantsUC:        aclGrant.NewListUserGrantsUsecase(db),
+		expiredGrantJanitor:     aclGrant.NewExpiredGrantJanitor(db),
+		listApprovalPolicyUC:    ticketspolicy.NewListApprovalPolicyUsecase(db),
+		saveApprovalPolicyUC:    ticketspolicy.NewSaveApprovalPolicyUsecase(db),
+		deleteApprovalPolicyUC:  ticketspolicy.NewDeleteApprovalPolicyUsecase(db),
+		listTicketSLAUC:         ticketssla.NewListTicketSLAUsecase(db),
+		saveTicketSLAUC:         ticketssla.NewSaveTicketSLAUsecase(db),
+		deleteTicketSLAUC:       ticketssla.NewDeleteTicketSLAUsecase(db),
+		ticketSLAJanitor:        ticketssla.NewTicketSLAJanitor(db),
+		reassignTicketUC:        tickets.NewReassignTicketUsecase(db),
+		searchTicketsUC:         tickets.NewSearchTicketsUsecase(db),
+
+		getMyDelegationUC: aclDelegation.NewGetMyDelegationUsecase(db),
+		setDelegationUC:   aclDelegation.NewSetDelegationUsecase(db),
+		clearDelegationUC: aclDelegation.NewClearDelegationUsecase(db),
+
+		listMyNotificationsUC:        notificationUC.NewListMyNotificationsUsecase(db),
+		markNotificationsReadUC:      notificationUC.NewMarkNotificationsReadUsecase(db),
+		getNotificationPreferenceUC:  notificationUC.NewGetNotificationPreferenceUsecase(db),
+		saveNotificationPreferenceUC: notificationUC.NewSaveNotificationPreferenceUsecase(db),
+
+		snapshotUC:    backupUC.NewSnapshotUsecase(db, cfg),
+		backupJanitor: backupUC.NewBackupJanitor(db, cfg),
+		exportStateUC: backupUC.NewExportStateUsecase(db),
+		importStateUC: backupUC.NewImportStateUsecase(db),
 	}
 }
 
 func (h Handler) routes(mux *http.ServeMux) {
+	// the tokens of deleted and deactivated users are refused by every middleware
 	// this middleware is login required
-	authMiddleware := handlerPkg.InitJWTMiddleware(string(h.config.SecretKey))
+	authMiddleware := handlerPkg.InitJWTMiddleware(string(h.config.SecretKey), h.sessionUC)
 
 	// this middleware is login optional
-	sessionMiddleware := handlerPkg.InitSessionMiddleware(string(h.config.SecretKey))
+	sessionMiddleware := handlerPkg.InitSessionMiddleware(string(h.config.SecretKey), h.sessionUC)
 
 	// this middleware is root access only
-	rootMiddleware := handlerPkg.InitJWTMiddlewareWithRoot(string(h.config.SecretKey))
+	rootMiddleware := handlerPkg.InitJWTMiddlewareWithRoot(string(h.config.SecretKey), h.sessionUC)
 
-	mux.HandleFunc("/api/login", h.loginUC.Handle)
+	// the endpoints open to anyone are throttled per client IP, and per account or reset token where the request
+	// names one, a client going over is blocked with a growing backoff
+	byIP := handlerPkg.RateLimitByIP(h.config.TrustForwardedFor)
+	authLimiter := handlerPkg.NewRateLimiter(h.config.AuthRateLimit)
+	authLimiter.OnBlock = h.securityUC.AuditRateLimited
+	loginLimit := handlerPkg.InitRateLimitMiddleware(authLimiter, "login", byIP, handlerPkg.RateLimitByJSONField("username"))
+	signupLimit := handlerPkg.InitRateLimitMiddleware(authLimiter, "signup", byIP, handlerPkg.RateLimitByJSONField("username"))
+	resetPasswordLimit := handlerPkg.InitRateLimitMiddleware(authLimiter, "reset-password", byIP, handlerPkg.RateLimitByJSONField("token"))
+	changePasswordLimit := handlerPkg.InitRateLimitMiddleware(authLimiter, "change-password", byIP, handlerPkg.RateLimitByUser())
+	forgotLimit := handlerPkg.InitRateLimitMiddleware(authLimiter, "forgot", byIP, handlerPkg.RateLimitByJSONField("username"))
+	oidcLimit := handlerPkg.InitRateLimitMiddleware(authLimiter, "oidc", byIP)
+	publicLimiter := handlerPkg.NewRateLimiter(h.config.PublicRateLimit)
+	publicLimiter.OnBlock = h.securityUC.AuditRateLimited
+	publicLimit := handlerPkg.InitRateLimitMiddleware(publicLimiter, "public", byIP)
+
+	mux.HandleFunc("/api/login", loginLimit(h.loginUC.Handle))
+	mux.HandleFunc("/api/login/2fa", loginLimit(h.loginUC.HandleTwoFactor))
+	mux.HandleFunc("/api/auth/providers", handlerPkg.HandleGenericGet(h.oidcLoginUC.HandleProviders))
+	mux.HandleFunc("/api/auth/oidc/start", oidcLimit(h.oidcLoginUC.HandleStart))
+	mux.HandleFunc("/api/auth/oidc/callback", oidcLimit(h.oidcLoginUC.HandleCallback))
 	mux.HandleFunc("/logout", h.logoutUC.Handle)
 
 	mux.HandleFunc("/api/user/list", rootMiddleware(handlerPkg.HandleGenericPost(h.getUserListUC.Handle)))
@@ -127,13 +243,31 @@ func (h Handler) routes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/user/update", rootMiddleware(handlerPkg.HandleGenericPost(h.updateUserUC.Handle)))
 	mux.HandleFunc("/api/user/assign-to-group", rootMiddleware(handlerPkg.HandleGenericPost(h.assignUserToGroupUC.Handle)))
 	mux.HandleFunc("/api/user/delete", rootMiddleware(handlerPkg.HandleGenericPost(h.deleteUserUC.Handle)))
-	mux.HandleFunc("/api/user/change-password", handlerPkg.HandleGenericPost(h.changePasswordUC.Handle))
-	mux.HandleFunc("/api/user/reset-password", handlerPkg.HandleGetPost(
+	mux.HandleFunc("/api/user/deactivate", rootMiddleware(handlerPkg.HandleGenericPost(h.userLifecycleUC.HandleDeactivate)))
+	mux.HandleFunc("/api/user/reactivate", rootMiddleware(handlerPkg.HandleGenericPost(h.userLifecycleUC.HandleReactivate)))
+	mux.HandleFunc("/api/user/resend-invitation", rootMiddleware(handlerPkg.HandleGenericPost(h.invitationUC.HandleResend)))
+	mux.HandleFunc("/api/user/change-password", authMiddleware(changePasswordLimit(handlerPkg.HandleGenericPost(h.changePasswordUC.Handle))))
+	mux.HandleFunc("/api/user/reset-password", resetPasswordLimit(handlerPkg.HandleGetPost(
 		h.resetPasswordUC.HandleGet,
 		h.resetPasswordUC.HandlePost,
-	))
+	)))
+	mux.HandleFunc("/api/user/2fa/status", authMiddleware(handlerPkg.HandleGenericGet(h.twoFactorUC.HandleStatus)))
+	mux.HandleFunc("/api/user/2fa/setup", authMiddleware(handlerPkg.HandleGenericPost(h.twoFactorUC.HandleSetup)))
+	mux.HandleFunc("/api/user/2fa/enable", authMiddleware(handlerPkg.HandleGenericPost(h.twoFactorUC.HandleEnable)))
+	mux.HandleFunc("/api/user/2fa/disable", authMiddleware(handlerPkg.HandleGenericPost(h.twoFactorUC.HandleDisable)))
+	mux.HandleFunc("/api/user/2fa/recovery-codes", authMiddleware(handlerPkg.HandleGenericPost(h.twoFactorUC.HandleRegenerateRecovery)))
+	mux.HandleFunc("/api/user/2fa/reset", rootMiddleware(handlerPkg.HandleGenericPost(h.twoFactorUC.HandleReset)))
+	mux.HandleFunc("/api/security/events", rootMiddleware(handlerPkg.HandleGenericGet(h.securityUC.HandleListEvents)))
+	mux.HandleFunc("/api/security/lockouts", rootMiddleware(handlerPkg.HandleGenericGet(h.securityUC.HandleListLockouts)))
+	mux.HandleFunc("/api/security/unlock", rootMiddleware(handlerPkg.HandleGenericPost(h.securityUC.HandleUnlock)))
+
+	mux.HandleFunc("/api/backup/create", rootMiddleware(handlerPkg.HandleGenericPost(h.snapshotUC.HandleCreate)))
+	mux.HandleFunc("/api/backup/list", rootMiddleware(handlerPkg.HandleGenericGet(h.snapshotUC.HandleList)))
+	mux.HandleFunc("/api/backup/download", rootMiddleware(h.snapshotUC.HandleDownload))
+	mux.HandleFunc("/api/backup/export", rootMiddleware(handlerPkg.HandleGenericGet(h.exportStateUC.HandleExport)))
+	mux.HandleFunc("/api/backup/import", rootMiddleware(handlerPkg.HandleGenericPost(h.importStateUC.HandleImport)))
 
-	mux.HandleFunc("/api/change-password", handlerPkg.HandleGenericPost(h.changePasswordUC.Handle))
+	mux.HandleFunc("/api/change-password", authMiddleware(changePasswordLimit(handlerPkg.HandleGenericPost(h.changePasswordUC.Handle))))
 	mux.HandleFunc("/api/sync-topics", handlerPkg.HandleGenericGet(h.syncTopicsUC.HandleQuery))
 
 	mux.HandleFunc("/api/group/create", rootMiddleware(handlerPkg.HandleGenericPost(h.createGroupUC.Handle)))
@@ -141,24 +275,62 @@ func (h Handler) routes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/group/list-simple", handlerPkg.HandleGenericPost(h.getGroupListSimpleUC.Handle))
 	mux.HandleFunc("/api/group/update-group-by-id", rootMiddleware(handlerPkg.HandleGenericPost(h.updateGroupByIDUC.Handle)))
 	mux.HandleFunc("/api/group/delete-group", rootMiddleware(handlerPkg.HandleGenericPost(h.deleteGroupUC.Handle)))
+	mux.HandleFunc("/api/group/apply-membership", authMiddleware(handlerPkg.HandleGenericPost(h.applyGroupMembershipUC.Handle)))
+	mux.HandleFunc("/api/group/members", authMiddleware(handlerPkg.HandleGenericGet(h.groupMemberUC.HandleList)))
+	mux.HandleFunc("/api/group/remove-member", authMiddleware(handlerPkg.HandleGenericPost(h.groupMemberUC.HandleRemove)))
+	mux.HandleFunc("/api/group/set-member-role", authMiddleware(handlerPkg.HandleGenericPost(h.groupMemberUC.HandleSetRole)))
+	mux.HandleFunc("/api/group/roles", authMiddleware(handlerPkg.HandleGenericGet(h.customRoleUC.HandleList)))
+	mux.HandleFunc("/api/group/save-role", authMiddleware(handlerPkg.HandleGenericPost(h.customRoleUC.HandleSave)))
+	mux.HandleFunc("/api/group/delete-role", authMiddleware(handlerPkg.HandleGenericPost(h.customRoleUC.HandleDelete)))
 
 	mux.HandleFunc("/api/topic/list-all-topics", sessionMiddleware(handlerPkg.HandleGenericGet(h.listAllTopicsUC.HandleQuery)))
 
-	mux.HandleFunc("/api/reset-password", handlerPkg.HandleGetPost(
+	mux.HandleFunc("/api/reset-password", resetPasswordLimit(handlerPkg.HandleGetPost(
 		h.resetPasswordUC.HandleGet,
 		h.resetPasswordUC.HandlePost,
-	))
+	)))
+
+	mux.HandleFunc("/api/forgot-password", forgotLimit(handlerPkg.HandleGenericPost(h.forgotPasswordUC.Handle)))
 
-	mux.HandleFunc("/api/signup", handlerPkg.HandleGenericPost(h.signupUC.Handle))
+	mux.HandleFunc("/api/signup", signupLimit(handlerPkg.HandleGenericPost(h.signupUC.Handle)))
 	mux.HandleFunc("/api/signup/app", handlerPkg.HandleGenericGet(h.viewSignupApplicationUC.Handle))
 
 	mux.HandleFunc("/api/tickets/list-my-assignment", authMiddleware(handlerPkg.HandleGenericGet(h.listMyAssignmentUC.Handle)))
 	mux.HandleFunc("/api/tickets/list-my-applications", authMiddleware(handlerPkg.HandleGenericGet(h.listMyApplicationsUC.Handle)))
 	mux.HandleFunc("/api/tickets/detail", authMiddleware(handlerPkg.HandleGenericGet(h.ticketDetailUC.Handle)))
+	mux.HandleFunc("/api/tickets/search", authMiddleware(handlerPkg.HandleGenericGet(h.searchTicketsUC.Handle)))
 	mux.HandleFunc("/api/tickets/action", authMiddleware(handlerPkg.HandleGenericPost(h.actionCoordinatorUC.Handle)))
+	mux.HandleFunc("/api/tickets/bulk-action", authMiddleware(handlerPkg.HandleGenericPost(h.actionCoordinatorUC.HandleBulk)))
+	mux.HandleFunc("/api/tickets/comment", authMiddleware(handlerPkg.HandleGenericPost(h.ticketConversationUC.HandleComment)))
+	mux.HandleFunc("/api/tickets/request-info", authMiddleware(handlerPkg.HandleGenericPost(h.ticketConversationUC.HandleRequestInfo)))
+	mux.HandleFunc("/api/tickets/provide-info", authMiddleware(handlerPkg.HandleGenericPost(h.ticketConversationUC.HandleProvideInfo)))
+	mux.HandleFunc("/api/tickets/withdraw", authMiddleware(handlerPkg.HandleGenericPost(h.ticketConversationUC.HandleWithdraw)))
 	mux.HandleFunc("/api/tickets/new-application-form", authMiddleware(handlerPkg.HandleGenericGet(h.newApplicationUC.Handle)))
 	mux.HandleFunc("/api/tickets/submit-application", authMiddleware(handlerPkg.HandleGenericPost(h.submitApplicationUC.Handle)))
 
+	mux.HandleFunc("/api/tickets/approval-policy/list", rootMiddleware(handlerPkg.HandleGenericGet(h.listApprovalPolicyUC.Handle)))
+	mux.HandleFunc("/api/tickets/approval-policy/save", rootMiddleware(handlerPkg.HandleGenericPost(h.saveApprovalPolicyUC.Handle)))
+	mux.HandleFunc("/api/tickets/approval-policy/delete", rootMiddleware(handlerPkg.HandleGenericPost(h.deleteApprovalPolicyUC.Handle)))
+
+	mux.HandleFunc("/api/tickets/sla/list", rootMiddleware(handlerPkg.HandleGenericGet(h.listTicketSLAUC.Handle)))
+	mux.HandleFunc("/api/tickets/sla/save", rootMiddleware(handlerPkg.HandleGenericPost(h.saveTicketSLAUC.Handle)))
+	mux.HandleFunc("/api/tickets/sla/delete", rootMiddleware(handlerPkg.HandleGenericPost(h.deleteTicketSLAUC.Handle)))
+	mux.HandleFunc("/api/tickets/reassign", rootMiddleware(handlerPkg.HandleGenericPost(h.reassignTicketUC.Handle)))
+
+	mux.HandleFunc("/api/delegation/my", authMiddleware(handlerPkg.HandleGenericGet(h.getMyDelegationUC.Handle)))
+	mux.HandleFunc("/api/delegation/set", authMiddleware(handlerPkg.HandleGenericPost(h.setDelegationUC.Handle)))
+	mux.HandleFunc("/api/delegation/clear", authMiddleware(handlerPkg.HandleGenericPost(h.clearDelegationUC.Handle)))
+
+	mux.HandleFunc("/api/notifications/list", authMiddleware(handlerPkg.HandleGenericGet(h.listMyNotificationsUC.Handle)))
+	mux.HandleFunc("/api/notifications/read", authMiddleware(handlerPkg.HandleGenericPost(h.markNotificationsReadUC.Handle)))
+	mux.HandleFunc("/api/notifications/preference", authMiddleware(handlerPkg.HandleGenericGet(h.getNotificationPreferenceUC.Handle)))
+	mux.HandleFunc("/api/notifications/preference/save", authMiddleware(handlerPkg.HandleGenericPost(h.saveNotificationPreferenceUC.Handle)))
+
+	mux.HandleFunc("/api/grants/my", authMiddleware(handlerPkg.HandleGenericGet(h.listMyGrantsUC.Handle)))
+	mux.HandleFunc("/api/grants/entity", authMiddleware(handlerPkg.HandleGenericGet(h.listEntityGrantsUC.Handle)))
+	mux.HandleFunc("/api/grants/revoke", authMiddleware(handlerPkg.HandleGenericPost(h.revokeGrantUC.Handle)))
+	mux.HandleFunc("/api/grants/user", authMiddleware(handlerPkg.HandleGenericGet(h.listUserGrantsUC.Handle)))
+
 	mux.HandleFunc("/api/user/get-username", authMiddleware(handlerPkg.HandleGenericGet(h.getUsernameUC.Handle)))
 
 	mux.HandleFunc("/api/topic/detail", sessionMiddleware(handlerPkg.HandleGenericGet(h.getTopicDetailUC.HandleQuery)))
@@ -173,14 +345,38 @@ func (h Handler) routes(mux *http.ServeMux) {
 		acl.Permission_Entity_Desc_Update.Name,
 	)))
 
-	mux.HandleFunc("/api/topic/publish", sessionMiddleware(actionAuthMiddleware(
+	mux.HandleFunc("/api/topic/publish", publicLimit(sessionMiddleware(actionAuthMiddleware(
 		handlerPkg.HandleGenericPost(h.getTopicDetailUC.HandlePublish),
 		acl.Permission_Topic_Publish.Name,
-	)))
-	mux.HandleFunc("/api/topic/tail", sessionMiddleware(actionAuthMiddleware(
+	))))
+	mux.HandleFunc("/api/topic/tail", publicLimit(sessionMiddleware(actionAuthMiddleware(
 		h.tailMessageUC.HandleTailMessage,
 		acl.Permission_Topic_Tail.Name,
+	))))
+	mux.HandleFunc("/api/topic/tail/sse", publicLimit(sessionMiddleware(actionAuthMiddleware(
+		h.tailMessageUC.HandleTailSSE,
+		acl.Permission_Topic_Tail.Name,
+	))))
+	mux.HandleFunc("/api/topic/tail/stream", publicLimit(sessionMiddleware(actionAuthMiddleware(
+		h.tailMessageUC.HandleTailStream,
+		acl.Permission_Topic_Tail.Name,
+	))))
+	// topic:tail is checked per resolved topic inside the usecase
+	mux.HandleFunc("/api/topic/tail/multi", publicLimit(sessionMiddleware(h.tailMessageUC.HandleTailMulti)))
+	mux.HandleFunc("/api/topic/decoder", sessionMiddleware(handlerPkg.HandleGenericGet(h.topicDecoderUC.HandleGet)))
+	mux.HandleFunc("/api/topic/decoder/save", sessionMiddleware(actionAuthMiddleware(
+		handlerPkg.HandleGenericPost(h.topicDecoderUC.HandleSave),
+		acl.Permission_Topic_Decoder_Update.Name,
 	)))
+	mux.HandleFunc("/api/topic/masking", sessionMiddleware(handlerPkg.HandleGenericGet(h.topicMaskingUC.HandleGet)))
+	mux.HandleFunc("/api/topic/masking/save", sessionMiddleware(actionAuthMiddleware(
+		handlerPkg.HandleGenericPost(h.topicMaskingUC.HandleSave),
+		acl.Permission_Topic_Masking_Update.Name,
+	)))
+	mux.HandleFunc("/api/topic/publish/preview", publicLimit(sessionMiddleware(actionAuthMiddleware(
+		handlerPkg.HandleGenericPost(h.topicDecoderUC.HandlePreview),
+		acl.Permission_Topic_Publish.Name,
+	))))
 	mux.HandleFunc("/api/topic/delete", sessionMiddleware(actionAuthMiddleware(
 		handlerPkg.HandleGenericGet(h.deleteTopicUC.Handle),
 		acl.Permission_Topic_Delete.Name,
@@ -217,6 +413,9 @@ func (h Handler) routes(mux *http.ServeMux) {
 	)))
 
 	mux.HandleFunc("/api/entity/claim", authMiddleware(handlerPkg.HandleGenericPost(h.claimEntityUC.Handle)))
+	mux.HandleFunc("/api/entity/transfer-ownership", authMiddleware(handlerPkg.HandleGenericPost(h.transferOwnershipUC.Handle)))
+	mux.HandleFunc("/api/entity/default-permissions", authMiddleware(handlerPkg.HandleGenericGet(h.defaultPermissionUC.HandleGet)))
+	mux.HandleFunc("/api/entity/save-default-permissions", authMiddleware(handlerPkg.HandleGenericPost(h.defaultPermissionUC.HandleSave)))
 
 	mux.HandleFunc("/api/auth/check-action", authMiddleware(handlerPkg.HandleGenericPost(h.checkActionAuthUC.Handle)))
 
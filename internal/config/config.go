@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"time"
 
 	"github.com/tidwall/buntdb"
 	"github.com/vmihailenco/msgpack/v5"
@@ -21,6 +22,82 @@ type Config struct {
 	NSQLookupdHTTPAddr string
 	NSQDAddr           string
 	SecretKey          []byte
+
+	// runtime options, provided by flags on every start and never persisted
+	TailAllowedOrigins []string      `msgpack:"-"`
+	SMTP               SMTPConfig    `msgpack:"-"`
+	MailDir            string        `msgpack:"-"` // emails are written there instead of sent when no SMTP server is set, for development
+	PublicURL          string        `msgpack:"-"` // base URL of the web UI, used for links in notifications
+	InviteTTL          time.Duration `msgpack:"-"` // validity of the invitation link sent to a new user
+	ResetTokenTTL      time.Duration `msgpack:"-"` // validity of a password reset link
+	LDAP               LDAPConfig    `msgpack:"-"`
+	OIDC               OIDCConfig    `msgpack:"-"`
+	Require2FA         string        `msgpack:"-"` // Require2FARoot or Require2FAAdmins, 2FA stays optional when empty
+	// AuthRateLimit throttles login, signup and password reset, PublicRateLimit the publish and tail endpoints
+	AuthRateLimit     RateLimitConfig `msgpack:"-"`
+	PublicRateLimit   RateLimitConfig `msgpack:"-"`
+	Lockout           LockoutConfig   `msgpack:"-"`
+	TrustForwardedFor bool            `msgpack:"-"` // take the client IP from X-Forwarded-For, only behind a trusted proxy
+	InactivityDays    int             `msgpack:"-"` // users without login or activity for that many days are deactivated, disabled when 0
+	BackupDir         string          `msgpack:"-"` // snapshots are saved there, and the data before it is migrated
+	BackupInterval    time.Duration   `msgpack:"-"` // a snapshot is taken every interval, disabled when 0
+	BackupRetention   int             `msgpack:"-"` // scheduled and manual snapshots kept, all are kept when 0
+}
+
+// RateLimitConfig allows Requests per Window for each client IP or username. A key going over
+// is blocked for one Window, doubled on every repeat up to MaxBackoff. Disabled when Requests is 0.
+type RateLimitConfig struct {
+	Requests   int
+	Window     time.Duration
+	MaxBackoff time.Duration
+}
+
+// LockoutConfig locks an account for Duration after Threshold failed logins in a row, disabled when Threshold is 0.
+type LockoutConfig struct {
+	Threshold int
+	Duration  time.Duration
+}
+
+const (
+	Require2FARoot   = "root"   // members of the root group
+	Require2FAAdmins = "admins" // root members and the admins of any group
+)
+
+// SMTPConfig is the mail server used for email notifications, disabled when Addr is empty.
+type SMTPConfig struct {
+	Addr     string // host:port
+	Username string // optional, PLAIN auth is used when set
+	Password string
+	From     string
+}
+
+// LDAPConfig is the directory used to log in, disabled when URL is empty.
+type LDAPConfig struct {
+	URL          string // ldap://host:389 or ldaps://host:636
+	StartTLS     bool   // upgrade a ldap:// connection before binding
+	BindDN       string // service account searching the users, anonymous search when empty
+	BindPassword string
+	BaseDN       string
+	UserFilter   string // %s is replaced by the escaped username, e.g. (uid=%s)
+	NameAttr     string // attribute holding the display name, cn when empty
+	GroupAttr    string // attribute listing the groups of a user, memberOf when empty
+	// GroupMapping maps a directory group DN to a topic-master group name,
+	// memberships of the mapped groups follow the directory on every login
+	GroupMapping map[string]string
+}
+
+// OIDCConfig is the OpenID Connect provider used for single sign-on, disabled when IssuerURL is empty.
+type OIDCConfig struct {
+	IssuerURL     string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string   // callback registered at the provider, <PublicURL>/api/auth/oidc/callback
+	Scopes        []string // openid is always requested
+	UsernameClaim string   // preferred_username when empty
+	GroupsClaim   string   // groups when empty, groups are not synced when the claim is missing
+	// GroupMapping maps a group of the claim to a topic-master group name,
+	// memberships of the mapped groups follow the provider on every login
+	GroupMapping map[string]string
 }
 
 func NewConfig(db *buntdb.DB) (*Config, error) {
@@ -7,8 +7,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/logic/notification"
 	"github.com/jekiapp/topic-master/internal/model/acl"
+	notifmodel "github.com/jekiapp/topic-master/internal/model/notification"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
 	util "github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
 )
 
 type CreateApplicationInput struct {
@@ -18,6 +23,7 @@ type CreateApplicationInput struct {
 	Reason             string
 	ReviewerGroupID    string
 	MetaData           map[string]string
+	Stages             []acl.ApplicationStage // from ResolveApprovalStages, empty means one approval from ReviewerGroupID
 	HistoryInitAction  string
 	HistoryInitComment string
 }
@@ -27,12 +33,41 @@ type CreateApplicationOutput struct {
 }
 
 type iCreateApplicationRepo interface {
-	CreateApplication(app acl.Application) error
 	GetReviewerIDsByGroupID(groupID string) ([]string, error)
+	WithApplicationTx(fn func(w IApplicationWriter) error) error
+}
+
+// IApplicationWriter writes the records of a new application
+type IApplicationWriter interface {
+	CreateApplication(app acl.Application) error
 	CreateApplicationAssignment(assignment acl.ApplicationAssignment) error
 	CreateApplicationHistory(history acl.ApplicationHistory) error
 }
 
+// WithApplicationTx runs fn with a writer over one transaction of db, so an application is
+// stored along with its assignments and history, or not at all when fn fails.
+func WithApplicationTx(db *buntdb.DB, fn func(w IApplicationWriter) error) error {
+	return dbpkg.WithTx(db, func(tx *buntdb.Tx) error {
+		return fn(applicationTxWriter{tx: tx})
+	})
+}
+
+type applicationTxWriter struct {
+	tx *buntdb.Tx
+}
+
+func (w applicationTxWriter) CreateApplication(app acl.Application) error {
+	return apprepo.CreateApplicationTx(w.tx, app)
+}
+
+func (w applicationTxWriter) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return apprepo.CreateApplicationAssignmentTx(w.tx, assignment)
+}
+
+func (w applicationTxWriter) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return apprepo.CreateApplicationHistoryTx(w.tx, history)
+}
+
 func CreateApplication(ctx context.Context, req CreateApplicationInput, repo iCreateApplicationRepo) (CreateApplicationOutput, error) {
 	user := util.GetUserInfo(ctx)
 	if user == nil {
@@ -48,46 +83,61 @@ func CreateApplication(ctx context.Context, req CreateApplicationInput, repo iCr
 		Reason:        req.Reason,
 		Status:        acl.StatusWaitingForApproval,
 		MetaData:      req.MetaData,
+		Stages:        req.Stages,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
-	if err := repo.CreateApplication(app); err != nil {
-		return CreateApplicationOutput{}, err
+	// only the first stage is assigned now, the next ones when the previous stage is approved.
+	// ResolveApprovalStages already checked every stage has enough reviewers.
+	reviewerGroupID := req.ReviewerGroupID
+	if len(req.Stages) > 0 {
+		reviewerGroupID = req.Stages[0].ReviewerGroupID
 	}
-
-	adminUserIDs, err := repo.GetReviewerIDsByGroupID(req.ReviewerGroupID)
+	adminUserIDs, err := repo.GetReviewerIDsByGroupID(reviewerGroupID)
 	if err != nil {
 		return CreateApplicationOutput{}, errors.New("failed to get admin user ids: " + err.Error())
 	}
 
-	for _, userID := range adminUserIDs {
-		assignment := acl.ApplicationAssignment{
+	// an application without its assignments would wait for nobody, so they are written together
+	err = repo.WithApplicationTx(func(w IApplicationWriter) error {
+		if err := w.CreateApplication(app); err != nil {
+			return err
+		}
+		for _, userID := range adminUserIDs {
+			assignment := acl.ApplicationAssignment{
+				ID:            uuid.NewString(),
+				ApplicationID: app.ID,
+				ReviewerID:    userID,
+				ReviewStatus:  acl.ActionWaitingForApproval,
+				CreatedAt:     time.Now(),
+				UpdatedAt:     time.Now(),
+			}
+			if err := w.CreateApplicationAssignment(assignment); err != nil {
+				return err
+			}
+		}
+		// Insert application history
+		history := acl.ApplicationHistory{
 			ID:            uuid.NewString(),
 			ApplicationID: app.ID,
-			ReviewerID:    userID,
-			ReviewStatus:  acl.ActionWaitingForApproval,
+			Action:        req.HistoryInitAction,
+			ActorID:       user.ID,
+			Comment:       req.HistoryInitComment,
 			CreatedAt:     time.Now(),
 			UpdatedAt:     time.Now(),
 		}
-		if err := repo.CreateApplicationAssignment(assignment); err != nil {
-			return CreateApplicationOutput{}, err
+		if err := w.CreateApplicationHistory(history); err != nil {
+			log.Println("failed to create application history", err)
 		}
-	}
-	// Insert application history
-	history := acl.ApplicationHistory{
-		ID:            uuid.NewString(),
-		ApplicationID: app.ID,
-		Action:        req.HistoryInitAction,
-		ActorID:       user.ID,
-		Comment:       req.HistoryInitComment,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
-	}
-	err = repo.CreateApplicationHistory(history)
+		return nil
+	})
 	if err != nil {
-		log.Println("failed to create application history", err)
+		return CreateApplicationOutput{}, err
 	}
 
+	notification.Publish(notification.TicketEvent(notifmodel.EventTicketCreated, app, app.UserID))
+	notification.Publish(notification.TicketEvent(notifmodel.EventTicketAssigned, app, adminUserIDs...))
+
 	return CreateApplicationOutput{ApplicationID: app.ID}, nil
 }
 
@@ -108,21 +158,45 @@ func ApproveApplication(
 	assignments []acl.ApplicationAssignment,
 	comment string,
 ) error {
+	event, err := ApproveApplicationTx(ctx, repo, appID, assignments, comment)
+	if err != nil {
+		return err
+	}
+	notification.Publish(event)
+	return nil
+}
+
+// ApproveApplicationTx is ApproveApplication for a repo working inside a transaction:
+// the applicant must not hear of an approval that is rolled back, so the event is
+// returned for the caller to publish once the transaction committed.
+func ApproveApplicationTx(
+	ctx context.Context,
+	repo IApplicationAction,
+	appID string,
+	assignments []acl.ApplicationAssignment,
+	comment string,
+) (notification.Event, error) {
 	user := util.GetUserInfo(ctx)
 	if user == nil {
-		return errors.New("unauthorized")
+		return notification.Event{}, errors.New("unauthorized")
 	}
 	app, err := repo.GetApplicationByID(appID)
 	if err != nil {
-		return err
+		return notification.Event{}, err
 	}
 	app.Status = acl.StatusCompleted
 	app.UpdatedAt = time.Now()
 	if err := repo.UpdateApplication(app); err != nil {
-		return err
+		return notification.Event{}, err
 	}
+	onBehalfOf := ""
 	for _, assignment := range assignments {
+		// reviews of earlier approval stages are kept as they are
+		if assignment.ReviewStatus != acl.ReviewStatusWaiting {
+			continue
+		}
 		if assignment.ReviewerID == user.ID {
+			onBehalfOf = assignment.OnBehalfOf
 			assignment.ReviewStatus = acl.ReviewStatusApproved
 			assignment.ReviewedAt = time.Now()
 		} else {
@@ -136,6 +210,7 @@ func ApproveApplication(
 		ApplicationID: appID,
 		Action:        acl.ActionApprove,
 		ActorID:       user.ID,
+		OnBehalfOf:    onBehalfOf,
 		Comment:       comment,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
@@ -144,7 +219,7 @@ func ApproveApplication(
 		// log but do not fail
 		log.Println("failed to create application history", err)
 	}
-	return nil
+	return notification.TicketEvent(notifmodel.EventTicketApproved, app, app.UserID), nil
 }
 
 // RejectApplication marks the application as completed, updates assignments as rejected, and adds history
@@ -155,21 +230,44 @@ func RejectApplication(
 	assignments []acl.ApplicationAssignment,
 	comment string,
 ) error {
+	event, err := RejectApplicationTx(ctx, repo, appID, assignments, comment)
+	if err != nil {
+		return err
+	}
+	notification.Publish(event)
+	return nil
+}
+
+// RejectApplicationTx is RejectApplication for a repo working inside a transaction,
+// the returned event is published by the caller once the transaction committed.
+func RejectApplicationTx(
+	ctx context.Context,
+	repo IApplicationAction,
+	appID string,
+	assignments []acl.ApplicationAssignment,
+	comment string,
+) (notification.Event, error) {
 	user := util.GetUserInfo(ctx)
 	if user == nil {
-		return errors.New("unauthorized")
+		return notification.Event{}, errors.New("unauthorized")
 	}
 	app, err := repo.GetApplicationByID(appID)
 	if err != nil {
-		return err
+		return notification.Event{}, err
 	}
 	app.Status = acl.StatusCompleted
 	app.UpdatedAt = time.Now()
 	if err := repo.UpdateApplication(app); err != nil {
-		return err
+		return notification.Event{}, err
 	}
+	onBehalfOf := ""
 	for _, assignment := range assignments {
+		// reviews of earlier approval stages are kept as they are
+		if assignment.ReviewStatus != acl.ReviewStatusWaiting {
+			continue
+		}
 		if assignment.ReviewerID == user.ID {
+			onBehalfOf = assignment.OnBehalfOf
 			assignment.ReviewStatus = acl.ReviewStatusRejected
 			assignment.ReviewedAt = time.Now()
 		} else {
@@ -183,6 +281,7 @@ func RejectApplication(
 		ApplicationID: appID,
 		Action:        acl.ActionReject,
 		ActorID:       user.ID,
+		OnBehalfOf:    onBehalfOf,
 		Comment:       comment,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
@@ -191,7 +290,7 @@ func RejectApplication(
 		// log but do not fail
 		log.Println("failed to create application history", err)
 	}
-	return nil
+	return notification.TicketEvent(notifmodel.EventTicketRejected, app, app.UserID), nil
 }
 
 /*
@@ -0,0 +1,296 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/logic/notification"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	notifmodel "github.com/jekiapp/topic-master/internal/model/notification"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	util "github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// IApprovalProgress abstracts the repo methods needed to move an application through its stages.
+type IApprovalProgress interface {
+	GetApplicationByID(id string) (acl.Application, error)
+	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
+	UpdateApplication(app acl.Application) error
+	UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	CreateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	CreateApplicationHistory(history acl.ApplicationHistory) error
+}
+
+// IApprovalStore runs the progress of an approval in one transaction, see WithApprovalTx.
+type IApprovalStore interface {
+	GetReviewerIDsByGroupID(groupID string) ([]string, error)
+	WithApprovalTx(fn func(repo IApprovalProgress) error) error
+}
+
+// WithApprovalTx runs fn with a repo over one transaction of db, so concurrent approvals of the
+// same stage are counted one after the other instead of from the same snapshot.
+func WithApprovalTx(db *buntdb.DB, fn func(repo IApprovalProgress) error) error {
+	return dbpkg.WithTx(db, func(tx *buntdb.Tx) error {
+		return fn(approvalTxRepo{tx: tx})
+	})
+}
+
+type approvalTxRepo struct {
+	tx *buntdb.Tx
+}
+
+func (r approvalTxRepo) GetApplicationByID(id string) (acl.Application, error) {
+	return dbpkg.GetByIDTx[acl.Application](r.tx, id)
+}
+
+func (r approvalTxRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	return dbpkg.SelectAllTx[acl.ApplicationAssignment](r.tx, "="+appID, acl.IdxAppAssign_ApplicationID)
+}
+
+func (r approvalTxRepo) UpdateApplication(app acl.Application) error {
+	return dbpkg.UpdateTx(r.tx, &app)
+}
+
+func (r approvalTxRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return dbpkg.UpdateTx(r.tx, &assignment)
+}
+
+func (r approvalTxRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return apprepo.CreateApplicationAssignmentTx(r.tx, assignment)
+}
+
+func (r approvalTxRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return apprepo.CreateApplicationHistoryTx(r.tx, history)
+}
+
+// ActiveAssignment returns the assignment of the user waiting for review in the current stage.
+func ActiveAssignment(app acl.Application, assignments []acl.ApplicationAssignment, userID string) (acl.ApplicationAssignment, bool) {
+	for _, assignment := range assignments {
+		if assignment.ReviewerID == userID && assignment.Stage == app.CurrentStage &&
+			assignment.ReviewStatus == acl.ReviewStatusWaiting {
+			return assignment, true
+		}
+	}
+	return acl.ApplicationAssignment{}, false
+}
+
+// CountStageApprovals returns the approvals collected by a stage.
+func CountStageApprovals(assignments []acl.ApplicationAssignment, stage int) int {
+	count := 0
+	for _, assignment := range assignments {
+		if assignment.Stage == stage && assignment.ReviewStatus == acl.ReviewStatusApproved {
+			count++
+		}
+	}
+	return count
+}
+
+// RecordApproval counts the approval of the current user towards the current stage of app.
+// It returns true when this approval completes the last stage: the caller then grants the application
+// through ApproveApplication. Otherwise the approval is stored, the next stage is assigned once the current
+// one reaches its quorum, and message describes the progress. The optional comment of the reviewer is kept in the history.
+// The application and its assignments are read again in the transaction of the update,
+// so two reviewers approving at once both count towards the quorum.
+func RecordApproval(
+	ctx context.Context,
+	store IApprovalStore,
+	app acl.Application,
+	comment string,
+) (done bool, message string, err error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return false, "", errors.New("unauthorized")
+	}
+
+	// reviewers are resolved from the groups outside the transaction, the stage is checked again inside it
+	stageAtRead := app.CurrentStage
+	var candidates []string
+	if app.CurrentStage+1 < app.StageCount() {
+		nextStage := app.StageAt(app.CurrentStage + 1)
+		candidates, err = store.GetReviewerIDsByGroupID(nextStage.ReviewerGroupID)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to get reviewers of %s: %v", stageLabel(app, app.CurrentStage+1), err)
+		}
+	}
+
+	var nextReviewers []string
+	err = store.WithApprovalTx(func(repo IApprovalProgress) error {
+		app, err = repo.GetApplicationByID(app.ID)
+		if err != nil {
+			return err
+		}
+		if app.IsClosed() {
+			return errors.New("application is already " + app.Status)
+		}
+		if app.CurrentStage != stageAtRead {
+			return errors.New("the application moved to another stage, reload it and try again")
+		}
+		assignments, err := repo.ListAssignmentsByApplicationID(app.ID)
+		if err != nil {
+			return err
+		}
+		assignment, ok := ActiveAssignment(app, assignments, user.ID)
+		if !ok {
+			return errors.New("you are not eligible to perform this action")
+		}
+
+		stage := app.StageAt(app.CurrentStage)
+		approvals := CountStageApprovals(assignments, app.CurrentStage) + 1
+		lastStage := app.CurrentStage+1 >= app.StageCount()
+		if approvals >= stage.RequiredApprovals && lastStage {
+			done = true
+			return nil
+		}
+
+		if approvals >= stage.RequiredApprovals {
+			nextReviewers, err = nextStageReviewers(app, assignments, candidates, user.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		assignment.ReviewStatus = acl.ReviewStatusApproved
+		assignment.ReviewedAt = now
+		assignment.UpdatedAt = now
+		if err := repo.UpdateApplicationAssignment(assignment); err != nil {
+			return err
+		}
+
+		if approvals < stage.RequiredApprovals {
+			// the reviewer and their delegate count as one approval
+			for _, other := range assignments {
+				if other.Stage == app.CurrentStage && other.ReviewStatus == acl.ReviewStatusWaiting && other.ID != assignment.ID && SameReviewer(assignment, other) {
+					other.ReviewStatus = acl.ReviewStatusPassed
+					other.UpdatedAt = now
+					if err := repo.UpdateApplicationAssignment(other); err != nil {
+						return err
+					}
+				}
+			}
+			message = fmt.Sprintf("%s: %d of %d approvals", stageLabel(app, app.CurrentStage), approvals, stage.RequiredApprovals)
+			addApprovalHistory(repo, app.ID, user.ID, assignment.OnBehalfOf, withComment(message, comment))
+			return nil
+		}
+
+		// the stage reached its quorum, the remaining reviewers of the stage are no longer needed
+		for _, other := range assignments {
+			if other.Stage == app.CurrentStage && other.ReviewStatus == acl.ReviewStatusWaiting && other.ID != assignment.ID {
+				other.ReviewStatus = acl.ReviewStatusPassed
+				other.UpdatedAt = now
+				if err := repo.UpdateApplicationAssignment(other); err != nil {
+					return err
+				}
+			}
+		}
+
+		approvedStage := app.CurrentStage
+		app.CurrentStage++
+		app.UpdatedAt = now
+		if err := repo.UpdateApplication(app); err != nil {
+			return err
+		}
+		for _, reviewerID := range nextReviewers {
+			next := acl.ApplicationAssignment{
+				ID:            uuid.NewString(),
+				ApplicationID: app.ID,
+				ReviewerID:    reviewerID,
+				ReviewStatus:  acl.ReviewStatusWaiting,
+				Stage:         app.CurrentStage,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+			if err := repo.CreateApplicationAssignment(next); err != nil {
+				return err
+			}
+		}
+
+		message = fmt.Sprintf("%s approved, waiting for %s", stageLabel(app, approvedStage), stageLabel(app, app.CurrentStage))
+		addApprovalHistory(repo, app.ID, user.ID, assignment.OnBehalfOf, withComment(message, comment))
+		return nil
+	})
+	if err != nil {
+		return false, "", err
+	}
+	if len(nextReviewers) > 0 {
+		notification.Publish(notification.TicketEvent(notifmodel.EventTicketAssigned, app, nextReviewers...))
+	}
+	return done, message, nil
+}
+
+// nextStageReviewers returns the candidates for the stage following the current one.
+// Reviewers who already approved an earlier stage are left out, so every stage is approved by different people.
+func nextStageReviewers(app acl.Application, assignments []acl.ApplicationAssignment, candidates []string, approverID string) ([]string, error) {
+	nextStage := app.StageAt(app.CurrentStage + 1)
+	approved := map[string]bool{approverID: true}
+	for _, assignment := range assignments {
+		if assignment.ReviewStatus == acl.ReviewStatusApproved {
+			approved[assignment.ReviewerID] = true
+			if assignment.OnBehalfOf != "" {
+				approved[assignment.OnBehalfOf] = true
+			}
+		}
+	}
+	var reviewers []string
+	for _, id := range candidates {
+		if !approved[id] {
+			reviewers = append(reviewers, id)
+		}
+	}
+	if len(reviewers) < nextStage.RequiredApprovals {
+		return nil, fmt.Errorf("%s requires %d approvals from group %s, but only %d eligible reviewers are left",
+			stageLabel(app, app.CurrentStage+1), nextStage.RequiredApprovals, nextStage.ReviewerGroupName, len(reviewers))
+	}
+	return reviewers, nil
+}
+
+func stageLabel(app acl.Application, i int) string {
+	if name := app.StageAt(i).Name; name != "" {
+		return name
+	}
+	return fmt.Sprintf("Stage %d", i+1)
+}
+
+// SameReviewer reports whether two assignments stand for the same reviewer: one is the delegate of the other,
+// or both are delegates of the same reviewer.
+func SameReviewer(a, b acl.ApplicationAssignment) bool {
+	principal := func(x acl.ApplicationAssignment) string {
+		if x.OnBehalfOf != "" {
+			return x.OnBehalfOf
+		}
+		return x.ReviewerID
+	}
+	return principal(a) == principal(b)
+}
+
+func withComment(message, comment string) string {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return message
+	}
+	return message + ": " + comment
+}
+
+func addApprovalHistory(repo IApprovalProgress, appID, actorID, onBehalfOf, comment string) {
+	history := acl.ApplicationHistory{
+		ID:            uuid.NewString(),
+		ApplicationID: appID,
+		Action:        acl.ActionApprove,
+		ActorID:       actorID,
+		OnBehalfOf:    onBehalfOf,
+		Comment:       comment,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := repo.CreateApplicationHistory(history); err != nil {
+		// log but do not fail
+		log.Println("failed to create application history", err)
+	}
+}
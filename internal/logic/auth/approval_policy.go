@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+)
+
+// maxApprovalStages bounds the length of an approval policy.
+const maxApprovalStages = 5
+
+// ReviewerGroups are the groups an application can be routed to.
+type ReviewerGroups struct {
+	DefaultGroupID  string // reviewers when no policy applies
+	OwnerGroupID    string // empty when the entity has no owner
+	ClaimantGroupID string // claim applications, or the new owner of an ownership transfer
+}
+
+type IResolveApprovalStages interface {
+	ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error)
+	GetGroupByName(name string) (acl.Group, error)
+	GetGroupByID(id string) (acl.Group, error)
+	GetReviewerIDsByGroupID(groupID string) ([]string, error)
+}
+
+// ResolveApprovalStages picks the approval policy of an application and resolves its reviewer groups.
+// A policy for a specific permission wins over the policy of the whole type; when the application
+// requests several permissions, the policy requiring the most approvals applies.
+// It returns nil when no policy applies, the application then needs one approval from the default group.
+func ResolveApprovalStages(repo IResolveApprovalStages, applicationType string, permissions []string, groups ReviewerGroups) ([]acl.ApplicationStage, error) {
+	policies, err := repo.ListApprovalPoliciesByType(applicationType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approval policies: %v", err)
+	}
+	policy, ok := selectApprovalPolicy(policies, permissions)
+	if !ok {
+		return nil, nil
+	}
+
+	var stages []acl.ApplicationStage
+	for i, stage := range policy.Stages {
+		group, err := resolveReviewerGroup(repo, stage.ReviewerGroup, groups)
+		if err != nil {
+			return nil, fmt.Errorf("approval policy %s stage %d: %v", policy.ID, i+1, err)
+		}
+		// an entity without owner has nobody to approve as owner
+		if group.ID == "" {
+			continue
+		}
+		reviewers, err := repo.GetReviewerIDsByGroupID(group.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reviewers of group %s: %v", group.Name, err)
+		}
+		if len(reviewers) < stage.RequiredApprovals {
+			return nil, fmt.Errorf("approval policy requires %d approvals from group %s, but it has only %d reviewers",
+				stage.RequiredApprovals, group.Name, len(reviewers))
+		}
+		name := stage.Name
+		if name == "" {
+			name = fmt.Sprintf("Stage %d", i+1)
+		}
+		stages = append(stages, acl.ApplicationStage{
+			Name:              name,
+			ReviewerGroupID:   group.ID,
+			ReviewerGroupName: group.Name,
+			RequiredApprovals: stage.RequiredApprovals,
+		})
+	}
+	return stages, nil
+}
+
+func selectApprovalPolicy(policies []acl.ApprovalPolicy, permissions []string) (acl.ApprovalPolicy, bool) {
+	byPermission := map[string]acl.ApprovalPolicy{}
+	for _, policy := range policies {
+		byPermission[policy.Permission] = policy
+	}
+	var selected acl.ApprovalPolicy
+	found := false
+	for _, permission := range permissions {
+		policy, ok := byPermission[permission]
+		if !ok {
+			policy, ok = byPermission[""]
+		}
+		if !ok {
+			continue
+		}
+		if !found || policy.RequiredApprovals() > selected.RequiredApprovals() {
+			selected = policy
+			found = true
+		}
+	}
+	return selected, found
+}
+
+func resolveReviewerGroup(repo IResolveApprovalStages, reviewerGroup string, groups ReviewerGroups) (acl.Group, error) {
+	var groupID string
+	switch reviewerGroup {
+	case acl.ReviewerGroup_Owner:
+		groupID = groups.OwnerGroupID
+	case acl.ReviewerGroup_Claimant:
+		groupID = groups.ClaimantGroupID
+	default:
+		return repo.GetGroupByName(reviewerGroup)
+	}
+	if groupID == "" {
+		return acl.Group{}, nil
+	}
+	return repo.GetGroupByID(groupID)
+}
+
+// ValidateApprovalPolicy checks the shape of a policy before it is saved.
+func ValidateApprovalPolicy(policy acl.ApprovalPolicy) error {
+	if len(policy.Stages) == 0 {
+		return fmt.Errorf("approval policy needs at least one stage")
+	}
+	if len(policy.Stages) > maxApprovalStages {
+		return fmt.Errorf("approval policy can have at most %d stages", maxApprovalStages)
+	}
+	for i, stage := range policy.Stages {
+		if stage.ReviewerGroup == "" {
+			return fmt.Errorf("stage %d: reviewer group is required", i+1)
+		}
+		if stage.RequiredApprovals < 1 {
+			return fmt.Errorf("stage %d: required approvals must be at least 1", i+1)
+		}
+		if stage.ReviewerGroup == acl.ReviewerGroup_Claimant &&
+			policy.ApplicationType != acl.ApplicationType_Claim && policy.ApplicationType != acl.ApplicationType_Transfer {
+			return fmt.Errorf("stage %d: %s is only available for claim and ownership transfer applications", i+1, acl.ReviewerGroup_Claimant)
+		}
+	}
+	return nil
+}
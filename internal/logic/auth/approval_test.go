@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// approvalStore is an IApprovalStore over an in-memory database with fixed reviewers per group.
+type approvalStore struct {
+	db        *buntdb.DB
+	reviewers map[string][]string
+}
+
+func (s approvalStore) GetReviewerIDsByGroupID(groupID string) ([]string, error) {
+	return s.reviewers[groupID], nil
+}
+
+func (s approvalStore) WithApprovalTx(fn func(repo IApprovalProgress) error) error {
+	return WithApprovalTx(s.db, fn)
+}
+
+func TestRecordApproval_CountsFromStoredAssignments(t *testing.T) {
+	bdb, err := buntdb.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bdb.Close()
+	if err := apprepo.InitIndexApplication(bdb); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	app := acl.Application{
+		ID:     "app1",
+		UserID: "applicant",
+		Type:   acl.ApplicationType_Claim,
+		Status: acl.StatusWaitingForApproval,
+		Stages: []acl.ApplicationStage{
+			{Name: "Owners", ReviewerGroupID: "g-owners", RequiredApprovals: 2},
+			{Name: "Security", ReviewerGroupID: "g-security", RequiredApprovals: 1},
+		},
+		CreatedAt: now,
+	}
+	if err := dbpkg.Insert(bdb, &app); err != nil {
+		t.Fatal(err)
+	}
+	for _, reviewerID := range []string{"u1", "u2", "u3"} {
+		if err := apprepo.CreateApplicationAssignment(bdb, acl.ApplicationAssignment{
+			ID: "a-" + reviewerID, ApplicationID: app.ID, ReviewerID: reviewerID,
+			ReviewStatus: acl.ReviewStatusWaiting, CreatedAt: now,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := approvalStore{db: bdb, reviewers: map[string][]string{"g-security": {"u4"}}}
+	approve := func(userID string) (bool, string, error) {
+		// every reviewer acts on the application as loaded before anyone approved
+		ctx := util.MockContextWithUser(context.Background(), &acl.User{ID: userID})
+		return RecordApproval(ctx, store, app, "")
+	}
+
+	done, message, err := approve("u1")
+	if err != nil || done || message != "Owners: 1 of 2 approvals" {
+		t.Fatalf("first approval = %v, %q, %v", done, message, err)
+	}
+	// the approval of u1 is read again, the stale snapshot must not count u2 as the first one
+	done, message, err = approve("u2")
+	if err != nil || done || message != "Owners approved, waiting for Security" {
+		t.Fatalf("second approval = %v, %q, %v", done, message, err)
+	}
+
+	stored, err := dbpkg.GetByID[acl.Application](bdb, app.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.CurrentStage != 1 {
+		t.Errorf("current stage = %d, want 1", stored.CurrentStage)
+	}
+	assignments, err := dbpkg.SelectAll[acl.ApplicationAssignment](bdb, "="+app.ID, acl.IdxAppAssign_ApplicationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := map[string]string{}
+	for _, a := range assignments {
+		status[a.ReviewerID] = a.ReviewStatus
+	}
+	want := map[string]string{
+		"u1": acl.ReviewStatusApproved,
+		"u2": acl.ReviewStatusApproved,
+		"u3": acl.ReviewStatusPassed,
+		"u4": acl.ReviewStatusWaiting,
+	}
+	for reviewerID, s := range want {
+		if status[reviewerID] != s {
+			t.Errorf("assignment of %s = %q, want %q", reviewerID, status[reviewerID], s)
+		}
+	}
+
+	// the stage moved on while u3 looked at the first one
+	if _, _, err := approve("u3"); err == nil || !strings.Contains(err.Error(), "another stage") {
+		t.Errorf("late approval error = %v", err)
+	}
+}
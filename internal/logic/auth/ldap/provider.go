@@ -0,0 +1,186 @@
+// Package ldap logs users in against an LDAP or Active Directory server.
+package ldap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/tidwall/buntdb"
+)
+
+const dialTimeout = 10 * time.Second
+
+// conn is the part of *goldap.Conn the provider uses.
+type conn interface {
+	StartTLS(config *tls.Config) error
+	Bind(username, password string) error
+	Search(req *goldap.SearchRequest) (*goldap.SearchResult, error)
+	Close() error
+}
+
+type providerRepo struct {
+	db *buntdb.DB
+}
+
+func (r *providerRepo) GetUserByUsername(username string) (acl.User, error) {
+	return userrepo.GetUserByUsername(r.db, username)
+}
+
+func (r *providerRepo) CreateUser(user acl.User) error {
+	return userrepo.CreateUser(r.db, user)
+}
+
+func (r *providerRepo) UpdateUser(user acl.User) error {
+	return userrepo.UpdateUser(r.db, user)
+}
+
+func (r *providerRepo) GetGroupByName(name string) (acl.Group, error) {
+	return userrepo.GetGroupByName(r.db, name)
+}
+
+func (r *providerRepo) ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error) {
+	return userrepo.ListUserGroupsByUserID(r.db, userID)
+}
+
+func (r *providerRepo) CreateUserGroup(userGroup acl.UserGroup) error {
+	return userrepo.CreateUserGroup(r.db, userGroup)
+}
+
+func (r *providerRepo) DeleteUserGroup(id string) error {
+	return userrepo.DeleteUserGroup(r.db, id)
+}
+
+// Provider checks the password by binding as the user found with the search filter.
+// Users are created on their first login and their mapped groups follow the directory.
+type Provider struct {
+	cfg  config.LDAPConfig
+	repo auth.IProvisionRepo
+	dial func(cfg config.LDAPConfig) (conn, error)
+}
+
+func NewProvider(cfg config.LDAPConfig, db *buntdb.DB) Provider {
+	return Provider{
+		cfg:  cfg,
+		repo: &providerRepo{db: db},
+		dial: dial,
+	}
+}
+
+func dial(cfg config.LDAPConfig) (conn, error) {
+	c, err := goldap.DialURL(cfg.URL, goldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}))
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (p Provider) Authenticate(username, password string) (acl.User, error) {
+	// an empty password is an unauthenticated bind, which most servers accept
+	if username == "" || password == "" {
+		return acl.User{}, auth.ErrInvalidPassword
+	}
+	entry, err := p.verify(username, password)
+	if err != nil {
+		return acl.User{}, err
+	}
+
+	user, err := auth.ProvisionUser(p.repo, acl.UserSourceLDAP, username, entry.GetAttributeValue(p.nameAttr()))
+	if err != nil {
+		return acl.User{}, err
+	}
+
+	// DNs are compared case and space insensitive
+	mapping := make(map[string]string, len(p.cfg.GroupMapping))
+	for dn, name := range p.cfg.GroupMapping {
+		mapping[normalizeDN(dn)] = name
+	}
+	var groups []string
+	for _, dn := range entry.GetAttributeValues(p.groupAttr()) {
+		groups = append(groups, normalizeDN(dn))
+	}
+	if err := auth.SyncMappedGroups(p.repo, user, mapping, groups); err != nil {
+		return acl.User{}, err
+	}
+	return user, nil
+}
+
+// verify finds the entry of the user and binds as it with the password.
+func (p Provider) verify(username, password string) (*goldap.Entry, error) {
+	c, err := p.dial(p.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP: %w", err)
+	}
+	defer c.Close()
+
+	if p.cfg.StartTLS {
+		u, err := url.Parse(p.cfg.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid LDAP url: %w", err)
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: u.Hostname()}); err != nil {
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if p.cfg.BindDN != "" {
+		if err := c.Bind(p.cfg.BindDN, p.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind as %s: %w", p.cfg.BindDN, err)
+		}
+	}
+
+	res, err := c.Search(goldap.NewSearchRequest(
+		p.cfg.BaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 2, int(dialTimeout.Seconds()), false,
+		fmt.Sprintf(p.cfg.UserFilter, goldap.EscapeFilter(username)),
+		[]string{p.nameAttr(), p.groupAttr()},
+		nil,
+	))
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("failed to search %s: %w", username, err)
+	}
+	if res == nil || len(res.Entries) == 0 {
+		return nil, auth.ErrUnknownUser
+	}
+	if len(res.Entries) > 1 {
+		return nil, fmt.Errorf("more than one LDAP entry matches %s", username)
+	}
+
+	entry := res.Entries[0]
+	if err := c.Bind(entry.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, auth.ErrInvalidPassword
+		}
+		return nil, fmt.Errorf("failed to bind as %s: %w", username, err)
+	}
+	return entry, nil
+}
+
+func (p Provider) nameAttr() string {
+	if p.cfg.NameAttr == "" {
+		return "cn"
+	}
+	return p.cfg.NameAttr
+}
+
+func (p Provider) groupAttr() string {
+	if p.cfg.GroupAttr == "" {
+		return "memberOf"
+	}
+	return p.cfg.GroupAttr
+}
+
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.ToLower(strings.Join(parts, ","))
+}
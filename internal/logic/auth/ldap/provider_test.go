@@ -0,0 +1,251 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+)
+
+// directory is an in-memory LDAP stand-in: entries are found by exact filter, binds check the passwords.
+type directory struct {
+	passwords map[string]string        // dn -> password
+	entries   map[string]*goldap.Entry // filter -> entry
+	startTLS  bool
+	binds     []string
+}
+
+func (d *directory) StartTLS(config *tls.Config) error {
+	d.startTLS = true
+	return nil
+}
+
+func (d *directory) Bind(username, password string) error {
+	d.binds = append(d.binds, username)
+	if pw, ok := d.passwords[username]; !ok || pw != password {
+		return goldap.NewError(goldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+func (d *directory) Search(req *goldap.SearchRequest) (*goldap.SearchResult, error) {
+	res := &goldap.SearchResult{}
+	if e, ok := d.entries[req.Filter]; ok {
+		res.Entries = append(res.Entries, e)
+	}
+	return res, nil
+}
+
+func (d *directory) Close() error { return nil }
+
+type memRepo struct {
+	users      map[string]acl.User  // username -> user
+	groups     map[string]acl.Group // name -> group
+	userGroups map[string]acl.UserGroup
+}
+
+func (m *memRepo) GetUserByUsername(username string) (acl.User, error) {
+	if u, ok := m.users[username]; ok {
+		return u, nil
+	}
+	return acl.User{}, db.ErrNotFound
+}
+func (m *memRepo) CreateUser(user acl.User) error {
+	m.users[user.Username] = user
+	return nil
+}
+func (m *memRepo) UpdateUser(user acl.User) error {
+	m.users[user.Username] = user
+	return nil
+}
+func (m *memRepo) GetGroupByName(name string) (acl.Group, error) {
+	if g, ok := m.groups[name]; ok {
+		return g, nil
+	}
+	return acl.Group{}, db.ErrNotFound
+}
+func (m *memRepo) ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error) {
+	var res []acl.UserGroup
+	for _, ug := range m.userGroups {
+		if ug.UserID == userID {
+			res = append(res, ug)
+		}
+	}
+	return res, nil
+}
+func (m *memRepo) CreateUserGroup(userGroup acl.UserGroup) error {
+	m.userGroups[userGroup.ID] = userGroup
+	return nil
+}
+func (m *memRepo) DeleteUserGroup(id string) error {
+	delete(m.userGroups, id)
+	return nil
+}
+
+func (m *memRepo) groupNamesOf(userID string) []string {
+	var names []string
+	for _, ug := range m.userGroups {
+		if ug.UserID != userID {
+			continue
+		}
+		for _, g := range m.groups {
+			if g.ID == ug.GroupID {
+				names = append(names, g.Name)
+			}
+		}
+	}
+	return names
+}
+
+func TestProvider_Authenticate(t *testing.T) {
+	cfg := config.LDAPConfig{
+		URL:          "ldap://ldap.example.com:389",
+		StartTLS:     true,
+		BindDN:       "cn=svc,dc=example,dc=com",
+		BindPassword: "svc-pw",
+		BaseDN:       "dc=example,dc=com",
+		UserFilter:   "(&(objectClass=person)(uid=%s))",
+		GroupMapping: map[string]string{
+			"cn=payments,ou=groups,dc=example,dc=com": "payments",
+			"cn=billing,ou=groups,dc=example,dc=com":  "billing",
+		},
+	}
+	aliceDN := "uid=alice,ou=people,dc=example,dc=com"
+	newDirectory := func() *directory {
+		return &directory{
+			passwords: map[string]string{cfg.BindDN: cfg.BindPassword, aliceDN: "alice-pw"},
+			entries: map[string]*goldap.Entry{
+				"(&(objectClass=person)(uid=alice))": goldap.NewEntry(aliceDN, map[string][]string{
+					"cn":       {"Alice Liddell"},
+					"memberOf": {"CN=Payments, OU=Groups, DC=example, DC=com", "cn=other,dc=example,dc=com"},
+				}),
+			},
+		}
+	}
+	newRepo := func() *memRepo {
+		return &memRepo{
+			users: map[string]acl.User{
+				"root": {ID: "u-root", Username: "root", Source: acl.UserSourceLocal},
+			},
+			groups: map[string]acl.Group{
+				"payments": {ID: "g-payments", Name: "payments"},
+				"billing":  {ID: "g-billing", Name: "billing"},
+				"ops":      {ID: "g-ops", Name: "ops"},
+			},
+			userGroups: map[string]acl.UserGroup{},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		username   string
+		password   string
+		setup      func(repo *memRepo)
+		wantErr    error
+		wantErrMsg string
+		wantGroups []string
+	}{
+		{
+			name:       "first login creates the user and maps its groups",
+			username:   "alice",
+			password:   "alice-pw",
+			wantGroups: []string{"payments"},
+		},
+		{
+			name:     "left directory groups are removed, unmapped ones kept",
+			username: "alice",
+			password: "alice-pw",
+			setup: func(repo *memRepo) {
+				repo.users["alice"] = acl.User{ID: "u-alice", Username: "alice", Name: "Alice", Source: acl.UserSourceLDAP}
+				repo.userGroups["ug1"] = acl.UserGroup{ID: "ug1", UserID: "u-alice", GroupID: "g-billing", Role: acl.RoleGroupMember}
+				repo.userGroups["ug2"] = acl.UserGroup{ID: "ug2", UserID: "u-alice", GroupID: "g-ops", Role: acl.RoleGroupAdmin}
+			},
+			wantGroups: []string{"ops", "payments"},
+		},
+		{
+			name:     "wrong password",
+			username: "alice",
+			password: "nope",
+			wantErr:  auth.ErrInvalidPassword,
+		},
+		{
+			name:     "empty password never binds",
+			username: "alice",
+			password: "",
+			wantErr:  auth.ErrInvalidPassword,
+		},
+		{
+			name:     "unknown user lets the next provider try",
+			username: "bob",
+			password: "pw",
+			wantErr:  auth.ErrUnknownUser,
+		},
+		{
+			name:     "filter characters in the username are escaped",
+			username: "*",
+			password: "pw",
+			wantErr:  auth.ErrUnknownUser,
+		},
+		{
+			name:     "local user is not taken over",
+			username: "alice",
+			password: "alice-pw",
+			setup: func(repo *memRepo) {
+				repo.users["alice"] = acl.User{ID: "u-alice", Username: "alice", Source: acl.UserSourceLocal}
+			},
+			wantErrMsg: "not managed by ldap",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newDirectory()
+			repo := newRepo()
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			p := Provider{cfg: cfg, repo: repo, dial: func(config.LDAPConfig) (conn, error) { return dir, nil }}
+
+			user, err := p.Authenticate(tt.username, tt.password)
+			if tt.wantErr != nil || tt.wantErrMsg != "" {
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErrMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErrMsg)) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !dir.startTLS {
+				t.Error("StartTLS not called")
+			}
+			if len(dir.binds) != 2 || dir.binds[0] != cfg.BindDN || dir.binds[1] != aliceDN {
+				t.Errorf("unexpected binds %v", dir.binds)
+			}
+			if user.Source != acl.UserSourceLDAP || user.Name != "Alice Liddell" || user.ID == "" {
+				t.Errorf("unexpected user %+v", user)
+			}
+			if stored := repo.users["alice"]; stored.ID != user.ID || stored.Name != "Alice Liddell" {
+				t.Errorf("stored user %+v doesn't match %+v", stored, user)
+			}
+			got := repo.groupNamesOf(user.ID)
+			if strings.Join(sortStrings(got), ",") != strings.Join(tt.wantGroups, ",") {
+				t.Errorf("groups = %v, want %v", got, tt.wantGroups)
+			}
+		})
+	}
+}
+
+func sortStrings(s []string) []string {
+	sort.Strings(s)
+	return s
+}
@@ -0,0 +1,367 @@
+// Package oidc signs users in with the authorization code flow of an OpenID Connect provider.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/tidwall/buntdb"
+)
+
+const httpTimeout = 10 * time.Second
+
+// Flow holds the secrets of one login, kept by the browser between the redirect and the callback.
+type Flow struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"` // PKCE code verifier
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type provisionRepo struct {
+	db *buntdb.DB
+}
+
+func (r *provisionRepo) GetUserByUsername(username string) (acl.User, error) {
+	return userrepo.GetUserByUsername(r.db, username)
+}
+
+func (r *provisionRepo) CreateUser(user acl.User) error {
+	return userrepo.CreateUser(r.db, user)
+}
+
+func (r *provisionRepo) UpdateUser(user acl.User) error {
+	return userrepo.UpdateUser(r.db, user)
+}
+
+func (r *provisionRepo) GetGroupByName(name string) (acl.Group, error) {
+	return userrepo.GetGroupByName(r.db, name)
+}
+
+func (r *provisionRepo) ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error) {
+	return userrepo.ListUserGroupsByUserID(r.db, userID)
+}
+
+func (r *provisionRepo) CreateUserGroup(userGroup acl.UserGroup) error {
+	return userrepo.CreateUserGroup(r.db, userGroup)
+}
+
+func (r *provisionRepo) DeleteUserGroup(id string) error {
+	return userrepo.DeleteUserGroup(r.db, id)
+}
+
+// Client talks to the provider found by discovery and provisions the users it signs in.
+type Client struct {
+	cfg        config.OIDCConfig
+	repo       auth.IProvisionRepo
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	meta *discovery
+	keys map[string]*rsa.PublicKey // kid -> key of the JWKS
+}
+
+func NewClient(cfg config.OIDCConfig, db *buntdb.DB) *Client {
+	return newClient(cfg, &provisionRepo{db: db})
+}
+
+func newClient(cfg config.OIDCConfig, repo auth.IProvisionRepo) *Client {
+	return &Client{
+		cfg:        cfg,
+		repo:       repo,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// NewFlow generates the state, nonce and PKCE verifier of a login.
+func (c *Client) NewFlow() (Flow, error) {
+	var flow Flow
+	for _, s := range []*string{&flow.State, &flow.Nonce, &flow.Verifier} {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return Flow{}, err
+		}
+		*s = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return flow, nil
+}
+
+// AuthCodeURL is where the browser is sent to sign in.
+func (c *Client) AuthCodeURL(ctx context.Context, flow Flow) (string, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	challenge := sha256.Sum256([]byte(flow.Verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.cfg.ClientID},
+		"redirect_uri":          {c.cfg.RedirectURL},
+		"scope":                 {strings.Join(append([]string{"openid"}, c.cfg.Scopes...), " ")},
+		"state":                 {flow.State},
+		"nonce":                 {flow.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Login exchanges the code of the callback, verifies the ID token and returns the provisioned user.
+func (c *Client) Login(ctx context.Context, code string, flow Flow) (acl.User, error) {
+	rawIDToken, err := c.exchange(ctx, code, flow.Verifier)
+	if err != nil {
+		return acl.User{}, err
+	}
+	claims, err := c.verify(ctx, rawIDToken, flow.Nonce)
+	if err != nil {
+		return acl.User{}, err
+	}
+
+	username, _ := claims[c.usernameClaim()].(string)
+	if username == "" {
+		return acl.User{}, fmt.Errorf("ID token has no %s claim", c.usernameClaim())
+	}
+	name, _ := claims["name"].(string)
+	user, err := auth.ProvisionUser(c.repo, acl.UserSourceOIDC, username, name)
+	if err != nil {
+		return acl.User{}, err
+	}
+	if groups, ok := stringsClaim(claims, c.groupsClaim()); ok {
+		if err := auth.SyncMappedGroups(c.repo, user, c.cfg.GroupMapping, groups); err != nil {
+			return acl.User{}, err
+		}
+	}
+	return user, nil
+}
+
+func (c *Client) exchange(ctx context.Context, code, verifier string) (string, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.cfg.RedirectURL},
+		"client_id":     {c.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret))
+	}
+
+	var token struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if token.Error != "" {
+		return "", fmt.Errorf("token endpoint: %s %s", token.Error, token.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK || token.IDToken == "" {
+		return "", fmt.Errorf("token endpoint returned %d without ID token", resp.StatusCode)
+	}
+	return token.IDToken, nil
+}
+
+// verify checks the signature of the ID token against the JWKS, then its issuer, audience, expiry and nonce.
+func (c *Client) verify(ctx context.Context, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.key(ctx, kid)
+	}, jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	if !claims.VerifyIssuer(meta.Issuer, true) {
+		return nil, errors.New("invalid ID token: unexpected issuer")
+	}
+	if !claims.VerifyAudience(c.cfg.ClientID, true) {
+		return nil, errors.New("invalid ID token: unexpected audience")
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("invalid ID token: expired")
+	}
+	if n, _ := claims["nonce"].(string); n == "" || n != nonce {
+		return nil, errors.New("invalid ID token: nonce mismatch")
+	}
+	return claims, nil
+}
+
+// key returns the JWKS key of kid, the JWKS is fetched again once when the key is unknown.
+func (c *Client) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	meta, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := c.fetchKeys(ctx, meta.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	// a provider with a single key may leave kid out
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (c *Client) fetchKeys(ctx context.Context, jwksURI string) (map[string]*rsa.PublicKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := c.getJSON(ctx, jwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key %s: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key %s: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	return keys, nil
+}
+
+// discover reads the provider metadata once, the issuer must match the configured one.
+func (c *Client) discover(ctx context.Context) (*discovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.meta != nil {
+		return c.meta, nil
+	}
+	issuer := strings.TrimSuffix(c.cfg.IssuerURL, "/")
+	var meta discovery
+	if err := c.getJSON(ctx, issuer+"/.well-known/openid-configuration", &meta); err != nil {
+		return nil, fmt.Errorf("failed to discover %s: %w", issuer, err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("discovery returned issuer %s, expected %s", meta.Issuer, issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery of %s is missing endpoints", issuer)
+	}
+	c.meta = &meta
+	return c.meta, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %d", u, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
+
+func (c *Client) usernameClaim() string {
+	if c.cfg.UsernameClaim == "" {
+		return "preferred_username"
+	}
+	return c.cfg.UsernameClaim
+}
+
+func (c *Client) groupsClaim() string {
+	if c.cfg.GroupsClaim == "" {
+		return "groups"
+	}
+	return c.cfg.GroupsClaim
+}
+
+// stringsClaim reads a claim holding a list of strings or a single string.
+func stringsClaim(claims jwt.MapClaims, name string) ([]string, bool) {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}, true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
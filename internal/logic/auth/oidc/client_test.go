@@ -0,0 +1,205 @@
+package oidc
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth/oidc/oidctest"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+)
+
+type memRepo struct {
+	users      map[string]acl.User // username -> user
+	groups     map[string]acl.Group
+	userGroups map[string]acl.UserGroup
+}
+
+func (m *memRepo) GetUserByUsername(username string) (acl.User, error) {
+	if u, ok := m.users[username]; ok {
+		return u, nil
+	}
+	return acl.User{}, db.ErrNotFound
+}
+func (m *memRepo) CreateUser(user acl.User) error {
+	m.users[user.Username] = user
+	return nil
+}
+func (m *memRepo) UpdateUser(user acl.User) error {
+	m.users[user.Username] = user
+	return nil
+}
+func (m *memRepo) GetGroupByName(name string) (acl.Group, error) {
+	if g, ok := m.groups[name]; ok {
+		return g, nil
+	}
+	return acl.Group{}, db.ErrNotFound
+}
+func (m *memRepo) ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error) {
+	var res []acl.UserGroup
+	for _, ug := range m.userGroups {
+		if ug.UserID == userID {
+			res = append(res, ug)
+		}
+	}
+	return res, nil
+}
+func (m *memRepo) CreateUserGroup(userGroup acl.UserGroup) error {
+	m.userGroups[userGroup.ID] = userGroup
+	return nil
+}
+func (m *memRepo) DeleteUserGroup(id string) error {
+	delete(m.userGroups, id)
+	return nil
+}
+
+func (m *memRepo) groupNamesOf(userID string) []string {
+	var names []string
+	for _, ug := range m.userGroups {
+		for _, g := range m.groups {
+			if ug.UserID == userID && g.ID == ug.GroupID {
+				names = append(names, g.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestClient_Login(t *testing.T) {
+	provider, err := oidctest.NewProvider("topic-master", "s3cret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+
+	cfg := config.OIDCConfig{
+		IssuerURL:    provider.URL,
+		ClientID:     "topic-master",
+		ClientSecret: "s3cret",
+		RedirectURL:  "https://topic-master.example.com/api/auth/oidc/callback",
+		Scopes:       []string{"profile", "groups"},
+		GroupMapping: map[string]string{"sso-payments": "payments", "sso-billing": "billing"},
+	}
+
+	tests := []struct {
+		name       string
+		claims     map[string]interface{}
+		setup      func(repo *memRepo)
+		tamper     func(flow *Flow)
+		wantErr    string
+		wantGroups []string
+	}{
+		{
+			name:       "first login provisions the user and its groups",
+			claims:     map[string]interface{}{"preferred_username": "alice", "name": "Alice", "groups": []string{"sso-payments", "unmapped"}},
+			wantGroups: []string{"payments"},
+		},
+		{
+			name:   "groups follow the claim, unmapped memberships are kept",
+			claims: map[string]interface{}{"preferred_username": "alice", "name": "Alice", "groups": []string{"sso-billing"}},
+			setup: func(repo *memRepo) {
+				repo.users["alice"] = acl.User{ID: "u-alice", Username: "alice", Name: "Alice", Source: acl.UserSourceOIDC}
+				repo.userGroups["ug1"] = acl.UserGroup{ID: "ug1", UserID: "u-alice", GroupID: "g-payments"}
+				repo.userGroups["ug2"] = acl.UserGroup{ID: "ug2", UserID: "u-alice", GroupID: "g-ops"}
+			},
+			wantGroups: []string{"billing", "ops"},
+		},
+		{
+			name:    "PKCE verifier mismatch",
+			claims:  map[string]interface{}{"preferred_username": "alice"},
+			tamper:  func(flow *Flow) { flow.Verifier = "other" },
+			wantErr: "invalid_grant",
+		},
+		{
+			name:    "nonce mismatch",
+			claims:  map[string]interface{}{"preferred_username": "alice"},
+			tamper:  func(flow *Flow) { flow.Nonce = "replayed" },
+			wantErr: "nonce mismatch",
+		},
+		{
+			name:    "expired ID token",
+			claims:  map[string]interface{}{"preferred_username": "alice", "exp": time.Now().Add(-time.Hour).Unix()},
+			wantErr: "expired",
+		},
+		{
+			name:    "token for another client",
+			claims:  map[string]interface{}{"preferred_username": "alice", "aud": "someone-else"},
+			wantErr: "unexpected audience",
+		},
+		{
+			name:    "missing username claim",
+			claims:  map[string]interface{}{"email": "alice@example.com"},
+			wantErr: "no preferred_username claim",
+		},
+		{
+			name:   "local user is not taken over",
+			claims: map[string]interface{}{"preferred_username": "root"},
+			setup: func(repo *memRepo) {
+				repo.users["root"] = acl.User{ID: "u-root", Username: "root"}
+			},
+			wantErr: "not managed by oidc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memRepo{
+				users: map[string]acl.User{},
+				groups: map[string]acl.Group{
+					"payments": {ID: "g-payments", Name: "payments"},
+					"billing":  {ID: "g-billing", Name: "billing"},
+					"ops":      {ID: "g-ops", Name: "ops"},
+				},
+				userGroups: map[string]acl.UserGroup{},
+			}
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			client := newClient(cfg, repo)
+			ctx := context.Background()
+
+			flow, err := client.NewFlow()
+			if err != nil {
+				t.Fatal(err)
+			}
+			authURL, err := client.AuthCodeURL(ctx, flow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(authURL, provider.URL+"/authorize?") || !strings.Contains(authURL, "scope=openid+profile+groups") {
+				t.Errorf("unexpected authorization url %s", authURL)
+			}
+			code, state, err := provider.Authorize(authURL, tt.claims)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state != flow.State {
+				t.Fatalf("state = %s, want %s", state, flow.State)
+			}
+			if tt.tamper != nil {
+				tt.tamper(&flow)
+			}
+
+			user, err := client.Login(ctx, code, flow)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if user.Username != "alice" || user.Name != "Alice" || user.Source != acl.UserSourceOIDC || user.ID == "" {
+				t.Errorf("unexpected user %+v", user)
+			}
+			if got := repo.groupNamesOf(user.ID); strings.Join(got, ",") != strings.Join(tt.wantGroups, ",") {
+				t.Errorf("groups = %v, want %v", got, tt.wantGroups)
+			}
+		})
+	}
+}
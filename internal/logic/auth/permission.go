@@ -2,6 +2,10 @@ package auth
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/internal/model/entity"
@@ -12,6 +16,8 @@ type ICheckUserActionPermission interface {
 	GetEntityByID(id string) (*entity.Entity, error)
 	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
 	GetPermissionByActionEntity(userID, entityID, action string) (acl.PermissionMap, error)
+	GetCustomRole(groupID, name string) (acl.CustomRole, error)
+	GetEntityDefaultPermission(entityID, action string) (entity.EntityDefaultPermission, error)
 }
 
 // CheckUserEntityActionPermission checks if a user can perform an action on an entity.
@@ -22,8 +28,36 @@ func CheckUserActionPermission(user *acl.User, entityID string, action string, d
 		return err
 	}
 
-	// 2. If entity GroupOwner is empty or None, allow
-	if ent.GroupOwner == "" || ent.GroupOwner == entity.GroupNone {
+	// 2. Unowned entities follow the global default, which only root can bypass
+	if !ent.IsOwned() {
+		def, err := deps.GetEntityDefaultPermission(entity.DefaultPermission_Unowned, action)
+		if err != nil {
+			return err
+		}
+		if def.Allows(user != nil) {
+			return nil
+		}
+		if user == nil {
+			return errors.New("This action is restricted, please login to perform this action")
+		}
+		userGroups, err := userGroupsOf(user, deps)
+		if err != nil {
+			return err
+		}
+		for _, g := range userGroups {
+			if g.GroupName == acl.GroupRoot {
+				return nil
+			}
+		}
+		return errors.New("permission denied")
+	}
+
+	// 3. Owned entities may open the action to users outside the owner group
+	def, err := deps.GetEntityDefaultPermission(ent.ID, action)
+	if err != nil {
+		return err
+	}
+	if def.Allows(user != nil) {
 		return nil
 	}
 
@@ -31,28 +65,96 @@ func CheckUserActionPermission(user *acl.User, entityID string, action string, d
 		return errors.New("This entity is owned by a group, please login to perform this action")
 	}
 
-	// 3. If owner is not nil, check group membership
-	userGroups := user.Groups
-	if len(userGroups) == 0 {
-		userGroups, err = deps.GetGroupsByUserID(user.ID)
-		if err != nil {
-			return err
-		}
+	userGroups, err := userGroupsOf(user, deps)
+	if err != nil {
+		return err
 	}
 
-	// 4. If entity is owned by a group and user is a member, allow
+	// 4. If entity is owned by a group and the user's role in it holds the action, allow
 	for _, g := range userGroups {
-		if ent.GroupOwner == g.GroupName {
+		if ent.GroupOwnerID != g.GroupID {
+			continue
+		}
+		if acl.IsBuiltinRole(g.Role) {
+			if acl.BuiltinRoleAllows(g.Role, action) {
+				return nil
+			}
+			continue
+		}
+		role, err := deps.GetCustomRole(g.GroupID, g.Role)
+		if err == nil && role.Allows(action) {
 			return nil
 		}
 	}
 
 	// 5. Query permission by action/entity/user
 	perm, err := deps.GetPermissionByActionEntity(user.ID, entityID, action)
-	if err == nil && perm.UserID == user.ID {
+	if err == nil && perm.UserID == user.ID && !perm.IsExpired(time.Now()) {
 		return nil
 	}
 
 	// 6. Deny if all checks fail
 	return errors.New("permission denied")
 }
+
+// userGroupsOf loads the stored memberships, the groups in the token may be stale until it expires.
+func userGroupsOf(user *acl.User, deps ICheckUserActionPermission) ([]acl.GroupRole, error) {
+	return deps.GetGroupsByUserID(user.ID)
+}
+
+// MaxGrantDuration bounds how long a time-bound grant can be requested for.
+const MaxGrantDuration = 90 * 24 * time.Hour
+
+// ParseGrantDuration parses a requested grant duration such as "30m", "2h" or "7d".
+// An empty string means a permanent grant and returns 0.
+func ParseGrantDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+	}
+	if d <= 0 {
+		return 0, errors.New("duration must be positive")
+	}
+	if d > MaxGrantDuration {
+		return 0, fmt.Errorf("duration must not exceed %d days", int(MaxGrantDuration.Hours()/24))
+	}
+	return d, nil
+}
+
+// GrantExpiresAt returns the expiry of the grants approved at now for the application.
+// The zero time means the grants are permanent.
+func GrantExpiresAt(app acl.Application, now time.Time) (time.Time, error) {
+	d, err := ParseGrantDuration(app.MetaData[acl.AppMetaData_GrantDuration])
+	if err != nil || d == 0 {
+		return time.Time{}, err
+	}
+	return now.Add(d), nil
+}
+
+// IsEntityOwner reports whether a user in the given groups may manage grants of the entity:
+// admins and members of the owning group, and root. Custom roles only hold their own permissions.
+func IsEntityOwner(groups []acl.GroupRole, ent *entity.Entity) bool {
+	for _, g := range groups {
+		if g.GroupName == acl.GroupRoot {
+			return true
+		}
+		if ent.IsOwned() && g.GroupID == ent.GroupOwnerID && acl.IsBuiltinRole(g.Role) {
+			return true
+		}
+	}
+	return false
+}
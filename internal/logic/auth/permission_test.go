@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+)
+
+// permissionStore is an in-memory ICheckUserActionPermission keyed like the database.
+type permissionStore struct {
+	entities    map[string]*entity.Entity
+	memberships map[string][]acl.GroupRole
+	roles       map[string]acl.CustomRole // by group id and role name
+	grants      map[string]acl.PermissionMap
+	defaults    map[string]entity.EntityDefaultPermission
+}
+
+func (s permissionStore) GetEntityByID(id string) (*entity.Entity, error) {
+	if ent, ok := s.entities[id]; ok {
+		return ent, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (s permissionStore) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return s.memberships[userID], nil
+}
+
+func (s permissionStore) GetPermissionByActionEntity(userID, entityID, action string) (acl.PermissionMap, error) {
+	if perm, ok := s.grants[userID+":"+entityID+":"+action]; ok {
+		return perm, nil
+	}
+	return acl.PermissionMap{}, errors.New("not found")
+}
+
+func (s permissionStore) GetCustomRole(groupID, name string) (acl.CustomRole, error) {
+	if role, ok := s.roles[groupID+":"+name]; ok {
+		return role, nil
+	}
+	return acl.CustomRole{}, errors.New("not found")
+}
+
+func (s permissionStore) GetEntityDefaultPermission(entityID, action string) (entity.EntityDefaultPermission, error) {
+	return s.defaults[entityID+":"+action], nil
+}
+
+func TestCheckUserActionPermission_StoredMemberships(t *testing.T) {
+	store := permissionStore{
+		entities: map[string]*entity.Entity{
+			"orders":   {ID: "orders", GroupOwnerID: "g-pay", GroupOwner: "payments"},
+			"unowned":  {ID: "unowned"},
+			"payments": {ID: "payments", GroupOwnerID: "g-pay", GroupOwner: "payments"},
+		},
+		memberships: map[string][]acl.GroupRole{
+			// demoted from admin to a custom role after logging in
+			"alice": {{GroupID: "g-pay", GroupName: "payments", Role: "viewer"}},
+			// left the root group after logging in
+			"bob": {},
+			// joined the owner group after logging in
+			"carol": {{GroupID: "g-pay", GroupName: "payments", Role: acl.RoleGroupMember}},
+			"dave":  {{GroupID: "g-pay", GroupName: "payments", Role: acl.RoleGroupAdmin}},
+			"erin":  {{GroupID: "g-pay", GroupName: "payments", Role: acl.RoleGroupMember}},
+		},
+		roles: map[string]acl.CustomRole{
+			"g-pay:viewer": {Name: "viewer", Permissions: []string{"topic:tail"}},
+		},
+		grants: map[string]acl.PermissionMap{
+			"alice:payments:topic:delete": {UserID: "alice", EntityID: "payments", Action: "topic:delete", ExpiresAt: time.Now().Add(-time.Hour)},
+			"erin:orders:topic:delete":    {UserID: "erin", EntityID: "orders", Action: "topic:delete"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		user     *acl.User // Groups holds the claims of the token
+		entityID string
+		action   string
+		wantErr  bool
+	}{
+		{
+			name:     "stored custom role replaces the admin role of the token",
+			user:     &acl.User{ID: "alice", Groups: []acl.GroupRole{{GroupID: "g-pay", GroupName: "payments", Role: acl.RoleGroupAdmin}}},
+			entityID: "orders",
+			action:   "topic:delete",
+			wantErr:  true,
+		},
+		{
+			name:     "stored custom role still holds its own permissions",
+			user:     &acl.User{ID: "alice", Groups: []acl.GroupRole{{GroupID: "g-pay", GroupName: "payments", Role: acl.RoleGroupAdmin}}},
+			entityID: "orders",
+			action:   "topic:tail",
+		},
+		{
+			name:     "expired grant does not make up for the lost role",
+			user:     &acl.User{ID: "alice", Groups: []acl.GroupRole{{GroupID: "g-pay", GroupName: "payments", Role: acl.RoleGroupAdmin}}},
+			entityID: "payments",
+			action:   "topic:delete",
+			wantErr:  true,
+		},
+		{
+			name:     "root membership of the token is not trusted once removed",
+			user:     &acl.User{ID: "bob", Groups: []acl.GroupRole{{GroupID: "g-root", GroupName: acl.GroupRoot, Role: acl.RoleGroupAdmin}}},
+			entityID: "unowned",
+			action:   "topic:delete",
+			wantErr:  true,
+		},
+		{
+			name:     "membership added after login applies without a new token",
+			user:     &acl.User{ID: "carol"},
+			entityID: "orders",
+			action:   "topic:tail",
+		},
+		{
+			name:     "member of the owner group is refused topic:delete",
+			user:     &acl.User{ID: "carol"},
+			entityID: "orders",
+			action:   "topic:delete",
+			wantErr:  true,
+		},
+		{
+			name:     "member of the owner group is refused chan:empty",
+			user:     &acl.User{ID: "carol"},
+			entityID: "orders",
+			action:   "chan:empty",
+			wantErr:  true,
+		},
+		{
+			name:     "admin of the owner group may delete",
+			user:     &acl.User{ID: "dave"},
+			entityID: "orders",
+			action:   "topic:delete",
+		},
+		{
+			name:     "member with a grant may delete",
+			user:     &acl.User{ID: "erin"},
+			entityID: "orders",
+			action:   "topic:delete",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckUserActionPermission(tt.user, tt.entityID, tt.action, store)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected permission denied, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected allowed, got %v", err)
+			}
+		})
+	}
+}
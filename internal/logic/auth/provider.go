@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+)
+
+var (
+	// ErrUnknownUser tells the next provider of a chain to try, the provider doesn't know the user.
+	ErrUnknownUser     = errors.New("user not found")
+	ErrInvalidPassword = errors.New("invalid password")
+)
+
+// AuthProvider checks the credentials of a login and returns the matching topic-master user.
+type AuthProvider interface {
+	Authenticate(username, password string) (acl.User, error)
+}
+
+type ILocalProviderRepo interface {
+	GetUserByUsername(username string) (acl.User, error)
+}
+
+// LocalProvider checks the password hash stored with the user.
+type LocalProvider struct {
+	repo ILocalProviderRepo
+}
+
+func NewLocalProvider(repo ILocalProviderRepo) LocalProvider {
+	return LocalProvider{repo: repo}
+}
+
+func (p LocalProvider) Authenticate(username, password string) (acl.User, error) {
+	user, err := p.repo.GetUserByUsername(username)
+	if err != nil {
+		return acl.User{}, ErrUnknownUser
+	}
+	// users of a directory have no local password
+	if user.Source != "" && user.Source != acl.UserSourceLocal {
+		return acl.User{}, ErrUnknownUser
+	}
+	hash := sha256.Sum256([]byte(password))
+	if user.Password != hex.EncodeToString(hash[:]) {
+		return acl.User{}, ErrInvalidPassword
+	}
+	return user, nil
+}
+
+// ChainProvider asks its providers in order until one knows the user.
+type ChainProvider []AuthProvider
+
+func (c ChainProvider) Authenticate(username, password string) (acl.User, error) {
+	for _, p := range c {
+		user, err := p.Authenticate(username, password)
+		if errors.Is(err, ErrUnknownUser) {
+			continue
+		}
+		return user, err
+	}
+	return acl.User{}, ErrUnknownUser
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+)
+
+// IProvisionRepo stores the users of external identity providers.
+type IProvisionRepo interface {
+	GetUserByUsername(username string) (acl.User, error)
+	CreateUser(user acl.User) error
+	UpdateUser(user acl.User) error
+	GetGroupByName(name string) (acl.Group, error)
+	ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error)
+	CreateUserGroup(userGroup acl.UserGroup) error
+	DeleteUserGroup(id string) error
+}
+
+// ProvisionUser returns the user of an external identity, creating it on the first login.
+// A user of another source with the same name is never taken over.
+func ProvisionUser(repo IProvisionRepo, source, username, name string) (acl.User, error) {
+	if name == "" {
+		name = username
+	}
+	user, err := repo.GetUserByUsername(username)
+	switch {
+	case errors.Is(err, db.ErrNotFound):
+		now := time.Now()
+		user = acl.User{
+			ID:        uuid.NewString(),
+			Username:  username,
+			Name:      name,
+			Status:    acl.StatusUserActive,
+			Source:    source,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := repo.CreateUser(user); err != nil {
+			return acl.User{}, fmt.Errorf("failed to create user %s: %w", username, err)
+		}
+	case err != nil:
+		return acl.User{}, err
+	case user.Source != source:
+		return acl.User{}, fmt.Errorf("user %s is not managed by %s", username, source)
+	case user.Name != name:
+		user.Name = name
+		user.UpdatedAt = time.Now()
+		if err := repo.UpdateUser(user); err != nil {
+			return acl.User{}, fmt.Errorf("failed to update user %s: %w", username, err)
+		}
+	}
+	return user, nil
+}
+
+// SyncMappedGroups makes the memberships of the mapped groups follow the external groups of the user:
+// mapping maps an external group to a topic-master group name, external lists the groups the provider reports.
+// Memberships of groups outside the mapping are kept.
+func SyncMappedGroups(repo IProvisionRepo, user acl.User, mapping map[string]string, external []string) error {
+	if len(mapping) == 0 {
+		return nil
+	}
+	wanted := map[string]bool{}
+	for _, g := range external {
+		if name, ok := mapping[g]; ok {
+			wanted[name] = true
+		}
+	}
+
+	managed := map[string]acl.Group{} // group id -> group
+	for _, name := range mapping {
+		group, err := repo.GetGroupByName(name)
+		if err != nil {
+			log.Printf("mapped group %s not found: %v", name, err)
+			continue
+		}
+		managed[group.ID] = group
+	}
+
+	memberships, err := repo.ListUserGroupsByUserID(user.ID)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return err
+	}
+	current := map[string]bool{}
+	for _, ug := range memberships {
+		group, ok := managed[ug.GroupID]
+		if !ok {
+			continue
+		}
+		current[group.Name] = true
+		if wanted[group.Name] {
+			continue
+		}
+		if err := repo.DeleteUserGroup(ug.ID); err != nil {
+			return fmt.Errorf("failed to remove %s from %s: %w", user.Username, group.Name, err)
+		}
+	}
+
+	now := time.Now()
+	for _, group := range managed {
+		if !wanted[group.Name] || current[group.Name] {
+			continue
+		}
+		err := repo.CreateUserGroup(acl.UserGroup{
+			ID:        uuid.NewString(),
+			UserID:    user.ID,
+			GroupID:   group.ID,
+			Role:      acl.RoleGroupMember,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to %s: %w", user.Username, group.Name, err)
+		}
+	}
+	return nil
+}
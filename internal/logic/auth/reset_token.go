@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+)
+
+// NewResetToken creates a single use token for the reset password page. The token goes in the
+// link given to the user, the returned record only holds its hash.
+func NewResetToken(username, purpose string, ttl time.Duration, now time.Time) (string, acl.ResetPassword, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", acl.ResetPassword{}, err
+	}
+	token := hex.EncodeToString(b)
+	return token, acl.ResetPassword{
+		Token:     HashResetToken(token),
+		Username:  username,
+		Purpose:   purpose,
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}, nil
+}
+
+// HashResetToken is the key a reset token is stored under.
+func HashResetToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// ResetPasswordLink is the link of the reset password page for the token, relative when publicURL is empty.
+func ResetPasswordLink(publicURL, token string) string {
+	return strings.TrimSuffix(publicURL, "/") + "/reset-password?token=" + url.QueryEscape(token)
+}
+
+// ValidityText tells how long a link stays valid in the words of an email, e.g. "3 days".
+func ValidityText(ttl time.Duration) string {
+	plural := func(n int64, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+	switch {
+	case ttl >= 48*time.Hour && ttl%(24*time.Hour) == 0:
+		return plural(int64(ttl/(24*time.Hour)), "day")
+	case ttl >= time.Hour && ttl%time.Hour == 0:
+		return plural(int64(ttl/time.Hour), "hour")
+	default:
+		return plural(int64(ttl.Round(time.Minute)/time.Minute), "minute")
+	}
+}
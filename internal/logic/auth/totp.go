@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+)
+
+// TOTP as authenticator apps expect it (RFC 6238): HMAC-SHA1, 30 second steps, 6 digits.
+const (
+	TOTPIssuer = "Topic Master"
+	totpPeriod = 30
+	totpDigits = 6
+	// codes of the previous and next step are accepted too, clocks drift
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret returns a random 160 bit secret, base32 encoded.
+func NewTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPProvisioningURI is the otpauth:// URI shown as QR code to enrol an authenticator app.
+func TOTPProvisioningURI(account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", TOTPIssuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(totpDigits))
+	q.Set("period", fmt.Sprint(totpPeriod))
+	label := url.PathEscape(TOTPIssuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// TOTPCode is the code of the secret at the given time step.
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
+
+// VerifyTOTP checks a code against the steps around now and returns the matching step.
+// Steps up to lastUsed are refused so an intercepted code can't be replayed.
+func VerifyTOTP(secret, code string, now time.Time, lastUsed int64) (int64, bool) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastUsed {
+			continue
+		}
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// NewRecoveryCodes returns n single use codes to show once, and their hashes to store.
+func NewRecoveryCodes(n int) (codes []string, hashes []string, err error) {
+	for i := 0; i < n; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(b)
+		code := raw[:5] + "-" + raw[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
+
+// UseRecoveryCode removes the code from the stored hashes, it reports false when the code is unknown.
+func UseRecoveryCode(totp *acl.UserTOTP, code string) bool {
+	hashed := HashRecoveryCode(code)
+	for i, h := range totp.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hashed)) == 1 {
+			totp.RecoveryCodes = append(totp.RecoveryCodes[:i:i], totp.RecoveryCodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresTwoFactor tells whether the policy forces a user of these groups to use 2FA.
+func RequiresTwoFactor(policy string, groups []acl.GroupRole) bool {
+	for _, g := range groups {
+		switch policy {
+		case config.Require2FARoot:
+			if g.GroupName == acl.GroupRoot {
+				return true
+			}
+		case config.Require2FAAdmins:
+			if g.GroupName == acl.GroupRoot || g.Role == acl.RoleGroupAdmin {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+)
+
+// base32 of the RFC 6238 SHA1 test secret "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// the RFC vectors are 8 digits, authenticator apps show the last 6
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		got, err := TOTPCode(rfcSecret, tt.unix/30)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / 30
+	code := func(step int64) string {
+		c, _ := TOTPCode(rfcSecret, step)
+		return c
+	}
+
+	if got, ok := VerifyTOTP(rfcSecret, code(step), now, 0); !ok || got != step {
+		t.Errorf("current code: step %d ok %v", got, ok)
+	}
+	if _, ok := VerifyTOTP(rfcSecret, code(step-1), now, 0); !ok {
+		t.Error("previous step should be accepted for clock drift")
+	}
+	if _, ok := VerifyTOTP(rfcSecret, code(step-2), now, 0); ok {
+		t.Error("code two steps old should be refused")
+	}
+	if _, ok := VerifyTOTP(rfcSecret, code(step), now, step); ok {
+		t.Error("a used code should not be accepted again")
+	}
+	if _, ok := VerifyTOTP(rfcSecret, "12345", now, 0); ok {
+		t.Error("short code should be refused")
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	totp := acl.UserTOTP{RecoveryCodes: hashes}
+	if !UseRecoveryCode(&totp, " "+codes[1]+" ") {
+		t.Fatal("recovery code should be accepted")
+	}
+	if UseRecoveryCode(&totp, codes[1]) {
+		t.Error("recovery code should be single use")
+	}
+	if len(totp.RecoveryCodes) != 2 || len(hashes) != 3 {
+		t.Errorf("remaining codes = %d, stored slice changed to %d", len(totp.RecoveryCodes), len(hashes))
+	}
+}
+
+func TestRequiresTwoFactor(t *testing.T) {
+	root := []acl.GroupRole{{GroupName: acl.GroupRoot, Role: acl.RoleGroupMember}}
+	admin := []acl.GroupRole{{GroupName: "payments", Role: acl.RoleGroupAdmin}}
+	member := []acl.GroupRole{{GroupName: "payments", Role: acl.RoleGroupMember}}
+
+	tests := []struct {
+		policy string
+		groups []acl.GroupRole
+		want   bool
+	}{
+		{"", root, false},
+		{config.Require2FARoot, root, true},
+		{config.Require2FARoot, admin, false},
+		{config.Require2FAAdmins, root, true},
+		{config.Require2FAAdmins, admin, true},
+		{config.Require2FAAdmins, member, false},
+	}
+	for _, tt := range tests {
+		if got := RequiresTwoFactor(tt.policy, tt.groups); got != tt.want {
+			t.Errorf("RequiresTwoFactor(%q, %+v) = %v, want %v", tt.policy, tt.groups, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+// FileSender writes every email as a .eml file in a directory instead of sending it,
+// so invitation and reset links can be followed without a mail server.
+type FileSender struct {
+	dir string
+	seq atomic.Uint64
+}
+
+func NewFileSender(dir string) *FileSender {
+	return &FileSender{dir: dir}
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]+`)
+
+func (s *FileSender) Send(m Mail) error {
+	if m.To == "" {
+		return errors.New("no recipient address")
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	now := time.Now()
+	name := fmt.Sprintf("%s-%03d-%s.eml", now.Format("20060102T150405"), s.seq.Add(1)%1000, unsafeFileChars.ReplaceAllString(m.To, "_"))
+	// the links in the body are credentials, the files are only readable by the owner
+	return os.WriteFile(filepath.Join(s.dir, name), message("topic-master", m, now.Format(time.RFC1123Z)), 0o600)
+}
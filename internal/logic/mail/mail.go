@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"strings"
+
+	"github.com/jekiapp/topic-master/internal/config"
+)
+
+// Mail is a plain text email to one recipient.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers emails, through SMTP or to a directory during development.
+type Sender interface {
+	Send(m Mail) error
+}
+
+// NewSender returns the SMTP sender when a server is configured, else the file sink when a mail
+// directory is set. It returns nil when emails are disabled.
+func NewSender(cfg *config.Config) Sender {
+	if cfg.SMTP.Addr != "" {
+		return NewSMTPSender(cfg.SMTP)
+	}
+	if cfg.MailDir != "" {
+		return NewFileSender(cfg.MailDir)
+	}
+	return nil
+}
+
+// headerValue drops line breaks so a value cannot inject extra headers
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", " ").Replace(value)
+}
+
+// message renders the mail with its headers, as sent over SMTP and written by the file sink
+func message(from string, m Mail, date string) []byte {
+	return []byte(strings.Join([]string{
+		"From: " + headerValue(from),
+		"To: " + headerValue(m.To),
+		"Subject: " + headerValue(m.Subject),
+		"Date: " + date,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		m.Body,
+	}, "\r\n"))
+}
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+)
+
+// SMTPSender sends the emails through the configured mail server.
+type SMTPSender struct {
+	cfg config.SMTPConfig
+}
+
+func NewSMTPSender(cfg config.SMTPConfig) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+func (s *SMTPSender) Send(m Mail) error {
+	if m.To == "" {
+		return errors.New("no recipient address")
+	}
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		host, _, err := net.SplitHostPort(s.cfg.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, host)
+	}
+	data := message(s.cfg.From, m, time.Now().Format(time.RFC1123Z))
+	if err := smtp.SendMail(s.cfg.Addr, auth, s.cfg.From, []string{m.To}, data); err != nil {
+		return fmt.Errorf("smtp: %v", err)
+	}
+	return nil
+}
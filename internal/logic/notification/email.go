@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"errors"
+
+	"github.com/jekiapp/topic-master/internal/logic/mail"
+	model "github.com/jekiapp/topic-master/internal/model/notification"
+)
+
+// EmailSender sends plain text emails to the address of the recipient preference.
+type EmailSender struct {
+	mail mail.Sender
+}
+
+func NewEmailSender(sender mail.Sender) *EmailSender {
+	return &EmailSender{mail: sender}
+}
+
+func (s *EmailSender) Send(pref model.Preference, msg Message) error {
+	if pref.Email == "" {
+		return errors.New("no email address in preference")
+	}
+	body := msg.Body
+	if msg.Link != "" {
+		body += "\r\n\r\n" + msg.Link
+	}
+	return s.mail.Send(mail.Mail{To: pref.Email, Subject: msg.Subject, Body: body})
+}
@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	model "github.com/jekiapp/topic-master/internal/model/notification"
+)
+
+// TicketEvent builds the event of a ticket with the wording of its type.
+func TicketEvent(eventType string, app acl.Application, recipientIDs ...string) Event {
+	event := Event{
+		Type:          eventType,
+		ApplicationID: app.ID,
+		RecipientIDs:  recipientIDs,
+	}
+	switch eventType {
+	case model.EventTicketCreated:
+		event.Subject = "Ticket submitted: " + app.Title
+		event.Body = fmt.Sprintf("Your application %q was submitted and is waiting for approval.", app.Title)
+	case model.EventTicketAssigned:
+		event.Subject = "Review requested: " + app.Title
+		event.Body = fmt.Sprintf("The application %q is waiting for your review.", app.Title)
+	case model.EventTicketApproved:
+		event.Subject = "Ticket approved: " + app.Title
+		event.Body = fmt.Sprintf("Your application %q was approved.", app.Title)
+	case model.EventTicketRejected:
+		event.Subject = "Ticket rejected: " + app.Title
+		event.Body = fmt.Sprintf("Your application %q was rejected.", app.Title)
+	case model.EventTicketReminder:
+		event.Subject = "Reminder: " + app.Title
+		event.Body = fmt.Sprintf("The application %q is nearing its SLA and still waits for your review.", app.Title)
+	}
+	return event
+}
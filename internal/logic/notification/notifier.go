@@ -0,0 +1,151 @@
+package notification
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/mail"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	model "github.com/jekiapp/topic-master/internal/model/notification"
+	repo "github.com/jekiapp/topic-master/internal/repository/notification"
+	"github.com/tidwall/buntdb"
+)
+
+// queueSize bounds the events waiting for delivery, events are dropped when the queue is full
+const queueSize = 256
+
+// Event is something that happened on a ticket, delivered to every recipient on the channels they chose.
+type Event struct {
+	Type          string
+	ApplicationID string
+	Subject       string
+	Body          string
+	RecipientIDs  []string
+}
+
+// Message is an event rendered for one channel
+type Message struct {
+	Event         string
+	ApplicationID string
+	Subject       string
+	Body          string
+	Link          string
+}
+
+// Sender delivers messages outside of the app, to the address found in the recipient preference.
+type Sender interface {
+	Send(pref model.Preference, msg Message) error
+}
+
+// Dispatcher stores inbox notifications and hands the other channels to their sender.
+type Dispatcher struct {
+	db        *buntdb.DB
+	senders   map[string]Sender
+	publicURL string
+	queue     chan Event
+}
+
+var dispatcher *Dispatcher
+
+// Init starts the dispatcher used by Publish. Email is only enabled when an SMTP server or a mail directory is configured.
+func Init(cfg *config.Config, db *buntdb.DB) {
+	senders := map[string]Sender{
+		model.ChannelWebhook: NewWebhookSender(),
+	}
+	if sender := mail.NewSender(cfg); sender != nil {
+		senders[model.ChannelEmail] = NewEmailSender(sender)
+	}
+	dispatcher = NewDispatcher(db, senders, cfg.PublicURL)
+	go dispatcher.run()
+}
+
+func NewDispatcher(db *buntdb.DB, senders map[string]Sender, publicURL string) *Dispatcher {
+	return &Dispatcher{
+		db:        db,
+		senders:   senders,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		queue:     make(chan Event, queueSize),
+	}
+}
+
+// Publish queues the event for delivery without blocking the caller.
+// It does nothing until Init is called, e.g. in unit tests.
+func Publish(event Event) {
+	if dispatcher == nil || len(event.RecipientIDs) == 0 {
+		return
+	}
+	select {
+	case dispatcher.queue <- event:
+	default:
+		log.Printf("[NOTIFICATION] queue is full, dropping %s event of ticket %s", event.Type, event.ApplicationID)
+	}
+}
+
+func (d *Dispatcher) run() {
+	for event := range d.queue {
+		d.Deliver(event)
+	}
+}
+
+// Deliver sends the event to each recipient, failures of a channel are logged and do not stop the others.
+func (d *Dispatcher) Deliver(event Event) {
+	msg := Message{
+		Event:         event.Type,
+		ApplicationID: event.ApplicationID,
+		Subject:       event.Subject,
+		Body:          event.Body,
+		Link:          d.ticketLink(event.ApplicationID),
+	}
+	seen := map[string]bool{}
+	for _, userID := range event.RecipientIDs {
+		if userID == "" || userID == acl.ActorSystem || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		pref, err := repo.GetPreference(d.db, userID)
+		if err != nil {
+			log.Printf("[NOTIFICATION] error getting preference of %s: %s", userID, err)
+			continue
+		}
+		for _, channel := range pref.ChannelsFor(event.Type) {
+			if channel == model.ChannelInbox {
+				d.addToInbox(userID, event)
+				continue
+			}
+			sender, ok := d.senders[channel]
+			if !ok {
+				continue
+			}
+			if err := sender.Send(pref, msg); err != nil {
+				log.Printf("[NOTIFICATION] error sending %s to %s by %s: %s", event.Type, userID, channel, err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) addToInbox(userID string, event Event) {
+	n := model.Notification{
+		ID:            uuid.NewString(),
+		UserID:        userID,
+		Event:         event.Type,
+		Subject:       event.Subject,
+		Body:          event.Body,
+		ApplicationID: event.ApplicationID,
+		CreatedAt:     time.Now(),
+	}
+	if err := repo.CreateNotification(d.db, n); err != nil {
+		log.Printf("[NOTIFICATION] error adding notification to the inbox of %s: %s", userID, err)
+	}
+}
+
+func (d *Dispatcher) ticketLink(applicationID string) string {
+	if applicationID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/#ticket-detail?id=%s", d.publicURL, applicationID)
+}
@@ -0,0 +1,132 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	model "github.com/jekiapp/topic-master/internal/model/notification"
+)
+
+// WebhookSender posts Slack-compatible payloads to the webhook URL of the recipient preference.
+// Besides the Slack "text" field, the payload carries the raw event for other consumers.
+type WebhookSender struct {
+	client *http.Client
+}
+
+type webhookPayload struct {
+	Text          string `json:"text"`
+	Event         string `json:"event"`
+	ApplicationID string `json:"application_id"`
+	Subject       string `json:"subject"`
+	Body          string `json:"body"`
+	Link          string `json:"link,omitempty"`
+}
+
+var errWebhookAddress = errors.New("webhook url must not point to a private, loopback or link-local address")
+
+func NewWebhookSender() *WebhookSender {
+	// the address is checked again once resolved, a name may point elsewhere than when it was saved
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("%w: %s", errWebhookAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := &http.Transport{
+		// no proxy from the environment, the dialer would only check the address of the proxy
+		Proxy:               nil,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	}
+	return &WebhookSender{client: &http.Client{Timeout: 10 * time.Second, Transport: transport}}
+}
+
+// ValidateWebhookURL accepts http and https urls whose host is not a private, loopback or link-local address.
+// Names are resolved when possible; one that does not resolve yet is left to the check of WebhookSender when it dials.
+func ValidateWebhookURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("webhook url must be an http or https url")
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errWebhookAddress
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if !isPublicIP(ip) {
+			return errWebhookAddress
+		}
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return errWebhookAddress
+		}
+	}
+	return nil
+}
+
+// isPublicIP is false for the loopback, private, link-local, unspecified and multicast addresses
+// and for the shared address space of carrier-grade NAT.
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 100 && ip4[1]&0xc0 == 64 {
+		return false
+	}
+	return true
+}
+
+func (s *WebhookSender) Send(pref model.Preference, msg Message) error {
+	if pref.WebhookURL == "" {
+		return errors.New("no webhook url in preference")
+	}
+	text := fmt.Sprintf("*%s*\n%s", msg.Subject, msg.Body)
+	if msg.Link != "" {
+		text += fmt.Sprintf("\n<%s|Open ticket>", msg.Link)
+	}
+	payload, err := json.Marshal(webhookPayload{
+		Text:          text,
+		Event:         msg.Event,
+		ApplicationID: msg.ApplicationID,
+		Subject:       msg.Subject,
+		Body:          msg.Body,
+		Link:          msg.Link,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(pref.WebhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/jekiapp/topic-master/internal/model/notification"
+)
+
+func TestWebhookSender_RefusesPrivateAddresses(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	// the preference may have been saved with a name resolving to a public address at the time
+	err := NewWebhookSender().Send(model.Preference{WebhookURL: srv.URL}, Message{Subject: "s", Body: "b"})
+	if !errors.Is(err, errWebhookAddress) {
+		t.Fatalf("error = %v, want %v", err, errWebhookAddress)
+	}
+	if called {
+		t.Error("webhook on a loopback address was called")
+	}
+}
@@ -0,0 +1,216 @@
+package topic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/golang/snappy"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// maxDecompressedSize guards against compression bombs in tailed messages.
+const maxDecompressedSize = 16 << 20
+
+const (
+	PayloadEncoding_Text   = "text"
+	PayloadEncoding_JSON   = "json"
+	PayloadEncoding_Hex    = "hex"
+	PayloadEncoding_Base64 = "base64"
+)
+
+// DecodedPayload is a message body rendered for display.
+// Encoding tells the client how to read Payload; binary data never travels as a raw string.
+type DecodedPayload struct {
+	Payload  string `json:"payload"`
+	Encoding string `json:"encoding"`
+	Error    string `json:"decode_error,omitempty"`
+	Masked   bool   `json:"masked,omitempty"`
+}
+
+// MessageDecoder renders message bodies of one topic, built once per session from its TopicDecoder.
+type MessageDecoder struct {
+	compression string
+	decoder     string
+	messageType protoreflect.MessageDescriptor
+}
+
+// DefaultMessageDecoder renders valid UTF-8 as text and anything else as base64.
+func DefaultMessageDecoder() *MessageDecoder {
+	return &MessageDecoder{decoder: entity.Decoder_Text}
+}
+
+// NewMessageDecoder validates the configuration and prepares the protobuf descriptor if any.
+func NewMessageDecoder(cfg entity.TopicDecoder) (*MessageDecoder, error) {
+	d := &MessageDecoder{compression: cfg.Compression, decoder: cfg.Decoder}
+	if d.decoder == "" {
+		d.decoder = entity.Decoder_Text
+	}
+
+	switch d.compression {
+	case entity.Compression_None, entity.Compression_Gzip, entity.Compression_Snappy:
+	default:
+		return nil, fmt.Errorf("unknown compression %q", cfg.Compression)
+	}
+
+	switch d.decoder {
+	case entity.Decoder_Text, entity.Decoder_Hex, entity.Decoder_Base64, entity.Decoder_Msgpack:
+	case entity.Decoder_Protobuf:
+		md, err := parseMessageDescriptor(cfg.Descriptor, cfg.MessageType)
+		if err != nil {
+			return nil, err
+		}
+		d.messageType = md
+	default:
+		return nil, fmt.Errorf("unknown decoder %q", cfg.Decoder)
+	}
+	return d, nil
+}
+
+// parseMessageDescriptor finds the message type in a serialized FileDescriptorSet.
+func parseMessageDescriptor(descriptor []byte, messageType string) (protoreflect.MessageDescriptor, error) {
+	if len(descriptor) == 0 || messageType == "" {
+		return nil, errors.New("protobuf decoder requires a descriptor set and a message type")
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(descriptor, set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set: %v", err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set (generate it with protoc --include_imports): %v", err)
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(messageType))
+	if err != nil {
+		return nil, fmt.Errorf("message type %s not found in descriptor set", messageType)
+	}
+	md, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message type", messageType)
+	}
+	return md, nil
+}
+
+// Decode never fails: when the body cannot be decoded it is returned as base64 with the reason.
+func (d *MessageDecoder) Decode(body []byte) DecodedPayload {
+	data, err := d.decompress(body)
+	if err != nil {
+		return rawPayload(body, err)
+	}
+
+	switch d.decoder {
+	case entity.Decoder_Hex:
+		return DecodedPayload{Payload: hex.EncodeToString(data), Encoding: PayloadEncoding_Hex}
+	case entity.Decoder_Base64:
+		return DecodedPayload{Payload: base64.StdEncoding.EncodeToString(data), Encoding: PayloadEncoding_Base64}
+	case entity.Decoder_Msgpack:
+		dec := msgpack.NewDecoder(bytes.NewReader(data))
+		// keep maps untyped, msgpack keys are not necessarily strings
+		dec.SetMapDecoder(func(d *msgpack.Decoder) (interface{}, error) {
+			return d.DecodeUntypedMap()
+		})
+		value, err := dec.DecodeInterface()
+		if err != nil {
+			return rawPayload(body, err)
+		}
+		jsonValue, err := json.Marshal(normalizeMsgpack(value))
+		if err != nil {
+			return rawPayload(body, fmt.Errorf("msgpack: %v", err))
+		}
+		return DecodedPayload{Payload: string(jsonValue), Encoding: PayloadEncoding_JSON}
+	case entity.Decoder_Protobuf:
+		msg := dynamicpb.NewMessage(d.messageType)
+		if err := proto.Unmarshal(data, msg); err != nil {
+			return rawPayload(body, fmt.Errorf("protobuf: %v", err))
+		}
+		jsonValue, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+		if err != nil {
+			return rawPayload(body, fmt.Errorf("protobuf: %v", err))
+		}
+		return DecodedPayload{Payload: string(jsonValue), Encoding: PayloadEncoding_JSON}
+	default:
+		if utf8.Valid(data) {
+			return DecodedPayload{Payload: string(data), Encoding: PayloadEncoding_Text}
+		}
+		return DecodedPayload{Payload: base64.StdEncoding.EncodeToString(data), Encoding: PayloadEncoding_Base64}
+	}
+}
+
+func (d *MessageDecoder) decompress(body []byte) ([]byte, error) {
+	switch d.compression {
+	case entity.Compression_Gzip:
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		data, err := io.ReadAll(io.LimitReader(reader, maxDecompressedSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxDecompressedSize {
+			return nil, errors.New("gzip: decompressed payload too large")
+		}
+		return data, nil
+	case entity.Compression_Snappy:
+		size, err := snappy.DecodedLen(body)
+		if err != nil {
+			return nil, err
+		}
+		if size > maxDecompressedSize {
+			return nil, errors.New("snappy: decompressed payload too large")
+		}
+		data, err := snappy.Decode(nil, body)
+		if err != nil {
+			return nil, err
+		}
+		return data, nil
+	default:
+		return body, nil
+	}
+}
+
+func rawPayload(body []byte, err error) DecodedPayload {
+	return DecodedPayload{
+		Payload:  base64.StdEncoding.EncodeToString(body),
+		Encoding: PayloadEncoding_Base64,
+		Error:    err.Error(),
+	}
+}
+
+// normalizeMsgpack converts maps with non-string keys so the value can be encoded as JSON.
+func normalizeMsgpack(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeMsgpack(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[fmt.Sprint(key)] = normalizeMsgpack(item)
+		}
+		return out
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeMsgpack(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
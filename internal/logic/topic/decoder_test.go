@@ -0,0 +1,198 @@
+package topic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// orderDescriptor is the FileDescriptorSet of
+//
+//	package shop; message Order { string id = 1; int64 amount = 2; }
+func orderDescriptor(t *testing.T) []byte {
+	t.Helper()
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("shop/order.proto"),
+		Package: proto.String("shop"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("id"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), JsonName: proto.String("id")},
+				{Name: proto.String("amount"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), JsonName: proto.String("amount")},
+			},
+		}},
+	}
+	raw, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{file}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNewMessageDecoder(t *testing.T) {
+	descriptor := orderDescriptor(t)
+	tests := []struct {
+		name    string
+		cfg     entity.TopicDecoder
+		wantErr string
+	}{
+		{name: "default text", cfg: entity.TopicDecoder{}},
+		{name: "msgpack with snappy", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Msgpack, Compression: entity.Compression_Snappy}},
+		{name: "protobuf", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, Descriptor: descriptor, MessageType: "shop.Order"}},
+		{name: "unknown compression", cfg: entity.TopicDecoder{Compression: "lz4"}, wantErr: `unknown compression "lz4"`},
+		{name: "unknown decoder", cfg: entity.TopicDecoder{Decoder: "avro"}, wantErr: `unknown decoder "avro"`},
+		{name: "protobuf without descriptor", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, MessageType: "shop.Order"}, wantErr: "requires a descriptor set"},
+		{name: "protobuf with a corrupt descriptor", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, Descriptor: []byte{0xff, 0xff}, MessageType: "shop.Order"}, wantErr: "invalid descriptor set"},
+		{name: "protobuf with an unknown type", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, Descriptor: descriptor, MessageType: "shop.Refund"}, wantErr: "shop.Refund not found"},
+		{name: "protobuf with a package name", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, Descriptor: descriptor, MessageType: "shop"}, wantErr: "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewMessageDecoder(tt.cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, d)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, d)
+		})
+	}
+}
+
+func TestMessageDecoder_Decode(t *testing.T) {
+	descriptor := orderDescriptor(t)
+	md, err := parseMessageDescriptor(descriptor, "shop.Order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := dynamicpb.NewMessage(md)
+	order.Set(md.Fields().ByName("id"), protoreflect.ValueOfString("o-1"))
+	order.Set(md.Fields().ByName("amount"), protoreflect.ValueOfInt64(1250))
+	orderBytes, err := proto.Marshal(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgpackBytes, err := msgpack.Marshal(map[string]interface{}{"id": "o-1", "items": []int{1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// msgpack maps may have integer keys, JSON only has strings
+	intKeys, err := msgpack.Marshal(map[int]string{1: "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonBody := []byte(`{"id":"o-1"}`)
+	binaryBody := []byte{0x00, 0xff, 0xfe}
+	gz := gzipped(t, jsonBody)
+	// a snappy block announcing more than the decompression limit
+	snappyBomb := binary.AppendUvarint(nil, maxDecompressedSize+1)
+	snappyBomb = append(snappyBomb, 0x00)
+
+	tests := []struct {
+		name        string
+		cfg         entity.TopicDecoder
+		body        []byte
+		want        DecodedPayload
+		wantErrPart string // decode error, the body then comes back as base64
+	}{
+		{name: "utf-8 text", body: jsonBody, want: DecodedPayload{Payload: string(jsonBody), Encoding: PayloadEncoding_Text}},
+		{name: "binary falls back to base64", body: binaryBody, want: DecodedPayload{Payload: base64.StdEncoding.EncodeToString(binaryBody), Encoding: PayloadEncoding_Base64}},
+		{name: "hex", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Hex}, body: binaryBody, want: DecodedPayload{Payload: "00fffe", Encoding: PayloadEncoding_Hex}},
+		{name: "base64", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Base64}, body: jsonBody, want: DecodedPayload{Payload: base64.StdEncoding.EncodeToString(jsonBody), Encoding: PayloadEncoding_Base64}},
+		{name: "gzip", cfg: entity.TopicDecoder{Compression: entity.Compression_Gzip}, body: gz, want: DecodedPayload{Payload: string(jsonBody), Encoding: PayloadEncoding_Text}},
+		{name: "snappy", cfg: entity.TopicDecoder{Compression: entity.Compression_Snappy}, body: snappy.Encode(nil, jsonBody), want: DecodedPayload{Payload: string(jsonBody), Encoding: PayloadEncoding_Text}},
+		{name: "msgpack", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Msgpack}, body: msgpackBytes, want: DecodedPayload{Payload: `{"id":"o-1","items":[1,2]}`, Encoding: PayloadEncoding_JSON}},
+		{name: "msgpack with integer keys", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Msgpack}, body: intKeys, want: DecodedPayload{Payload: `{"1":"one"}`, Encoding: PayloadEncoding_JSON}},
+		{name: "msgpack in gzip", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Msgpack, Compression: entity.Compression_Gzip}, body: gzipped(t, msgpackBytes), want: DecodedPayload{Payload: `{"id":"o-1","items":[1,2]}`, Encoding: PayloadEncoding_JSON}},
+		{
+			name: "protobuf",
+			cfg:  entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, Descriptor: descriptor, MessageType: "shop.Order"},
+			body: orderBytes,
+			want: DecodedPayload{Payload: `{"id":"o-1","amount":"1250"}`, Encoding: PayloadEncoding_JSON},
+		},
+		{
+			name: "protobuf in snappy",
+			cfg:  entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, Descriptor: descriptor, MessageType: "shop.Order", Compression: entity.Compression_Snappy},
+			body: snappy.Encode(nil, orderBytes),
+			want: DecodedPayload{Payload: `{"id":"o-1","amount":"1250"}`, Encoding: PayloadEncoding_JSON},
+		},
+
+		{name: "gzip header only", cfg: entity.TopicDecoder{Compression: entity.Compression_Gzip}, body: []byte{0x1f}, wantErrPart: "EOF"},
+		{name: "not gzip", cfg: entity.TopicDecoder{Compression: entity.Compression_Gzip}, body: jsonBody, wantErrPart: "gzip: invalid header"},
+		{name: "truncated gzip", cfg: entity.TopicDecoder{Compression: entity.Compression_Gzip}, body: gz[:len(gz)-6], wantErrPart: "EOF"},
+		{name: "corrupt snappy", cfg: entity.TopicDecoder{Compression: entity.Compression_Snappy}, body: []byte{0x0a, 0xff, 0xff}, wantErrPart: "snappy: corrupt input"},
+		{name: "empty snappy", cfg: entity.TopicDecoder{Compression: entity.Compression_Snappy}, body: nil, wantErrPart: "snappy: corrupt input"},
+		{name: "snappy bomb", cfg: entity.TopicDecoder{Compression: entity.Compression_Snappy}, body: snappyBomb, wantErrPart: "decompressed payload too large"},
+		{name: "truncated msgpack", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Msgpack}, body: msgpackBytes[:len(msgpackBytes)-2], wantErrPart: "EOF"},
+		{name: "empty msgpack", cfg: entity.TopicDecoder{Decoder: entity.Decoder_Msgpack}, body: []byte{}, wantErrPart: "EOF"},
+		{
+			name:        "truncated protobuf",
+			cfg:         entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, Descriptor: descriptor, MessageType: "shop.Order"},
+			body:        orderBytes[:len(orderBytes)-1],
+			wantErrPart: "protobuf:",
+		},
+		{
+			name:        "protobuf with an invalid field number",
+			cfg:         entity.TopicDecoder{Decoder: entity.Decoder_Protobuf, Descriptor: descriptor, MessageType: "shop.Order"},
+			body:        []byte{0x00, 0x01}, // field 0 does not exist on the wire
+			wantErrPart: "protobuf:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewMessageDecoder(tt.cfg)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var got DecodedPayload
+			assert.NotPanics(t, func() { got = d.Decode(tt.body) })
+			if tt.wantErrPart != "" {
+				assert.Contains(t, got.Error, tt.wantErrPart)
+				assert.Equal(t, PayloadEncoding_Base64, got.Encoding)
+				assert.Equal(t, base64.StdEncoding.EncodeToString(tt.body), got.Payload)
+				return
+			}
+			if tt.want.Encoding == PayloadEncoding_JSON {
+				assert.JSONEq(t, tt.want.Payload, got.Payload)
+				tt.want.Payload = got.Payload
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMessageDecoder_GzipBomb(t *testing.T) {
+	d, err := NewMessageDecoder(entity.TopicDecoder{Compression: entity.Compression_Gzip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := d.Decode(gzipped(t, make([]byte, maxDecompressedSize+1)))
+	assert.Equal(t, "gzip: decompressed payload too large", got.Error)
+}
@@ -0,0 +1,169 @@
+package topic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jekiapp/topic-master/internal/model/entity"
+)
+
+// maxMaskRules bounds the work done for every tailed message.
+const maxMaskRules = 50
+
+type jsonPathRule struct {
+	path        []string
+	replacement string
+}
+
+type regexRule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// PayloadMasker applies the masking rules of one topic to decoded payloads.
+// JSON path rules run first on JSON documents, then regex rules on the resulting text.
+type PayloadMasker struct {
+	jsonRules  []jsonPathRule
+	regexRules []regexRule
+}
+
+// NewPayloadMasker validates and compiles the rules. It returns nil when there is nothing to mask.
+func NewPayloadMasker(rules []entity.MaskRule) (*PayloadMasker, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if len(rules) > maxMaskRules {
+		return nil, fmt.Errorf("at most %d masking rules are allowed", maxMaskRules)
+	}
+
+	m := &PayloadMasker{}
+	for i, rule := range rules {
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = entity.MaskDefaultReplacement
+		}
+		switch rule.Type {
+		case entity.MaskRule_JSONPath:
+			path, err := parseJSONPath(rule.Expression)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: %v", i+1, err)
+			}
+			m.jsonRules = append(m.jsonRules, jsonPathRule{path: path, replacement: replacement})
+		case entity.MaskRule_Regex:
+			if rule.Expression == "" {
+				return nil, fmt.Errorf("rule %d: regex is empty", i+1)
+			}
+			re, err := regexp.Compile(rule.Expression)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d: invalid regex: %v", i+1, err)
+			}
+			m.regexRules = append(m.regexRules, regexRule{re: re, replacement: replacement})
+		default:
+			return nil, fmt.Errorf("rule %d: unknown rule type %q", i+1, rule.Type)
+		}
+	}
+	return m, nil
+}
+
+// parseJSONPath accepts dotted paths with an optional `$.` prefix; `*` matches every key or array element.
+func parseJSONPath(expression string) ([]string, error) {
+	expression = strings.TrimPrefix(strings.TrimPrefix(expression, "$"), ".")
+	if expression == "" {
+		return nil, errors.New("JSON path is empty")
+	}
+	path := strings.Split(expression, ".")
+	for _, part := range path {
+		if part == "" {
+			return nil, fmt.Errorf("invalid JSON path %q", expression)
+		}
+	}
+	return path, nil
+}
+
+// Mask returns the payload with the rules applied.
+// Payloads the rules cannot be applied to (hex, base64 or undecodable data, or text that is not
+// a JSON document while JSON path rules are set) are withheld entirely,
+// so sensitive data never leaves masked topics in a form the rules did not see.
+func (m *PayloadMasker) Mask(p DecodedPayload) DecodedPayload {
+	if m == nil {
+		return p
+	}
+	if p.Encoding != PayloadEncoding_Text && p.Encoding != PayloadEncoding_JSON {
+		return withheldPayload(fmt.Sprintf("masking rules cannot be applied to %s data", p.Encoding))
+	}
+
+	payload := p.Payload
+	if len(m.jsonRules) > 0 {
+		masked, ok := m.maskJSON(payload)
+		if !ok {
+			return withheldPayload("JSON path rules cannot be applied to a payload that is not a JSON document")
+		}
+		payload = masked
+	}
+	for _, rule := range m.regexRules {
+		payload = rule.re.ReplaceAllString(payload, rule.replacement)
+	}
+
+	p.Payload = payload
+	p.Masked = true
+	return p
+}
+
+func withheldPayload(reason string) DecodedPayload {
+	return DecodedPayload{
+		Encoding: PayloadEncoding_Text,
+		Error:    "payload withheld: " + reason,
+		Masked:   true,
+	}
+}
+
+// maskJSON applies the JSON path rules; ok is false when the payload is not a JSON document.
+func (m *PayloadMasker) maskJSON(payload string) (string, bool) {
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	// keep numbers as written, float64 would round large IDs
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return "", false
+	}
+	for _, rule := range m.jsonRules {
+		doc = maskJSONPath(doc, rule.path, rule.replacement)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), true
+}
+
+func maskJSONPath(value interface{}, path []string, replacement string) interface{} {
+	if len(path) == 0 {
+		return replacement
+	}
+	key, rest := path[0], path[1:]
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if key == "*" || key == k {
+				v[k] = maskJSONPath(item, rest, replacement)
+			}
+		}
+	case []interface{}:
+		if key == "*" {
+			for i, item := range v {
+				v[i] = maskJSONPath(item, rest, replacement)
+			}
+		} else if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(v) {
+			v[i] = maskJSONPath(v[i], rest, replacement)
+		}
+	}
+	return value
+}
@@ -0,0 +1,185 @@
+package topic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPayloadMasker(t *testing.T) {
+	tooMany := make([]entity.MaskRule, maxMaskRules+1)
+	for i := range tooMany {
+		tooMany[i] = entity.MaskRule{Type: entity.MaskRule_Regex, Expression: "x"}
+	}
+
+	tests := []struct {
+		name    string
+		rules   []entity.MaskRule
+		wantNil bool
+		wantErr string
+	}{
+		{name: "no rules", wantNil: true},
+		{name: "json path", rules: []entity.MaskRule{{Type: entity.MaskRule_JSONPath, Expression: "$.user.email"}}},
+		{name: "regex", rules: []entity.MaskRule{{Type: entity.MaskRule_Regex, Expression: `\d{16}`}}},
+		{name: "invalid regex", rules: []entity.MaskRule{{Type: entity.MaskRule_Regex, Expression: "(unclosed"}}, wantErr: "rule 1: invalid regex"},
+		{name: "empty regex", rules: []entity.MaskRule{{Type: entity.MaskRule_Regex}}, wantErr: "rule 1: regex is empty"},
+		{name: "empty JSON path", rules: []entity.MaskRule{{Type: entity.MaskRule_JSONPath, Expression: "$."}}, wantErr: "JSON path is empty"},
+		{name: "JSON path with an empty segment", rules: []entity.MaskRule{{Type: entity.MaskRule_JSONPath, Expression: "user..email"}}, wantErr: "invalid JSON path"},
+		{
+			name: "error names the failing rule",
+			rules: []entity.MaskRule{
+				{Type: entity.MaskRule_Regex, Expression: "ok"},
+				{Type: "xpath", Expression: "//user"},
+			},
+			wantErr: `rule 2: unknown rule type "xpath"`,
+		},
+		{name: "too many rules", rules: tooMany, wantErr: "at most 50 masking rules"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewPayloadMasker(tt.rules)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, m)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNil, m == nil)
+		})
+	}
+}
+
+func TestPayloadMasker_Mask(t *testing.T) {
+	jsonPath := func(expression string) entity.MaskRule {
+		return entity.MaskRule{Type: entity.MaskRule_JSONPath, Expression: expression}
+	}
+	text := func(payload string) DecodedPayload {
+		return DecodedPayload{Payload: payload, Encoding: PayloadEncoding_Text}
+	}
+
+	tests := []struct {
+		name  string
+		rules []entity.MaskRule
+		in    DecodedPayload
+		want  DecodedPayload
+	}{
+		{
+			name:  "nested path",
+			rules: []entity.MaskRule{jsonPath("user.email")},
+			in:    text(`{"user":{"email":"a@example.com","name":"alice"}}`),
+			want:  DecodedPayload{Payload: `{"user":{"email":"***","name":"alice"}}`, Encoding: PayloadEncoding_Text, Masked: true},
+		},
+		{
+			name:  "dollar prefix and wildcard over an array",
+			rules: []entity.MaskRule{jsonPath("$.items.*.card")},
+			in:    DecodedPayload{Payload: `{"items":[{"card":"4111"},{"card":"5500"}]}`, Encoding: PayloadEncoding_JSON},
+			want:  DecodedPayload{Payload: `{"items":[{"card":"***"},{"card":"***"}]}`, Encoding: PayloadEncoding_JSON, Masked: true},
+		},
+		{
+			name:  "array index",
+			rules: []entity.MaskRule{{Type: entity.MaskRule_JSONPath, Expression: "items.1", Replacement: "[hidden]"}},
+			in:    text(`{"items":["a","b","c"]}`),
+			want:  DecodedPayload{Payload: `{"items":["a","[hidden]","c"]}`, Encoding: PayloadEncoding_Text, Masked: true},
+		},
+		{
+			name:  "missing path leaves the document as it is",
+			rules: []entity.MaskRule{jsonPath("user.phone"), jsonPath("items.9"), jsonPath("user.email.domain")},
+			in:    text(`{"user":{"email":"a@example.com"},"items":[1]}`),
+			want:  DecodedPayload{Payload: `{"items":[1],"user":{"email":"a@example.com"}}`, Encoding: PayloadEncoding_Text, Masked: true},
+		},
+		{
+			name:  "large numbers and HTML are kept as written",
+			rules: []entity.MaskRule{jsonPath("secret")},
+			in:    text(`{"id":12345678901234567890,"note":"<b>","secret":1}`),
+			want:  DecodedPayload{Payload: `{"id":12345678901234567890,"note":"<b>","secret":"***"}`, Encoding: PayloadEncoding_Text, Masked: true},
+		},
+		{
+			name:  "non-JSON text body is withheld when JSON path rules are set",
+			rules: []entity.MaskRule{jsonPath("user.email"), {Type: entity.MaskRule_Regex, Expression: `token=\w+`, Replacement: "token=***"}},
+			in:    text(`login ok token=abc123 user.email=a@example.com`),
+			want: DecodedPayload{
+				Encoding: PayloadEncoding_Text,
+				Error:    "payload withheld: JSON path rules cannot be applied to a payload that is not a JSON document",
+				Masked:   true,
+			},
+		},
+		{
+			name:  "several JSON values are not a document",
+			rules: []entity.MaskRule{jsonPath("a")},
+			in:    text(`{"a":1} {"a":2}`),
+			want: DecodedPayload{
+				Encoding: PayloadEncoding_Text,
+				Error:    "payload withheld: JSON path rules cannot be applied to a payload that is not a JSON document",
+				Masked:   true,
+			},
+		},
+		{
+			name:  "non-JSON text body with regex rules only",
+			rules: []entity.MaskRule{{Type: entity.MaskRule_Regex, Expression: `token=\w+`, Replacement: "token=***"}},
+			in:    text(`login ok token=abc123`),
+			want:  DecodedPayload{Payload: `login ok token=***`, Encoding: PayloadEncoding_Text, Masked: true},
+		},
+		{
+			name:  "regex groups in the replacement",
+			rules: []entity.MaskRule{{Type: entity.MaskRule_Regex, Expression: `(\d{4})\d{8}(\d{4})`, Replacement: "$1********$2"}},
+			in:    text(`card 4111111111111111`),
+			want:  DecodedPayload{Payload: `card 4111********1111`, Encoding: PayloadEncoding_Text, Masked: true},
+		},
+		{
+			name:  "regex runs after the JSON rules",
+			rules: []entity.MaskRule{jsonPath("email"), {Type: entity.MaskRule_Regex, Expression: `\*\*\*`, Replacement: "[masked]"}},
+			in:    text(`{"email":"a@example.com"}`),
+			want:  DecodedPayload{Payload: `{"email":"[masked]"}`, Encoding: PayloadEncoding_Text, Masked: true},
+		},
+		{
+			name:  "base64 body is withheld",
+			rules: []entity.MaskRule{jsonPath("email")},
+			in:    DecodedPayload{Payload: "AAEC", Encoding: PayloadEncoding_Base64},
+			want: DecodedPayload{
+				Encoding: PayloadEncoding_Text,
+				Error:    "payload withheld: masking rules cannot be applied to base64 data",
+				Masked:   true,
+			},
+		},
+		{
+			name:  "hex body is withheld",
+			rules: []entity.MaskRule{{Type: entity.MaskRule_Regex, Expression: "00"}},
+			in:    DecodedPayload{Payload: "0001", Encoding: PayloadEncoding_Hex},
+			want: DecodedPayload{
+				Encoding: PayloadEncoding_Text,
+				Error:    "payload withheld: masking rules cannot be applied to hex data",
+				Masked:   true,
+			},
+		},
+		{
+			name:  "undecodable body is withheld with the decode error dropped",
+			rules: []entity.MaskRule{jsonPath("email")},
+			in:    DecodedPayload{Payload: "AAEC", Encoding: PayloadEncoding_Base64, Error: "msgpack: invalid code"},
+			want: DecodedPayload{
+				Encoding: PayloadEncoding_Text,
+				Error:    "payload withheld: masking rules cannot be applied to base64 data",
+				Masked:   true,
+			},
+		},
+		{
+			name: "no rules",
+			in:   text(`{"email":"a@example.com"}`),
+			want: text(`{"email":"a@example.com"}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewPayloadMasker(tt.rules)
+			if !assert.NoError(t, err) {
+				return
+			}
+			got := m.Mask(tt.in)
+			assert.Equal(t, tt.want, got)
+			if tt.want.Error != "" {
+				assert.False(t, strings.Contains(got.Payload, tt.in.Payload))
+			}
+		})
+	}
+}
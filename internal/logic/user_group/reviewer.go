@@ -2,13 +2,39 @@ package usergroup
 
 import (
 	"errors"
+	"time"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	userRepo "github.com/jekiapp/topic-master/internal/repository/user"
 	"github.com/tidwall/buntdb"
 )
 
+// GetReviewerIDsByGroupID returns the admins of the group, or the root members when it has no admin.
+// The active delegates of the reviewers come last, so a reviewer on leave does not stall the ticket.
 func GetReviewerIDsByGroupID(db *buntdb.DB, groupID string) ([]string, error) {
+	reviewers, err := getReviewerIDsByGroupID(db, groupID)
+	if err != nil {
+		return nil, err
+	}
+	return withDelegates(db, reviewers, time.Now()), nil
+}
+
+func withDelegates(db *buntdb.DB, reviewerIDs []string, now time.Time) []string {
+	seen := map[string]bool{}
+	for _, id := range reviewerIDs {
+		seen[id] = true
+	}
+	for _, id := range reviewerIDs {
+		delegateID, ok := userRepo.GetActiveDelegateID(db, id, now)
+		if ok && !seen[delegateID] {
+			seen[delegateID] = true
+			reviewerIDs = append(reviewerIDs, delegateID)
+		}
+	}
+	return reviewerIDs
+}
+
+func getReviewerIDsByGroupID(db *buntdb.DB, groupID string) ([]string, error) {
 	group, err := userRepo.GetGroupByID(db, groupID)
 	if err != nil {
 		return nil, err
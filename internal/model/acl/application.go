@@ -19,16 +19,36 @@ const (
 	ApplicationType_Claim       = "claim"
 	ApplicationType_TopicForm   = "topic_action"
 	ApplicationType_ChannelForm = "channel_action"
+	ApplicationType_Membership  = "group_membership"
+	ApplicationType_Transfer    = "ownership_transfer"
+
+	// MetaData keys
+	AppMetaData_EntityID      = "entity_id"
+	AppMetaData_GrantDuration = "grant_duration" // e.g. "2h", empty for permanent grants
+	AppMetaData_GroupID       = "group_id"
+	AppMetaData_GroupRole     = "group_role"    // role requested by a group membership application
+	AppMetaData_FromGroupID   = "from_group_id" // owner of the entity when an ownership transfer was requested
 
 	// Status constants
 	StatusWaitingForApproval = "waiting for approval"
 	StatusPending            = "pending"
 	StatusCompleted          = "completed"
+	StatusNeedsInfo          = "needs info"    // a reviewer asked the applicant for more information
+	StatusWithdrawn          = "withdrawn"     // the applicant cancelled the application
+	StatusAutoRejected       = "auto rejected" // nobody acted before the final SLA deadline
 
 	// Action constants
 	ActionWaitingForApproval = "waiting for approval"
 	ActionApprove            = "approve"
 	ActionReject             = "reject"
+	ActionComment            = "comment"
+	ActionRequestInfo        = "request_info"
+	ActionProvideInfo        = "provide_info"
+	ActionWithdraw           = "withdraw"
+	ActionRemind             = "remind"
+	ActionEscalate           = "escalate"
+	ActionAutoReject         = "auto_reject"
+	ActionReassign           = "reassign"
 
 	// Actor constants
 	ActorSystem = "system"
@@ -51,16 +71,50 @@ const (
 // Application represents a user's request to obtain a permission.
 // or a new signup request
 type Application struct {
-	ID            string            `json:"id"`             // UUID
-	Title         string            `json:"title"`          // Title of the application
-	UserID        string            `json:"user_id"`        // Reference to User.ID (the applicant)
-	PermissionIDs []string          `json:"permission_ids"` // Reference to Permission.ID (the requested permission)
-	Reason        string            `json:"reason"`         // Reason for the application
-	Status        string            `json:"status"`         // Overall status (e.g., pending, approved, rejected)
-	Type          string            `json:"type"`           // Type of the application (e.g., signup, claim,topic_action)
-	MetaData      map[string]string `json:"meta_data"`      // Meta data for the application
-	CreatedAt     time.Time         `json:"created_at"`     // When the application was created
-	UpdatedAt     time.Time         `json:"updated_at"`     // Last update timestamp
+	ID            string             `json:"id"`             // UUID
+	Title         string             `json:"title"`          // Title of the application
+	UserID        string             `json:"user_id"`        // Reference to User.ID (the applicant)
+	PermissionIDs []string           `json:"permission_ids"` // Reference to Permission.ID (the requested permission)
+	Reason        string             `json:"reason"`         // Reason for the application
+	Status        string             `json:"status"`         // Overall status (e.g., pending, approved, rejected)
+	Type          string             `json:"type"`           // Type of the application (e.g., signup, claim,topic_action)
+	MetaData      map[string]string  `json:"meta_data"`      // Meta data for the application
+	Stages        []ApplicationStage `json:"stages"`         // Approval stages, resolved from the approval policy on submission
+	CurrentStage  int                `json:"current_stage"`  // Index of the stage waiting for approval
+	RemindedAt    time.Time          `json:"reminded_at"`    // Last SLA reminder sent to the reviewers
+	EscalatedAt   time.Time          `json:"escalated_at"`   // Last SLA escalation to the root group
+	CreatedAt     time.Time          `json:"created_at"`     // When the application was created
+	UpdatedAt     time.Time          `json:"updated_at"`     // Last update timestamp
+}
+
+// ApplicationStage is an approval stage of an application, with its reviewer group resolved.
+type ApplicationStage struct {
+	Name              string `json:"name"`
+	ReviewerGroupID   string `json:"reviewer_group_id"`
+	ReviewerGroupName string `json:"reviewer_group_name"`
+	RequiredApprovals int    `json:"required_approvals"`
+}
+
+// IsClosed reports whether the application can no longer be acted on.
+func (a Application) IsClosed() bool {
+	return a.Status == StatusCompleted || a.Status == StatusWithdrawn || a.Status == StatusAutoRejected
+}
+
+// StageAt returns the approval stage at index i.
+// Applications created before approval policies have a single stage needing one approval.
+func (a Application) StageAt(i int) ApplicationStage {
+	if i < len(a.Stages) {
+		return a.Stages[i]
+	}
+	return ApplicationStage{RequiredApprovals: 1}
+}
+
+// StageCount returns the number of approval stages, at least one.
+func (a Application) StageCount() int {
+	if len(a.Stages) == 0 {
+		return 1
+	}
+	return len(a.Stages)
 }
 
 func (a *Application) GetPrimaryKey(id string) string {
@@ -125,6 +179,8 @@ type ApplicationAssignment struct {
 	ApplicationID string    `json:"application_id"` // Reference to Application.ID
 	ReviewerID    string    `json:"reviewer_id"`    // Reference to User.ID (the reviewer)
 	ReviewStatus  string    `json:"review_status"`  // Status (e.g., pending, approved, rejected)
+	Stage         int       `json:"stage"`          // Index of the application stage the reviewer is assigned to
+	OnBehalfOf    string    `json:"on_behalf_of"`   // User.ID of the reviewer on leave, set when ReviewerID is their delegate
 	ReviewComment string    `json:"review_comment"` // Optional comment from the reviewer
 	ReviewedAt    time.Time `json:"reviewed_at"`    // When the review was made
 	CreatedAt     time.Time `json:"created_at"`     // When the mapping was created
@@ -177,6 +233,7 @@ type ApplicationHistory struct {
 	ApplicationID string    `json:"application_id"` // Reference to Application.ID
 	Action        string    `json:"action"`         // Action taken (e.g., submitted, reviewed, approved, rejected)
 	ActorID       string    `json:"actor_id"`       // Reference to User.ID (who performed the action)
+	OnBehalfOf    string    `json:"on_behalf_of"`   // User.ID the actor stood in for as delegate, if any
 	Comment       string    `json:"comment"`        // Optional comment or reason for the action
 	CreatedAt     time.Time `json:"created_at"`     // When the action was taken
 	UpdatedAt     time.Time `json:"updated_at"`     // Last update timestamp
@@ -224,6 +281,9 @@ type AppAction struct {
 }
 
 var (
-	AppActionApprove = AppAction{ActionApprove, "#22c55e"} // modern green
-	AppActionReject  = AppAction{ActionReject, "#ef4444"}  // modern red
+	AppActionApprove     = AppAction{ActionApprove, "#22c55e"}     // modern green
+	AppActionReject      = AppAction{ActionReject, "#ef4444"}      // modern red
+	AppActionRequestInfo = AppAction{ActionRequestInfo, "#f59e0b"} // amber
+	AppActionProvideInfo = AppAction{ActionProvideInfo, "#3b82f6"} // blue
+	AppActionWithdraw    = AppAction{ActionWithdraw, "#6b7280"}    // gray
 )
@@ -0,0 +1,52 @@
+package acl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// ApplicationComment is a message posted on a ticket by its applicant or reviewers.
+// Replies point to the comment they answer through ParentID.
+const (
+	TableApplicationComment     = "app_comment"
+	IdxAppComment_ApplicationID = TableApplicationComment + ":application_id"
+)
+
+type ApplicationComment struct {
+	ID            string    `json:"id"`             // UUID
+	ApplicationID string    `json:"application_id"` // Reference to Application.ID
+	ParentID      string    `json:"parent_id"`      // Reference to the ApplicationComment.ID replied to, empty for top level comments
+	AuthorID      string    `json:"author_id"`      // Reference to User.ID
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (c *ApplicationComment) GetPrimaryKey(id string) string {
+	if c.ID == "" && id != "" {
+		c.ID = id
+	}
+	return fmt.Sprintf("%s:%s", TableApplicationComment, c.ID)
+}
+
+func (c ApplicationComment) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxAppComment_ApplicationID,
+			Pattern: fmt.Sprintf("%s:*:%s", TableApplicationComment, "application_id"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (c ApplicationComment) GetIndexValues() map[string]string {
+	return map[string]string{
+		"application_id": fmt.Sprintf("%s:%d", c.ApplicationID, c.CreatedAt.UnixNano()),
+	}
+}
+
+func (c *ApplicationComment) SetID(id string) {
+	c.ID = id
+}
@@ -0,0 +1,78 @@
+package acl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// ApprovalPolicy configures who must approve an application before it is granted.
+// Stages are approved in order; a stage is done once it collected RequiredApprovals approvals
+// from distinct reviewers, and any reject closes the application.
+// Applications without a matching policy need a single approval from the default reviewer group.
+type ApprovalPolicy struct {
+	ID              string          `json:"id"`               // ApplicationType:Permission
+	ApplicationType string          `json:"application_type"` // e.g. ApplicationType_TopicForm
+	Permission      string          `json:"permission"`       // Permission name, empty applies to every permission of the type
+	Stages          []ApprovalStage `json:"stages"`
+	UpdatedBy       string          `json:"updated_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// ApprovalStage is one step of an approval policy.
+// ReviewerGroup is a group name or one of the ReviewerGroup_* placeholders resolved per application.
+type ApprovalStage struct {
+	Name              string `json:"name"`
+	ReviewerGroup     string `json:"reviewer_group"`
+	RequiredApprovals int    `json:"required_approvals"`
+}
+
+const (
+	TableApprovalPolicy    = "approval_policy"
+	IdxApprovalPolicy_Type = TableApprovalPolicy + ":type"
+
+	// the group owning the entity of the application
+	ReviewerGroup_Owner = "@owner"
+	// the group claiming the entity, for claim applications, or receiving it for ownership transfers
+	ReviewerGroup_Claimant = "@claimant"
+)
+
+// ApprovalPolicyID returns the ID of the policy for an application type and permission.
+func ApprovalPolicyID(applicationType, permission string) string {
+	return applicationType + ":" + permission
+}
+
+func (p *ApprovalPolicy) GetPrimaryKey(id string) string {
+	if p.ID == "" && id != "" {
+		p.ID = id
+	}
+	return fmt.Sprintf("%s:%s", TableApprovalPolicy, p.ID)
+}
+
+func (p ApprovalPolicy) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxApprovalPolicy_Type,
+			Pattern: fmt.Sprintf("%s:*:%s", TableApprovalPolicy, "type"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (p ApprovalPolicy) GetIndexValues() map[string]string {
+	return map[string]string{
+		"type": p.ApplicationType,
+	}
+}
+
+// RequiredApprovals is the total number of approvals over all stages.
+func (p ApprovalPolicy) RequiredApprovals() int {
+	total := 0
+	for _, stage := range p.Stages {
+		total += stage.RequiredApprovals
+	}
+	return total
+}
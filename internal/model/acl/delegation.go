@@ -0,0 +1,53 @@
+package acl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// Delegation routes the reviews of a user to a delegate while they are out of office.
+// A user has at most one delegation; it is ignored outside of [StartAt, EndAt).
+type Delegation struct {
+	UserID     string    `json:"user_id"`     // primary key, the reviewer on leave
+	DelegateID string    `json:"delegate_id"` // reviews on behalf of UserID
+	StartAt    time.Time `json:"start_at"`
+	EndAt      time.Time `json:"end_at"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+const (
+	TableDelegation          = "delegation"
+	IdxDelegation_DelegateID = TableDelegation + ":delegate_id"
+)
+
+// IsActive reports whether the delegate stands in for the user at now.
+func (d Delegation) IsActive(now time.Time) bool {
+	return d.DelegateID != "" && !now.Before(d.StartAt) && now.Before(d.EndAt)
+}
+
+func (d *Delegation) GetPrimaryKey(id string) string {
+	if d.UserID == "" && id != "" {
+		d.UserID = id
+	}
+	return fmt.Sprintf("%s:%s", TableDelegation, d.UserID)
+}
+
+func (d Delegation) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxDelegation_DelegateID,
+			Pattern: fmt.Sprintf("%s:*:%s", TableDelegation, "delegate_id"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (d Delegation) GetIndexValues() map[string]string {
+	return map[string]string{
+		"delegate_id": d.DelegateID,
+	}
+}
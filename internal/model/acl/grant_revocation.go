@@ -0,0 +1,58 @@
+package acl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// GrantRevocation records a PermissionMap removed by an owner before it expired, for audit.
+type GrantRevocation struct {
+	ID              string // UUID
+	PermissionMapID string // Reference to the revoked PermissionMap.ID
+	Action          string
+	UserID          string // grantee, reference to User.ID
+	EntityID        string // Reference to Entity.ID
+	ApplicationID   string // application that granted the permission, if any
+	GrantedAt       time.Time
+	RevokedBy       string // User.ID of the revoker
+	Reason          string
+	RevokedAt       time.Time
+}
+
+const (
+	TableGrantRevocation      = "grant_revocation"
+	IdxGrantRevocation_Entity = TableGrantRevocation + ":entity"
+	IdxGrantRevocation_User   = TableGrantRevocation + ":user"
+)
+
+func (r GrantRevocation) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxGrantRevocation_Entity,
+			Pattern: fmt.Sprintf("%s:*:%s", TableGrantRevocation, "entity"),
+			Type:    buntdb.IndexString,
+		},
+		{
+			Name:    IdxGrantRevocation_User,
+			Pattern: fmt.Sprintf("%s:*:%s", TableGrantRevocation, "user"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (r *GrantRevocation) GetPrimaryKey(id string) string {
+	if r.ID == "" && id != "" {
+		r.ID = id
+	}
+	return fmt.Sprintf("%s:%s", TableGrantRevocation, r.ID)
+}
+
+func (r GrantRevocation) GetIndexValues() map[string]string {
+	return map[string]string{
+		"entity": r.EntityID,
+		"user":   r.UserID,
+	}
+}
@@ -1,6 +1,8 @@
 package acl
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/tidwall/buntdb"
@@ -15,10 +17,12 @@ type JWTClaims struct {
 }
 
 // ResetPassword represents a password reset token and its association to a username
-// and is used for password reset flows.
+// and is used for password reset flows. Only the sha256 of the token is stored, the token
+// itself is in the link given to the user and works once.
 type ResetPassword struct {
-	Token     string `json:"token"`
+	Token     string `json:"token"` // sha256 of the token, hex encoded
 	Username  string `json:"username"`
+	Purpose   string `json:"purpose"` // ResetPurposeReset (or empty) or ResetPurposeInvite
 	CreatedAt int64  `json:"created_at"`
 	ExpiresAt int64  `json:"expires_at"`
 }
@@ -26,8 +30,16 @@ type ResetPassword struct {
 const (
 	TableResetPassword        = "reset_password"
 	IdxResetPassword_Username = TableResetPassword + ":username"
+
+	ResetPurposeReset  = "reset"  // forgotten password, or first login of a user created with a password
+	ResetPurposeInvite = "invite" // invitation of a user created by root
 )
 
+// IsExpired tells whether the token can no longer be used at now.
+func (r ResetPassword) IsExpired(now time.Time) bool {
+	return r.ExpiresAt > 0 && now.Unix() > r.ExpiresAt
+}
+
 func (r *ResetPassword) GetPrimaryKey(id string) string {
 	if r.Token == "" && id != "" {
 		r.Token = id
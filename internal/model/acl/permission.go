@@ -26,11 +26,27 @@ var (
 		Name:        "topic:pause",
 		Description: "Pause a topic",
 	}
+	Permission_Topic_Decoder_Update = Permission{
+		Name:        "topic:decoder:update",
+		Description: "Configure the payload decoder of a topic",
+	}
+	Permission_Topic_Masking_Update = Permission{
+		Name:        "topic:masking:update",
+		Description: "Configure the masking rules of a topic",
+	}
+	Permission_Topic_Tail_Unmasked = Permission{
+		Name:        "topic:tail:unmasked",
+		Description: "Tail a topic without masking sensitive data",
+	}
 
 	Permission_Claim_Entity = Permission{
 		Name:        "claim",
 		Description: "Claim an entity",
 	}
+	Permission_Transfer_Entity = Permission{
+		Name:        "transfer",
+		Description: "Transfer the ownership of an entity",
+	}
 	Permission_Signup_User = Permission{
 		Name:        "signup",
 		Description: "Signup a user",
@@ -39,12 +55,25 @@ var (
 		Name:        "entity:desc:update",
 		Description: "Update the description of an entity",
 	}
+	Permission_Group_Join_Member = Permission{
+		Name:        "group:join:member",
+		Description: "Join a group as member",
+	}
+	Permission_Group_Join_Admin = Permission{
+		Name:        "group:join:admin",
+		Description: "Join a group as admin",
+	}
 )
 
 var PermissionList = map[string]Permission{
 	// common permissions
-	Permission_Claim_Entity.Name: Permission_Claim_Entity,
-	Permission_Signup_User.Name:  Permission_Signup_User,
+	Permission_Claim_Entity.Name:    Permission_Claim_Entity,
+	Permission_Transfer_Entity.Name: Permission_Transfer_Entity,
+	Permission_Signup_User.Name:     Permission_Signup_User,
+
+	// group permissions
+	Permission_Group_Join_Member.Name: Permission_Group_Join_Member,
+	Permission_Group_Join_Admin.Name:  Permission_Group_Join_Admin,
 
 	// topic permissions
 	Permission_Topic_Publish.Name: Permission_Topic_Publish,
@@ -53,6 +82,10 @@ var PermissionList = map[string]Permission{
 	Permission_Topic_Empty.Name:   Permission_Topic_Empty,
 	Permission_Topic_Pause.Name:   Permission_Topic_Pause,
 
+	Permission_Topic_Decoder_Update.Name: Permission_Topic_Decoder_Update,
+	Permission_Topic_Masking_Update.Name: Permission_Topic_Masking_Update,
+	Permission_Topic_Tail_Unmasked.Name:  Permission_Topic_Tail_Unmasked,
+
 	// channel permissions
 	Permission_Channel_Pause.Name:  Permission_Channel_Pause,
 	Permission_Channel_Empty.Name:  Permission_Channel_Empty,
@@ -65,6 +98,9 @@ var TopicActionPermissions = []Permission{
 	Permission_Topic_Empty,
 	Permission_Topic_Pause,
 	Permission_Topic_Delete,
+	Permission_Topic_Decoder_Update,
+	Permission_Topic_Masking_Update,
+	Permission_Topic_Tail_Unmasked,
 }
 
 var (
@@ -87,3 +123,19 @@ var ChannelActionPermissions = []Permission{
 	Permission_Channel_Empty,
 	Permission_Channel_Delete,
 }
+
+var GroupMembershipPermissions = []Permission{
+	Permission_Group_Join_Member,
+	Permission_Group_Join_Admin,
+}
+
+// GroupJoinPermission returns the permission requested to join a group with the role
+func GroupJoinPermission(role string) (Permission, bool) {
+	switch role {
+	case RoleGroupMember:
+		return Permission_Group_Join_Member, true
+	case RoleGroupAdmin:
+		return Permission_Group_Join_Admin, true
+	}
+	return Permission{}, false
+}
@@ -23,6 +23,14 @@ type PermissionMap struct {
 	UserID    string // Reference to User.ID
 	EntityID  string // Reference to Entity.ID
 	CreatedAt time.Time
+	ExpiresAt time.Time // zero means the grant never expires
+	// ApplicationID references the application that granted the permission, if any
+	ApplicationID string
+}
+
+// IsExpired reports whether a time-bound grant is no longer valid at now.
+func (p PermissionMap) IsExpired(now time.Time) bool {
+	return !p.ExpiresAt.IsZero() && !now.Before(p.ExpiresAt)
 }
 
 const (
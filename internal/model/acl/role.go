@@ -0,0 +1,94 @@
+package acl
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// CustomRole is a named set of permissions a group gives to some of its members,
+// e.g. "operator" with pause and empty or "viewer" with tail only.
+// Members with a builtin role hold the permissions of that role, see BuiltinRoleAllows.
+type CustomRole struct {
+	ID          string
+	GroupID     string // Reference to Group.ID
+	Name        string // unique within the group
+	Description string
+	Permissions []string // Permission.Name values, see RolePermissions
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+const (
+	TableCustomRole         = "custom_role"
+	IdxCustomRole_GroupID   = TableCustomRole + ":group_id"
+	IdxCustomRole_GroupName = TableCustomRole + ":group_name"
+)
+
+// RolePermissions lists the permissions a custom role can hold: the actions on the entities of the group.
+var RolePermissions = append(append(append([]Permission{}, TopicActionPermissions...), ChannelActionPermissions...), Permission_Entity_Desc_Update)
+
+// MemberPermissions lists the actions the builtin member role holds on the group's entities.
+// Deleting or emptying, masking and tailing unmasked data are left to admins or to a grant.
+var MemberPermissions = []Permission{
+	Permission_Topic_Publish,
+	Permission_Topic_Tail,
+	Permission_Topic_Pause,
+	Permission_Topic_Decoder_Update,
+	Permission_Channel_Pause,
+	Permission_Entity_Desc_Update,
+}
+
+// IsBuiltinRole reports whether the role is one every group has: admin or member.
+func IsBuiltinRole(role string) bool {
+	return role == RoleGroupAdmin || role == RoleGroupMember
+}
+
+// BuiltinRoleAllows reports whether a builtin role holds the action on the group's entities:
+// admins hold every action, members the ones in MemberPermissions.
+func BuiltinRoleAllows(role, action string) bool {
+	switch role {
+	case RoleGroupAdmin:
+		return true
+	case RoleGroupMember:
+		return slices.ContainsFunc(MemberPermissions, func(p Permission) bool { return p.Name == action })
+	}
+	return false
+}
+
+// Allows reports whether the role holds the permission.
+func (r CustomRole) Allows(action string) bool {
+	return slices.Contains(r.Permissions, action)
+}
+
+func (r *CustomRole) GetPrimaryKey(id string) string {
+	if r.ID == "" && id != "" {
+		r.ID = id
+	}
+	return fmt.Sprintf("%s:%s", TableCustomRole, r.ID)
+}
+
+func (r CustomRole) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxCustomRole_GroupID,
+			Pattern: fmt.Sprintf("%s:*:%s", TableCustomRole, "group_id"),
+			Type:    buntdb.IndexString,
+		},
+		{
+			Name:    IdxCustomRole_GroupName,
+			Pattern: fmt.Sprintf("%s:*:%s", TableCustomRole, "group_name"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (r CustomRole) GetIndexValues() map[string]string {
+	return map[string]string{
+		"group_id":   r.GroupID,
+		"group_name": fmt.Sprintf("%s:%s", r.GroupID, r.Name),
+	}
+}
@@ -0,0 +1,112 @@
+package acl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// Security events kept for audit
+const (
+	SecurityEvent_LoginFailed     = "login_failed"
+	SecurityEvent_TwoFactorFailed = "two_factor_failed"
+	SecurityEvent_AccountLocked   = "account_locked"
+	SecurityEvent_AccountUnlocked = "account_unlocked"
+	SecurityEvent_RateLimited     = "rate_limited"
+
+	SecurityEvent_AccountDeactivated = "account_deactivated"
+	SecurityEvent_AccountReactivated = "account_reactivated"
+)
+
+// SecurityEvent records a failed login, a lockout, a throttled client or an account being deactivated or reactivated.
+type SecurityEvent struct {
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	Username  string    `json:"username"` // account concerned, empty for a throttled IP
+	IP        string    `json:"ip"`
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	TableSecurityEvent         = "security_event"
+	IdxSecurityEvent_CreatedAt = TableSecurityEvent + ":created_at"
+)
+
+func (e *SecurityEvent) GetPrimaryKey(id string) string {
+	if e.ID == "" && id != "" {
+		e.ID = id
+	}
+	return fmt.Sprintf("%s:%s", TableSecurityEvent, e.ID)
+}
+
+func (e SecurityEvent) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxSecurityEvent_CreatedAt,
+			Pattern: fmt.Sprintf("%s:*:%s", TableSecurityEvent, "created_at"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (e SecurityEvent) GetIndexValues() map[string]string {
+	return map[string]string{
+		"created_at": fmt.Sprintf("%020d", e.CreatedAt.UnixNano()),
+	}
+}
+
+func (e *SecurityEvent) SetID(id string) {
+	e.ID = id
+}
+
+// LoginLockout counts the failed logins of an account in a row, keyed by the user ID.
+type LoginLockout struct {
+	UserID        string `json:"user_id"`
+	Username      string `json:"username"`
+	Failures      int    `json:"failures"`
+	LastFailureAt int64  `json:"last_failure_at"`
+	LockedUntil   int64  `json:"locked_until"` // unix seconds, the account is locked until then
+}
+
+const (
+	TableLoginLockout        = "login_lockout"
+	IdxLoginLockout_Username = TableLoginLockout + ":username"
+)
+
+// LockedFor returns how long the account stays locked, 0 when it isn't.
+func (l LoginLockout) LockedFor(now time.Time) time.Duration {
+	if l.LockedUntil <= now.Unix() {
+		return 0
+	}
+	return time.Unix(l.LockedUntil, 0).Sub(now)
+}
+
+func (l *LoginLockout) GetPrimaryKey(id string) string {
+	if l.UserID == "" && id != "" {
+		l.UserID = id
+	}
+	return fmt.Sprintf("%s:%s", TableLoginLockout, l.UserID)
+}
+
+func (l LoginLockout) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxLoginLockout_Username,
+			Pattern: fmt.Sprintf("%s:*:%s", TableLoginLockout, "username"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (l LoginLockout) GetIndexValues() map[string]string {
+	return map[string]string{
+		"username": l.Username,
+	}
+}
+
+func (l *LoginLockout) SetID(id string) {
+	l.UserID = id
+}
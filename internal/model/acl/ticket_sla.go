@@ -0,0 +1,58 @@
+package acl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// TicketSLA configures how long tickets of an application type may wait for their reviewers.
+// Deadlines are counted in hours from the last progress of the ticket (submission, stage change
+// or answer to a request for information); 0 disables the step.
+type TicketSLA struct {
+	ApplicationType      string    `json:"application_type"`        // primary key, e.g. ApplicationType_TopicForm
+	RemindAfterHours     int       `json:"remind_after_hours"`      // remind the assignees
+	EscalateAfterHours   int       `json:"escalate_after_hours"`    // add the root group members as reviewers
+	AutoRejectAfterHours int       `json:"auto_reject_after_hours"` // final deadline, the ticket is rejected
+	UpdatedBy            string    `json:"updated_by"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+const (
+	TableTicketSLA    = "ticket_sla"
+	IdxTicketSLA_Type = TableTicketSLA + ":type"
+)
+
+func (s *TicketSLA) GetPrimaryKey(id string) string {
+	if s.ApplicationType == "" && id != "" {
+		s.ApplicationType = id
+	}
+	return fmt.Sprintf("%s:%s", TableTicketSLA, s.ApplicationType)
+}
+
+func (s TicketSLA) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxTicketSLA_Type,
+			Pattern: fmt.Sprintf("%s:*:%s", TableTicketSLA, "type"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (s TicketSLA) GetIndexValues() map[string]string {
+	return map[string]string{
+		"type": s.ApplicationType,
+	}
+}
+
+// Deadline returns when a step configured with hours is due for a ticket idle since start.
+// ok is false when the step is disabled.
+func (s TicketSLA) Deadline(start time.Time, hours int) (deadline time.Time, ok bool) {
+	if hours <= 0 {
+		return time.Time{}, false
+	}
+	return start.Add(time.Duration(hours) * time.Hour), true
+}
@@ -0,0 +1,75 @@
+package acl
+
+import (
+	"github.com/jekiapp/topic-master/pkg/db"
+)
+
+// UserTOTP is the authenticator enrolment of a user, keyed by the user ID.
+// The secret is stored disabled until the user confirms a first code.
+type UserTOTP struct {
+	UserID        string   `json:"user_id"`
+	Secret        string   `json:"-"` // base32, as shown to the authenticator app
+	Enabled       bool     `json:"enabled"`
+	RecoveryCodes []string `json:"-"` // sha256 of the unused recovery codes
+	LastUsedStep  int64    `json:"-"` // time step of the last accepted code, a code is accepted once
+	CreatedAt     int64    `json:"created_at"`
+	EnabledAt     int64    `json:"enabled_at"`
+}
+
+const (
+	TableUserTOTP       = "user_totp"
+	TableLoginChallenge = "login_challenge"
+
+	// LoginChallengeTTL is how long the second step of a login stays open, in seconds
+	LoginChallengeTTL = 5 * 60
+	// LoginChallengeMaxAttempts is the number of wrong codes before the login has to start over
+	LoginChallengeMaxAttempts = 5
+)
+
+func (t *UserTOTP) GetPrimaryKey(id string) string {
+	if t.UserID == "" && id != "" {
+		t.UserID = id
+	}
+	return TableUserTOTP + ":" + t.UserID
+}
+
+func (t UserTOTP) GetIndexes() []db.Index {
+	return []db.Index{}
+}
+
+func (t UserTOTP) GetIndexValues() map[string]string {
+	return map[string]string{}
+}
+
+func (t *UserTOTP) SetID(id string) {
+	t.UserID = id
+}
+
+// LoginChallenge is a password login waiting for its second factor.
+// Enroll is set when the user has to set up an authenticator before logging in.
+type LoginChallenge struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Enroll    bool   `json:"enroll"`
+	Attempts  int    `json:"attempts"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+func (c *LoginChallenge) GetPrimaryKey(id string) string {
+	if c.ID == "" && id != "" {
+		c.ID = id
+	}
+	return TableLoginChallenge + ":" + c.ID
+}
+
+func (c LoginChallenge) GetIndexes() []db.Index {
+	return []db.Index{}
+}
+
+func (c LoginChallenge) GetIndexValues() map[string]string {
+	return map[string]string{}
+}
+
+func (c *LoginChallenge) SetID(id string) {
+	c.ID = id
+}
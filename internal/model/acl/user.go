@@ -22,6 +22,12 @@ const (
 	StatusUserInactive   = "inactive"
 )
 
+const (
+	UserSourceLocal = "local" // password stored in topic-master, the default
+	UserSourceLDAP  = "ldap"  // created on the first LDAP login, the directory checks the password
+	UserSourceOIDC  = "oidc"  // created on the first single sign-on through the OpenID Connect provider
+)
+
 // User represents a system user (master)
 type User struct {
 	ID        string      `json:"id"`         // Unique identifier (e.g., UUID or string key)
@@ -29,9 +35,15 @@ type User struct {
 	Name      string      `json:"name"`       // Display Name
 	Password  string      `json:"password"`   // Password hash
 	Status    string      `json:"status"`     // Status (e.g., active, inactive, etc.)
+	Source    string      `json:"source"`     // UserSourceLocal (or empty), UserSourceLDAP or UserSourceOIDC
 	CreatedAt time.Time   `json:"created_at"` // Creation timestamp
 	UpdatedAt time.Time   `json:"updated_at"` // Last update timestamp
 	Groups    []GroupRole `json:"groups"`     // List of groups and roles
+
+	DeactivatedAt time.Time `json:"deactivated_at,omitempty"` // when the account was set inactive
+	DeactivatedBy string    `json:"deactivated_by,omitempty"` // username of the admin, or ActorSystem for the inactivity policy
+	// TokensValidAfter revokes the sessions issued before it (unix seconds)
+	TokensValidAfter int64 `json:"-"`
 }
 
 const (
@@ -99,3 +111,44 @@ func (u *UserPending) GetIndexValues() map[string]string {
 		"username": u.Username,
 	}
 }
+
+// UserActivity keeps when a user last logged in and used the API, keyed by the user ID.
+// It is kept apart from the user so that touching it doesn't race with user updates.
+type UserActivity struct {
+	UserID       string    `json:"user_id"`
+	Username     string    `json:"username"`
+	LastLoginAt  time.Time `json:"last_login_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+}
+
+const (
+	TableUserActivity        = "user_activity"
+	IdxUserActivity_Username = TableUserActivity + ":username"
+)
+
+func (a *UserActivity) GetPrimaryKey(id string) string {
+	if a.UserID == "" && id != "" {
+		a.UserID = id
+	}
+	return fmt.Sprintf("%s:%s", TableUserActivity, a.UserID)
+}
+
+func (a UserActivity) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxUserActivity_Username,
+			Pattern: fmt.Sprintf("%s:*:%s", TableUserActivity, "username"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (a UserActivity) GetIndexValues() map[string]string {
+	return map[string]string{
+		"username": a.Username,
+	}
+}
+
+func (a *UserActivity) SetID(id string) {
+	a.UserID = id
+}
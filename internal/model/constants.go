@@ -1,3 +1,6 @@
 package model
 
 const UserInfoKey = "user_info"
+
+// ActionEntityIDKey holds the entity_id the action middleware checked
+const ActionEntityIDKey = "action_entity_id"
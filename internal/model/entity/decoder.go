@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+)
+
+// TopicDecoder configures how the payload of a topic is rendered for humans.
+// One record per topic entity, keyed by the entity ID.
+type TopicDecoder struct {
+	EntityID string
+	// Compression is applied before the decoder: "", gzip or snappy
+	Compression string
+	Decoder     string // e.g. Decoder_Text
+	// protobuf only: serialized google.protobuf.FileDescriptorSet and the fully qualified message name
+	Descriptor  []byte
+	MessageType string
+	UpdatedBy   string
+	UpdatedAt   time.Time
+}
+
+const (
+	TableTopicDecoder = "topic_decoder"
+
+	Decoder_Text     = "text"
+	Decoder_Hex      = "hex"
+	Decoder_Base64   = "base64"
+	Decoder_Msgpack  = "msgpack"
+	Decoder_Protobuf = "protobuf"
+
+	Compression_None   = ""
+	Compression_Gzip   = "gzip"
+	Compression_Snappy = "snappy"
+)
+
+func (d *TopicDecoder) GetPrimaryKey(id string) string {
+	if d.EntityID == "" && id != "" {
+		d.EntityID = id
+	}
+	return TableTopicDecoder + ":" + d.EntityID
+}
+
+func (d TopicDecoder) GetIndexes() []db.Index {
+	return []db.Index{}
+}
+
+func (d TopicDecoder) GetIndexValues() map[string]string {
+	return map[string]string{}
+}
@@ -9,17 +9,34 @@ import (
 )
 
 type Entity struct {
-	ID          string
-	TypeID      string // e.g EntityType_NSQTopic
-	GroupOwner  string // Group.Name
-	Name        string
-	Resource    string
-	Status      string
-	Description string
-	Tags        []string
-	Metadata    map[string]string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           string
+	TypeID       string // e.g EntityType_NSQTopic
+	GroupOwnerID string // Group.ID of the owner, GroupNone when not owned
+	GroupOwner   string // Group.Name of the owner, kept in sync on rename for display
+	Name         string
+	Resource     string
+	Status       string
+	Description  string
+	Tags         []string
+	Metadata     map[string]string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsOwned reports whether the entity belongs to a group
+func (e Entity) IsOwned() bool {
+	return e.GroupOwnerID != "" && e.GroupOwnerID != GroupNone
+}
+
+// SetOwner moves the entity to the group, an empty id releases it
+func (e *Entity) SetOwner(groupID, groupName string) {
+	if groupID == "" || groupID == GroupNone {
+		e.GroupOwnerID = GroupNone
+		e.GroupOwner = GroupNone
+		return
+	}
+	e.GroupOwnerID = groupID
+	e.GroupOwner = groupName
 }
 
 const (
@@ -31,15 +48,94 @@ const (
 	EntityStatus_Deleted = "deleted"
 )
 
-// publish, tail, etc.
+// EntityDefaultPermission opens an action (publish, tail, etc.) of an entity to users outside the owner group.
+// EntityID is DefaultPermission_Unowned for the global policy of the entities without owner.
 type EntityDefaultPermission struct {
 	ID             string
 	EntityID       string
 	PermissionName string
+	Audience       string // Audience_Anonymous, Audience_Authenticated or Audience_None
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
 
+const (
+	Audience_Anonymous     = "anonymous"     // anyone, logged in or not
+	Audience_Authenticated = "authenticated" // any logged in user
+	Audience_None          = "none"          // owners and explicit grants only
+
+	// DefaultPermission_Unowned is the EntityID of the global policy for entities without owner.
+	DefaultPermission_Unowned = "unowned"
+
+	TableEntityDefaultPermission                = "entity_default_perm"
+	IdxEntityDefaultPermission_Entity           = TableEntityDefaultPermission + ":entity"
+	IdxEntityDefaultPermission_EntityPermission = TableEntityDefaultPermission + ":entity_permission"
+)
+
+// IsValidAudience reports whether the audience is one of the Audience_ values.
+func IsValidAudience(audience string) bool {
+	return audience == Audience_Anonymous || audience == Audience_Authenticated || audience == Audience_None
+}
+
+// DefaultAudience is the audience of an action without EntityDefaultPermission:
+// unowned entities are open to anyone, owned entities to nobody outside the owner group.
+func DefaultAudience(entityID string) string {
+	if entityID == DefaultPermission_Unowned {
+		return Audience_Anonymous
+	}
+	return Audience_None
+}
+
+// Allows reports whether the default permission lets a user in, loggedIn tells whether there is a user.
+func (p EntityDefaultPermission) Allows(loggedIn bool) bool {
+	switch p.Audience {
+	case Audience_Anonymous:
+		return true
+	case Audience_Authenticated:
+		return loggedIn
+	}
+	return false
+}
+
+// IsOpenToAnyone reports whether none of the default permissions restricts anonymous users.
+func IsOpenToAnyone(perms []EntityDefaultPermission) bool {
+	for _, p := range perms {
+		if p.Audience != Audience_Anonymous {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *EntityDefaultPermission) GetPrimaryKey(id string) string {
+	if p.ID == "" && id != "" {
+		p.ID = id
+	}
+	return TableEntityDefaultPermission + ":" + p.ID
+}
+
+func (p EntityDefaultPermission) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxEntityDefaultPermission_Entity,
+			Pattern: TableEntityDefaultPermission + ":*:entity",
+			Type:    buntdb.IndexString,
+		},
+		{
+			Name:    IdxEntityDefaultPermission_EntityPermission,
+			Pattern: TableEntityDefaultPermission + ":*:entity_permission",
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (p EntityDefaultPermission) GetIndexValues() map[string]string {
+	return map[string]string{
+		"entity":            p.EntityID,
+		"entity_permission": p.EntityID + ":" + p.PermissionName,
+	}
+}
+
 const (
 	TableEntity            = "entity"
 	IdxEntity_TypeID       = TableEntity + ":typeid"
@@ -104,10 +200,10 @@ func (e Entity) GetIndexes() []db.Index {
 func (e Entity) GetIndexValues() map[string]string {
 	values := map[string]string{
 		"typeid":     e.TypeID,
-		"group":      e.GroupOwner,
+		"group":      e.GroupOwnerID,
 		"name":       e.Name,
 		"status":     e.Status,
-		"group_type": e.GroupOwner + ":" + e.TypeID,
+		"group_type": e.GroupOwnerID + ":" + e.TypeID,
 		"type_name":  e.TypeID + ":" + e.Name,
 	}
 
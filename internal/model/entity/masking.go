@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+)
+
+// TopicMasking holds the masking rules applied to the payload of a topic before it leaves the server.
+// One record per topic entity, keyed by the entity ID.
+type TopicMasking struct {
+	EntityID  string
+	Rules     []MaskRule
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// MaskRule replaces sensitive data in a payload.
+// Expression is a JSON path for MaskRule_JSONPath (e.g. `user.email`, `items.*.card_number`)
+// or a regular expression for MaskRule_Regex, whose Replacement may use $1 style groups.
+type MaskRule struct {
+	Type        string `json:"type"`
+	Expression  string `json:"expression"`
+	Replacement string `json:"replacement"`
+}
+
+const (
+	TableTopicMasking = "topic_masking"
+
+	MaskRule_JSONPath = "json_path"
+	MaskRule_Regex    = "regex"
+
+	MaskDefaultReplacement = "***"
+)
+
+func (m *TopicMasking) GetPrimaryKey(id string) string {
+	if m.EntityID == "" && id != "" {
+		m.EntityID = id
+	}
+	return TableTopicMasking + ":" + m.EntityID
+}
+
+func (m TopicMasking) GetIndexes() []db.Index {
+	return []db.Index{}
+}
+
+func (m TopicMasking) GetIndexValues() map[string]string {
+	return map[string]string{}
+}
@@ -0,0 +1,121 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// Ticket events a user can be notified about
+const (
+	EventTicketCreated  = "ticket_created"  // to the applicant
+	EventTicketAssigned = "ticket_assigned" // to the reviewers of the ticket
+	EventTicketApproved = "ticket_approved" // to the applicant
+	EventTicketRejected = "ticket_rejected" // to the applicant
+	EventTicketReminder = "ticket_reminder" // to the reviewers, when the ticket nears its SLA
+)
+
+// Events lists every event, in the order shown on the preference page.
+var Events = []string{
+	EventTicketCreated,
+	EventTicketAssigned,
+	EventTicketApproved,
+	EventTicketRejected,
+	EventTicketReminder,
+}
+
+// Delivery channels
+const (
+	ChannelInbox   = "inbox"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook" // Slack-compatible incoming webhook
+)
+
+var Channels = []string{ChannelInbox, ChannelEmail, ChannelWebhook}
+
+const (
+	TableNotification      = "notification"
+	IdxNotification_UserID = TableNotification + ":user_id"
+)
+
+// Notification is an item of the in-app inbox of a user.
+type Notification struct {
+	ID            string    `json:"id"`
+	UserID        string    `json:"user_id"` // recipient
+	Event         string    `json:"event"`
+	Subject       string    `json:"subject"`
+	Body          string    `json:"body"`
+	ApplicationID string    `json:"application_id"`
+	Read          bool      `json:"read"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (n *Notification) GetPrimaryKey(id string) string {
+	if n.ID == "" && id != "" {
+		n.ID = id
+	}
+	return fmt.Sprintf("%s:%s", TableNotification, n.ID)
+}
+
+func (n Notification) GetIndexes() []db.Index {
+	return []db.Index{
+		{
+			Name:    IdxNotification_UserID,
+			Pattern: fmt.Sprintf("%s:*:%s", TableNotification, "user_id"),
+			Type:    buntdb.IndexString,
+		},
+	}
+}
+
+func (n Notification) GetIndexValues() map[string]string {
+	return map[string]string{
+		"user_id": fmt.Sprintf("%s:%d", n.UserID, n.CreatedAt.UnixNano()),
+	}
+}
+
+func (n *Notification) SetID(id string) {
+	n.ID = id
+}
+
+const TableNotificationPreference = "notification_pref"
+
+// Preference holds the channels a user receives each event on.
+// Users without a preference get every event in their inbox only.
+type Preference struct {
+	UserID     string              `json:"user_id"` // primary key
+	Email      string              `json:"email"`
+	WebhookURL string              `json:"webhook_url"`
+	Events     map[string][]string `json:"events"` // event -> channels, a missing event is muted
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// DefaultPreference delivers every event to the inbox.
+func DefaultPreference(userID string) Preference {
+	events := map[string][]string{}
+	for _, event := range Events {
+		events[event] = []string{ChannelInbox}
+	}
+	return Preference{UserID: userID, Events: events}
+}
+
+// ChannelsFor returns the channels the event is delivered on.
+func (p Preference) ChannelsFor(event string) []string {
+	return p.Events[event]
+}
+
+func (p *Preference) GetPrimaryKey(id string) string {
+	if p.UserID == "" && id != "" {
+		p.UserID = id
+	}
+	return fmt.Sprintf("%s:%s", TableNotificationPreference, p.UserID)
+}
+
+func (p Preference) GetIndexes() []db.Index {
+	return []db.Index{}
+}
+
+func (p Preference) GetIndexValues() map[string]string {
+	return map[string]string{}
+}
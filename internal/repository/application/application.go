@@ -2,8 +2,12 @@ package application
 
 import (
 	"encoding/json"
+	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
 	dbpkg "github.com/jekiapp/topic-master/pkg/db"
 	"github.com/tidwall/buntdb"
 )
@@ -15,6 +19,11 @@ func CreateApplication(db *buntdb.DB, app acl.Application) error {
 	return dbpkg.Insert(db, &app)
 }
 
+// CreateApplicationTx is CreateApplication inside the transaction tx.
+func CreateApplicationTx(tx *buntdb.Tx, app acl.Application) error {
+	return dbpkg.InsertTx(tx, &app)
+}
+
 func GetApplicationByUserAndPermission(db *buntdb.DB, userID, permissionID string) (acl.Application, error) {
 	key := permissionApplicationPrefix + userID + ":" + permissionID
 	var app acl.Application
@@ -31,14 +40,53 @@ func GetApplicationByUserAndPermission(db *buntdb.DB, userID, permissionID strin
 	return app, nil
 }
 
+// CreateApplicationAssignment assigns a reviewer to a stage of an application, once.
+// When the reviewer is out of office, their active delegate is assigned as well to review on their behalf.
 func CreateApplicationAssignment(db *buntdb.DB, assignment acl.ApplicationAssignment) error {
-	return dbpkg.Insert(db, &assignment)
+	return dbpkg.WithTx(db, func(tx *buntdb.Tx) error {
+		return CreateApplicationAssignmentTx(tx, assignment)
+	})
+}
+
+// CreateApplicationAssignmentTx is CreateApplicationAssignment inside the transaction tx.
+func CreateApplicationAssignmentTx(tx *buntdb.Tx, assignment acl.ApplicationAssignment) error {
+	existing, err := dbpkg.SelectAllTx[acl.ApplicationAssignment](tx, "="+assignment.ApplicationID, acl.IdxAppAssign_ApplicationID)
+	if err != nil && !errors.Is(err, dbpkg.ErrNotFound) {
+		return err
+	}
+	for _, other := range existing {
+		if other.Stage == assignment.Stage && other.ReviewerID == assignment.ReviewerID && other.ReviewStatus == acl.ReviewStatusWaiting {
+			return nil
+		}
+	}
+	if err := dbpkg.InsertTx(tx, &assignment); err != nil {
+		return err
+	}
+
+	// a delegate does not pass the review on to their own delegate
+	if assignment.OnBehalfOf != "" {
+		return nil
+	}
+	delegateID, ok := userrepo.GetActiveDelegateIDTx(tx, assignment.ReviewerID, time.Now())
+	if !ok {
+		return nil
+	}
+	delegated := assignment
+	delegated.ID = uuid.NewString()
+	delegated.ReviewerID = delegateID
+	delegated.OnBehalfOf = assignment.ReviewerID
+	return CreateApplicationAssignmentTx(tx, delegated)
 }
 
 func CreateApplicationHistory(db *buntdb.DB, history acl.ApplicationHistory) error {
 	return dbpkg.Insert(db, &history)
 }
 
+// CreateApplicationHistoryTx is CreateApplicationHistory inside the transaction tx.
+func CreateApplicationHistoryTx(tx *buntdb.Tx, history acl.ApplicationHistory) error {
+	return dbpkg.InsertTx(tx, &history)
+}
+
 // InitIndexApplication registers indexes for Application, ApplicationAssignment, and ApplicationHistory
 func InitIndexApplication(db *buntdb.DB) error {
 	appIndexes := acl.Application{}.GetIndexes()
@@ -59,5 +107,23 @@ func InitIndexApplication(db *buntdb.DB) error {
 			return err
 		}
 	}
+	commentIndexes := acl.ApplicationComment{}.GetIndexes()
+	for _, index := range commentIndexes {
+		if err := db.CreateIndex(index.Name, index.Pattern, index.Type); err != nil {
+			return err
+		}
+	}
+	policyIndexes := acl.ApprovalPolicy{}.GetIndexes()
+	for _, index := range policyIndexes {
+		if err := db.CreateIndex(index.Name, index.Pattern, index.Type); err != nil {
+			return err
+		}
+	}
+	slaIndexes := acl.TicketSLA{}.GetIndexes()
+	for _, index := range slaIndexes {
+		if err := db.CreateIndex(index.Name, index.Pattern, index.Type); err != nil {
+			return err
+		}
+	}
 	return nil
 }
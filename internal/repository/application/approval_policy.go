@@ -0,0 +1,33 @@
+package application
+
+import (
+	"errors"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+func ListApprovalPolicies(db *buntdb.DB) ([]acl.ApprovalPolicy, error) {
+	policies, err := dbpkg.SelectAll[acl.ApprovalPolicy](db, "*", acl.IdxApprovalPolicy_Type)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return []acl.ApprovalPolicy{}, nil
+	}
+	return policies, err
+}
+
+func ListApprovalPoliciesByType(db *buntdb.DB, applicationType string) ([]acl.ApprovalPolicy, error) {
+	policies, err := dbpkg.SelectAll[acl.ApprovalPolicy](db, "="+applicationType, acl.IdxApprovalPolicy_Type)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return []acl.ApprovalPolicy{}, nil
+	}
+	return policies, err
+}
+
+func UpsertApprovalPolicy(db *buntdb.DB, policy acl.ApprovalPolicy) error {
+	return dbpkg.Upsert(db, &policy)
+}
+
+func DeleteApprovalPolicyByID(db *buntdb.DB, id string) error {
+	return dbpkg.DeleteByID[acl.ApprovalPolicy](db, id)
+}
@@ -0,0 +1,27 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+func CreateApplicationComment(db *buntdb.DB, comment acl.ApplicationComment) error {
+	return dbpkg.Insert(db, &comment)
+}
+
+func GetApplicationCommentByID(db *buntdb.DB, id string) (acl.ApplicationComment, error) {
+	return dbpkg.GetByID[acl.ApplicationComment](db, id)
+}
+
+// ListCommentsByApplicationID returns the comments of an application, oldest first.
+func ListCommentsByApplicationID(db *buntdb.DB, appID string) ([]acl.ApplicationComment, error) {
+	comments, err := dbpkg.SelectAll[acl.ApplicationComment](db, fmt.Sprintf(">=%s:0", appID), acl.IdxAppComment_ApplicationID)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return []acl.ApplicationComment{}, nil
+	}
+	return comments, err
+}
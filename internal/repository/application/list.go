@@ -1,48 +1,43 @@
 package application
 
 import (
-	"encoding/json"
+	"errors"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
 	"github.com/tidwall/buntdb"
 )
 
+// ListAllApplications returns every Application, in no particular order.
+func ListAllApplications(db *buntdb.DB) ([]acl.Application, error) {
+	apps, err := dbpkg.SelectAll[acl.Application](db, "*", acl.IdxApplication_Type)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return []acl.Application{}, nil
+	}
+	return apps, err
+}
+
 // ListApplicationsByUserID returns all Applications for a given userID.
 func ListApplicationsByUserID(db *buntdb.DB, userID string) ([]acl.Application, error) {
-	var apps []acl.Application
-	prefix := permissionApplicationPrefix + userID + ":"
-	err := db.View(func(tx *buntdb.Tx) error {
-		return tx.AscendKeys(prefix+"*", func(key, value string) bool {
-			var app acl.Application
-			if err := json.Unmarshal([]byte(value), &app); err == nil {
-				apps = append(apps, app)
-			}
-			return true
-		})
-	})
-	if err != nil {
-		return nil, err
+	apps, err := dbpkg.SelectAll[acl.Application](db, "="+userID, acl.IdxApplication_UserID)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return []acl.Application{}, nil
 	}
-	return apps, nil
+	return apps, err
 }
 
 // ListAssignmentsByReviewerID returns all ApplicationAssignments for a given reviewerID.
 func ListAssignmentsByReviewerID(db *buntdb.DB, reviewerID string) ([]acl.ApplicationAssignment, error) {
-	var assignments []acl.ApplicationAssignment
-	prefix := "app_assign:"
-	err := db.View(func(tx *buntdb.Tx) error {
-		return tx.AscendKeys(prefix+"*", func(key, value string) bool {
-			var assignment acl.ApplicationAssignment
-			if err := json.Unmarshal([]byte(value), &assignment); err == nil {
-				if assignment.ReviewerID == reviewerID {
-					assignments = append(assignments, assignment)
-				}
-			}
-			return true
-		})
-	})
-	if err != nil {
+	assignments, err := dbpkg.SelectAll[acl.ApplicationAssignment](db, ">="+reviewerID+":0", acl.IdxAppAssign_ReviewerID)
+	if err != nil && !errors.Is(err, dbpkg.ErrNotFound) {
 		return nil, err
 	}
-	return assignments, nil
+	// the range matches on prefix, drop the reviewers whose id only starts with reviewerID
+	result := []acl.ApplicationAssignment{}
+	for _, assignment := range assignments {
+		if assignment.ReviewerID == reviewerID {
+			result = append(result, assignment)
+		}
+	}
+	return result, nil
 }
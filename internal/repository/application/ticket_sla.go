@@ -0,0 +1,34 @@
+package application
+
+import (
+	"errors"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+func ListTicketSLAs(db *buntdb.DB) ([]acl.TicketSLA, error) {
+	slas, err := dbpkg.SelectAll[acl.TicketSLA](db, "*", acl.IdxTicketSLA_Type)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return []acl.TicketSLA{}, nil
+	}
+	return slas, err
+}
+
+func UpsertTicketSLA(db *buntdb.DB, sla acl.TicketSLA) error {
+	return dbpkg.Upsert(db, &sla)
+}
+
+func DeleteTicketSLA(db *buntdb.DB, applicationType string) error {
+	return dbpkg.DeleteByID[acl.TicketSLA](db, applicationType)
+}
+
+// ListApplicationsByStatus returns every application with the given status.
+func ListApplicationsByStatus(db *buntdb.DB, status string) ([]acl.Application, error) {
+	apps, err := dbpkg.SelectAll[acl.Application](db, "="+status, acl.IdxApplication_Status)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return []acl.Application{}, nil
+	}
+	return apps, err
+}
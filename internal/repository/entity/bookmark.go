@@ -52,3 +52,32 @@ func ListBookmarkedTopicIDsByUser(dbConn *buntdb.DB, userID, entityType string)
 	}
 	return ids, nil
 }
+
+// DeleteBookmarksByUser removes every bookmark of a user, whatever the entity type.
+func DeleteBookmarksByUser(dbConn *buntdb.DB, userID string) error {
+	bookmarks, err := db.SelectAll[entity.Bookmark](dbConn, ">="+userID+":", entity.IdxBookmark_UserID_EntityType)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, b := range bookmarks {
+		// the range matches on prefix, skip the users whose id only starts with userID
+		if b.UserID != userID {
+			continue
+		}
+		if err := db.DeleteByID[entity.Bookmark](dbConn, b.GetPrimaryKey("")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ListAllBookmarks(dbConn *buntdb.DB) ([]entity.Bookmark, error) {
+	bookmarks, err := db.SelectAll[entity.Bookmark](dbConn, "*", entity.IdxBookmark_UserID_EntityType)
+	if errors.Is(err, db.ErrNotFound) {
+		return []entity.Bookmark{}, nil
+	}
+	return bookmarks, err
+}
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// GetTopicDecoder returns the decoder configured for the topic entity, or db.ErrNotFound.
+func GetTopicDecoder(dbConn *buntdb.DB, entityID string) (entity.TopicDecoder, error) {
+	return db.GetByID[entity.TopicDecoder](dbConn, entityID)
+}
+
+func UpsertTopicDecoder(dbConn *buntdb.DB, decoder *entity.TopicDecoder) error {
+	return db.Upsert(dbConn, decoder)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// GetEntityDefaultPermission returns the default permission of an action on the entity,
+// with the default audience when none is stored.
+func GetEntityDefaultPermission(dbConn *buntdb.DB, entityID, action string) (entity.EntityDefaultPermission, error) {
+	perm, err := db.SelectOne[entity.EntityDefaultPermission](dbConn, entityID+":"+action, entity.IdxEntityDefaultPermission_EntityPermission)
+	if errors.Is(err, db.ErrNotFound) {
+		return entity.EntityDefaultPermission{
+			EntityID:       entityID,
+			PermissionName: action,
+			Audience:       entity.DefaultAudience(entityID),
+		}, nil
+	}
+	return perm, err
+}
+
+func ListEntityDefaultPermissions(dbConn *buntdb.DB, entityID string) ([]entity.EntityDefaultPermission, error) {
+	perms, err := db.SelectAll[entity.EntityDefaultPermission](dbConn, "="+entityID, entity.IdxEntityDefaultPermission_Entity)
+	if errors.Is(err, db.ErrNotFound) {
+		return []entity.EntityDefaultPermission{}, nil
+	}
+	return perms, err
+}
+
+// ReplaceEntityDefaultPermissions stores perms as the whole policy of the entity.
+func ReplaceEntityDefaultPermissions(dbConn *buntdb.DB, entityID string, perms []entity.EntityDefaultPermission) error {
+	existing, err := ListEntityDefaultPermissions(dbConn, entityID)
+	if err != nil {
+		return err
+	}
+	for _, perm := range existing {
+		if err := db.DeleteByID[entity.EntityDefaultPermission](dbConn, perm.ID); err != nil {
+			return fmt.Errorf("failed to delete default permission %s: %w", perm.PermissionName, err)
+		}
+	}
+	for _, perm := range perms {
+		if err := db.Insert(dbConn, &perm); err != nil {
+			return fmt.Errorf("failed to save default permission %s: %w", perm.PermissionName, err)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/internal/model/entity"
@@ -14,9 +15,13 @@ func InitIndexEntity(db *buntdb.DB) error {
 	indexes := entity.Entity{}.GetIndexes()
 	bookmarkIndexes := entity.Bookmark{}.GetIndexes()
 	permissionMapIndexes := acl.PermissionMap{}.GetIndexes()
+	revocationIndexes := acl.GrantRevocation{}.GetIndexes()
+	defaultPermissionIndexes := entity.EntityDefaultPermission{}.GetIndexes()
 
 	indexes = append(indexes, bookmarkIndexes...)
 	indexes = append(indexes, permissionMapIndexes...)
+	indexes = append(indexes, revocationIndexes...)
+	indexes = append(indexes, defaultPermissionIndexes...)
 
 	for _, index := range indexes {
 		err := db.CreateIndex(index.Name, index.Pattern, index.Type)
@@ -42,9 +47,16 @@ func GetEntityByID(dbConn *buntdb.DB, id string) (entity.Entity, error) {
 // GetPermissionMapByActionEntityUser fetches a PermissionMap by action, entityID, and userID
 func GetPermissionMapByActionEntityUser(dbConn *buntdb.DB, userID, entityID, action string) (acl.PermissionMap, error) {
 	pivot := action + ":" + entityID + ":" + userID
-	perm, err := db.SelectOne[acl.PermissionMap](dbConn, pivot, acl.IdxPermissionMap_ActionEntityUser)
+	perms, err := db.SelectAll[acl.PermissionMap](dbConn, "="+pivot, acl.IdxPermissionMap_ActionEntityUser)
 	if err != nil {
 		return acl.PermissionMap{}, errors.New("permission not found")
 	}
-	return perm, nil
+	// expired grants stay in the db until the janitor removes them
+	now := time.Now()
+	for _, perm := range perms {
+		if !perm.IsExpired(now) {
+			return perm, nil
+		}
+	}
+	return acl.PermissionMap{}, errors.New("permission not found")
 }
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// GetTopicMasking returns the masking rules of the topic entity, or db.ErrNotFound.
+func GetTopicMasking(dbConn *buntdb.DB, entityID string) (entity.TopicMasking, error) {
+	return db.GetByID[entity.TopicMasking](dbConn, entityID)
+}
+
+func UpsertTopicMasking(dbConn *buntdb.DB, masking *entity.TopicMasking) error {
+	return db.Upsert(dbConn, masking)
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// ListEntitiesByGroupID returns all the entities owned by the group
+func ListEntitiesByGroupID(dbConn *buntdb.DB, groupID string) ([]entity.Entity, error) {
+	entities, err := db.SelectAll[entity.Entity](dbConn, "="+groupID, entity.IdxEntity_Group)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func UpdateEntity(dbConn *buntdb.DB, ent entity.Entity) error {
+	return db.Update(dbConn, &ent)
+}
+
+// MigrateGroupOwnerID fills the owner id of the entities stored before ownership was kept by group id.
+// Entities owned by a group that does not exist anymore go to root: released, they would fall back
+// to the default of unowned entities, open to anyone. Any other lookup failure aborts the migration.
+func MigrateGroupOwnerID(tx *buntdb.Tx) error {
+	_, err := db.RewriteRecordsTx[entity.Entity](tx, entity.IdxEntity_TypeID, func(ent *entity.Entity) (bool, error) {
+		if ent.GroupOwnerID != "" {
+			return false, nil
+		}
+		switch ent.GroupOwner {
+		case "", entity.GroupNone:
+			ent.SetOwner(entity.GroupNone, "")
+			return true, nil
+		}
+		group, err := db.SelectOneTx[acl.Group](tx, ent.GroupOwner, acl.IdxGroup_Name)
+		if errors.Is(err, db.ErrNotFound) {
+			group, err = db.SelectOneTx[acl.Group](tx, acl.GroupRoot, acl.IdxGroup_Name)
+			if err != nil {
+				return false, fmt.Errorf("entity %s: owner group %s not found, failed to get the root group: %w", ent.ID, ent.GroupOwner, err)
+			}
+			log.Printf("entity %s: owner group %s not found, moving the entity to %s", ent.ID, ent.GroupOwner, acl.GroupRoot)
+		} else if err != nil {
+			return false, fmt.Errorf("entity %s: failed to get owner group %s: %w", ent.ID, ent.GroupOwner, err)
+		}
+		ent.SetOwner(group.ID, group.Name)
+		return true, nil
+	})
+	return err
+}
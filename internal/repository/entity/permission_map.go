@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// ListPermissionMaps returns every grant, including expired ones.
+func ListPermissionMaps(dbConn *buntdb.DB) ([]acl.PermissionMap, error) {
+	perms, err := db.SelectAll[acl.PermissionMap](dbConn, "*", acl.IdxPermissionMap_Entity)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.PermissionMap{}, nil
+	}
+	return perms, err
+}
+
+func ListPermissionMapsByEntity(dbConn *buntdb.DB, entityID string) ([]acl.PermissionMap, error) {
+	perms, err := db.SelectAll[acl.PermissionMap](dbConn, "="+entityID, acl.IdxPermissionMap_Entity)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.PermissionMap{}, nil
+	}
+	return perms, err
+}
+
+func GetPermissionMapByID(dbConn *buntdb.DB, id string) (acl.PermissionMap, error) {
+	return db.GetByID[acl.PermissionMap](dbConn, id)
+}
+
+func DeletePermissionMapByID(dbConn *buntdb.DB, id string) error {
+	return db.DeleteByID[acl.PermissionMap](dbConn, id)
+}
+
+// ListPermissionMapsByUser returns every grant of a user, including expired ones.
+// There is no user index on grants, so it scans them all.
+func ListPermissionMapsByUser(dbConn *buntdb.DB, userID string) ([]acl.PermissionMap, error) {
+	perms, err := ListPermissionMaps(dbConn)
+	if err != nil {
+		return nil, err
+	}
+	result := []acl.PermissionMap{}
+	for _, perm := range perms {
+		if perm.UserID == userID {
+			result = append(result, perm)
+		}
+	}
+	return result, nil
+}
+
+func CreateGrantRevocation(dbConn *buntdb.DB, revocation acl.GrantRevocation) error {
+	return db.Insert(dbConn, &revocation)
+}
+
+func ListGrantRevocationsByEntity(dbConn *buntdb.DB, entityID string) ([]acl.GrantRevocation, error) {
+	revocations, err := db.SelectAll[acl.GrantRevocation](dbConn, "="+entityID, acl.IdxGrantRevocation_Entity)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.GrantRevocation{}, nil
+	}
+	return revocations, err
+}
+
+func ListGrantRevocationsByUser(dbConn *buntdb.DB, userID string) ([]acl.GrantRevocation, error) {
+	revocations, err := db.SelectAll[acl.GrantRevocation](dbConn, "="+userID, acl.IdxGrantRevocation_User)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.GrantRevocation{}, nil
+	}
+	return revocations, err
+}
@@ -11,14 +11,15 @@ import (
 
 func CreateNsqTopicEntity(dbConn *buntdb.DB, topic string) (*entity.Entity, error) {
 	entityObj := &entity.Entity{
-		ID:         uuid.NewString(),
-		TypeID:     entity.EntityType_NSQTopic,
-		Name:       topic,
-		Resource:   "NSQ",
-		Status:     "active",
-		GroupOwner: entity.GroupNone,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		ID:           uuid.NewString(),
+		TypeID:       entity.EntityType_NSQTopic,
+		Name:         topic,
+		Resource:     "NSQ",
+		Status:       "active",
+		GroupOwnerID: entity.GroupNone,
+		GroupOwner:   entity.GroupNone,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 	if err := db.Insert(dbConn, entityObj); err != nil {
 		return nil, err
@@ -48,9 +49,9 @@ func DeleteNsqTopicEntity(dbConn *buntdb.DB, topic string) error {
 	return db.DeleteByIndex(dbConn, tmp, entity.IdxEntity_TypeName)
 }
 
-// ListNsqTopicEntitiesByGroup returns all nsq topic entities owned by the given group. If group is entity.GroupRoot, returns all topics.
-func ListNsqTopicEntitiesByGroup(dbConn *buntdb.DB, group string) ([]entity.Entity, error) {
-	pivot := group + ":" + entity.EntityType_NSQTopic
+// ListNsqTopicEntitiesByGroup returns all nsq topic entities owned by the given group id.
+func ListNsqTopicEntitiesByGroup(dbConn *buntdb.DB, groupID string) ([]entity.Entity, error) {
+	pivot := groupID + ":" + entity.EntityType_NSQTopic
 	entities, err := db.SelectAll[entity.Entity](dbConn, "="+pivot, entity.IdxEntity_GroupType)
 	if err != nil {
 		return nil, err
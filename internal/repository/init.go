@@ -5,6 +5,7 @@ import (
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/internal/repository/application"
 	"github.com/jekiapp/topic-master/internal/repository/entity"
+	"github.com/jekiapp/topic-master/internal/repository/notification"
 	"github.com/jekiapp/topic-master/internal/repository/nsq"
 	"github.com/jekiapp/topic-master/internal/repository/user"
 	"github.com/tidwall/buntdb"
@@ -35,7 +36,26 @@ func Init(cfg *config.Config, db *buntdb.DB) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	err = user.InitIndexDelegation(db)
+	if err != nil {
+		return err
+	}
+	err = user.InitIndexCustomRole(db)
+	if err != nil {
+		return err
+	}
+	err = user.InitIndexSecurity(db)
+	if err != nil {
+		return err
+	}
+	err = notification.InitIndexNotification(db)
+	if err != nil {
+		return err
+	}
+
+	// the migrations need the indexes above
+	_, err = Migrate(cfg, db)
+	return err
 }
 
 func InitIndexResetPassword(db *buntdb.DB) error {
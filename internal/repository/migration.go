@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/repository/entity"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// migrations upgrade the data stored by earlier versions. Append a new one with the next
+// version when a stored struct or the value of an index changes, an applied migration is
+// never run again so it must not be changed or removed.
+var migrations = []db.Migration{
+	{
+		Version:     1,
+		Description: "entities are owned by group id instead of group name",
+		Migrate:     entity.MigrateGroupOwnerID,
+	},
+}
+
+// Migrate applies the pending migrations, after saving the data to cfg.BackupDir.
+func Migrate(cfg *config.Config, dbConn *buntdb.DB) ([]db.Migration, error) {
+	return db.Migrate(dbConn, migrations, cfg.BackupDir)
+}
+
+// PendingMigrations returns the migrations the next start would apply.
+func PendingMigrations(dbConn *buntdb.DB) ([]db.Migration, error) {
+	return db.PendingMigrations(dbConn, migrations)
+}
@@ -0,0 +1,64 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+func InitIndexNotification(db *buntdb.DB) error {
+	for _, index := range (notification.Notification{}).GetIndexes() {
+		if err := db.CreateIndex(index.Name, index.Pattern, index.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func CreateNotification(db *buntdb.DB, n notification.Notification) error {
+	return dbpkg.Insert(db, &n)
+}
+
+func GetNotificationByID(db *buntdb.DB, id string) (notification.Notification, error) {
+	return dbpkg.GetByID[notification.Notification](db, id)
+}
+
+func UpdateNotification(db *buntdb.DB, n notification.Notification) error {
+	return dbpkg.Update(db, &n)
+}
+
+// ListNotificationsByUserID returns the inbox of a user, newest first.
+// A nil pagination returns the whole inbox.
+func ListNotificationsByUserID(db *buntdb.DB, userID string, pagination *dbpkg.Pagination) ([]notification.Notification, error) {
+	pivot := fmt.Sprintf("-<=%s:%d", userID, time.Now().UnixNano())
+	items, err := dbpkg.SelectPaginated[notification.Notification](db, pivot, notification.IdxNotification_UserID, pagination)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return []notification.Notification{}, nil
+	}
+	return items, err
+}
+
+// GetPreference returns the preference of a user, or the default one if they never saved it.
+func GetPreference(db *buntdb.DB, userID string) (notification.Preference, error) {
+	pref, err := dbpkg.GetByID[notification.Preference](db, userID)
+	if errors.Is(err, dbpkg.ErrNotFound) {
+		return notification.DefaultPreference(userID), nil
+	}
+	return pref, err
+}
+
+func UpsertPreference(db *buntdb.DB, pref notification.Preference) error {
+	return dbpkg.Upsert(db, &pref)
+}
+
+// DeletePreference drops the saved preference of a user, it is not an error when there is none.
+func DeletePreference(db *buntdb.DB, userID string) error {
+	if _, err := dbpkg.GetByID[notification.Preference](db, userID); errors.Is(err, dbpkg.ErrNotFound) {
+		return nil
+	}
+	return dbpkg.DeleteByID[notification.Preference](db, userID)
+}
@@ -51,16 +51,17 @@ func GetAllNsqTopicChannels(db *buntdb.DB, topic string) ([]entity.Entity, error
 // CreateNsqChannelEntity creates a channel entity in the database
 func CreateNsqChannelEntity(db *buntdb.DB, topic, channel string) (*entity.Entity, error) {
 	entity := &entity.Entity{
-		ID:          uuid.NewString(),
-		Name:        channel,
-		TypeID:      entity.EntityType_NSQChannel,
-		Resource:    entity.EntityResource_NSQ,
-		Status:      entity.EntityStatus_Active,
-		Description: "NSQ channel",
-		Metadata:    map[string]string{"topic": topic},
-		GroupOwner:  entity.GroupNone,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:           uuid.NewString(),
+		Name:         channel,
+		TypeID:       entity.EntityType_NSQChannel,
+		Resource:     entity.EntityResource_NSQ,
+		Status:       entity.EntityStatus_Active,
+		Description:  "NSQ channel",
+		Metadata:     map[string]string{"topic": topic},
+		GroupOwnerID: entity.GroupNone,
+		GroupOwner:   entity.GroupNone,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 	if err := dbPkg.Insert(db, entity); err != nil {
 		return nil, err
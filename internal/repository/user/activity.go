@@ -0,0 +1,38 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// GetUserActivity returns when the user was last seen, an empty record when never.
+func GetUserActivity(dbConn *buntdb.DB, userID string) (acl.UserActivity, error) {
+	activity, err := db.GetByID[acl.UserActivity](dbConn, userID)
+	if errors.Is(err, db.ErrNotFound) {
+		return acl.UserActivity{UserID: userID}, nil
+	}
+	return activity, err
+}
+
+func UpsertUserActivity(dbConn *buntdb.DB, activity acl.UserActivity) error {
+	return db.Upsert(dbConn, &activity)
+}
+
+func ListUserActivities(dbConn *buntdb.DB) ([]acl.UserActivity, error) {
+	activities, err := db.SelectAll[acl.UserActivity](dbConn, "*", acl.IdxUserActivity_Username)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.UserActivity{}, nil
+	}
+	return activities, err
+}
+
+// DeleteUserActivity forgets the activity of a user, it is not an error when there is none.
+func DeleteUserActivity(dbConn *buntdb.DB, userID string) error {
+	if _, err := db.GetByID[acl.UserActivity](dbConn, userID); errors.Is(err, db.ErrNotFound) {
+		return nil
+	}
+	return db.DeleteByID[acl.UserActivity](dbConn, userID)
+}
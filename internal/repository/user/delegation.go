@@ -0,0 +1,58 @@
+package user
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+func InitIndexDelegation(dbConn *buntdb.DB) error {
+	for _, index := range (acl.Delegation{}).GetIndexes() {
+		if err := dbConn.CreateIndex(index.Name, index.Pattern, index.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func GetDelegationByUserID(dbConn *buntdb.DB, userID string) (acl.Delegation, error) {
+	return db.GetByID[acl.Delegation](dbConn, userID)
+}
+
+func UpsertDelegation(dbConn *buntdb.DB, delegation acl.Delegation) error {
+	return db.Upsert(dbConn, &delegation)
+}
+
+func DeleteDelegation(dbConn *buntdb.DB, userID string) error {
+	return db.DeleteByID[acl.Delegation](dbConn, userID)
+}
+
+// ListDelegationsByDelegateID returns the delegations naming the user as delegate, active or not.
+func ListDelegationsByDelegateID(dbConn *buntdb.DB, delegateID string) ([]acl.Delegation, error) {
+	delegations, err := db.SelectAll[acl.Delegation](dbConn, "="+delegateID, acl.IdxDelegation_DelegateID)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.Delegation{}, nil
+	}
+	return delegations, err
+}
+
+// GetActiveDelegateID returns the delegate standing in for the user at now, if any.
+func GetActiveDelegateID(dbConn *buntdb.DB, userID string, now time.Time) (string, bool) {
+	delegation, err := GetDelegationByUserID(dbConn, userID)
+	if err != nil || !delegation.IsActive(now) || delegation.DelegateID == userID {
+		return "", false
+	}
+	return delegation.DelegateID, true
+}
+
+// GetActiveDelegateIDTx is GetActiveDelegateID inside the transaction tx.
+func GetActiveDelegateIDTx(tx *buntdb.Tx, userID string, now time.Time) (string, bool) {
+	delegation, err := db.GetByIDTx[acl.Delegation](tx, userID)
+	if err != nil || !delegation.IsActive(now) || delegation.DelegateID == userID {
+		return "", false
+	}
+	return delegation.DelegateID, true
+}
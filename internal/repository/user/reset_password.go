@@ -0,0 +1,43 @@
+package user
+
+import (
+	"errors"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+func InsertResetPassword(dbConn *buntdb.DB, rp acl.ResetPassword) error {
+	return db.Insert(dbConn, &rp)
+}
+
+func ListResetPasswords(dbConn *buntdb.DB) ([]acl.ResetPassword, error) {
+	rps, err := db.SelectAll[acl.ResetPassword](dbConn, "*", acl.IdxResetPassword_Username)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.ResetPassword{}, nil
+	}
+	return rps, err
+}
+
+func DeleteResetPassword(dbConn *buntdb.DB, hashedToken string) error {
+	return db.DeleteByID[acl.ResetPassword](dbConn, hashedToken)
+}
+
+// DeleteResetPasswordsByUsername revokes the reset and invitation links of a user,
+// done before a new link is sent so only the latest one works.
+func DeleteResetPasswordsByUsername(dbConn *buntdb.DB, username string) error {
+	rps, err := db.SelectAll[acl.ResetPassword](dbConn, "="+username, acl.IdxResetPassword_Username)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, rp := range rps {
+		if err := db.DeleteByID[acl.ResetPassword](dbConn, rp.Token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
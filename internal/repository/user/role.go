@@ -0,0 +1,48 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+func InitIndexCustomRole(dbConn *buntdb.DB) error {
+	for _, index := range (acl.CustomRole{}).GetIndexes() {
+		if err := dbConn.CreateIndex(index.Name, index.Pattern, index.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCustomRole returns the custom role of a group by name.
+func GetCustomRole(dbConn *buntdb.DB, groupID, name string) (acl.CustomRole, error) {
+	return db.SelectOne[acl.CustomRole](dbConn, fmt.Sprintf("%s:%s", groupID, name), acl.IdxCustomRole_GroupName)
+}
+
+func GetCustomRoleByID(dbConn *buntdb.DB, id string) (acl.CustomRole, error) {
+	return db.GetByID[acl.CustomRole](dbConn, id)
+}
+
+func ListCustomRolesByGroupID(dbConn *buntdb.DB, groupID string) ([]acl.CustomRole, error) {
+	roles, err := db.SelectAll[acl.CustomRole](dbConn, "="+groupID, acl.IdxCustomRole_GroupID)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.CustomRole{}, nil
+	}
+	return roles, err
+}
+
+func CreateCustomRole(dbConn *buntdb.DB, role acl.CustomRole) error {
+	return db.Insert(dbConn, &role)
+}
+
+func UpdateCustomRole(dbConn *buntdb.DB, role acl.CustomRole) error {
+	return db.Update(dbConn, &role)
+}
+
+func DeleteCustomRole(dbConn *buntdb.DB, id string) error {
+	return db.DeleteByID[acl.CustomRole](dbConn, id)
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+func InitIndexSecurity(dbConn *buntdb.DB) error {
+	indexes := append(acl.SecurityEvent{}.GetIndexes(), acl.LoginLockout{}.GetIndexes()...)
+	for _, index := range indexes {
+		err := dbConn.CreateIndex(index.Name, index.Pattern, index.Type)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertSecurityEvent stores an audit event, the ID and time are set when empty.
+func InsertSecurityEvent(dbConn *buntdb.DB, event acl.SecurityEvent) error {
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	return db.Insert(dbConn, &event)
+}
+
+// ListSecurityEvents returns the audit events, newest first.
+func ListSecurityEvents(dbConn *buntdb.DB, pagination *db.Pagination) ([]acl.SecurityEvent, error) {
+	pivot := fmt.Sprintf("-<=%020d", time.Now().UnixNano())
+	events, err := db.SelectPaginated[acl.SecurityEvent](dbConn, pivot, acl.IdxSecurityEvent_CreatedAt, pagination)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.SecurityEvent{}, nil
+	}
+	return events, err
+}
+
+// GetLoginLockout returns the failed logins of a user, an empty record when there are none.
+func GetLoginLockout(dbConn *buntdb.DB, userID string) (acl.LoginLockout, error) {
+	lockout, err := db.GetByID[acl.LoginLockout](dbConn, userID)
+	if errors.Is(err, db.ErrNotFound) {
+		return acl.LoginLockout{UserID: userID}, nil
+	}
+	return lockout, err
+}
+
+func UpsertLoginLockout(dbConn *buntdb.DB, lockout acl.LoginLockout) error {
+	return db.Upsert(dbConn, &lockout)
+}
+
+// DeleteLoginLockout forgets the failed logins of a user, it is not an error when there are none.
+func DeleteLoginLockout(dbConn *buntdb.DB, userID string) error {
+	if _, err := db.GetByID[acl.LoginLockout](dbConn, userID); errors.Is(err, db.ErrNotFound) {
+		return nil
+	}
+	return db.DeleteByID[acl.LoginLockout](dbConn, userID)
+}
+
+func ListLoginLockouts(dbConn *buntdb.DB) ([]acl.LoginLockout, error) {
+	lockouts, err := db.SelectAll[acl.LoginLockout](dbConn, "*", acl.IdxLoginLockout_Username)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.LoginLockout{}, nil
+	}
+	return lockouts, err
+}
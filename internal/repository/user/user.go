@@ -7,7 +7,7 @@ import (
 )
 
 func InitIndexUser(db *buntdb.DB) error {
-	indexes := acl.User{}.GetIndexes()
+	indexes := append(acl.User{}.GetIndexes(), acl.UserActivity{}.GetIndexes()...)
 	for _, index := range indexes {
 		err := db.CreateIndex(index.Name, index.Pattern, index.Type)
 		if err != nil {
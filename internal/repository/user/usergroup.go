@@ -77,3 +77,11 @@ func GetAdminUserIDsByGroupID(dbConn *buntdb.DB, groupID string) ([]string, erro
 func ListUserGroupsByUserID(dbConn *buntdb.DB, userID string) ([]acl.UserGroup, error) {
 	return db.SelectAll[acl.UserGroup](dbConn, "="+userID, acl.IdxUserGroup_UserID)
 }
+
+func UpdateUserGroup(dbConn *buntdb.DB, userGroup acl.UserGroup) error {
+	return db.Update(dbConn, &userGroup)
+}
+
+func DeleteUserGroup(dbConn *buntdb.DB, userGroupID string) error {
+	return db.DeleteByID[acl.UserGroup](dbConn, userGroupID)
+}
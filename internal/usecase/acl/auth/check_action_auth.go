@@ -47,6 +47,14 @@ func (r *checkActionAuthRepo) GetPermissionByActionEntity(userID, entityID, acti
 	return entityrepo.GetPermissionMapByActionEntityUser(r.db, userID, entityID, action)
 }
 
+func (r *checkActionAuthRepo) GetCustomRole(groupID, name string) (acl.CustomRole, error) {
+	return userrepo.GetCustomRole(r.db, groupID, name)
+}
+
+func (r *checkActionAuthRepo) GetEntityDefaultPermission(entityID, action string) (entitymodel.EntityDefaultPermission, error) {
+	return entityrepo.GetEntityDefaultPermission(r.db, entityID, action)
+}
+
 type CheckActionAuthUsecase struct {
 	repo iCheckActionAuthRepo
 }
@@ -42,8 +42,35 @@ func TestCheckActionAuthUsecase_Handle(t *testing.T) {
 			req:  CheckActionAuthRequest{EntityID: "e1", Action: "write"},
 			setup: func() {
 				mockRepo.EXPECT().GetEntityByID("e1").Return(&entity.Entity{}, nil)
+				mockRepo.EXPECT().GetEntityDefaultPermission(entity.DefaultPermission_Unowned, "write").Return(entity.EntityDefaultPermission{Audience: entity.Audience_None}, nil)
 				mockRepo.EXPECT().GetGroupsByUserID("u1").Return([]acl.GroupRole{}, nil)
-				mockRepo.EXPECT().GetPermissionByActionEntity("u1", "e1", "write").Return(acl.PermissionMap{}, errors.New("denied"))
+			},
+			want:    CheckActionAuthResponse{Allowed: false, Error: "permission denied"},
+			wantErr: false,
+		},
+		{
+			name: "custom role holds the action",
+			ctx:  context.WithValue(context.Background(), model.UserInfoKey, &acl.JWTClaims{UserID: "u3", Groups: []acl.GroupRole{{GroupID: "g1", Role: "operator"}}}),
+			req:  CheckActionAuthRequest{EntityID: "e3", Action: "topic:pause"},
+			setup: func() {
+				mockRepo.EXPECT().GetEntityByID("e3").Return(&entity.Entity{ID: "e3", GroupOwnerID: "g1", GroupOwner: "payments"}, nil)
+				mockRepo.EXPECT().GetEntityDefaultPermission("e3", "topic:pause").Return(entity.EntityDefaultPermission{Audience: entity.Audience_None}, nil)
+				mockRepo.EXPECT().GetGroupsByUserID("u3").Return([]acl.GroupRole{{GroupID: "g1", Role: "operator"}}, nil)
+				mockRepo.EXPECT().GetCustomRole("g1", "operator").Return(acl.CustomRole{Name: "operator", Permissions: []string{"topic:pause", "topic:empty"}}, nil)
+			},
+			want:    CheckActionAuthResponse{Allowed: true},
+			wantErr: false,
+		},
+		{
+			name: "custom role lacks the action",
+			ctx:  context.WithValue(context.Background(), model.UserInfoKey, &acl.JWTClaims{UserID: "u3", Groups: []acl.GroupRole{{GroupID: "g1", Role: "viewer"}}}),
+			req:  CheckActionAuthRequest{EntityID: "e3", Action: "topic:delete"},
+			setup: func() {
+				mockRepo.EXPECT().GetEntityByID("e3").Return(&entity.Entity{ID: "e3", GroupOwnerID: "g1", GroupOwner: "payments"}, nil)
+				mockRepo.EXPECT().GetEntityDefaultPermission("e3", "topic:delete").Return(entity.EntityDefaultPermission{Audience: entity.Audience_None}, nil)
+				mockRepo.EXPECT().GetGroupsByUserID("u3").Return([]acl.GroupRole{{GroupID: "g1", Role: "viewer"}}, nil)
+				mockRepo.EXPECT().GetCustomRole("g1", "viewer").Return(acl.CustomRole{Name: "viewer", Permissions: []string{"topic:tail"}}, nil)
+				mockRepo.EXPECT().GetPermissionByActionEntity("u3", "e3", "topic:delete").Return(acl.PermissionMap{}, errors.New("not found"))
 			},
 			want:    CheckActionAuthResponse{Allowed: false, Error: "permission denied"},
 			wantErr: false,
@@ -54,6 +81,42 @@ func TestCheckActionAuthUsecase_Handle(t *testing.T) {
 			req:  CheckActionAuthRequest{EntityID: "e2", Action: "read"},
 			setup: func() {
 				mockRepo.EXPECT().GetEntityByID("e2").Return(&entity.Entity{GroupOwner: ""}, nil)
+				mockRepo.EXPECT().GetEntityDefaultPermission(entity.DefaultPermission_Unowned, "read").Return(entity.EntityDefaultPermission{Audience: entity.Audience_Anonymous}, nil)
+			},
+			want:    CheckActionAuthResponse{Allowed: true},
+			wantErr: false,
+		},
+		{
+			name: "default permission opens owned entity to logged in users",
+			ctx:  context.WithValue(context.Background(), model.UserInfoKey, &acl.JWTClaims{UserID: "u4"}),
+			req:  CheckActionAuthRequest{EntityID: "e4", Action: "topic:tail"},
+			setup: func() {
+				mockRepo.EXPECT().GetEntityByID("e4").Return(&entity.Entity{ID: "e4", GroupOwnerID: "g1", GroupOwner: "payments"}, nil)
+				mockRepo.EXPECT().GetEntityDefaultPermission("e4", "topic:tail").Return(entity.EntityDefaultPermission{Audience: entity.Audience_Authenticated}, nil)
+			},
+			want:    CheckActionAuthResponse{Allowed: true},
+			wantErr: false,
+		},
+		{
+			name: "global default restricts unowned entity",
+			ctx:  context.WithValue(context.Background(), model.UserInfoKey, &acl.JWTClaims{UserID: "u5", Groups: []acl.GroupRole{{GroupID: "g2", GroupName: "ops", Role: acl.RoleGroupMember}}}),
+			req:  CheckActionAuthRequest{EntityID: "e5", Action: "topic:delete"},
+			setup: func() {
+				mockRepo.EXPECT().GetEntityByID("e5").Return(&entity.Entity{ID: "e5"}, nil)
+				mockRepo.EXPECT().GetEntityDefaultPermission(entity.DefaultPermission_Unowned, "topic:delete").Return(entity.EntityDefaultPermission{Audience: entity.Audience_None}, nil)
+				mockRepo.EXPECT().GetGroupsByUserID("u5").Return([]acl.GroupRole{{GroupID: "g2", GroupName: "ops", Role: acl.RoleGroupMember}}, nil)
+			},
+			want:    CheckActionAuthResponse{Allowed: false, Error: "permission denied"},
+			wantErr: false,
+		},
+		{
+			name: "root bypasses global default",
+			ctx:  context.WithValue(context.Background(), model.UserInfoKey, &acl.JWTClaims{UserID: "u6", Groups: []acl.GroupRole{{GroupID: "g0", GroupName: acl.GroupRoot, Role: acl.RoleGroupAdmin}}}),
+			req:  CheckActionAuthRequest{EntityID: "e5", Action: "topic:delete"},
+			setup: func() {
+				mockRepo.EXPECT().GetEntityByID("e5").Return(&entity.Entity{ID: "e5"}, nil)
+				mockRepo.EXPECT().GetEntityDefaultPermission(entity.DefaultPermission_Unowned, "topic:delete").Return(entity.EntityDefaultPermission{Audience: entity.Audience_None}, nil)
+				mockRepo.EXPECT().GetGroupsByUserID("u6").Return([]acl.GroupRole{{GroupID: "g0", GroupName: acl.GroupRoot, Role: acl.RoleGroupAdmin}}, nil)
 			},
 			want:    CheckActionAuthResponse{Allowed: true},
 			wantErr: false,
@@ -0,0 +1,79 @@
+package acl
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/tidwall/buntdb"
+)
+
+type IExpiredTokenJanitorRepo interface {
+	ListResetPasswords() ([]acl.ResetPassword, error)
+	DeleteResetPassword(hashedToken string) error
+}
+
+type expiredTokenJanitorRepo struct {
+	db *buntdb.DB
+}
+
+func (r *expiredTokenJanitorRepo) ListResetPasswords() ([]acl.ResetPassword, error) {
+	return userrepo.ListResetPasswords(r.db)
+}
+
+func (r *expiredTokenJanitorRepo) DeleteResetPassword(hashedToken string) error {
+	return userrepo.DeleteResetPassword(r.db, hashedToken)
+}
+
+// ExpiredTokenJanitor deletes the reset password and invitation tokens once they expire.
+// Expired tokens are already refused, the janitor only keeps the db clean.
+type ExpiredTokenJanitor struct {
+	repo IExpiredTokenJanitorRepo
+}
+
+func NewExpiredTokenJanitor(db *buntdb.DB) ExpiredTokenJanitor {
+	return ExpiredTokenJanitor{
+		repo: &expiredTokenJanitorRepo{db: db},
+	}
+}
+
+// Run purges expired tokens every interval until ctx is done.
+func (j ExpiredTokenJanitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deleted, err := j.PurgeExpired(now)
+			if err != nil {
+				log.Printf("[RESET] error purging expired tokens: %s", err)
+			}
+			if deleted > 0 {
+				log.Printf("[RESET] purged %d expired tokens", deleted)
+			}
+		}
+	}
+}
+
+// PurgeExpired deletes the tokens expired at now and returns how many were deleted.
+func (j ExpiredTokenJanitor) PurgeExpired(now time.Time) (int, error) {
+	rps, err := j.repo.ListResetPasswords()
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, rp := range rps {
+		if !rp.IsExpired(now) {
+			continue
+		}
+		if err := j.repo.DeleteResetPassword(rp.Token); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
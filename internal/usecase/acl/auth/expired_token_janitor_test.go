@@ -0,0 +1,72 @@
+package acl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/usecase/acl/auth/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiredTokenJanitor_PurgeExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	tests := []struct {
+		name        string
+		setupMock   func(m *mock.MockIExpiredTokenJanitorRepo)
+		wantErr     bool
+		wantDeleted int
+	}{
+		{
+			name: "list error",
+			setupMock: func(m *mock.MockIExpiredTokenJanitorRepo) {
+				m.EXPECT().ListResetPasswords().Return(nil, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name: "only expired tokens are deleted",
+			setupMock: func(m *mock.MockIExpiredTokenJanitorRepo) {
+				m.EXPECT().ListResetPasswords().Return([]acl.ResetPassword{
+					{Token: "no-expiry"},
+					{Token: "active", ExpiresAt: now.Add(time.Minute).Unix()},
+					{Token: "expired-reset", ExpiresAt: now.Add(-time.Minute).Unix()},
+					{Token: "expired-invite", Purpose: acl.ResetPurposeInvite, ExpiresAt: now.Add(-time.Hour).Unix()},
+				}, nil)
+				m.EXPECT().DeleteResetPassword("expired-reset").Return(nil)
+				m.EXPECT().DeleteResetPassword("expired-invite").Return(nil)
+			},
+			wantDeleted: 2,
+		},
+		{
+			name: "delete error stops the purge",
+			setupMock: func(m *mock.MockIExpiredTokenJanitorRepo) {
+				m.EXPECT().ListResetPasswords().Return([]acl.ResetPassword{
+					{Token: "expired-1", ExpiresAt: now.Add(-time.Hour).Unix()},
+					{Token: "expired-2", ExpiresAt: now.Add(-time.Hour).Unix()},
+				}, nil)
+				m.EXPECT().DeleteResetPassword("expired-1").Return(context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock.NewMockIExpiredTokenJanitorRepo(ctrl)
+			tt.setupMock(mockRepo)
+			j := ExpiredTokenJanitor{repo: mockRepo}
+			deleted, err := j.PurgeExpired(now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDeleted, deleted)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package acl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/logic/mail"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	notifrepo "github.com/jekiapp/topic-master/internal/repository/notification"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/tidwall/buntdb"
+)
+
+type ForgotPasswordRequest struct {
+	Username string `json:"username"`
+}
+
+type ForgotPasswordResponse struct {
+	Message string `json:"message"`
+}
+
+type IForgotPasswordRepo interface {
+	GetUserByUsername(username string) (acl.User, error)
+	GetUserEmail(userID string) (string, error)
+	DeleteResetPasswordsByUsername(username string) error
+	InsertResetPassword(rp acl.ResetPassword) error
+}
+
+type IMailSender interface {
+	Send(m mail.Mail) error
+}
+
+type forgotPasswordRepo struct {
+	db *buntdb.DB
+}
+
+func (r *forgotPasswordRepo) GetUserByUsername(username string) (acl.User, error) {
+	return userrepo.GetUserByUsername(r.db, username)
+}
+
+// GetUserEmail is the address of the notification preference, empty when the user never set it.
+func (r *forgotPasswordRepo) GetUserEmail(userID string) (string, error) {
+	pref, err := notifrepo.GetPreference(r.db, userID)
+	return pref.Email, err
+}
+
+func (r *forgotPasswordRepo) DeleteResetPasswordsByUsername(username string) error {
+	return userrepo.DeleteResetPasswordsByUsername(r.db, username)
+}
+
+func (r *forgotPasswordRepo) InsertResetPassword(rp acl.ResetPassword) error {
+	return userrepo.InsertResetPassword(r.db, rp)
+}
+
+// ForgotPasswordUsecase emails a reset link to a user who forgot their password.
+type ForgotPasswordUsecase struct {
+	repo   IForgotPasswordRepo
+	mailer IMailSender
+	config *config.Config
+}
+
+func NewForgotPasswordUsecase(db *buntdb.DB, cfg *config.Config) ForgotPasswordUsecase {
+	return ForgotPasswordUsecase{
+		repo:   &forgotPasswordRepo{db: db},
+		mailer: mail.NewSender(cfg),
+		config: cfg,
+	}
+}
+
+// the answer is the same whether the account exists or not, so the form can't be used to find usernames
+const forgotPasswordMessage = "If the account exists and has an email address, a reset link has been sent to it."
+
+// Handle sends the link to the email address of the notification preference. Only local accounts
+// that are active or waiting for their first password can reset it, the others get no email.
+func (uc ForgotPasswordUsecase) Handle(ctx context.Context, req ForgotPasswordRequest) (ForgotPasswordResponse, error) {
+	if uc.mailer == nil {
+		return ForgotPasswordResponse{}, errors.New("password reset by email is not enabled, ask an administrator to reset your password")
+	}
+	username := strings.TrimSpace(req.Username)
+	if username == "" {
+		return ForgotPasswordResponse{}, errors.New("username is required")
+	}
+	resp := ForgotPasswordResponse{Message: forgotPasswordMessage}
+
+	user, err := uc.repo.GetUserByUsername(username)
+	if err != nil {
+		return resp, nil
+	}
+	if user.Source != "" && user.Source != acl.UserSourceLocal {
+		log.Printf("[RESET] %s asked for a password reset, the password is managed by %s", user.Username, user.Source)
+		return resp, nil
+	}
+	if user.Status != acl.StatusUserActive && user.Status != acl.StatusUserPending {
+		log.Printf("[RESET] %s asked for a password reset, the account is %s", user.Username, user.Status)
+		return resp, nil
+	}
+	email, err := uc.repo.GetUserEmail(user.ID)
+	if err != nil {
+		return ForgotPasswordResponse{}, err
+	}
+	if email == "" {
+		log.Printf("[RESET] %s asked for a password reset without an email address", user.Username)
+		return resp, nil
+	}
+
+	ttl := uc.config.ResetTokenTTL
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	token, rp, err := auth.NewResetToken(user.Username, acl.ResetPurposeReset, ttl, time.Now())
+	if err != nil {
+		return ForgotPasswordResponse{}, errors.New("failed to generate reset token")
+	}
+	// only the latest link works
+	if err := uc.repo.DeleteResetPasswordsByUsername(user.Username); err != nil {
+		return ForgotPasswordResponse{}, err
+	}
+	if err := uc.repo.InsertResetPassword(rp); err != nil {
+		return ForgotPasswordResponse{}, err
+	}
+	err = uc.mailer.Send(mail.Mail{
+		To:      email,
+		Subject: "Reset your topic-master password",
+		Body: fmt.Sprintf("Hello %s,\r\n\r\nSomeone asked to reset the password of your topic-master account. "+
+			"Open the link below within %s to choose a new password:\r\n\r\n%s\r\n\r\n"+
+			"If it wasn't you, ignore this email, your password stays unchanged.",
+			user.Name, auth.ValidityText(ttl), auth.ResetPasswordLink(uc.config.PublicURL, token)),
+	})
+	if err != nil {
+		log.Printf("[RESET] error sending the reset link of %s: %s", user.Username, err)
+	}
+	return resp, nil
+}
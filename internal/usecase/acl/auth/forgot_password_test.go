@@ -0,0 +1,135 @@
+package acl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/logic/mail"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/usecase/acl/auth/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForgotPasswordUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockIForgotPasswordRepo(ctrl)
+	mockMailer := mock.NewMockIMailSender(ctrl)
+	cfg := &config.Config{PublicURL: "https://tm.example.com", ResetTokenTTL: 2 * time.Hour}
+	uc := ForgotPasswordUsecase{repo: mockRepo, mailer: mockMailer, config: cfg}
+
+	alice := acl.User{ID: "u1", Username: "alice", Name: "Alice", Status: acl.StatusUserActive}
+
+	tests := []struct {
+		name    string
+		uc      ForgotPasswordUsecase
+		req     ForgotPasswordRequest
+		setup   func()
+		wantErr string
+	}{
+		{
+			name:    "email disabled",
+			uc:      ForgotPasswordUsecase{repo: mockRepo, config: cfg},
+			req:     ForgotPasswordRequest{Username: "alice"},
+			setup:   func() {},
+			wantErr: "not enabled",
+		},
+		{
+			name:    "missing username",
+			req:     ForgotPasswordRequest{Username: " "},
+			setup:   func() {},
+			wantErr: "username is required",
+		},
+		{
+			name: "unknown user gets the same answer",
+			req:  ForgotPasswordRequest{Username: "nobody"},
+			setup: func() {
+				mockRepo.EXPECT().GetUserByUsername("nobody").Return(acl.User{}, errors.New("not found"))
+			},
+		},
+		{
+			name: "ldap user gets no email",
+			req:  ForgotPasswordRequest{Username: "bob"},
+			setup: func() {
+				mockRepo.EXPECT().GetUserByUsername("bob").Return(acl.User{ID: "u2", Username: "bob", Status: acl.StatusUserActive, Source: acl.UserSourceLDAP}, nil)
+			},
+		},
+		{
+			name: "inactive user gets no email",
+			req:  ForgotPasswordRequest{Username: "carol"},
+			setup: func() {
+				mockRepo.EXPECT().GetUserByUsername("carol").Return(acl.User{ID: "u3", Username: "carol", Status: acl.StatusUserInactive}, nil)
+			},
+		},
+		{
+			name: "no email address",
+			req:  ForgotPasswordRequest{Username: "alice"},
+			setup: func() {
+				mockRepo.EXPECT().GetUserByUsername("alice").Return(alice, nil)
+				mockRepo.EXPECT().GetUserEmail("u1").Return("", nil)
+			},
+		},
+		{
+			name: "link is sent, only its hash is stored",
+			req:  ForgotPasswordRequest{Username: "alice"},
+			setup: func() {
+				var stored acl.ResetPassword
+				mockRepo.EXPECT().GetUserByUsername("alice").Return(alice, nil)
+				mockRepo.EXPECT().GetUserEmail("u1").Return("alice@example.com", nil)
+				mockRepo.EXPECT().DeleteResetPasswordsByUsername("alice").Return(nil)
+				mockRepo.EXPECT().InsertResetPassword(gomock.Any()).DoAndReturn(func(rp acl.ResetPassword) error {
+					assert.Equal(t, "alice", rp.Username)
+					assert.Equal(t, acl.ResetPurposeReset, rp.Purpose)
+					assert.InDelta(t, time.Now().Add(2*time.Hour).Unix(), rp.ExpiresAt, 2)
+					stored = rp
+					return nil
+				})
+				mockMailer.EXPECT().Send(gomock.Any()).DoAndReturn(func(m mail.Mail) error {
+					assert.Equal(t, "alice@example.com", m.To)
+					assert.Contains(t, m.Body, "within 2 hours")
+					i := strings.Index(m.Body, "https://tm.example.com/reset-password?token=")
+					if assert.NotEqual(t, -1, i) {
+						token := strings.Fields(m.Body[i+len("https://tm.example.com/reset-password?token="):])[0]
+						assert.Equal(t, stored.Token, auth.HashResetToken(token))
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "mail error is not shown",
+			req:  ForgotPasswordRequest{Username: "alice"},
+			setup: func() {
+				mockRepo.EXPECT().GetUserByUsername("alice").Return(alice, nil)
+				mockRepo.EXPECT().GetUserEmail("u1").Return("alice@example.com", nil)
+				mockRepo.EXPECT().DeleteResetPasswordsByUsername("alice").Return(nil)
+				mockRepo.EXPECT().InsertResetPassword(gomock.Any()).Return(nil)
+				mockMailer.EXPECT().Send(gomock.Any()).Return(errors.New("connection refused"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := uc
+			if tt.uc.config != nil {
+				u = tt.uc
+			}
+			resp, err := u.Handle(context.Background(), tt.req)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, forgotPasswordMessage, resp.Message)
+		})
+	}
+}
@@ -3,20 +3,24 @@ package acl
 import (
 	"context"
 	"crypto/rand"
-	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"log"
+	"math"
 	"net/http"
 	"time"
 
 	"github.com/jekiapp/topic-master/internal/config"
 	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/logic/auth/ldap"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
 	dbPkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/tidwall/buntdb"
 )
 
@@ -30,10 +34,30 @@ type LoginResponse struct {
 	User  acl.User `json:"user"`
 }
 
+// TwoFactorChallenge asks the login page for a code, Secret and URI are set when the user has to enrol first.
+type TwoFactorChallenge struct {
+	Challenge string `json:"challenge"`
+	Enroll    bool   `json:"enroll"`
+	Secret    string `json:"secret,omitempty"`
+	URI       string `json:"uri,omitempty"`
+}
+
 type IUserLoginRepo interface {
 	GetUserByUsername(username string) (acl.User, error)
+	GetUserByID(id string) (acl.User, error)
 	ListGroupsForUser(userID string) ([]acl.GroupRole, error)
 	InsertResetPassword(rp acl.ResetPassword) error
+	GetUserTOTP(userID string) (acl.UserTOTP, error)
+	UpsertUserTOTP(totp acl.UserTOTP) error
+	CreateLoginChallenge(challenge acl.LoginChallenge) error
+	GetLoginChallenge(id string) (acl.LoginChallenge, error)
+	UpdateLoginChallenge(challenge acl.LoginChallenge) error
+	DeleteLoginChallenge(id string) error
+	GetLoginLockout(userID string) (acl.LoginLockout, error)
+	UpsertLoginLockout(lockout acl.LoginLockout) error
+	DeleteLoginLockout(userID string) error
+	InsertSecurityEvent(event acl.SecurityEvent) error
+	UpsertUserActivity(activity acl.UserActivity) error
 }
 
 type loginRepo struct {
@@ -48,24 +72,82 @@ func (r *loginRepo) ListGroupsForUser(userID string) ([]acl.GroupRole, error) {
 	return userrepo.ListGroupsForUser(r.db, userID)
 }
 
+func (r *loginRepo) GetUserByID(id string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, id)
+}
+
 func (r *loginRepo) InsertResetPassword(rp acl.ResetPassword) error {
-	return dbPkg.Insert(r.db, &rp)
+	return userrepo.InsertResetPassword(r.db, rp)
+}
+
+func (r *loginRepo) GetUserTOTP(userID string) (acl.UserTOTP, error) {
+	return dbPkg.GetByID[acl.UserTOTP](r.db, userID)
+}
+
+func (r *loginRepo) UpsertUserTOTP(totp acl.UserTOTP) error {
+	return dbPkg.Upsert(r.db, &totp)
+}
+
+func (r *loginRepo) CreateLoginChallenge(challenge acl.LoginChallenge) error {
+	return dbPkg.Insert(r.db, &challenge)
+}
+
+func (r *loginRepo) GetLoginChallenge(id string) (acl.LoginChallenge, error) {
+	return dbPkg.GetByID[acl.LoginChallenge](r.db, id)
+}
+
+func (r *loginRepo) UpdateLoginChallenge(challenge acl.LoginChallenge) error {
+	return dbPkg.Update(r.db, &challenge)
+}
+
+func (r *loginRepo) DeleteLoginChallenge(id string) error {
+	return dbPkg.DeleteByID[acl.LoginChallenge](r.db, id)
+}
+
+func (r *loginRepo) GetLoginLockout(userID string) (acl.LoginLockout, error) {
+	return userrepo.GetLoginLockout(r.db, userID)
+}
+
+func (r *loginRepo) UpsertLoginLockout(lockout acl.LoginLockout) error {
+	return userrepo.UpsertLoginLockout(r.db, lockout)
+}
+
+func (r *loginRepo) DeleteLoginLockout(userID string) error {
+	return userrepo.DeleteLoginLockout(r.db, userID)
+}
+
+func (r *loginRepo) InsertSecurityEvent(event acl.SecurityEvent) error {
+	return userrepo.InsertSecurityEvent(r.db, event)
+}
+
+func (r *loginRepo) UpsertUserActivity(activity acl.UserActivity) error {
+	return userrepo.UpsertUserActivity(r.db, activity)
 }
 
 type LoginUsecase struct {
-	repo   IUserLoginRepo
-	config *config.Config
+	repo     IUserLoginRepo
+	provider auth.AuthProvider
+	config   *config.Config
 }
 
+// NewLoginUsecase checks the local users first, then the LDAP directory when one is configured.
 func NewLoginUsecase(db *buntdb.DB, cfg *config.Config) LoginUsecase {
+	repo := &loginRepo{db: db}
+	providers := auth.ChainProvider{auth.NewLocalProvider(repo)}
+	if cfg.LDAP.URL != "" {
+		providers = append(providers, ldap.NewProvider(cfg.LDAP, db))
+	}
 	return LoginUsecase{
-		repo:   &loginRepo{db: db},
-		config: cfg,
+		repo:     repo,
+		provider: providers,
+		config:   cfg,
 	}
 }
 
 const ACCESS_TOKEN_COOKIE_NAME = "access_token"
 
+var errAccountDeactivated = errors.New("account is deactivated, contact an administrator")
+
 func (uc LoginUsecase) Handle(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -81,37 +163,38 @@ func (uc LoginUsecase) Handle(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	user, err := uc.repo.GetUserByUsername(req.Username)
+	ip := util.ClientIP(r, uc.config.TrustForwardedFor)
+	user, err := uc.provider.Authenticate(req.Username, req.Password)
+	// a locked account is refused whether the password is right or not
+	lockout, tracked := uc.lockoutOf(req.Username, user)
+	if wait := lockout.LockedFor(time.Now()); tracked && wait > 0 {
+		w.WriteHeader(http.StatusLocked)
+		json.NewEncoder(w).Encode(map[string]string{"error": lockedMessage(wait)})
+		return
+	}
 	if err != nil {
+		uc.recordFailure(acl.SecurityEvent_LoginFailed, req.Username, ip, err.Error(), lockout, tracked)
 		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(map[string]string{"error": "user not found"})
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
 	}
-	// Hash the provided password and compare
-	hash := sha256.Sum256([]byte(req.Password))
-	hashedPassword := hex.EncodeToString(hash[:])
-	if user.Password != hashedPassword {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(map[string]string{"error": "invalid password"})
+	if tracked && (lockout.Failures > 0 || lockout.LockedUntil > 0) {
+		uc.repo.DeleteLoginLockout(user.ID)
+	}
+	if user.Status == acl.StatusUserInactive {
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(map[string]string{"error": errAccountDeactivated.Error()})
 		return
 	}
 	if user.Status == acl.StatusUserPending {
-		// Generate reset token
-		tokenBytes := make([]byte, 32)
-		if _, err := rand.Read(tokenBytes); err != nil {
+		// the user sets their own password before the first login
+		token, rp, err := auth.NewResetToken(user.Username, acl.ResetPurposeReset, time.Hour, time.Now())
+		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{"error": "failed to generate reset token"})
 			return
 		}
-		token := hex.EncodeToString(tokenBytes)
-		expiresAt := time.Now().Add(1 * time.Hour).Unix()
-		rp := &acl.ResetPassword{
-			Token:     token,
-			Username:  user.Username,
-			CreatedAt: time.Now().Unix(),
-			ExpiresAt: expiresAt,
-		}
-		if err := uc.repo.InsertResetPassword(*rp); err != nil {
+		if err := uc.repo.InsertResetPassword(rp); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			json.NewEncoder(w).Encode(map[string]string{"error": "failed to save reset token"})
 			return
@@ -120,64 +203,128 @@ func (uc LoginUsecase) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp, err := uc.doLogin(r.Context(), req)
+	// the password is only the first step when the user has, or must have, an authenticator
+	resp, challenge, err := uc.beginLogin(r.Context(), user)
+	if errors.Is(err, errTwoFactorSetup) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusUnauthorized)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
 	}
-	// Set JWT as HttpOnly, Secure cookie
-	cookie := &http.Cookie{
-		Name:     ACCESS_TOKEN_COOKIE_NAME,
-		Value:    resp.Token,
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteStrictMode,
-		Path:     "/",
+	if challenge != nil {
+		json.NewEncoder(w).Encode(map[string]interface{}{"two_factor": challenge})
+		return
 	}
-	http.SetCookie(w, cookie)
+	setAccessTokenCookie(w, resp.Token)
 	// Return only user info (no token)
 	json.NewEncoder(w).Encode(map[string]interface{}{"user": resp.User})
 }
 
-func (uc LoginUsecase) doLogin(ctx context.Context, req LoginRequest) (LoginResponse, error) {
-	user, err := uc.repo.GetUserByUsername(req.Username)
+// errTwoFactorSetup wraps the failures to open the second step of a login
+var errTwoFactorSetup = errors.New("two-factor")
+
+// beginLogin logs in a user authenticated by any provider. When the user has, or must have, an
+// authenticator it returns the challenge of the second step instead of a token, which
+// HandleTwoFactor issues once the code is checked.
+func (uc LoginUsecase) beginLogin(ctx context.Context, user acl.User) (LoginResponse, *TwoFactorChallenge, error) {
+	if user.Status == acl.StatusUserInactive {
+		return LoginResponse{}, nil, errAccountDeactivated
+	}
+	challenge, err := uc.twoFactorChallenge(user)
 	if err != nil {
-		if err == dbPkg.ErrNotFound {
-			return LoginResponse{}, errors.New("user not found")
+		return LoginResponse{}, nil, fmt.Errorf("%w: %v", errTwoFactorSetup, err)
+	}
+	if challenge != nil {
+		return LoginResponse{}, challenge, nil
+	}
+	resp, err := uc.doLogin(ctx, user)
+	return resp, nil, err
+}
+
+// twoFactorChallenge opens the second step of the login, it returns nil when the password is enough.
+func (uc LoginUsecase) twoFactorChallenge(user acl.User) (*TwoFactorChallenge, error) {
+	totp, err := uc.repo.GetUserTOTP(user.ID)
+	if err != nil && err != dbPkg.ErrNotFound {
+		return nil, errors.New("failed to get two-factor settings")
+	}
+	if totp.Enabled {
+		id, err := uc.newLoginChallenge(user.ID, false)
+		if err != nil {
+			return nil, err
 		}
-		return LoginResponse{}, err
+		return &TwoFactorChallenge{Challenge: id}, nil
+	}
+	if uc.config.Require2FA == "" {
+		return nil, nil
+	}
+	groups, err := uc.repo.ListGroupsForUser(user.ID)
+	if err != nil {
+		return nil, errors.New("failed to fetch user groups (" + err.Error() + ")")
+	}
+	if !auth.RequiresTwoFactor(uc.config.Require2FA, groups) {
+		return nil, nil
 	}
 
-	// Hash the provided password and compare
-	hash := sha256.Sum256([]byte(req.Password))
-	hashedPassword := hex.EncodeToString(hash[:])
-	if user.Password != hashedPassword {
-		return LoginResponse{}, errors.New("invalid password")
+	// enrolment is part of the login, the secret is confirmed by the first code
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return nil, errors.New("failed to generate two-factor secret")
 	}
+	if err := uc.repo.UpsertUserTOTP(acl.UserTOTP{UserID: user.ID, Secret: secret, CreatedAt: time.Now().Unix()}); err != nil {
+		return nil, errors.New("failed to save two-factor secret")
+	}
+	id, err := uc.newLoginChallenge(user.ID, true)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorChallenge{
+		Challenge: id,
+		Enroll:    true,
+		Secret:    secret,
+		URI:       auth.TOTPProvisioningURI(user.Username, secret),
+	}, nil
+}
 
-	// password is ok, now check if user is pending
+func (uc LoginUsecase) newLoginChallenge(userID string, enroll bool) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate login challenge")
+	}
+	challenge := acl.LoginChallenge{
+		ID:        hex.EncodeToString(b),
+		UserID:    userID,
+		Enroll:    enroll,
+		ExpiresAt: time.Now().Add(acl.LoginChallengeTTL * time.Second).Unix(),
+	}
+	if err := uc.repo.CreateLoginChallenge(challenge); err != nil {
+		return "", errors.New("failed to save login challenge")
+	}
+	return challenge.ID, nil
+}
 
-	if user.Status == acl.StatusUserPending {
-		// Generate reset token
-		tokenBytes := make([]byte, 32)
-		if _, err := rand.Read(tokenBytes); err != nil {
-			return LoginResponse{}, errors.New("failed to generate reset token")
-		}
-		token := hex.EncodeToString(tokenBytes)
-		expiresAt := time.Now().Add(1 * time.Hour).Unix()
-		rp := &acl.ResetPassword{
-			Token:     token,
-			Username:  user.Username,
-			CreatedAt: time.Now().Unix(),
-			ExpiresAt: expiresAt,
-		}
-		if err := dbPkg.Insert(uc.repo.(*loginRepo).db, rp); err != nil {
-			return LoginResponse{}, errors.New("failed to save reset token")
-		}
-		return LoginResponse{}, nil
+// setAccessTokenCookie sets the JWT as HttpOnly, Secure cookie
+func setAccessTokenCookie(w http.ResponseWriter, token string) {
+	cookie := &http.Cookie{
+		Name:     ACCESS_TOKEN_COOKIE_NAME,
+		Value:    token,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
 	}
+	http.SetCookie(w, cookie)
+}
 
+// doLogin issues the token of an authenticated user and records the login. It is only called
+// once the second factor is checked or known not to be needed, logins start with beginLogin.
+func (uc LoginUsecase) doLogin(ctx context.Context, user acl.User) (LoginResponse, error) {
+	if user.Status == acl.StatusUserInactive {
+		return LoginResponse{}, errAccountDeactivated
+	}
 	// Fetch all groups for the user
 	groups, err := uc.repo.ListGroupsForUser(user.ID)
 	if err != nil {
@@ -203,8 +350,171 @@ func (uc LoginUsecase) doLogin(ctx context.Context, req LoginRequest) (LoginResp
 		return LoginResponse{}, errors.New("failed to generate token")
 	}
 
+	now := time.Now()
+	activity := acl.UserActivity{UserID: user.ID, Username: user.Username, LastLoginAt: now, LastActiveAt: now}
+	if err := uc.repo.UpsertUserActivity(activity); err != nil {
+		log.Printf("failed to record the login of %s: %v", user.Username, err)
+	}
+
 	return LoginResponse{
 		Token: token,
 		User:  user,
 	}, nil
 }
+
+type TwoFactorLoginRequest struct {
+	Challenge string `json:"challenge"`
+	Code      string `json:"code"`
+}
+
+// recoveryCodeCount is the number of recovery codes handed out on enrolment
+const recoveryCodeCount = 10
+
+// HandleTwoFactor finishes a login waiting for its second factor, an authenticator code
+// or, outside of enrolment, one of the recovery codes.
+func (uc LoginUsecase) HandleTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var req TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fail := func(code int, msg string) {
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]string{"error": msg})
+	}
+
+	challenge, err := uc.repo.GetLoginChallenge(req.Challenge)
+	if err != nil || req.Challenge == "" {
+		fail(http.StatusUnauthorized, "login expired, please sign in again")
+		return
+	}
+	if time.Now().Unix() > challenge.ExpiresAt || challenge.Attempts >= acl.LoginChallengeMaxAttempts {
+		uc.repo.DeleteLoginChallenge(challenge.ID)
+		fail(http.StatusUnauthorized, "login expired, please sign in again")
+		return
+	}
+	user, err := uc.repo.GetUserByID(challenge.UserID)
+	if err != nil {
+		fail(http.StatusUnauthorized, "user not found")
+		return
+	}
+	lockout, tracked := uc.lockoutOf(user.Username, user)
+	if wait := lockout.LockedFor(time.Now()); tracked && wait > 0 {
+		uc.repo.DeleteLoginChallenge(challenge.ID)
+		fail(http.StatusLocked, lockedMessage(wait))
+		return
+	}
+	totp, err := uc.repo.GetUserTOTP(challenge.UserID)
+	if err != nil {
+		fail(http.StatusUnauthorized, "two-factor authentication is not set up")
+		return
+	}
+
+	var recoveryCodes []string
+	if step, ok := auth.VerifyTOTP(totp.Secret, req.Code, time.Now(), totp.LastUsedStep); ok {
+		totp.LastUsedStep = step
+		if challenge.Enroll {
+			codes, hashes, err := auth.NewRecoveryCodes(recoveryCodeCount)
+			if err != nil {
+				fail(http.StatusInternalServerError, "failed to generate recovery codes")
+				return
+			}
+			totp.Enabled = true
+			totp.EnabledAt = time.Now().Unix()
+			totp.RecoveryCodes = hashes
+			recoveryCodes = codes
+		}
+	} else if challenge.Enroll || !auth.UseRecoveryCode(&totp, req.Code) {
+		challenge.Attempts++
+		uc.repo.UpdateLoginChallenge(challenge)
+		uc.recordFailure(acl.SecurityEvent_TwoFactorFailed, user.Username, util.ClientIP(r, uc.config.TrustForwardedFor), "invalid code", lockout, tracked)
+		fail(http.StatusUnauthorized, "invalid code")
+		return
+	}
+	if err := uc.repo.UpsertUserTOTP(totp); err != nil {
+		fail(http.StatusInternalServerError, "failed to save two-factor settings")
+		return
+	}
+	uc.repo.DeleteLoginChallenge(challenge.ID)
+	if tracked && (lockout.Failures > 0 || lockout.LockedUntil > 0) {
+		uc.repo.DeleteLoginLockout(user.ID)
+	}
+
+	resp, err := uc.doLogin(r.Context(), user)
+	if err != nil {
+		fail(http.StatusUnauthorized, err.Error())
+		return
+	}
+	setAccessTokenCookie(w, resp.Token)
+	result := map[string]interface{}{"user": resp.User}
+	if recoveryCodes != nil {
+		result["recovery_codes"] = recoveryCodes
+	}
+	json.NewEncoder(w).Encode(result)
+}
+
+// lockoutOf returns the failed logins of the account. tracked is false when lockout is disabled
+// or the username is unknown, user is used when the provider already found it.
+func (uc LoginUsecase) lockoutOf(username string, user acl.User) (acl.LoginLockout, bool) {
+	if uc.config.Lockout.Threshold <= 0 {
+		return acl.LoginLockout{}, false
+	}
+	if user.ID == "" {
+		found, err := uc.repo.GetUserByUsername(username)
+		if err != nil {
+			return acl.LoginLockout{}, false
+		}
+		user = found
+	}
+	lockout, err := uc.repo.GetLoginLockout(user.ID)
+	if err != nil {
+		log.Printf("failed to get login lockout of %s: %v", user.Username, err)
+		return acl.LoginLockout{}, false
+	}
+	lockout.UserID = user.ID
+	lockout.Username = user.Username
+	return lockout, true
+}
+
+// recordFailure audits a failed login and locks the account once it failed Threshold times in a row.
+// Failures older than the lockout duration are forgotten.
+func (uc LoginUsecase) recordFailure(event, username, ip, detail string, lockout acl.LoginLockout, tracked bool) {
+	uc.audit(acl.SecurityEvent{Event: event, Username: username, IP: ip, Detail: detail})
+	if !tracked {
+		return
+	}
+	now := time.Now()
+	if now.Sub(time.Unix(lockout.LastFailureAt, 0)) > uc.config.Lockout.Duration {
+		lockout.Failures = 0
+	}
+	lockout.Failures++
+	lockout.LastFailureAt = now.Unix()
+	if lockout.Failures >= uc.config.Lockout.Threshold {
+		lockout.LockedUntil = now.Add(uc.config.Lockout.Duration).Unix()
+		lockout.Failures = 0
+		uc.audit(acl.SecurityEvent{
+			Event:    acl.SecurityEvent_AccountLocked,
+			Username: username,
+			IP:       ip,
+			Detail:   fmt.Sprintf("%d failed logins in a row, locked for %s", uc.config.Lockout.Threshold, uc.config.Lockout.Duration),
+		})
+	}
+	if err := uc.repo.UpsertLoginLockout(lockout); err != nil {
+		log.Printf("failed to save login lockout of %s: %v", username, err)
+	}
+}
+
+func (uc LoginUsecase) audit(event acl.SecurityEvent) {
+	if err := uc.repo.InsertSecurityEvent(event); err != nil {
+		log.Printf("failed to audit %s of %s: %v", event.Event, event.Username, err)
+	}
+}
+
+func lockedMessage(wait time.Duration) string {
+	minutes := int(math.Ceil(wait.Minutes()))
+	return fmt.Sprintf("account is locked after too many failed logins, try again in %d minutes or ask an administrator to unlock it", minutes)
+}
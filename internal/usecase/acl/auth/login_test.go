@@ -9,11 +9,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/internal/usecase/acl/auth/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,7 +25,7 @@ func TestLoginUsecase_Handle(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockIUserLoginRepo(ctrl)
-	uc := LoginUsecase{repo: mockRepo, config: &config.Config{SecretKey: []byte("c2VjcmV0a2V5MTIzNDU2")}} // base64 for 'secretkey123456'
+	uc := LoginUsecase{repo: mockRepo, provider: auth.NewLocalProvider(mockRepo), config: &config.Config{SecretKey: []byte("c2VjcmV0a2V5MTIzNDU2")}} // base64 for 'secretkey123456'
 
 	hash := func(pw string) string {
 		h := sha256.Sum256([]byte(pw))
@@ -51,6 +54,7 @@ func TestLoginUsecase_Handle(t *testing.T) {
 			body:   LoginRequest{Username: "nouser", Password: "pw"},
 			setup: func() {
 				mockRepo.EXPECT().GetUserByUsername("nouser").Return(acl.User{}, errors.New("not found"))
+				mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).Return(nil)
 			},
 			wantCode: http.StatusUnauthorized,
 			wantKey:  "error",
@@ -62,6 +66,7 @@ func TestLoginUsecase_Handle(t *testing.T) {
 			body:   LoginRequest{Username: "user1", Password: "wrong"},
 			setup: func() {
 				mockRepo.EXPECT().GetUserByUsername("user1").Return(acl.User{Username: "user1", Password: hash("pw")}, nil)
+				mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).Return(nil)
 			},
 			wantCode: http.StatusUnauthorized,
 			wantKey:  "error",
@@ -78,13 +83,30 @@ func TestLoginUsecase_Handle(t *testing.T) {
 			wantCode: http.StatusOK,
 			wantKey:  "redirect",
 		},
+		{
+			name:   "deactivated user is refused",
+			method: http.MethodPost,
+			body:   LoginRequest{Username: "gone", Password: "pw"},
+			setup: func() {
+				mockRepo.EXPECT().GetUserByUsername("gone").Return(acl.User{ID: "id9", Username: "gone", Password: hash("pw"), Status: acl.StatusUserInactive}, nil)
+			},
+			wantCode: http.StatusForbidden,
+			wantKey:  "error",
+			wantVal:  errAccountDeactivated.Error(),
+		},
 		{
 			name:   "success login",
 			method: http.MethodPost,
 			body:   LoginRequest{Username: "user2", Password: "pw2"},
 			setup: func() {
 				mockRepo.EXPECT().GetUserByUsername("user2").Return(acl.User{ID: "id2", Username: "user2", Password: hash("pw2")}, nil)
+				mockRepo.EXPECT().GetUserTOTP("id2").Return(acl.UserTOTP{}, db.ErrNotFound)
 				mockRepo.EXPECT().ListGroupsForUser("id2").Return([]acl.GroupRole{}, nil)
+				mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).DoAndReturn(func(a acl.UserActivity) error {
+					assert.Equal(t, "id2", a.UserID)
+					assert.False(t, a.LastLoginAt.IsZero())
+					return nil
+				})
 			},
 			wantCode: http.StatusOK,
 			wantKey:  "user",
@@ -111,3 +133,276 @@ func TestLoginUsecase_Handle(t *testing.T) {
 		})
 	}
 }
+
+type stubProvider struct {
+	user acl.User
+}
+
+func (p stubProvider) Authenticate(username, password string) (acl.User, error) {
+	if username != p.user.Username {
+		return acl.User{}, auth.ErrUnknownUser
+	}
+	return p.user, nil
+}
+
+func TestLoginUsecase_ProviderChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockIUserLoginRepo(ctrl)
+	directoryUser := acl.User{ID: "id3", Username: "alice", Source: acl.UserSourceLDAP, Status: acl.StatusUserActive}
+	uc := LoginUsecase{
+		repo:     mockRepo,
+		provider: auth.ChainProvider{auth.NewLocalProvider(mockRepo), stubProvider{user: directoryUser}},
+		config:   &config.Config{SecretKey: []byte("c2VjcmV0a2V5MTIzNDU2")},
+	}
+
+	// the local provider skips directory users, the next provider logs them in
+	mockRepo.EXPECT().GetUserByUsername("alice").Return(directoryUser, nil)
+	mockRepo.EXPECT().GetUserTOTP("id3").Return(acl.UserTOTP{}, db.ErrNotFound)
+	mockRepo.EXPECT().ListGroupsForUser("id3").Return([]acl.GroupRole{}, nil)
+	mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).Return(nil)
+
+	b, _ := json.Marshal(LoginRequest{Username: "alice", Password: "directory-pw"})
+	rw := httptest.NewRecorder()
+	uc.Handle(rw, httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(b)))
+	assert.Equal(t, http.StatusOK, rw.Result().StatusCode)
+
+	// nobody knows the user
+	mockRepo.EXPECT().GetUserByUsername("bob").Return(acl.User{}, errors.New("not found"))
+	mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).Return(nil)
+	b, _ = json.Marshal(LoginRequest{Username: "bob", Password: "pw"})
+	rw = httptest.NewRecorder()
+	uc.Handle(rw, httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(b)))
+	assert.Equal(t, http.StatusUnauthorized, rw.Result().StatusCode)
+}
+
+func TestLoginUsecase_TwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockIUserLoginRepo(ctrl)
+	cfg := &config.Config{SecretKey: []byte("c2VjcmV0a2V5MTIzNDU2"), Require2FA: config.Require2FARoot}
+	uc := LoginUsecase{repo: mockRepo, provider: auth.NewLocalProvider(mockRepo), config: cfg}
+
+	h := sha256.Sum256([]byte("pw"))
+	root := acl.User{ID: "root-id", Username: "root", Password: hex.EncodeToString(h[:]), Status: acl.StatusUserActive}
+	secret, _ := auth.NewTOTPSecret()
+	code, _ := auth.TOTPCode(secret, time.Now().Unix()/30)
+
+	post := func(handler http.HandlerFunc, body interface{}) (int, map[string]interface{}) {
+		b, _ := json.Marshal(body)
+		rw := httptest.NewRecorder()
+		handler(rw, httptest.NewRequest(http.MethodPost, "/api/login", bytes.NewReader(b)))
+		var m map[string]interface{}
+		json.NewDecoder(rw.Result().Body).Decode(&m)
+		return rw.Result().StatusCode, m
+	}
+	challengeOf := func(m map[string]interface{}) map[string]interface{} {
+		c, _ := m["two_factor"].(map[string]interface{})
+		return c
+	}
+
+	t.Run("required user enrols on login", func(t *testing.T) {
+		var stored acl.UserTOTP
+		mockRepo.EXPECT().GetUserByUsername("root").Return(root, nil)
+		mockRepo.EXPECT().GetUserTOTP("root-id").Return(acl.UserTOTP{}, db.ErrNotFound)
+		mockRepo.EXPECT().ListGroupsForUser("root-id").Return([]acl.GroupRole{{GroupName: acl.GroupRoot}}, nil)
+		mockRepo.EXPECT().UpsertUserTOTP(gomock.Any()).DoAndReturn(func(totp acl.UserTOTP) error {
+			stored = totp
+			return nil
+		})
+		mockRepo.EXPECT().CreateLoginChallenge(gomock.Any()).Return(nil)
+
+		status, m := post(uc.Handle, LoginRequest{Username: "root", Password: "pw"})
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotContains(t, m, "user")
+		c := challengeOf(m)
+		if assert.NotNil(t, c) {
+			assert.Equal(t, true, c["enroll"])
+			assert.Equal(t, stored.Secret, c["secret"])
+			assert.Contains(t, c["uri"], "otpauth://totp/")
+		}
+		assert.False(t, stored.Enabled)
+	})
+
+	t.Run("enrolment code enables 2FA and logs in", func(t *testing.T) {
+		challenge := acl.LoginChallenge{ID: "c1", UserID: "root-id", Enroll: true, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+		var stored acl.UserTOTP
+		mockRepo.EXPECT().GetLoginChallenge("c1").Return(challenge, nil)
+		mockRepo.EXPECT().GetUserTOTP("root-id").Return(acl.UserTOTP{UserID: "root-id", Secret: secret}, nil)
+		mockRepo.EXPECT().UpsertUserTOTP(gomock.Any()).DoAndReturn(func(totp acl.UserTOTP) error {
+			stored = totp
+			return nil
+		})
+		mockRepo.EXPECT().DeleteLoginChallenge("c1").Return(nil)
+		mockRepo.EXPECT().GetUserByID("root-id").Return(root, nil)
+		mockRepo.EXPECT().ListGroupsForUser("root-id").Return([]acl.GroupRole{{GroupName: acl.GroupRoot}}, nil)
+		mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).Return(nil)
+
+		status, m := post(uc.HandleTwoFactor, TwoFactorLoginRequest{Challenge: "c1", Code: code})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Contains(t, m, "user")
+		assert.Len(t, m["recovery_codes"], recoveryCodeCount)
+		assert.True(t, stored.Enabled)
+		assert.Len(t, stored.RecoveryCodes, recoveryCodeCount)
+	})
+
+	t.Run("enrolled user gets a challenge", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByUsername("root").Return(root, nil)
+		mockRepo.EXPECT().GetUserTOTP("root-id").Return(acl.UserTOTP{UserID: "root-id", Secret: secret, Enabled: true}, nil)
+		mockRepo.EXPECT().CreateLoginChallenge(gomock.Any()).Return(nil)
+
+		status, m := post(uc.Handle, LoginRequest{Username: "root", Password: "pw"})
+		assert.Equal(t, http.StatusOK, status)
+		c := challengeOf(m)
+		if assert.NotNil(t, c) {
+			assert.Equal(t, false, c["enroll"])
+			assert.NotContains(t, c, "secret")
+		}
+	})
+
+	t.Run("wrong code counts an attempt", func(t *testing.T) {
+		challenge := acl.LoginChallenge{ID: "c2", UserID: "root-id", ExpiresAt: time.Now().Add(time.Minute).Unix()}
+		mockRepo.EXPECT().GetLoginChallenge("c2").Return(challenge, nil)
+		mockRepo.EXPECT().GetUserByID("root-id").Return(root, nil)
+		mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).DoAndReturn(func(e acl.SecurityEvent) error {
+			assert.Equal(t, acl.SecurityEvent_TwoFactorFailed, e.Event)
+			assert.Equal(t, "root", e.Username)
+			return nil
+		})
+		mockRepo.EXPECT().GetUserTOTP("root-id").Return(acl.UserTOTP{UserID: "root-id", Secret: secret, Enabled: true}, nil)
+		mockRepo.EXPECT().UpdateLoginChallenge(gomock.Any()).DoAndReturn(func(c acl.LoginChallenge) error {
+			assert.Equal(t, 1, c.Attempts)
+			return nil
+		})
+
+		status, m := post(uc.HandleTwoFactor, TwoFactorLoginRequest{Challenge: "c2", Code: "000000"})
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "invalid code", m["error"])
+	})
+
+	t.Run("recovery code is accepted once", func(t *testing.T) {
+		codes, hashes, _ := auth.NewRecoveryCodes(2)
+		challenge := acl.LoginChallenge{ID: "c3", UserID: "root-id", ExpiresAt: time.Now().Add(time.Minute).Unix()}
+		mockRepo.EXPECT().GetLoginChallenge("c3").Return(challenge, nil)
+		mockRepo.EXPECT().GetUserTOTP("root-id").Return(acl.UserTOTP{UserID: "root-id", Secret: secret, Enabled: true, RecoveryCodes: hashes}, nil)
+		mockRepo.EXPECT().UpsertUserTOTP(gomock.Any()).DoAndReturn(func(totp acl.UserTOTP) error {
+			assert.Equal(t, hashes[1:], totp.RecoveryCodes)
+			return nil
+		})
+		mockRepo.EXPECT().DeleteLoginChallenge("c3").Return(nil)
+		mockRepo.EXPECT().GetUserByID("root-id").Return(root, nil)
+		mockRepo.EXPECT().ListGroupsForUser("root-id").Return([]acl.GroupRole{}, nil)
+		mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).Return(nil)
+
+		status, m := post(uc.HandleTwoFactor, TwoFactorLoginRequest{Challenge: "c3", Code: codes[0]})
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotContains(t, m, "recovery_codes")
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		challenge := acl.LoginChallenge{ID: "c4", UserID: "root-id", Attempts: acl.LoginChallengeMaxAttempts, ExpiresAt: time.Now().Add(time.Minute).Unix()}
+		mockRepo.EXPECT().GetLoginChallenge("c4").Return(challenge, nil)
+		mockRepo.EXPECT().DeleteLoginChallenge("c4").Return(nil)
+
+		status, _ := post(uc.HandleTwoFactor, TwoFactorLoginRequest{Challenge: "c4", Code: code})
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		challenge := acl.LoginChallenge{ID: "c5", UserID: "root-id", ExpiresAt: time.Now().Add(-time.Second).Unix()}
+		mockRepo.EXPECT().GetLoginChallenge("c5").Return(challenge, nil)
+		mockRepo.EXPECT().DeleteLoginChallenge("c5").Return(nil)
+
+		status, _ := post(uc.HandleTwoFactor, TwoFactorLoginRequest{Challenge: "c5", Code: code})
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+}
+
+func TestLoginUsecase_Lockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockIUserLoginRepo(ctrl)
+	cfg := &config.Config{
+		SecretKey: []byte("c2VjcmV0a2V5MTIzNDU2"),
+		Lockout:   config.LockoutConfig{Threshold: 3, Duration: 15 * time.Minute},
+	}
+	uc := LoginUsecase{repo: mockRepo, provider: auth.NewLocalProvider(mockRepo), config: cfg}
+
+	h := sha256.Sum256([]byte("pw"))
+	alice := acl.User{ID: "id1", Username: "alice", Password: hex.EncodeToString(h[:]), Status: acl.StatusUserActive}
+	now := time.Now().Unix()
+
+	login := func(password string) (int, map[string]string) {
+		b, _ := json.Marshal(LoginRequest{Username: "alice", Password: password})
+		rw := httptest.NewRecorder()
+		uc.Handle(rw, httptest.NewRequest(http.MethodPost, "/api/login", bytes.NewReader(b)))
+		var m map[string]string
+		json.NewDecoder(rw.Result().Body).Decode(&m)
+		return rw.Result().StatusCode, m
+	}
+
+	t.Run("failure is counted", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByUsername("alice").Return(alice, nil).Times(2)
+		mockRepo.EXPECT().GetLoginLockout("id1").Return(acl.LoginLockout{UserID: "id1", Failures: 1, LastFailureAt: now}, nil)
+		mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().UpsertLoginLockout(gomock.Any()).DoAndReturn(func(l acl.LoginLockout) error {
+			assert.Equal(t, 2, l.Failures)
+			assert.Zero(t, l.LockedUntil)
+			return nil
+		})
+		status, _ := login("wrong")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("threshold locks the account", func(t *testing.T) {
+		var events []string
+		mockRepo.EXPECT().GetUserByUsername("alice").Return(alice, nil).Times(2)
+		mockRepo.EXPECT().GetLoginLockout("id1").Return(acl.LoginLockout{UserID: "id1", Failures: 2, LastFailureAt: now}, nil)
+		mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).DoAndReturn(func(e acl.SecurityEvent) error {
+			events = append(events, e.Event)
+			return nil
+		}).Times(2)
+		mockRepo.EXPECT().UpsertLoginLockout(gomock.Any()).DoAndReturn(func(l acl.LoginLockout) error {
+			assert.Greater(t, l.LockedUntil, now)
+			assert.Equal(t, "alice", l.Username)
+			return nil
+		})
+		status, _ := login("wrong")
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, []string{acl.SecurityEvent_LoginFailed, acl.SecurityEvent_AccountLocked}, events)
+	})
+
+	t.Run("locked account refuses the right password", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByUsername("alice").Return(alice, nil)
+		mockRepo.EXPECT().GetLoginLockout("id1").Return(acl.LoginLockout{UserID: "id1", LockedUntil: now + 600}, nil)
+		status, m := login("pw")
+		assert.Equal(t, http.StatusLocked, status)
+		assert.Contains(t, m["error"], "locked")
+	})
+
+	t.Run("old failures are forgotten", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByUsername("alice").Return(alice, nil).Times(2)
+		mockRepo.EXPECT().GetLoginLockout("id1").Return(acl.LoginLockout{UserID: "id1", Failures: 2, LastFailureAt: now - 3600}, nil)
+		mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().UpsertLoginLockout(gomock.Any()).DoAndReturn(func(l acl.LoginLockout) error {
+			assert.Equal(t, 1, l.Failures)
+			return nil
+		})
+		status, _ := login("wrong")
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("success clears the failures", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByUsername("alice").Return(alice, nil)
+		mockRepo.EXPECT().GetLoginLockout("id1").Return(acl.LoginLockout{UserID: "id1", Failures: 2, LastFailureAt: now}, nil)
+		mockRepo.EXPECT().DeleteLoginLockout("id1").Return(nil)
+		mockRepo.EXPECT().GetUserTOTP("id1").Return(acl.UserTOTP{}, db.ErrNotFound)
+		mockRepo.EXPECT().ListGroupsForUser("id1").Return([]acl.GroupRole{}, nil)
+		mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).Return(nil)
+		status, _ := login("pw")
+		assert.Equal(t, http.StatusOK, status)
+	})
+}
@@ -35,6 +35,21 @@ func (m *MockICheckUserActionPermission) EXPECT() *MockICheckUserActionPermissio
 	return m.recorder
 }
 
+// GetCustomRole mocks base method.
+func (m *MockICheckUserActionPermission) GetCustomRole(arg0, arg1 string) (acl.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomRole", arg0, arg1)
+	ret0, _ := ret[0].(acl.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomRole indicates an expected call of GetCustomRole.
+func (mr *MockICheckUserActionPermissionMockRecorder) GetCustomRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomRole", reflect.TypeOf((*MockICheckUserActionPermission)(nil).GetCustomRole), arg0, arg1)
+}
+
 // GetEntityByID mocks base method.
 func (m *MockICheckUserActionPermission) GetEntityByID(arg0 string) (*entity.Entity, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,21 @@ func (mr *MockICheckUserActionPermissionMockRecorder) GetEntityByID(arg0 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityByID", reflect.TypeOf((*MockICheckUserActionPermission)(nil).GetEntityByID), arg0)
 }
 
+// GetEntityDefaultPermission mocks base method.
+func (m *MockICheckUserActionPermission) GetEntityDefaultPermission(arg0, arg1 string) (entity.EntityDefaultPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityDefaultPermission", arg0, arg1)
+	ret0, _ := ret[0].(entity.EntityDefaultPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityDefaultPermission indicates an expected call of GetEntityDefaultPermission.
+func (mr *MockICheckUserActionPermissionMockRecorder) GetEntityDefaultPermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityDefaultPermission", reflect.TypeOf((*MockICheckUserActionPermission)(nil).GetEntityDefaultPermission), arg0, arg1)
+}
+
 // GetGroupsByUserID mocks base method.
 func (m *MockICheckUserActionPermission) GetGroupsByUserID(arg0 string) ([]acl.GroupRole, error) {
 	m.ctrl.T.Helper()
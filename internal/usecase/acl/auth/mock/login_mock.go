@@ -34,6 +34,93 @@ func (m *MockIUserLoginRepo) EXPECT() *MockIUserLoginRepoMockRecorder {
 	return m.recorder
 }
 
+// CreateLoginChallenge mocks base method.
+func (m *MockIUserLoginRepo) CreateLoginChallenge(arg0 acl.LoginChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoginChallenge", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoginChallenge indicates an expected call of CreateLoginChallenge.
+func (mr *MockIUserLoginRepoMockRecorder) CreateLoginChallenge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoginChallenge", reflect.TypeOf((*MockIUserLoginRepo)(nil).CreateLoginChallenge), arg0)
+}
+
+// DeleteLoginChallenge mocks base method.
+func (m *MockIUserLoginRepo) DeleteLoginChallenge(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginChallenge", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginChallenge indicates an expected call of DeleteLoginChallenge.
+func (mr *MockIUserLoginRepoMockRecorder) DeleteLoginChallenge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginChallenge", reflect.TypeOf((*MockIUserLoginRepo)(nil).DeleteLoginChallenge), arg0)
+}
+
+// DeleteLoginLockout mocks base method.
+func (m *MockIUserLoginRepo) DeleteLoginLockout(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginLockout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginLockout indicates an expected call of DeleteLoginLockout.
+func (mr *MockIUserLoginRepoMockRecorder) DeleteLoginLockout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginLockout", reflect.TypeOf((*MockIUserLoginRepo)(nil).DeleteLoginLockout), arg0)
+}
+
+// GetLoginChallenge mocks base method.
+func (m *MockIUserLoginRepo) GetLoginChallenge(arg0 string) (acl.LoginChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginChallenge", arg0)
+	ret0, _ := ret[0].(acl.LoginChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginChallenge indicates an expected call of GetLoginChallenge.
+func (mr *MockIUserLoginRepoMockRecorder) GetLoginChallenge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginChallenge", reflect.TypeOf((*MockIUserLoginRepo)(nil).GetLoginChallenge), arg0)
+}
+
+// GetLoginLockout mocks base method.
+func (m *MockIUserLoginRepo) GetLoginLockout(arg0 string) (acl.LoginLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginLockout", arg0)
+	ret0, _ := ret[0].(acl.LoginLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLockout indicates an expected call of GetLoginLockout.
+func (mr *MockIUserLoginRepoMockRecorder) GetLoginLockout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLockout", reflect.TypeOf((*MockIUserLoginRepo)(nil).GetLoginLockout), arg0)
+}
+
+// GetUserByID mocks base method.
+func (m *MockIUserLoginRepo) GetUserByID(arg0 string) (acl.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0)
+	ret0, _ := ret[0].(acl.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockIUserLoginRepoMockRecorder) GetUserByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockIUserLoginRepo)(nil).GetUserByID), arg0)
+}
+
 // GetUserByUsername mocks base method.
 func (m *MockIUserLoginRepo) GetUserByUsername(arg0 string) (acl.User, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +136,21 @@ func (mr *MockIUserLoginRepoMockRecorder) GetUserByUsername(arg0 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockIUserLoginRepo)(nil).GetUserByUsername), arg0)
 }
 
+// GetUserTOTP mocks base method.
+func (m *MockIUserLoginRepo) GetUserTOTP(arg0 string) (acl.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTOTP", arg0)
+	ret0, _ := ret[0].(acl.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTOTP indicates an expected call of GetUserTOTP.
+func (mr *MockIUserLoginRepoMockRecorder) GetUserTOTP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTOTP", reflect.TypeOf((*MockIUserLoginRepo)(nil).GetUserTOTP), arg0)
+}
+
 // InsertResetPassword mocks base method.
 func (m *MockIUserLoginRepo) InsertResetPassword(arg0 acl.ResetPassword) error {
 	m.ctrl.T.Helper()
@@ -63,6 +165,20 @@ func (mr *MockIUserLoginRepoMockRecorder) InsertResetPassword(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertResetPassword", reflect.TypeOf((*MockIUserLoginRepo)(nil).InsertResetPassword), arg0)
 }
 
+// InsertSecurityEvent mocks base method.
+func (m *MockIUserLoginRepo) InsertSecurityEvent(arg0 acl.SecurityEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSecurityEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSecurityEvent indicates an expected call of InsertSecurityEvent.
+func (mr *MockIUserLoginRepoMockRecorder) InsertSecurityEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSecurityEvent", reflect.TypeOf((*MockIUserLoginRepo)(nil).InsertSecurityEvent), arg0)
+}
+
 // ListGroupsForUser mocks base method.
 func (m *MockIUserLoginRepo) ListGroupsForUser(arg0 string) ([]acl.GroupRole, error) {
 	m.ctrl.T.Helper()
@@ -77,3 +193,59 @@ func (mr *MockIUserLoginRepoMockRecorder) ListGroupsForUser(arg0 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupsForUser", reflect.TypeOf((*MockIUserLoginRepo)(nil).ListGroupsForUser), arg0)
 }
+
+// UpdateLoginChallenge mocks base method.
+func (m *MockIUserLoginRepo) UpdateLoginChallenge(arg0 acl.LoginChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLoginChallenge", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLoginChallenge indicates an expected call of UpdateLoginChallenge.
+func (mr *MockIUserLoginRepoMockRecorder) UpdateLoginChallenge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoginChallenge", reflect.TypeOf((*MockIUserLoginRepo)(nil).UpdateLoginChallenge), arg0)
+}
+
+// UpsertLoginLockout mocks base method.
+func (m *MockIUserLoginRepo) UpsertLoginLockout(arg0 acl.LoginLockout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertLoginLockout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertLoginLockout indicates an expected call of UpsertLoginLockout.
+func (mr *MockIUserLoginRepoMockRecorder) UpsertLoginLockout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertLoginLockout", reflect.TypeOf((*MockIUserLoginRepo)(nil).UpsertLoginLockout), arg0)
+}
+
+// UpsertUserActivity mocks base method.
+func (m *MockIUserLoginRepo) UpsertUserActivity(arg0 acl.UserActivity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserActivity", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserActivity indicates an expected call of UpsertUserActivity.
+func (mr *MockIUserLoginRepoMockRecorder) UpsertUserActivity(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserActivity", reflect.TypeOf((*MockIUserLoginRepo)(nil).UpsertUserActivity), arg0)
+}
+
+// UpsertUserTOTP mocks base method.
+func (m *MockIUserLoginRepo) UpsertUserTOTP(arg0 acl.UserTOTP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserTOTP", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserTOTP indicates an expected call of UpsertUserTOTP.
+func (mr *MockIUserLoginRepoMockRecorder) UpsertUserTOTP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserTOTP", reflect.TypeOf((*MockIUserLoginRepo)(nil).UpsertUserTOTP), arg0)
+}
@@ -0,0 +1,163 @@
+package acl
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth/oidc"
+	"github.com/jekiapp/topic-master/internal/logic/mail"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	OIDC_FLOW_COOKIE_NAME = "oidc_flow"
+	oidcFlowCookiePath    = "/api/auth/oidc"
+	oidcFlowMaxAge        = 600 // seconds to sign in at the provider
+)
+
+type IOIDCClient interface {
+	NewFlow() (oidc.Flow, error)
+	AuthCodeURL(ctx context.Context, flow oidc.Flow) (string, error)
+	Login(ctx context.Context, code string, flow oidc.Flow) (acl.User, error)
+}
+
+type AuthProvidersResponse struct {
+	OIDC           bool `json:"oidc"`
+	ForgotPassword bool `json:"forgot_password"` // reset links can be emailed
+}
+
+// OIDCLoginUsecase signs users in with the OpenID Connect provider, the client is nil when none is configured.
+type OIDCLoginUsecase struct {
+	client      IOIDCClient
+	login       LoginUsecase
+	mailEnabled bool
+}
+
+func NewOIDCLoginUsecase(db *buntdb.DB, cfg *config.Config) OIDCLoginUsecase {
+	uc := OIDCLoginUsecase{login: NewLoginUsecase(db, cfg), mailEnabled: mail.NewSender(cfg) != nil}
+	if cfg.OIDC.IssuerURL != "" {
+		uc.client = oidc.NewClient(cfg.OIDC, db)
+	}
+	return uc
+}
+
+// HandleProviders tells the login page which sign in options to show.
+func (uc OIDCLoginUsecase) HandleProviders(ctx context.Context, params map[string]string) (AuthProvidersResponse, error) {
+	return AuthProvidersResponse{OIDC: uc.client != nil, ForgotPassword: uc.mailEnabled}, nil
+}
+
+// HandleStart redirects the browser to the provider, the flow secrets wait for the callback in a cookie.
+func (uc OIDCLoginUsecase) HandleStart(w http.ResponseWriter, r *http.Request) {
+	if uc.client == nil {
+		http.NotFound(w, r)
+		return
+	}
+	flow, err := uc.client.NewFlow()
+	if err != nil {
+		http.Error(w, "failed to start sign in", http.StatusInternalServerError)
+		return
+	}
+	authURL, err := uc.client.AuthCodeURL(r.Context(), flow)
+	if err != nil {
+		log.Printf("oidc: %v", err)
+		http.Error(w, "single sign-on provider is unavailable", http.StatusBadGateway)
+		return
+	}
+	raw, _ := json.Marshal(flow)
+	http.SetCookie(w, &http.Cookie{
+		Name:     OIDC_FLOW_COOKIE_NAME,
+		Value:    base64.RawURLEncoding.EncodeToString(raw),
+		HttpOnly: true,
+		Secure:   true,
+		// the callback is a top level navigation from the provider
+		SameSite: http.SameSiteLaxMode,
+		Path:     oidcFlowCookiePath,
+		MaxAge:   oidcFlowMaxAge,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// HandleCallback finishes the sign in and sets the same access token cookie as the password login.
+func (uc OIDCLoginUsecase) HandleCallback(w http.ResponseWriter, r *http.Request) {
+	if uc.client == nil {
+		http.NotFound(w, r)
+		return
+	}
+	// the flow is single use
+	http.SetCookie(w, &http.Cookie{Name: OIDC_FLOW_COOKIE_NAME, Path: oidcFlowCookiePath, MaxAge: -1, HttpOnly: true, Secure: true})
+
+	q := r.URL.Query()
+	if providerErr := q.Get("error"); providerErr != "" {
+		redirectToLogin(w, r, "single sign-on failed: "+providerErr)
+		return
+	}
+	flow, ok := readFlowCookie(r)
+	if !ok || q.Get("state") == "" || subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(flow.State)) != 1 {
+		redirectToLogin(w, r, "single sign-on expired, please try again")
+		return
+	}
+
+	user, err := uc.client.Login(r.Context(), q.Get("code"), flow)
+	if err != nil {
+		log.Printf("oidc: %v", err)
+		redirectToLogin(w, r, "single sign-on failed")
+		return
+	}
+	resp, challenge, err := uc.login.beginLogin(r.Context(), user)
+	if err != nil {
+		log.Printf("oidc: %v", err)
+		redirectToLogin(w, r, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if challenge != nil {
+		// the login page asks for the code, the challenge and any enrolment secret are
+		// handed over in the session storage rather than the URL
+		raw, _ := json.Marshal(challenge)
+		twoFactorPage.Execute(w, string(raw))
+		return
+	}
+	setAccessTokenCookie(w, resp.Token)
+
+	// a redirect would keep the navigation cross site and the strict cookie would not be sent,
+	// the page moves on from the same site instead
+	signedInPage.Execute(w, nil)
+}
+
+var signedInPage = template.Must(template.New("signed-in").Parse(
+	`<!DOCTYPE html><html><head><meta http-equiv="refresh" content="0;url=/"></head><body>Signed in, <a href="/">continue</a>.</body></html>`))
+
+// twoFactorPageKey is the session storage key login.js resumes the second step from
+const twoFactorPageKey = "two_factor"
+
+var twoFactorPage = template.Must(template.New("two-factor").Parse(
+	`<!DOCTYPE html><html><head><script>sessionStorage.setItem("` + twoFactorPageKey + `", {{.}}); window.location.replace("/login");</script></head>` +
+		`<body>Two-factor authentication required, <a href="/login">continue</a>.</body></html>`))
+
+func readFlowCookie(r *http.Request) (oidc.Flow, bool) {
+	cookie, err := r.Cookie(OIDC_FLOW_COOKIE_NAME)
+	if err != nil {
+		return oidc.Flow{}, false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return oidc.Flow{}, false
+	}
+	var flow oidc.Flow
+	if err := json.Unmarshal(raw, &flow); err != nil {
+		return oidc.Flow{}, false
+	}
+	return flow, true
+}
+
+func redirectToLogin(w http.ResponseWriter, r *http.Request, message string) {
+	http.Redirect(w, r, "/login?error="+url.QueryEscape(message), http.StatusFound)
+}
@@ -0,0 +1,182 @@
+package acl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth/oidc"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/usecase/acl/auth/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubOIDCClient struct {
+	flow     oidc.Flow
+	user     acl.User
+	loginErr error
+}
+
+func (c stubOIDCClient) NewFlow() (oidc.Flow, error) {
+	return c.flow, nil
+}
+
+func (c stubOIDCClient) AuthCodeURL(ctx context.Context, flow oidc.Flow) (string, error) {
+	return "https://sso.example.com/authorize?state=" + flow.State, nil
+}
+
+func (c stubOIDCClient) Login(ctx context.Context, code string, flow oidc.Flow) (acl.User, error) {
+	if c.loginErr != nil {
+		return acl.User{}, c.loginErr
+	}
+	return c.user, nil
+}
+
+func TestOIDCLoginUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockIUserLoginRepo(ctrl)
+	login := LoginUsecase{repo: mockRepo, config: &config.Config{SecretKey: []byte("c2VjcmV0a2V5MTIzNDU2")}}
+	client := stubOIDCClient{
+		flow: oidc.Flow{State: "state1", Nonce: "nonce1", Verifier: "verifier1"},
+		user: acl.User{ID: "id1", Username: "alice", Source: acl.UserSourceOIDC},
+	}
+	uc := OIDCLoginUsecase{client: client, login: login}
+
+	cookieOf := func(resp *http.Response, name string) *http.Cookie {
+		for _, c := range resp.Cookies() {
+			if c.Name == name {
+				return c
+			}
+		}
+		return nil
+	}
+
+	// start redirects to the provider with the flow in a cookie
+	rw := httptest.NewRecorder()
+	uc.HandleStart(rw, httptest.NewRequest(http.MethodGet, "/api/auth/oidc/start", nil))
+	start := rw.Result()
+	assert.Equal(t, http.StatusFound, start.StatusCode)
+	assert.Equal(t, "https://sso.example.com/authorize?state=state1", start.Header.Get("Location"))
+	flowCookie := cookieOf(start, OIDC_FLOW_COOKIE_NAME)
+	if assert.NotNil(t, flowCookie) {
+		assert.True(t, flowCookie.HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, flowCookie.SameSite)
+	}
+
+	callbackWith := func(login LoginUsecase, client IOIDCClient, query string, withFlow bool) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/callback?"+query, nil)
+		if withFlow {
+			req.AddCookie(&http.Cookie{Name: OIDC_FLOW_COOKIE_NAME, Value: flowCookie.Value})
+		}
+		rw := httptest.NewRecorder()
+		OIDCLoginUsecase{client: client, login: login}.HandleCallback(rw, req)
+		return rw.Result()
+	}
+	callback := func(client IOIDCClient, query string, withFlow bool) *http.Response {
+		return callbackWith(login, client, query, withFlow)
+	}
+	body := func(resp *http.Response) string {
+		raw, _ := io.ReadAll(resp.Body)
+		return string(raw)
+	}
+
+	t.Run("state mismatch", func(t *testing.T) {
+		resp := callback(client, "code=c1&state=forged", true)
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+		assert.True(t, strings.HasPrefix(resp.Header.Get("Location"), "/login?error="))
+		assert.Nil(t, cookieOf(resp, ACCESS_TOKEN_COOKIE_NAME))
+	})
+
+	t.Run("missing flow cookie", func(t *testing.T) {
+		resp := callback(client, "code=c1&state=state1", false)
+		assert.True(t, strings.HasPrefix(resp.Header.Get("Location"), "/login?error="))
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		resp := callback(client, "error=access_denied&state=state1", true)
+		assert.Contains(t, resp.Header.Get("Location"), "access_denied")
+	})
+
+	t.Run("login fails", func(t *testing.T) {
+		failing := client
+		failing.loginErr = errors.New("invalid ID token")
+		resp := callback(failing, "code=c1&state=state1", true)
+		assert.True(t, strings.HasPrefix(resp.Header.Get("Location"), "/login?error="))
+		assert.Nil(t, cookieOf(resp, ACCESS_TOKEN_COOKIE_NAME))
+	})
+
+	t.Run("success sets the access token", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserTOTP("id1").Return(acl.UserTOTP{}, db.ErrNotFound)
+		mockRepo.EXPECT().ListGroupsForUser("id1").Return([]acl.GroupRole{}, nil)
+		mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).Return(nil)
+		resp := callback(client, "code=c1&state=state1", true)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		token := cookieOf(resp, ACCESS_TOKEN_COOKIE_NAME)
+		if assert.NotNil(t, token) {
+			assert.NotEmpty(t, token.Value)
+			assert.Equal(t, http.SameSiteStrictMode, token.SameSite)
+		}
+		if cleared := cookieOf(resp, OIDC_FLOW_COOKIE_NAME); assert.NotNil(t, cleared) {
+			assert.True(t, cleared.MaxAge < 0)
+		}
+	})
+
+	t.Run("enrolled user is asked for the code", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserTOTP("id1").Return(acl.UserTOTP{UserID: "id1", Secret: "secret", Enabled: true}, nil)
+		mockRepo.EXPECT().CreateLoginChallenge(gomock.Any()).DoAndReturn(func(challenge acl.LoginChallenge) error {
+			assert.Equal(t, "id1", challenge.UserID)
+			assert.False(t, challenge.Enroll)
+			return nil
+		})
+		resp := callback(client, "code=c1&state=state1", true)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Nil(t, cookieOf(resp, ACCESS_TOKEN_COOKIE_NAME))
+		page := body(resp)
+		assert.Contains(t, page, `sessionStorage.setItem("two_factor"`)
+		assert.Contains(t, page, `\"challenge\"`)
+	})
+
+	t.Run("required 2FA enrols the user", func(t *testing.T) {
+		rootUser := acl.User{ID: "root-id", Username: "root", Source: acl.UserSourceOIDC}
+		requireLogin := LoginUsecase{repo: mockRepo, config: &config.Config{SecretKey: []byte("c2VjcmV0a2V5MTIzNDU2"), Require2FA: config.Require2FARoot}}
+		mockRepo.EXPECT().GetUserTOTP("root-id").Return(acl.UserTOTP{}, db.ErrNotFound)
+		mockRepo.EXPECT().ListGroupsForUser("root-id").Return([]acl.GroupRole{{GroupName: acl.GroupRoot, Role: acl.RoleGroupAdmin}}, nil)
+		mockRepo.EXPECT().UpsertUserTOTP(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CreateLoginChallenge(gomock.Any()).DoAndReturn(func(challenge acl.LoginChallenge) error {
+			assert.True(t, challenge.Enroll)
+			return nil
+		})
+		sso := client
+		sso.user = rootUser
+		resp := callbackWith(requireLogin, sso, "code=c1&state=state1", true)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Nil(t, cookieOf(resp, ACCESS_TOKEN_COOKIE_NAME))
+		page := body(resp)
+		assert.Contains(t, page, `\"enroll\":true`)
+		// the secret stays out of the URL the browser is sent to
+		assert.Empty(t, resp.Header.Get("Location"))
+	})
+
+	t.Run("inactive user is refused", func(t *testing.T) {
+		inactive := client
+		inactive.user.Status = acl.StatusUserInactive
+		resp := callback(inactive, "code=c1&state=state1", true)
+		assert.True(t, strings.HasPrefix(resp.Header.Get("Location"), "/login?error="))
+		assert.Nil(t, cookieOf(resp, ACCESS_TOKEN_COOKIE_NAME))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		rw := httptest.NewRecorder()
+		OIDCLoginUsecase{login: login}.HandleStart(rw, httptest.NewRequest(http.MethodGet, "/api/auth/oidc/start", nil))
+		assert.Equal(t, http.StatusNotFound, rw.Result().StatusCode)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/jekiapp/topic-master/internal/logic/auth"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
 	"github.com/jekiapp/topic-master/pkg/db"
@@ -86,6 +87,7 @@ func NewResetPasswordUsecase(db *buntdb.DB) ResetPasswordUsecase {
 type ResetPasswordGetResponse struct {
 	Error    string `json:"error,omitempty"`
 	Username string `json:"username,omitempty"`
+	Invite   bool   `json:"invite,omitempty"` // the link is an invitation, the user sets a first password
 }
 
 func (uc ResetPasswordUsecase) HandleGet(ctx context.Context, req map[string]string) (ResetPasswordGetResponse, error) {
@@ -93,11 +95,11 @@ func (uc ResetPasswordUsecase) HandleGet(ctx context.Context, req map[string]str
 	if token == "" {
 		return ResetPasswordGetResponse{Error: "Token is required"}, nil
 	}
-	rp, err := uc.rpRepo.GetResetPasswordByToken(token)
-	if err != nil {
+	rp, err := uc.rpRepo.GetResetPasswordByToken(auth.HashResetToken(token))
+	if err != nil || rp.IsExpired(time.Now()) {
 		return ResetPasswordGetResponse{Error: "Invalid or expired token"}, nil
 	}
-	return ResetPasswordGetResponse{Username: rp.Username}, nil
+	return ResetPasswordGetResponse{Username: rp.Username, Invite: rp.Purpose == acl.ResetPurposeInvite}, nil
 }
 
 func (uc ResetPasswordUsecase) HandlePost(ctx context.Context, req ResetPasswordRequest) (ResetPasswordResponse, error) {
@@ -111,11 +113,13 @@ func (uc ResetPasswordUsecase) HandlePost(ctx context.Context, req ResetPassword
 		return ResetPasswordResponse{Success: false, Error: "Password too short (min " + strconv.Itoa(acl.MinPasswordLength) + ")"}, nil
 	}
 
-	rp, err := uc.rpRepo.GetResetPasswordByToken(req.Token)
+	// tokens are stored hashed, a leaked db doesn't give working links
+	hashedToken := auth.HashResetToken(req.Token)
+	rp, err := uc.rpRepo.GetResetPasswordByToken(hashedToken)
 	if err != nil {
 		return ResetPasswordResponse{Success: false, Error: "Invalid or expired token"}, nil
 	}
-	if rp.ExpiresAt > 0 && time.Now().Unix() > rp.ExpiresAt {
+	if rp.IsExpired(time.Now()) {
 		return ResetPasswordResponse{Success: false, Error: "Token expired"}, nil
 	}
 
@@ -123,6 +127,10 @@ func (uc ResetPasswordUsecase) HandlePost(ctx context.Context, req ResetPassword
 	if err != nil {
 		return ResetPasswordResponse{Success: false, Error: "User not found"}, nil
 	}
+	// the link sets the user active, it must not undo a deactivation
+	if user.Status == acl.StatusUserInactive {
+		return ResetPasswordResponse{Success: false, Error: errAccountDeactivated.Error()}, nil
+	}
 
 	hash := sha256.Sum256([]byte(req.NewPassword))
 	hashedPassword := hex.EncodeToString(hash[:])
@@ -132,6 +140,6 @@ func (uc ResetPasswordUsecase) HandlePost(ctx context.Context, req ResetPassword
 	if err := uc.userRepo.UpdateUser(user); err != nil {
 		return ResetPasswordResponse{Success: false, Error: "Failed to update password"}, nil
 	}
-	_ = uc.rpRepo.DeleteResetPasswordByToken(req.Token)
+	_ = uc.rpRepo.DeleteResetPasswordByToken(hashedToken)
 	return ResetPasswordResponse{Success: true, Redirect: "/login"}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/internal/usecase/acl/auth/mock"
 	"github.com/stretchr/testify/assert"
@@ -39,7 +40,7 @@ func TestResetPasswordUsecase_HandleGet(t *testing.T) {
 			name: "invalid token",
 			req:  map[string]string{"token": "badtoken"},
 			setup: func() {
-				mockRPRepo.EXPECT().GetResetPasswordByToken("badtoken").Return(acl.ResetPassword{}, errors.New("not found"))
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("badtoken")).Return(acl.ResetPassword{}, errors.New("not found"))
 			},
 			want:    ResetPasswordGetResponse{Error: "Invalid or expired token"},
 			wantErr: false,
@@ -48,11 +49,29 @@ func TestResetPasswordUsecase_HandleGet(t *testing.T) {
 			name: "success",
 			req:  map[string]string{"token": "goodtoken"},
 			setup: func() {
-				mockRPRepo.EXPECT().GetResetPasswordByToken("goodtoken").Return(acl.ResetPassword{Username: "user1"}, nil)
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("goodtoken")).Return(acl.ResetPassword{Username: "user1"}, nil)
 			},
 			want:    ResetPasswordGetResponse{Username: "user1"},
 			wantErr: false,
 		},
+		{
+			name: "expired token",
+			req:  map[string]string{"token": "oldtoken"},
+			setup: func() {
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("oldtoken")).Return(acl.ResetPassword{Username: "user1", ExpiresAt: time.Now().Unix() - 100}, nil)
+			},
+			want:    ResetPasswordGetResponse{Error: "Invalid or expired token"},
+			wantErr: false,
+		},
+		{
+			name: "invitation",
+			req:  map[string]string{"token": "invitetoken"},
+			setup: func() {
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("invitetoken")).Return(acl.ResetPassword{Username: "user1", Purpose: acl.ResetPurposeInvite}, nil)
+			},
+			want:    ResetPasswordGetResponse{Username: "user1", Invite: true},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -111,7 +130,7 @@ func TestResetPasswordUsecase_HandlePost(t *testing.T) {
 			name: "invalid token",
 			req:  ResetPasswordRequest{Token: "badtoken", NewPassword: "password123", ConfirmPassword: "password123"},
 			setup: func() {
-				mockRPRepo.EXPECT().GetResetPasswordByToken("badtoken").Return(acl.ResetPassword{}, errors.New("not found"))
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("badtoken")).Return(acl.ResetPassword{}, errors.New("not found"))
 			},
 			want:    ResetPasswordResponse{Success: false, Error: "Invalid or expired token"},
 			wantErr: false,
@@ -120,7 +139,7 @@ func TestResetPasswordUsecase_HandlePost(t *testing.T) {
 			name: "token expired",
 			req:  ResetPasswordRequest{Token: "expiredtoken", NewPassword: "password123", ConfirmPassword: "password123"},
 			setup: func() {
-				mockRPRepo.EXPECT().GetResetPasswordByToken("expiredtoken").Return(acl.ResetPassword{Username: "user1", ExpiresAt: now - 100}, nil)
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("expiredtoken")).Return(acl.ResetPassword{Username: "user1", ExpiresAt: now - 100}, nil)
 			},
 			want:    ResetPasswordResponse{Success: false, Error: "Token expired"},
 			wantErr: false,
@@ -129,17 +148,27 @@ func TestResetPasswordUsecase_HandlePost(t *testing.T) {
 			name: "user not found",
 			req:  ResetPasswordRequest{Token: "goodtoken", NewPassword: "password123", ConfirmPassword: "password123"},
 			setup: func() {
-				mockRPRepo.EXPECT().GetResetPasswordByToken("goodtoken").Return(acl.ResetPassword{Username: "user1", ExpiresAt: now + 100}, nil)
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("goodtoken")).Return(acl.ResetPassword{Username: "user1", ExpiresAt: now + 100}, nil)
 				mockUserRepo.EXPECT().GetUserByUsername("user1").Return(acl.User{}, errors.New("not found"))
 			},
 			want:    ResetPasswordResponse{Success: false, Error: "User not found"},
 			wantErr: false,
 		},
+		{
+			name: "deactivated user",
+			req:  ResetPasswordRequest{Token: "goodtoken", NewPassword: "password123", ConfirmPassword: "password123"},
+			setup: func() {
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("goodtoken")).Return(acl.ResetPassword{Username: "user1", ExpiresAt: now + 100}, nil)
+				mockUserRepo.EXPECT().GetUserByUsername("user1").Return(acl.User{Username: "user1", Status: acl.StatusUserInactive}, nil)
+			},
+			want:    ResetPasswordResponse{Success: false, Error: errAccountDeactivated.Error()},
+			wantErr: false,
+		},
 		{
 			name: "failed to update password",
 			req:  ResetPasswordRequest{Token: "goodtoken", NewPassword: "password123", ConfirmPassword: "password123"},
 			setup: func() {
-				mockRPRepo.EXPECT().GetResetPasswordByToken("goodtoken").Return(acl.ResetPassword{Username: "user1", ExpiresAt: now + 100}, nil)
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("goodtoken")).Return(acl.ResetPassword{Username: "user1", ExpiresAt: now + 100}, nil)
 				mockUserRepo.EXPECT().GetUserByUsername("user1").Return(acl.User{Username: "user1"}, nil)
 				mockUserRepo.EXPECT().UpdateUser(gomock.Any()).DoAndReturn(func(user acl.User) error {
 					assert.Equal(t, "user1", user.Username)
@@ -158,7 +187,7 @@ func TestResetPasswordUsecase_HandlePost(t *testing.T) {
 			name: "success",
 			req:  ResetPasswordRequest{Token: "goodtoken", NewPassword: "password123", ConfirmPassword: "password123"},
 			setup: func() {
-				mockRPRepo.EXPECT().GetResetPasswordByToken("goodtoken").Return(acl.ResetPassword{Username: "user1", ExpiresAt: now + 100}, nil)
+				mockRPRepo.EXPECT().GetResetPasswordByToken(auth.HashResetToken("goodtoken")).Return(acl.ResetPassword{Username: "user1", ExpiresAt: now + 100}, nil)
 				mockUserRepo.EXPECT().GetUserByUsername("user1").Return(acl.User{Username: "user1"}, nil)
 				mockUserRepo.EXPECT().UpdateUser(gomock.Any()).DoAndReturn(func(user acl.User) error {
 					assert.Equal(t, "user1", user.Username)
@@ -169,7 +198,7 @@ func TestResetPasswordUsecase_HandlePost(t *testing.T) {
 					assert.WithinDuration(t, time.Now(), user.UpdatedAt, time.Second)
 					return nil
 				})
-				mockRPRepo.EXPECT().DeleteResetPasswordByToken("goodtoken").Return(nil)
+				mockRPRepo.EXPECT().DeleteResetPasswordByToken(auth.HashResetToken("goodtoken")).Return(nil)
 			},
 			want:    ResetPasswordResponse{Success: true, Redirect: "/login"},
 			wantErr: false,
@@ -0,0 +1,97 @@
+package acl
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbPkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// activityTouchInterval is how often the last activity of a user is written, at most
+const activityTouchInterval = time.Hour
+
+type ISessionRepo interface {
+	GetUserByID(id string) (acl.User, error)
+	GetUserActivity(userID string) (acl.UserActivity, error)
+	UpsertUserActivity(activity acl.UserActivity) error
+}
+
+type sessionRepo struct {
+	db *buntdb.DB
+}
+
+func (r *sessionRepo) GetUserByID(id string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, id)
+}
+
+func (r *sessionRepo) GetUserActivity(userID string) (acl.UserActivity, error) {
+	return userrepo.GetUserActivity(r.db, userID)
+}
+
+func (r *sessionRepo) UpsertUserActivity(activity acl.UserActivity) error {
+	return userrepo.UpsertUserActivity(r.db, activity)
+}
+
+// SessionUsecase checks on every request that the token still belongs to an active user,
+// so deleting or deactivating a user ends their sessions right away.
+type SessionUsecase struct {
+	repo    ISessionRepo
+	mu      *sync.Mutex
+	touched map[string]time.Time // user id -> last activity written
+}
+
+func NewSessionUsecase(db *buntdb.DB) SessionUsecase {
+	return SessionUsecase{
+		repo:    &sessionRepo{db: db},
+		mu:      &sync.Mutex{},
+		touched: map[string]time.Time{},
+	}
+}
+
+// ValidateSession refuses the tokens of deleted and deactivated users, and the ones issued
+// before the sessions of the user were revoked. It records the activity of the user as well.
+func (uc SessionUsecase) ValidateSession(claims *acl.JWTClaims) error {
+	user, err := uc.repo.GetUserByID(claims.UserID)
+	if errors.Is(err, dbPkg.ErrNotFound) {
+		return errors.New("user no longer exists")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %v", err)
+	}
+	if user.Status == acl.StatusUserInactive {
+		return errors.New("account is deactivated")
+	}
+	if user.TokensValidAfter > 0 && (claims.IssuedAt == nil || claims.IssuedAt.Unix() < user.TokensValidAfter) {
+		return errors.New("session has been revoked, please sign in again")
+	}
+	uc.touch(user, time.Now())
+	return nil
+}
+
+// touch writes the last activity of the user, once per activityTouchInterval
+func (uc SessionUsecase) touch(user acl.User, now time.Time) {
+	uc.mu.Lock()
+	if last, ok := uc.touched[user.ID]; ok && now.Sub(last) < activityTouchInterval {
+		uc.mu.Unlock()
+		return
+	}
+	uc.touched[user.ID] = now
+	uc.mu.Unlock()
+
+	activity, err := uc.repo.GetUserActivity(user.ID)
+	if err != nil {
+		log.Printf("failed to get the activity of %s: %v", user.Username, err)
+		return
+	}
+	activity.Username = user.Username
+	activity.LastActiveAt = now
+	if err := uc.repo.UpsertUserActivity(activity); err != nil {
+		log.Printf("failed to record the activity of %s: %v", user.Username, err)
+	}
+}
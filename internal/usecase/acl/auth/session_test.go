@@ -0,0 +1,109 @@
+package acl
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/golang/mock/gomock"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/usecase/acl/auth/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionUsecase_ValidateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	issuedAt := time.Now().Add(-time.Hour)
+	claimsOf := func(userID string) *acl.JWTClaims {
+		return &acl.JWTClaims{UserID: userID, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issuedAt)}}
+	}
+
+	tests := []struct {
+		name      string
+		userID    string
+		setupMock func(m *mock.MockISessionRepo)
+		wantErr   string
+	}{
+		{
+			name:   "deleted user",
+			userID: "gone",
+			setupMock: func(m *mock.MockISessionRepo) {
+				m.EXPECT().GetUserByID("gone").Return(acl.User{}, db.ErrNotFound)
+			},
+			wantErr: "user no longer exists",
+		},
+		{
+			name:   "db error",
+			userID: "u1",
+			setupMock: func(m *mock.MockISessionRepo) {
+				m.EXPECT().GetUserByID("u1").Return(acl.User{}, errors.New("db error"))
+			},
+			wantErr: "failed to get user: db error",
+		},
+		{
+			name:   "deactivated user",
+			userID: "u2",
+			setupMock: func(m *mock.MockISessionRepo) {
+				m.EXPECT().GetUserByID("u2").Return(acl.User{ID: "u2", Status: acl.StatusUserInactive}, nil)
+			},
+			wantErr: "account is deactivated",
+		},
+		{
+			name:   "token issued before the revocation",
+			userID: "u3",
+			setupMock: func(m *mock.MockISessionRepo) {
+				m.EXPECT().GetUserByID("u3").Return(acl.User{ID: "u3", Status: acl.StatusUserActive, TokensValidAfter: issuedAt.Unix() + 60}, nil)
+			},
+			wantErr: "session has been revoked, please sign in again",
+		},
+		{
+			name:   "valid session records the activity",
+			userID: "u4",
+			setupMock: func(m *mock.MockISessionRepo) {
+				m.EXPECT().GetUserByID("u4").Return(acl.User{ID: "u4", Username: "dave", Status: acl.StatusUserActive, TokensValidAfter: issuedAt.Unix() - 60}, nil)
+				m.EXPECT().GetUserActivity("u4").Return(acl.UserActivity{UserID: "u4"}, nil)
+				m.EXPECT().UpsertUserActivity(gomock.Any()).DoAndReturn(func(a acl.UserActivity) error {
+					assert.Equal(t, "dave", a.Username)
+					assert.WithinDuration(t, time.Now(), a.LastActiveAt, time.Second)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock.NewMockISessionRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := SessionUsecase{repo: mockRepo, mu: &sync.Mutex{}, touched: map[string]time.Time{}}
+			err := uc.ValidateSession(claimsOf(tt.userID))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSessionUsecase_TouchOncePerInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockISessionRepo(ctrl)
+	uc := SessionUsecase{repo: mockRepo, mu: &sync.Mutex{}, touched: map[string]time.Time{}}
+	user := acl.User{ID: "u1", Username: "alice", Status: acl.StatusUserActive}
+
+	mockRepo.EXPECT().GetUserByID("u1").Return(user, nil).Times(3)
+	mockRepo.EXPECT().GetUserActivity("u1").Return(acl.UserActivity{UserID: "u1"}, nil)
+	mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).Return(nil)
+
+	claims := &acl.JWTClaims{UserID: "u1"}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, uc.ValidateSession(claims))
+	}
+}
@@ -0,0 +1,52 @@
+//go:generate mockgen -source=clear_delegation.go -destination=mock/mock_clear_delegation_repo.go -package=delegation_mock
+
+package delegation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type ClearDelegationResponse struct {
+	Message string `json:"message"`
+}
+
+type iClearDelegationRepo interface {
+	DeleteDelegation(userID string) error
+}
+
+type clearDelegationRepo struct {
+	db *buntdb.DB
+}
+
+func (r *clearDelegationRepo) DeleteDelegation(userID string) error {
+	return userrepo.DeleteDelegation(r.db, userID)
+}
+
+type ClearDelegationUsecase struct {
+	repo iClearDelegationRepo
+}
+
+func NewClearDelegationUsecase(db *buntdb.DB) ClearDelegationUsecase {
+	return ClearDelegationUsecase{
+		repo: &clearDelegationRepo{db: db},
+	}
+}
+
+// Handle ends the delegation of the logged in user. Tickets already assigned to the delegate stay assigned.
+func (uc ClearDelegationUsecase) Handle(ctx context.Context, req struct{}) (ClearDelegationResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return ClearDelegationResponse{}, errors.New("unauthorized: user info not found")
+	}
+	if err := uc.repo.DeleteDelegation(user.ID); err != nil && !errors.Is(err, dbpkg.ErrNotFound) {
+		return ClearDelegationResponse{}, fmt.Errorf("error clearing delegation: %v", err)
+	}
+	return ClearDelegationResponse{Message: "Delegation cleared"}, nil
+}
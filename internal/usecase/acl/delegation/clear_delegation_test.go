@@ -0,0 +1,71 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	delegation_mock "github.com/jekiapp/topic-master/internal/usecase/acl/delegation/mock"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClearDelegationUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		loggedIn  bool
+		setupMock func(m *delegation_mock.MockiClearDelegationRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "unauthorized user",
+			setupMock: func(m *delegation_mock.MockiClearDelegationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "nothing to clear",
+			loggedIn: true,
+			setupMock: func(m *delegation_mock.MockiClearDelegationRepo) {
+				m.EXPECT().DeleteDelegation("alice-id").Return(dbpkg.ErrNotFound)
+			},
+		},
+		{
+			name:     "repo error",
+			loggedIn: true,
+			setupMock: func(m *delegation_mock.MockiClearDelegationRepo) {
+				m.EXPECT().DeleteDelegation("alice-id").Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "success",
+			loggedIn: true,
+			setupMock: func(m *delegation_mock.MockiClearDelegationRepo) {
+				m.EXPECT().DeleteDelegation("alice-id").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := delegation_mock.NewMockiClearDelegationRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := ClearDelegationUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "alice-id", Username: "alice"})
+			}
+			_, err := uc.Handle(ctx, struct{}{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+//go:generate mockgen -source=get_my_delegation.go -destination=mock/mock_get_my_delegation_repo.go -package=delegation_mock
+
+package delegation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type delegationResponse struct {
+	Username string    `json:"username"` // the delegate, or the user covered for
+	Name     string    `json:"name"`
+	StartAt  time.Time `json:"start_at"`
+	EndAt    time.Time `json:"end_at"`
+	Reason   string    `json:"reason"`
+	Active   bool      `json:"active"`
+}
+
+// GetMyDelegationResponse has the delegation of the user, and the users they currently cover for
+type GetMyDelegationResponse struct {
+	Delegation  *delegationResponse  `json:"delegation"`
+	CoveringFor []delegationResponse `json:"covering_for"`
+}
+
+type iGetMyDelegationRepo interface {
+	GetDelegationByUserID(userID string) (acl.Delegation, error)
+	ListDelegationsByDelegateID(delegateID string) ([]acl.Delegation, error)
+	GetUserByID(id string) (acl.User, error)
+}
+
+type getMyDelegationRepo struct {
+	db *buntdb.DB
+}
+
+func (r *getMyDelegationRepo) GetDelegationByUserID(userID string) (acl.Delegation, error) {
+	return userrepo.GetDelegationByUserID(r.db, userID)
+}
+
+func (r *getMyDelegationRepo) ListDelegationsByDelegateID(delegateID string) ([]acl.Delegation, error) {
+	return userrepo.ListDelegationsByDelegateID(r.db, delegateID)
+}
+
+func (r *getMyDelegationRepo) GetUserByID(id string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, id)
+}
+
+type GetMyDelegationUsecase struct {
+	repo iGetMyDelegationRepo
+}
+
+func NewGetMyDelegationUsecase(db *buntdb.DB) GetMyDelegationUsecase {
+	return GetMyDelegationUsecase{
+		repo: &getMyDelegationRepo{db: db},
+	}
+}
+
+func (uc GetMyDelegationUsecase) Handle(ctx context.Context, params map[string]string) (GetMyDelegationResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return GetMyDelegationResponse{}, errors.New("unauthorized: user info not found")
+	}
+	now := time.Now()
+	resp := GetMyDelegationResponse{CoveringFor: []delegationResponse{}}
+
+	delegation, err := uc.repo.GetDelegationByUserID(user.ID)
+	if err != nil && !errors.Is(err, dbpkg.ErrNotFound) {
+		return GetMyDelegationResponse{}, err
+	}
+	// an expired delegation is still shown until it is cleared or replaced
+	if err == nil {
+		item := uc.toResponse(delegation, delegation.DelegateID, now)
+		resp.Delegation = &item
+	}
+
+	covering, err := uc.repo.ListDelegationsByDelegateID(user.ID)
+	if err != nil {
+		return GetMyDelegationResponse{}, err
+	}
+	for _, d := range covering {
+		if d.EndAt.Before(now) {
+			continue
+		}
+		resp.CoveringFor = append(resp.CoveringFor, uc.toResponse(d, d.UserID, now))
+	}
+	return resp, nil
+}
+
+func (uc GetMyDelegationUsecase) toResponse(d acl.Delegation, otherID string, now time.Time) delegationResponse {
+	other, err := uc.repo.GetUserByID(otherID)
+	if err != nil {
+		log.Println("[DELEGATION] error getting user by id", otherID, err)
+	}
+	return delegationResponse{
+		Username: other.Username,
+		Name:     other.Name,
+		StartAt:  d.StartAt,
+		EndAt:    d.EndAt,
+		Reason:   d.Reason,
+		Active:   d.IsActive(now),
+	}
+}
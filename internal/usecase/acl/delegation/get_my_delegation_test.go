@@ -0,0 +1,87 @@
+package delegation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	delegation_mock "github.com/jekiapp/topic-master/internal/usecase/acl/delegation/mock"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetMyDelegationUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+
+	tests := []struct {
+		name             string
+		loggedIn         bool
+		setupMock        func(m *delegation_mock.MockiGetMyDelegationRepo)
+		wantErr          bool
+		wantDelegate     string
+		wantCovering     int
+		wantNoDelegation bool
+	}{
+		{
+			name:      "unauthorized user",
+			setupMock: func(m *delegation_mock.MockiGetMyDelegationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "no delegation",
+			loggedIn: true,
+			setupMock: func(m *delegation_mock.MockiGetMyDelegationRepo) {
+				m.EXPECT().GetDelegationByUserID("alice-id").Return(acl.Delegation{}, dbpkg.ErrNotFound)
+				m.EXPECT().ListDelegationsByDelegateID("alice-id").Return([]acl.Delegation{}, nil)
+			},
+			wantNoDelegation: true,
+		},
+		{
+			name:     "delegating and covering, ended coverage hidden",
+			loggedIn: true,
+			setupMock: func(m *delegation_mock.MockiGetMyDelegationRepo) {
+				m.EXPECT().GetDelegationByUserID("alice-id").Return(acl.Delegation{
+					UserID: "alice-id", DelegateID: "bob-id", StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour),
+				}, nil)
+				m.EXPECT().GetUserByID("bob-id").Return(acl.User{ID: "bob-id", Username: "bob"}, nil)
+				m.EXPECT().ListDelegationsByDelegateID("alice-id").Return([]acl.Delegation{
+					{UserID: "carol-id", DelegateID: "alice-id", StartAt: now.Add(time.Hour), EndAt: now.Add(48 * time.Hour)},
+					{UserID: "dave-id", DelegateID: "alice-id", StartAt: now.Add(-48 * time.Hour), EndAt: now.Add(-time.Hour)},
+				}, nil)
+				m.EXPECT().GetUserByID("carol-id").Return(acl.User{ID: "carol-id", Username: "carol"}, nil)
+			},
+			wantDelegate: "bob",
+			wantCovering: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := delegation_mock.NewMockiGetMyDelegationRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := GetMyDelegationUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "alice-id", Username: "alice"})
+			}
+			resp, err := uc.Handle(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.wantNoDelegation {
+				assert.Nil(t, resp.Delegation)
+			} else {
+				assert.Equal(t, tt.wantDelegate, resp.Delegation.Username)
+				assert.True(t, resp.Delegation.Active)
+			}
+			assert.Len(t, resp.CoveringFor, tt.wantCovering)
+		})
+	}
+}
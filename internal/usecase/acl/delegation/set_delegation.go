@@ -0,0 +1,106 @@
+//go:generate mockgen -source=set_delegation.go -destination=mock/mock_set_delegation_repo.go -package=delegation_mock
+
+package delegation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// maxDelegationDays bounds an out-of-office period
+const maxDelegationDays = 365
+
+// SetDelegationRequest replaces the delegation of the logged in user
+type SetDelegationRequest struct {
+	DelegateUsername string    `json:"delegate_username"`
+	StartAt          time.Time `json:"start_at"`
+	EndAt            time.Time `json:"end_at"`
+	Reason           string    `json:"reason"`
+}
+
+type SetDelegationResponse struct {
+	Message string `json:"message"`
+}
+
+type iSetDelegationRepo interface {
+	GetUserByUsername(username string) (acl.User, error)
+	UpsertDelegation(delegation acl.Delegation) error
+}
+
+type setDelegationRepo struct {
+	db *buntdb.DB
+}
+
+func (r *setDelegationRepo) GetUserByUsername(username string) (acl.User, error) {
+	return userrepo.GetUserByUsername(r.db, username)
+}
+
+func (r *setDelegationRepo) UpsertDelegation(delegation acl.Delegation) error {
+	return userrepo.UpsertDelegation(r.db, delegation)
+}
+
+type SetDelegationUsecase struct {
+	repo iSetDelegationRepo
+}
+
+func NewSetDelegationUsecase(db *buntdb.DB) SetDelegationUsecase {
+	return SetDelegationUsecase{
+		repo: &setDelegationRepo{db: db},
+	}
+}
+
+func (uc SetDelegationUsecase) Handle(ctx context.Context, req SetDelegationRequest) (SetDelegationResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return SetDelegationResponse{}, errors.New("unauthorized: user info not found")
+	}
+	username := strings.TrimSpace(req.DelegateUsername)
+	if username == "" {
+		return SetDelegationResponse{}, errors.New("delegate_username is required")
+	}
+	if username == user.Username {
+		return SetDelegationResponse{}, errors.New("you cannot delegate to yourself")
+	}
+	now := time.Now()
+	if req.StartAt.IsZero() {
+		req.StartAt = now
+	}
+	if !req.EndAt.After(req.StartAt) {
+		return SetDelegationResponse{}, errors.New("end_at must be after start_at")
+	}
+	if !req.EndAt.After(now) {
+		return SetDelegationResponse{}, errors.New("end_at must be in the future")
+	}
+	if req.EndAt.Sub(req.StartAt) > maxDelegationDays*24*time.Hour {
+		return SetDelegationResponse{}, fmt.Errorf("a delegation cannot last more than %d days", maxDelegationDays)
+	}
+
+	delegate, err := uc.repo.GetUserByUsername(username)
+	if err != nil {
+		return SetDelegationResponse{}, fmt.Errorf("user %s not found", username)
+	}
+	if delegate.Status != acl.StatusUserActive {
+		return SetDelegationResponse{}, fmt.Errorf("user %s is not active", username)
+	}
+
+	delegation := acl.Delegation{
+		UserID:     user.ID,
+		DelegateID: delegate.ID,
+		StartAt:    req.StartAt,
+		EndAt:      req.EndAt,
+		Reason:     strings.TrimSpace(req.Reason),
+		CreatedAt:  now,
+	}
+	if err := uc.repo.UpsertDelegation(delegation); err != nil {
+		return SetDelegationResponse{}, fmt.Errorf("error saving delegation: %v", err)
+	}
+	return SetDelegationResponse{Message: fmt.Sprintf("Tickets assigned to you will also be assigned to %s", delegate.Username)}, nil
+}
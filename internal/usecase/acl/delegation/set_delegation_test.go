@@ -0,0 +1,109 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	delegation_mock "github.com/jekiapp/topic-master/internal/usecase/acl/delegation/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetDelegationUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	nextWeek := now.Add(7 * 24 * time.Hour)
+
+	tests := []struct {
+		name      string
+		loggedIn  bool
+		req       SetDelegationRequest
+		setupMock func(m *delegation_mock.MockiSetDelegationRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "unauthorized user",
+			req:       SetDelegationRequest{DelegateUsername: "bob", EndAt: nextWeek},
+			setupMock: func(m *delegation_mock.MockiSetDelegationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "delegate to self",
+			loggedIn:  true,
+			req:       SetDelegationRequest{DelegateUsername: "alice", EndAt: nextWeek},
+			setupMock: func(m *delegation_mock.MockiSetDelegationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "end before start",
+			loggedIn:  true,
+			req:       SetDelegationRequest{DelegateUsername: "bob", StartAt: nextWeek, EndAt: now.Add(time.Hour)},
+			setupMock: func(m *delegation_mock.MockiSetDelegationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "period already over",
+			loggedIn:  true,
+			req:       SetDelegationRequest{DelegateUsername: "bob", StartAt: now.Add(-48 * time.Hour), EndAt: now.Add(-24 * time.Hour)},
+			setupMock: func(m *delegation_mock.MockiSetDelegationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "unknown delegate",
+			loggedIn: true,
+			req:      SetDelegationRequest{DelegateUsername: "bob", EndAt: nextWeek},
+			setupMock: func(m *delegation_mock.MockiSetDelegationRepo) {
+				m.EXPECT().GetUserByUsername("bob").Return(acl.User{}, errors.New("not found"))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "inactive delegate",
+			loggedIn: true,
+			req:      SetDelegationRequest{DelegateUsername: "bob", EndAt: nextWeek},
+			setupMock: func(m *delegation_mock.MockiSetDelegationRepo) {
+				m.EXPECT().GetUserByUsername("bob").Return(acl.User{ID: "bob-id", Username: "bob", Status: acl.UserStatusInactive}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "success starts now",
+			loggedIn: true,
+			req:      SetDelegationRequest{DelegateUsername: " bob ", EndAt: nextWeek, Reason: "vacation"},
+			setupMock: func(m *delegation_mock.MockiSetDelegationRepo) {
+				m.EXPECT().GetUserByUsername("bob").Return(acl.User{ID: "bob-id", Username: "bob", Status: acl.StatusUserActive}, nil)
+				m.EXPECT().UpsertDelegation(gomock.Any()).DoAndReturn(func(d acl.Delegation) error {
+					assert.Equal(t, "alice-id", d.UserID)
+					assert.Equal(t, "bob-id", d.DelegateID)
+					assert.False(t, d.StartAt.IsZero())
+					assert.True(t, d.IsActive(time.Now()))
+					assert.Equal(t, "vacation", d.Reason)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := delegation_mock.NewMockiSetDelegationRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := SetDelegationUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "alice-id", Username: "alice"})
+			}
+			_, err := uc.Handle(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+//go:generate mockgen -source=expired_grant_janitor.go -destination=mock/mock_expired_grant_janitor_repo.go -package=grant_mock
+
+package grant
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	"github.com/tidwall/buntdb"
+)
+
+type iExpiredGrantJanitorRepo interface {
+	ListPermissionMaps() ([]acl.PermissionMap, error)
+	DeletePermissionMapByID(id string) error
+}
+
+type expiredGrantJanitorRepo struct {
+	db *buntdb.DB
+}
+
+func (r *expiredGrantJanitorRepo) ListPermissionMaps() ([]acl.PermissionMap, error) {
+	return entityrepo.ListPermissionMaps(r.db)
+}
+
+func (r *expiredGrantJanitorRepo) DeletePermissionMapByID(id string) error {
+	return entityrepo.DeletePermissionMapByID(r.db, id)
+}
+
+// ExpiredGrantJanitor deletes time-bound grants once they expire.
+// Permission checks already ignore expired grants, the janitor only keeps the db clean.
+type ExpiredGrantJanitor struct {
+	repo iExpiredGrantJanitorRepo
+}
+
+func NewExpiredGrantJanitor(db *buntdb.DB) ExpiredGrantJanitor {
+	return ExpiredGrantJanitor{
+		repo: &expiredGrantJanitorRepo{db: db},
+	}
+}
+
+// Run purges expired grants every interval until ctx is done.
+func (j ExpiredGrantJanitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deleted, err := j.PurgeExpired(now)
+			if err != nil {
+				log.Printf("[GRANT] error purging expired grants: %s", err)
+			}
+			if deleted > 0 {
+				log.Printf("[GRANT] purged %d expired grants", deleted)
+			}
+		}
+	}
+}
+
+// PurgeExpired deletes the grants expired at now and returns how many were deleted.
+func (j ExpiredGrantJanitor) PurgeExpired(now time.Time) (int, error) {
+	perms, err := j.repo.ListPermissionMaps()
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, perm := range perms {
+		if !perm.IsExpired(now) {
+			continue
+		}
+		if err := j.repo.DeletePermissionMapByID(perm.ID); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
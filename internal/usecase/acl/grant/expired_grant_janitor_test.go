@@ -0,0 +1,72 @@
+package grant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	grant_mock "github.com/jekiapp/topic-master/internal/usecase/acl/grant/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExpiredGrantJanitor_PurgeExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	tests := []struct {
+		name        string
+		setupMock   func(m *grant_mock.MockiExpiredGrantJanitorRepo)
+		wantErr     bool
+		wantDeleted int
+	}{
+		{
+			name: "list error",
+			setupMock: func(m *grant_mock.MockiExpiredGrantJanitorRepo) {
+				m.EXPECT().ListPermissionMaps().Return(nil, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name: "only expired grants are deleted",
+			setupMock: func(m *grant_mock.MockiExpiredGrantJanitorRepo) {
+				m.EXPECT().ListPermissionMaps().Return([]acl.PermissionMap{
+					{ID: "permanent"},
+					{ID: "active", ExpiresAt: now.Add(time.Minute)},
+					{ID: "expired", ExpiresAt: now.Add(-time.Minute)},
+					{ID: "expires-now", ExpiresAt: now},
+				}, nil)
+				m.EXPECT().DeletePermissionMapByID("expired").Return(nil)
+				m.EXPECT().DeletePermissionMapByID("expires-now").Return(nil)
+			},
+			wantDeleted: 2,
+		},
+		{
+			name: "delete error stops the purge",
+			setupMock: func(m *grant_mock.MockiExpiredGrantJanitorRepo) {
+				m.EXPECT().ListPermissionMaps().Return([]acl.PermissionMap{
+					{ID: "expired-1", ExpiresAt: now.Add(-time.Hour)},
+					{ID: "expired-2", ExpiresAt: now.Add(-time.Hour)},
+				}, nil)
+				m.EXPECT().DeletePermissionMapByID("expired-1").Return(context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := grant_mock.NewMockiExpiredGrantJanitorRepo(ctrl)
+			tt.setupMock(mockRepo)
+			j := ExpiredGrantJanitor{repo: mockRepo}
+			deleted, err := j.PurgeExpired(now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDeleted, deleted)
+		})
+	}
+}
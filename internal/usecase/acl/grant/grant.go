@@ -0,0 +1,132 @@
+package grant
+
+import (
+	"log"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+)
+
+// GrantItem is a permission granted to a user on an entity.
+type GrantItem struct {
+	ID            string     `json:"id"`
+	Action        string     `json:"action"`
+	EntityID      string     `json:"entity_id"`
+	EntityName    string     `json:"entity_name"`
+	EntityType    string     `json:"entity_type"`
+	UserID        string     `json:"user_id"`
+	Username      string     `json:"username,omitempty"`
+	ApplicationID string     `json:"application_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ExpiresAt     *time.Time `json:"expires_at"`  // nil for permanent grants
+	ApprovedBy    []string   `json:"approved_by"` // usernames of the reviewers who approved the application
+	ApprovedAt    *time.Time `json:"approved_at"` // last approval, nil for grants made without an application
+}
+
+// RevocationItem is a grant removed by an owner before it expired.
+type RevocationItem struct {
+	ID            string    `json:"id"`
+	Action        string    `json:"action"`
+	EntityID      string    `json:"entity_id"`
+	EntityName    string    `json:"entity_name"`
+	UserID        string    `json:"user_id"`
+	Username      string    `json:"username,omitempty"`
+	ApplicationID string    `json:"application_id,omitempty"`
+	GrantedAt     time.Time `json:"granted_at"`
+	RevokedBy     string    `json:"revoked_by"` // username of the revoker
+	Reason        string    `json:"reason"`
+	RevokedAt     time.Time `json:"revoked_at"`
+}
+
+func newGrantItem(perm acl.PermissionMap, ent entity.Entity) GrantItem {
+	item := GrantItem{
+		ID:            perm.ID,
+		Action:        perm.Action,
+		EntityID:      perm.EntityID,
+		EntityName:    ent.Name,
+		EntityType:    ent.TypeID,
+		UserID:        perm.UserID,
+		ApplicationID: perm.ApplicationID,
+		CreatedAt:     perm.CreatedAt,
+	}
+	if !perm.ExpiresAt.IsZero() {
+		expiresAt := perm.ExpiresAt
+		item.ExpiresAt = &expiresAt
+	}
+	return item
+}
+
+// setApproval fills who approved the grant and when, from the assignments of its application.
+func (item *GrantItem) setApproval(assignments []acl.ApplicationAssignment, usernameOf func(userID string) string) {
+	item.ApprovedBy = []string{}
+	seen := map[string]bool{}
+	for _, assignment := range assignments {
+		if assignment.ReviewStatus != acl.ReviewStatusApproved {
+			continue
+		}
+		if !seen[assignment.ReviewerID] {
+			seen[assignment.ReviewerID] = true
+			item.ApprovedBy = append(item.ApprovedBy, usernameOf(assignment.ReviewerID))
+		}
+		if item.ApprovedAt == nil || assignment.ReviewedAt.After(*item.ApprovedAt) {
+			reviewedAt := assignment.ReviewedAt
+			item.ApprovedAt = &reviewedAt
+		}
+	}
+}
+
+func newRevocationItem(revocation acl.GrantRevocation, entityName string, usernameOf func(userID string) string) RevocationItem {
+	return RevocationItem{
+		ID:            revocation.ID,
+		Action:        revocation.Action,
+		EntityID:      revocation.EntityID,
+		EntityName:    entityName,
+		UserID:        revocation.UserID,
+		Username:      usernameOf(revocation.UserID),
+		ApplicationID: revocation.ApplicationID,
+		GrantedAt:     revocation.GrantedAt,
+		RevokedBy:     usernameOf(revocation.RevokedBy),
+		Reason:        revocation.Reason,
+		RevokedAt:     revocation.RevokedAt,
+	}
+}
+
+// usernameCache resolves user ids to usernames, falling back to the id for deleted users.
+type usernameCache struct {
+	getUser func(id string) (acl.User, error)
+	names   map[string]string
+}
+
+func newUsernameCache(getUser func(id string) (acl.User, error)) *usernameCache {
+	return &usernameCache{getUser: getUser, names: map[string]string{}}
+}
+
+func (c *usernameCache) lookup(userID string) string {
+	if name, ok := c.names[userID]; ok {
+		return name
+	}
+	name := userID
+	user, err := c.getUser(userID)
+	if err != nil {
+		log.Printf("[GRANT] user %s not found: %s", userID, err)
+	} else if user.Username != "" {
+		name = user.Username
+	}
+	c.names[userID] = name
+	return name
+}
+
+// lessGrantItem orders grants expiring soonest first, permanent grants last.
+func lessGrantItem(a, b GrantItem) bool {
+	if (a.ExpiresAt == nil) != (b.ExpiresAt == nil) {
+		return a.ExpiresAt != nil
+	}
+	if a.ExpiresAt != nil && !a.ExpiresAt.Equal(*b.ExpiresAt) {
+		return a.ExpiresAt.Before(*b.ExpiresAt)
+	}
+	if a.EntityName != b.EntityName {
+		return a.EntityName < b.EntityName
+	}
+	return a.Action < b.Action
+}
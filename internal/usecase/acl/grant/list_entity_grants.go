@@ -0,0 +1,139 @@
+//go:generate mockgen -source=list_entity_grants.go -destination=mock/mock_list_entity_grants_repo.go -package=grant_mock
+
+package grant
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type ListEntityGrantsResponse struct {
+	Grants      []GrantItem      `json:"grants"`
+	Revocations []RevocationItem `json:"revocations"`
+}
+
+type iListEntityGrantsRepo interface {
+	GetEntityByID(id string) (entity.Entity, error)
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	ListPermissionMapsByEntity(entityID string) ([]acl.PermissionMap, error)
+	GetUserByID(id string) (acl.User, error)
+	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
+	ListGrantRevocationsByEntity(entityID string) ([]acl.GrantRevocation, error)
+}
+
+type listEntityGrantsRepo struct {
+	db *buntdb.DB
+}
+
+func (r *listEntityGrantsRepo) GetEntityByID(id string) (entity.Entity, error) {
+	return entityrepo.GetEntityByID(r.db, id)
+}
+
+func (r *listEntityGrantsRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *listEntityGrantsRepo) ListPermissionMapsByEntity(entityID string) ([]acl.PermissionMap, error) {
+	return entityrepo.ListPermissionMapsByEntity(r.db, entityID)
+}
+
+func (r *listEntityGrantsRepo) GetUserByID(id string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, id)
+}
+
+func (r *listEntityGrantsRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	return db.SelectAll[acl.ApplicationAssignment](r.db, "="+appID, acl.IdxAppAssign_ApplicationID)
+}
+
+func (r *listEntityGrantsRepo) ListGrantRevocationsByEntity(entityID string) ([]acl.GrantRevocation, error) {
+	return entityrepo.ListGrantRevocationsByEntity(r.db, entityID)
+}
+
+type ListEntityGrantsUsecase struct {
+	repo iListEntityGrantsRepo
+}
+
+func NewListEntityGrantsUsecase(db *buntdb.DB) ListEntityGrantsUsecase {
+	return ListEntityGrantsUsecase{
+		repo: &listEntityGrantsRepo{db: db},
+	}
+}
+
+// Handle lists the active grants of an entity with their approvers, and the past revocations, for its owners.
+// params should contain "entity_id"
+func (uc ListEntityGrantsUsecase) Handle(ctx context.Context, params map[string]string) (ListEntityGrantsResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return ListEntityGrantsResponse{}, errors.New("unauthorized: user info not found")
+	}
+	entityID := params["entity_id"]
+	if entityID == "" {
+		return ListEntityGrantsResponse{}, errors.New("missing required field: entity_id")
+	}
+
+	ent, err := uc.repo.GetEntityByID(entityID)
+	if err != nil {
+		return ListEntityGrantsResponse{}, err
+	}
+	// the groups in the token may be stale, a removed owner must not list the grants
+	groups, err := uc.repo.GetGroupsByUserID(user.ID)
+	if err != nil {
+		return ListEntityGrantsResponse{}, err
+	}
+	if !authlogic.IsEntityOwner(groups, &ent) {
+		return ListEntityGrantsResponse{}, errors.New("forbidden: only the owner group can view grants of this entity")
+	}
+
+	perms, err := uc.repo.ListPermissionMapsByEntity(entityID)
+	if err != nil {
+		return ListEntityGrantsResponse{}, err
+	}
+
+	now := time.Now()
+	usernames := newUsernameCache(uc.repo.GetUserByID)
+	assignments := map[string][]acl.ApplicationAssignment{}
+	grants := []GrantItem{}
+	for _, perm := range perms {
+		if perm.IsExpired(now) {
+			continue
+		}
+		item := newGrantItem(perm, ent)
+		item.Username = usernames.lookup(perm.UserID)
+		if perm.ApplicationID != "" {
+			appAssignments, ok := assignments[perm.ApplicationID]
+			if !ok {
+				appAssignments, err = uc.repo.ListAssignmentsByApplicationID(perm.ApplicationID)
+				if err != nil {
+					log.Printf("[GRANT] assignments of application %s not found: %s", perm.ApplicationID, err)
+				}
+				assignments[perm.ApplicationID] = appAssignments
+			}
+			item.setApproval(appAssignments, usernames.lookup)
+		}
+		grants = append(grants, item)
+	}
+	sort.Slice(grants, func(i, j int) bool { return lessGrantItem(grants[i], grants[j]) })
+
+	revocations, err := uc.repo.ListGrantRevocationsByEntity(entityID)
+	if err != nil {
+		return ListEntityGrantsResponse{}, err
+	}
+	revocationItems := make([]RevocationItem, 0, len(revocations))
+	for _, revocation := range revocations {
+		revocationItems = append(revocationItems, newRevocationItem(revocation, ent.Name, usernames.lookup))
+	}
+	sort.Slice(revocationItems, func(i, j int) bool { return revocationItems[i].RevokedAt.After(revocationItems[j].RevokedAt) })
+	return ListEntityGrantsResponse{Grants: grants, Revocations: revocationItems}, nil
+}
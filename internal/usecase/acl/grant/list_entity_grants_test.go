@@ -0,0 +1,185 @@
+package grant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	grant_mock "github.com/jekiapp/topic-master/internal/usecase/acl/grant/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListEntityGrantsUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orders := entity.Entity{ID: "topic-orders", Name: "orders", GroupOwnerID: "g-payments", GroupOwner: "payments-team"}
+	ownerGroups := []acl.GroupRole{{GroupID: "g-payments", GroupName: "payments-team", Role: acl.RoleGroupMember}}
+	approvedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		groups   []acl.GroupRole // stored memberships
+		loggedIn bool
+		// claims of the token when they differ from groups
+		tokenGroups []acl.GroupRole
+		params      map[string]string
+		setupMock   func(m *grant_mock.MockiListEntityGrantsRepo)
+		wantErr     bool
+		wantUsers   []string
+		// set for single grant cases
+		wantApprovedBy  []string
+		wantRevocations []string
+	}{
+		{
+			name:      "unauthorized user cannot list grants",
+			params:    map[string]string{"entity_id": "topic-orders"},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "missing entity id",
+			loggedIn:  true,
+			groups:    ownerGroups,
+			params:    map[string]string{},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "non owner is forbidden",
+			loggedIn: true,
+			groups:   []acl.GroupRole{{GroupName: "other-team"}},
+			params:   map[string]string{"entity_id": "topic-orders"},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "custom role of the owner group is forbidden",
+			loggedIn: true,
+			groups:   []acl.GroupRole{{GroupID: "g-payments", GroupName: "payments-team", Role: "viewer"}},
+			params:   map[string]string{"entity_id": "topic-orders"},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "removed owner still listed in the token is forbidden",
+			loggedIn: true,
+			groups:   []acl.GroupRole{},
+			// the token was issued before the removal
+			tokenGroups: ownerGroups,
+			params:      map[string]string{"entity_id": "topic-orders"},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "groups are loaded when missing from the session",
+			loggedIn:    true,
+			groups:      ownerGroups,
+			tokenGroups: []acl.GroupRole{},
+			params:      map[string]string{"entity_id": "topic-orders"},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().ListPermissionMapsByEntity("topic-orders").Return([]acl.PermissionMap{}, nil)
+				m.EXPECT().ListGrantRevocationsByEntity("topic-orders").Return([]acl.GrantRevocation{}, nil)
+			},
+		},
+		{
+			name:     "owner lists active grants with usernames",
+			loggedIn: true,
+			groups:   ownerGroups,
+			params:   map[string]string{"entity_id": "topic-orders"},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().ListPermissionMapsByEntity("topic-orders").Return([]acl.PermissionMap{
+					{ID: "p1", UserID: "alice-id", EntityID: "topic-orders", Action: "topic:publish"},
+					{ID: "p2", UserID: "bob-id", EntityID: "topic-orders", Action: "topic:empty", ExpiresAt: time.Now().Add(-time.Second)},
+				}, nil)
+				m.EXPECT().GetUserByID("alice-id").Return(acl.User{ID: "alice-id", Username: "alice"}, nil)
+				m.EXPECT().ListGrantRevocationsByEntity("topic-orders").Return([]acl.GrantRevocation{}, nil)
+			},
+			wantUsers: []string{"alice"},
+		},
+		{
+			name:     "grants show their approvers and revocations are listed",
+			loggedIn: true,
+			groups:   ownerGroups,
+			params:   map[string]string{"entity_id": "topic-orders"},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().ListPermissionMapsByEntity("topic-orders").Return([]acl.PermissionMap{
+					{ID: "p1", UserID: "alice-id", EntityID: "topic-orders", Action: "topic:publish", ApplicationID: "app-1"},
+				}, nil)
+				m.EXPECT().GetUserByID("alice-id").Return(acl.User{ID: "alice-id", Username: "alice"}, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{
+					{ReviewerID: "carol-id", ReviewStatus: acl.ReviewStatusApproved, ReviewedAt: approvedAt},
+					{ReviewerID: "dave-id", ReviewStatus: acl.ReviewStatusPassed},
+				}, nil)
+				m.EXPECT().GetUserByID("carol-id").Return(acl.User{ID: "carol-id", Username: "carol"}, nil)
+				m.EXPECT().ListGrantRevocationsByEntity("topic-orders").Return([]acl.GrantRevocation{
+					{ID: "r1", UserID: "bob-id", EntityID: "topic-orders", Action: "topic:empty", RevokedBy: "carol-id"},
+				}, nil)
+				m.EXPECT().GetUserByID("bob-id").Return(acl.User{ID: "bob-id", Username: "bob"}, nil)
+			},
+			wantUsers:       []string{"alice"},
+			wantApprovedBy:  []string{"carol"},
+			wantRevocations: []string{"bob revoked by carol"},
+		},
+		{
+			name:     "root can list grants of any entity",
+			loggedIn: true,
+			groups:   []acl.GroupRole{{GroupName: acl.GroupRoot}},
+			params:   map[string]string{"entity_id": "topic-orders"},
+			setupMock: func(m *grant_mock.MockiListEntityGrantsRepo) {
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().ListPermissionMapsByEntity("topic-orders").Return(nil, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.loggedIn {
+				tokenGroups := tt.groups
+				if tt.tokenGroups != nil {
+					tokenGroups = tt.tokenGroups
+				}
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "owner-id", Username: "owner", Groups: tokenGroups})
+			}
+			mockRepo := grant_mock.NewMockiListEntityGrantsRepo(ctrl)
+			tt.setupMock(mockRepo)
+			mockRepo.EXPECT().GetGroupsByUserID("owner-id").Return(tt.groups, nil).AnyTimes()
+			uc := ListEntityGrantsUsecase{repo: mockRepo}
+			resp, err := uc.Handle(ctx, tt.params)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var users []string
+			for _, g := range resp.Grants {
+				users = append(users, g.Username)
+			}
+			assert.Equal(t, tt.wantUsers, users)
+			if tt.wantApprovedBy != nil {
+				assert.Equal(t, tt.wantApprovedBy, resp.Grants[0].ApprovedBy)
+				assert.Equal(t, approvedAt, *resp.Grants[0].ApprovedAt)
+			}
+			var revocations []string
+			for _, r := range resp.Revocations {
+				revocations = append(revocations, r.Username+" revoked by "+r.RevokedBy)
+			}
+			assert.Equal(t, tt.wantRevocations, revocations)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+//go:generate mockgen -source=list_my_grants.go -destination=mock/mock_list_my_grants_repo.go -package=grant_mock
+
+package grant
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type ListMyGrantsResponse struct {
+	Grants []GrantItem `json:"grants"`
+}
+
+type iListMyGrantsRepo interface {
+	ListPermissionMaps() ([]acl.PermissionMap, error)
+	GetEntityByID(id string) (entity.Entity, error)
+}
+
+type listMyGrantsRepo struct {
+	db *buntdb.DB
+}
+
+func (r *listMyGrantsRepo) ListPermissionMaps() ([]acl.PermissionMap, error) {
+	return entityrepo.ListPermissionMaps(r.db)
+}
+
+func (r *listMyGrantsRepo) GetEntityByID(id string) (entity.Entity, error) {
+	return entityrepo.GetEntityByID(r.db, id)
+}
+
+type ListMyGrantsUsecase struct {
+	repo iListMyGrantsRepo
+}
+
+func NewListMyGrantsUsecase(db *buntdb.DB) ListMyGrantsUsecase {
+	return ListMyGrantsUsecase{
+		repo: &listMyGrantsRepo{db: db},
+	}
+}
+
+// Handle lists the active grants of the logged in user.
+func (uc ListMyGrantsUsecase) Handle(ctx context.Context, params map[string]string) (ListMyGrantsResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return ListMyGrantsResponse{}, errors.New("unauthorized: user info not found")
+	}
+
+	perms, err := uc.repo.ListPermissionMaps()
+	if err != nil {
+		return ListMyGrantsResponse{}, err
+	}
+
+	now := time.Now()
+	entities := map[string]entity.Entity{}
+	grants := []GrantItem{}
+	for _, perm := range perms {
+		if perm.UserID != user.ID || perm.IsExpired(now) {
+			continue
+		}
+		ent, ok := entities[perm.EntityID]
+		if !ok {
+			ent, err = uc.repo.GetEntityByID(perm.EntityID)
+			if err != nil {
+				log.Printf("[GRANT] entity %s of grant %s not found: %s", perm.EntityID, perm.ID, err)
+			}
+			entities[perm.EntityID] = ent
+		}
+		grants = append(grants, newGrantItem(perm, ent))
+	}
+	sort.Slice(grants, func(i, j int) bool { return lessGrantItem(grants[i], grants[j]) })
+	return ListMyGrantsResponse{Grants: grants}, nil
+}
@@ -0,0 +1,96 @@
+package grant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	grant_mock "github.com/jekiapp/topic-master/internal/usecase/acl/grant/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListMyGrantsUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	soon := now.Add(time.Hour)
+	later := now.Add(48 * time.Hour)
+	orders := entity.Entity{ID: "topic-orders", Name: "orders", TypeID: entity.EntityType_NSQTopic}
+	payments := entity.Entity{ID: "topic-payments", Name: "payments", TypeID: entity.EntityType_NSQTopic}
+
+	tests := []struct {
+		name       string
+		loggedIn   bool
+		setupMock  func(m *grant_mock.MockiListMyGrantsRepo)
+		wantErr    bool
+		wantGrants []string
+	}{
+		{
+			name:      "unauthorized user cannot list grants",
+			setupMock: func(m *grant_mock.MockiListMyGrantsRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "repo error",
+			loggedIn: true,
+			setupMock: func(m *grant_mock.MockiListMyGrantsRepo) {
+				m.EXPECT().ListPermissionMaps().Return(nil, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "only active grants of alice, soonest expiry first",
+			loggedIn: true,
+			setupMock: func(m *grant_mock.MockiListMyGrantsRepo) {
+				m.EXPECT().ListPermissionMaps().Return([]acl.PermissionMap{
+					{ID: "permanent", UserID: "alice-id", EntityID: "topic-orders", Action: "topic:publish"},
+					{ID: "later", UserID: "alice-id", EntityID: "topic-payments", Action: "topic:tail", ExpiresAt: later},
+					{ID: "soon", UserID: "alice-id", EntityID: "topic-orders", Action: "topic:empty", ExpiresAt: soon},
+					{ID: "expired", UserID: "alice-id", EntityID: "topic-orders", Action: "topic:pause", ExpiresAt: now.Add(-time.Minute)},
+					{ID: "bob", UserID: "bob-id", EntityID: "topic-orders", Action: "topic:publish"},
+				}, nil)
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().GetEntityByID("topic-payments").Return(payments, nil)
+			},
+			wantGrants: []string{"soon", "later", "permanent"},
+		},
+		{
+			name:     "grant on a deleted entity is still listed",
+			loggedIn: true,
+			setupMock: func(m *grant_mock.MockiListMyGrantsRepo) {
+				m.EXPECT().ListPermissionMaps().Return([]acl.PermissionMap{
+					{ID: "orphan", UserID: "alice-id", EntityID: "topic-gone", Action: "topic:publish"},
+				}, nil)
+				m.EXPECT().GetEntityByID("topic-gone").Return(entity.Entity{}, context.DeadlineExceeded)
+			},
+			wantGrants: []string{"orphan"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "alice-id", Username: "alice"})
+			}
+			mockRepo := grant_mock.NewMockiListMyGrantsRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := ListMyGrantsUsecase{repo: mockRepo}
+			resp, err := uc.Handle(ctx, map[string]string{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var ids []string
+			for _, g := range resp.Grants {
+				ids = append(ids, g.ID)
+			}
+			assert.Equal(t, tt.wantGrants, ids)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+//go:generate mockgen -source=list_user_grants.go -destination=mock/mock_list_user_grants_repo.go -package=grant_mock
+
+package grant
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// OwnedEntityItem is an entity a user can fully manage through one of their groups.
+type OwnedEntityItem struct {
+	EntityID   string `json:"entity_id"`
+	EntityName string `json:"entity_name"`
+	EntityType string `json:"entity_type"`
+	GroupName  string `json:"group_name"`
+	Role       string `json:"role"`
+}
+
+type ListUserGrantsResponse struct {
+	UserID      string            `json:"user_id"`
+	Username    string            `json:"username"`
+	Owned       []OwnedEntityItem `json:"owned"`
+	Grants      []GrantItem       `json:"grants"`
+	Revocations []RevocationItem  `json:"revocations"`
+}
+
+type iListUserGrantsRepo interface {
+	GetUserByID(id string) (acl.User, error)
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	GetEntityByID(id string) (entity.Entity, error)
+	ListEntitiesByGroupID(groupID string) ([]entity.Entity, error)
+	ListPermissionMapsByUser(userID string) ([]acl.PermissionMap, error)
+	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
+	ListGrantRevocationsByUser(userID string) ([]acl.GrantRevocation, error)
+}
+
+type listUserGrantsRepo struct {
+	db *buntdb.DB
+}
+
+func (r *listUserGrantsRepo) GetUserByID(id string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, id)
+}
+
+func (r *listUserGrantsRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *listUserGrantsRepo) GetEntityByID(id string) (entity.Entity, error) {
+	return entityrepo.GetEntityByID(r.db, id)
+}
+
+func (r *listUserGrantsRepo) ListEntitiesByGroupID(groupID string) ([]entity.Entity, error) {
+	return entityrepo.ListEntitiesByGroupID(r.db, groupID)
+}
+
+func (r *listUserGrantsRepo) ListPermissionMapsByUser(userID string) ([]acl.PermissionMap, error) {
+	return entityrepo.ListPermissionMapsByUser(r.db, userID)
+}
+
+func (r *listUserGrantsRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	return db.SelectAll[acl.ApplicationAssignment](r.db, "="+appID, acl.IdxAppAssign_ApplicationID)
+}
+
+func (r *listUserGrantsRepo) ListGrantRevocationsByUser(userID string) ([]acl.GrantRevocation, error) {
+	return entityrepo.ListGrantRevocationsByUser(r.db, userID)
+}
+
+type ListUserGrantsUsecase struct {
+	repo iListUserGrantsRepo
+}
+
+func NewListUserGrantsUsecase(db *buntdb.DB) ListUserGrantsUsecase {
+	return ListUserGrantsUsecase{
+		repo: &listUserGrantsRepo{db: db},
+	}
+}
+
+// Handle lists everything a user can do across entities: the entities owned by their groups,
+// their active grants and the grants revoked from them.
+// Root and the user themself see every grant, owners only see the grants on the entities they own.
+// params may contain "user_id", it defaults to the logged in user.
+func (uc ListUserGrantsUsecase) Handle(ctx context.Context, params map[string]string) (ListUserGrantsResponse, error) {
+	viewer := util.GetUserInfo(ctx)
+	if viewer == nil {
+		return ListUserGrantsResponse{}, errors.New("unauthorized: user info not found")
+	}
+	userID := params["user_id"]
+	if userID == "" {
+		userID = viewer.ID
+	}
+	target, err := uc.repo.GetUserByID(userID)
+	if err != nil {
+		return ListUserGrantsResponse{}, errors.New("user not found")
+	}
+
+	viewerGroups := viewer.Groups
+	if len(viewerGroups) == 0 {
+		viewerGroups, err = uc.repo.GetGroupsByUserID(viewer.ID)
+		if err != nil {
+			return ListUserGrantsResponse{}, err
+		}
+	}
+	seeAll := viewer.ID == target.ID
+	for _, g := range viewerGroups {
+		if g.GroupName == acl.GroupRoot {
+			seeAll = true
+		}
+	}
+
+	targetGroups, err := uc.repo.GetGroupsByUserID(target.ID)
+	if err != nil {
+		return ListUserGrantsResponse{}, err
+	}
+	owned := []OwnedEntityItem{}
+	for _, g := range targetGroups {
+		entities, err := uc.repo.ListEntitiesByGroupID(g.GroupID)
+		if err != nil {
+			return ListUserGrantsResponse{}, err
+		}
+		for _, ent := range entities {
+			owned = append(owned, OwnedEntityItem{
+				EntityID:   ent.ID,
+				EntityName: ent.Name,
+				EntityType: ent.TypeID,
+				GroupName:  g.GroupName,
+				Role:       g.Role,
+			})
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool { return owned[i].EntityName < owned[j].EntityName })
+
+	entities := map[string]entity.Entity{}
+	// visible caches the entity lookup and tells whether the viewer may see grants on it
+	visible := func(entityID string) (entity.Entity, bool) {
+		ent, ok := entities[entityID]
+		if !ok {
+			ent, err = uc.repo.GetEntityByID(entityID)
+			if err != nil {
+				log.Printf("[GRANT] entity %s not found: %s", entityID, err)
+			}
+			entities[entityID] = ent
+		}
+		return ent, seeAll || authlogic.IsEntityOwner(viewerGroups, &ent)
+	}
+
+	perms, err := uc.repo.ListPermissionMapsByUser(target.ID)
+	if err != nil {
+		return ListUserGrantsResponse{}, err
+	}
+	now := time.Now()
+	usernames := newUsernameCache(uc.repo.GetUserByID)
+	grants := []GrantItem{}
+	for _, perm := range perms {
+		if perm.IsExpired(now) {
+			continue
+		}
+		ent, ok := visible(perm.EntityID)
+		if !ok {
+			continue
+		}
+		item := newGrantItem(perm, ent)
+		item.Username = target.Username
+		if perm.ApplicationID != "" {
+			assignments, err := uc.repo.ListAssignmentsByApplicationID(perm.ApplicationID)
+			if err != nil {
+				log.Printf("[GRANT] assignments of application %s not found: %s", perm.ApplicationID, err)
+			}
+			item.setApproval(assignments, usernames.lookup)
+		}
+		grants = append(grants, item)
+	}
+	sort.Slice(grants, func(i, j int) bool { return lessGrantItem(grants[i], grants[j]) })
+
+	revocations, err := uc.repo.ListGrantRevocationsByUser(target.ID)
+	if err != nil {
+		return ListUserGrantsResponse{}, err
+	}
+	revocationItems := []RevocationItem{}
+	for _, revocation := range revocations {
+		ent, ok := visible(revocation.EntityID)
+		if !ok {
+			continue
+		}
+		revocationItems = append(revocationItems, newRevocationItem(revocation, ent.Name, usernames.lookup))
+	}
+	sort.Slice(revocationItems, func(i, j int) bool { return revocationItems[i].RevokedAt.After(revocationItems[j].RevokedAt) })
+
+	return ListUserGrantsResponse{
+		UserID:      target.ID,
+		Username:    target.Username,
+		Owned:       owned,
+		Grants:      grants,
+		Revocations: revocationItems,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package grant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	grant_mock "github.com/jekiapp/topic-master/internal/usecase/acl/grant/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListUserGrantsUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alice := acl.User{ID: "alice-id", Username: "alice"}
+	orders := entity.Entity{ID: "topic-orders", Name: "orders", GroupOwnerID: "g-payments", GroupOwner: "payments-team"}
+	invoices := entity.Entity{ID: "topic-invoices", Name: "invoices", GroupOwnerID: "g-billing", GroupOwner: "billing"}
+	aliceGroups := []acl.GroupRole{{GroupID: "g-search", GroupName: "search", Role: acl.RoleGroupMember}}
+	aliceGrants := []acl.PermissionMap{
+		{ID: "p1", UserID: "alice-id", EntityID: "topic-orders", Action: "topic:publish"},
+		{ID: "p2", UserID: "alice-id", EntityID: "topic-invoices", Action: "topic:tail"},
+		{ID: "p3", UserID: "alice-id", EntityID: "topic-orders", Action: "topic:empty", ExpiresAt: time.Now().Add(-time.Minute)},
+	}
+	// expectTarget sets up the lookups done for alice regardless of the viewer
+	expectTarget := func(m *grant_mock.MockiListUserGrantsRepo) {
+		m.EXPECT().GetUserByID("alice-id").Return(alice, nil)
+		m.EXPECT().GetGroupsByUserID("alice-id").Return(aliceGroups, nil)
+		m.EXPECT().ListEntitiesByGroupID("g-search").Return([]entity.Entity{{ID: "topic-queries", Name: "queries"}}, nil)
+		m.EXPECT().ListPermissionMapsByUser("alice-id").Return(aliceGrants, nil)
+		m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+		m.EXPECT().GetEntityByID("topic-invoices").Return(invoices, nil)
+		m.EXPECT().ListGrantRevocationsByUser("alice-id").Return([]acl.GrantRevocation{}, nil)
+	}
+
+	tests := []struct {
+		name       string
+		viewer     *acl.User
+		params     map[string]string
+		setupMock  func(m *grant_mock.MockiListUserGrantsRepo)
+		wantErr    bool
+		wantGrants []string
+		wantOwned  []string
+	}{
+		{
+			name:      "unauthorized user",
+			params:    map[string]string{"user_id": "alice-id"},
+			setupMock: func(m *grant_mock.MockiListUserGrantsRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:   "unknown user",
+			viewer: &acl.User{ID: "root-id", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}},
+			params: map[string]string{"user_id": "ghost"},
+			setupMock: func(m *grant_mock.MockiListUserGrantsRepo) {
+				m.EXPECT().GetUserByID("ghost").Return(acl.User{}, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name:       "user sees all of their own grants by default",
+			viewer:     &acl.User{ID: "alice-id", Username: "alice", Groups: aliceGroups},
+			params:     map[string]string{},
+			setupMock:  expectTarget,
+			wantGrants: []string{"invoices:topic:tail", "orders:topic:publish"},
+			wantOwned:  []string{"queries"},
+		},
+		{
+			name:       "root sees every grant",
+			viewer:     &acl.User{ID: "root-id", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}},
+			params:     map[string]string{"user_id": "alice-id"},
+			setupMock:  expectTarget,
+			wantGrants: []string{"invoices:topic:tail", "orders:topic:publish"},
+			wantOwned:  []string{"queries"},
+		},
+		{
+			name:       "owner only sees grants on their entities",
+			viewer:     &acl.User{ID: "bob-id", Groups: []acl.GroupRole{{GroupID: "g-payments", GroupName: "payments-team", Role: acl.RoleGroupMember}}},
+			params:     map[string]string{"user_id": "alice-id"},
+			setupMock:  expectTarget,
+			wantGrants: []string{"orders:topic:publish"},
+			wantOwned:  []string{"queries"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.viewer != nil {
+				ctx = util.MockContextWithUser(ctx, tt.viewer)
+			}
+			mockRepo := grant_mock.NewMockiListUserGrantsRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := ListUserGrantsUsecase{repo: mockRepo}
+			resp, err := uc.Handle(ctx, tt.params)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var grants []string
+			for _, g := range resp.Grants {
+				grants = append(grants, g.EntityName+":"+g.Action)
+			}
+			assert.Equal(t, tt.wantGrants, grants)
+			var owned []string
+			for _, o := range resp.Owned {
+				owned = append(owned, o.EntityName)
+			}
+			assert.Equal(t, tt.wantOwned, owned)
+		})
+	}
+}
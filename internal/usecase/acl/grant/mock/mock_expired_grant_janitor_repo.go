@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/acl/grant/expired_grant_janitor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/acl/grant/expired_grant_janitor.go -destination=internal/usecase/acl/grant/mock/mock_expired_grant_janitor_repo.go -package=grant_mock
+//
+
+// Package grant_mock is a generated GoMock package.
+package grant_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiExpiredGrantJanitorRepo is a mock of iExpiredGrantJanitorRepo interface.
+type MockiExpiredGrantJanitorRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiExpiredGrantJanitorRepoMockRecorder
+}
+
+// MockiExpiredGrantJanitorRepoMockRecorder is the mock recorder for MockiExpiredGrantJanitorRepo.
+type MockiExpiredGrantJanitorRepoMockRecorder struct {
+	mock *MockiExpiredGrantJanitorRepo
+}
+
+// NewMockiExpiredGrantJanitorRepo creates a new mock instance.
+func NewMockiExpiredGrantJanitorRepo(ctrl *gomock.Controller) *MockiExpiredGrantJanitorRepo {
+	mock := &MockiExpiredGrantJanitorRepo{ctrl: ctrl}
+	mock.recorder = &MockiExpiredGrantJanitorRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiExpiredGrantJanitorRepo) EXPECT() *MockiExpiredGrantJanitorRepoMockRecorder {
+	return m.recorder
+}
+
+// DeletePermissionMapByID mocks base method.
+func (m *MockiExpiredGrantJanitorRepo) DeletePermissionMapByID(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermissionMapByID", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePermissionMapByID indicates an expected call of DeletePermissionMapByID.
+func (mr *MockiExpiredGrantJanitorRepoMockRecorder) DeletePermissionMapByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermissionMapByID", reflect.TypeOf((*MockiExpiredGrantJanitorRepo)(nil).DeletePermissionMapByID), id)
+}
+
+// ListPermissionMaps mocks base method.
+func (m *MockiExpiredGrantJanitorRepo) ListPermissionMaps() ([]acl.PermissionMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissionMaps")
+	ret0, _ := ret[0].([]acl.PermissionMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissionMaps indicates an expected call of ListPermissionMaps.
+func (mr *MockiExpiredGrantJanitorRepoMockRecorder) ListPermissionMaps() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissionMaps", reflect.TypeOf((*MockiExpiredGrantJanitorRepo)(nil).ListPermissionMaps))
+}
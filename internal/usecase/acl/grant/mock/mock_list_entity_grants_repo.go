@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/acl/grant/list_entity_grants.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/acl/grant/list_entity_grants.go -destination=internal/usecase/acl/grant/mock/mock_list_entity_grants_repo.go -package=grant_mock
+//
+
+// Package grant_mock is a generated GoMock package.
+package grant_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	entity "github.com/jekiapp/topic-master/internal/model/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiListEntityGrantsRepo is a mock of iListEntityGrantsRepo interface.
+type MockiListEntityGrantsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiListEntityGrantsRepoMockRecorder
+}
+
+// MockiListEntityGrantsRepoMockRecorder is the mock recorder for MockiListEntityGrantsRepo.
+type MockiListEntityGrantsRepoMockRecorder struct {
+	mock *MockiListEntityGrantsRepo
+}
+
+// NewMockiListEntityGrantsRepo creates a new mock instance.
+func NewMockiListEntityGrantsRepo(ctrl *gomock.Controller) *MockiListEntityGrantsRepo {
+	mock := &MockiListEntityGrantsRepo{ctrl: ctrl}
+	mock.recorder = &MockiListEntityGrantsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiListEntityGrantsRepo) EXPECT() *MockiListEntityGrantsRepoMockRecorder {
+	return m.recorder
+}
+
+// GetEntityByID mocks base method.
+func (m *MockiListEntityGrantsRepo) GetEntityByID(id string) (entity.Entity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityByID", id)
+	ret0, _ := ret[0].(entity.Entity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityByID indicates an expected call of GetEntityByID.
+func (mr *MockiListEntityGrantsRepoMockRecorder) GetEntityByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityByID", reflect.TypeOf((*MockiListEntityGrantsRepo)(nil).GetEntityByID), id)
+}
+
+// GetGroupsByUserID mocks base method.
+func (m *MockiListEntityGrantsRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsByUserID", userID)
+	ret0, _ := ret[0].([]acl.GroupRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsByUserID indicates an expected call of GetGroupsByUserID.
+func (mr *MockiListEntityGrantsRepoMockRecorder) GetGroupsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByUserID", reflect.TypeOf((*MockiListEntityGrantsRepo)(nil).GetGroupsByUserID), userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockiListEntityGrantsRepo) GetUserByID(id string) (acl.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", id)
+	ret0, _ := ret[0].(acl.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockiListEntityGrantsRepoMockRecorder) GetUserByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockiListEntityGrantsRepo)(nil).GetUserByID), id)
+}
+
+// ListAssignmentsByApplicationID mocks base method.
+func (m *MockiListEntityGrantsRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignmentsByApplicationID", appID)
+	ret0, _ := ret[0].([]acl.ApplicationAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignmentsByApplicationID indicates an expected call of ListAssignmentsByApplicationID.
+func (mr *MockiListEntityGrantsRepoMockRecorder) ListAssignmentsByApplicationID(appID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignmentsByApplicationID", reflect.TypeOf((*MockiListEntityGrantsRepo)(nil).ListAssignmentsByApplicationID), appID)
+}
+
+// ListGrantRevocationsByEntity mocks base method.
+func (m *MockiListEntityGrantsRepo) ListGrantRevocationsByEntity(entityID string) ([]acl.GrantRevocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGrantRevocationsByEntity", entityID)
+	ret0, _ := ret[0].([]acl.GrantRevocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGrantRevocationsByEntity indicates an expected call of ListGrantRevocationsByEntity.
+func (mr *MockiListEntityGrantsRepoMockRecorder) ListGrantRevocationsByEntity(entityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGrantRevocationsByEntity", reflect.TypeOf((*MockiListEntityGrantsRepo)(nil).ListGrantRevocationsByEntity), entityID)
+}
+
+// ListPermissionMapsByEntity mocks base method.
+func (m *MockiListEntityGrantsRepo) ListPermissionMapsByEntity(entityID string) ([]acl.PermissionMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissionMapsByEntity", entityID)
+	ret0, _ := ret[0].([]acl.PermissionMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissionMapsByEntity indicates an expected call of ListPermissionMapsByEntity.
+func (mr *MockiListEntityGrantsRepoMockRecorder) ListPermissionMapsByEntity(entityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissionMapsByEntity", reflect.TypeOf((*MockiListEntityGrantsRepo)(nil).ListPermissionMapsByEntity), entityID)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/acl/grant/list_my_grants.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/acl/grant/list_my_grants.go -destination=internal/usecase/acl/grant/mock/mock_list_my_grants_repo.go -package=grant_mock
+//
+
+// Package grant_mock is a generated GoMock package.
+package grant_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	entity "github.com/jekiapp/topic-master/internal/model/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiListMyGrantsRepo is a mock of iListMyGrantsRepo interface.
+type MockiListMyGrantsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiListMyGrantsRepoMockRecorder
+}
+
+// MockiListMyGrantsRepoMockRecorder is the mock recorder for MockiListMyGrantsRepo.
+type MockiListMyGrantsRepoMockRecorder struct {
+	mock *MockiListMyGrantsRepo
+}
+
+// NewMockiListMyGrantsRepo creates a new mock instance.
+func NewMockiListMyGrantsRepo(ctrl *gomock.Controller) *MockiListMyGrantsRepo {
+	mock := &MockiListMyGrantsRepo{ctrl: ctrl}
+	mock.recorder = &MockiListMyGrantsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiListMyGrantsRepo) EXPECT() *MockiListMyGrantsRepoMockRecorder {
+	return m.recorder
+}
+
+// GetEntityByID mocks base method.
+func (m *MockiListMyGrantsRepo) GetEntityByID(id string) (entity.Entity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityByID", id)
+	ret0, _ := ret[0].(entity.Entity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityByID indicates an expected call of GetEntityByID.
+func (mr *MockiListMyGrantsRepoMockRecorder) GetEntityByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityByID", reflect.TypeOf((*MockiListMyGrantsRepo)(nil).GetEntityByID), id)
+}
+
+// ListPermissionMaps mocks base method.
+func (m *MockiListMyGrantsRepo) ListPermissionMaps() ([]acl.PermissionMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissionMaps")
+	ret0, _ := ret[0].([]acl.PermissionMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissionMaps indicates an expected call of ListPermissionMaps.
+func (mr *MockiListMyGrantsRepoMockRecorder) ListPermissionMaps() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissionMaps", reflect.TypeOf((*MockiListMyGrantsRepo)(nil).ListPermissionMaps))
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/acl/grant/list_user_grants.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/acl/grant/list_user_grants.go -destination=internal/usecase/acl/grant/mock/mock_list_user_grants_repo.go -package=grant_mock
+//
+
+// Package grant_mock is a generated GoMock package.
+package grant_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	entity "github.com/jekiapp/topic-master/internal/model/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiListUserGrantsRepo is a mock of iListUserGrantsRepo interface.
+type MockiListUserGrantsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiListUserGrantsRepoMockRecorder
+}
+
+// MockiListUserGrantsRepoMockRecorder is the mock recorder for MockiListUserGrantsRepo.
+type MockiListUserGrantsRepoMockRecorder struct {
+	mock *MockiListUserGrantsRepo
+}
+
+// NewMockiListUserGrantsRepo creates a new mock instance.
+func NewMockiListUserGrantsRepo(ctrl *gomock.Controller) *MockiListUserGrantsRepo {
+	mock := &MockiListUserGrantsRepo{ctrl: ctrl}
+	mock.recorder = &MockiListUserGrantsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiListUserGrantsRepo) EXPECT() *MockiListUserGrantsRepoMockRecorder {
+	return m.recorder
+}
+
+// GetEntityByID mocks base method.
+func (m *MockiListUserGrantsRepo) GetEntityByID(id string) (entity.Entity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityByID", id)
+	ret0, _ := ret[0].(entity.Entity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityByID indicates an expected call of GetEntityByID.
+func (mr *MockiListUserGrantsRepoMockRecorder) GetEntityByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityByID", reflect.TypeOf((*MockiListUserGrantsRepo)(nil).GetEntityByID), id)
+}
+
+// GetGroupsByUserID mocks base method.
+func (m *MockiListUserGrantsRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsByUserID", userID)
+	ret0, _ := ret[0].([]acl.GroupRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsByUserID indicates an expected call of GetGroupsByUserID.
+func (mr *MockiListUserGrantsRepoMockRecorder) GetGroupsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByUserID", reflect.TypeOf((*MockiListUserGrantsRepo)(nil).GetGroupsByUserID), userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockiListUserGrantsRepo) GetUserByID(id string) (acl.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", id)
+	ret0, _ := ret[0].(acl.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockiListUserGrantsRepoMockRecorder) GetUserByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockiListUserGrantsRepo)(nil).GetUserByID), id)
+}
+
+// ListAssignmentsByApplicationID mocks base method.
+func (m *MockiListUserGrantsRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignmentsByApplicationID", appID)
+	ret0, _ := ret[0].([]acl.ApplicationAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignmentsByApplicationID indicates an expected call of ListAssignmentsByApplicationID.
+func (mr *MockiListUserGrantsRepoMockRecorder) ListAssignmentsByApplicationID(appID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignmentsByApplicationID", reflect.TypeOf((*MockiListUserGrantsRepo)(nil).ListAssignmentsByApplicationID), appID)
+}
+
+// ListEntitiesByGroupID mocks base method.
+func (m *MockiListUserGrantsRepo) ListEntitiesByGroupID(groupID string) ([]entity.Entity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntitiesByGroupID", groupID)
+	ret0, _ := ret[0].([]entity.Entity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntitiesByGroupID indicates an expected call of ListEntitiesByGroupID.
+func (mr *MockiListUserGrantsRepoMockRecorder) ListEntitiesByGroupID(groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntitiesByGroupID", reflect.TypeOf((*MockiListUserGrantsRepo)(nil).ListEntitiesByGroupID), groupID)
+}
+
+// ListGrantRevocationsByUser mocks base method.
+func (m *MockiListUserGrantsRepo) ListGrantRevocationsByUser(userID string) ([]acl.GrantRevocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGrantRevocationsByUser", userID)
+	ret0, _ := ret[0].([]acl.GrantRevocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGrantRevocationsByUser indicates an expected call of ListGrantRevocationsByUser.
+func (mr *MockiListUserGrantsRepoMockRecorder) ListGrantRevocationsByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGrantRevocationsByUser", reflect.TypeOf((*MockiListUserGrantsRepo)(nil).ListGrantRevocationsByUser), userID)
+}
+
+// ListPermissionMapsByUser mocks base method.
+func (m *MockiListUserGrantsRepo) ListPermissionMapsByUser(userID string) ([]acl.PermissionMap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissionMapsByUser", userID)
+	ret0, _ := ret[0].([]acl.PermissionMap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissionMapsByUser indicates an expected call of ListPermissionMapsByUser.
+func (mr *MockiListUserGrantsRepoMockRecorder) ListPermissionMapsByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissionMapsByUser", reflect.TypeOf((*MockiListUserGrantsRepo)(nil).ListPermissionMapsByUser), userID)
+}
@@ -0,0 +1,151 @@
+//go:generate mockgen -source=revoke_grant.go -destination=mock/mock_revoke_grant_repo.go -package=grant_mock
+
+package grant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type RevokeGrantRequest struct {
+	ID     string   `json:"id"`
+	IDs    []string `json:"ids"` // bulk revoke, combined with ID
+	Reason string   `json:"reason"`
+}
+
+// grantIDs returns the distinct ids to revoke.
+func (r RevokeGrantRequest) grantIDs() []string {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, id := range append([]string{r.ID}, r.IDs...) {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+type RevokeGrantResponse struct {
+	Success bool     `json:"success"`
+	Revoked []string `json:"revoked"`
+}
+
+type iRevokeGrantRepo interface {
+	GetPermissionMapByID(id string) (acl.PermissionMap, error)
+	GetEntityByID(id string) (entity.Entity, error)
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	DeletePermissionMapByID(id string) error
+	CreateGrantRevocation(revocation acl.GrantRevocation) error
+}
+
+type revokeGrantRepo struct {
+	db *buntdb.DB
+}
+
+func (r *revokeGrantRepo) GetPermissionMapByID(id string) (acl.PermissionMap, error) {
+	return entityrepo.GetPermissionMapByID(r.db, id)
+}
+
+func (r *revokeGrantRepo) GetEntityByID(id string) (entity.Entity, error) {
+	return entityrepo.GetEntityByID(r.db, id)
+}
+
+func (r *revokeGrantRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *revokeGrantRepo) DeletePermissionMapByID(id string) error {
+	return entityrepo.DeletePermissionMapByID(r.db, id)
+}
+
+func (r *revokeGrantRepo) CreateGrantRevocation(revocation acl.GrantRevocation) error {
+	return entityrepo.CreateGrantRevocation(r.db, revocation)
+}
+
+type RevokeGrantUsecase struct {
+	repo iRevokeGrantRepo
+}
+
+func NewRevokeGrantUsecase(db *buntdb.DB) RevokeGrantUsecase {
+	return RevokeGrantUsecase{
+		repo: &revokeGrantRepo{db: db},
+	}
+}
+
+// Handle removes grants before they expire. Only the owner group of each entity (or root) can revoke.
+// Every grant is checked before any is removed, so a bulk revoke is rejected as a whole.
+// Each removal is recorded as a GrantRevocation.
+func (uc RevokeGrantUsecase) Handle(ctx context.Context, req RevokeGrantRequest) (RevokeGrantResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return RevokeGrantResponse{}, errors.New("unauthorized: user info not found")
+	}
+	ids := req.grantIDs()
+	if len(ids) == 0 {
+		return RevokeGrantResponse{}, errors.New("missing required field: id")
+	}
+
+	// the groups in the token may be stale, a removed owner must not revoke
+	groups, err := uc.repo.GetGroupsByUserID(user.ID)
+	if err != nil {
+		return RevokeGrantResponse{}, err
+	}
+	perms := make([]acl.PermissionMap, 0, len(ids))
+	owned := map[string]bool{}
+	for _, id := range ids {
+		perm, err := uc.repo.GetPermissionMapByID(id)
+		if err != nil {
+			return RevokeGrantResponse{}, fmt.Errorf("grant %s not found", id)
+		}
+		if _, ok := owned[perm.EntityID]; !ok {
+			ent, err := uc.repo.GetEntityByID(perm.EntityID)
+			if err != nil {
+				return RevokeGrantResponse{}, err
+			}
+			owned[perm.EntityID] = authlogic.IsEntityOwner(groups, &ent)
+		}
+		if !owned[perm.EntityID] {
+			return RevokeGrantResponse{}, errors.New("forbidden: only the owner group can revoke grants of this entity")
+		}
+		perms = append(perms, perm)
+	}
+
+	revoked := []string{}
+	for _, perm := range perms {
+		if err := uc.repo.DeletePermissionMapByID(perm.ID); err != nil {
+			return RevokeGrantResponse{Revoked: revoked}, err
+		}
+		revoked = append(revoked, perm.ID)
+		revocation := acl.GrantRevocation{
+			ID:              uuid.NewString(),
+			PermissionMapID: perm.ID,
+			Action:          perm.Action,
+			UserID:          perm.UserID,
+			EntityID:        perm.EntityID,
+			ApplicationID:   perm.ApplicationID,
+			GrantedAt:       perm.CreatedAt,
+			RevokedBy:       user.ID,
+			Reason:          req.Reason,
+			RevokedAt:       time.Now(),
+		}
+		if err := uc.repo.CreateGrantRevocation(revocation); err != nil {
+			// the grant is gone already, losing the audit record should not fail the request
+			log.Printf("[GRANT] failed to record revocation of grant %s: %s", perm.ID, err)
+		}
+	}
+	return RevokeGrantResponse{Success: true, Revoked: revoked}, nil
+}
@@ -0,0 +1,163 @@
+package grant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	grant_mock "github.com/jekiapp/topic-master/internal/usecase/acl/grant/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRevokeGrantUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	grant := acl.PermissionMap{ID: "grant-1", UserID: "alice-id", EntityID: "topic-orders", Action: "topic:empty"}
+	orders := entity.Entity{ID: "topic-orders", Name: "orders", GroupOwnerID: "g-payments", GroupOwner: "payments-team"}
+	ownerGroups := []acl.GroupRole{{GroupID: "g-payments", GroupName: "payments-team", Role: acl.RoleGroupMember}}
+
+	tests := []struct {
+		name        string
+		loggedIn    bool
+		groups      []acl.GroupRole // stored memberships
+		tokenGroups []acl.GroupRole // claims of the token when they differ from groups
+		req         RevokeGrantRequest
+		setupMock   func(m *grant_mock.MockiRevokeGrantRepo)
+		wantErr     bool
+		wantRevoked []string
+	}{
+		{
+			name:      "unauthorized user cannot revoke",
+			req:       RevokeGrantRequest{ID: "grant-1"},
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "missing grant id",
+			loggedIn:  true,
+			groups:    ownerGroups,
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "grant not found",
+			loggedIn: true,
+			groups:   ownerGroups,
+			req:      RevokeGrantRequest{ID: "grant-x"},
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {
+				m.EXPECT().GetPermissionMapByID("grant-x").Return(acl.PermissionMap{}, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "grantee outside the owner group cannot revoke",
+			loggedIn: true,
+			groups:   []acl.GroupRole{{GroupName: "other-team"}},
+			req:      RevokeGrantRequest{ID: "grant-1"},
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {
+				m.EXPECT().GetPermissionMapByID("grant-1").Return(grant, nil)
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "removed owner still listed in the token cannot revoke",
+			loggedIn:    true,
+			groups:      []acl.GroupRole{},
+			tokenGroups: ownerGroups,
+			req:         RevokeGrantRequest{ID: "grant-1"},
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {
+				m.EXPECT().GetPermissionMapByID("grant-1").Return(grant, nil)
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "owner revokes the grant",
+			loggedIn: true,
+			groups:   ownerGroups,
+			req:      RevokeGrantRequest{ID: "grant-1", Reason: "no longer needed"},
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {
+				m.EXPECT().GetPermissionMapByID("grant-1").Return(grant, nil)
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().DeletePermissionMapByID("grant-1").Return(nil)
+				m.EXPECT().CreateGrantRevocation(gomock.Any()).DoAndReturn(func(r acl.GrantRevocation) error {
+					assert.Equal(t, "grant-1", r.PermissionMapID)
+					assert.Equal(t, "alice-id", r.UserID)
+					assert.Equal(t, "owner-id", r.RevokedBy)
+					assert.Equal(t, "no longer needed", r.Reason)
+					return nil
+				})
+			},
+			wantRevoked: []string{"grant-1"},
+		},
+		{
+			name:     "bulk revoke removes every grant",
+			loggedIn: true,
+			groups:   ownerGroups,
+			req:      RevokeGrantRequest{IDs: []string{"grant-1", "grant-2", "grant-1"}},
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {
+				m.EXPECT().GetPermissionMapByID("grant-1").Return(grant, nil)
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().GetPermissionMapByID("grant-2").Return(acl.PermissionMap{ID: "grant-2", UserID: "bob-id", EntityID: "topic-orders"}, nil)
+				m.EXPECT().DeletePermissionMapByID("grant-1").Return(nil)
+				m.EXPECT().DeletePermissionMapByID("grant-2").Return(nil)
+				m.EXPECT().CreateGrantRevocation(gomock.Any()).Return(nil).Times(2)
+			},
+			wantRevoked: []string{"grant-1", "grant-2"},
+		},
+		{
+			name:     "bulk revoke is rejected when one grant is not owned",
+			loggedIn: true,
+			groups:   ownerGroups,
+			req:      RevokeGrantRequest{IDs: []string{"grant-1", "grant-3"}},
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {
+				m.EXPECT().GetPermissionMapByID("grant-1").Return(grant, nil)
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().GetPermissionMapByID("grant-3").Return(acl.PermissionMap{ID: "grant-3", UserID: "bob-id", EntityID: "topic-billing"}, nil)
+				m.EXPECT().GetEntityByID("topic-billing").Return(entity.Entity{ID: "topic-billing", GroupOwnerID: "g-billing", GroupOwner: "billing"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "delete error",
+			loggedIn: true,
+			groups:   []acl.GroupRole{{GroupName: acl.GroupRoot}},
+			req:      RevokeGrantRequest{ID: "grant-1"},
+			setupMock: func(m *grant_mock.MockiRevokeGrantRepo) {
+				m.EXPECT().GetPermissionMapByID("grant-1").Return(grant, nil)
+				m.EXPECT().GetEntityByID("topic-orders").Return(orders, nil)
+				m.EXPECT().DeletePermissionMapByID("grant-1").Return(context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.loggedIn {
+				tokenGroups := tt.groups
+				if tt.tokenGroups != nil {
+					tokenGroups = tt.tokenGroups
+				}
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "owner-id", Username: "owner", Groups: tokenGroups})
+			}
+			mockRepo := grant_mock.NewMockiRevokeGrantRepo(ctrl)
+			tt.setupMock(mockRepo)
+			mockRepo.EXPECT().GetGroupsByUserID("owner-id").Return(tt.groups, nil).AnyTimes()
+			uc := RevokeGrantUsecase{repo: mockRepo}
+			resp, err := uc.Handle(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, resp.Success)
+			assert.Equal(t, tt.wantRevoked, resp.Revoked)
+		})
+	}
+}
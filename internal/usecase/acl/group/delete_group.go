@@ -3,15 +3,21 @@ package group
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
-	grouprepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/tidwall/buntdb"
 )
 
 type DeleteGroupRequest struct {
 	ID string `json:"id"`
+	// ReassignTo is the id of the group receiving the entities of the deleted group, or None to release them.
+	// A group that owns entities cannot be deleted without it.
+	ReassignTo string `json:"reassign_to"`
 }
 
 type DeleteGroupResponse struct {
@@ -21,27 +27,58 @@ type DeleteGroupResponse struct {
 type iDeleteGroupRepo interface {
 	DeleteGroupByID(id string) error
 	GetGroupByID(id string) (acl.Group, error)
+	ListEntitiesByGroupID(groupID string) ([]entity.Entity, error)
+	UpdateEntity(ent entity.Entity) error
 }
 
-type deleteGroupRepo struct {
+// iDeleteGroupStore runs the deletion of a group in one write transaction,
+// so the group is never deleted while its entities still point to it
+type iDeleteGroupStore interface {
+	WithTx(fn func(repo iDeleteGroupRepo) error) error
+}
+
+type deleteGroupStore struct {
 	db *buntdb.DB
 }
 
+func (s *deleteGroupStore) WithTx(fn func(repo iDeleteGroupRepo) error) error {
+	return db.WithTx(s.db, func(tx *buntdb.Tx) error {
+		return fn(&deleteGroupRepo{tx: tx})
+	})
+}
+
+// deleteGroupRepo works inside the transaction of deleteGroupStore.WithTx
+type deleteGroupRepo struct {
+	tx *buntdb.Tx
+}
+
 func (r *deleteGroupRepo) DeleteGroupByID(id string) error {
-	return grouprepo.DeleteGroupByID(r.db, id)
+	return db.DeleteByIDTx[acl.Group](r.tx, id)
 }
 
 func (r *deleteGroupRepo) GetGroupByID(id string) (acl.Group, error) {
-	return grouprepo.GetGroupByID(r.db, id)
+	return db.GetByIDTx[acl.Group](r.tx, id)
+}
+
+func (r *deleteGroupRepo) ListEntitiesByGroupID(groupID string) ([]entity.Entity, error) {
+	entities, err := db.SelectAllTx[entity.Entity](r.tx, "="+groupID, entity.IdxEntity_Group)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *deleteGroupRepo) UpdateEntity(ent entity.Entity) error {
+	return db.UpdateTx(r.tx, &ent)
 }
 
 type DeleteGroupUsecase struct {
-	repo iDeleteGroupRepo
+	store iDeleteGroupStore
 }
 
 func NewDeleteGroupUsecase(db *buntdb.DB) DeleteGroupUsecase {
 	return DeleteGroupUsecase{
-		repo: &deleteGroupRepo{db: db},
+		store: &deleteGroupStore{db: db},
 	}
 }
 
@@ -64,19 +101,57 @@ func (uc DeleteGroupUsecase) Handle(ctx context.Context, req DeleteGroupRequest)
 		return DeleteGroupResponse{Success: false}, errors.New("missing required field: id")
 	}
 
-	// get group by id
-	// if group is root, return error
-	group, err := uc.repo.GetGroupByID(req.ID)
-	if err != nil {
-		return DeleteGroupResponse{Success: false}, err
-	}
-	if group.Name == acl.GroupRoot {
-		return DeleteGroupResponse{Success: false}, errors.New("forbidden: root group cannot be deleted")
-	}
+	err := uc.store.WithTx(func(repo iDeleteGroupRepo) error {
+		// get group by id
+		// if group is root, return error
+		group, err := repo.GetGroupByID(req.ID)
+		if err != nil {
+			return err
+		}
+		if group.Name == acl.GroupRoot {
+			return errors.New("forbidden: root group cannot be deleted")
+		}
 
-	err = uc.repo.DeleteGroupByID(req.ID)
+		entities, err := repo.ListEntitiesByGroupID(req.ID)
+		if err != nil {
+			return err
+		}
+		if len(entities) > 0 {
+			if err := reassignEntities(repo, group, entities, req.ReassignTo); err != nil {
+				return err
+			}
+		}
+		return repo.DeleteGroupByID(req.ID)
+	})
 	if err != nil {
 		return DeleteGroupResponse{Success: false}, err
 	}
 	return DeleteGroupResponse{Success: true}, nil
 }
+
+// reassignEntities moves the entities of a group being deleted to another group, or releases them
+func reassignEntities(repo iDeleteGroupRepo, group acl.Group, entities []entity.Entity, reassignTo string) error {
+	if reassignTo == "" {
+		return fmt.Errorf("group %s owns %d entities, choose a group to reassign them to, or %s to release them",
+			group.Name, len(entities), entity.GroupNone)
+	}
+	if reassignTo == group.ID {
+		return errors.New("cannot reassign the entities to the deleted group")
+	}
+	target := acl.Group{ID: entity.GroupNone}
+	if reassignTo != entity.GroupNone {
+		var err error
+		target, err = repo.GetGroupByID(reassignTo)
+		if err != nil {
+			return fmt.Errorf("group %s to reassign the entities to is not found", reassignTo)
+		}
+	}
+	for _, ent := range entities {
+		ent.SetOwner(target.ID, target.Name)
+		ent.UpdatedAt = time.Now()
+		if err := repo.UpdateEntity(ent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
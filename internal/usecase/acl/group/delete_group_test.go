@@ -5,12 +5,23 @@ import (
 	"testing"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
 	group_mock "github.com/jekiapp/topic-master/internal/usecase/acl/group/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
+// deleteGroupTxStore hands the mocked repo to the transaction
+type deleteGroupTxStore struct {
+	repo iDeleteGroupRepo
+}
+
+func (s deleteGroupTxStore) WithTx(fn func(repo iDeleteGroupRepo) error) error {
+	return fn(s.repo)
+}
+
 func TestDeleteGroupUsecase_Handle(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -84,6 +95,7 @@ func TestDeleteGroupUsecase_Handle(t *testing.T) {
 					acl.Group{Name: "notroot"},
 					nil,
 				)
+				m.EXPECT().ListEntitiesByGroupID("engineering-id").Return(nil, nil)
 				m.EXPECT().DeleteGroupByID(
 					"engineering-id",
 				).Return(
@@ -103,6 +115,7 @@ func TestDeleteGroupUsecase_Handle(t *testing.T) {
 					acl.Group{Name: "notroot"},
 					nil,
 				)
+				m.EXPECT().ListEntitiesByGroupID("marketing-id").Return(nil, nil)
 				m.EXPECT().DeleteGroupByID(
 					"marketing-id",
 				).Return(
@@ -122,6 +135,7 @@ func TestDeleteGroupUsecase_Handle(t *testing.T) {
 					acl.Group{},
 					nil,
 				)
+				m.EXPECT().ListEntitiesByGroupID("support-id").Return(nil, nil)
 				m.EXPECT().DeleteGroupByID(
 					"support-id",
 				).Return(
@@ -160,6 +174,7 @@ func TestDeleteGroupUsecase_Handle(t *testing.T) {
 					acl.Group{Name: "forbidden"},
 					nil,
 				)
+				m.EXPECT().ListEntitiesByGroupID("finance-id").Return(nil, nil)
 				m.EXPECT().DeleteGroupByID(
 					"finance-id",
 				).Return(
@@ -169,6 +184,48 @@ func TestDeleteGroupUsecase_Handle(t *testing.T) {
 			req:     DeleteGroupRequest{ID: "finance-id"},
 			wantErr: true,
 		},
+		{
+			name:   "group owning entities needs a reassignment",
+			groups: rootGroup,
+			setupMock: func(m *group_mock.MockiDeleteGroupRepo) {
+				m.EXPECT().GetGroupByID("payments-id").Return(acl.Group{ID: "payments-id", Name: "payments"}, nil)
+				m.EXPECT().ListEntitiesByGroupID("payments-id").Return([]entity.Entity{{ID: "topic-1"}}, nil)
+			},
+			req:     DeleteGroupRequest{ID: "payments-id"},
+			wantErr: true,
+		},
+		{
+			name:   "entities are reassigned before the group is deleted",
+			groups: rootGroup,
+			setupMock: func(m *group_mock.MockiDeleteGroupRepo) {
+				m.EXPECT().GetGroupByID("payments-id").Return(acl.Group{ID: "payments-id", Name: "payments"}, nil)
+				m.EXPECT().ListEntitiesByGroupID("payments-id").Return([]entity.Entity{{ID: "topic-1"}}, nil)
+				m.EXPECT().GetGroupByID("billing-id").Return(acl.Group{ID: "billing-id", Name: "billing"}, nil)
+				m.EXPECT().UpdateEntity(gomock.Any()).DoAndReturn(func(ent entity.Entity) error {
+					assert.Equal(t, "billing-id", ent.GroupOwnerID)
+					assert.Equal(t, "billing", ent.GroupOwner)
+					return nil
+				})
+				m.EXPECT().DeleteGroupByID("payments-id").Return(nil)
+			},
+			req:    DeleteGroupRequest{ID: "payments-id", ReassignTo: "billing-id"},
+			wantOK: true,
+		},
+		{
+			name:   "entities are released before the group is deleted",
+			groups: rootGroup,
+			setupMock: func(m *group_mock.MockiDeleteGroupRepo) {
+				m.EXPECT().GetGroupByID("payments-id").Return(acl.Group{ID: "payments-id", Name: "payments"}, nil)
+				m.EXPECT().ListEntitiesByGroupID("payments-id").Return([]entity.Entity{{ID: "topic-1"}}, nil)
+				m.EXPECT().UpdateEntity(gomock.Any()).DoAndReturn(func(ent entity.Entity) error {
+					assert.False(t, ent.IsOwned())
+					return nil
+				})
+				m.EXPECT().DeleteGroupByID("payments-id").Return(nil)
+			},
+			req:    DeleteGroupRequest{ID: "payments-id", ReassignTo: entity.GroupNone},
+			wantOK: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -183,7 +240,7 @@ func TestDeleteGroupUsecase_Handle(t *testing.T) {
 			}
 			mockRepo := group_mock.NewMockiDeleteGroupRepo(ctrl)
 			tt.setupMock(mockRepo)
-			uc := DeleteGroupUsecase{repo: mockRepo}
+			uc := DeleteGroupUsecase{store: deleteGroupTxStore{repo: mockRepo}}
 			resp, err := uc.Handle(ctx, tt.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -196,3 +253,66 @@ func TestDeleteGroupUsecase_Handle(t *testing.T) {
 		})
 	}
 }
+
+type failingDeleteGroupRepo struct {
+	iDeleteGroupRepo
+	failing *failingEntityUpdates
+}
+
+func (r failingDeleteGroupRepo) UpdateEntity(ent entity.Entity) error {
+	if err := r.failing.fail(); err != nil {
+		return err
+	}
+	return r.iDeleteGroupRepo.UpdateEntity(ent)
+}
+
+type failingDeleteGroupStore struct {
+	*deleteGroupStore
+}
+
+func (s failingDeleteGroupStore) WithTx(fn func(repo iDeleteGroupRepo) error) error {
+	return s.deleteGroupStore.WithTx(func(repo iDeleteGroupRepo) error {
+		return fn(failingDeleteGroupRepo{iDeleteGroupRepo: repo, failing: &failingEntityUpdates{}})
+	})
+}
+
+func TestDeleteGroupUsecase_ReassignRollsBack(t *testing.T) {
+	bdb := openGroupTestDB(t)
+	for _, group := range []acl.Group{{ID: "engineering-id", Name: "engineering"}, {ID: "platform-id", Name: "platform"}} {
+		if err := db.Insert(bdb, &group); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, id := range []string{"topic-1", "topic-2"} {
+		if err := db.Insert(bdb, &entity.Entity{ID: id, TypeID: entity.EntityType_NSQTopic, Name: id, Status: entity.EntityStatus_Active, GroupOwnerID: "engineering-id", GroupOwner: "engineering"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := util.MockContextWithUser(context.Background(), &acl.User{ID: "root-user", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}})
+
+	uc := DeleteGroupUsecase{store: failingDeleteGroupStore{&deleteGroupStore{db: bdb}}}
+	resp, err := uc.Handle(ctx, DeleteGroupRequest{ID: "engineering-id", ReassignTo: "platform-id"})
+	assert.ErrorContains(t, err, "disk full")
+	assert.False(t, resp.Success)
+
+	// the group is kept with all of its entities, none of them moved
+	_, err = db.GetByID[acl.Group](bdb, "engineering-id")
+	assert.NoError(t, err)
+	entities, err := db.SelectAll[entity.Entity](bdb, "=engineering-id", entity.IdxEntity_Group)
+	assert.NoError(t, err)
+	assert.Len(t, entities, 2)
+
+	// without the failure the entities move and the group is deleted together
+	resp, err = NewDeleteGroupUsecase(bdb).Handle(ctx, DeleteGroupRequest{ID: "engineering-id", ReassignTo: "platform-id"})
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+	_, err = db.GetByID[acl.Group](bdb, "engineering-id")
+	assert.ErrorIs(t, err, db.ErrNotFound)
+	entities, err = db.SelectAll[entity.Entity](bdb, "=platform-id", entity.IdxEntity_Group)
+	assert.NoError(t, err)
+	if assert.Len(t, entities, 2) {
+		for _, ent := range entities {
+			assert.Equal(t, "platform", ent.GroupOwner)
+		}
+	}
+}
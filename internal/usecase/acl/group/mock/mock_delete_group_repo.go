@@ -3,23 +3,24 @@
 //
 // Generated by this command:
 //
-//	Cursor-0.48.8-x86_64.AppImage -source=internal/usecase/acl/group/delete_group.go -destination=internal/usecase/acl/group/mock/mock_delete_group_repo.go -package=group_mock
+//	mockgen -source=internal/usecase/acl/group/delete_group.go -destination=internal/usecase/acl/group/mock/mock_delete_group_repo.go -package=group_mock -exclude_interfaces=iDeleteGroupStore
 //
 
 // Package group_mock is a generated GoMock package.
 package group_mock
 
 import (
-	reflect "reflect"
-
 	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	entity "github.com/jekiapp/topic-master/internal/model/entity"
 	gomock "go.uber.org/mock/gomock"
+	reflect "reflect"
 )
 
 // MockiDeleteGroupRepo is a mock of iDeleteGroupRepo interface.
 type MockiDeleteGroupRepo struct {
 	ctrl     *gomock.Controller
 	recorder *MockiDeleteGroupRepoMockRecorder
+	isgomock struct{}
 }
 
 // MockiDeleteGroupRepoMockRecorder is the mock recorder for MockiDeleteGroupRepo.
@@ -67,3 +68,32 @@ func (mr *MockiDeleteGroupRepoMockRecorder) GetGroupByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByID", reflect.TypeOf((*MockiDeleteGroupRepo)(nil).GetGroupByID), id)
 }
+
+// ListEntitiesByGroupID mocks base method.
+func (m *MockiDeleteGroupRepo) ListEntitiesByGroupID(groupID string) ([]entity.Entity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntitiesByGroupID", groupID)
+	ret0, _ := ret[0].([]entity.Entity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntitiesByGroupID indicates an expected call of ListEntitiesByGroupID.
+func (mr *MockiDeleteGroupRepoMockRecorder) ListEntitiesByGroupID(groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntitiesByGroupID", reflect.TypeOf((*MockiDeleteGroupRepo)(nil).ListEntitiesByGroupID), groupID)
+}
+
+// UpdateEntity mocks base method.
+func (m *MockiDeleteGroupRepo) UpdateEntity(ent entity.Entity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEntity", ent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEntity indicates an expected call of UpdateEntity.
+func (mr *MockiDeleteGroupRepoMockRecorder) UpdateEntity(ent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntity", reflect.TypeOf((*MockiDeleteGroupRepo)(nil).UpdateEntity), ent)
+}
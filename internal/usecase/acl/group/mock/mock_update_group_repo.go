@@ -3,23 +3,24 @@
 //
 // Generated by this command:
 //
-//	Cursor-0.48.8-x86_64.AppImage -source=internal/usecase/acl/group/update_group_by_id.go -destination=internal/usecase/acl/group/mock/mock_update_group_repo.go -package=group_mock
+//	mockgen -source=internal/usecase/acl/group/update_group_by_id.go -destination=internal/usecase/acl/group/mock/mock_update_group_repo.go -package=group_mock -exclude_interfaces=iUpdateGroupStore
 //
 
 // Package group_mock is a generated GoMock package.
 package group_mock
 
 import (
-	reflect "reflect"
-
 	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	entity "github.com/jekiapp/topic-master/internal/model/entity"
 	gomock "go.uber.org/mock/gomock"
+	reflect "reflect"
 )
 
 // MockiUpdateGroupRepo is a mock of iUpdateGroupRepo interface.
 type MockiUpdateGroupRepo struct {
 	ctrl     *gomock.Controller
 	recorder *MockiUpdateGroupRepoMockRecorder
+	isgomock struct{}
 }
 
 // MockiUpdateGroupRepoMockRecorder is the mock recorder for MockiUpdateGroupRepo.
@@ -54,6 +55,50 @@ func (mr *MockiUpdateGroupRepoMockRecorder) GetGroupByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByID", reflect.TypeOf((*MockiUpdateGroupRepo)(nil).GetGroupByID), id)
 }
 
+// GetGroupByName mocks base method.
+func (m *MockiUpdateGroupRepo) GetGroupByName(name string) (acl.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupByName", name)
+	ret0, _ := ret[0].(acl.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupByName indicates an expected call of GetGroupByName.
+func (mr *MockiUpdateGroupRepoMockRecorder) GetGroupByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByName", reflect.TypeOf((*MockiUpdateGroupRepo)(nil).GetGroupByName), name)
+}
+
+// ListEntitiesByGroupID mocks base method.
+func (m *MockiUpdateGroupRepo) ListEntitiesByGroupID(groupID string) ([]entity.Entity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntitiesByGroupID", groupID)
+	ret0, _ := ret[0].([]entity.Entity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntitiesByGroupID indicates an expected call of ListEntitiesByGroupID.
+func (mr *MockiUpdateGroupRepoMockRecorder) ListEntitiesByGroupID(groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntitiesByGroupID", reflect.TypeOf((*MockiUpdateGroupRepo)(nil).ListEntitiesByGroupID), groupID)
+}
+
+// UpdateEntity mocks base method.
+func (m *MockiUpdateGroupRepo) UpdateEntity(ent entity.Entity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEntity", ent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEntity indicates an expected call of UpdateEntity.
+func (mr *MockiUpdateGroupRepoMockRecorder) UpdateEntity(ent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEntity", reflect.TypeOf((*MockiUpdateGroupRepo)(nil).UpdateEntity), ent)
+}
+
 // UpdateGroup mocks base method.
 func (m *MockiUpdateGroupRepo) UpdateGroup(group acl.Group) error {
 	m.ctrl.T.Helper()
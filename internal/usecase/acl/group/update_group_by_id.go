@@ -1,5 +1,6 @@
 // this is for updating a group by id
-// only allow update for the description only
+// allow update for the name and the description, a rename is propagated to the owned entities
+// in the same transaction
 // check delete_group.go for the reference
 // only root group can do this
 
@@ -12,13 +13,15 @@ import (
 	"time"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
-	grouprepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/tidwall/buntdb"
 )
 
 type UpdateGroupByIDRequest struct {
 	ID          string `json:"id"`
+	Name        string `json:"name"` // optional, empty keeps the current name
 	Description string `json:"description"`
 }
 
@@ -28,28 +31,64 @@ type UpdateGroupByIDResponse struct {
 
 type iUpdateGroupRepo interface {
 	GetGroupByID(id string) (acl.Group, error)
+	GetGroupByName(name string) (acl.Group, error)
 	UpdateGroup(group acl.Group) error
+	ListEntitiesByGroupID(groupID string) ([]entity.Entity, error)
+	UpdateEntity(ent entity.Entity) error
 }
 
-type updateGroupRepo struct {
+// iUpdateGroupStore runs the update of a group in one write transaction,
+// so a rename is never stored without the owner name of its entities
+type iUpdateGroupStore interface {
+	WithTx(fn func(repo iUpdateGroupRepo) error) error
+}
+
+type updateGroupStore struct {
 	db *buntdb.DB
 }
 
+func (s *updateGroupStore) WithTx(fn func(repo iUpdateGroupRepo) error) error {
+	return db.WithTx(s.db, func(tx *buntdb.Tx) error {
+		return fn(&updateGroupRepo{tx: tx})
+	})
+}
+
+// updateGroupRepo works inside the transaction of updateGroupStore.WithTx
+type updateGroupRepo struct {
+	tx *buntdb.Tx
+}
+
 func (r *updateGroupRepo) GetGroupByID(id string) (acl.Group, error) {
-	return grouprepo.GetGroupByID(r.db, id)
+	return db.GetByIDTx[acl.Group](r.tx, id)
+}
+
+func (r *updateGroupRepo) GetGroupByName(name string) (acl.Group, error) {
+	return db.SelectOneTx[acl.Group](r.tx, name, acl.IdxGroup_Name)
 }
 
 func (r *updateGroupRepo) UpdateGroup(group acl.Group) error {
-	return grouprepo.UpdateGroup(r.db, group)
+	return db.UpdateTx(r.tx, &group)
+}
+
+func (r *updateGroupRepo) ListEntitiesByGroupID(groupID string) ([]entity.Entity, error) {
+	entities, err := db.SelectAllTx[entity.Entity](r.tx, "="+groupID, entity.IdxEntity_Group)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+	return entities, nil
+}
+
+func (r *updateGroupRepo) UpdateEntity(ent entity.Entity) error {
+	return db.UpdateTx(r.tx, &ent)
 }
 
 type UpdateGroupByIDUsecase struct {
-	repo iUpdateGroupRepo
+	store iUpdateGroupStore
 }
 
 func NewUpdateGroupByIDUsecase(db *buntdb.DB) UpdateGroupByIDUsecase {
 	return UpdateGroupByIDUsecase{
-		repo: &updateGroupRepo{db: db},
+		store: &updateGroupStore{db: db},
 	}
 }
 
@@ -71,14 +110,64 @@ func (uc UpdateGroupByIDUsecase) Handle(ctx context.Context, req UpdateGroupByID
 	if req.ID == "" {
 		return UpdateGroupByIDResponse{}, errors.New("missing required field: id")
 	}
-	group, err := uc.repo.GetGroupByID(req.ID)
+	var group acl.Group
+	err := uc.store.WithTx(func(repo iUpdateGroupRepo) error {
+		var err error
+		group, err = repo.GetGroupByID(req.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get group by id: %w", err)
+		}
+		renamed := req.Name != "" && req.Name != group.Name
+		if renamed {
+			if err := validateRename(repo, group, req.Name); err != nil {
+				return err
+			}
+			group.Name = req.Name
+		}
+		group.Description = req.Description
+		group.UpdatedAt = time.Now()
+		if err := repo.UpdateGroup(group); err != nil {
+			return err
+		}
+		if renamed {
+			if err := propagateRename(repo, group); err != nil {
+				return fmt.Errorf("failed to update the entities of the group: %w", err)
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return UpdateGroupByIDResponse{}, fmt.Errorf("failed to get group by id: %w", err)
-	}
-	group.Description = req.Description
-	group.UpdatedAt = time.Now()
-	if err := uc.repo.UpdateGroup(group); err != nil {
 		return UpdateGroupByIDResponse{}, err
 	}
 	return UpdateGroupByIDResponse{Group: group}, nil
 }
+
+func validateRename(repo iUpdateGroupRepo, group acl.Group, name string) error {
+	if group.Name == acl.GroupRoot {
+		return errors.New("forbidden: root group cannot be renamed")
+	}
+	if name == acl.GroupRoot || name == acl.GroupNone {
+		return fmt.Errorf("group name %s is reserved", name)
+	}
+	if _, err := repo.GetGroupByName(name); err == nil {
+		return fmt.Errorf("group %s already exists", name)
+	}
+	return nil
+}
+
+// propagateRename updates the owner name displayed on the entities of the group,
+// the ownership itself is kept by group id
+func propagateRename(repo iUpdateGroupRepo, group acl.Group) error {
+	entities, err := repo.ListEntitiesByGroupID(group.ID)
+	if err != nil {
+		return err
+	}
+	for _, ent := range entities {
+		ent.SetOwner(group.ID, group.Name)
+		ent.UpdatedAt = time.Now()
+		if err := repo.UpdateEntity(ent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
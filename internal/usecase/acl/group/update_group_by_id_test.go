@@ -2,16 +2,59 @@ package group
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
 	group_mock "github.com/jekiapp/topic-master/internal/usecase/acl/group/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/buntdb"
 	"go.uber.org/mock/gomock"
 )
 
+// updateGroupTxStore hands the mocked repo to the transaction
+type updateGroupTxStore struct {
+	repo iUpdateGroupRepo
+}
+
+func (s updateGroupTxStore) WithTx(fn func(repo iUpdateGroupRepo) error) error {
+	return fn(s.repo)
+}
+
+// openGroupTestDB opens an in-memory database with the indexes of groups and entities
+func openGroupTestDB(t *testing.T) *buntdb.DB {
+	t.Helper()
+	bdb, err := buntdb.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { bdb.Close() })
+	indexes := append(acl.Group{}.GetIndexes(), entity.Entity{}.GetIndexes()...)
+	for _, index := range indexes {
+		if err := bdb.CreateIndex(index.Name, index.Pattern, index.Type); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return bdb
+}
+
+// failingEntityUpdates lets the first entity update through and fails the next ones
+type failingEntityUpdates struct {
+	updated int
+}
+
+func (f *failingEntityUpdates) fail() error {
+	f.updated++
+	if f.updated > 1 {
+		return errors.New("disk full")
+	}
+	return nil
+}
+
 func TestUpdateGroupByIDUsecase_Handle(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -98,6 +141,44 @@ func TestUpdateGroupByIDUsecase_Handle(t *testing.T) {
 			req:     UpdateGroupByIDRequest{ID: "engineering-id", Description: "Updated Description"},
 			wantErr: false,
 		},
+		{
+			name:   "root group cannot be renamed",
+			groups: rootGroup,
+			setupMock: func(m *group_mock.MockiUpdateGroupRepo) {
+				m.EXPECT().GetGroupByID("root-id").Return(acl.Group{ID: "root-id", Name: acl.GroupRoot}, nil)
+			},
+			req:     UpdateGroupByIDRequest{ID: "root-id", Name: "admins"},
+			wantErr: true,
+		},
+		{
+			name:   "rename to an existing group name",
+			groups: rootGroup,
+			setupMock: func(m *group_mock.MockiUpdateGroupRepo) {
+				m.EXPECT().GetGroupByID("engineering-id").Return(acl.Group{ID: "engineering-id", Name: "engineering"}, nil)
+				m.EXPECT().GetGroupByName("platform").Return(acl.Group{ID: "platform-id", Name: "platform"}, nil)
+			},
+			req:     UpdateGroupByIDRequest{ID: "engineering-id", Name: "platform"},
+			wantErr: true,
+		},
+		{
+			name:   "rename is propagated to the owned entities",
+			groups: rootGroup,
+			setupMock: func(m *group_mock.MockiUpdateGroupRepo) {
+				m.EXPECT().GetGroupByID("engineering-id").Return(acl.Group{ID: "engineering-id", Name: "engineering"}, nil)
+				m.EXPECT().GetGroupByName("eng").Return(acl.Group{}, context.DeadlineExceeded)
+				m.EXPECT().UpdateGroup(gomock.Any()).Return(nil)
+				m.EXPECT().ListEntitiesByGroupID("engineering-id").Return([]entity.Entity{
+					{ID: "topic-1", GroupOwnerID: "engineering-id", GroupOwner: "engineering"},
+				}, nil)
+				m.EXPECT().UpdateEntity(gomock.Any()).DoAndReturn(func(ent entity.Entity) error {
+					assert.Equal(t, "engineering-id", ent.GroupOwnerID)
+					assert.Equal(t, "eng", ent.GroupOwner)
+					return nil
+				})
+			},
+			req:     UpdateGroupByIDRequest{ID: "engineering-id", Name: "eng", Description: "Engineering"},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -112,7 +193,7 @@ func TestUpdateGroupByIDUsecase_Handle(t *testing.T) {
 			}
 			mockRepo := group_mock.NewMockiUpdateGroupRepo(ctrl)
 			tt.setupMock(mockRepo)
-			uc := UpdateGroupByIDUsecase{repo: mockRepo}
+			uc := UpdateGroupByIDUsecase{store: updateGroupTxStore{repo: mockRepo}}
 			_, err := uc.Handle(ctx, tt.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -122,3 +203,67 @@ func TestUpdateGroupByIDUsecase_Handle(t *testing.T) {
 		})
 	}
 }
+
+type failingUpdateGroupRepo struct {
+	iUpdateGroupRepo
+	failing *failingEntityUpdates
+}
+
+func (r failingUpdateGroupRepo) UpdateEntity(ent entity.Entity) error {
+	if err := r.failing.fail(); err != nil {
+		return err
+	}
+	return r.iUpdateGroupRepo.UpdateEntity(ent)
+}
+
+type failingUpdateGroupStore struct {
+	*updateGroupStore
+}
+
+func (s failingUpdateGroupStore) WithTx(fn func(repo iUpdateGroupRepo) error) error {
+	return s.updateGroupStore.WithTx(func(repo iUpdateGroupRepo) error {
+		return fn(failingUpdateGroupRepo{iUpdateGroupRepo: repo, failing: &failingEntityUpdates{}})
+	})
+}
+
+func TestUpdateGroupByIDUsecase_RenameRollsBack(t *testing.T) {
+	bdb := openGroupTestDB(t)
+	if err := db.Insert(bdb, &acl.Group{ID: "engineering-id", Name: "engineering", Description: "Engineering"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"topic-1", "topic-2"} {
+		if err := db.Insert(bdb, &entity.Entity{ID: id, TypeID: entity.EntityType_NSQTopic, Name: id, Status: entity.EntityStatus_Active, GroupOwnerID: "engineering-id", GroupOwner: "engineering"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx := util.MockContextWithUser(context.Background(), &acl.User{ID: "root-user", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}})
+
+	uc := UpdateGroupByIDUsecase{store: failingUpdateGroupStore{&updateGroupStore{db: bdb}}}
+	_, err := uc.Handle(ctx, UpdateGroupByIDRequest{ID: "engineering-id", Name: "eng", Description: "Renamed"})
+	assert.ErrorContains(t, err, "disk full")
+
+	// neither the group nor the entity updated before the failure are kept
+	group, err := db.GetByID[acl.Group](bdb, "engineering-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "engineering", group.Name)
+	assert.Equal(t, "Engineering", group.Description)
+	_, err = db.SelectOne[acl.Group](bdb, "eng", acl.IdxGroup_Name)
+	assert.ErrorIs(t, err, db.ErrNotFound)
+	for _, id := range []string{"topic-1", "topic-2"} {
+		ent, err := db.GetByID[entity.Entity](bdb, id)
+		assert.NoError(t, err)
+		assert.Equal(t, "engineering", ent.GroupOwner)
+	}
+
+	// without the failure the rename reaches every entity
+	uc = NewUpdateGroupByIDUsecase(bdb)
+	_, err = uc.Handle(ctx, UpdateGroupByIDRequest{ID: "engineering-id", Name: "eng", Description: "Renamed"})
+	assert.NoError(t, err)
+	entities, err := db.SelectAll[entity.Entity](bdb, "=engineering-id", entity.IdxEntity_Group)
+	assert.NoError(t, err)
+	if assert.Len(t, entities, 2) {
+		for _, ent := range entities {
+			assert.Equal(t, "eng", ent.GroupOwner)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/tidwall/buntdb"
 )
 
@@ -54,6 +55,10 @@ func (uc ChangePasswordUsecase) Handle(ctx context.Context, req ChangePasswordRe
 	if req.UserID == "" || req.OldPassword == "" || req.NewPassword == "" {
 		return ChangePasswordResponse{Success: false}, errors.New("missing required fields: user_id, old_password, or new_password")
 	}
+	// the rate limit counts the attempts of the logged in user, who can only change their own password
+	if caller := util.GetUserInfo(ctx); caller != nil && caller.ID != req.UserID {
+		return ChangePasswordResponse{Success: false}, errors.New("forbidden: cannot change the password of another user")
+	}
 	user, err := uc.repo.GetUserByID(req.UserID)
 	if err != nil {
 		return ChangePasswordResponse{Success: false}, errors.New("user not found")
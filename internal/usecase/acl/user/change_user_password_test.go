@@ -9,6 +9,7 @@ import (
 
 	acl "github.com/jekiapp/topic-master/internal/model/acl"
 	user_mock "github.com/jekiapp/topic-master/internal/usecase/acl/user/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -24,6 +25,7 @@ func TestChangePasswordUsecase_Handle(t *testing.T) {
 
 	tests := []struct {
 		name      string
+		caller    *acl.User // logged in user, nil when not set by the middleware
 		req       ChangePasswordRequest
 		setupMock func(m *user_mock.MockiUserPasswordRepo)
 		wantErr   bool
@@ -81,13 +83,34 @@ func TestChangePasswordUsecase_Handle(t *testing.T) {
 			},
 			wantOK: true,
 		},
+		{
+			name:      "password of another user",
+			caller:    &acl.User{ID: "erin"},
+			req:       ChangePasswordRequest{UserID: "dave", OldPassword: "old", NewPassword: "new"},
+			setupMock: func(m *user_mock.MockiUserPasswordRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:   "own password of the logged in user",
+			caller: &acl.User{ID: "dave"},
+			req:    ChangePasswordRequest{UserID: "dave", OldPassword: "old", NewPassword: "new"},
+			setupMock: func(m *user_mock.MockiUserPasswordRepo) {
+				m.EXPECT().GetUserByID("dave").Return(acl.User{Password: hash("old")}, nil)
+				m.EXPECT().UpdateUser(gomock.Any()).Return(nil)
+			},
+			wantOK: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := user_mock.NewMockiUserPasswordRepo(ctrl)
 			tt.setupMock(mockRepo)
 			uc := ChangePasswordUsecase{repo: mockRepo}
-			resp, err := uc.Handle(context.Background(), tt.req)
+			ctx := context.Background()
+			if tt.caller != nil {
+				ctx = util.MockContextWithUser(ctx, tt.caller)
+			}
+			resp, err := uc.Handle(ctx, tt.req)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -7,12 +7,18 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
+	netmail "net/mail"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/mail"
 	"github.com/jekiapp/topic-master/internal/model/acl"
+	notifrepo "github.com/jekiapp/topic-master/internal/repository/notification"
 	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
 	"github.com/tidwall/buntdb"
 )
@@ -21,6 +27,8 @@ type CreateUserRequest struct {
 	Username string `json:"username"`
 	Name     string `json:"name"`
 	Password string `json:"password"`
+	Email    string `json:"email"`  // optional, saved in the notification preference of the user
+	Invite   bool   `json:"invite"` // email a link to set the password instead of generating one
 	Groups   []struct {
 		GroupID string `json:"group_id"`
 		Role    string `json:"role"`
@@ -30,22 +38,30 @@ type CreateUserRequest struct {
 
 type CreateUserResponse struct {
 	Username          string `json:"username"`
-	GeneratedPassword string `json:"generated_password"`
+	GeneratedPassword string `json:"generated_password,omitempty"`
+	InviteSent        bool   `json:"invite_sent"`
 }
 
 type iUserRepo interface {
 	CreateUser(user acl.User) error
 	GetUserByUsername(username string) (acl.User, error)
 	CreateUserGroup(userGroup acl.UserGroup) error
+	SetUserEmail(userID, email string) error
+	DeleteResetPasswordsByUsername(username string) error
+	InsertResetPassword(rp acl.ResetPassword) error
 }
 
 type CreateUserUsecase struct {
-	repo iUserRepo
+	repo   iUserRepo
+	mailer iMailSender
+	config *config.Config
 }
 
-func NewCreateUserUsecase(db *buntdb.DB) CreateUserUsecase {
+func NewCreateUserUsecase(db *buntdb.DB, cfg *config.Config) CreateUserUsecase {
 	return CreateUserUsecase{
-		repo: &createUserRepo{db: db},
+		repo:   &createUserRepo{db: db},
+		mailer: mail.NewSender(cfg),
+		config: cfg,
 	}
 }
 
@@ -90,6 +106,9 @@ func validateCreateUserRequest(req CreateUserRequest) error {
 	if req.Name == "" {
 		return errors.New("name is required")
 	}
+	if req.Invite && req.Email == "" {
+		return errors.New("email is required to send an invitation")
+	}
 	return nil
 }
 
@@ -98,6 +117,17 @@ func (uc CreateUserUsecase) Handle(ctx context.Context, req CreateUserRequest) (
 	if err := validateCreateUserRequest(req); err != nil {
 		return CreateUserResponse{}, err
 	}
+	if req.Invite && uc.mailer == nil {
+		return CreateUserResponse{}, errors.New("email is not enabled, set up an SMTP server to send invitations")
+	}
+	email := strings.TrimSpace(req.Email)
+	if email != "" {
+		addr, err := netmail.ParseAddress(email)
+		if err != nil {
+			return CreateUserResponse{}, fmt.Errorf("invalid email address: %v", err)
+		}
+		email = addr.Address
+	}
 	// Check if user already exists
 	_, err := uc.repo.GetUserByUsername(req.Username)
 	if err == nil {
@@ -106,7 +136,8 @@ func (uc CreateUserUsecase) Handle(ctx context.Context, req CreateUserRequest) (
 	// Generate UUID for user ID
 	userID := uuid.NewString()
 	password := req.Password
-	if password == "" {
+	if password == "" || req.Invite {
+		// an invited user chooses the password, the generated one is never shown
 		var err error
 		password, err = generateRandomPassword(12)
 		if err != nil {
@@ -144,6 +175,18 @@ func (uc CreateUserUsecase) Handle(ctx context.Context, req CreateUserRequest) (
 		}
 	}
 
+	if email != "" {
+		if err := uc.repo.SetUserEmail(user.ID, email); err != nil {
+			return CreateUserResponse{}, err
+		}
+	}
+	if req.Invite {
+		if err := sendInvitation(uc.repo, uc.mailer, uc.config, user, email); err != nil {
+			return CreateUserResponse{}, fmt.Errorf("user %s is created but %v, resend the invitation from the user list", user.Username, err)
+		}
+		return CreateUserResponse{Username: user.Username, InviteSent: true}, nil
+	}
+
 	return CreateUserResponse{Username: user.Username, GeneratedPassword: password}, nil
 }
 
@@ -162,3 +205,22 @@ func (r *createUserRepo) GetUserByUsername(username string) (acl.User, error) {
 func (r *createUserRepo) CreateUserGroup(userGroup acl.UserGroup) error {
 	return userrepo.CreateUserGroup(r.db, userGroup)
 }
+
+// SetUserEmail saves the address in the notification preference, where notifications and reset links are sent.
+func (r *createUserRepo) SetUserEmail(userID, email string) error {
+	pref, err := notifrepo.GetPreference(r.db, userID)
+	if err != nil {
+		return err
+	}
+	pref.Email = email
+	pref.UpdatedAt = time.Now()
+	return notifrepo.UpsertPreference(r.db, pref)
+}
+
+func (r *createUserRepo) DeleteResetPasswordsByUsername(username string) error {
+	return userrepo.DeleteResetPasswordsByUsername(r.db, username)
+}
+
+func (r *createUserRepo) InsertResetPassword(rp acl.ResetPassword) error {
+	return userrepo.InsertResetPassword(r.db, rp)
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/mail"
 	acl "github.com/jekiapp/topic-master/internal/model/acl"
 	user_mock "github.com/jekiapp/topic-master/internal/usecase/acl/user/mock"
 	"github.com/stretchr/testify/assert"
@@ -195,3 +198,99 @@ func TestCreateUserUsecase_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateUserUsecase_Invite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	validGroups := []groupInput{{GroupID: "g1", Role: "member", Name: "dev"}}
+	cfg := &config.Config{PublicURL: "https://tm.example.com", InviteTTL: 48 * time.Hour}
+
+	tests := []struct {
+		name      string
+		req       CreateUserRequest
+		noMailer  bool
+		setupMock func(m *user_mock.MockiUserRepo, mailer *user_mock.MockiMailSender)
+		wantErr   string
+		wantResp  CreateUserResponse
+	}{
+		{
+			name:      "invite without email",
+			req:       CreateUserRequest{Username: "alice", Name: "Alice", Invite: true, Groups: validGroups},
+			setupMock: func(m *user_mock.MockiUserRepo, mailer *user_mock.MockiMailSender) {},
+			wantErr:   "email is required",
+		},
+		{
+			name:      "invite without mail server",
+			req:       CreateUserRequest{Username: "alice", Name: "Alice", Email: "alice@example.com", Invite: true, Groups: validGroups},
+			noMailer:  true,
+			setupMock: func(m *user_mock.MockiUserRepo, mailer *user_mock.MockiMailSender) {},
+			wantErr:   "email is not enabled",
+		},
+		{
+			name:      "invalid email",
+			req:       CreateUserRequest{Username: "alice", Name: "Alice", Email: "not an email", Groups: validGroups},
+			setupMock: func(m *user_mock.MockiUserRepo, mailer *user_mock.MockiMailSender) {},
+			wantErr:   "invalid email address",
+		},
+		{
+			name: "invitation is sent instead of the password",
+			req:  CreateUserRequest{Username: "alice", Name: "Alice", Email: "Alice <alice@example.com>", Invite: true, Password: "ignored", Groups: validGroups},
+			setupMock: func(m *user_mock.MockiUserRepo, mailer *user_mock.MockiMailSender) {
+				m.EXPECT().GetUserByUsername("alice").Return(acl.User{}, errors.New("not found"))
+				m.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(u acl.User) error {
+					assert.Equal(t, acl.StatusUserPending, u.Status)
+					return nil
+				})
+				m.EXPECT().CreateUserGroup(gomock.Any()).Return(nil)
+				m.EXPECT().SetUserEmail(gomock.Any(), "alice@example.com").Return(nil)
+				m.EXPECT().DeleteResetPasswordsByUsername("alice").Return(nil)
+				m.EXPECT().InsertResetPassword(gomock.Any()).DoAndReturn(func(rp acl.ResetPassword) error {
+					assert.Equal(t, acl.ResetPurposeInvite, rp.Purpose)
+					assert.InDelta(t, time.Now().Add(48*time.Hour).Unix(), rp.ExpiresAt, 2)
+					return nil
+				})
+				mailer.EXPECT().Send(gomock.Any()).DoAndReturn(func(m mail.Mail) error {
+					assert.Equal(t, "alice@example.com", m.To)
+					assert.Contains(t, m.Body, "https://tm.example.com/reset-password?token=")
+					assert.Contains(t, m.Body, "within 2 days")
+					return nil
+				})
+			},
+			wantResp: CreateUserResponse{Username: "alice", InviteSent: true},
+		},
+		{
+			name: "failed invitation can be resent",
+			req:  CreateUserRequest{Username: "alice", Name: "Alice", Email: "alice@example.com", Invite: true, Groups: validGroups},
+			setupMock: func(m *user_mock.MockiUserRepo, mailer *user_mock.MockiMailSender) {
+				m.EXPECT().GetUserByUsername("alice").Return(acl.User{}, errors.New("not found"))
+				m.EXPECT().CreateUser(gomock.Any()).Return(nil)
+				m.EXPECT().CreateUserGroup(gomock.Any()).Return(nil)
+				m.EXPECT().SetUserEmail(gomock.Any(), "alice@example.com").Return(nil)
+				m.EXPECT().DeleteResetPasswordsByUsername("alice").Return(nil)
+				m.EXPECT().InsertResetPassword(gomock.Any()).Return(nil)
+				mailer.EXPECT().Send(gomock.Any()).Return(errors.New("connection refused"))
+			},
+			wantErr: "resend the invitation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := user_mock.NewMockiUserRepo(ctrl)
+			mockMailer := user_mock.NewMockiMailSender(ctrl)
+			tt.setupMock(mockRepo, mockMailer)
+			uc := CreateUserUsecase{repo: mockRepo, mailer: mockMailer, config: cfg}
+			if tt.noMailer {
+				uc.mailer = nil
+			}
+			resp, err := uc.Handle(context.Background(), tt.req)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResp, resp)
+		})
+	}
+}
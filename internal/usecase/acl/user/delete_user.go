@@ -3,10 +3,14 @@ package user
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
 	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/tidwall/buntdb"
 )
 
@@ -19,6 +23,7 @@ type DeleteUserResponse struct {
 }
 
 type iUserDeleteRepo interface {
+	GetUserByID(userID string) (acl.User, error)
 	DeleteUser(userID string) error
 	ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error)
 	DeleteUserGroup(userGroupID string) error
@@ -28,6 +33,10 @@ type userDeleteRepo struct {
 	db *buntdb.DB
 }
 
+func (r *userDeleteRepo) GetUserByID(userID string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, userID)
+}
+
 func (r *userDeleteRepo) DeleteUser(userID string) error {
 	return db.DeleteByID[acl.User](r.db, userID)
 }
@@ -41,17 +50,25 @@ func (r *userDeleteRepo) DeleteUserGroup(userGroupID string) error {
 }
 
 type DeleteUserUsecase struct {
-	repo iUserDeleteRepo
+	repo    iUserDeleteRepo
+	cleanup userCleanup
 }
 
 func NewDeleteUserUsecase(db *buntdb.DB) DeleteUserUsecase {
 	return DeleteUserUsecase{
-		repo: &userDeleteRepo{db: db},
+		repo:    &userDeleteRepo{db: db},
+		cleanup: newUserCleanup(db),
 	}
 }
 
+// Handle deletes the user, their memberships and, through the cleanup, everything else they leave
+// behind. Deactivate the user instead to keep their grants.
 func (uc DeleteUserUsecase) Handle(ctx context.Context, req DeleteUserRequest) (DeleteUserResponse, error) {
-	err := uc.repo.DeleteUser(req.UserID)
+	user, err := uc.repo.GetUserByID(req.UserID)
+	if err != nil {
+		return DeleteUserResponse{Success: false}, errors.New("user not found")
+	}
+	err = uc.repo.DeleteUser(req.UserID)
 	if err != nil {
 		return DeleteUserResponse{Success: false}, err
 	}
@@ -68,5 +85,13 @@ func (uc DeleteUserUsecase) Handle(ctx context.Context, req DeleteUserRequest) (
 		}
 	}
 
+	actorID := acl.ActorSystem
+	if admin := util.GetUserInfo(ctx); admin != nil {
+		actorID = admin.ID
+	}
+	if err := uc.cleanup.purge(user.ID, user.Username, actorID, time.Now()); err != nil {
+		// the user is gone, the janitor finishes the cleanup of what is still referenced
+		return DeleteUserResponse{Success: false}, fmt.Errorf("user %s is deleted but the cleanup failed: %v", user.Username, err)
+	}
 	return DeleteUserResponse{Success: true}, nil
 }
@@ -18,14 +18,23 @@ func TestDeleteUserUsecase_Handle(t *testing.T) {
 	tests := []struct {
 		name      string
 		req       DeleteUserRequest
-		setupMock func(m *user_mock.MockiUserDeleteRepo)
+		setupMock func(m *user_mock.MockiUserDeleteRepo, c *user_mock.MockiUserCleanupRepo)
 		wantErr   bool
 		wantOK    bool
 	}{
+		{
+			name: "user not found",
+			req:  DeleteUserRequest{UserID: "nobody"},
+			setupMock: func(m *user_mock.MockiUserDeleteRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("nobody").Return(acl.User{}, errors.New("not found"))
+			},
+			wantErr: true,
+		},
 		{
 			name: "delete user error",
 			req:  DeleteUserRequest{UserID: "alice"},
-			setupMock: func(m *user_mock.MockiUserDeleteRepo) {
+			setupMock: func(m *user_mock.MockiUserDeleteRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("alice").Return(acl.User{ID: "alice", Username: "alice"}, nil)
 				m.EXPECT().DeleteUser("alice").Return(errors.New("fail"))
 			},
 			wantErr: true,
@@ -33,7 +42,8 @@ func TestDeleteUserUsecase_Handle(t *testing.T) {
 		{
 			name: "list user groups error",
 			req:  DeleteUserRequest{UserID: "bob"},
-			setupMock: func(m *user_mock.MockiUserDeleteRepo) {
+			setupMock: func(m *user_mock.MockiUserDeleteRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("bob").Return(acl.User{ID: "bob", Username: "bob"}, nil)
 				m.EXPECT().DeleteUser("bob").Return(nil)
 				m.EXPECT().ListUserGroupsByUserID("bob").Return(nil, errors.New("fail"))
 			},
@@ -42,7 +52,8 @@ func TestDeleteUserUsecase_Handle(t *testing.T) {
 		{
 			name: "delete user group error",
 			req:  DeleteUserRequest{UserID: "carol"},
-			setupMock: func(m *user_mock.MockiUserDeleteRepo) {
+			setupMock: func(m *user_mock.MockiUserDeleteRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("carol").Return(acl.User{ID: "carol", Username: "carol"}, nil)
 				m.EXPECT().DeleteUser("carol").Return(nil)
 				m.EXPECT().ListUserGroupsByUserID("carol").Return([]acl.UserGroup{{ID: "ug1"}}, nil)
 				m.EXPECT().DeleteUserGroup("ug1").Return(errors.New("fail"))
@@ -52,19 +63,43 @@ func TestDeleteUserUsecase_Handle(t *testing.T) {
 		{
 			name: "success",
 			req:  DeleteUserRequest{UserID: "dave"},
-			setupMock: func(m *user_mock.MockiUserDeleteRepo) {
+			setupMock: func(m *user_mock.MockiUserDeleteRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("dave").Return(acl.User{ID: "dave", Username: "dave"}, nil)
 				m.EXPECT().DeleteUser("dave").Return(nil)
 				m.EXPECT().ListUserGroupsByUserID("dave").Return([]acl.UserGroup{{ID: "ug2"}}, nil)
 				m.EXPECT().DeleteUserGroup("ug2").Return(nil)
+				c.EXPECT().ListPermissionMapsByUser("dave").Return([]acl.PermissionMap{{ID: "p1", UserID: "dave"}}, nil)
+				c.EXPECT().DeletePermissionMapByID("p1").Return(nil)
+				c.EXPECT().CreateGrantRevocation(gomock.Any()).DoAndReturn(func(r acl.GrantRevocation) error {
+					assert.Equal(t, "p1", r.PermissionMapID)
+					assert.Equal(t, "user deleted", r.Reason)
+					return nil
+				})
+				c.EXPECT().ListApplicationsByUserID("dave").Return([]acl.Application{}, nil)
+				c.EXPECT().ListAssignmentsByReviewerID("dave").Return([]acl.ApplicationAssignment{}, nil)
+				c.EXPECT().DeleteBookmarksByUser("dave").Return(nil)
+				c.EXPECT().DeleteUserData("dave", "dave").Return(nil)
 			},
 			wantOK: true,
 		},
+		{
+			name: "cleanup error",
+			req:  DeleteUserRequest{UserID: "erin"},
+			setupMock: func(m *user_mock.MockiUserDeleteRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("erin").Return(acl.User{ID: "erin", Username: "erin"}, nil)
+				m.EXPECT().DeleteUser("erin").Return(nil)
+				m.EXPECT().ListUserGroupsByUserID("erin").Return([]acl.UserGroup{}, nil)
+				c.EXPECT().ListPermissionMapsByUser("erin").Return(nil, errors.New("fail"))
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := user_mock.NewMockiUserDeleteRepo(ctrl)
-			tt.setupMock(mockRepo)
-			uc := DeleteUserUsecase{repo: mockRepo}
+			mockCleanup := user_mock.NewMockiUserCleanupRepo(ctrl)
+			tt.setupMock(mockRepo, mockCleanup)
+			uc := DeleteUserUsecase{repo: mockRepo, cleanup: userCleanup{repo: mockCleanup}}
 			resp, err := uc.Handle(context.Background(), tt.req)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -9,6 +9,7 @@ import (
 	"context"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/internal/repository/user"
@@ -28,7 +29,12 @@ type UserListItem struct {
 	Email        string        `json:"email"`
 	Groups       string        `json:"groups"`
 	Status       string        `json:"status"`
+	Source       string        `json:"source"`
 	GroupDetails []GroupDetail `json:"group_details"`
+
+	LastActiveAt  time.Time `json:"last_active_at"` // zero when the user was never seen
+	DeactivatedAt time.Time `json:"deactivated_at"`
+	DeactivatedBy string    `json:"deactivated_by"`
 }
 
 type GroupDetail struct {
@@ -41,6 +47,7 @@ type iUserDataRepo interface {
 	GetAllUsers() ([]acl.User, error)
 	ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error)
 	GetGroupByID(groupID string) (acl.Group, error)
+	ListUserActivities() ([]acl.UserActivity, error)
 }
 
 type GetUserListUsecase struct {
@@ -58,6 +65,14 @@ func (uc GetUserListUsecase) Handle(ctx context.Context, req GetUserListRequest)
 	if err != nil {
 		return GetUserListResponse{}, err
 	}
+	lastActive := map[string]time.Time{}
+	activities, err := uc.dataRepo.ListUserActivities()
+	if err != nil {
+		log.Printf("error listing user activities: %s", err)
+	}
+	for _, activity := range activities {
+		lastActive[activity.UserID] = activity.LastActiveAt
+	}
 	var result []UserListItem
 	for _, u := range users {
 		userGroups, err := uc.dataRepo.ListUserGroupsByUserID(u.ID)
@@ -85,7 +100,12 @@ func (uc GetUserListUsecase) Handle(ctx context.Context, req GetUserListRequest)
 			Name:         u.Name,
 			Groups:       strings.Join(groupNames, ","),
 			Status:       u.Status,
+			Source:       u.Source,
 			GroupDetails: groupDetails,
+
+			LastActiveAt:  lastActive[u.ID],
+			DeactivatedAt: u.DeactivatedAt,
+			DeactivatedBy: u.DeactivatedBy,
 		})
 	}
 	return GetUserListResponse{Users: result}, nil
@@ -107,3 +127,7 @@ func (r *userDataRepoImpl) ListUserGroupsByUserID(userID string) ([]acl.UserGrou
 func (r *userDataRepoImpl) GetGroupByID(groupID string) (acl.Group, error) {
 	return user.GetGroupByID(r.db, groupID)
 }
+
+func (r *userDataRepoImpl) ListUserActivities() ([]acl.UserActivity, error) {
+	return user.ListUserActivities(r.db)
+}
@@ -32,6 +32,7 @@ func TestGetUserListUsecase_Handle(t *testing.T) {
 			name: "success single user, single group",
 			setupMock: func(m *user_mock.MockiUserDataRepo) {
 				m.EXPECT().GetAllUsers().Return([]acl.User{{ID: "u1", Username: "alice", Name: "Alice"}}, nil)
+				m.EXPECT().ListUserActivities().Return(nil, nil)
 				m.EXPECT().ListUserGroupsByUserID(
 					"u1",
 				).Return([]acl.UserGroup{{GroupID: "g1", Role: "admin"}}, nil)
@@ -45,6 +46,7 @@ func TestGetUserListUsecase_Handle(t *testing.T) {
 			name: "user group error",
 			setupMock: func(m *user_mock.MockiUserDataRepo) {
 				m.EXPECT().GetAllUsers().Return([]acl.User{{ID: "u2", Username: "bob", Name: "Bob"}}, nil)
+				m.EXPECT().ListUserActivities().Return(nil, nil)
 				m.EXPECT().ListUserGroupsByUserID(
 					"u2",
 				).Return(nil, errors.New("fail"))
@@ -55,6 +57,7 @@ func TestGetUserListUsecase_Handle(t *testing.T) {
 			name: "user with no groups",
 			setupMock: func(m *user_mock.MockiUserDataRepo) {
 				m.EXPECT().GetAllUsers().Return([]acl.User{{ID: "u3", Username: "carol", Name: "Carol"}}, nil)
+				m.EXPECT().ListUserActivities().Return(nil, nil)
 				m.EXPECT().ListUserGroupsByUserID(
 					"u3",
 				).Return([]acl.UserGroup{}, nil)
@@ -65,6 +68,7 @@ func TestGetUserListUsecase_Handle(t *testing.T) {
 			name: "user with multiple groups, one group lookup error",
 			setupMock: func(m *user_mock.MockiUserDataRepo) {
 				m.EXPECT().GetAllUsers().Return([]acl.User{{ID: "u4", Username: "dave", Name: "Dave"}}, nil)
+				m.EXPECT().ListUserActivities().Return(nil, nil)
 				m.EXPECT().ListUserGroupsByUserID(
 					"u4",
 				).Return([]acl.UserGroup{{GroupID: "g1", Role: "admin"}, {GroupID: "g2", Role: "member"}}, nil)
@@ -84,6 +88,7 @@ func TestGetUserListUsecase_Handle(t *testing.T) {
 					{ID: "u5", Username: "eve", Name: "Eve"},
 					{ID: "u6", Username: "frank", Name: "Frank"},
 				}, nil)
+				m.EXPECT().ListUserActivities().Return(nil, errors.New("fail"))
 				m.EXPECT().ListUserGroupsByUserID(
 					"u5",
 				).Return([]acl.UserGroup{{GroupID: "g1", Role: "admin"}}, nil)
@@ -0,0 +1,134 @@
+//go:generate mockgen -source=invitation.go -destination=mock/mock_invitation_repo.go -package=user_mock
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/logic/mail"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	notifrepo "github.com/jekiapp/topic-master/internal/repository/notification"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/tidwall/buntdb"
+)
+
+type ResendInvitationRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type ResendInvitationResponse struct {
+	Email string `json:"email"`
+}
+
+// iInvitationStore keeps the invitation links, shared by the create user and the invitation usecases.
+type iInvitationStore interface {
+	DeleteResetPasswordsByUsername(username string) error
+	InsertResetPassword(rp acl.ResetPassword) error
+}
+
+type iInvitationRepo interface {
+	iInvitationStore
+	GetUserByID(userID string) (acl.User, error)
+	GetUserEmail(userID string) (string, error)
+}
+
+type iMailSender interface {
+	Send(m mail.Mail) error
+}
+
+type invitationRepo struct {
+	db *buntdb.DB
+}
+
+func (r *invitationRepo) GetUserByID(userID string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, userID)
+}
+
+func (r *invitationRepo) GetUserEmail(userID string) (string, error) {
+	pref, err := notifrepo.GetPreference(r.db, userID)
+	return pref.Email, err
+}
+
+func (r *invitationRepo) DeleteResetPasswordsByUsername(username string) error {
+	return userrepo.DeleteResetPasswordsByUsername(r.db, username)
+}
+
+func (r *invitationRepo) InsertResetPassword(rp acl.ResetPassword) error {
+	return userrepo.InsertResetPassword(r.db, rp)
+}
+
+// InvitationUsecase sends a new invitation link to a user who has not set their password yet.
+type InvitationUsecase struct {
+	repo   iInvitationRepo
+	mailer iMailSender
+	config *config.Config
+}
+
+func NewInvitationUsecase(db *buntdb.DB, cfg *config.Config) InvitationUsecase {
+	return InvitationUsecase{
+		repo:   &invitationRepo{db: db},
+		mailer: mail.NewSender(cfg),
+		config: cfg,
+	}
+}
+
+// HandleResend replaces the invitation link of a pending user, e.g. once the first one expired.
+func (uc InvitationUsecase) HandleResend(ctx context.Context, req ResendInvitationRequest) (ResendInvitationResponse, error) {
+	if uc.mailer == nil {
+		return ResendInvitationResponse{}, errors.New("email is not enabled, set up an SMTP server to send invitations")
+	}
+	if req.UserID == "" {
+		return ResendInvitationResponse{}, errors.New("missing user_id")
+	}
+	user, err := uc.repo.GetUserByID(req.UserID)
+	if err != nil {
+		return ResendInvitationResponse{}, errors.New("user not found")
+	}
+	if user.Status != acl.StatusUserPending {
+		return ResendInvitationResponse{}, errors.New("user has already set a password")
+	}
+	email, err := uc.repo.GetUserEmail(user.ID)
+	if err != nil {
+		return ResendInvitationResponse{}, err
+	}
+	if email == "" {
+		return ResendInvitationResponse{}, errors.New("user has no email address")
+	}
+	if err := sendInvitation(uc.repo, uc.mailer, uc.config, user, email); err != nil {
+		return ResendInvitationResponse{}, err
+	}
+	return ResendInvitationResponse{Email: email}, nil
+}
+
+// sendInvitation emails a single use link to set the first password, previous links stop working.
+func sendInvitation(store iInvitationStore, mailer iMailSender, cfg *config.Config, user acl.User, email string) error {
+	ttl := cfg.InviteTTL
+	if ttl <= 0 {
+		ttl = 72 * time.Hour
+	}
+	token, rp, err := auth.NewResetToken(user.Username, acl.ResetPurposeInvite, ttl, time.Now())
+	if err != nil {
+		return errors.New("failed to generate invitation token")
+	}
+	if err := store.DeleteResetPasswordsByUsername(user.Username); err != nil {
+		return err
+	}
+	if err := store.InsertResetPassword(rp); err != nil {
+		return err
+	}
+	err = mailer.Send(mail.Mail{
+		To:      email,
+		Subject: "You are invited to topic-master",
+		Body: fmt.Sprintf("Hello %s,\r\n\r\nAn account with the username %s has been created for you on topic-master. "+
+			"Open the link below within %s to choose your password:\r\n\r\n%s",
+			user.Name, user.Username, auth.ValidityText(ttl), auth.ResetPasswordLink(cfg.PublicURL, token)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send the invitation: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	user_mock "github.com/jekiapp/topic-master/internal/usecase/acl/user/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInvitationUsecase_HandleResend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pending := acl.User{ID: "u1", Username: "alice", Name: "Alice", Status: acl.StatusUserPending}
+
+	tests := []struct {
+		name      string
+		req       ResendInvitationRequest
+		setupMock func(m *user_mock.MockiInvitationRepo, mailer *user_mock.MockiMailSender)
+		wantErr   string
+	}{
+		{
+			name:      "missing user",
+			req:       ResendInvitationRequest{},
+			setupMock: func(m *user_mock.MockiInvitationRepo, mailer *user_mock.MockiMailSender) {},
+			wantErr:   "missing user_id",
+		},
+		{
+			name: "unknown user",
+			req:  ResendInvitationRequest{UserID: "u9"},
+			setupMock: func(m *user_mock.MockiInvitationRepo, mailer *user_mock.MockiMailSender) {
+				m.EXPECT().GetUserByID("u9").Return(acl.User{}, errors.New("not found"))
+			},
+			wantErr: "user not found",
+		},
+		{
+			name: "active user",
+			req:  ResendInvitationRequest{UserID: "u2"},
+			setupMock: func(m *user_mock.MockiInvitationRepo, mailer *user_mock.MockiMailSender) {
+				m.EXPECT().GetUserByID("u2").Return(acl.User{ID: "u2", Status: acl.StatusUserActive}, nil)
+			},
+			wantErr: "already set a password",
+		},
+		{
+			name: "no email address",
+			req:  ResendInvitationRequest{UserID: "u1"},
+			setupMock: func(m *user_mock.MockiInvitationRepo, mailer *user_mock.MockiMailSender) {
+				m.EXPECT().GetUserByID("u1").Return(pending, nil)
+				m.EXPECT().GetUserEmail("u1").Return("", nil)
+			},
+			wantErr: "no email address",
+		},
+		{
+			name: "previous links are revoked",
+			req:  ResendInvitationRequest{UserID: "u1"},
+			setupMock: func(m *user_mock.MockiInvitationRepo, mailer *user_mock.MockiMailSender) {
+				m.EXPECT().GetUserByID("u1").Return(pending, nil)
+				m.EXPECT().GetUserEmail("u1").Return("alice@example.com", nil)
+				gomock.InOrder(
+					m.EXPECT().DeleteResetPasswordsByUsername("alice").Return(nil),
+					m.EXPECT().InsertResetPassword(gomock.Any()).Return(nil),
+				)
+				mailer.EXPECT().Send(gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := user_mock.NewMockiInvitationRepo(ctrl)
+			mockMailer := user_mock.NewMockiMailSender(ctrl)
+			tt.setupMock(mockRepo, mockMailer)
+			uc := InvitationUsecase{repo: mockRepo, mailer: mockMailer, config: &config.Config{}}
+			resp, err := uc.HandleResend(context.Background(), tt.req)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "alice@example.com", resp.Email)
+		})
+	}
+}
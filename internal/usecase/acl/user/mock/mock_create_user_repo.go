@@ -67,6 +67,20 @@ func (mr *MockiUserRepoMockRecorder) CreateUserGroup(userGroup any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserGroup", reflect.TypeOf((*MockiUserRepo)(nil).CreateUserGroup), userGroup)
 }
 
+// DeleteResetPasswordsByUsername mocks base method.
+func (m *MockiUserRepo) DeleteResetPasswordsByUsername(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResetPasswordsByUsername", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResetPasswordsByUsername indicates an expected call of DeleteResetPasswordsByUsername.
+func (mr *MockiUserRepoMockRecorder) DeleteResetPasswordsByUsername(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResetPasswordsByUsername", reflect.TypeOf((*MockiUserRepo)(nil).DeleteResetPasswordsByUsername), username)
+}
+
 // GetUserByUsername mocks base method.
 func (m *MockiUserRepo) GetUserByUsername(username string) (acl.User, error) {
 	m.ctrl.T.Helper()
@@ -81,3 +95,31 @@ func (mr *MockiUserRepoMockRecorder) GetUserByUsername(username any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockiUserRepo)(nil).GetUserByUsername), username)
 }
+
+// InsertResetPassword mocks base method.
+func (m *MockiUserRepo) InsertResetPassword(rp acl.ResetPassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertResetPassword", rp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertResetPassword indicates an expected call of InsertResetPassword.
+func (mr *MockiUserRepoMockRecorder) InsertResetPassword(rp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertResetPassword", reflect.TypeOf((*MockiUserRepo)(nil).InsertResetPassword), rp)
+}
+
+// SetUserEmail mocks base method.
+func (m *MockiUserRepo) SetUserEmail(userID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserEmail", userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserEmail indicates an expected call of SetUserEmail.
+func (mr *MockiUserRepoMockRecorder) SetUserEmail(userID, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserEmail", reflect.TypeOf((*MockiUserRepo)(nil).SetUserEmail), userID, email)
+}
@@ -67,6 +67,21 @@ func (mr *MockiUserDeleteRepoMockRecorder) DeleteUserGroup(userGroupID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserGroup", reflect.TypeOf((*MockiUserDeleteRepo)(nil).DeleteUserGroup), userGroupID)
 }
 
+// GetUserByID mocks base method.
+func (m *MockiUserDeleteRepo) GetUserByID(userID string) (acl.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", userID)
+	ret0, _ := ret[0].(acl.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockiUserDeleteRepoMockRecorder) GetUserByID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockiUserDeleteRepo)(nil).GetUserByID), userID)
+}
+
 // ListUserGroupsByUserID mocks base method.
 func (m *MockiUserDeleteRepo) ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error) {
 	m.ctrl.T.Helper()
@@ -69,6 +69,21 @@ func (mr *MockiUserDataRepoMockRecorder) GetGroupByID(groupID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByID", reflect.TypeOf((*MockiUserDataRepo)(nil).GetGroupByID), groupID)
 }
 
+// ListUserActivities mocks base method.
+func (m *MockiUserDataRepo) ListUserActivities() ([]acl.UserActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserActivities")
+	ret0, _ := ret[0].([]acl.UserActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserActivities indicates an expected call of ListUserActivities.
+func (mr *MockiUserDataRepoMockRecorder) ListUserActivities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserActivities", reflect.TypeOf((*MockiUserDataRepo)(nil).ListUserActivities))
+}
+
 // ListUserGroupsByUserID mocks base method.
 func (m *MockiUserDataRepo) ListUserGroupsByUserID(userID string) ([]acl.UserGroup, error) {
 	m.ctrl.T.Helper()
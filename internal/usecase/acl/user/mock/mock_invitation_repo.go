@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invitation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=invitation.go -destination=mock/mock_invitation_repo.go -package=user_mock
+//
+
+// Package user_mock is a generated GoMock package.
+package user_mock
+
+import (
+	reflect "reflect"
+
+	mail "github.com/jekiapp/topic-master/internal/logic/mail"
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiInvitationStore is a mock of iInvitationStore interface.
+type MockiInvitationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockiInvitationStoreMockRecorder
+}
+
+// MockiInvitationStoreMockRecorder is the mock recorder for MockiInvitationStore.
+type MockiInvitationStoreMockRecorder struct {
+	mock *MockiInvitationStore
+}
+
+// NewMockiInvitationStore creates a new mock instance.
+func NewMockiInvitationStore(ctrl *gomock.Controller) *MockiInvitationStore {
+	mock := &MockiInvitationStore{ctrl: ctrl}
+	mock.recorder = &MockiInvitationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiInvitationStore) EXPECT() *MockiInvitationStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteResetPasswordsByUsername mocks base method.
+func (m *MockiInvitationStore) DeleteResetPasswordsByUsername(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResetPasswordsByUsername", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResetPasswordsByUsername indicates an expected call of DeleteResetPasswordsByUsername.
+func (mr *MockiInvitationStoreMockRecorder) DeleteResetPasswordsByUsername(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResetPasswordsByUsername", reflect.TypeOf((*MockiInvitationStore)(nil).DeleteResetPasswordsByUsername), username)
+}
+
+// InsertResetPassword mocks base method.
+func (m *MockiInvitationStore) InsertResetPassword(rp acl.ResetPassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertResetPassword", rp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertResetPassword indicates an expected call of InsertResetPassword.
+func (mr *MockiInvitationStoreMockRecorder) InsertResetPassword(rp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertResetPassword", reflect.TypeOf((*MockiInvitationStore)(nil).InsertResetPassword), rp)
+}
+
+// MockiInvitationRepo is a mock of iInvitationRepo interface.
+type MockiInvitationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiInvitationRepoMockRecorder
+}
+
+// MockiInvitationRepoMockRecorder is the mock recorder for MockiInvitationRepo.
+type MockiInvitationRepoMockRecorder struct {
+	mock *MockiInvitationRepo
+}
+
+// NewMockiInvitationRepo creates a new mock instance.
+func NewMockiInvitationRepo(ctrl *gomock.Controller) *MockiInvitationRepo {
+	mock := &MockiInvitationRepo{ctrl: ctrl}
+	mock.recorder = &MockiInvitationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiInvitationRepo) EXPECT() *MockiInvitationRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteResetPasswordsByUsername mocks base method.
+func (m *MockiInvitationRepo) DeleteResetPasswordsByUsername(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResetPasswordsByUsername", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResetPasswordsByUsername indicates an expected call of DeleteResetPasswordsByUsername.
+func (mr *MockiInvitationRepoMockRecorder) DeleteResetPasswordsByUsername(username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResetPasswordsByUsername", reflect.TypeOf((*MockiInvitationRepo)(nil).DeleteResetPasswordsByUsername), username)
+}
+
+// GetUserByID mocks base method.
+func (m *MockiInvitationRepo) GetUserByID(userID string) (acl.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", userID)
+	ret0, _ := ret[0].(acl.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockiInvitationRepoMockRecorder) GetUserByID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockiInvitationRepo)(nil).GetUserByID), userID)
+}
+
+// GetUserEmail mocks base method.
+func (m *MockiInvitationRepo) GetUserEmail(userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEmail", userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEmail indicates an expected call of GetUserEmail.
+func (mr *MockiInvitationRepoMockRecorder) GetUserEmail(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEmail", reflect.TypeOf((*MockiInvitationRepo)(nil).GetUserEmail), userID)
+}
+
+// InsertResetPassword mocks base method.
+func (m *MockiInvitationRepo) InsertResetPassword(rp acl.ResetPassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertResetPassword", rp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertResetPassword indicates an expected call of InsertResetPassword.
+func (mr *MockiInvitationRepoMockRecorder) InsertResetPassword(rp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertResetPassword", reflect.TypeOf((*MockiInvitationRepo)(nil).InsertResetPassword), rp)
+}
+
+// MockiMailSender is a mock of iMailSender interface.
+type MockiMailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockiMailSenderMockRecorder
+}
+
+// MockiMailSenderMockRecorder is the mock recorder for MockiMailSender.
+type MockiMailSenderMockRecorder struct {
+	mock *MockiMailSender
+}
+
+// NewMockiMailSender creates a new mock instance.
+func NewMockiMailSender(ctrl *gomock.Controller) *MockiMailSender {
+	mock := &MockiMailSender{ctrl: ctrl}
+	mock.recorder = &MockiMailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiMailSender) EXPECT() *MockiMailSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m_2 *MockiMailSender) Send(m mail.Mail) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Send", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockiMailSenderMockRecorder) Send(m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockiMailSender)(nil).Send), m)
+}
@@ -20,7 +20,6 @@ import (
 type MockISignupRepo struct {
 	ctrl     *gomock.Controller
 	recorder *MockISignupRepoMockRecorder
-	isgomock struct{}
 }
 
 // MockISignupRepoMockRecorder is the mock recorder for MockISignupRepo.
@@ -40,74 +39,18 @@ func (m *MockISignupRepo) EXPECT() *MockISignupRepoMockRecorder {
 	return m.recorder
 }
 
-// CreateApplication mocks base method.
-func (m *MockISignupRepo) CreateApplication(app acl.Application) error {
+// CreateSignup mocks base method.
+func (m *MockISignupRepo) CreateSignup(app acl.Application, assignments []acl.ApplicationAssignment, user acl.UserPending, history acl.ApplicationHistory) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateApplication", app)
+	ret := m.ctrl.Call(m, "CreateSignup", app, assignments, user, history)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateApplication indicates an expected call of CreateApplication.
-func (mr *MockISignupRepoMockRecorder) CreateApplication(app any) *gomock.Call {
+// CreateSignup indicates an expected call of CreateSignup.
+func (mr *MockISignupRepoMockRecorder) CreateSignup(app, assignments, user, history any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplication", reflect.TypeOf((*MockISignupRepo)(nil).CreateApplication), app)
-}
-
-// CreateApplicationAssignment mocks base method.
-func (m *MockISignupRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateApplicationAssignment", assignment)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateApplicationAssignment indicates an expected call of CreateApplicationAssignment.
-func (mr *MockISignupRepoMockRecorder) CreateApplicationAssignment(assignment any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplicationAssignment", reflect.TypeOf((*MockISignupRepo)(nil).CreateApplicationAssignment), assignment)
-}
-
-// CreateApplicationHistory mocks base method.
-func (m *MockISignupRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateApplicationHistory", history)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateApplicationHistory indicates an expected call of CreateApplicationHistory.
-func (mr *MockISignupRepoMockRecorder) CreateApplicationHistory(history any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplicationHistory", reflect.TypeOf((*MockISignupRepo)(nil).CreateApplicationHistory), history)
-}
-
-// CreateUserGroup mocks base method.
-func (m *MockISignupRepo) CreateUserGroup(userGroup acl.UserGroup) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserGroup", userGroup)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateUserGroup indicates an expected call of CreateUserGroup.
-func (mr *MockISignupRepoMockRecorder) CreateUserGroup(userGroup any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserGroup", reflect.TypeOf((*MockISignupRepo)(nil).CreateUserGroup), userGroup)
-}
-
-// CreateUserPending mocks base method.
-func (m *MockISignupRepo) CreateUserPending(user acl.UserPending) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserPending", user)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateUserPending indicates an expected call of CreateUserPending.
-func (mr *MockISignupRepoMockRecorder) CreateUserPending(user any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserPending", reflect.TypeOf((*MockISignupRepo)(nil).CreateUserPending), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSignup", reflect.TypeOf((*MockISignupRepo)(nil).CreateSignup), app, assignments, user, history)
 }
 
 // GetAdminUserIDsByGroupID mocks base method.
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: two_factor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=two_factor.go -destination=mock/mock_two_factor_repo.go -package=user_mock
+//
+
+// Package user_mock is a generated GoMock package.
+package user_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiTwoFactorRepo is a mock of iTwoFactorRepo interface.
+type MockiTwoFactorRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiTwoFactorRepoMockRecorder
+}
+
+// MockiTwoFactorRepoMockRecorder is the mock recorder for MockiTwoFactorRepo.
+type MockiTwoFactorRepoMockRecorder struct {
+	mock *MockiTwoFactorRepo
+}
+
+// NewMockiTwoFactorRepo creates a new mock instance.
+func NewMockiTwoFactorRepo(ctrl *gomock.Controller) *MockiTwoFactorRepo {
+	mock := &MockiTwoFactorRepo{ctrl: ctrl}
+	mock.recorder = &MockiTwoFactorRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiTwoFactorRepo) EXPECT() *MockiTwoFactorRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserTOTP mocks base method.
+func (m *MockiTwoFactorRepo) DeleteUserTOTP(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTOTP", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTOTP indicates an expected call of DeleteUserTOTP.
+func (mr *MockiTwoFactorRepoMockRecorder) DeleteUserTOTP(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTOTP", reflect.TypeOf((*MockiTwoFactorRepo)(nil).DeleteUserTOTP), userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockiTwoFactorRepo) GetUserByID(userID string) (acl.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", userID)
+	ret0, _ := ret[0].(acl.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockiTwoFactorRepoMockRecorder) GetUserByID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockiTwoFactorRepo)(nil).GetUserByID), userID)
+}
+
+// GetUserTOTP mocks base method.
+func (m *MockiTwoFactorRepo) GetUserTOTP(userID string) (acl.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTOTP", userID)
+	ret0, _ := ret[0].(acl.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTOTP indicates an expected call of GetUserTOTP.
+func (mr *MockiTwoFactorRepoMockRecorder) GetUserTOTP(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTOTP", reflect.TypeOf((*MockiTwoFactorRepo)(nil).GetUserTOTP), userID)
+}
+
+// UpsertUserTOTP mocks base method.
+func (m *MockiTwoFactorRepo) UpsertUserTOTP(totp acl.UserTOTP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserTOTP", totp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserTOTP indicates an expected call of UpsertUserTOTP.
+func (mr *MockiTwoFactorRepoMockRecorder) UpsertUserTOTP(totp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserTOTP", reflect.TypeOf((*MockiTwoFactorRepo)(nil).UpsertUserTOTP), totp)
+}
@@ -0,0 +1,168 @@
+//go:generate mockgen -source=security.go -destination=mock/mock_security_repo.go -package=user_mock
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type ListSecurityEventsResponse struct {
+	Events  []acl.SecurityEvent `json:"events"`
+	HasNext bool                `json:"has_next"`
+}
+
+type ListLockoutsResponse struct {
+	Lockouts []acl.LoginLockout `json:"lockouts"`
+}
+
+type UnlockUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type UnlockUserResponse struct {
+	Success bool `json:"success"`
+}
+
+type iSecurityRepo interface {
+	GetUserByID(userID string) (acl.User, error)
+	ListSecurityEvents(page, limit int) ([]acl.SecurityEvent, error)
+	InsertSecurityEvent(event acl.SecurityEvent) error
+	ListLoginLockouts() ([]acl.LoginLockout, error)
+	DeleteLoginLockout(userID string) error
+}
+
+type securityRepo struct {
+	db *buntdb.DB
+}
+
+func (r *securityRepo) GetUserByID(userID string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, userID)
+}
+
+func (r *securityRepo) ListSecurityEvents(page, limit int) ([]acl.SecurityEvent, error) {
+	return userrepo.ListSecurityEvents(r.db, &db.Pagination{Page: page, Limit: limit})
+}
+
+func (r *securityRepo) InsertSecurityEvent(event acl.SecurityEvent) error {
+	return userrepo.InsertSecurityEvent(r.db, event)
+}
+
+func (r *securityRepo) ListLoginLockouts() ([]acl.LoginLockout, error) {
+	return userrepo.ListLoginLockouts(r.db)
+}
+
+func (r *securityRepo) DeleteLoginLockout(userID string) error {
+	return userrepo.DeleteLoginLockout(r.db, userID)
+}
+
+// SecurityUsecase shows root the audit of failed logins and throttled clients, and unlocks accounts.
+type SecurityUsecase struct {
+	repo   iSecurityRepo
+	config *config.Config
+}
+
+func NewSecurityUsecase(db *buntdb.DB, cfg *config.Config) SecurityUsecase {
+	return SecurityUsecase{
+		repo:   &securityRepo{db: db},
+		config: cfg,
+	}
+}
+
+// HandleListEvents returns a page of the audit, newest first. params may contain "page" and "limit"
+func (uc SecurityUsecase) HandleListEvents(ctx context.Context, params map[string]string) (ListSecurityEventsResponse, error) {
+	page, limit := 1, 50
+	if v, ok := params["page"]; ok {
+		fmt.Sscanf(v, "%d", &page)
+	}
+	if v, ok := params["limit"]; ok {
+		fmt.Sscanf(v, "%d", &limit)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	// one extra item tells whether there is a next page
+	events, err := uc.repo.ListSecurityEvents(page, limit+1)
+	if err != nil {
+		return ListSecurityEventsResponse{}, fmt.Errorf("error listing security events: %v", err)
+	}
+	hasNext := false
+	if len(events) > limit {
+		hasNext = true
+		events = events[:limit]
+	}
+	return ListSecurityEventsResponse{Events: events, HasNext: hasNext}, nil
+}
+
+// HandleListLockouts returns the accounts locked right now.
+func (uc SecurityUsecase) HandleListLockouts(ctx context.Context, params map[string]string) (ListLockoutsResponse, error) {
+	lockouts, err := uc.repo.ListLoginLockouts()
+	if err != nil {
+		return ListLockoutsResponse{}, fmt.Errorf("error listing lockouts: %v", err)
+	}
+	now := time.Now()
+	locked := []acl.LoginLockout{}
+	for _, l := range lockouts {
+		if l.LockedFor(now) > 0 {
+			locked = append(locked, l)
+		}
+	}
+	return ListLockoutsResponse{Lockouts: locked}, nil
+}
+
+// HandleUnlock lifts the lock of an account and forgets its failed logins.
+func (uc SecurityUsecase) HandleUnlock(ctx context.Context, req UnlockUserRequest) (UnlockUserResponse, error) {
+	if req.UserID == "" {
+		return UnlockUserResponse{}, errors.New("missing user_id")
+	}
+	admin := util.GetUserInfo(ctx)
+	if admin == nil {
+		return UnlockUserResponse{}, errors.New("user is not authenticated")
+	}
+	user, err := uc.repo.GetUserByID(req.UserID)
+	if err != nil {
+		return UnlockUserResponse{}, errors.New("user not found")
+	}
+	if err := uc.repo.DeleteLoginLockout(user.ID); err != nil {
+		return UnlockUserResponse{}, err
+	}
+	if err := uc.repo.InsertSecurityEvent(acl.SecurityEvent{
+		Event:    acl.SecurityEvent_AccountUnlocked,
+		Username: user.Username,
+		Detail:   "unlocked by " + admin.Username,
+	}); err != nil {
+		log.Printf("failed to audit unlock of %s: %v", user.Username, err)
+	}
+	return UnlockUserResponse{Success: true}, nil
+}
+
+// AuditRateLimited records a client starting a rate limit block, it is the OnBlock of the limiters.
+func (uc SecurityUsecase) AuditRateLimited(r *http.Request, key string, block time.Duration) {
+	event := acl.SecurityEvent{
+		Event:  acl.SecurityEvent_RateLimited,
+		IP:     util.ClientIP(r, uc.config.TrustForwardedFor),
+		Detail: fmt.Sprintf("%s blocked for %s on %s", key, block, r.URL.Path),
+	}
+	// keys look like <route>:username:<name>
+	if i := strings.Index(key, ":username:"); i >= 0 {
+		event.Username = key[i+len(":username:"):]
+	}
+	if err := uc.repo.InsertSecurityEvent(event); err != nil {
+		log.Printf("failed to audit rate limit of %s: %v", key, err)
+	}
+}
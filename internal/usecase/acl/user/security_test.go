@@ -0,0 +1,116 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	user_mock "github.com/jekiapp/topic-master/internal/usecase/acl/user/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSecurityUsecase_HandleUnlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	root := &acl.User{ID: "r1", Username: "root", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}}
+	ctx := util.MockContextWithUser(context.Background(), root)
+
+	tests := []struct {
+		name      string
+		req       UnlockUserRequest
+		setupMock func(m *user_mock.MockiSecurityRepo)
+		wantErr   string
+	}{
+		{
+			name:      "missing user",
+			req:       UnlockUserRequest{},
+			setupMock: func(m *user_mock.MockiSecurityRepo) {},
+			wantErr:   "missing user_id",
+		},
+		{
+			name: "unknown user",
+			req:  UnlockUserRequest{UserID: "u9"},
+			setupMock: func(m *user_mock.MockiSecurityRepo) {
+				m.EXPECT().GetUserByID("u9").Return(acl.User{}, errors.New("not found"))
+			},
+			wantErr: "user not found",
+		},
+		{
+			name: "unlock is audited",
+			req:  UnlockUserRequest{UserID: "u1"},
+			setupMock: func(m *user_mock.MockiSecurityRepo) {
+				m.EXPECT().GetUserByID("u1").Return(acl.User{ID: "u1", Username: "alice"}, nil)
+				m.EXPECT().DeleteLoginLockout("u1").Return(nil)
+				m.EXPECT().InsertSecurityEvent(gomock.Any()).DoAndReturn(func(e acl.SecurityEvent) error {
+					assert.Equal(t, acl.SecurityEvent_AccountUnlocked, e.Event)
+					assert.Equal(t, "alice", e.Username)
+					assert.Equal(t, "unlocked by root", e.Detail)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := user_mock.NewMockiSecurityRepo(ctrl)
+			tt.setupMock(m)
+			uc := SecurityUsecase{repo: m, config: &config.Config{}}
+			resp, err := uc.HandleUnlock(ctx, tt.req)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, resp.Success)
+		})
+	}
+}
+
+func TestSecurityUsecase_Lists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := user_mock.NewMockiSecurityRepo(ctrl)
+	uc := SecurityUsecase{repo: m, config: &config.Config{}}
+	now := time.Now().Unix()
+
+	m.EXPECT().ListLoginLockouts().Return([]acl.LoginLockout{
+		{UserID: "u1", Username: "alice", LockedUntil: now + 600},
+		{UserID: "u2", Username: "bob", Failures: 2},
+		{UserID: "u3", Username: "carol", LockedUntil: now - 600},
+	}, nil)
+	lockouts, err := uc.HandleListLockouts(context.Background(), nil)
+	assert.NoError(t, err)
+	if assert.Len(t, lockouts.Lockouts, 1) {
+		assert.Equal(t, "alice", lockouts.Lockouts[0].Username)
+	}
+
+	m.EXPECT().ListSecurityEvents(2, 3).Return([]acl.SecurityEvent{{ID: "e1"}, {ID: "e2"}, {ID: "e3"}}, nil)
+	events, err := uc.HandleListEvents(context.Background(), map[string]string{"page": "2", "limit": "2"})
+	assert.NoError(t, err)
+	assert.Len(t, events.Events, 2)
+	assert.True(t, events.HasNext)
+}
+
+func TestSecurityUsecase_AuditRateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := user_mock.NewMockiSecurityRepo(ctrl)
+	uc := SecurityUsecase{repo: m, config: &config.Config{}}
+	m.EXPECT().InsertSecurityEvent(gomock.Any()).DoAndReturn(func(e acl.SecurityEvent) error {
+		assert.Equal(t, acl.SecurityEvent_RateLimited, e.Event)
+		assert.Equal(t, "alice", e.Username)
+		assert.Equal(t, "192.0.2.1", e.IP)
+		assert.Contains(t, e.Detail, "/api/login")
+		return nil
+	})
+	uc.AuditRateLimited(httptest.NewRequest("POST", "/api/login", nil), "login:username:alice", time.Minute)
+}
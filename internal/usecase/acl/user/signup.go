@@ -11,7 +11,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/logic/notification"
 	"github.com/jekiapp/topic-master/internal/model/acl"
+	notifmodel "github.com/jekiapp/topic-master/internal/model/notification"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
 	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
 	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/tidwall/buntdb"
@@ -103,10 +106,6 @@ func (uc SignupUsecase) Handle(ctx context.Context, req SignupRequest) (SignupRe
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
-	if err := uc.repo.CreateApplication(*app); err != nil {
-		return SignupResponse{}, err
-	}
-
 	rootGroup, err := uc.repo.GetGroupByName(acl.GroupRoot)
 	if err != nil {
 		return SignupResponse{}, errors.New("root group not found")
@@ -134,6 +133,7 @@ func (uc SignupUsecase) Handle(ctx context.Context, req SignupRequest) (SignupRe
 	}
 
 	hasActiveReviewer := false
+	var reviewerIDs []string
 	for _, userID := range adminUserIDs {
 		user, err := uc.repo.GetUserByID(userID)
 		if err != nil {
@@ -145,18 +145,7 @@ func (uc SignupUsecase) Handle(ctx context.Context, req SignupRequest) (SignupRe
 		}
 
 		hasActiveReviewer = true
-		assignment := &acl.ApplicationAssignment{
-			ID:            uuid.NewString(),
-			ApplicationID: app.ID,
-			ReviewerID:    userID,
-			ReviewStatus:  acl.ActionWaitingForApproval,
-			CreatedAt:     time.Now(),
-			UpdatedAt:     time.Now(),
-		}
-
-		if err := uc.repo.CreateApplicationAssignment(*assignment); err != nil {
-			return SignupResponse{}, fmt.Errorf("failed to create application assignment: %w", err)
-		}
+		reviewerIDs = append(reviewerIDs, userID)
 	}
 
 	if !hasActiveReviewer {
@@ -183,10 +172,18 @@ func (uc SignupUsecase) Handle(ctx context.Context, req SignupRequest) (SignupRe
 			UpdatedAt: time.Now(),
 		},
 	}
-	if err := uc.repo.CreateUserPending(user); err != nil {
-		return SignupResponse{}, fmt.Errorf("failed to create user pending: %w", err)
-	}
 
+	var assignments []acl.ApplicationAssignment
+	for _, reviewerID := range reviewerIDs {
+		assignments = append(assignments, acl.ApplicationAssignment{
+			ID:            uuid.NewString(),
+			ApplicationID: app.ID,
+			ReviewerID:    reviewerID,
+			ReviewStatus:  acl.ActionWaitingForApproval,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		})
+	}
 	history := &acl.ApplicationHistory{
 		ID:            uuid.NewString(),
 		ApplicationID: app.ID,
@@ -196,32 +193,28 @@ func (uc SignupUsecase) Handle(ctx context.Context, req SignupRequest) (SignupRe
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
-	_ = uc.repo.CreateApplicationHistory(*history)
+	if err := uc.repo.CreateSignup(*app, assignments, user, *history); err != nil {
+		return SignupResponse{}, err
+	}
+	// the applicant cannot log in yet, only the reviewers are notified
+	notification.Publish(notification.TicketEvent(notifmodel.EventTicketAssigned, *app, reviewerIDs...))
 	return SignupResponse{ApplicationID: app.ID}, nil
 }
 
 // --- ISignupRepo interface and implementation ---
 type ISignupRepo interface {
-	CreateApplication(app acl.Application) error
 	GetGroupByName(name string) (acl.Group, error)
 	ListUserGroupsByGroupID(groupID string, limit int) ([]acl.UserGroup, error)
 	GetAdminUserIDsByGroupID(groupID string) ([]string, error)
-	CreateApplicationAssignment(assignment acl.ApplicationAssignment) error
-	CreateApplicationHistory(history acl.ApplicationHistory) error
 	GetUserByID(userID string) (acl.User, error)
-	CreateUserPending(user acl.UserPending) error
-	CreateUserGroup(userGroup acl.UserGroup) error
 	GetUserByUsername(username string) (acl.User, error)
+	CreateSignup(app acl.Application, assignments []acl.ApplicationAssignment, user acl.UserPending, history acl.ApplicationHistory) error
 }
 
 type signupRepo struct {
 	db *buntdb.DB
 }
 
-func (r *signupRepo) CreateApplication(app acl.Application) error {
-	return db.Insert(r.db, &app)
-}
-
 func (r *signupRepo) GetGroupByName(name string) (acl.Group, error) {
 	return userrepo.GetGroupByName(r.db, name)
 }
@@ -234,26 +227,31 @@ func (r *signupRepo) GetAdminUserIDsByGroupID(groupID string) ([]string, error)
 	return userrepo.GetAdminUserIDsByGroupID(r.db, groupID)
 }
 
-func (r *signupRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
-	return db.Insert(r.db, &assignment)
-}
-
-func (r *signupRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
-	return db.Insert(r.db, &history)
-}
-
 func (r *signupRepo) GetUserByID(userID string) (acl.User, error) {
 	return db.GetByID[acl.User](r.db, userID)
 }
 
-func (r *signupRepo) CreateUserPending(user acl.UserPending) error {
-	return db.Insert(r.db, &user)
-}
-
-func (r *signupRepo) CreateUserGroup(userGroup acl.UserGroup) error {
-	return db.Insert(r.db, &userGroup)
-}
-
 func (r *signupRepo) GetUserByUsername(username string) (acl.User, error) {
 	return db.SelectOne[acl.User](r.db, username, acl.IdxUser_Username)
 }
+
+// CreateSignup stores the pending user along with the application reviewing it, in one transaction
+func (r *signupRepo) CreateSignup(app acl.Application, assignments []acl.ApplicationAssignment, user acl.UserPending, history acl.ApplicationHistory) error {
+	return db.WithTx(r.db, func(tx *buntdb.Tx) error {
+		if err := apprepo.CreateApplicationTx(tx, app); err != nil {
+			return err
+		}
+		for _, assignment := range assignments {
+			if err := apprepo.CreateApplicationAssignmentTx(tx, assignment); err != nil {
+				return fmt.Errorf("failed to create application assignment: %w", err)
+			}
+		}
+		if err := db.InsertTx(tx, &user); err != nil {
+			return fmt.Errorf("failed to create user pending: %w", err)
+		}
+		if err := apprepo.CreateApplicationHistoryTx(tx, history); err != nil {
+			log.Println("failed to create application history", err)
+		}
+		return nil
+	})
+}
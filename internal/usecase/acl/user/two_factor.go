@@ -0,0 +1,252 @@
+//go:generate mockgen -source=two_factor.go -destination=mock/mock_two_factor_repo.go -package=user_mock
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type TwoFactorStatusResponse struct {
+	Enabled           bool `json:"enabled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type TwoFactorRecoveryResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorResetRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type TwoFactorResponse struct {
+	Success bool `json:"success"`
+}
+
+type iTwoFactorRepo interface {
+	GetUserByID(userID string) (acl.User, error)
+	GetUserTOTP(userID string) (acl.UserTOTP, error)
+	UpsertUserTOTP(totp acl.UserTOTP) error
+	DeleteUserTOTP(userID string) error
+}
+
+type twoFactorRepo struct {
+	db *buntdb.DB
+}
+
+func (r *twoFactorRepo) GetUserByID(userID string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, userID)
+}
+
+func (r *twoFactorRepo) GetUserTOTP(userID string) (acl.UserTOTP, error) {
+	return db.GetByID[acl.UserTOTP](r.db, userID)
+}
+
+func (r *twoFactorRepo) UpsertUserTOTP(totp acl.UserTOTP) error {
+	return db.Upsert(r.db, &totp)
+}
+
+func (r *twoFactorRepo) DeleteUserTOTP(userID string) error {
+	return db.DeleteByID[acl.UserTOTP](r.db, userID)
+}
+
+// TwoFactorUsecase lets a user manage the authenticator used as second login step.
+type TwoFactorUsecase struct {
+	repo   iTwoFactorRepo
+	config *config.Config
+}
+
+func NewTwoFactorUsecase(db *buntdb.DB, cfg *config.Config) TwoFactorUsecase {
+	return TwoFactorUsecase{
+		repo:   &twoFactorRepo{db: db},
+		config: cfg,
+	}
+}
+
+const recoveryCodeCount = 10
+
+func (uc TwoFactorUsecase) HandleStatus(ctx context.Context, params map[string]string) (TwoFactorStatusResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TwoFactorStatusResponse{}, errors.New("user is not authenticated")
+	}
+	totp, err := uc.getTOTP(user.ID)
+	if err != nil {
+		return TwoFactorStatusResponse{}, err
+	}
+	resp := TwoFactorStatusResponse{
+		Enabled:  totp.Enabled,
+		Required: auth.RequiresTwoFactor(uc.config.Require2FA, user.Groups),
+	}
+	if totp.Enabled {
+		resp.RecoveryCodesLeft = len(totp.RecoveryCodes)
+	}
+	return resp, nil
+}
+
+// HandleSetup creates a new secret to scan, it is only used once confirmed by HandleEnable.
+func (uc TwoFactorUsecase) HandleSetup(ctx context.Context, req struct{}) (TwoFactorSetupResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TwoFactorSetupResponse{}, errors.New("user is not authenticated")
+	}
+	totp, err := uc.getTOTP(user.ID)
+	if err != nil {
+		return TwoFactorSetupResponse{}, err
+	}
+	if totp.Enabled {
+		return TwoFactorSetupResponse{}, errors.New("two-factor authentication is already enabled, disable it first")
+	}
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return TwoFactorSetupResponse{}, errors.New("failed to generate secret")
+	}
+	totp = acl.UserTOTP{UserID: user.ID, Secret: secret, CreatedAt: time.Now().Unix()}
+	if err := uc.repo.UpsertUserTOTP(totp); err != nil {
+		return TwoFactorSetupResponse{}, err
+	}
+	return TwoFactorSetupResponse{Secret: secret, URI: auth.TOTPProvisioningURI(user.Username, secret)}, nil
+}
+
+// HandleEnable confirms the secret of HandleSetup with a first code and returns the recovery codes.
+func (uc TwoFactorUsecase) HandleEnable(ctx context.Context, req TwoFactorCodeRequest) (TwoFactorRecoveryResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TwoFactorRecoveryResponse{}, errors.New("user is not authenticated")
+	}
+	totp, err := uc.getTOTP(user.ID)
+	if err != nil {
+		return TwoFactorRecoveryResponse{}, err
+	}
+	if totp.Secret == "" {
+		return TwoFactorRecoveryResponse{}, errors.New("two-factor authentication is not set up")
+	}
+	if totp.Enabled {
+		return TwoFactorRecoveryResponse{}, errors.New("two-factor authentication is already enabled")
+	}
+	if !verifyCode(&totp, req.Code, false) {
+		return TwoFactorRecoveryResponse{}, errors.New("invalid code")
+	}
+	codes, hashes, err := auth.NewRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return TwoFactorRecoveryResponse{}, errors.New("failed to generate recovery codes")
+	}
+	totp.Enabled = true
+	totp.EnabledAt = time.Now().Unix()
+	totp.RecoveryCodes = hashes
+	if err := uc.repo.UpsertUserTOTP(totp); err != nil {
+		return TwoFactorRecoveryResponse{}, err
+	}
+	return TwoFactorRecoveryResponse{RecoveryCodes: codes}, nil
+}
+
+// HandleDisable turns 2FA off with a current code, unless the policy requires it for the user.
+func (uc TwoFactorUsecase) HandleDisable(ctx context.Context, req TwoFactorCodeRequest) (TwoFactorResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TwoFactorResponse{}, errors.New("user is not authenticated")
+	}
+	if auth.RequiresTwoFactor(uc.config.Require2FA, user.Groups) {
+		return TwoFactorResponse{}, errors.New("two-factor authentication is required for your account")
+	}
+	totp, err := uc.getTOTP(user.ID)
+	if err != nil {
+		return TwoFactorResponse{}, err
+	}
+	if !totp.Enabled {
+		return TwoFactorResponse{}, errors.New("two-factor authentication is not enabled")
+	}
+	if !verifyCode(&totp, req.Code, true) {
+		return TwoFactorResponse{}, errors.New("invalid code")
+	}
+	if err := uc.repo.DeleteUserTOTP(user.ID); err != nil {
+		return TwoFactorResponse{}, err
+	}
+	return TwoFactorResponse{Success: true}, nil
+}
+
+// HandleRegenerateRecovery replaces the recovery codes, the previous ones stop working.
+func (uc TwoFactorUsecase) HandleRegenerateRecovery(ctx context.Context, req TwoFactorCodeRequest) (TwoFactorRecoveryResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TwoFactorRecoveryResponse{}, errors.New("user is not authenticated")
+	}
+	totp, err := uc.getTOTP(user.ID)
+	if err != nil {
+		return TwoFactorRecoveryResponse{}, err
+	}
+	if !totp.Enabled {
+		return TwoFactorRecoveryResponse{}, errors.New("two-factor authentication is not enabled")
+	}
+	if !verifyCode(&totp, req.Code, false) {
+		return TwoFactorRecoveryResponse{}, errors.New("invalid code")
+	}
+	codes, hashes, err := auth.NewRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return TwoFactorRecoveryResponse{}, errors.New("failed to generate recovery codes")
+	}
+	totp.RecoveryCodes = hashes
+	if err := uc.repo.UpsertUserTOTP(totp); err != nil {
+		return TwoFactorRecoveryResponse{}, err
+	}
+	return TwoFactorRecoveryResponse{RecoveryCodes: codes}, nil
+}
+
+// HandleReset removes the authenticator of a user who lost it, the route is for root only.
+// A user the policy requires 2FA for enrols again on the next login.
+func (uc TwoFactorUsecase) HandleReset(ctx context.Context, req TwoFactorResetRequest) (TwoFactorResponse, error) {
+	if req.UserID == "" {
+		return TwoFactorResponse{}, errors.New("missing user_id")
+	}
+	if _, err := uc.repo.GetUserByID(req.UserID); err != nil {
+		return TwoFactorResponse{}, errors.New("user not found")
+	}
+	if _, err := uc.repo.GetUserTOTP(req.UserID); err != nil {
+		if err == db.ErrNotFound {
+			return TwoFactorResponse{}, errors.New("two-factor authentication is not set up for this user")
+		}
+		return TwoFactorResponse{}, err
+	}
+	if err := uc.repo.DeleteUserTOTP(req.UserID); err != nil {
+		return TwoFactorResponse{}, err
+	}
+	return TwoFactorResponse{Success: true}, nil
+}
+
+// getTOTP returns an empty enrolment when the user never set up 2FA.
+func (uc TwoFactorUsecase) getTOTP(userID string) (acl.UserTOTP, error) {
+	totp, err := uc.repo.GetUserTOTP(userID)
+	if err == db.ErrNotFound {
+		return acl.UserTOTP{UserID: userID}, nil
+	}
+	return totp, err
+}
+
+// verifyCode checks an authenticator code, or a recovery code when allowed, and consumes it.
+// The caller saves the enrolment.
+func verifyCode(totp *acl.UserTOTP, code string, allowRecovery bool) bool {
+	if step, ok := auth.VerifyTOTP(totp.Secret, code, time.Now(), totp.LastUsedStep); ok {
+		totp.LastUsedStep = step
+		return true
+	}
+	return allowRecovery && auth.UseRecoveryCode(totp, code)
+}
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	user_mock "github.com/jekiapp/topic-master/internal/usecase/acl/user/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTwoFactorUsecase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret, _ := auth.NewTOTPSecret()
+	code, _ := auth.TOTPCode(secret, time.Now().Unix()/30)
+	alice := &acl.User{ID: "u1", Username: "alice", Groups: []acl.GroupRole{{GroupName: "payments", Role: acl.RoleGroupAdmin}}}
+	ctx := util.MockContextWithUser(context.Background(), alice)
+
+	t.Run("setup then enable", func(t *testing.T) {
+		m := user_mock.NewMockiTwoFactorRepo(ctrl)
+		uc := TwoFactorUsecase{repo: m, config: &config.Config{}}
+
+		var stored acl.UserTOTP
+		m.EXPECT().GetUserTOTP("u1").Return(acl.UserTOTP{}, db.ErrNotFound)
+		m.EXPECT().UpsertUserTOTP(gomock.Any()).DoAndReturn(func(totp acl.UserTOTP) error {
+			stored = totp
+			return nil
+		})
+		setup, err := uc.HandleSetup(ctx, struct{}{})
+		assert.NoError(t, err)
+		assert.Equal(t, stored.Secret, setup.Secret)
+		assert.Contains(t, setup.URI, "Topic%20Master:alice")
+		assert.False(t, stored.Enabled)
+
+		enrolCode, _ := auth.TOTPCode(stored.Secret, time.Now().Unix()/30)
+		m.EXPECT().GetUserTOTP("u1").Return(stored, nil)
+		m.EXPECT().UpsertUserTOTP(gomock.Any()).DoAndReturn(func(totp acl.UserTOTP) error {
+			stored = totp
+			return nil
+		})
+		resp, err := uc.HandleEnable(ctx, TwoFactorCodeRequest{Code: enrolCode})
+		assert.NoError(t, err)
+		assert.Len(t, resp.RecoveryCodes, recoveryCodeCount)
+		assert.True(t, stored.Enabled)
+	})
+
+	tests := []struct {
+		name      string
+		policy    string
+		call      func(uc TwoFactorUsecase) (interface{}, error)
+		setupMock func(m *user_mock.MockiTwoFactorRepo)
+		wantErr   string
+	}{
+		{
+			name: "status reports the policy",
+			setupMock: func(m *user_mock.MockiTwoFactorRepo) {
+				m.EXPECT().GetUserTOTP("u1").Return(acl.UserTOTP{Enabled: true, RecoveryCodes: []string{"h1", "h2"}}, nil)
+			},
+			policy: config.Require2FAAdmins,
+			call: func(uc TwoFactorUsecase) (interface{}, error) {
+				resp, err := uc.HandleStatus(ctx, nil)
+				assert.Equal(t, TwoFactorStatusResponse{Enabled: true, Required: true, RecoveryCodesLeft: 2}, resp)
+				return resp, err
+			},
+		},
+		{
+			name: "setup refused while enabled",
+			setupMock: func(m *user_mock.MockiTwoFactorRepo) {
+				m.EXPECT().GetUserTOTP("u1").Return(acl.UserTOTP{Secret: secret, Enabled: true}, nil)
+			},
+			call:    func(uc TwoFactorUsecase) (interface{}, error) { return uc.HandleSetup(ctx, struct{}{}) },
+			wantErr: "already enabled",
+		},
+		{
+			name: "enable with a wrong code",
+			setupMock: func(m *user_mock.MockiTwoFactorRepo) {
+				m.EXPECT().GetUserTOTP("u1").Return(acl.UserTOTP{Secret: secret}, nil)
+			},
+			call: func(uc TwoFactorUsecase) (interface{}, error) {
+				return uc.HandleEnable(ctx, TwoFactorCodeRequest{Code: "000000"})
+			},
+			wantErr: "invalid code",
+		},
+		{
+			name:   "disable refused when required",
+			policy: config.Require2FAAdmins,
+			call: func(uc TwoFactorUsecase) (interface{}, error) {
+				return uc.HandleDisable(ctx, TwoFactorCodeRequest{Code: code})
+			},
+			wantErr: "required",
+		},
+		{
+			name:   "disable with a code",
+			policy: config.Require2FARoot,
+			setupMock: func(m *user_mock.MockiTwoFactorRepo) {
+				m.EXPECT().GetUserTOTP("u1").Return(acl.UserTOTP{Secret: secret, Enabled: true}, nil)
+				m.EXPECT().DeleteUserTOTP("u1").Return(nil)
+			},
+			call: func(uc TwoFactorUsecase) (interface{}, error) {
+				return uc.HandleDisable(ctx, TwoFactorCodeRequest{Code: code})
+			},
+		},
+		{
+			name: "regenerate recovery codes",
+			setupMock: func(m *user_mock.MockiTwoFactorRepo) {
+				m.EXPECT().GetUserTOTP("u1").Return(acl.UserTOTP{Secret: secret, Enabled: true, RecoveryCodes: []string{"old"}}, nil)
+				m.EXPECT().UpsertUserTOTP(gomock.Any()).DoAndReturn(func(totp acl.UserTOTP) error {
+					assert.Len(t, totp.RecoveryCodes, recoveryCodeCount)
+					assert.NotContains(t, totp.RecoveryCodes, "old")
+					return nil
+				})
+			},
+			call: func(uc TwoFactorUsecase) (interface{}, error) {
+				return uc.HandleRegenerateRecovery(ctx, TwoFactorCodeRequest{Code: code})
+			},
+		},
+		{
+			name: "reset by root",
+			setupMock: func(m *user_mock.MockiTwoFactorRepo) {
+				m.EXPECT().GetUserByID("u2").Return(acl.User{ID: "u2"}, nil)
+				m.EXPECT().GetUserTOTP("u2").Return(acl.UserTOTP{Enabled: true}, nil)
+				m.EXPECT().DeleteUserTOTP("u2").Return(nil)
+			},
+			call: func(uc TwoFactorUsecase) (interface{}, error) {
+				return uc.HandleReset(ctx, TwoFactorResetRequest{UserID: "u2"})
+			},
+		},
+		{
+			name: "reset of a user without 2FA",
+			setupMock: func(m *user_mock.MockiTwoFactorRepo) {
+				m.EXPECT().GetUserByID("u2").Return(acl.User{ID: "u2"}, nil)
+				m.EXPECT().GetUserTOTP("u2").Return(acl.UserTOTP{}, db.ErrNotFound)
+			},
+			call: func(uc TwoFactorUsecase) (interface{}, error) {
+				return uc.HandleReset(ctx, TwoFactorResetRequest{UserID: "u2"})
+			},
+			wantErr: "not set up",
+		},
+		{
+			name: "reset of an unknown user",
+			setupMock: func(m *user_mock.MockiTwoFactorRepo) {
+				m.EXPECT().GetUserByID("u3").Return(acl.User{}, errors.New("not found"))
+			},
+			call: func(uc TwoFactorUsecase) (interface{}, error) {
+				return uc.HandleReset(ctx, TwoFactorResetRequest{UserID: "u3"})
+			},
+			wantErr: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := user_mock.NewMockiTwoFactorRepo(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(m)
+			}
+			uc := TwoFactorUsecase{repo: m, config: &config.Config{Require2FA: tt.policy}}
+			_, err := tt.call(uc)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
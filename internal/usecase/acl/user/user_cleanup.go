@@ -0,0 +1,398 @@
+//go:generate mockgen -source=user_cleanup.go -destination=mock/mock_user_cleanup_repo.go -package=user_mock
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	notifrepo "github.com/jekiapp/topic-master/internal/repository/notification"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+type iUserCleanupRepo interface {
+	GetUserByID(userID string) (acl.User, error)
+	ListPermissionMapsByUser(userID string) ([]acl.PermissionMap, error)
+	DeletePermissionMapByID(id string) error
+	CreateGrantRevocation(revocation acl.GrantRevocation) error
+	ListApplicationsByUserID(userID string) ([]acl.Application, error)
+	GetApplicationByID(id string) (acl.Application, error)
+	UpdateApplication(app acl.Application) error
+	ListAssignmentsByReviewerID(reviewerID string) ([]acl.ApplicationAssignment, error)
+	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
+	CreateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	CreateApplicationHistory(history acl.ApplicationHistory) error
+	GetRootMemberIDs() ([]string, error)
+	DeleteBookmarksByUser(userID string) error
+	DeleteUserData(userID, username string) error
+	ListReferencedUserIDs() ([]string, error)
+}
+
+type userCleanupRepo struct {
+	db *buntdb.DB
+}
+
+func (r *userCleanupRepo) GetUserByID(userID string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, userID)
+}
+
+func (r *userCleanupRepo) ListPermissionMapsByUser(userID string) ([]acl.PermissionMap, error) {
+	return entityrepo.ListPermissionMapsByUser(r.db, userID)
+}
+
+func (r *userCleanupRepo) DeletePermissionMapByID(id string) error {
+	return entityrepo.DeletePermissionMapByID(r.db, id)
+}
+
+func (r *userCleanupRepo) CreateGrantRevocation(revocation acl.GrantRevocation) error {
+	return entityrepo.CreateGrantRevocation(r.db, revocation)
+}
+
+func (r *userCleanupRepo) ListApplicationsByUserID(userID string) ([]acl.Application, error) {
+	return apprepo.ListApplicationsByUserID(r.db, userID)
+}
+
+func (r *userCleanupRepo) GetApplicationByID(id string) (acl.Application, error) {
+	return db.GetByID[acl.Application](r.db, id)
+}
+
+func (r *userCleanupRepo) UpdateApplication(app acl.Application) error {
+	return db.Update(r.db, &app)
+}
+
+func (r *userCleanupRepo) ListAssignmentsByReviewerID(reviewerID string) ([]acl.ApplicationAssignment, error) {
+	return apprepo.ListAssignmentsByReviewerID(r.db, reviewerID)
+}
+
+func (r *userCleanupRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	assignments, err := db.SelectAll[acl.ApplicationAssignment](r.db, "="+appID, acl.IdxAppAssign_ApplicationID)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.ApplicationAssignment{}, nil
+	}
+	return assignments, err
+}
+
+func (r *userCleanupRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return apprepo.CreateApplicationAssignment(r.db, assignment)
+}
+
+func (r *userCleanupRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return db.Update(r.db, &assignment)
+}
+
+func (r *userCleanupRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return apprepo.CreateApplicationHistory(r.db, history)
+}
+
+func (r *userCleanupRepo) GetRootMemberIDs() ([]string, error) {
+	rootGroup, err := userrepo.GetGroupByName(r.db, acl.GroupRoot)
+	if err != nil {
+		return nil, fmt.Errorf("root group not found: %v", err)
+	}
+	members, err := userrepo.ListUserGroupsByGroupID(r.db, rootGroup.ID, 0)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+	var ids []string
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	return ids, nil
+}
+
+func (r *userCleanupRepo) DeleteBookmarksByUser(userID string) error {
+	return entityrepo.DeleteBookmarksByUser(r.db, userID)
+}
+
+// DeleteUserData removes the records only meaningful to the user: their delegation and the ones
+// naming them as delegate, the authenticator, failed logins, reset links, activity and notification preference.
+func (r *userCleanupRepo) DeleteUserData(userID, username string) error {
+	if _, err := userrepo.GetDelegationByUserID(r.db, userID); err == nil {
+		if err := userrepo.DeleteDelegation(r.db, userID); err != nil {
+			return err
+		}
+	}
+	delegations, err := userrepo.ListDelegationsByDelegateID(r.db, userID)
+	if err != nil {
+		return err
+	}
+	for _, delegation := range delegations {
+		if err := userrepo.DeleteDelegation(r.db, delegation.UserID); err != nil {
+			return err
+		}
+	}
+	if _, err := db.GetByID[acl.UserTOTP](r.db, userID); err == nil {
+		if err := db.DeleteByID[acl.UserTOTP](r.db, userID); err != nil {
+			return err
+		}
+	}
+	if err := userrepo.DeleteLoginLockout(r.db, userID); err != nil {
+		return err
+	}
+	if username != "" {
+		if err := userrepo.DeleteResetPasswordsByUsername(r.db, username); err != nil {
+			return err
+		}
+	}
+	if err := userrepo.DeleteUserActivity(r.db, userID); err != nil {
+		return err
+	}
+	return notifrepo.DeletePreference(r.db, userID)
+}
+
+// ListReferencedUserIDs returns the users holding a grant, a bookmark, an open application
+// or a review waiting on them.
+func (r *userCleanupRepo) ListReferencedUserIDs() ([]string, error) {
+	seen := map[string]bool{}
+	var ids []string
+	add := func(id string) {
+		if id != "" && id != acl.ActorSystem && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	perms, err := entityrepo.ListPermissionMaps(r.db)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+	for _, perm := range perms {
+		add(perm.UserID)
+	}
+	bookmarks, err := entityrepo.ListAllBookmarks(r.db)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bookmarks {
+		add(b.UserID)
+	}
+	apps, err := apprepo.ListAllApplications(r.db)
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range apps {
+		// signup applicants are pending users, they are not in the user table yet
+		if app.IsClosed() || app.Type == acl.ApplicationType_Signup {
+			continue
+		}
+		add(app.UserID)
+		assignments, err := r.ListAssignmentsByApplicationID(app.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, assignment := range assignments {
+			if assignment.ReviewStatus == acl.ReviewStatusWaiting {
+				add(assignment.ReviewerID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// userCleanup removes or hands over what a user leaves behind when they are deleted,
+// and hands over their reviews when they are deactivated.
+type userCleanup struct {
+	repo iUserCleanupRepo
+}
+
+func newUserCleanup(db *buntdb.DB) userCleanup {
+	return userCleanup{
+		repo: &userCleanupRepo{db: db},
+	}
+}
+
+// purge revokes the grants of a deleted user, withdraws their open applications, hands over
+// the reviews waiting on them and deletes their bookmarks and personal records.
+func (c userCleanup) purge(userID, username, actorID string, now time.Time) error {
+	if username == "" {
+		username = userID
+	}
+	perms, err := c.repo.ListPermissionMapsByUser(userID)
+	if err != nil {
+		return err
+	}
+	for _, perm := range perms {
+		if err := c.repo.DeletePermissionMapByID(perm.ID); err != nil {
+			return err
+		}
+		revocation := acl.GrantRevocation{
+			ID:              uuid.NewString(),
+			PermissionMapID: perm.ID,
+			Action:          perm.Action,
+			UserID:          perm.UserID,
+			EntityID:        perm.EntityID,
+			ApplicationID:   perm.ApplicationID,
+			GrantedAt:       perm.CreatedAt,
+			RevokedBy:       actorID,
+			Reason:          "user deleted",
+			RevokedAt:       now,
+		}
+		if err := c.repo.CreateGrantRevocation(revocation); err != nil {
+			log.Printf("failed to record the revocation of grant %s: %v", perm.ID, err)
+		}
+	}
+
+	apps, err := c.repo.ListApplicationsByUserID(userID)
+	if err != nil {
+		return err
+	}
+	for _, app := range apps {
+		if app.IsClosed() || app.Type == acl.ApplicationType_Signup {
+			continue
+		}
+		if err := c.withdraw(app, username, actorID, now); err != nil {
+			return err
+		}
+	}
+
+	if err := c.reassignReviews(userID, username, actorID, now); err != nil {
+		return err
+	}
+	if err := c.repo.DeleteBookmarksByUser(userID); err != nil {
+		return err
+	}
+	return c.repo.DeleteUserData(userID, username)
+}
+
+// withdraw closes an open application whose applicant is gone
+func (c userCleanup) withdraw(app acl.Application, username, actorID string, now time.Time) error {
+	assignments, err := c.repo.ListAssignmentsByApplicationID(app.ID)
+	if err != nil {
+		return err
+	}
+	app.Status = acl.StatusWithdrawn
+	app.UpdatedAt = now
+	if err := c.repo.UpdateApplication(app); err != nil {
+		return err
+	}
+	for _, assignment := range assignments {
+		if assignment.ReviewStatus != acl.ReviewStatusWaiting {
+			continue
+		}
+		assignment.ReviewStatus = acl.ReviewStatusPassed
+		assignment.UpdatedAt = now
+		if err := c.repo.UpdateApplicationAssignment(assignment); err != nil {
+			log.Println("[USER CLEANUP] error updating assignment", assignment.ID, err)
+		}
+	}
+	c.addHistory(app.ID, acl.ActionWithdraw, actorID, fmt.Sprintf("applicant %s was deleted", username), now)
+	return nil
+}
+
+// reassignReviews passes the reviews waiting on the user. A stage left without any
+// waiting reviewer is handed to the root group members, as an SLA escalation does.
+func (c userCleanup) reassignReviews(userID, username, actorID string, now time.Time) error {
+	waiting, err := c.repo.ListAssignmentsByReviewerID(userID)
+	if err != nil {
+		return err
+	}
+	for _, assignment := range waiting {
+		if assignment.ReviewStatus != acl.ReviewStatusWaiting {
+			continue
+		}
+		assignment.ReviewStatus = acl.ReviewStatusPassed
+		assignment.UpdatedAt = now
+		if err := c.repo.UpdateApplicationAssignment(assignment); err != nil {
+			return err
+		}
+
+		app, err := c.repo.GetApplicationByID(assignment.ApplicationID)
+		if err != nil || app.IsClosed() || assignment.Stage != app.CurrentStage {
+			continue
+		}
+		assignments, err := c.repo.ListAssignmentsByApplicationID(app.ID)
+		if err != nil {
+			return err
+		}
+		assigned := map[string]bool{app.UserID: true, userID: true}
+		stillWaiting := false
+		for _, other := range assignments {
+			if other.Stage != app.CurrentStage {
+				continue
+			}
+			assigned[other.ReviewerID] = true
+			if other.ID != assignment.ID && other.ReviewStatus == acl.ReviewStatusWaiting {
+				stillWaiting = true
+			}
+		}
+		if stillWaiting {
+			c.addHistory(app.ID, acl.ActionReassign, actorID, fmt.Sprintf("reviewer %s removed", username), now)
+			continue
+		}
+
+		rootIDs, err := c.repo.GetRootMemberIDs()
+		if err != nil {
+			return err
+		}
+		added := 0
+		for _, rootID := range rootIDs {
+			if assigned[rootID] {
+				continue
+			}
+			rootAssignment := acl.ApplicationAssignment{
+				ID:            uuid.NewString(),
+				ApplicationID: app.ID,
+				ReviewerID:    rootID,
+				ReviewStatus:  acl.ReviewStatusWaiting,
+				Stage:         app.CurrentStage,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+			if err := c.repo.CreateApplicationAssignment(rootAssignment); err != nil {
+				return err
+			}
+			assigned[rootID] = true
+			added++
+		}
+		c.addHistory(app.ID, acl.ActionReassign, actorID, fmt.Sprintf("reviewer %s removed, reassigned to %s: %d reviewers added", username, acl.GroupRoot, added), now)
+	}
+	return nil
+}
+
+// purgeOrphans cleans up after the users deleted while their data was still referenced,
+// e.g. before the cleanup existed, and returns how many users were cleaned up.
+func (c userCleanup) purgeOrphans(now time.Time) (int, error) {
+	ids, err := c.repo.ListReferencedUserIDs()
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, id := range ids {
+		_, err := c.repo.GetUserByID(id)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, db.ErrNotFound) {
+			return purged, err
+		}
+		if err := c.purge(id, "", acl.ActorSystem, now); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (c userCleanup) addHistory(appID, action, actorID, comment string, now time.Time) {
+	history := acl.ApplicationHistory{
+		ID:            uuid.NewString(),
+		ApplicationID: appID,
+		Action:        action,
+		ActorID:       actorID,
+		Comment:       comment,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := c.repo.CreateApplicationHistory(history); err != nil {
+		// log but do not fail
+		log.Println("failed to create application history", err)
+	}
+}
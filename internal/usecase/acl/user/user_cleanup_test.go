@@ -0,0 +1,125 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	user_mock "github.com/jekiapp/topic-master/internal/usecase/acl/user/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserCleanup_ReassignReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	app := acl.Application{ID: "a1", UserID: "applicant", Status: acl.StatusWaitingForApproval, CurrentStage: 0}
+	mine := acl.ApplicationAssignment{ID: "as1", ApplicationID: "a1", ReviewerID: "u1", ReviewStatus: acl.ReviewStatusWaiting}
+
+	t.Run("other reviewers are still waiting", func(t *testing.T) {
+		m := user_mock.NewMockiUserCleanupRepo(ctrl)
+		m.EXPECT().ListAssignmentsByReviewerID("u1").Return([]acl.ApplicationAssignment{mine}, nil)
+		m.EXPECT().UpdateApplicationAssignment(gomock.Any()).DoAndReturn(func(a acl.ApplicationAssignment) error {
+			assert.Equal(t, acl.ReviewStatusPassed, a.ReviewStatus)
+			return nil
+		})
+		m.EXPECT().GetApplicationByID("a1").Return(app, nil)
+		m.EXPECT().ListAssignmentsByApplicationID("a1").Return([]acl.ApplicationAssignment{
+			mine,
+			{ID: "as2", ApplicationID: "a1", ReviewerID: "u2", ReviewStatus: acl.ReviewStatusWaiting},
+		}, nil)
+		m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+			assert.Equal(t, acl.ActionReassign, h.Action)
+			assert.Equal(t, "reviewer alice removed", h.Comment)
+			return nil
+		})
+
+		err := userCleanup{repo: m}.reassignReviews("u1", "alice", "r1", now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("stage left without reviewer goes to root", func(t *testing.T) {
+		m := user_mock.NewMockiUserCleanupRepo(ctrl)
+		m.EXPECT().ListAssignmentsByReviewerID("u1").Return([]acl.ApplicationAssignment{mine}, nil)
+		m.EXPECT().UpdateApplicationAssignment(gomock.Any()).Return(nil)
+		m.EXPECT().GetApplicationByID("a1").Return(app, nil)
+		m.EXPECT().ListAssignmentsByApplicationID("a1").Return([]acl.ApplicationAssignment{mine}, nil)
+		// the deleted user and the applicant are never assigned, even when they are root members
+		m.EXPECT().GetRootMemberIDs().Return([]string{"r1", "u1", "applicant"}, nil)
+		m.EXPECT().CreateApplicationAssignment(gomock.Any()).DoAndReturn(func(a acl.ApplicationAssignment) error {
+			assert.Equal(t, "r1", a.ReviewerID)
+			assert.Equal(t, acl.ReviewStatusWaiting, a.ReviewStatus)
+			return nil
+		})
+		m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+			assert.Equal(t, "reviewer alice removed, reassigned to root: 1 reviewers added", h.Comment)
+			return nil
+		})
+
+		err := userCleanup{repo: m}.reassignReviews("u1", "alice", acl.ActorSystem, now)
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserCleanup_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	m := user_mock.NewMockiUserCleanupRepo(ctrl)
+	m.EXPECT().ListPermissionMapsByUser("u1").Return([]acl.PermissionMap{}, nil)
+	m.EXPECT().ListApplicationsByUserID("u1").Return([]acl.Application{
+		{ID: "open", UserID: "u1", Status: acl.StatusWaitingForApproval},
+		{ID: "done", UserID: "u1", Status: acl.StatusCompleted},
+	}, nil)
+	m.EXPECT().ListAssignmentsByApplicationID("open").Return([]acl.ApplicationAssignment{
+		{ID: "as1", ApplicationID: "open", ReviewerID: "r1", ReviewStatus: acl.ReviewStatusWaiting},
+	}, nil)
+	m.EXPECT().UpdateApplication(gomock.Any()).DoAndReturn(func(app acl.Application) error {
+		assert.Equal(t, "open", app.ID)
+		assert.Equal(t, acl.StatusWithdrawn, app.Status)
+		return nil
+	})
+	m.EXPECT().UpdateApplicationAssignment(gomock.Any()).DoAndReturn(func(a acl.ApplicationAssignment) error {
+		assert.Equal(t, acl.ReviewStatusPassed, a.ReviewStatus)
+		return nil
+	})
+	m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+		assert.Equal(t, acl.ActionWithdraw, h.Action)
+		assert.Equal(t, "applicant alice was deleted", h.Comment)
+		return nil
+	})
+	m.EXPECT().ListAssignmentsByReviewerID("u1").Return([]acl.ApplicationAssignment{}, nil)
+	m.EXPECT().DeleteBookmarksByUser("u1").Return(nil)
+	m.EXPECT().DeleteUserData("u1", "alice").Return(nil)
+
+	assert.NoError(t, userCleanup{repo: m}.purge("u1", "alice", "r1", now))
+}
+
+func TestUserCleanup_PurgeOrphans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	m := user_mock.NewMockiUserCleanupRepo(ctrl)
+	m.EXPECT().ListReferencedUserIDs().Return([]string{"u1", "gone"}, nil)
+	m.EXPECT().GetUserByID("u1").Return(acl.User{ID: "u1"}, nil)
+	m.EXPECT().GetUserByID("gone").Return(acl.User{}, db.ErrNotFound)
+	m.EXPECT().ListPermissionMapsByUser("gone").Return([]acl.PermissionMap{{ID: "p1", UserID: "gone"}}, nil)
+	m.EXPECT().DeletePermissionMapByID("p1").Return(nil)
+	m.EXPECT().CreateGrantRevocation(gomock.Any()).DoAndReturn(func(r acl.GrantRevocation) error {
+		assert.Equal(t, acl.ActorSystem, r.RevokedBy)
+		return nil
+	})
+	m.EXPECT().ListApplicationsByUserID("gone").Return([]acl.Application{}, nil)
+	m.EXPECT().ListAssignmentsByReviewerID("gone").Return([]acl.ApplicationAssignment{}, nil)
+	m.EXPECT().DeleteBookmarksByUser("gone").Return(nil)
+	m.EXPECT().DeleteUserData("gone", "gone").Return(nil)
+
+	purged, err := userCleanup{repo: m}.purgeOrphans(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
@@ -0,0 +1,290 @@
+//go:generate mockgen -source=user_lifecycle.go -destination=mock/mock_user_lifecycle_repo.go -package=user_mock
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type DeactivateUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type DeactivateUserResponse struct {
+	Success bool `json:"success"`
+}
+
+type ReactivateUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type ReactivateUserResponse struct {
+	Success bool `json:"success"`
+}
+
+type iUserLifecycleRepo interface {
+	GetUserByID(userID string) (acl.User, error)
+	UpdateUser(user acl.User) error
+	GetAllUsers() ([]acl.User, error)
+	ListGroupsForUser(userID string) ([]acl.GroupRole, error)
+	DeleteResetPasswordsByUsername(username string) error
+	InsertSecurityEvent(event acl.SecurityEvent) error
+	ListUserActivities() ([]acl.UserActivity, error)
+	UpsertUserActivity(activity acl.UserActivity) error
+}
+
+type userLifecycleRepo struct {
+	db *buntdb.DB
+}
+
+func (r *userLifecycleRepo) GetUserByID(userID string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, userID)
+}
+
+func (r *userLifecycleRepo) UpdateUser(user acl.User) error {
+	return userrepo.UpdateUser(r.db, user)
+}
+
+func (r *userLifecycleRepo) GetAllUsers() ([]acl.User, error) {
+	return userrepo.GetAllUsers(r.db)
+}
+
+func (r *userLifecycleRepo) ListGroupsForUser(userID string) ([]acl.GroupRole, error) {
+	groups, err := userrepo.ListGroupsForUser(r.db, userID)
+	if errors.Is(err, db.ErrNotFound) {
+		return []acl.GroupRole{}, nil
+	}
+	return groups, err
+}
+
+func (r *userLifecycleRepo) DeleteResetPasswordsByUsername(username string) error {
+	return userrepo.DeleteResetPasswordsByUsername(r.db, username)
+}
+
+func (r *userLifecycleRepo) InsertSecurityEvent(event acl.SecurityEvent) error {
+	return userrepo.InsertSecurityEvent(r.db, event)
+}
+
+func (r *userLifecycleRepo) ListUserActivities() ([]acl.UserActivity, error) {
+	return userrepo.ListUserActivities(r.db)
+}
+
+func (r *userLifecycleRepo) UpsertUserActivity(activity acl.UserActivity) error {
+	return userrepo.UpsertUserActivity(r.db, activity)
+}
+
+// UserLifecycleUsecase deactivates and reactivates users. A deactivated user can't log in and
+// their sessions end, but unlike a deletion their groups, grants and history are kept.
+type UserLifecycleUsecase struct {
+	repo    iUserLifecycleRepo
+	cleanup userCleanup
+}
+
+func NewUserLifecycleUsecase(db *buntdb.DB) UserLifecycleUsecase {
+	return UserLifecycleUsecase{
+		repo:    &userLifecycleRepo{db: db},
+		cleanup: newUserCleanup(db),
+	}
+}
+
+func (uc UserLifecycleUsecase) HandleDeactivate(ctx context.Context, req DeactivateUserRequest) (DeactivateUserResponse, error) {
+	if req.UserID == "" {
+		return DeactivateUserResponse{}, errors.New("missing user_id")
+	}
+	admin := util.GetUserInfo(ctx)
+	if admin == nil {
+		return DeactivateUserResponse{}, errors.New("user is not authenticated")
+	}
+	if admin.ID == req.UserID {
+		return DeactivateUserResponse{}, errors.New("you cannot deactivate yourself")
+	}
+	user, err := uc.repo.GetUserByID(req.UserID)
+	if err != nil {
+		return DeactivateUserResponse{}, errors.New("user not found")
+	}
+	if user.Status != acl.StatusUserActive {
+		return DeactivateUserResponse{}, fmt.Errorf("only active users can be deactivated, %s is %s", user.Username, user.Status)
+	}
+	if err := uc.deactivate(user, admin.Username, admin.ID, "deactivated by "+admin.Username, time.Now()); err != nil {
+		return DeactivateUserResponse{}, err
+	}
+	return DeactivateUserResponse{Success: true}, nil
+}
+
+// deactivate sets the user inactive and revokes their sessions and reset links. The reviews
+// waiting on them are handed over, nobody would make them until they are back.
+func (uc UserLifecycleUsecase) deactivate(user acl.User, by, actorID, detail string, now time.Time) error {
+	user.Status = acl.StatusUserInactive
+	user.DeactivatedAt = now
+	user.DeactivatedBy = by
+	user.TokensValidAfter = now.Unix()
+	user.UpdatedAt = now
+	if err := uc.repo.UpdateUser(user); err != nil {
+		return err
+	}
+	if err := uc.repo.DeleteResetPasswordsByUsername(user.Username); err != nil {
+		log.Printf("failed to revoke the reset links of %s: %v", user.Username, err)
+	}
+	if err := uc.cleanup.reassignReviews(user.ID, user.Username, actorID, now); err != nil {
+		log.Printf("failed to hand over the reviews of %s: %v", user.Username, err)
+	}
+	if err := uc.repo.InsertSecurityEvent(acl.SecurityEvent{
+		Event:    acl.SecurityEvent_AccountDeactivated,
+		Username: user.Username,
+		Detail:   detail,
+	}); err != nil {
+		log.Printf("failed to audit deactivation of %s: %v", user.Username, err)
+	}
+	return nil
+}
+
+func (uc UserLifecycleUsecase) HandleReactivate(ctx context.Context, req ReactivateUserRequest) (ReactivateUserResponse, error) {
+	if req.UserID == "" {
+		return ReactivateUserResponse{}, errors.New("missing user_id")
+	}
+	admin := util.GetUserInfo(ctx)
+	if admin == nil {
+		return ReactivateUserResponse{}, errors.New("user is not authenticated")
+	}
+	user, err := uc.repo.GetUserByID(req.UserID)
+	if err != nil {
+		return ReactivateUserResponse{}, errors.New("user not found")
+	}
+	if user.Status != acl.StatusUserInactive {
+		return ReactivateUserResponse{}, fmt.Errorf("user %s is not deactivated", user.Username)
+	}
+
+	now := time.Now()
+	user.Status = acl.StatusUserActive
+	user.DeactivatedAt = time.Time{}
+	user.DeactivatedBy = ""
+	user.UpdatedAt = now
+	if err := uc.repo.UpdateUser(user); err != nil {
+		return ReactivateUserResponse{}, err
+	}
+	// the inactivity policy counts from the reactivation, not from the last login
+	if err := uc.repo.UpsertUserActivity(acl.UserActivity{UserID: user.ID, Username: user.Username, LastActiveAt: now}); err != nil {
+		log.Printf("failed to record the activity of %s: %v", user.Username, err)
+	}
+	if err := uc.repo.InsertSecurityEvent(acl.SecurityEvent{
+		Event:    acl.SecurityEvent_AccountReactivated,
+		Username: user.Username,
+		Detail:   "reactivated by " + admin.Username,
+	}); err != nil {
+		log.Printf("failed to audit reactivation of %s: %v", user.Username, err)
+	}
+	return ReactivateUserResponse{Success: true}, nil
+}
+
+// UserLifecycleJanitor applies the inactivity policy and cleans up after deleted users.
+type UserLifecycleJanitor struct {
+	lifecycle UserLifecycleUsecase
+	config    *config.Config
+}
+
+func NewUserLifecycleJanitor(db *buntdb.DB, cfg *config.Config) UserLifecycleJanitor {
+	return UserLifecycleJanitor{
+		lifecycle: NewUserLifecycleUsecase(db),
+		config:    cfg,
+	}
+}
+
+// Run checks the users every interval until ctx is done.
+func (j UserLifecycleJanitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deactivated, err := j.DeactivateInactive(now)
+			if err != nil {
+				log.Printf("[USER LIFECYCLE] error deactivating inactive users: %s", err)
+			}
+			if deactivated > 0 {
+				log.Printf("[USER LIFECYCLE] deactivated %d inactive users", deactivated)
+			}
+			purged, err := j.lifecycle.cleanup.purgeOrphans(now)
+			if err != nil {
+				log.Printf("[USER LIFECYCLE] error cleaning up deleted users: %s", err)
+			}
+			if purged > 0 {
+				log.Printf("[USER LIFECYCLE] cleaned up after %d deleted users", purged)
+			}
+		}
+	}
+}
+
+// DeactivateInactive deactivates the active users without login or activity for InactivityDays
+// and returns how many were deactivated. Root members are never deactivated, so someone can
+// always reactivate the others. A user never seen starts counting at now, which keeps an
+// upgrade from deactivating everybody.
+func (j UserLifecycleJanitor) DeactivateInactive(now time.Time) (int, error) {
+	days := j.config.InactivityDays
+	if days <= 0 {
+		return 0, nil
+	}
+	users, err := j.lifecycle.repo.GetAllUsers()
+	if err != nil {
+		return 0, err
+	}
+	activities, err := j.lifecycle.repo.ListUserActivities()
+	if err != nil {
+		return 0, err
+	}
+	lastActive := map[string]time.Time{}
+	for _, activity := range activities {
+		lastActive[activity.UserID] = activity.LastActiveAt
+	}
+
+	cutoff := now.AddDate(0, 0, -days)
+	deactivated := 0
+	for _, user := range users {
+		if user.Status != acl.StatusUserActive {
+			continue
+		}
+		last := lastActive[user.ID]
+		if last.IsZero() {
+			if err := j.lifecycle.repo.UpsertUserActivity(acl.UserActivity{UserID: user.ID, Username: user.Username, LastActiveAt: now}); err != nil {
+				return deactivated, err
+			}
+			continue
+		}
+		if !last.Before(cutoff) {
+			continue
+		}
+		groups, err := j.lifecycle.repo.ListGroupsForUser(user.ID)
+		if err != nil {
+			return deactivated, err
+		}
+		if isRootMember(groups) {
+			continue
+		}
+		detail := fmt.Sprintf("no activity for %d days", days)
+		if err := j.lifecycle.deactivate(user, acl.ActorSystem, acl.ActorSystem, detail, now); err != nil {
+			return deactivated, err
+		}
+		deactivated++
+	}
+	return deactivated, nil
+}
+
+func isRootMember(groups []acl.GroupRole) bool {
+	for _, group := range groups {
+		if group.GroupName == acl.GroupRoot {
+			return true
+		}
+	}
+	return false
+}
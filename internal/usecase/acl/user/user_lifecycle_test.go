@@ -0,0 +1,200 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	user_mock "github.com/jekiapp/topic-master/internal/usecase/acl/user/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserLifecycleUsecase_HandleDeactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	root := &acl.User{ID: "r1", Username: "root", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}}
+	ctx := util.MockContextWithUser(context.Background(), root)
+
+	tests := []struct {
+		name      string
+		req       DeactivateUserRequest
+		setupMock func(m *user_mock.MockiUserLifecycleRepo, c *user_mock.MockiUserCleanupRepo)
+		wantErr   string
+	}{
+		{
+			name:      "missing user",
+			req:       DeactivateUserRequest{},
+			setupMock: func(m *user_mock.MockiUserLifecycleRepo, c *user_mock.MockiUserCleanupRepo) {},
+			wantErr:   "missing user_id",
+		},
+		{
+			name:      "yourself",
+			req:       DeactivateUserRequest{UserID: "r1"},
+			setupMock: func(m *user_mock.MockiUserLifecycleRepo, c *user_mock.MockiUserCleanupRepo) {},
+			wantErr:   "you cannot deactivate yourself",
+		},
+		{
+			name: "unknown user",
+			req:  DeactivateUserRequest{UserID: "u9"},
+			setupMock: func(m *user_mock.MockiUserLifecycleRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("u9").Return(acl.User{}, errors.New("not found"))
+			},
+			wantErr: "user not found",
+		},
+		{
+			name: "pending user",
+			req:  DeactivateUserRequest{UserID: "u2"},
+			setupMock: func(m *user_mock.MockiUserLifecycleRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("u2").Return(acl.User{ID: "u2", Username: "bob", Status: acl.StatusUserPending}, nil)
+			},
+			wantErr: "only active users can be deactivated, bob is pending",
+		},
+		{
+			name: "deactivation revokes the sessions and is audited",
+			req:  DeactivateUserRequest{UserID: "u1"},
+			setupMock: func(m *user_mock.MockiUserLifecycleRepo, c *user_mock.MockiUserCleanupRepo) {
+				m.EXPECT().GetUserByID("u1").Return(acl.User{ID: "u1", Username: "alice", Status: acl.StatusUserActive}, nil)
+				m.EXPECT().UpdateUser(gomock.Any()).DoAndReturn(func(u acl.User) error {
+					assert.Equal(t, acl.StatusUserInactive, u.Status)
+					assert.Equal(t, "root", u.DeactivatedBy)
+					assert.WithinDuration(t, time.Now(), u.DeactivatedAt, time.Second)
+					assert.InDelta(t, time.Now().Unix(), u.TokensValidAfter, 1)
+					return nil
+				})
+				m.EXPECT().DeleteResetPasswordsByUsername("alice").Return(nil)
+				c.EXPECT().ListAssignmentsByReviewerID("u1").Return([]acl.ApplicationAssignment{}, nil)
+				m.EXPECT().InsertSecurityEvent(gomock.Any()).DoAndReturn(func(e acl.SecurityEvent) error {
+					assert.Equal(t, acl.SecurityEvent_AccountDeactivated, e.Event)
+					assert.Equal(t, "alice", e.Username)
+					assert.Equal(t, "deactivated by root", e.Detail)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := user_mock.NewMockiUserLifecycleRepo(ctrl)
+			mockCleanup := user_mock.NewMockiUserCleanupRepo(ctrl)
+			tt.setupMock(mockRepo, mockCleanup)
+			uc := UserLifecycleUsecase{repo: mockRepo, cleanup: userCleanup{repo: mockCleanup}}
+			resp, err := uc.HandleDeactivate(ctx, tt.req)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, resp.Success)
+		})
+	}
+}
+
+func TestUserLifecycleUsecase_HandleReactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	root := &acl.User{ID: "r1", Username: "root", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}}
+	ctx := util.MockContextWithUser(context.Background(), root)
+
+	t.Run("active user", func(t *testing.T) {
+		mockRepo := user_mock.NewMockiUserLifecycleRepo(ctrl)
+		mockRepo.EXPECT().GetUserByID("u1").Return(acl.User{ID: "u1", Username: "alice", Status: acl.StatusUserActive}, nil)
+		uc := UserLifecycleUsecase{repo: mockRepo}
+		_, err := uc.HandleReactivate(ctx, ReactivateUserRequest{UserID: "u1"})
+		assert.EqualError(t, err, "user alice is not deactivated")
+	})
+
+	t.Run("reactivation restarts the inactivity count", func(t *testing.T) {
+		mockRepo := user_mock.NewMockiUserLifecycleRepo(ctrl)
+		deactivated := acl.User{ID: "u1", Username: "alice", Status: acl.StatusUserInactive, DeactivatedBy: acl.ActorSystem, DeactivatedAt: time.Now().Add(-time.Hour), TokensValidAfter: 100}
+		mockRepo.EXPECT().GetUserByID("u1").Return(deactivated, nil)
+		mockRepo.EXPECT().UpdateUser(gomock.Any()).DoAndReturn(func(u acl.User) error {
+			assert.Equal(t, acl.StatusUserActive, u.Status)
+			assert.Empty(t, u.DeactivatedBy)
+			assert.True(t, u.DeactivatedAt.IsZero())
+			// the sessions revoked by the deactivation stay revoked
+			assert.Equal(t, int64(100), u.TokensValidAfter)
+			return nil
+		})
+		mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).DoAndReturn(func(a acl.UserActivity) error {
+			assert.WithinDuration(t, time.Now(), a.LastActiveAt, time.Second)
+			return nil
+		})
+		mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).DoAndReturn(func(e acl.SecurityEvent) error {
+			assert.Equal(t, acl.SecurityEvent_AccountReactivated, e.Event)
+			assert.Equal(t, "reactivated by root", e.Detail)
+			return nil
+		})
+		uc := UserLifecycleUsecase{repo: mockRepo}
+		resp, err := uc.HandleReactivate(ctx, ReactivateUserRequest{UserID: "u1"})
+		assert.NoError(t, err)
+		assert.True(t, resp.Success)
+	})
+}
+
+func TestUserLifecycleJanitor_DeactivateInactive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	old := now.AddDate(0, 0, -31)
+
+	t.Run("disabled", func(t *testing.T) {
+		j := UserLifecycleJanitor{config: &config.Config{}}
+		deactivated, err := j.DeactivateInactive(now)
+		assert.NoError(t, err)
+		assert.Zero(t, deactivated)
+	})
+
+	t.Run("only inactive users outside root are deactivated", func(t *testing.T) {
+		mockRepo := user_mock.NewMockiUserLifecycleRepo(ctrl)
+		mockCleanup := user_mock.NewMockiUserCleanupRepo(ctrl)
+		mockRepo.EXPECT().GetAllUsers().Return([]acl.User{
+			{ID: "u1", Username: "idle", Status: acl.StatusUserActive},
+			{ID: "u2", Username: "recent", Status: acl.StatusUserActive},
+			{ID: "u3", Username: "never", Status: acl.StatusUserActive},
+			{ID: "u4", Username: "admin", Status: acl.StatusUserActive},
+			{ID: "u5", Username: "gone", Status: acl.StatusUserInactive},
+		}, nil)
+		mockRepo.EXPECT().ListUserActivities().Return([]acl.UserActivity{
+			{UserID: "u1", LastActiveAt: old},
+			{UserID: "u2", LastActiveAt: now.AddDate(0, 0, -2)},
+			{UserID: "u4", LastActiveAt: old},
+			{UserID: "u5", LastActiveAt: old},
+		}, nil)
+		// a user never seen starts counting now
+		mockRepo.EXPECT().UpsertUserActivity(gomock.Any()).DoAndReturn(func(a acl.UserActivity) error {
+			assert.Equal(t, "u3", a.UserID)
+			assert.Equal(t, now, a.LastActiveAt)
+			return nil
+		})
+		mockRepo.EXPECT().ListGroupsForUser("u1").Return([]acl.GroupRole{{GroupName: "team"}}, nil)
+		mockRepo.EXPECT().ListGroupsForUser("u4").Return([]acl.GroupRole{{GroupName: acl.GroupRoot}}, nil)
+		mockRepo.EXPECT().UpdateUser(gomock.Any()).DoAndReturn(func(u acl.User) error {
+			assert.Equal(t, "u1", u.ID)
+			assert.Equal(t, acl.StatusUserInactive, u.Status)
+			assert.Equal(t, acl.ActorSystem, u.DeactivatedBy)
+			return nil
+		})
+		mockRepo.EXPECT().DeleteResetPasswordsByUsername("idle").Return(nil)
+		mockCleanup.EXPECT().ListAssignmentsByReviewerID("u1").Return([]acl.ApplicationAssignment{}, nil)
+		mockRepo.EXPECT().InsertSecurityEvent(gomock.Any()).DoAndReturn(func(e acl.SecurityEvent) error {
+			assert.Equal(t, "no activity for 30 days", e.Detail)
+			return nil
+		})
+
+		j := UserLifecycleJanitor{
+			lifecycle: UserLifecycleUsecase{repo: mockRepo, cleanup: userCleanup{repo: mockCleanup}},
+			config:    &config.Config{InactivityDays: 30},
+		}
+		deactivated, err := j.DeactivateInactive(now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, deactivated)
+	})
+}
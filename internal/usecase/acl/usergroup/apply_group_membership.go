@@ -0,0 +1,150 @@
+//go:generate mockgen -source=apply_group_membership.go -destination=mock/mock_apply_group_membership_repo.go -package=usergroup_mock
+package acl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	usergrouplogic "github.com/jekiapp/topic-master/internal/logic/user_group"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	usergrouprepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type ApplyGroupMembershipRequest struct {
+	GroupName string `json:"group_name"`
+	Role      string `json:"role"` // admin or member
+	Reason    string `json:"reason"`
+}
+
+type ApplyGroupMembershipResponse struct {
+	ApplicationID string `json:"application_id"`
+	LinkRedirect  string `json:"link_redirect"`
+}
+
+func (r ApplyGroupMembershipRequest) Validate() error {
+	if r.GroupName == "" {
+		return errors.New("missing group_name")
+	}
+	if _, ok := acl.GroupJoinPermission(r.Role); !ok {
+		return fmt.Errorf("invalid role %q, must be %s or %s", r.Role, acl.RoleGroupMember, acl.RoleGroupAdmin)
+	}
+	return nil
+}
+
+type iApplyGroupMembershipRepo interface {
+	GetGroupByName(name string) (acl.Group, error)
+	GetGroupByID(id string) (acl.Group, error)
+	GetUserGroup(userID, groupID string) (acl.UserGroup, error)
+	ListApplicationsByUserID(userID string) ([]acl.Application, error)
+	ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error)
+	GetReviewerIDsByGroupID(groupID string) ([]string, error)
+	WithApplicationTx(fn func(w auth.IApplicationWriter) error) error
+}
+
+type applyGroupMembershipRepo struct {
+	db *buntdb.DB
+}
+
+func (r *applyGroupMembershipRepo) GetGroupByName(name string) (acl.Group, error) {
+	return usergrouprepo.GetGroupByName(r.db, name)
+}
+
+func (r *applyGroupMembershipRepo) GetGroupByID(id string) (acl.Group, error) {
+	return usergrouprepo.GetGroupByID(r.db, id)
+}
+
+func (r *applyGroupMembershipRepo) GetUserGroup(userID, groupID string) (acl.UserGroup, error) {
+	return usergrouprepo.GetUserGroup(r.db, userID, groupID)
+}
+
+func (r *applyGroupMembershipRepo) ListApplicationsByUserID(userID string) ([]acl.Application, error) {
+	return apprepo.ListApplicationsByUserID(r.db, userID)
+}
+
+func (r *applyGroupMembershipRepo) ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error) {
+	return apprepo.ListApprovalPoliciesByType(r.db, applicationType)
+}
+
+func (r *applyGroupMembershipRepo) GetReviewerIDsByGroupID(groupID string) ([]string, error) {
+	return usergrouplogic.GetReviewerIDsByGroupID(r.db, groupID)
+}
+
+func (r *applyGroupMembershipRepo) WithApplicationTx(fn func(w auth.IApplicationWriter) error) error {
+	return auth.WithApplicationTx(r.db, fn)
+}
+
+type ApplyGroupMembershipUsecase struct {
+	repo iApplyGroupMembershipRepo
+}
+
+func NewApplyGroupMembershipUsecase(db *buntdb.DB) ApplyGroupMembershipUsecase {
+	return ApplyGroupMembershipUsecase{
+		repo: &applyGroupMembershipRepo{db: db},
+	}
+}
+
+// Handle creates a ticket to join a group, it is reviewed by the admins of the group.
+// A member can apply again to become admin of the group.
+func (uc ApplyGroupMembershipUsecase) Handle(ctx context.Context, req ApplyGroupMembershipRequest) (ApplyGroupMembershipResponse, error) {
+	if err := req.Validate(); err != nil {
+		return ApplyGroupMembershipResponse{}, err
+	}
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return ApplyGroupMembershipResponse{}, errors.New("user not found in context")
+	}
+	group, err := uc.repo.GetGroupByName(req.GroupName)
+	if err != nil {
+		return ApplyGroupMembershipResponse{}, fmt.Errorf("group %s not found", req.GroupName)
+	}
+	if userGroup, err := uc.repo.GetUserGroup(user.ID, group.ID); err == nil {
+		if userGroup.Role == req.Role || userGroup.Role == acl.RoleGroupAdmin {
+			return ApplyGroupMembershipResponse{}, fmt.Errorf("you are already %s of the group %s", userGroup.Role, group.Name)
+		}
+	}
+	apps, err := uc.repo.ListApplicationsByUserID(user.ID)
+	if err != nil {
+		return ApplyGroupMembershipResponse{}, err
+	}
+	for _, app := range apps {
+		if app.Type == acl.ApplicationType_Membership && app.MetaData[acl.AppMetaData_GroupID] == group.ID && !app.IsClosed() {
+			return ApplyGroupMembershipResponse{}, fmt.Errorf("you already have an open application to join the group %s", group.Name)
+		}
+	}
+
+	permission, _ := acl.GroupJoinPermission(req.Role)
+	stages, err := auth.ResolveApprovalStages(uc.repo, acl.ApplicationType_Membership, []string{permission.Name}, auth.ReviewerGroups{
+		DefaultGroupID: group.ID,
+		OwnerGroupID:   group.ID,
+	})
+	if err != nil {
+		return ApplyGroupMembershipResponse{}, err
+	}
+
+	out, err := auth.CreateApplication(ctx, auth.CreateApplicationInput{
+		Title:           fmt.Sprintf("Join group %s as %s", group.Name, req.Role),
+		ApplicationType: acl.ApplicationType_Membership,
+		PermissionIDs:   []string{permission.Name},
+		Reason:          req.Reason,
+		ReviewerGroupID: group.ID,
+		MetaData: map[string]string{
+			acl.AppMetaData_GroupID:   group.ID,
+			acl.AppMetaData_GroupRole: req.Role,
+		},
+		Stages:             stages,
+		HistoryInitAction:  "Create group membership ticket",
+		HistoryInitComment: fmt.Sprintf("%s applies to join the group %s as %s", user.Username, group.Name, req.Role),
+	}, uc.repo)
+	if err != nil {
+		return ApplyGroupMembershipResponse{}, err
+	}
+	return ApplyGroupMembershipResponse{
+		ApplicationID: out.ApplicationID,
+		LinkRedirect:  fmt.Sprintf("/#ticket-detail?id=%s", out.ApplicationID),
+	}, nil
+}
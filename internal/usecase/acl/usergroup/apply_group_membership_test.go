@@ -0,0 +1,141 @@
+package acl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	aclmodel "github.com/jekiapp/topic-master/internal/model/acl"
+	usergroup_mock "github.com/jekiapp/topic-master/internal/usecase/acl/usergroup/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplyGroupMembershipUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alice := &aclmodel.User{ID: "alice-id", Username: "alice"}
+	group := aclmodel.Group{ID: "g1", Name: "payments"}
+
+	tests := []struct {
+		name      string
+		user      *aclmodel.User
+		req       ApplyGroupMembershipRequest
+		setupMock func(m *usergroup_mock.MockiApplyGroupMembershipRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "invalid role",
+			user:      alice,
+			req:       ApplyGroupMembershipRequest{GroupName: "payments", Role: "owner"},
+			setupMock: func(m *usergroup_mock.MockiApplyGroupMembershipRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "unauthorized",
+			req:       ApplyGroupMembershipRequest{GroupName: "payments", Role: aclmodel.RoleGroupMember},
+			setupMock: func(m *usergroup_mock.MockiApplyGroupMembershipRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "group not found",
+			user: alice,
+			req:  ApplyGroupMembershipRequest{GroupName: "payments", Role: aclmodel.RoleGroupMember},
+			setupMock: func(m *usergroup_mock.MockiApplyGroupMembershipRepo) {
+				m.EXPECT().GetGroupByName("payments").Return(aclmodel.Group{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "already member",
+			user: alice,
+			req:  ApplyGroupMembershipRequest{GroupName: "payments", Role: aclmodel.RoleGroupMember},
+			setupMock: func(m *usergroup_mock.MockiApplyGroupMembershipRepo) {
+				m.EXPECT().GetGroupByName("payments").Return(group, nil)
+				m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupMember}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "open application exists",
+			user: alice,
+			req:  ApplyGroupMembershipRequest{GroupName: "payments", Role: aclmodel.RoleGroupMember},
+			setupMock: func(m *usergroup_mock.MockiApplyGroupMembershipRepo) {
+				m.EXPECT().GetGroupByName("payments").Return(group, nil)
+				m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{}, assert.AnError)
+				m.EXPECT().ListApplicationsByUserID("alice-id").Return([]aclmodel.Application{{
+					ID: "app-0", Type: aclmodel.ApplicationType_Membership, Status: aclmodel.StatusWaitingForApproval,
+					MetaData: map[string]string{aclmodel.AppMetaData_GroupID: "g1"},
+				}}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "member applies as admin",
+			user: alice,
+			req:  ApplyGroupMembershipRequest{GroupName: "payments", Role: aclmodel.RoleGroupAdmin, Reason: "new lead"},
+			setupMock: func(m *usergroup_mock.MockiApplyGroupMembershipRepo) {
+				m.EXPECT().GetGroupByName("payments").Return(group, nil)
+				m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupMember}, nil)
+				m.EXPECT().ListApplicationsByUserID("alice-id").Return([]aclmodel.Application{}, nil)
+				m.EXPECT().ListApprovalPoliciesByType(aclmodel.ApplicationType_Membership).Return(nil, nil)
+				m.EXPECT().GetReviewerIDsByGroupID("g1").Return([]string{"bob-id"}, nil)
+				m.EXPECT().WithApplicationTx(gomock.Any()).DoAndReturn(func(fn func(auth.IApplicationWriter) error) error {
+					w := &applicationWriter{}
+					err := fn(w)
+					assert.Len(t, w.apps, 1)
+					app := w.apps[0]
+					assert.Equal(t, aclmodel.ApplicationType_Membership, app.Type)
+					assert.Equal(t, []string{aclmodel.Permission_Group_Join_Admin.Name}, app.PermissionIDs)
+					assert.Equal(t, "g1", app.MetaData[aclmodel.AppMetaData_GroupID])
+					assert.Equal(t, aclmodel.RoleGroupAdmin, app.MetaData[aclmodel.AppMetaData_GroupRole])
+					assert.Len(t, w.assignments, 1)
+					assert.Len(t, w.histories, 1)
+					return err
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := usergroup_mock.NewMockiApplyGroupMembershipRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := ApplyGroupMembershipUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = util.MockContextWithUser(ctx, tt.user)
+			}
+			resp, err := uc.Handle(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, resp.ApplicationID)
+		})
+	}
+}
+
+// applicationWriter records what CreateApplication writes in its transaction
+type applicationWriter struct {
+	apps        []aclmodel.Application
+	assignments []aclmodel.ApplicationAssignment
+	histories   []aclmodel.ApplicationHistory
+}
+
+func (w *applicationWriter) CreateApplication(app aclmodel.Application) error {
+	w.apps = append(w.apps, app)
+	return nil
+}
+
+func (w *applicationWriter) CreateApplicationAssignment(assignment aclmodel.ApplicationAssignment) error {
+	w.assignments = append(w.assignments, assignment)
+	return nil
+}
+
+func (w *applicationWriter) CreateApplicationHistory(history aclmodel.ApplicationHistory) error {
+	w.histories = append(w.histories, history)
+	return nil
+}
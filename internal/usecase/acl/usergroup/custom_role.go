@@ -0,0 +1,265 @@
+//go:generate mockgen -source=custom_role.go -destination=mock/mock_custom_role_repo.go -package=usergroup_mock
+package acl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	usergrouprepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/tidwall/buntdb"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+type ListCustomRolesResponse struct {
+	GroupID   string           `json:"group_id"`
+	GroupName string           `json:"group_name"`
+	Roles     []CustomRoleItem `json:"roles"`
+	// Permissions lists what a custom role can hold
+	Permissions []acl.Permission `json:"permissions"`
+}
+
+type CustomRoleItem struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SaveCustomRoleRequest struct {
+	GroupID     string   `json:"group_id"`
+	ID          string   `json:"id"` // empty to create a role
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func (r SaveCustomRoleRequest) Validate() error {
+	if r.GroupID == "" {
+		return errors.New("missing required field: group_id")
+	}
+	if r.ID == "" {
+		if !roleNamePattern.MatchString(r.Name) {
+			return errors.New("role name must be 2 to 32 lowercase letters, digits, - or _")
+		}
+		if acl.IsBuiltinRole(r.Name) {
+			return fmt.Errorf("%s is a builtin role", r.Name)
+		}
+	}
+	if len(r.Permissions) == 0 {
+		return errors.New("a role needs at least one permission")
+	}
+	for _, perm := range r.Permissions {
+		if !isRolePermission(perm) {
+			return fmt.Errorf("permission %s cannot be given to a role", perm)
+		}
+	}
+	return nil
+}
+
+func isRolePermission(name string) bool {
+	for _, perm := range acl.RolePermissions {
+		if perm.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type SaveCustomRoleResponse struct {
+	Role CustomRoleItem `json:"role"`
+}
+
+type DeleteCustomRoleRequest struct {
+	GroupID string `json:"group_id"`
+	ID      string `json:"id"`
+}
+
+type DeleteCustomRoleResponse struct {
+	Message string `json:"message"`
+}
+
+type iCustomRoleRepo interface {
+	GetGroupByID(id string) (acl.Group, error)
+	GetUserGroup(userID, groupID string) (acl.UserGroup, error)
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	ListUserGroupsByGroupID(groupID string) ([]acl.UserGroup, error)
+	ListCustomRolesByGroupID(groupID string) ([]acl.CustomRole, error)
+	GetCustomRole(groupID, name string) (acl.CustomRole, error)
+	GetCustomRoleByID(id string) (acl.CustomRole, error)
+	CreateCustomRole(role acl.CustomRole) error
+	UpdateCustomRole(role acl.CustomRole) error
+	DeleteCustomRole(id string) error
+}
+
+type customRoleRepo struct {
+	db *buntdb.DB
+}
+
+func (r *customRoleRepo) GetGroupByID(id string) (acl.Group, error) {
+	return usergrouprepo.GetGroupByID(r.db, id)
+}
+
+func (r *customRoleRepo) GetUserGroup(userID, groupID string) (acl.UserGroup, error) {
+	return usergrouprepo.GetUserGroup(r.db, userID, groupID)
+}
+
+func (r *customRoleRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return usergrouprepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *customRoleRepo) ListUserGroupsByGroupID(groupID string) ([]acl.UserGroup, error) {
+	return usergrouprepo.ListUserGroupsByGroupID(r.db, groupID, 0)
+}
+
+func (r *customRoleRepo) ListCustomRolesByGroupID(groupID string) ([]acl.CustomRole, error) {
+	return usergrouprepo.ListCustomRolesByGroupID(r.db, groupID)
+}
+
+func (r *customRoleRepo) GetCustomRole(groupID, name string) (acl.CustomRole, error) {
+	return usergrouprepo.GetCustomRole(r.db, groupID, name)
+}
+
+func (r *customRoleRepo) GetCustomRoleByID(id string) (acl.CustomRole, error) {
+	return usergrouprepo.GetCustomRoleByID(r.db, id)
+}
+
+func (r *customRoleRepo) CreateCustomRole(role acl.CustomRole) error {
+	return usergrouprepo.CreateCustomRole(r.db, role)
+}
+
+func (r *customRoleRepo) UpdateCustomRole(role acl.CustomRole) error {
+	return usergrouprepo.UpdateCustomRole(r.db, role)
+}
+
+func (r *customRoleRepo) DeleteCustomRole(id string) error {
+	return usergrouprepo.DeleteCustomRole(r.db, id)
+}
+
+// CustomRoleUsecase lets root and the admins of a group define roles with a subset of the permissions
+// on the group's entities, for the members who need other actions than the builtin member role.
+type CustomRoleUsecase struct {
+	repo iCustomRoleRepo
+}
+
+func NewCustomRoleUsecase(db *buntdb.DB) CustomRoleUsecase {
+	return CustomRoleUsecase{
+		repo: &customRoleRepo{db: db},
+	}
+}
+
+func newCustomRoleItem(role acl.CustomRole) CustomRoleItem {
+	return CustomRoleItem{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// HandleList returns the custom roles of the group in params["group_id"]
+func (uc CustomRoleUsecase) HandleList(ctx context.Context, params map[string]string) (ListCustomRolesResponse, error) {
+	group, err := authorizeGroupAdmin(ctx, uc.repo, params["group_id"])
+	if err != nil {
+		return ListCustomRolesResponse{}, err
+	}
+	roles, err := uc.repo.ListCustomRolesByGroupID(group.ID)
+	if err != nil {
+		return ListCustomRolesResponse{}, err
+	}
+	items := make([]CustomRoleItem, 0, len(roles))
+	for _, role := range roles {
+		items = append(items, newCustomRoleItem(role))
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return ListCustomRolesResponse{
+		GroupID:     group.ID,
+		GroupName:   group.Name,
+		Roles:       items,
+		Permissions: acl.RolePermissions,
+	}, nil
+}
+
+// HandleSave creates a role, or updates the description and permissions of an existing one.
+// The name of a role cannot change since members refer to it by name.
+func (uc CustomRoleUsecase) HandleSave(ctx context.Context, req SaveCustomRoleRequest) (SaveCustomRoleResponse, error) {
+	if err := req.Validate(); err != nil {
+		return SaveCustomRoleResponse{}, err
+	}
+	group, err := authorizeGroupAdmin(ctx, uc.repo, req.GroupID)
+	if err != nil {
+		return SaveCustomRoleResponse{}, err
+	}
+
+	now := time.Now()
+	if req.ID == "" {
+		if _, err := uc.repo.GetCustomRole(group.ID, req.Name); err == nil {
+			return SaveCustomRoleResponse{}, fmt.Errorf("role %s already exists in the group %s", req.Name, group.Name)
+		}
+		role := acl.CustomRole{
+			ID:          uuid.NewString(),
+			GroupID:     group.ID,
+			Name:        req.Name,
+			Description: req.Description,
+			Permissions: req.Permissions,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := uc.repo.CreateCustomRole(role); err != nil {
+			return SaveCustomRoleResponse{}, err
+		}
+		return SaveCustomRoleResponse{Role: newCustomRoleItem(role)}, nil
+	}
+
+	role, err := uc.repo.GetCustomRoleByID(req.ID)
+	if err != nil || role.GroupID != group.ID {
+		return SaveCustomRoleResponse{}, errors.New("role not found")
+	}
+	role.Description = req.Description
+	role.Permissions = req.Permissions
+	role.UpdatedAt = now
+	if err := uc.repo.UpdateCustomRole(role); err != nil {
+		return SaveCustomRoleResponse{}, err
+	}
+	return SaveCustomRoleResponse{Role: newCustomRoleItem(role)}, nil
+}
+
+// HandleDelete removes a role no member holds anymore.
+func (uc CustomRoleUsecase) HandleDelete(ctx context.Context, req DeleteCustomRoleRequest) (DeleteCustomRoleResponse, error) {
+	if req.ID == "" {
+		return DeleteCustomRoleResponse{}, errors.New("missing required field: id")
+	}
+	group, err := authorizeGroupAdmin(ctx, uc.repo, req.GroupID)
+	if err != nil {
+		return DeleteCustomRoleResponse{}, err
+	}
+	role, err := uc.repo.GetCustomRoleByID(req.ID)
+	if err != nil || role.GroupID != group.ID {
+		return DeleteCustomRoleResponse{}, errors.New("role not found")
+	}
+	userGroups, err := uc.repo.ListUserGroupsByGroupID(group.ID)
+	if err != nil {
+		return DeleteCustomRoleResponse{}, err
+	}
+	holders := 0
+	for _, ug := range userGroups {
+		if ug.Role == role.Name {
+			holders++
+		}
+	}
+	if holders > 0 {
+		return DeleteCustomRoleResponse{}, fmt.Errorf("role %s is held by %d member(s), change their role first", role.Name, holders)
+	}
+	if err := uc.repo.DeleteCustomRole(role.ID); err != nil {
+		return DeleteCustomRoleResponse{}, err
+	}
+	return DeleteCustomRoleResponse{Message: "role " + role.Name + " deleted"}, nil
+}
@@ -0,0 +1,182 @@
+package acl
+
+import (
+	"context"
+	"testing"
+
+	aclmodel "github.com/jekiapp/topic-master/internal/model/acl"
+	usergroup_mock "github.com/jekiapp/topic-master/internal/usecase/acl/usergroup/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSaveCustomRoleRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     SaveCustomRoleRequest
+		wantErr bool
+	}{
+		{"ok", SaveCustomRoleRequest{GroupID: "g1", Name: "operator", Permissions: []string{"topic:pause", "topic:empty"}}, false},
+		{"update keeps the name", SaveCustomRoleRequest{GroupID: "g1", ID: "r1", Permissions: []string{"topic:tail"}}, false},
+		{"missing group", SaveCustomRoleRequest{Name: "operator", Permissions: []string{"topic:pause"}}, true},
+		{"invalid name", SaveCustomRoleRequest{GroupID: "g1", Name: "Ops Team", Permissions: []string{"topic:pause"}}, true},
+		{"builtin name", SaveCustomRoleRequest{GroupID: "g1", Name: aclmodel.RoleGroupAdmin, Permissions: []string{"topic:pause"}}, true},
+		{"no permission", SaveCustomRoleRequest{GroupID: "g1", Name: "viewer"}, true},
+		{"not an entity permission", SaveCustomRoleRequest{GroupID: "g1", Name: "viewer", Permissions: []string{"signup"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCustomRoleUsecase_HandleSave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := &aclmodel.User{ID: "alice-id", Username: "alice"}
+	group := aclmodel.Group{ID: "g1", Name: "payments"}
+	expectAdmin := func(m *usergroup_mock.MockiCustomRoleRepo) {
+		m.EXPECT().GetGroupByID("g1").Return(group, nil)
+		m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupAdmin}, nil)
+	}
+
+	tests := []struct {
+		name      string
+		user      *aclmodel.User
+		req       SaveCustomRoleRequest
+		setupMock func(m *usergroup_mock.MockiCustomRoleRepo)
+		wantErr   bool
+	}{
+		{
+			name: "members cannot define roles",
+			user: &aclmodel.User{ID: "bob-id"},
+			req:  SaveCustomRoleRequest{GroupID: "g1", Name: "viewer", Permissions: []string{"topic:tail"}},
+			setupMock: func(m *usergroup_mock.MockiCustomRoleRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetUserGroup("bob-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupMember}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			user: admin,
+			req:  SaveCustomRoleRequest{GroupID: "g1", Name: "viewer", Permissions: []string{"topic:tail"}},
+			setupMock: func(m *usergroup_mock.MockiCustomRoleRepo) {
+				expectAdmin(m)
+				m.EXPECT().GetCustomRole("g1", "viewer").Return(aclmodel.CustomRole{ID: "r1"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "create role",
+			user: admin,
+			req:  SaveCustomRoleRequest{GroupID: "g1", Name: "operator", Permissions: []string{"topic:pause", "topic:empty"}},
+			setupMock: func(m *usergroup_mock.MockiCustomRoleRepo) {
+				expectAdmin(m)
+				m.EXPECT().GetCustomRole("g1", "operator").Return(aclmodel.CustomRole{}, context.DeadlineExceeded)
+				m.EXPECT().CreateCustomRole(gomock.Any()).DoAndReturn(func(role aclmodel.CustomRole) error {
+					assert.Equal(t, "g1", role.GroupID)
+					assert.NotEmpty(t, role.ID)
+					assert.Equal(t, []string{"topic:pause", "topic:empty"}, role.Permissions)
+					return nil
+				})
+			},
+		},
+		{
+			name: "update role of another group",
+			user: admin,
+			req:  SaveCustomRoleRequest{GroupID: "g1", ID: "r9", Permissions: []string{"topic:tail"}},
+			setupMock: func(m *usergroup_mock.MockiCustomRoleRepo) {
+				expectAdmin(m)
+				m.EXPECT().GetCustomRoleByID("r9").Return(aclmodel.CustomRole{ID: "r9", GroupID: "g2", Name: "viewer"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update permissions",
+			user: admin,
+			req:  SaveCustomRoleRequest{GroupID: "g1", ID: "r1", Permissions: []string{"topic:tail"}},
+			setupMock: func(m *usergroup_mock.MockiCustomRoleRepo) {
+				expectAdmin(m)
+				m.EXPECT().GetCustomRoleByID("r1").Return(aclmodel.CustomRole{ID: "r1", GroupID: "g1", Name: "viewer", Permissions: []string{"topic:pause"}}, nil)
+				m.EXPECT().UpdateCustomRole(gomock.Any()).DoAndReturn(func(role aclmodel.CustomRole) error {
+					assert.Equal(t, "viewer", role.Name)
+					assert.Equal(t, []string{"topic:tail"}, role.Permissions)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := usergroup_mock.NewMockiCustomRoleRepo(ctrl)
+			mockRepo.EXPECT().GetGroupsByUserID(gomock.Any()).DoAndReturn(storedGroups).AnyTimes()
+			tt.setupMock(mockRepo)
+			uc := CustomRoleUsecase{repo: mockRepo}
+			ctx := util.MockContextWithUser(context.Background(), tt.user)
+			_, err := uc.HandleSave(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCustomRoleUsecase_HandleDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	root := &aclmodel.User{ID: "root-id", Groups: []aclmodel.GroupRole{{GroupName: aclmodel.GroupRoot}}}
+	group := aclmodel.Group{ID: "g1", Name: "payments"}
+	viewer := aclmodel.CustomRole{ID: "r1", GroupID: "g1", Name: "viewer"}
+
+	tests := []struct {
+		name      string
+		setupMock func(m *usergroup_mock.MockiCustomRoleRepo)
+		wantErr   bool
+	}{
+		{
+			name: "role still held",
+			setupMock: func(m *usergroup_mock.MockiCustomRoleRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetCustomRoleByID("r1").Return(viewer, nil)
+				m.EXPECT().ListUserGroupsByGroupID("g1").Return([]aclmodel.UserGroup{{UserID: "bob-id", Role: "viewer"}}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete unused role",
+			setupMock: func(m *usergroup_mock.MockiCustomRoleRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetCustomRoleByID("r1").Return(viewer, nil)
+				m.EXPECT().ListUserGroupsByGroupID("g1").Return([]aclmodel.UserGroup{{UserID: "bob-id", Role: aclmodel.RoleGroupMember}}, nil)
+				m.EXPECT().DeleteCustomRole("r1").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := usergroup_mock.NewMockiCustomRoleRepo(ctrl)
+			mockRepo.EXPECT().GetGroupsByUserID(gomock.Any()).DoAndReturn(storedGroups).AnyTimes()
+			tt.setupMock(mockRepo)
+			uc := CustomRoleUsecase{repo: mockRepo}
+			ctx := util.MockContextWithUser(context.Background(), root)
+			_, err := uc.HandleDelete(ctx, DeleteCustomRoleRequest{GroupID: "g1", ID: "r1"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
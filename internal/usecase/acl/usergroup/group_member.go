@@ -0,0 +1,239 @@
+//go:generate mockgen -source=group_member.go -destination=mock/mock_group_member_repo.go -package=usergroup_mock
+package acl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	usergrouprepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type GroupMember struct {
+	UserGroupID string `json:"user_group_id"`
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+}
+
+type ListGroupMembersResponse struct {
+	GroupID   string        `json:"group_id"`
+	GroupName string        `json:"group_name"`
+	Members   []GroupMember `json:"members"`
+}
+
+type RemoveGroupMemberRequest struct {
+	GroupID string `json:"group_id"`
+	UserID  string `json:"user_id"`
+}
+
+type RemoveGroupMemberResponse struct {
+	Message string `json:"message"`
+}
+
+type SetGroupMemberRoleRequest struct {
+	GroupID string `json:"group_id"`
+	UserID  string `json:"user_id"`
+	Role    string `json:"role"`
+}
+
+type SetGroupMemberRoleResponse struct {
+	Message string `json:"message"`
+}
+
+type iGroupMemberRepo interface {
+	GetGroupByID(id string) (acl.Group, error)
+	GetUserGroup(userID, groupID string) (acl.UserGroup, error)
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	ListUserGroupsByGroupID(groupID string) ([]acl.UserGroup, error)
+	GetUserByID(id string) (acl.User, error)
+	DeleteUserGroup(userGroupID string) error
+	UpdateUserGroup(userGroup acl.UserGroup) error
+	GetCustomRole(groupID, name string) (acl.CustomRole, error)
+}
+
+type groupMemberRepo struct {
+	db *buntdb.DB
+}
+
+func (r *groupMemberRepo) GetGroupByID(id string) (acl.Group, error) {
+	return usergrouprepo.GetGroupByID(r.db, id)
+}
+
+func (r *groupMemberRepo) GetUserGroup(userID, groupID string) (acl.UserGroup, error) {
+	return usergrouprepo.GetUserGroup(r.db, userID, groupID)
+}
+
+func (r *groupMemberRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return usergrouprepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *groupMemberRepo) ListUserGroupsByGroupID(groupID string) ([]acl.UserGroup, error) {
+	return usergrouprepo.ListUserGroupsByGroupID(r.db, groupID, 0)
+}
+
+func (r *groupMemberRepo) GetUserByID(id string) (acl.User, error) {
+	return usergrouprepo.GetUserByID(r.db, id)
+}
+
+func (r *groupMemberRepo) DeleteUserGroup(userGroupID string) error {
+	return usergrouprepo.DeleteUserGroup(r.db, userGroupID)
+}
+
+func (r *groupMemberRepo) UpdateUserGroup(userGroup acl.UserGroup) error {
+	return usergrouprepo.UpdateUserGroup(r.db, userGroup)
+}
+
+func (r *groupMemberRepo) GetCustomRole(groupID, name string) (acl.CustomRole, error) {
+	return usergrouprepo.GetCustomRole(r.db, groupID, name)
+}
+
+// GroupMemberUsecase lets root and the admins of a group manage its members
+type GroupMemberUsecase struct {
+	repo iGroupMemberRepo
+}
+
+func NewGroupMemberUsecase(db *buntdb.DB) GroupMemberUsecase {
+	return GroupMemberUsecase{
+		repo: &groupMemberRepo{db: db},
+	}
+}
+
+// HandleList returns the members of the group in params["group_id"], admins first
+func (uc GroupMemberUsecase) HandleList(ctx context.Context, params map[string]string) (ListGroupMembersResponse, error) {
+	group, err := uc.authorize(ctx, params["group_id"])
+	if err != nil {
+		return ListGroupMembersResponse{}, err
+	}
+	userGroups, err := uc.repo.ListUserGroupsByGroupID(group.ID)
+	if err != nil {
+		return ListGroupMembersResponse{}, err
+	}
+	members := []GroupMember{}
+	for _, ug := range userGroups {
+		user, err := uc.repo.GetUserByID(ug.UserID)
+		if err != nil {
+			continue
+		}
+		members = append(members, GroupMember{
+			UserGroupID: ug.ID,
+			UserID:      ug.UserID,
+			Username:    user.Username,
+			Name:        user.Name,
+			Role:        ug.Role,
+		})
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].Role != members[j].Role {
+			return members[i].Role == acl.RoleGroupAdmin
+		}
+		return members[i].Username < members[j].Username
+	})
+	return ListGroupMembersResponse{GroupID: group.ID, GroupName: group.Name, Members: members}, nil
+}
+
+// HandleRemove removes a user from the group. Admins cannot remove themselves,
+// so a group is not left without admin by mistake.
+func (uc GroupMemberUsecase) HandleRemove(ctx context.Context, req RemoveGroupMemberRequest) (RemoveGroupMemberResponse, error) {
+	if req.GroupID == "" || req.UserID == "" {
+		return RemoveGroupMemberResponse{}, errors.New("missing required fields: group_id or user_id")
+	}
+	group, err := uc.authorize(ctx, req.GroupID)
+	if err != nil {
+		return RemoveGroupMemberResponse{}, err
+	}
+	user := util.GetUserInfo(ctx)
+	if req.UserID == user.ID {
+		return RemoveGroupMemberResponse{}, errors.New("you cannot remove yourself from the group")
+	}
+	userGroup, err := uc.repo.GetUserGroup(req.UserID, group.ID)
+	if err != nil {
+		return RemoveGroupMemberResponse{}, fmt.Errorf("user is not a member of the group %s", group.Name)
+	}
+	if err := uc.repo.DeleteUserGroup(userGroup.ID); err != nil {
+		return RemoveGroupMemberResponse{}, err
+	}
+	return RemoveGroupMemberResponse{Message: "member removed from the group " + group.Name}, nil
+}
+
+// HandleSetRole changes the role of a member: admin, member or one of the custom roles of the group.
+// Admins cannot change their own role, so a group is not left without admin by mistake.
+func (uc GroupMemberUsecase) HandleSetRole(ctx context.Context, req SetGroupMemberRoleRequest) (SetGroupMemberRoleResponse, error) {
+	if req.GroupID == "" || req.UserID == "" || req.Role == "" {
+		return SetGroupMemberRoleResponse{}, errors.New("missing required fields: group_id, user_id or role")
+	}
+	group, err := uc.authorize(ctx, req.GroupID)
+	if err != nil {
+		return SetGroupMemberRoleResponse{}, err
+	}
+	user := util.GetUserInfo(ctx)
+	if req.UserID == user.ID {
+		return SetGroupMemberRoleResponse{}, errors.New("you cannot change your own role")
+	}
+	if !acl.IsBuiltinRole(req.Role) {
+		if _, err := uc.repo.GetCustomRole(group.ID, req.Role); err != nil {
+			return SetGroupMemberRoleResponse{}, fmt.Errorf("role %s not found in the group %s", req.Role, group.Name)
+		}
+	}
+	userGroup, err := uc.repo.GetUserGroup(req.UserID, group.ID)
+	if err != nil {
+		return SetGroupMemberRoleResponse{}, fmt.Errorf("user is not a member of the group %s", group.Name)
+	}
+	userGroup.Role = req.Role
+	userGroup.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateUserGroup(userGroup); err != nil {
+		return SetGroupMemberRoleResponse{}, err
+	}
+	return SetGroupMemberRoleResponse{Message: fmt.Sprintf("role changed to %s, it applies on the next login of the member", req.Role)}, nil
+}
+
+// authorize returns the group when the user is root or an admin of the group.
+func (uc GroupMemberUsecase) authorize(ctx context.Context, groupID string) (acl.Group, error) {
+	return authorizeGroupAdmin(ctx, uc.repo, groupID)
+}
+
+type iGroupAdminRepo interface {
+	GetGroupByID(id string) (acl.Group, error)
+	GetUserGroup(userID, groupID string) (acl.UserGroup, error)
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+}
+
+// authorizeGroupAdmin returns the group when the user is root or an admin of the group.
+// The root group is managed by root only.
+func authorizeGroupAdmin(ctx context.Context, repo iGroupAdminRepo, groupID string) (acl.Group, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return acl.Group{}, errors.New("unauthorized: user info not found")
+	}
+	if groupID == "" {
+		return acl.Group{}, errors.New("missing required field: group_id")
+	}
+	group, err := repo.GetGroupByID(groupID)
+	if err != nil {
+		return acl.Group{}, fmt.Errorf("group %s not found", groupID)
+	}
+	// check the stored memberships and role, the ones in the token may be outdated
+	groups, err := repo.GetGroupsByUserID(user.ID)
+	if err != nil {
+		return acl.Group{}, fmt.Errorf("failed to get groups of %s: %w", user.Username, err)
+	}
+	for _, g := range groups {
+		if g.GroupName == acl.GroupRoot {
+			return group, nil
+		}
+	}
+	if group.Name == acl.GroupRoot {
+		return acl.Group{}, errors.New("forbidden: only root can manage the root group")
+	}
+	userGroup, err := repo.GetUserGroup(user.ID, group.ID)
+	if err != nil || userGroup.Role != acl.RoleGroupAdmin {
+		return acl.Group{}, fmt.Errorf("forbidden: only admins of the group %s can manage it", group.Name)
+	}
+	return group, nil
+}
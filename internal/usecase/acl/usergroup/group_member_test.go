@@ -0,0 +1,260 @@
+package acl
+
+import (
+	"context"
+	"testing"
+
+	aclmodel "github.com/jekiapp/topic-master/internal/model/acl"
+	usergroup_mock "github.com/jekiapp/topic-master/internal/usecase/acl/usergroup/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// storedGroups answers GetGroupsByUserID: root-id is the only stored member of root,
+// whatever the token of the other users lists
+func storedGroups(userID string) ([]aclmodel.GroupRole, error) {
+	if userID == "root-id" {
+		return []aclmodel.GroupRole{{GroupName: aclmodel.GroupRoot}}, nil
+	}
+	return nil, nil
+}
+
+func TestGroupMemberUsecase_HandleList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := &aclmodel.User{ID: "alice-id", Username: "alice"}
+	group := aclmodel.Group{ID: "g1", Name: "payments"}
+
+	tests := []struct {
+		name      string
+		user      *aclmodel.User
+		setupMock func(m *usergroup_mock.MockiGroupMemberRepo)
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name: "not an admin",
+			user: &aclmodel.User{ID: "carol-id"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetUserGroup("carol-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupMember}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "admins first",
+			user: admin,
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupAdmin}, nil)
+				m.EXPECT().ListUserGroupsByGroupID("g1").Return([]aclmodel.UserGroup{
+					{ID: "ug-1", UserID: "bob-id", Role: aclmodel.RoleGroupMember},
+					{ID: "ug-2", UserID: "alice-id", Role: aclmodel.RoleGroupAdmin},
+				}, nil)
+				m.EXPECT().GetUserByID("bob-id").Return(aclmodel.User{ID: "bob-id", Username: "bob"}, nil)
+				m.EXPECT().GetUserByID("alice-id").Return(aclmodel.User{ID: "alice-id", Username: "alice"}, nil)
+			},
+			want: []string{"alice", "bob"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := usergroup_mock.NewMockiGroupMemberRepo(ctrl)
+			mockRepo.EXPECT().GetGroupsByUserID(gomock.Any()).DoAndReturn(storedGroups).AnyTimes()
+			tt.setupMock(mockRepo)
+			uc := GroupMemberUsecase{repo: mockRepo}
+			ctx := util.MockContextWithUser(context.Background(), tt.user)
+			resp, err := uc.HandleList(ctx, map[string]string{"group_id": "g1"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			var got []string
+			for _, member := range resp.Members {
+				got = append(got, member.Username)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGroupMemberUsecase_HandleRemove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := &aclmodel.User{ID: "alice-id", Username: "alice"}
+	root := &aclmodel.User{ID: "root-id", Groups: []aclmodel.GroupRole{{GroupName: aclmodel.GroupRoot}}}
+	group := aclmodel.Group{ID: "g1", Name: "payments"}
+
+	tests := []struct {
+		name      string
+		user      *aclmodel.User
+		req       RemoveGroupMemberRequest
+		setupMock func(m *usergroup_mock.MockiGroupMemberRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "missing user_id",
+			user:      admin,
+			req:       RemoveGroupMemberRequest{GroupID: "g1"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "admin of another group",
+			user: admin,
+			req:  RemoveGroupMemberRequest{GroupID: "g1", UserID: "bob-id"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "only root manages the root group",
+			user: admin,
+			req:  RemoveGroupMemberRequest{GroupID: "g-root", UserID: "bob-id"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				m.EXPECT().GetGroupByID("g-root").Return(aclmodel.Group{ID: "g-root", Name: aclmodel.GroupRoot}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "cannot remove yourself",
+			user: admin,
+			req:  RemoveGroupMemberRequest{GroupID: "g1", UserID: "alice-id"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupAdmin}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "admin removes a member",
+			user: admin,
+			req:  RemoveGroupMemberRequest{GroupID: "g1", UserID: "bob-id"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupAdmin}, nil)
+				m.EXPECT().GetUserGroup("bob-id", "g1").Return(aclmodel.UserGroup{ID: "ug-1"}, nil)
+				m.EXPECT().DeleteUserGroup("ug-1").Return(nil)
+			},
+		},
+		{
+			name: "removed from root but still root in the token",
+			user: &aclmodel.User{ID: "mallory-id", Groups: []aclmodel.GroupRole{{GroupName: aclmodel.GroupRoot}}},
+			req:  RemoveGroupMemberRequest{GroupID: "g1", UserID: "bob-id"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetUserGroup("mallory-id", "g1").Return(aclmodel.UserGroup{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "root removes a member",
+			user: root,
+			req:  RemoveGroupMemberRequest{GroupID: "g1", UserID: "bob-id"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				m.EXPECT().GetGroupByID("g1").Return(group, nil)
+				m.EXPECT().GetUserGroup("bob-id", "g1").Return(aclmodel.UserGroup{ID: "ug-1"}, nil)
+				m.EXPECT().DeleteUserGroup("ug-1").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := usergroup_mock.NewMockiGroupMemberRepo(ctrl)
+			mockRepo.EXPECT().GetGroupsByUserID(gomock.Any()).DoAndReturn(storedGroups).AnyTimes()
+			tt.setupMock(mockRepo)
+			uc := GroupMemberUsecase{repo: mockRepo}
+			ctx := util.MockContextWithUser(context.Background(), tt.user)
+			_, err := uc.HandleRemove(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGroupMemberUsecase_HandleSetRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	admin := &aclmodel.User{ID: "alice-id", Username: "alice"}
+	group := aclmodel.Group{ID: "g1", Name: "payments"}
+	expectAdmin := func(m *usergroup_mock.MockiGroupMemberRepo) {
+		m.EXPECT().GetGroupByID("g1").Return(group, nil)
+		m.EXPECT().GetUserGroup("alice-id", "g1").Return(aclmodel.UserGroup{Role: aclmodel.RoleGroupAdmin}, nil)
+	}
+
+	tests := []struct {
+		name      string
+		req       SetGroupMemberRoleRequest
+		setupMock func(m *usergroup_mock.MockiGroupMemberRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "missing role",
+			req:       SetGroupMemberRoleRequest{GroupID: "g1", UserID: "bob-id"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "cannot change own role",
+			req:       SetGroupMemberRoleRequest{GroupID: "g1", UserID: "alice-id", Role: aclmodel.RoleGroupMember},
+			setupMock: expectAdmin,
+			wantErr:   true,
+		},
+		{
+			name: "unknown custom role",
+			req:  SetGroupMemberRoleRequest{GroupID: "g1", UserID: "bob-id", Role: "operator"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				expectAdmin(m)
+				m.EXPECT().GetCustomRole("g1", "operator").Return(aclmodel.CustomRole{}, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name: "assign custom role",
+			req:  SetGroupMemberRoleRequest{GroupID: "g1", UserID: "bob-id", Role: "operator"},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				expectAdmin(m)
+				m.EXPECT().GetCustomRole("g1", "operator").Return(aclmodel.CustomRole{ID: "r1", GroupID: "g1", Name: "operator"}, nil)
+				m.EXPECT().GetUserGroup("bob-id", "g1").Return(aclmodel.UserGroup{ID: "ug-1", UserID: "bob-id", GroupID: "g1", Role: aclmodel.RoleGroupMember}, nil)
+				m.EXPECT().UpdateUserGroup(gomock.Any()).DoAndReturn(func(ug aclmodel.UserGroup) error {
+					assert.Equal(t, "operator", ug.Role)
+					return nil
+				})
+			},
+		},
+		{
+			name: "promote to admin",
+			req:  SetGroupMemberRoleRequest{GroupID: "g1", UserID: "bob-id", Role: aclmodel.RoleGroupAdmin},
+			setupMock: func(m *usergroup_mock.MockiGroupMemberRepo) {
+				expectAdmin(m)
+				m.EXPECT().GetUserGroup("bob-id", "g1").Return(aclmodel.UserGroup{ID: "ug-1", UserID: "bob-id", GroupID: "g1", Role: "viewer"}, nil)
+				m.EXPECT().UpdateUserGroup(gomock.Any()).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := usergroup_mock.NewMockiGroupMemberRepo(ctrl)
+			mockRepo.EXPECT().GetGroupsByUserID(gomock.Any()).DoAndReturn(storedGroups).AnyTimes()
+			tt.setupMock(mockRepo)
+			uc := GroupMemberUsecase{repo: mockRepo}
+			ctx := util.MockContextWithUser(context.Background(), admin)
+			_, err := uc.HandleSetRole(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
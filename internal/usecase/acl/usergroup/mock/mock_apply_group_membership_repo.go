@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/acl/usergroup/apply_group_membership.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/acl/usergroup/apply_group_membership.go -destination=internal/usecase/acl/usergroup/mock/mock_apply_group_membership_repo.go -package=usergroup_mock
+//
+
+// Package usergroup_mock is a generated GoMock package.
+package usergroup_mock
+
+import (
+	reflect "reflect"
+
+	auth "github.com/jekiapp/topic-master/internal/logic/auth"
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiApplyGroupMembershipRepo is a mock of iApplyGroupMembershipRepo interface.
+type MockiApplyGroupMembershipRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiApplyGroupMembershipRepoMockRecorder
+}
+
+// MockiApplyGroupMembershipRepoMockRecorder is the mock recorder for MockiApplyGroupMembershipRepo.
+type MockiApplyGroupMembershipRepoMockRecorder struct {
+	mock *MockiApplyGroupMembershipRepo
+}
+
+// NewMockiApplyGroupMembershipRepo creates a new mock instance.
+func NewMockiApplyGroupMembershipRepo(ctrl *gomock.Controller) *MockiApplyGroupMembershipRepo {
+	mock := &MockiApplyGroupMembershipRepo{ctrl: ctrl}
+	mock.recorder = &MockiApplyGroupMembershipRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiApplyGroupMembershipRepo) EXPECT() *MockiApplyGroupMembershipRepoMockRecorder {
+	return m.recorder
+}
+
+// GetGroupByID mocks base method.
+func (m *MockiApplyGroupMembershipRepo) GetGroupByID(id string) (acl.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupByID", id)
+	ret0, _ := ret[0].(acl.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupByID indicates an expected call of GetGroupByID.
+func (mr *MockiApplyGroupMembershipRepoMockRecorder) GetGroupByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByID", reflect.TypeOf((*MockiApplyGroupMembershipRepo)(nil).GetGroupByID), id)
+}
+
+// GetGroupByName mocks base method.
+func (m *MockiApplyGroupMembershipRepo) GetGroupByName(name string) (acl.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupByName", name)
+	ret0, _ := ret[0].(acl.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupByName indicates an expected call of GetGroupByName.
+func (mr *MockiApplyGroupMembershipRepoMockRecorder) GetGroupByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByName", reflect.TypeOf((*MockiApplyGroupMembershipRepo)(nil).GetGroupByName), name)
+}
+
+// GetReviewerIDsByGroupID mocks base method.
+func (m *MockiApplyGroupMembershipRepo) GetReviewerIDsByGroupID(groupID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewerIDsByGroupID", groupID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewerIDsByGroupID indicates an expected call of GetReviewerIDsByGroupID.
+func (mr *MockiApplyGroupMembershipRepoMockRecorder) GetReviewerIDsByGroupID(groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewerIDsByGroupID", reflect.TypeOf((*MockiApplyGroupMembershipRepo)(nil).GetReviewerIDsByGroupID), groupID)
+}
+
+// GetUserGroup mocks base method.
+func (m *MockiApplyGroupMembershipRepo) GetUserGroup(userID, groupID string) (acl.UserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserGroup", userID, groupID)
+	ret0, _ := ret[0].(acl.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserGroup indicates an expected call of GetUserGroup.
+func (mr *MockiApplyGroupMembershipRepoMockRecorder) GetUserGroup(userID, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroup", reflect.TypeOf((*MockiApplyGroupMembershipRepo)(nil).GetUserGroup), userID, groupID)
+}
+
+// ListApplicationsByUserID mocks base method.
+func (m *MockiApplyGroupMembershipRepo) ListApplicationsByUserID(userID string) ([]acl.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApplicationsByUserID", userID)
+	ret0, _ := ret[0].([]acl.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApplicationsByUserID indicates an expected call of ListApplicationsByUserID.
+func (mr *MockiApplyGroupMembershipRepoMockRecorder) ListApplicationsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplicationsByUserID", reflect.TypeOf((*MockiApplyGroupMembershipRepo)(nil).ListApplicationsByUserID), userID)
+}
+
+// ListApprovalPoliciesByType mocks base method.
+func (m *MockiApplyGroupMembershipRepo) ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApprovalPoliciesByType", applicationType)
+	ret0, _ := ret[0].([]acl.ApprovalPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApprovalPoliciesByType indicates an expected call of ListApprovalPoliciesByType.
+func (mr *MockiApplyGroupMembershipRepoMockRecorder) ListApprovalPoliciesByType(applicationType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovalPoliciesByType", reflect.TypeOf((*MockiApplyGroupMembershipRepo)(nil).ListApprovalPoliciesByType), applicationType)
+}
+
+// WithApplicationTx mocks base method.
+func (m *MockiApplyGroupMembershipRepo) WithApplicationTx(fn func(auth.IApplicationWriter) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithApplicationTx", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithApplicationTx indicates an expected call of WithApplicationTx.
+func (mr *MockiApplyGroupMembershipRepoMockRecorder) WithApplicationTx(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithApplicationTx", reflect.TypeOf((*MockiApplyGroupMembershipRepo)(nil).WithApplicationTx), fn)
+}
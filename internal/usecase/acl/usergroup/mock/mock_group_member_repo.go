@@ -0,0 +1,227 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/acl/usergroup/group_member.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/acl/usergroup/group_member.go -destination=internal/usecase/acl/usergroup/mock/mock_group_member_repo.go -package=usergroup_mock
+//
+
+// Package usergroup_mock is a generated GoMock package.
+package usergroup_mock
+
+import (
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+	reflect "reflect"
+)
+
+// MockiGroupMemberRepo is a mock of iGroupMemberRepo interface.
+type MockiGroupMemberRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiGroupMemberRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockiGroupMemberRepoMockRecorder is the mock recorder for MockiGroupMemberRepo.
+type MockiGroupMemberRepoMockRecorder struct {
+	mock *MockiGroupMemberRepo
+}
+
+// NewMockiGroupMemberRepo creates a new mock instance.
+func NewMockiGroupMemberRepo(ctrl *gomock.Controller) *MockiGroupMemberRepo {
+	mock := &MockiGroupMemberRepo{ctrl: ctrl}
+	mock.recorder = &MockiGroupMemberRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiGroupMemberRepo) EXPECT() *MockiGroupMemberRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserGroup mocks base method.
+func (m *MockiGroupMemberRepo) DeleteUserGroup(userGroupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserGroup", userGroupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserGroup indicates an expected call of DeleteUserGroup.
+func (mr *MockiGroupMemberRepoMockRecorder) DeleteUserGroup(userGroupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserGroup", reflect.TypeOf((*MockiGroupMemberRepo)(nil).DeleteUserGroup), userGroupID)
+}
+
+// GetCustomRole mocks base method.
+func (m *MockiGroupMemberRepo) GetCustomRole(groupID, name string) (acl.CustomRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomRole", groupID, name)
+	ret0, _ := ret[0].(acl.CustomRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomRole indicates an expected call of GetCustomRole.
+func (mr *MockiGroupMemberRepoMockRecorder) GetCustomRole(groupID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomRole", reflect.TypeOf((*MockiGroupMemberRepo)(nil).GetCustomRole), groupID, name)
+}
+
+// GetGroupByID mocks base method.
+func (m *MockiGroupMemberRepo) GetGroupByID(id string) (acl.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupByID", id)
+	ret0, _ := ret[0].(acl.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupByID indicates an expected call of GetGroupByID.
+func (mr *MockiGroupMemberRepoMockRecorder) GetGroupByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByID", reflect.TypeOf((*MockiGroupMemberRepo)(nil).GetGroupByID), id)
+}
+
+// GetGroupsByUserID mocks base method.
+func (m *MockiGroupMemberRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsByUserID", userID)
+	ret0, _ := ret[0].([]acl.GroupRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsByUserID indicates an expected call of GetGroupsByUserID.
+func (mr *MockiGroupMemberRepoMockRecorder) GetGroupsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByUserID", reflect.TypeOf((*MockiGroupMemberRepo)(nil).GetGroupsByUserID), userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockiGroupMemberRepo) GetUserByID(id string) (acl.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", id)
+	ret0, _ := ret[0].(acl.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockiGroupMemberRepoMockRecorder) GetUserByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockiGroupMemberRepo)(nil).GetUserByID), id)
+}
+
+// GetUserGroup mocks base method.
+func (m *MockiGroupMemberRepo) GetUserGroup(userID, groupID string) (acl.UserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserGroup", userID, groupID)
+	ret0, _ := ret[0].(acl.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserGroup indicates an expected call of GetUserGroup.
+func (mr *MockiGroupMemberRepoMockRecorder) GetUserGroup(userID, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroup", reflect.TypeOf((*MockiGroupMemberRepo)(nil).GetUserGroup), userID, groupID)
+}
+
+// ListUserGroupsByGroupID mocks base method.
+func (m *MockiGroupMemberRepo) ListUserGroupsByGroupID(groupID string) ([]acl.UserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserGroupsByGroupID", groupID)
+	ret0, _ := ret[0].([]acl.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserGroupsByGroupID indicates an expected call of ListUserGroupsByGroupID.
+func (mr *MockiGroupMemberRepoMockRecorder) ListUserGroupsByGroupID(groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserGroupsByGroupID", reflect.TypeOf((*MockiGroupMemberRepo)(nil).ListUserGroupsByGroupID), groupID)
+}
+
+// UpdateUserGroup mocks base method.
+func (m *MockiGroupMemberRepo) UpdateUserGroup(userGroup acl.UserGroup) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserGroup", userGroup)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserGroup indicates an expected call of UpdateUserGroup.
+func (mr *MockiGroupMemberRepoMockRecorder) UpdateUserGroup(userGroup any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserGroup", reflect.TypeOf((*MockiGroupMemberRepo)(nil).UpdateUserGroup), userGroup)
+}
+
+// MockiGroupAdminRepo is a mock of iGroupAdminRepo interface.
+type MockiGroupAdminRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiGroupAdminRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockiGroupAdminRepoMockRecorder is the mock recorder for MockiGroupAdminRepo.
+type MockiGroupAdminRepoMockRecorder struct {
+	mock *MockiGroupAdminRepo
+}
+
+// NewMockiGroupAdminRepo creates a new mock instance.
+func NewMockiGroupAdminRepo(ctrl *gomock.Controller) *MockiGroupAdminRepo {
+	mock := &MockiGroupAdminRepo{ctrl: ctrl}
+	mock.recorder = &MockiGroupAdminRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiGroupAdminRepo) EXPECT() *MockiGroupAdminRepoMockRecorder {
+	return m.recorder
+}
+
+// GetGroupByID mocks base method.
+func (m *MockiGroupAdminRepo) GetGroupByID(id string) (acl.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupByID", id)
+	ret0, _ := ret[0].(acl.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupByID indicates an expected call of GetGroupByID.
+func (mr *MockiGroupAdminRepoMockRecorder) GetGroupByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByID", reflect.TypeOf((*MockiGroupAdminRepo)(nil).GetGroupByID), id)
+}
+
+// GetGroupsByUserID mocks base method.
+func (m *MockiGroupAdminRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsByUserID", userID)
+	ret0, _ := ret[0].([]acl.GroupRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsByUserID indicates an expected call of GetGroupsByUserID.
+func (mr *MockiGroupAdminRepoMockRecorder) GetGroupsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByUserID", reflect.TypeOf((*MockiGroupAdminRepo)(nil).GetGroupsByUserID), userID)
+}
+
+// GetUserGroup mocks base method.
+func (m *MockiGroupAdminRepo) GetUserGroup(userID, groupID string) (acl.UserGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserGroup", userID, groupID)
+	ret0, _ := ret[0].(acl.UserGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserGroup indicates an expected call of GetUserGroup.
+func (mr *MockiGroupAdminRepoMockRecorder) GetUserGroup(userID, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserGroup", reflect.TypeOf((*MockiGroupAdminRepo)(nil).GetUserGroup), userID, groupID)
+}
@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/tidwall/buntdb"
+)
+
+// BackupJanitor takes a snapshot on schedule and keeps the newest cfg.BackupRetention of them.
+type BackupJanitor struct {
+	snapshots SnapshotUsecase
+	retention int
+}
+
+func NewBackupJanitor(db *buntdb.DB, cfg *config.Config) BackupJanitor {
+	return BackupJanitor{
+		snapshots: NewSnapshotUsecase(db, cfg),
+		retention: cfg.BackupRetention,
+	}
+}
+
+// Run takes a snapshot every interval until ctx is done.
+func (j BackupJanitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			info, pruned, err := j.Backup(now)
+			if err != nil {
+				log.Printf("[BACKUP] error taking scheduled snapshot: %s", err)
+				continue
+			}
+			log.Printf("[BACKUP] snapshot %s taken, %d bytes, sha256 %s", info.File, info.Size, info.Checksum)
+			if pruned > 0 {
+				log.Printf("[BACKUP] deleted %d snapshots beyond the retention of %d", pruned, j.retention)
+			}
+		}
+	}
+}
+
+// Backup takes a snapshot at now, then deletes the snapshots beyond the retention. The old
+// snapshots are only deleted once the new one is saved.
+func (j BackupJanitor) Backup(now time.Time) (SnapshotInfo, int, error) {
+	info, err := j.snapshots.TakeSnapshot(now)
+	if err != nil {
+		return SnapshotInfo{}, 0, err
+	}
+	pruned, err := j.snapshots.PruneSnapshots(j.retention)
+	return info, pruned, err
+}
@@ -0,0 +1,62 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	backup_mock "github.com/jekiapp/topic-master/internal/usecase/backup/mock"
+	pkgdb "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// saveFile stands in for the database save, writing a small file and its checksum
+func saveFile(path string) (string, error) {
+	if err := os.WriteFile(path, []byte(filepath.Base(path)), 0o600); err != nil {
+		return "", err
+	}
+	checksum, err := pkgdb.FileChecksum(path)
+	if err != nil {
+		return "", err
+	}
+	return checksum, os.WriteFile(path+pkgdb.ChecksumSuffix, []byte(checksum+"  "+filepath.Base(path)+"\n"), 0o600)
+}
+
+func TestBackupJanitor_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	// taken before a migration, never pruned
+	if _, err := saveFile(filepath.Join(dir, "before-migration-1-20240101-000000.db")); err != nil {
+		t.Fatal(err)
+	}
+	m := backup_mock.NewMockiSnapshotRepo(ctrl)
+	m.EXPECT().SaveSnapshot(gomock.Any()).DoAndReturn(saveFile).Times(4)
+	j := BackupJanitor{snapshots: SnapshotUsecase{repo: m, dir: dir}, retention: 2}
+
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var pruned int
+	for i := 0; i < 4; i++ {
+		info, n, err := j.Backup(start.Add(time.Duration(i) * time.Hour))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, info.Checksum)
+		pruned += n
+	}
+	assert.Equal(t, 2, pruned)
+
+	names, _ := filepath.Glob(filepath.Join(dir, "*"))
+	for i := range names {
+		names[i] = filepath.Base(names[i])
+	}
+	assert.ElementsMatch(t, []string{
+		"before-migration-1-20240101-000000.db",
+		"before-migration-1-20240101-000000.db.sha256",
+		"snapshot-20240501-120000.000.db",
+		"snapshot-20240501-120000.000.db.sha256",
+		"snapshot-20240501-130000.000.db",
+		"snapshot-20240501-130000.000.db.sha256",
+	}, names)
+}
@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// ExportStateUsecase returns the state as JSON, to load into another instance with ImportStateUsecase.
+type ExportStateUsecase struct {
+	store stateStore
+}
+
+func NewExportStateUsecase(db *buntdb.DB) ExportStateUsecase {
+	return ExportStateUsecase{store: stateStore{db: db}}
+}
+
+func (uc ExportStateUsecase) HandleExport(ctx context.Context, params map[string]string) (StateExport, error) {
+	var state StateExport
+	err := uc.store.View(func(repo iStateRepo) error {
+		var err error
+		state, err = exportState(repo, time.Now())
+		return err
+	})
+	if err != nil {
+		return StateExport{}, fmt.Errorf("failed to export state: %w", err)
+	}
+	return state, nil
+}
+
+func exportState(repo iStateRepo, now time.Time) (StateExport, error) {
+	state := StateExport{FormatVersion: stateFormatVersion, ExportedAt: now}
+	var err error
+	if state.Users, err = repo.ListUsers(); err != nil {
+		return StateExport{}, err
+	}
+	if state.UserSecurity, err = exportUserSecurity(repo, state.Users); err != nil {
+		return StateExport{}, err
+	}
+	if state.Groups, err = repo.ListGroups(); err != nil {
+		return StateExport{}, err
+	}
+	if state.UserGroups, err = repo.ListUserGroups(); err != nil {
+		return StateExport{}, err
+	}
+	if state.Entities, err = repo.ListEntities(); err != nil {
+		return StateExport{}, err
+	}
+	if state.Grants, err = repo.ListPermissionMaps(); err != nil {
+		return StateExport{}, err
+	}
+
+	apps, err := repo.ListApplications()
+	if err != nil {
+		return StateExport{}, err
+	}
+	state.Tickets = make([]TicketExport, 0, len(apps))
+	for _, app := range apps {
+		ticket := TicketExport{Application: app}
+		if ticket.Assignments, err = repo.ListAssignmentsByApplicationID(app.ID); err != nil {
+			return StateExport{}, err
+		}
+		if ticket.History, err = repo.ListHistoriesByApplicationID(app.ID); err != nil {
+			return StateExport{}, err
+		}
+		if ticket.Comments, err = repo.ListCommentsByApplicationID(app.ID); err != nil {
+			return StateExport{}, err
+		}
+		state.Tickets = append(state.Tickets, ticket)
+	}
+	return state, nil
+}
+
+// exportUserSecurity lists the users having a two-factor setting or revoked sessions
+func exportUserSecurity(repo iStateRepo, users []acl.User) ([]UserSecurity, error) {
+	security := []UserSecurity{}
+	for _, user := range users {
+		sec := UserSecurity{UserID: user.ID, TokensValidAfter: user.TokensValidAfter}
+		totp, err := repo.GetUserTOTP(user.ID)
+		if err != nil && !errors.Is(err, db.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			sec.TwoFactor = &TwoFactorExport{
+				Secret:        totp.Secret,
+				Enabled:       totp.Enabled,
+				RecoveryCodes: totp.RecoveryCodes,
+				LastUsedStep:  totp.LastUsedStep,
+				CreatedAt:     totp.CreatedAt,
+				EnabledAt:     totp.EnabledAt,
+			}
+		}
+		if sec.TwoFactor == nil && sec.TokensValidAfter == 0 {
+			continue
+		}
+		security = append(security, sec)
+	}
+	return security, nil
+}
@@ -0,0 +1,51 @@
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	backup_mock "github.com/jekiapp/topic-master/internal/usecase/backup/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	m := backup_mock.NewMockiStateRepo(ctrl)
+	m.EXPECT().ListUsers().Return([]acl.User{
+		{ID: "u1", Username: "alice", TokensValidAfter: 1700000000},
+		{ID: "u2", Username: "bob"},
+		{ID: "u3", Username: "carol"},
+	}, nil)
+	m.EXPECT().GetUserTOTP("u1").Return(acl.UserTOTP{UserID: "u1", Secret: "JBSWY3DP", Enabled: true, RecoveryCodes: []string{"c1"}}, nil)
+	m.EXPECT().GetUserTOTP("u2").Return(acl.UserTOTP{}, db.ErrNotFound)
+	m.EXPECT().GetUserTOTP("u3").Return(acl.UserTOTP{UserID: "u3", Secret: "KRSXG5CT"}, nil)
+	m.EXPECT().ListGroups().Return([]acl.Group{{ID: "g1", Name: "team"}}, nil)
+	m.EXPECT().ListUserGroups().Return([]acl.UserGroup{{ID: "ug1", UserID: "u1", GroupID: "g1"}}, nil)
+	m.EXPECT().ListEntities().Return([]entity.Entity{{ID: "e1", Name: "orders"}}, nil)
+	m.EXPECT().ListPermissionMaps().Return([]acl.PermissionMap{}, nil)
+	m.EXPECT().ListApplications().Return([]acl.Application{{ID: "a1"}}, nil)
+	m.EXPECT().ListAssignmentsByApplicationID("a1").Return([]acl.ApplicationAssignment{{ID: "as1"}}, nil)
+	m.EXPECT().ListHistoriesByApplicationID("a1").Return([]acl.ApplicationHistory{{ID: "h1"}}, nil)
+	m.EXPECT().ListCommentsByApplicationID("a1").Return([]acl.ApplicationComment{}, nil)
+
+	state, err := exportState(m, now)
+	assert.NoError(t, err)
+	assert.Equal(t, stateFormatVersion, state.FormatVersion)
+	assert.Equal(t, now, state.ExportedAt)
+	assert.Len(t, state.Users, 3)
+	// the fields acl.User and acl.UserTOTP keep out of JSON are exported beside them
+	assert.Equal(t, []UserSecurity{
+		{UserID: "u1", TokensValidAfter: 1700000000, TwoFactor: &TwoFactorExport{Secret: "JBSWY3DP", Enabled: true, RecoveryCodes: []string{"c1"}}},
+		{UserID: "u3", TwoFactor: &TwoFactorExport{Secret: "KRSXG5CT"}},
+	}, state.UserSecurity)
+	assert.Len(t, state.Tickets, 1)
+	assert.Equal(t, "as1", state.Tickets[0].Assignments[0].ID)
+	assert.Equal(t, "h1", state.Tickets[0].History[0].ID)
+}
@@ -0,0 +1,353 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// ImportStateResponse counts the records created by an import, and the existing entities given
+// the owner or description they lacked.
+type ImportStateResponse struct {
+	Users           int `json:"users"`
+	Groups          int `json:"groups"`
+	Memberships     int `json:"memberships"`
+	Entities        int `json:"entities"`
+	EntitiesUpdated int `json:"entities_updated"`
+	Grants          int `json:"grants"`
+	Tickets         int `json:"tickets"`
+}
+
+// ImportStateUsecase loads an export into this instance. The records are matched by what names
+// them, users by username, groups by name and entities by type and name, and the existing ones
+// win: an import only adds what is missing, so it can be run twice or onto a running instance.
+// A created user gets the second factor and the session cutoff it had, an existing one keeps its own.
+// The import is one transaction, nothing is written when any record fails.
+type ImportStateUsecase struct {
+	store stateStore
+}
+
+func NewImportStateUsecase(db *buntdb.DB) ImportStateUsecase {
+	return ImportStateUsecase{store: stateStore{db: db}}
+}
+
+func (uc ImportStateUsecase) HandleImport(ctx context.Context, state StateExport) (ImportStateResponse, error) {
+	if state.FormatVersion != stateFormatVersion {
+		return ImportStateResponse{}, fmt.Errorf("unsupported export format %d, want %d", state.FormatVersion, stateFormatVersion)
+	}
+	var resp ImportStateResponse
+	err := uc.store.Update(func(repo iStateRepo) error {
+		var err error
+		resp, err = importState(repo, state, time.Now())
+		return err
+	})
+	if err != nil {
+		return ImportStateResponse{}, fmt.Errorf("failed to import state: %w", err)
+	}
+	if user := util.GetUserInfo(ctx); user != nil {
+		log.Printf("[BACKUP] state exported at %s imported by %s: %+v", state.ExportedAt.Format(time.RFC3339), user.Username, resp)
+	}
+	return resp, nil
+}
+
+// idMap maps the ids of the export to the ids of the records in this instance
+type idMap map[string]string
+
+// get returns the id id is mapped to, or id itself for the records outside the export such as
+// pending signups and ActorSystem.
+func (m idMap) get(id string) string {
+	if mapped, ok := m[id]; ok {
+		return mapped
+	}
+	return id
+}
+
+type stateImport struct {
+	repo     iStateRepo
+	now      time.Time
+	users    idMap
+	groups   idMap
+	entities idMap
+	resp     ImportStateResponse
+}
+
+func importState(repo iStateRepo, state StateExport, now time.Time) (ImportStateResponse, error) {
+	imp := &stateImport{repo: repo, now: now, users: idMap{}, groups: idMap{}, entities: idMap{}}
+	steps := []func(StateExport) error{
+		imp.importGroups,
+		imp.importUsers,
+		imp.importMemberships,
+		imp.importEntities,
+		imp.importGrants,
+		imp.importTickets,
+	}
+	for _, step := range steps {
+		if err := step(state); err != nil {
+			return ImportStateResponse{}, err
+		}
+	}
+	return imp.resp, nil
+}
+
+// freeID returns id, or a new one when the export has none or another record of this instance holds it.
+func freeID[T any](id string, getByID func(id string) (T, error)) (string, error) {
+	if id == "" {
+		return uuid.NewString(), nil
+	}
+	taken, err := exists(getByID(id))
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return uuid.NewString(), nil
+	}
+	return id, nil
+}
+
+func (imp *stateImport) importGroups(state StateExport) error {
+	for _, group := range state.Groups {
+		existing, err := imp.repo.GetGroupByName(group.Name)
+		if err == nil {
+			imp.groups[group.ID] = existing.ID
+			continue
+		}
+		if !errors.Is(err, db.ErrNotFound) {
+			return err
+		}
+		newID, err := freeID(group.ID, imp.repo.GetGroupByID)
+		if err != nil {
+			return err
+		}
+		imp.groups[group.ID] = newID
+		group.ID = newID
+		if err := imp.repo.CreateGroup(group); err != nil {
+			return fmt.Errorf("group %s: %w", group.Name, err)
+		}
+		imp.resp.Groups++
+	}
+	return nil
+}
+
+func (imp *stateImport) importUsers(state StateExport) error {
+	security := make(map[string]UserSecurity, len(state.UserSecurity))
+	for _, sec := range state.UserSecurity {
+		security[sec.UserID] = sec
+	}
+	for _, user := range state.Users {
+		if user.Username == "" {
+			return fmt.Errorf("user %s has no username", user.ID)
+		}
+		existing, err := imp.repo.GetUserByUsername(user.Username)
+		if err == nil {
+			imp.users[user.ID] = existing.ID
+			continue
+		}
+		if !errors.Is(err, db.ErrNotFound) {
+			return err
+		}
+		newID, err := freeID(user.ID, imp.repo.GetUserByID)
+		if err != nil {
+			return err
+		}
+		sec := security[user.ID]
+		imp.users[user.ID] = newID
+		user.ID = newID
+		user.TokensValidAfter = sec.TokensValidAfter
+		groups := make([]acl.GroupRole, 0, len(user.Groups))
+		for _, group := range user.Groups {
+			group.GroupID = imp.groups.get(group.GroupID)
+			groups = append(groups, group)
+		}
+		user.Groups = groups
+		if err := imp.repo.CreateUser(user); err != nil {
+			return fmt.Errorf("user %s: %w", user.Username, err)
+		}
+		if tf := sec.TwoFactor; tf != nil {
+			totp := acl.UserTOTP{
+				UserID:        newID,
+				Secret:        tf.Secret,
+				Enabled:       tf.Enabled,
+				RecoveryCodes: tf.RecoveryCodes,
+				LastUsedStep:  tf.LastUsedStep,
+				CreatedAt:     tf.CreatedAt,
+				EnabledAt:     tf.EnabledAt,
+			}
+			if err := imp.repo.CreateUserTOTP(totp); err != nil {
+				return fmt.Errorf("two-factor of user %s: %w", user.Username, err)
+			}
+		}
+		imp.resp.Users++
+	}
+	return nil
+}
+
+func (imp *stateImport) importMemberships(state StateExport) error {
+	for _, membership := range state.UserGroups {
+		userID, okUser := imp.users[membership.UserID]
+		groupID, okGroup := imp.groups[membership.GroupID]
+		if !okUser || !okGroup {
+			continue // refers to a record missing from the export
+		}
+		found, err := imp.repo.HasUserGroup(userID, groupID)
+		if err != nil {
+			return err
+		}
+		if found {
+			continue
+		}
+		membership.ID = uuid.NewString()
+		membership.UserID = userID
+		membership.GroupID = groupID
+		if err := imp.repo.CreateUserGroup(membership); err != nil {
+			return fmt.Errorf("membership of user %s in group %s: %w", userID, groupID, err)
+		}
+		imp.resp.Memberships++
+	}
+	return nil
+}
+
+func (imp *stateImport) importEntities(state StateExport) error {
+	for _, ent := range state.Entities {
+		// the owner group only follows when it was exported too
+		ownerID, owned := imp.groups[ent.GroupOwnerID]
+		owned = owned && ent.IsOwned()
+
+		existing, err := imp.repo.GetEntityByTypeName(ent.TypeID, ent.Name)
+		if err == nil {
+			imp.entities[ent.ID] = existing.ID
+			changed := false
+			if owned && !existing.IsOwned() {
+				existing.SetOwner(ownerID, ent.GroupOwner)
+				changed = true
+			}
+			if existing.Description == "" && ent.Description != "" {
+				existing.Description = ent.Description
+				changed = true
+			}
+			if !changed {
+				continue
+			}
+			existing.UpdatedAt = imp.now
+			if err := imp.repo.UpdateEntity(existing); err != nil {
+				return fmt.Errorf("entity %s %s: %w", ent.TypeID, ent.Name, err)
+			}
+			imp.resp.EntitiesUpdated++
+			continue
+		}
+		if !errors.Is(err, db.ErrNotFound) {
+			return err
+		}
+		newID, err := freeID(ent.ID, imp.repo.GetEntityByID)
+		if err != nil {
+			return err
+		}
+		imp.entities[ent.ID] = newID
+		ent.ID = newID
+		if owned {
+			ent.SetOwner(ownerID, ent.GroupOwner)
+		} else {
+			ent.SetOwner("", "")
+		}
+		if err := imp.repo.CreateEntity(ent); err != nil {
+			return fmt.Errorf("entity %s %s: %w", ent.TypeID, ent.Name, err)
+		}
+		imp.resp.Entities++
+	}
+	return nil
+}
+
+func (imp *stateImport) importGrants(state StateExport) error {
+	for _, perm := range state.Grants {
+		userID, okUser := imp.users[perm.UserID]
+		entityID, okEntity := imp.entities[perm.EntityID]
+		if !okUser || !okEntity {
+			continue // refers to a record missing from the export
+		}
+		found, err := imp.repo.HasPermissionMap(perm.Action, entityID, userID)
+		if err != nil {
+			return err
+		}
+		if found {
+			continue
+		}
+		perm.ID = uuid.NewString()
+		perm.UserID = userID
+		perm.EntityID = entityID
+		if err := imp.repo.CreatePermissionMap(perm); err != nil {
+			return fmt.Errorf("grant %s on %s: %w", perm.Action, entityID, err)
+		}
+		imp.resp.Grants++
+	}
+	return nil
+}
+
+// importTickets adds the tickets missing from this instance with their assignments, history
+// and comments. A ticket already here is left as it is, the two copies may have moved on.
+func (imp *stateImport) importTickets(state StateExport) error {
+	for _, ticket := range state.Tickets {
+		app := ticket.Application
+		found, err := exists(imp.repo.GetApplicationByID(app.ID))
+		if err != nil {
+			return err
+		}
+		if found {
+			continue
+		}
+
+		app.UserID = imp.users.get(app.UserID)
+		metadata := make(map[string]string, len(app.MetaData))
+		for key, value := range app.MetaData {
+			switch key {
+			case acl.AppMetaData_EntityID:
+				value = imp.entities.get(value)
+			case acl.AppMetaData_GroupID, acl.AppMetaData_FromGroupID:
+				value = imp.groups.get(value)
+			}
+			metadata[key] = value
+		}
+		app.MetaData = metadata
+		stages := make([]acl.ApplicationStage, 0, len(app.Stages))
+		for _, stage := range app.Stages {
+			stage.ReviewerGroupID = imp.groups.get(stage.ReviewerGroupID)
+			stages = append(stages, stage)
+		}
+		app.Stages = stages
+		if err := imp.repo.CreateApplication(app); err != nil {
+			return fmt.Errorf("ticket %s: %w", app.ID, err)
+		}
+
+		for _, assignment := range ticket.Assignments {
+			assignment.ApplicationID = app.ID
+			assignment.ReviewerID = imp.users.get(assignment.ReviewerID)
+			assignment.OnBehalfOf = imp.users.get(assignment.OnBehalfOf)
+			if err := imp.repo.CreateApplicationAssignment(assignment); err != nil {
+				return fmt.Errorf("ticket %s: %w", app.ID, err)
+			}
+		}
+		for _, history := range ticket.History {
+			history.ApplicationID = app.ID
+			history.ActorID = imp.users.get(history.ActorID)
+			history.OnBehalfOf = imp.users.get(history.OnBehalfOf)
+			if err := imp.repo.CreateApplicationHistory(history); err != nil {
+				return fmt.Errorf("ticket %s: %w", app.ID, err)
+			}
+		}
+		for _, comment := range ticket.Comments {
+			comment.ApplicationID = app.ID
+			comment.AuthorID = imp.users.get(comment.AuthorID)
+			if err := imp.repo.CreateApplicationComment(comment); err != nil {
+				return fmt.Errorf("ticket %s: %w", app.ID, err)
+			}
+		}
+		imp.resp.Tickets++
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	backup_mock "github.com/jekiapp/topic-master/internal/usecase/backup/mock"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	state := StateExport{
+		FormatVersion: stateFormatVersion,
+		Groups: []acl.Group{
+			{ID: "g-root", Name: acl.GroupRoot},
+			{ID: "g-team", Name: "team"},
+		},
+		Users: []acl.User{
+			{ID: "u-root", Username: "root"},
+			{ID: "u-alice", Username: "alice", Groups: []acl.GroupRole{{GroupID: "g-team", GroupName: "team", Role: acl.RoleGroupMember}}},
+		},
+		UserSecurity: []UserSecurity{
+			// root keeps the second factor of this instance
+			{UserID: "u-root", TwoFactor: &TwoFactorExport{Secret: "ROOTSECRET", Enabled: true}},
+			{UserID: "u-alice", TokensValidAfter: 1700000000, TwoFactor: &TwoFactorExport{Secret: "JBSWY3DP", Enabled: true, RecoveryCodes: []string{"c1"}, EnabledAt: 1690000000}},
+		},
+		UserGroups: []acl.UserGroup{
+			{ID: "ug1", UserID: "u-root", GroupID: "g-root", Role: acl.RoleGroupAdmin},
+			{ID: "ug2", UserID: "u-alice", GroupID: "g-team", Role: acl.RoleGroupMember},
+			{ID: "ug3", UserID: "u-gone", GroupID: "g-team", Role: acl.RoleGroupMember},
+		},
+		Entities: []entity.Entity{
+			{ID: "e-orders", TypeID: entity.EntityType_NSQTopic, Name: "orders", GroupOwnerID: "g-team", GroupOwner: "team", Description: "order events"},
+			{ID: "e-users", TypeID: entity.EntityType_NSQTopic, Name: "users", GroupOwnerID: "g-team", GroupOwner: "team"},
+		},
+		Grants: []acl.PermissionMap{
+			{ID: "p1", Action: "publish", UserID: "u-alice", EntityID: "e-orders"},
+		},
+		Tickets: []TicketExport{
+			{
+				Application: acl.Application{
+					ID: "a1", UserID: "u-alice", Type: acl.ApplicationType_Claim,
+					MetaData: map[string]string{acl.AppMetaData_EntityID: "e-users", acl.AppMetaData_GroupID: "g-team"},
+					Stages:   []acl.ApplicationStage{{ReviewerGroupID: "g-root"}},
+				},
+				Assignments: []acl.ApplicationAssignment{{ID: "as1", ApplicationID: "a1", ReviewerID: "u-root"}},
+				History:     []acl.ApplicationHistory{{ID: "h1", ApplicationID: "a1", ActorID: acl.ActorSystem}},
+				Comments:    []acl.ApplicationComment{{ID: "c1", ApplicationID: "a1", AuthorID: "u-alice"}},
+			},
+			{Application: acl.Application{ID: "a-known"}},
+		},
+	}
+
+	m := backup_mock.NewMockiStateRepo(ctrl)
+	// root exists here with other ids, team and alice are new
+	m.EXPECT().GetGroupByName(acl.GroupRoot).Return(acl.Group{ID: "local-root", Name: acl.GroupRoot}, nil)
+	m.EXPECT().GetGroupByName("team").Return(acl.Group{}, db.ErrNotFound)
+	m.EXPECT().GetGroupByID("g-team").Return(acl.Group{}, db.ErrNotFound)
+	m.EXPECT().CreateGroup(acl.Group{ID: "g-team", Name: "team"}).Return(nil)
+	m.EXPECT().GetUserByUsername("root").Return(acl.User{ID: "local-u-root", Username: "root"}, nil)
+	m.EXPECT().GetUserByUsername("alice").Return(acl.User{}, db.ErrNotFound)
+	// the id of alice is held by another record, she gets a new one
+	m.EXPECT().GetUserByID("u-alice").Return(acl.User{ID: "u-alice", Username: "bob"}, nil)
+	var aliceID string
+	m.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(user acl.User) error {
+		assert.NotEqual(t, "u-alice", user.ID)
+		assert.Equal(t, "g-team", user.Groups[0].GroupID)
+		assert.Equal(t, int64(1700000000), user.TokensValidAfter)
+		aliceID = user.ID
+		return nil
+	})
+	m.EXPECT().CreateUserTOTP(gomock.Any()).DoAndReturn(func(totp acl.UserTOTP) error {
+		assert.Equal(t, acl.UserTOTP{UserID: aliceID, Secret: "JBSWY3DP", Enabled: true, RecoveryCodes: []string{"c1"}, EnabledAt: 1690000000}, totp)
+		return nil
+	})
+
+	m.EXPECT().HasUserGroup("local-u-root", "local-root").Return(true, nil)
+	m.EXPECT().HasUserGroup(gomock.Any(), "g-team").Return(false, nil)
+	m.EXPECT().CreateUserGroup(gomock.Any()).DoAndReturn(func(ug acl.UserGroup) error {
+		assert.Equal(t, aliceID, ug.UserID)
+		assert.NotEqual(t, "ug2", ug.ID)
+		return nil
+	})
+
+	// orders exists unowned and without description, users is new
+	m.EXPECT().GetEntityByTypeName(entity.EntityType_NSQTopic, "orders").Return(entity.Entity{
+		ID: "local-orders", TypeID: entity.EntityType_NSQTopic, Name: "orders", GroupOwnerID: entity.GroupNone, GroupOwner: entity.GroupNone,
+	}, nil)
+	m.EXPECT().UpdateEntity(gomock.Any()).DoAndReturn(func(ent entity.Entity) error {
+		assert.Equal(t, "local-orders", ent.ID)
+		assert.Equal(t, "g-team", ent.GroupOwnerID)
+		assert.Equal(t, "order events", ent.Description)
+		return nil
+	})
+	m.EXPECT().GetEntityByTypeName(entity.EntityType_NSQTopic, "users").Return(entity.Entity{}, db.ErrNotFound)
+	m.EXPECT().GetEntityByID("e-users").Return(entity.Entity{}, db.ErrNotFound)
+	m.EXPECT().CreateEntity(gomock.Any()).DoAndReturn(func(ent entity.Entity) error {
+		assert.Equal(t, "e-users", ent.ID)
+		assert.Equal(t, "g-team", ent.GroupOwnerID)
+		return nil
+	})
+
+	m.EXPECT().HasPermissionMap("publish", "local-orders", gomock.Any()).Return(false, nil)
+	m.EXPECT().CreatePermissionMap(gomock.Any()).DoAndReturn(func(perm acl.PermissionMap) error {
+		assert.Equal(t, aliceID, perm.UserID)
+		assert.Equal(t, "local-orders", perm.EntityID)
+		return nil
+	})
+
+	m.EXPECT().GetApplicationByID("a1").Return(acl.Application{}, db.ErrNotFound)
+	m.EXPECT().CreateApplication(gomock.Any()).DoAndReturn(func(app acl.Application) error {
+		assert.Equal(t, aliceID, app.UserID)
+		assert.Equal(t, "e-users", app.MetaData[acl.AppMetaData_EntityID])
+		assert.Equal(t, "local-root", app.Stages[0].ReviewerGroupID)
+		return nil
+	})
+	m.EXPECT().CreateApplicationAssignment(gomock.Any()).DoAndReturn(func(a acl.ApplicationAssignment) error {
+		assert.Equal(t, "local-u-root", a.ReviewerID)
+		return nil
+	})
+	m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+		assert.Equal(t, acl.ActorSystem, h.ActorID)
+		return nil
+	})
+	m.EXPECT().CreateApplicationComment(gomock.Any()).DoAndReturn(func(c acl.ApplicationComment) error {
+		assert.Equal(t, aliceID, c.AuthorID)
+		return nil
+	})
+	// a ticket already here is left as it is
+	m.EXPECT().GetApplicationByID("a-known").Return(acl.Application{ID: "a-known"}, nil)
+
+	resp, err := importState(m, state, now)
+	assert.NoError(t, err)
+	assert.Equal(t, ImportStateResponse{
+		Users: 1, Groups: 1, Memberships: 1, Entities: 1, EntitiesUpdated: 1, Grants: 1, Tickets: 1,
+	}, resp)
+}
+
+func TestImportStateUsecase_FormatVersion(t *testing.T) {
+	_, err := ImportStateUsecase{}.HandleImport(context.Background(), StateExport{FormatVersion: stateFormatVersion + 1})
+	assert.ErrorContains(t, err, "unsupported export format")
+	// without the two-factor settings the accounts would lose their second factor
+	_, err = ImportStateUsecase{}.HandleImport(context.Background(), StateExport{FormatVersion: 1})
+	assert.ErrorContains(t, err, "unsupported export format")
+}
@@ -0,0 +1,218 @@
+//go:generate mockgen -source=snapshot.go -destination=mock/mock_snapshot_repo.go -package=backup_mock
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	pkgdb "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	snapshotPrefix     = "snapshot-"
+	snapshotExt        = ".db"
+	snapshotTimeFormat = "20060102-150405.000" // sorts in time order, several snapshots can be taken in a second
+)
+
+type CreateSnapshotRequest struct{}
+
+// SnapshotInfo describes a copy of the database in the backup directory
+type SnapshotInfo struct {
+	File      string    `json:"file"`
+	Size      int64     `json:"size"`
+	Checksum  string    `json:"checksum"` // hex sha256, empty when the copy has no checksum file
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListSnapshotsResponse struct {
+	Snapshots []SnapshotInfo `json:"snapshots"`
+}
+
+type iSnapshotRepo interface {
+	SaveSnapshot(path string) (string, error)
+}
+
+type snapshotRepo struct {
+	db *buntdb.DB
+}
+
+func (r *snapshotRepo) SaveSnapshot(path string) (string, error) {
+	return pkgdb.BackupToFile(r.db, path)
+}
+
+// SnapshotUsecase saves consistent copies of the database to the backup directory while the
+// server keeps running, and lists and serves them to root.
+type SnapshotUsecase struct {
+	repo iSnapshotRepo
+	dir  string
+}
+
+func NewSnapshotUsecase(db *buntdb.DB, cfg *config.Config) SnapshotUsecase {
+	return SnapshotUsecase{
+		repo: &snapshotRepo{db: db},
+		dir:  cfg.BackupDir,
+	}
+}
+
+func (uc SnapshotUsecase) HandleCreate(ctx context.Context, req CreateSnapshotRequest) (SnapshotInfo, error) {
+	info, err := uc.TakeSnapshot(time.Now())
+	if err != nil {
+		return SnapshotInfo{}, fmt.Errorf("failed to take snapshot: %w", err)
+	}
+	if user := util.GetUserInfo(ctx); user != nil {
+		log.Printf("[BACKUP] snapshot %s taken by %s", info.File, user.Username)
+	}
+	return info, nil
+}
+
+// TakeSnapshot saves the database to a new snapshot file named after now, along with its checksum.
+func (uc SnapshotUsecase) TakeSnapshot(now time.Time) (SnapshotInfo, error) {
+	if uc.dir == "" {
+		return SnapshotInfo{}, errors.New("no backup directory configured")
+	}
+	name := snapshotPrefix + now.Format(snapshotTimeFormat) + snapshotExt
+	path := filepath.Join(uc.dir, name)
+	checksum, err := uc.repo.SaveSnapshot(path)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	return SnapshotInfo{File: name, Size: stat.Size(), Checksum: checksum, CreatedAt: now}, nil
+}
+
+func (uc SnapshotUsecase) HandleList(ctx context.Context, params map[string]string) (ListSnapshotsResponse, error) {
+	snapshots, err := listBackups(uc.dir)
+	if err != nil {
+		return ListSnapshotsResponse{}, fmt.Errorf("failed to list backups: %w", err)
+	}
+	return ListSnapshotsResponse{Snapshots: snapshots}, nil
+}
+
+// HandleDownload serves a file of the backup directory, ?file= names a database copy or its checksum.
+func (uc SnapshotUsecase) HandleDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	name := r.URL.Query().Get("file")
+	if !isBackupFile(name) {
+		http.Error(w, "invalid backup file", http.StatusBadRequest)
+		return
+	}
+	f, err := os.Open(filepath.Join(uc.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		http.Error(w, "backup not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	http.ServeContent(w, r, name, stat.ModTime(), f)
+}
+
+// PruneSnapshots deletes the oldest snapshots beyond the newest keep ones, with their checksum
+// files, and returns how many were deleted. The backups taken before a migration are kept, and
+// nothing is deleted when keep is not positive.
+func (uc SnapshotUsecase) PruneSnapshots(keep int) (int, error) {
+	if keep <= 0 || uc.dir == "" {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(uc.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && strings.HasPrefix(name, snapshotPrefix) && strings.HasSuffix(name, snapshotExt) {
+			names = append(names, name)
+		}
+	}
+	if len(names) <= keep {
+		return 0, nil
+	}
+	// the names hold the time they were taken, oldest first once sorted
+	sort.Strings(names)
+	deleted := 0
+	for _, name := range names[:len(names)-keep] {
+		path := filepath.Join(uc.dir, name)
+		if err := os.Remove(path); err != nil {
+			return deleted, err
+		}
+		if err := os.Remove(path + pkgdb.ChecksumSuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// listBackups returns the database copies of dir, newest first: the snapshots and the backups
+// taken before a migration.
+func listBackups(dir string) ([]SnapshotInfo, error) {
+	backups := []SnapshotInfo{}
+	if dir == "" {
+		return backups, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return backups, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), snapshotExt) {
+			continue
+		}
+		stat, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		info := SnapshotInfo{File: entry.Name(), Size: stat.Size(), CreatedAt: stat.ModTime()}
+		if saved, err := os.ReadFile(filepath.Join(dir, entry.Name()+pkgdb.ChecksumSuffix)); err == nil {
+			if fields := strings.Fields(string(saved)); len(fields) > 0 {
+				info.Checksum = fields[0]
+			}
+		}
+		backups = append(backups, info)
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// isBackupFile reports whether name is a file of the backup directory that may be downloaded,
+// rejecting anything reaching out of it.
+func isBackupFile(name string) bool {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return false
+	}
+	return strings.HasSuffix(name, snapshotExt) || strings.HasSuffix(name, snapshotExt+pkgdb.ChecksumSuffix)
+}
@@ -0,0 +1,69 @@
+package backup
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	backup_mock "github.com/jekiapp/topic-master/internal/usecase/backup/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSnapshotUsecase_HandleCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	m := backup_mock.NewMockiSnapshotRepo(ctrl)
+	m.EXPECT().SaveSnapshot(gomock.Any()).DoAndReturn(func(path string) (string, error) {
+		assert.Equal(t, dir, filepath.Dir(path))
+		return saveFile(path)
+	})
+	uc := SnapshotUsecase{repo: m, dir: dir}
+
+	info, err := uc.HandleCreate(context.Background(), CreateSnapshotRequest{})
+	assert.NoError(t, err)
+	assert.Regexp(t, `^snapshot-\d{8}-\d{6}\.\d{3}\.db$`, info.File)
+	assert.Positive(t, info.Size)
+
+	list, err := uc.HandleList(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, list.Snapshots, 1)
+	assert.Equal(t, info.Checksum, list.Snapshots[0].Checksum)
+
+	_, err = SnapshotUsecase{repo: m}.TakeSnapshot(time.Now())
+	assert.ErrorContains(t, err, "no backup directory configured")
+}
+
+func TestSnapshotUsecase_HandleDownload(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := saveFile(filepath.Join(dir, "snapshot-20240501-100000.000.db")); err != nil {
+		t.Fatal(err)
+	}
+	uc := SnapshotUsecase{dir: dir}
+
+	tests := []struct {
+		file       string
+		wantStatus int
+	}{
+		{"snapshot-20240501-100000.000.db", http.StatusOK},
+		{"snapshot-20240501-100000.000.db.sha256", http.StatusOK},
+		{"snapshot-20240501-110000.000.db", http.StatusNotFound},
+		{"../topic-master.db", http.StatusBadRequest},
+		{"/etc/passwd", http.StatusBadRequest},
+		{"notes.txt", http.StatusBadRequest},
+		{"", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/backup/download?file="+tt.file, nil)
+			rec := httptest.NewRecorder()
+			uc.HandleDownload(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+//go:generate mockgen -source=state.go -destination=mock/mock_state_repo.go -package=backup_mock
+package backup
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+// stateFormatVersion is bumped when StateExport changes in a way older imports cannot read.
+// Version 1 exports lack the two-factor settings of the users and are refused.
+const stateFormatVersion = 2
+
+// StateExport is the logical copy of the state worth moving to another instance: the accounts,
+// the groups and their members, the topics and channels with their owner and description, the
+// grants and the tickets. It holds the password hashes and the two-factor secrets of the users,
+// keep it like a backup.
+type StateExport struct {
+	FormatVersion int                 `json:"format_version"`
+	ExportedAt    time.Time           `json:"exported_at"`
+	Users         []acl.User          `json:"users"`
+	UserSecurity  []UserSecurity      `json:"user_security"`
+	Groups        []acl.Group         `json:"groups"`
+	UserGroups    []acl.UserGroup     `json:"user_groups"`
+	Entities      []entity.Entity     `json:"entities"`
+	Grants        []acl.PermissionMap `json:"grants"`
+	Tickets       []TicketExport      `json:"tickets"`
+}
+
+// UserSecurity carries the fields of a user that acl.User and acl.UserTOTP keep out of JSON,
+// so an imported account keeps its second factor and its revoked sessions stay revoked.
+type UserSecurity struct {
+	UserID           string           `json:"user_id"`
+	TokensValidAfter int64            `json:"tokens_valid_after,omitempty"`
+	TwoFactor        *TwoFactorExport `json:"two_factor,omitempty"`
+}
+
+// TwoFactorExport is an acl.UserTOTP with its secret and recovery codes
+type TwoFactorExport struct {
+	Secret        string   `json:"secret"`
+	Enabled       bool     `json:"enabled"`
+	RecoveryCodes []string `json:"recovery_codes"`
+	LastUsedStep  int64    `json:"last_used_step"`
+	CreatedAt     int64    `json:"created_at"`
+	EnabledAt     int64    `json:"enabled_at"`
+}
+
+// TicketExport is an application with the records hanging off it
+type TicketExport struct {
+	Application acl.Application             `json:"application"`
+	Assignments []acl.ApplicationAssignment `json:"assignments"`
+	History     []acl.ApplicationHistory    `json:"history"`
+	Comments    []acl.ApplicationComment    `json:"comments"`
+}
+
+type iStateRepo interface {
+	ListUsers() ([]acl.User, error)
+	ListGroups() ([]acl.Group, error)
+	ListUserGroups() ([]acl.UserGroup, error)
+	ListEntities() ([]entity.Entity, error)
+	ListPermissionMaps() ([]acl.PermissionMap, error)
+	ListApplications() ([]acl.Application, error)
+	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
+	ListHistoriesByApplicationID(appID string) ([]acl.ApplicationHistory, error)
+	ListCommentsByApplicationID(appID string) ([]acl.ApplicationComment, error)
+
+	GetUserTOTP(userID string) (acl.UserTOTP, error)
+	GetUserByID(id string) (acl.User, error)
+	GetUserByUsername(username string) (acl.User, error)
+	GetGroupByID(id string) (acl.Group, error)
+	GetGroupByName(name string) (acl.Group, error)
+	GetEntityByID(id string) (entity.Entity, error)
+	GetEntityByTypeName(typeID, name string) (entity.Entity, error)
+	GetApplicationByID(id string) (acl.Application, error)
+	HasUserGroup(userID, groupID string) (bool, error)
+	HasPermissionMap(action, entityID, userID string) (bool, error)
+
+	CreateUser(user acl.User) error
+	CreateUserTOTP(totp acl.UserTOTP) error
+	CreateGroup(group acl.Group) error
+	CreateUserGroup(userGroup acl.UserGroup) error
+	CreateEntity(ent entity.Entity) error
+	UpdateEntity(ent entity.Entity) error
+	CreatePermissionMap(perm acl.PermissionMap) error
+	CreateApplication(app acl.Application) error
+	CreateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	CreateApplicationHistory(history acl.ApplicationHistory) error
+	CreateApplicationComment(comment acl.ApplicationComment) error
+}
+
+// stateStore runs the export in one read transaction, a consistent view of the state, and the
+// import in one write transaction, applied whole or not at all.
+type stateStore struct {
+	db *buntdb.DB
+}
+
+func (s stateStore) View(fn func(repo iStateRepo) error) error {
+	return s.db.View(func(tx *buntdb.Tx) error {
+		return fn(&stateRepo{tx: tx})
+	})
+}
+
+func (s stateStore) Update(fn func(repo iStateRepo) error) error {
+	return db.WithTx(s.db, func(tx *buntdb.Tx) error {
+		return fn(&stateRepo{tx: tx})
+	})
+}
+
+type stateRepo struct {
+	tx *buntdb.Tx
+}
+
+// selectAll lists the records of T found in indexName, none is not an error
+func selectAll[T any](tx *buntdb.Tx, pivot, indexName string) ([]T, error) {
+	records, err := db.SelectAllTx[T](tx, pivot, indexName)
+	if errors.Is(err, db.ErrNotFound) {
+		return []T{}, nil
+	}
+	return records, err
+}
+
+func (r *stateRepo) ListUsers() ([]acl.User, error) {
+	return selectAll[acl.User](r.tx, "*", acl.IdxUser_Username)
+}
+
+func (r *stateRepo) ListGroups() ([]acl.Group, error) {
+	return selectAll[acl.Group](r.tx, "*", acl.IdxGroup_Name)
+}
+
+func (r *stateRepo) ListUserGroups() ([]acl.UserGroup, error) {
+	return selectAll[acl.UserGroup](r.tx, "*", acl.IdxUserGroup_ID)
+}
+
+func (r *stateRepo) ListEntities() ([]entity.Entity, error) {
+	return selectAll[entity.Entity](r.tx, "*", entity.IdxEntity_TypeName)
+}
+
+func (r *stateRepo) ListPermissionMaps() ([]acl.PermissionMap, error) {
+	return selectAll[acl.PermissionMap](r.tx, "*", acl.IdxPermissionMap_Entity)
+}
+
+func (r *stateRepo) ListApplications() ([]acl.Application, error) {
+	return selectAll[acl.Application](r.tx, "*", acl.IdxApplication_Type)
+}
+
+func (r *stateRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	return selectAll[acl.ApplicationAssignment](r.tx, "="+appID, acl.IdxAppAssign_ApplicationID)
+}
+
+func (r *stateRepo) ListHistoriesByApplicationID(appID string) ([]acl.ApplicationHistory, error) {
+	return selectAll[acl.ApplicationHistory](r.tx, "="+appID, acl.IdxAppHistory_ApplicationID)
+}
+
+func (r *stateRepo) ListCommentsByApplicationID(appID string) ([]acl.ApplicationComment, error) {
+	return selectAll[acl.ApplicationComment](r.tx, "="+appID, acl.IdxAppComment_ApplicationID)
+}
+
+func (r *stateRepo) GetUserTOTP(userID string) (acl.UserTOTP, error) {
+	return db.GetByIDTx[acl.UserTOTP](r.tx, userID)
+}
+
+func (r *stateRepo) GetUserByID(id string) (acl.User, error) {
+	return db.GetByIDTx[acl.User](r.tx, id)
+}
+
+func (r *stateRepo) GetUserByUsername(username string) (acl.User, error) {
+	return db.SelectOneTx[acl.User](r.tx, username, acl.IdxUser_Username)
+}
+
+func (r *stateRepo) GetGroupByID(id string) (acl.Group, error) {
+	return db.GetByIDTx[acl.Group](r.tx, id)
+}
+
+func (r *stateRepo) GetGroupByName(name string) (acl.Group, error) {
+	return db.SelectOneTx[acl.Group](r.tx, name, acl.IdxGroup_Name)
+}
+
+func (r *stateRepo) GetEntityByID(id string) (entity.Entity, error) {
+	return db.GetByIDTx[entity.Entity](r.tx, id)
+}
+
+func (r *stateRepo) GetEntityByTypeName(typeID, name string) (entity.Entity, error) {
+	return db.SelectOneTx[entity.Entity](r.tx, typeID+":"+name, entity.IdxEntity_TypeName)
+}
+
+func (r *stateRepo) GetApplicationByID(id string) (acl.Application, error) {
+	return db.GetByIDTx[acl.Application](r.tx, id)
+}
+
+func (r *stateRepo) HasUserGroup(userID, groupID string) (bool, error) {
+	return exists(db.SelectOneTx[acl.UserGroup](r.tx, groupID+":"+userID, acl.IdxUserGroup_ID))
+}
+
+func (r *stateRepo) HasPermissionMap(action, entityID, userID string) (bool, error) {
+	return exists(db.SelectOneTx[acl.PermissionMap](r.tx, action+":"+entityID+":"+userID, acl.IdxPermissionMap_ActionEntityUser))
+}
+
+func exists[T any](_ T, err error) (bool, error) {
+	if errors.Is(err, db.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *stateRepo) CreateUser(user acl.User) error {
+	return db.InsertTx(r.tx, &user)
+}
+
+func (r *stateRepo) CreateUserTOTP(totp acl.UserTOTP) error {
+	return db.InsertTx(r.tx, &totp)
+}
+
+func (r *stateRepo) CreateGroup(group acl.Group) error {
+	return db.InsertTx(r.tx, &group)
+}
+
+func (r *stateRepo) CreateUserGroup(userGroup acl.UserGroup) error {
+	return db.InsertTx(r.tx, &userGroup)
+}
+
+func (r *stateRepo) CreateEntity(ent entity.Entity) error {
+	return db.InsertTx(r.tx, &ent)
+}
+
+func (r *stateRepo) UpdateEntity(ent entity.Entity) error {
+	return db.UpdateTx(r.tx, &ent)
+}
+
+func (r *stateRepo) CreatePermissionMap(perm acl.PermissionMap) error {
+	return db.InsertTx(r.tx, &perm)
+}
+
+func (r *stateRepo) CreateApplication(app acl.Application) error {
+	return db.InsertTx(r.tx, &app)
+}
+
+func (r *stateRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return db.InsertTx(r.tx, &assignment)
+}
+
+func (r *stateRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return db.InsertTx(r.tx, &history)
+}
+
+func (r *stateRepo) CreateApplicationComment(comment acl.ApplicationComment) error {
+	return db.InsertTx(r.tx, &comment)
+}
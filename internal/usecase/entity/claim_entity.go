@@ -10,9 +10,9 @@ import (
 	usergrouplogic "github.com/jekiapp/topic-master/internal/logic/user_group"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	entitymodel "github.com/jekiapp/topic-master/internal/model/entity"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
 	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
 	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
-	"github.com/jekiapp/topic-master/pkg/db"
 	util "github.com/jekiapp/topic-master/pkg/util"
 	"github.com/tidwall/buntdb"
 )
@@ -29,24 +29,20 @@ type ClaimEntityResponse struct {
 }
 
 type iClaimEntityRepo interface {
-	CreateApplication(app acl.Application) error
 	GetGroupByName(name string) (acl.Group, error)
 	GetUserGroup(userID, groupID string) (acl.UserGroup, error)
 	ListUserGroupsByGroupID(groupID string, limit int) ([]acl.UserGroup, error)
 	GetReviewerIDsByGroupID(groupID string) ([]string, error)
-	CreateApplicationAssignment(assignment acl.ApplicationAssignment) error
-	CreateApplicationHistory(history acl.ApplicationHistory) error
+	WithApplicationTx(fn func(w auth.IApplicationWriter) error) error
 	GetEntityByID(entityID string) (entitymodel.Entity, error)
+	GetGroupByID(id string) (acl.Group, error)
+	ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error)
 }
 
 type claimEntityRepo struct {
 	db *buntdb.DB
 }
 
-func (r *claimEntityRepo) CreateApplication(app acl.Application) error {
-	return db.Insert(r.db, &app)
-}
-
 func (r *claimEntityRepo) GetGroupByName(name string) (acl.Group, error) {
 	return userrepo.GetGroupByName(r.db, name)
 }
@@ -63,12 +59,8 @@ func (r *claimEntityRepo) GetReviewerIDsByGroupID(groupID string) ([]string, err
 	return usergrouplogic.GetReviewerIDsByGroupID(r.db, groupID)
 }
 
-func (r *claimEntityRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
-	return db.Insert(r.db, &assignment)
-}
-
-func (r *claimEntityRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
-	return db.Insert(r.db, &history)
+func (r *claimEntityRepo) WithApplicationTx(fn func(w auth.IApplicationWriter) error) error {
+	return auth.WithApplicationTx(r.db, fn)
 }
 
 func (r *claimEntityRepo) GetEntityByID(entityID string) (entitymodel.Entity, error) {
@@ -79,6 +71,14 @@ func (r *claimEntityRepo) GetEntityByID(entityID string) (entitymodel.Entity, er
 	return entityObj, nil
 }
 
+func (r *claimEntityRepo) GetGroupByID(id string) (acl.Group, error) {
+	return userrepo.GetGroupByID(r.db, id)
+}
+
+func (r *claimEntityRepo) ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error) {
+	return apprepo.ListApprovalPoliciesByType(r.db, applicationType)
+}
+
 type ClaimEntityUsecase struct {
 	repo iClaimEntityRepo
 }
@@ -123,16 +123,27 @@ func (uc ClaimEntityUsecase) Handle(ctx context.Context, req ClaimEntityRequest)
 	}
 
 	groupOwnerID := group.ID
-	if entityObj.GroupOwner != acl.GroupNone {
-		entityOwner, err := uc.repo.GetGroupByName(entityObj.GroupOwner)
+	currentOwnerID := ""
+	if entityObj.IsOwned() {
+		entityOwner, err := uc.repo.GetGroupByID(entityObj.GroupOwnerID)
 		// if err not nil, then it can be assumed the group is deleted
 		if err == nil {
 			groupOwnerID = entityOwner.ID
+			currentOwnerID = entityOwner.ID
 		} else {
-			log.Println("group owner not found", entityObj.GroupOwner)
+			log.Println("group owner not found", entityObj.GroupOwnerID)
 		}
 	}
 
+	stages, err := auth.ResolveApprovalStages(uc.repo, acl.ApplicationType_Claim, []string{acl.Permission_Claim_Entity.Name}, auth.ReviewerGroups{
+		DefaultGroupID:  groupOwnerID,
+		OwnerGroupID:    currentOwnerID,
+		ClaimantGroupID: group.ID,
+	})
+	if err != nil {
+		return ClaimEntityResponse{}, err
+	}
+
 	input := auth.CreateApplicationInput{
 		Title:              fmt.Sprintf("Claim %s:%s for group %s", entityObj.TypeID, entityObj.Name, req.GroupName),
 		ApplicationType:    acl.ApplicationType_Claim,
@@ -140,6 +151,7 @@ func (uc ClaimEntityUsecase) Handle(ctx context.Context, req ClaimEntityRequest)
 		Reason:             req.Reason,
 		ReviewerGroupID:    groupOwnerID,
 		MetaData:           map[string]string{"group_id": group.ID, "entity_id": req.EntityID},
+		Stages:             stages,
 		HistoryInitAction:  "Create claim ticket",
 		HistoryInitComment: fmt.Sprintf("Initial claim %s %s for group %s", entityObj.TypeID, entityObj.Name, req.GroupName),
 	}
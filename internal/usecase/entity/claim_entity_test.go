@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/jekiapp/topic-master/internal/logic/auth"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	entitymodel "github.com/jekiapp/topic-master/internal/model/entity"
 )
@@ -38,9 +39,18 @@ func (m *mockClaimEntityRepo) CreateApplicationAssignment(assignment acl.Applica
 func (m *mockClaimEntityRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
 	return nil
 }
+func (m *mockClaimEntityRepo) WithApplicationTx(fn func(w auth.IApplicationWriter) error) error {
+	return fn(m)
+}
 func (m *mockClaimEntityRepo) GetEntityByID(entityID string) (entitymodel.Entity, error) {
 	return m.getEntityByIDFunc(entityID)
 }
+func (m *mockClaimEntityRepo) GetGroupByID(id string) (acl.Group, error) {
+	return acl.Group{}, nil
+}
+func (m *mockClaimEntityRepo) ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error) {
+	return nil, nil
+}
 
 func TestClaimEntityRequest_Validate(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,226 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	entitymodel "github.com/jekiapp/topic-master/internal/model/entity"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	util "github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type DefaultPermissionItem struct {
+	Permission  string `json:"permission"`
+	Description string `json:"description"`
+	Audience    string `json:"audience"`
+}
+
+type GetDefaultPermissionsResponse struct {
+	EntityID    string                  `json:"entity_id"`
+	Name        string                  `json:"name"`
+	GroupOwner  string                  `json:"group_owner"`
+	Permissions []DefaultPermissionItem `json:"permissions"`
+}
+
+// Defaults maps a permission name to its audience, permissions left out fall back to the default audience.
+type SaveDefaultPermissionsRequest struct {
+	EntityID string            `json:"entity_id"`
+	Defaults map[string]string `json:"defaults"`
+}
+
+type SaveDefaultPermissionsResponse struct {
+	Success bool `json:"success"`
+}
+
+type iDefaultPermissionRepo interface {
+	GetEntityByID(entityID string) (entitymodel.Entity, error)
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	ListEntityDefaultPermissions(entityID string) ([]entitymodel.EntityDefaultPermission, error)
+	ReplaceEntityDefaultPermissions(entityID string, perms []entitymodel.EntityDefaultPermission) error
+}
+
+type defaultPermissionRepo struct {
+	db *buntdb.DB
+}
+
+func (r *defaultPermissionRepo) GetEntityByID(entityID string) (entitymodel.Entity, error) {
+	return entityrepo.GetEntityByID(r.db, entityID)
+}
+
+func (r *defaultPermissionRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *defaultPermissionRepo) ListEntityDefaultPermissions(entityID string) ([]entitymodel.EntityDefaultPermission, error) {
+	return entityrepo.ListEntityDefaultPermissions(r.db, entityID)
+}
+
+func (r *defaultPermissionRepo) ReplaceEntityDefaultPermissions(entityID string, perms []entitymodel.EntityDefaultPermission) error {
+	return entityrepo.ReplaceEntityDefaultPermissions(r.db, entityID, perms)
+}
+
+type DefaultPermissionUsecase struct {
+	repo iDefaultPermissionRepo
+}
+
+func NewDefaultPermissionUsecase(db *buntdb.DB) DefaultPermissionUsecase {
+	return DefaultPermissionUsecase{
+		repo: &defaultPermissionRepo{db: db},
+	}
+}
+
+func (r SaveDefaultPermissionsRequest) Validate() error {
+	if r.EntityID == "" {
+		return errors.New("missing entity_id")
+	}
+	for perm, audience := range r.Defaults {
+		if !entitymodel.IsValidAudience(audience) {
+			return fmt.Errorf("invalid audience %q for %s", audience, perm)
+		}
+	}
+	return nil
+}
+
+// HandleGet lists the default permissions of an entity, params should contain "entity_id".
+// The entity_id "unowned" returns the global default of the entities without owner.
+func (uc DefaultPermissionUsecase) HandleGet(ctx context.Context, params map[string]string) (GetDefaultPermissionsResponse, error) {
+	entityID := params["entity_id"]
+	if entityID == "" {
+		return GetDefaultPermissionsResponse{}, errors.New("missing entity_id")
+	}
+	permissions, resp, err := uc.authorize(ctx, entityID)
+	if err != nil {
+		return GetDefaultPermissionsResponse{}, err
+	}
+	stored, err := uc.repo.ListEntityDefaultPermissions(entityID)
+	if err != nil {
+		return GetDefaultPermissionsResponse{}, err
+	}
+	audiences := make(map[string]string, len(stored))
+	for _, p := range stored {
+		audiences[p.PermissionName] = p.Audience
+	}
+	resp.Permissions = make([]DefaultPermissionItem, 0, len(permissions))
+	for _, p := range permissions {
+		audience, ok := audiences[p.Name]
+		if !ok {
+			audience = entitymodel.DefaultAudience(entityID)
+		}
+		resp.Permissions = append(resp.Permissions, DefaultPermissionItem{
+			Permission:  p.Name,
+			Description: p.Description,
+			Audience:    audience,
+		})
+	}
+	return resp, nil
+}
+
+// HandleSave replaces the default permissions of an entity. Owners manage their entities,
+// root manages the global default of the entities without owner.
+func (uc DefaultPermissionUsecase) HandleSave(ctx context.Context, req SaveDefaultPermissionsRequest) (SaveDefaultPermissionsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return SaveDefaultPermissionsResponse{}, err
+	}
+	permissions, _, err := uc.authorize(ctx, req.EntityID)
+	if err != nil {
+		return SaveDefaultPermissionsResponse{}, err
+	}
+	valid := make(map[string]bool, len(permissions))
+	for _, p := range permissions {
+		valid[p.Name] = true
+	}
+
+	names := make([]string, 0, len(req.Defaults))
+	for perm := range req.Defaults {
+		if !valid[perm] {
+			return SaveDefaultPermissionsResponse{}, fmt.Errorf("permission %s can't be set on this entity", perm)
+		}
+		names = append(names, perm)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	perms := make([]entitymodel.EntityDefaultPermission, 0, len(names))
+	for _, perm := range names {
+		// the default audience needs no record
+		if req.Defaults[perm] == entitymodel.DefaultAudience(req.EntityID) {
+			continue
+		}
+		perms = append(perms, entitymodel.EntityDefaultPermission{
+			ID:             uuid.NewString(),
+			EntityID:       req.EntityID,
+			PermissionName: perm,
+			Audience:       req.Defaults[perm],
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+	if err := uc.repo.ReplaceEntityDefaultPermissions(req.EntityID, perms); err != nil {
+		return SaveDefaultPermissionsResponse{}, err
+	}
+	return SaveDefaultPermissionsResponse{Success: true}, nil
+}
+
+// authorize checks the user may manage the defaults of entityID and returns the permissions they cover.
+func (uc DefaultPermissionUsecase) authorize(ctx context.Context, entityID string) ([]acl.Permission, GetDefaultPermissionsResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return nil, GetDefaultPermissionsResponse{}, errors.New("user not found in context")
+	}
+	// the groups in the token may be stale, a removed owner must not open the entity to everyone
+	groups, err := uc.repo.GetGroupsByUserID(user.ID)
+	if err != nil {
+		return nil, GetDefaultPermissionsResponse{}, fmt.Errorf("failed to get the groups of the user: %w", err)
+	}
+	if entityID == entitymodel.DefaultPermission_Unowned {
+		if !isRootMember(groups) {
+			return nil, GetDefaultPermissionsResponse{}, errors.New("only root can manage the default of unowned entities")
+		}
+		return acl.RolePermissions, GetDefaultPermissionsResponse{
+			EntityID: entityID,
+			Name:     "Unowned entities",
+		}, nil
+	}
+
+	ent, err := uc.repo.GetEntityByID(entityID)
+	if err != nil {
+		return nil, GetDefaultPermissionsResponse{}, fmt.Errorf("entity %s not found", entityID)
+	}
+	if !ent.IsOwned() {
+		return nil, GetDefaultPermissionsResponse{}, errors.New("entity is not owned by any group, it follows the default of unowned entities")
+	}
+	if !auth.IsEntityOwner(groups, &ent) {
+		return nil, GetDefaultPermissionsResponse{}, fmt.Errorf("only members of %s can manage the defaults of %s", ent.GroupOwner, ent.Name)
+	}
+	var permissions []acl.Permission
+	switch ent.TypeID {
+	case entitymodel.EntityType_NSQTopic:
+		permissions = acl.TopicActionPermissions
+	case entitymodel.EntityType_NSQChannel:
+		permissions = acl.ChannelActionPermissions
+	default:
+		return nil, GetDefaultPermissionsResponse{}, fmt.Errorf("entity type %s has no default permissions", ent.TypeID)
+	}
+	return permissions, GetDefaultPermissionsResponse{
+		EntityID:   ent.ID,
+		Name:       ent.Name,
+		GroupOwner: ent.GroupOwner,
+	}, nil
+}
+
+func isRootMember(groups []acl.GroupRole) bool {
+	for _, g := range groups {
+		if g.GroupName == acl.GroupRoot {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	entitymodel "github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/util"
+)
+
+type mockDefaultPermissionRepo struct {
+	entity   entitymodel.Entity
+	groups   map[string][]acl.GroupRole // stored memberships by user id
+	stored   []entitymodel.EntityDefaultPermission
+	replaced map[string][]entitymodel.EntityDefaultPermission
+}
+
+func (m *mockDefaultPermissionRepo) GetEntityByID(entityID string) (entitymodel.Entity, error) {
+	if m.entity.ID != entityID {
+		return entitymodel.Entity{}, errors.New("not found")
+	}
+	return m.entity, nil
+}
+func (m *mockDefaultPermissionRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return m.groups[userID], nil
+}
+func (m *mockDefaultPermissionRepo) ListEntityDefaultPermissions(entityID string) ([]entitymodel.EntityDefaultPermission, error) {
+	return m.stored, nil
+}
+func (m *mockDefaultPermissionRepo) ReplaceEntityDefaultPermissions(entityID string, perms []entitymodel.EntityDefaultPermission) error {
+	if m.replaced == nil {
+		m.replaced = map[string][]entitymodel.EntityDefaultPermission{}
+	}
+	m.replaced[entityID] = perms
+	return nil
+}
+
+func TestDefaultPermissionUsecase_HandleSave(t *testing.T) {
+	owned := entitymodel.Entity{ID: "e1", TypeID: entitymodel.EntityType_NSQTopic, Name: "orders", GroupOwnerID: "g-payments", GroupOwner: "payments"}
+	unowned := entitymodel.Entity{ID: "e1", TypeID: entitymodel.EntityType_NSQTopic, Name: "orders", GroupOwnerID: entitymodel.GroupNone, GroupOwner: entitymodel.GroupNone}
+	owner := &acl.User{ID: "u1", Groups: []acl.GroupRole{{GroupID: "g-payments", GroupName: "payments", Role: acl.RoleGroupMember}}}
+	outsider := &acl.User{ID: "u2", Groups: []acl.GroupRole{{GroupID: "g-billing", GroupName: "billing", Role: acl.RoleGroupAdmin}}}
+	root := &acl.User{ID: "u0", Groups: []acl.GroupRole{{GroupID: "g-root", GroupName: acl.GroupRoot, Role: acl.RoleGroupAdmin}}}
+
+	tests := []struct {
+		name      string
+		input     SaveDefaultPermissionsRequest
+		user      *acl.User // Groups holds the claims of the token
+		stored    []acl.GroupRole
+		entity    entitymodel.Entity
+		wantSaved map[string]string
+		wantErr   string
+	}{
+		{
+			name: "owner opens tail to logged in users",
+			input: SaveDefaultPermissionsRequest{EntityID: "e1", Defaults: map[string]string{
+				acl.Permission_Topic_Tail.Name:    entitymodel.Audience_Authenticated,
+				acl.Permission_Topic_Publish.Name: entitymodel.Audience_None,
+			}},
+			user:      owner,
+			entity:    owned,
+			wantSaved: map[string]string{acl.Permission_Topic_Tail.Name: entitymodel.Audience_Authenticated},
+		},
+		{
+			name:    "outsider can't manage defaults",
+			input:   SaveDefaultPermissionsRequest{EntityID: "e1", Defaults: map[string]string{acl.Permission_Topic_Tail.Name: entitymodel.Audience_Anonymous}},
+			user:    outsider,
+			entity:  owned,
+			wantErr: "only members of payments",
+		},
+		{
+			name:    "channel permission on a topic",
+			input:   SaveDefaultPermissionsRequest{EntityID: "e1", Defaults: map[string]string{acl.Permission_Channel_Delete.Name: entitymodel.Audience_Anonymous}},
+			user:    owner,
+			entity:  owned,
+			wantErr: "can't be set on this entity",
+		},
+		{
+			name:    "invalid audience",
+			input:   SaveDefaultPermissionsRequest{EntityID: "e1", Defaults: map[string]string{acl.Permission_Topic_Tail.Name: "everyone"}},
+			user:    owner,
+			entity:  owned,
+			wantErr: "invalid audience",
+		},
+		{
+			name:    "unowned entity follows the global default",
+			input:   SaveDefaultPermissionsRequest{EntityID: "e1", Defaults: map[string]string{acl.Permission_Topic_Tail.Name: entitymodel.Audience_None}},
+			user:    root,
+			entity:  unowned,
+			wantErr: "not owned by any group",
+		},
+		{
+			name: "root restricts delete on unowned entities",
+			input: SaveDefaultPermissionsRequest{EntityID: entitymodel.DefaultPermission_Unowned, Defaults: map[string]string{
+				acl.Permission_Topic_Delete.Name: entitymodel.Audience_None,
+				acl.Permission_Topic_Tail.Name:   entitymodel.Audience_Anonymous,
+			}},
+			user:      root,
+			wantSaved: map[string]string{acl.Permission_Topic_Delete.Name: entitymodel.Audience_None},
+		},
+		{
+			name:    "removed owner keeping the group in the token",
+			input:   SaveDefaultPermissionsRequest{EntityID: "e1", Defaults: map[string]string{acl.Permission_Topic_Delete.Name: entitymodel.Audience_Anonymous}},
+			user:    owner,
+			stored:  []acl.GroupRole{},
+			entity:  owned,
+			wantErr: "only members of payments",
+		},
+		{
+			name:    "demoted root keeping root in the token",
+			input:   SaveDefaultPermissionsRequest{EntityID: entitymodel.DefaultPermission_Unowned, Defaults: map[string]string{acl.Permission_Topic_Delete.Name: entitymodel.Audience_Anonymous}},
+			user:    root,
+			stored:  []acl.GroupRole{{GroupID: "g-billing", GroupName: "billing", Role: acl.RoleGroupMember}},
+			wantErr: "only root",
+		},
+		{
+			name:    "only root manages the global default",
+			input:   SaveDefaultPermissionsRequest{EntityID: entitymodel.DefaultPermission_Unowned, Defaults: map[string]string{acl.Permission_Topic_Delete.Name: entitymodel.Audience_None}},
+			user:    owner,
+			wantErr: "only root",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := tt.stored
+			if stored == nil {
+				stored = tt.user.Groups
+			}
+			repo := &mockDefaultPermissionRepo{entity: tt.entity, groups: map[string][]acl.GroupRole{tt.user.ID: stored}}
+			uc := DefaultPermissionUsecase{repo: repo}
+			ctx := util.MockContextWithUser(context.Background(), tt.user)
+			got, err := uc.HandleSave(ctx, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Success {
+				t.Errorf("unexpected response %+v", got)
+			}
+			saved := repo.replaced[tt.input.EntityID]
+			if len(saved) != len(tt.wantSaved) {
+				t.Fatalf("saved %d defaults, want %d", len(saved), len(tt.wantSaved))
+			}
+			for _, p := range saved {
+				if tt.wantSaved[p.PermissionName] != p.Audience || p.EntityID != tt.input.EntityID || p.ID == "" {
+					t.Errorf("unexpected default %+v", p)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultPermissionUsecase_HandleGet(t *testing.T) {
+	owned := entitymodel.Entity{ID: "e1", TypeID: entitymodel.EntityType_NSQChannel, Name: "archiver", GroupOwnerID: "g-payments", GroupOwner: "payments"}
+	owner := &acl.User{ID: "u1", Groups: []acl.GroupRole{{GroupID: "g-payments", GroupName: "payments", Role: acl.RoleGroupAdmin}}}
+	repo := &mockDefaultPermissionRepo{
+		entity: owned,
+		groups: map[string][]acl.GroupRole{owner.ID: owner.Groups},
+		stored: []entitymodel.EntityDefaultPermission{{EntityID: "e1", PermissionName: acl.Permission_Channel_Pause.Name, Audience: entitymodel.Audience_Authenticated}},
+	}
+	uc := DefaultPermissionUsecase{repo: repo}
+	got, err := uc.HandleGet(util.MockContextWithUser(context.Background(), owner), map[string]string{"entity_id": "e1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Permissions) != len(acl.ChannelActionPermissions) {
+		t.Fatalf("got %d permissions, want %d", len(got.Permissions), len(acl.ChannelActionPermissions))
+	}
+	for _, p := range got.Permissions {
+		want := entitymodel.Audience_None
+		if p.Permission == acl.Permission_Channel_Pause.Name {
+			want = entitymodel.Audience_Authenticated
+		}
+		if p.Audience != want {
+			t.Errorf("audience of %s = %s, want %s", p.Permission, p.Audience, want)
+		}
+	}
+}
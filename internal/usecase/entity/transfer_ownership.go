@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	usergrouplogic "github.com/jekiapp/topic-master/internal/logic/user_group"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	entitymodel "github.com/jekiapp/topic-master/internal/model/entity"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	util "github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type TransferOwnershipRequest struct {
+	EntityID        string `json:"entity_id"`
+	TargetGroupName string `json:"target_group_name"`
+	Reason          string `json:"reason"`
+}
+
+type TransferOwnershipResponse struct {
+	ApplicationID string `json:"application_id"`
+	LinkRedirect  string `json:"link_redirect"`
+}
+
+type iTransferOwnershipRepo interface {
+	GetGroupByName(name string) (acl.Group, error)
+	GetGroupByID(id string) (acl.Group, error)
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	GetReviewerIDsByGroupID(groupID string) ([]string, error)
+	WithApplicationTx(fn func(w auth.IApplicationWriter) error) error
+	GetEntityByID(entityID string) (entitymodel.Entity, error)
+	ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error)
+}
+
+type transferOwnershipRepo struct {
+	db *buntdb.DB
+}
+
+func (r *transferOwnershipRepo) GetGroupByName(name string) (acl.Group, error) {
+	return userrepo.GetGroupByName(r.db, name)
+}
+
+func (r *transferOwnershipRepo) GetGroupByID(id string) (acl.Group, error) {
+	return userrepo.GetGroupByID(r.db, id)
+}
+
+func (r *transferOwnershipRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *transferOwnershipRepo) GetReviewerIDsByGroupID(groupID string) ([]string, error) {
+	return usergrouplogic.GetReviewerIDsByGroupID(r.db, groupID)
+}
+
+func (r *transferOwnershipRepo) WithApplicationTx(fn func(w auth.IApplicationWriter) error) error {
+	return auth.WithApplicationTx(r.db, fn)
+}
+
+func (r *transferOwnershipRepo) GetEntityByID(entityID string) (entitymodel.Entity, error) {
+	return entityrepo.GetEntityByID(r.db, entityID)
+}
+
+func (r *transferOwnershipRepo) ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error) {
+	return apprepo.ListApprovalPoliciesByType(r.db, applicationType)
+}
+
+type TransferOwnershipUsecase struct {
+	repo iTransferOwnershipRepo
+}
+
+func NewTransferOwnershipUsecase(db *buntdb.DB) TransferOwnershipUsecase {
+	return TransferOwnershipUsecase{
+		repo: &transferOwnershipRepo{db: db},
+	}
+}
+
+func (r TransferOwnershipRequest) Validate() error {
+	if r.EntityID == "" {
+		return errors.New("missing entity_id")
+	}
+	if r.TargetGroupName == "" {
+		return errors.New("missing target_group_name")
+	}
+	return nil
+}
+
+// Handle creates a ticket to move an owned entity to another group.
+// Members of the current owner group can request it, the current and the target group both approve.
+func (uc TransferOwnershipUsecase) Handle(ctx context.Context, req TransferOwnershipRequest) (TransferOwnershipResponse, error) {
+	if err := req.Validate(); err != nil {
+		return TransferOwnershipResponse{}, err
+	}
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TransferOwnershipResponse{}, errors.New("user not found in context")
+	}
+	entityObj, err := uc.repo.GetEntityByID(req.EntityID)
+	if err != nil {
+		return TransferOwnershipResponse{}, fmt.Errorf("entity %s not found", req.EntityID)
+	}
+	if !entityObj.IsOwned() {
+		return TransferOwnershipResponse{}, errors.New("entity is not owned by any group, claim it instead")
+	}
+	currentOwner, err := uc.repo.GetGroupByID(entityObj.GroupOwnerID)
+	if err != nil {
+		return TransferOwnershipResponse{}, fmt.Errorf("owner group of %s not found", entityObj.Name)
+	}
+	target, err := uc.repo.GetGroupByName(req.TargetGroupName)
+	if err != nil {
+		return TransferOwnershipResponse{}, fmt.Errorf("group %s not found", req.TargetGroupName)
+	}
+	if target.ID == currentOwner.ID {
+		return TransferOwnershipResponse{}, fmt.Errorf("%s is already owned by %s", entityObj.Name, target.Name)
+	}
+	// the groups in the token may be stale, a removed member must not hand the entity away.
+	// custom roles only hold actions on the entities, not their ownership
+	groups, err := uc.repo.GetGroupsByUserID(user.ID)
+	if err != nil {
+		return TransferOwnershipResponse{}, fmt.Errorf("failed to get groups of %s: %w", user.Username, err)
+	}
+	if !auth.IsEntityOwner(groups, &entityObj) {
+		return TransferOwnershipResponse{}, fmt.Errorf("only members of %s can transfer %s", currentOwner.Name, entityObj.Name)
+	}
+
+	stages, err := auth.ResolveApprovalStages(uc.repo, acl.ApplicationType_Transfer, []string{acl.Permission_Transfer_Entity.Name}, auth.ReviewerGroups{
+		DefaultGroupID:  currentOwner.ID,
+		OwnerGroupID:    currentOwner.ID,
+		ClaimantGroupID: target.ID,
+	})
+	if err != nil {
+		return TransferOwnershipResponse{}, err
+	}
+	// without a policy the current owner approves first, then the new owner
+	if len(stages) == 0 {
+		stages = []acl.ApplicationStage{
+			{Name: "Current owner", ReviewerGroupID: currentOwner.ID, ReviewerGroupName: currentOwner.Name, RequiredApprovals: 1},
+			{Name: "New owner", ReviewerGroupID: target.ID, ReviewerGroupName: target.Name, RequiredApprovals: 1},
+		}
+	}
+
+	out, err := auth.CreateApplication(ctx, auth.CreateApplicationInput{
+		Title:           fmt.Sprintf("Transfer %s:%s from %s to %s", entityObj.TypeID, entityObj.Name, currentOwner.Name, target.Name),
+		ApplicationType: acl.ApplicationType_Transfer,
+		PermissionIDs:   []string{acl.Permission_Transfer_Entity.Name},
+		Reason:          req.Reason,
+		ReviewerGroupID: currentOwner.ID,
+		MetaData: map[string]string{
+			acl.AppMetaData_EntityID:    entityObj.ID,
+			acl.AppMetaData_GroupID:     target.ID,
+			acl.AppMetaData_FromGroupID: currentOwner.ID,
+		},
+		Stages:             stages,
+		HistoryInitAction:  "Create ownership transfer ticket",
+		HistoryInitComment: fmt.Sprintf("Transfer %s %s from %s to %s", entityObj.TypeID, entityObj.Name, currentOwner.Name, target.Name),
+	}, uc.repo)
+	if err != nil {
+		return TransferOwnershipResponse{}, err
+	}
+	return TransferOwnershipResponse{
+		ApplicationID: out.ApplicationID,
+		LinkRedirect:  fmt.Sprintf("/#ticket-detail?id=%s", out.ApplicationID),
+	}, nil
+}
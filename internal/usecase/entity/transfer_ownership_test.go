@@ -0,0 +1,206 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	entitymodel "github.com/jekiapp/topic-master/internal/model/entity"
+	"github.com/jekiapp/topic-master/pkg/util"
+)
+
+type mockTransferOwnershipRepo struct {
+	groups       map[string]acl.Group // by id
+	members      map[string]bool      // userID + ":" + groupID
+	entity       entitymodel.Entity
+	createdApp   *acl.Application
+	createAppErr error
+}
+
+func (m *mockTransferOwnershipRepo) CreateApplication(app acl.Application) error {
+	if m.createAppErr != nil {
+		return m.createAppErr
+	}
+	m.createdApp = &app
+	return nil
+}
+func (m *mockTransferOwnershipRepo) GetGroupByName(name string) (acl.Group, error) {
+	for _, g := range m.groups {
+		if g.Name == name {
+			return g, nil
+		}
+	}
+	return acl.Group{}, errors.New("not found")
+}
+func (m *mockTransferOwnershipRepo) GetGroupByID(id string) (acl.Group, error) {
+	if g, ok := m.groups[id]; ok {
+		return g, nil
+	}
+	return acl.Group{}, errors.New("not found")
+}
+func (m *mockTransferOwnershipRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	var roles []acl.GroupRole
+	for id, g := range m.groups {
+		if m.members[userID+":"+id] {
+			roles = append(roles, acl.GroupRole{GroupID: id, GroupName: g.Name, Role: acl.RoleGroupMember})
+		}
+	}
+	return roles, nil
+}
+func (m *mockTransferOwnershipRepo) GetReviewerIDsByGroupID(groupID string) ([]string, error) {
+	return []string{"reviewer-" + groupID}, nil
+}
+func (m *mockTransferOwnershipRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return nil
+}
+func (m *mockTransferOwnershipRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return nil
+}
+func (m *mockTransferOwnershipRepo) WithApplicationTx(fn func(w auth.IApplicationWriter) error) error {
+	return fn(m)
+}
+func (m *mockTransferOwnershipRepo) GetEntityByID(entityID string) (entitymodel.Entity, error) {
+	if m.entity.ID != entityID {
+		return entitymodel.Entity{}, errors.New("not found")
+	}
+	return m.entity, nil
+}
+func (m *mockTransferOwnershipRepo) ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error) {
+	return nil, nil
+}
+
+func TestTransferOwnershipUsecase_Handle(t *testing.T) {
+	groups := map[string]acl.Group{
+		"g-payments": {ID: "g-payments", Name: "payments"},
+		"g-billing":  {ID: "g-billing", Name: "billing"},
+		"g-root":     {ID: "g-root", Name: acl.GroupRoot},
+	}
+	owned := entitymodel.Entity{ID: "e1", TypeID: "nsq_topic", Name: "orders", GroupOwnerID: "g-payments", GroupOwner: "payments"}
+	unowned := entitymodel.Entity{ID: "e1", TypeID: "nsq_topic", Name: "orders", GroupOwnerID: entitymodel.GroupNone, GroupOwner: entitymodel.GroupNone}
+
+	tests := []struct {
+		name         string
+		input        TransferOwnershipRequest
+		user         *acl.User
+		entity       entitymodel.Entity
+		members      map[string]bool
+		createAppErr error
+		wantErr      string
+	}{
+		{
+			name:    "member of current owner creates ticket",
+			input:   TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "billing", Reason: "team split"},
+			user:    &acl.User{ID: "u1"},
+			entity:  owned,
+			members: map[string]bool{"u1:g-payments": true},
+		},
+		{
+			name:    "root member creates ticket",
+			input:   TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "billing"},
+			user:    &acl.User{ID: "u4"},
+			entity:  owned,
+			members: map[string]bool{"u4:g-root": true},
+		},
+		{
+			name:    "removed member still listed in the token",
+			input:   TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "billing"},
+			user:    &acl.User{ID: "u2", Groups: []acl.GroupRole{{GroupID: "g-payments", GroupName: "payments", Role: acl.RoleGroupMember}}},
+			entity:  owned,
+			wantErr: "only members of payments",
+		},
+		{
+			name:    "demoted root still listed in the token",
+			input:   TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "billing"},
+			user:    &acl.User{ID: "u5", Groups: []acl.GroupRole{{GroupID: "g-root", GroupName: acl.GroupRoot, Role: acl.RoleGroupAdmin}}},
+			entity:  owned,
+			wantErr: "only members of payments",
+		},
+		{
+			name:    "missing target",
+			input:   TransferOwnershipRequest{EntityID: "e1"},
+			user:    &acl.User{ID: "u1"},
+			entity:  owned,
+			wantErr: "missing target_group_name",
+		},
+		{
+			name:    "unowned entity",
+			input:   TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "billing"},
+			user:    &acl.User{ID: "u1"},
+			entity:  unowned,
+			wantErr: "claim it instead",
+		},
+		{
+			name:    "same owner",
+			input:   TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "payments"},
+			user:    &acl.User{ID: "u1"},
+			entity:  owned,
+			members: map[string]bool{"u1:g-payments": true},
+			wantErr: "already owned",
+		},
+		{
+			name:    "unknown target",
+			input:   TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "ghost"},
+			user:    &acl.User{ID: "u1"},
+			entity:  owned,
+			wantErr: "group ghost not found",
+		},
+		{
+			name:    "requester not in owner group",
+			input:   TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "billing"},
+			user:    &acl.User{ID: "u3"},
+			entity:  owned,
+			members: map[string]bool{"u3:g-billing": true},
+			wantErr: "only members of payments",
+		},
+		{
+			name:         "create application error",
+			input:        TransferOwnershipRequest{EntityID: "e1", TargetGroupName: "billing"},
+			user:         &acl.User{ID: "u1"},
+			entity:       owned,
+			members:      map[string]bool{"u1:g-payments": true},
+			createAppErr: errors.New("db error"),
+			wantErr:      "db error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockTransferOwnershipRepo{
+				groups:       groups,
+				members:      tt.members,
+				entity:       tt.entity,
+				createAppErr: tt.createAppErr,
+			}
+			uc := TransferOwnershipUsecase{repo: repo}
+			ctx := util.MockContextWithUser(context.Background(), tt.user)
+			got, err := uc.Handle(ctx, tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ApplicationID == "" || got.LinkRedirect != "/#ticket-detail?id="+got.ApplicationID {
+				t.Errorf("unexpected response %+v", got)
+			}
+			app := repo.createdApp
+			if app == nil {
+				t.Fatal("application not created")
+			}
+			if app.Type != acl.ApplicationType_Transfer {
+				t.Errorf("type = %s, want %s", app.Type, acl.ApplicationType_Transfer)
+			}
+			if app.MetaData[acl.AppMetaData_GroupID] != "g-billing" || app.MetaData[acl.AppMetaData_FromGroupID] != "g-payments" {
+				t.Errorf("unexpected metadata %v", app.MetaData)
+			}
+			if len(app.Stages) != 2 || app.Stages[0].ReviewerGroupID != "g-payments" || app.Stages[1].ReviewerGroupID != "g-billing" {
+				t.Errorf("unexpected stages %+v", app.Stages)
+			}
+		})
+	}
+}
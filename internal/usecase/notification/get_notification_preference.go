@@ -0,0 +1,62 @@
+//go:generate mockgen -source=get_notification_preference.go -destination=mock/mock_get_notification_preference_repo.go -package=notification_mock
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	notifrepo "github.com/jekiapp/topic-master/internal/repository/notification"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// GetNotificationPreferenceResponse also lists the events and channels to build the preference form.
+type GetNotificationPreferenceResponse struct {
+	Preference notification.Preference `json:"preference"`
+	Events     []string                `json:"events"`
+	Channels   []string                `json:"channels"`
+}
+
+type iGetNotificationPreferenceRepo interface {
+	GetPreference(userID string) (notification.Preference, error)
+}
+
+type getNotificationPreferenceRepo struct {
+	db *buntdb.DB
+}
+
+func (r *getNotificationPreferenceRepo) GetPreference(userID string) (notification.Preference, error) {
+	return notifrepo.GetPreference(r.db, userID)
+}
+
+type GetNotificationPreferenceUsecase struct {
+	repo iGetNotificationPreferenceRepo
+}
+
+func NewGetNotificationPreferenceUsecase(db *buntdb.DB) GetNotificationPreferenceUsecase {
+	return GetNotificationPreferenceUsecase{
+		repo: &getNotificationPreferenceRepo{db: db},
+	}
+}
+
+func (uc GetNotificationPreferenceUsecase) Handle(ctx context.Context, params map[string]string) (GetNotificationPreferenceResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return GetNotificationPreferenceResponse{}, errors.New("unauthorized: user info not found")
+	}
+	pref, err := uc.repo.GetPreference(user.ID)
+	if err != nil {
+		return GetNotificationPreferenceResponse{}, fmt.Errorf("error getting notification preference: %v", err)
+	}
+	if pref.Events == nil {
+		pref.Events = map[string][]string{}
+	}
+	return GetNotificationPreferenceResponse{
+		Preference: pref,
+		Events:     notification.Events,
+		Channels:   notification.Channels,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	notification_mock "github.com/jekiapp/topic-master/internal/usecase/notification/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetNotificationPreferenceUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		loggedIn  bool
+		setupMock func(m *notification_mock.MockiGetNotificationPreferenceRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "unauthorized user",
+			setupMock: func(m *notification_mock.MockiGetNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "default preference",
+			loggedIn: true,
+			setupMock: func(m *notification_mock.MockiGetNotificationPreferenceRepo) {
+				m.EXPECT().GetPreference("user-1").Return(notification.DefaultPreference("user-1"), nil)
+			},
+		},
+		{
+			name:     "repo error",
+			loggedIn: true,
+			setupMock: func(m *notification_mock.MockiGetNotificationPreferenceRepo) {
+				m.EXPECT().GetPreference("user-1").Return(notification.Preference{}, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := notification_mock.NewMockiGetNotificationPreferenceRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := GetNotificationPreferenceUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "user-1", Username: "user1"})
+			}
+			resp, err := uc.Handle(ctx, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "user-1", resp.Preference.UserID)
+			assert.Equal(t, notification.Events, resp.Events)
+			assert.Equal(t, notification.Channels, resp.Channels)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+//go:generate mockgen -source=list_my_notifications.go -destination=mock/mock_list_my_notifications_repo.go -package=notification_mock
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	notifrepo "github.com/jekiapp/topic-master/internal/repository/notification"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type ListMyNotificationsResponse struct {
+	Notifications []notification.Notification `json:"notifications"`
+	Unread        int                         `json:"unread"`
+	HasNext       bool                        `json:"has_next"`
+}
+
+type iListMyNotificationsRepo interface {
+	ListNotificationsByUserID(userID string, page, limit int) ([]notification.Notification, error)
+	CountUnreadNotifications(userID string) (int, error)
+}
+
+type listMyNotificationsRepo struct {
+	db *buntdb.DB
+}
+
+func (r *listMyNotificationsRepo) ListNotificationsByUserID(userID string, page, limit int) ([]notification.Notification, error) {
+	return notifrepo.ListNotificationsByUserID(r.db, userID, &dbpkg.Pagination{Page: page, Limit: limit})
+}
+
+func (r *listMyNotificationsRepo) CountUnreadNotifications(userID string) (int, error) {
+	items, err := notifrepo.ListNotificationsByUserID(r.db, userID, nil)
+	if err != nil {
+		return 0, err
+	}
+	unread := 0
+	for _, item := range items {
+		if !item.Read {
+			unread++
+		}
+	}
+	return unread, nil
+}
+
+type ListMyNotificationsUsecase struct {
+	repo iListMyNotificationsRepo
+}
+
+func NewListMyNotificationsUsecase(db *buntdb.DB) ListMyNotificationsUsecase {
+	return ListMyNotificationsUsecase{
+		repo: &listMyNotificationsRepo{db: db},
+	}
+}
+
+// Handle returns a page of the inbox of the logged in user, newest first.
+// params may contain "page" and "limit"
+func (uc ListMyNotificationsUsecase) Handle(ctx context.Context, params map[string]string) (ListMyNotificationsResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return ListMyNotificationsResponse{}, errors.New("unauthorized: user info not found")
+	}
+	page, limit := 1, 20
+	if v, ok := params["page"]; ok {
+		fmt.Sscanf(v, "%d", &page)
+	}
+	if v, ok := params["limit"]; ok {
+		fmt.Sscanf(v, "%d", &limit)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	// one extra item tells whether there is a next page
+	items, err := uc.repo.ListNotificationsByUserID(user.ID, page, limit+1)
+	if err != nil {
+		return ListMyNotificationsResponse{}, fmt.Errorf("error listing notifications: %v", err)
+	}
+	hasNext := false
+	if len(items) > limit {
+		hasNext = true
+		items = items[:limit]
+	}
+	unread, err := uc.repo.CountUnreadNotifications(user.ID)
+	if err != nil {
+		return ListMyNotificationsResponse{}, fmt.Errorf("error counting unread notifications: %v", err)
+	}
+	return ListMyNotificationsResponse{Notifications: items, Unread: unread, HasNext: hasNext}, nil
+}
@@ -0,0 +1,86 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	notification_mock "github.com/jekiapp/topic-master/internal/usecase/notification/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListMyNotificationsUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		loggedIn    bool
+		params      map[string]string
+		setupMock   func(m *notification_mock.MockiListMyNotificationsRepo)
+		wantErr     bool
+		wantCount   int
+		wantUnread  int
+		wantHasNext bool
+	}{
+		{
+			name:      "unauthorized user",
+			setupMock: func(m *notification_mock.MockiListMyNotificationsRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "page with a next page",
+			loggedIn: true,
+			params:   map[string]string{"page": "1", "limit": "2"},
+			setupMock: func(m *notification_mock.MockiListMyNotificationsRepo) {
+				m.EXPECT().ListNotificationsByUserID("user-1", 1, 3).Return([]notification.Notification{
+					{ID: "n3", UserID: "user-1"}, {ID: "n2", UserID: "user-1", Read: true}, {ID: "n1", UserID: "user-1"},
+				}, nil)
+				m.EXPECT().CountUnreadNotifications("user-1").Return(2, nil)
+			},
+			wantCount:   2,
+			wantUnread:  2,
+			wantHasNext: true,
+		},
+		{
+			name:     "empty inbox",
+			loggedIn: true,
+			setupMock: func(m *notification_mock.MockiListMyNotificationsRepo) {
+				m.EXPECT().ListNotificationsByUserID("user-1", 1, 21).Return([]notification.Notification{}, nil)
+				m.EXPECT().CountUnreadNotifications("user-1").Return(0, nil)
+			},
+		},
+		{
+			name:     "repo error",
+			loggedIn: true,
+			setupMock: func(m *notification_mock.MockiListMyNotificationsRepo) {
+				m.EXPECT().ListNotificationsByUserID("user-1", 1, 21).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := notification_mock.NewMockiListMyNotificationsRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := ListMyNotificationsUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "user-1", Username: "user1"})
+			}
+			resp, err := uc.Handle(ctx, tt.params)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, resp.Notifications, tt.wantCount)
+			assert.Equal(t, tt.wantUnread, resp.Unread)
+			assert.Equal(t, tt.wantHasNext, resp.HasNext)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+//go:generate mockgen -source=mark_notifications_read.go -destination=mock/mock_mark_notifications_read_repo.go -package=notification_mock
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	notifrepo "github.com/jekiapp/topic-master/internal/repository/notification"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// MarkNotificationsReadRequest marks the given notifications as read, or the whole inbox when All is set.
+type MarkNotificationsReadRequest struct {
+	IDs []string `json:"ids"`
+	All bool     `json:"all"`
+}
+
+type MarkNotificationsReadResponse struct {
+	Updated int `json:"updated"`
+}
+
+type iMarkNotificationsReadRepo interface {
+	GetNotificationByID(id string) (notification.Notification, error)
+	ListAllNotificationsByUserID(userID string) ([]notification.Notification, error)
+	UpdateNotification(n notification.Notification) error
+}
+
+type markNotificationsReadRepo struct {
+	db *buntdb.DB
+}
+
+func (r *markNotificationsReadRepo) GetNotificationByID(id string) (notification.Notification, error) {
+	return notifrepo.GetNotificationByID(r.db, id)
+}
+
+func (r *markNotificationsReadRepo) ListAllNotificationsByUserID(userID string) ([]notification.Notification, error) {
+	return notifrepo.ListNotificationsByUserID(r.db, userID, nil)
+}
+
+func (r *markNotificationsReadRepo) UpdateNotification(n notification.Notification) error {
+	return notifrepo.UpdateNotification(r.db, n)
+}
+
+type MarkNotificationsReadUsecase struct {
+	repo iMarkNotificationsReadRepo
+}
+
+func NewMarkNotificationsReadUsecase(db *buntdb.DB) MarkNotificationsReadUsecase {
+	return MarkNotificationsReadUsecase{
+		repo: &markNotificationsReadRepo{db: db},
+	}
+}
+
+func (uc MarkNotificationsReadUsecase) Handle(ctx context.Context, req MarkNotificationsReadRequest) (MarkNotificationsReadResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return MarkNotificationsReadResponse{}, errors.New("unauthorized: user info not found")
+	}
+	if !req.All && len(req.IDs) == 0 {
+		return MarkNotificationsReadResponse{}, errors.New("no notification to mark as read")
+	}
+
+	var items []notification.Notification
+	if req.All {
+		all, err := uc.repo.ListAllNotificationsByUserID(user.ID)
+		if err != nil {
+			return MarkNotificationsReadResponse{}, err
+		}
+		items = all
+	} else {
+		for _, id := range req.IDs {
+			item, err := uc.repo.GetNotificationByID(id)
+			// someone else's notification is treated as not found
+			if err != nil || item.UserID != user.ID {
+				return MarkNotificationsReadResponse{}, errors.New("notification not found")
+			}
+			items = append(items, item)
+		}
+	}
+
+	updated := 0
+	for _, item := range items {
+		if item.Read {
+			continue
+		}
+		item.Read = true
+		if err := uc.repo.UpdateNotification(item); err != nil {
+			log.Println("[NOTIFICATION] error marking notification as read", item.ID, err)
+			continue
+		}
+		updated++
+	}
+	return MarkNotificationsReadResponse{Updated: updated}, nil
+}
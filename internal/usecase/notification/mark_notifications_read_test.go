@@ -0,0 +1,92 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	notification_mock "github.com/jekiapp/topic-master/internal/usecase/notification/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMarkNotificationsReadUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		loggedIn    bool
+		req         MarkNotificationsReadRequest
+		setupMock   func(m *notification_mock.MockiMarkNotificationsReadRepo)
+		wantErr     bool
+		wantUpdated int
+	}{
+		{
+			name:      "unauthorized user",
+			req:       MarkNotificationsReadRequest{All: true},
+			setupMock: func(m *notification_mock.MockiMarkNotificationsReadRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "nothing to mark",
+			loggedIn:  true,
+			setupMock: func(m *notification_mock.MockiMarkNotificationsReadRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "notification of another user",
+			loggedIn: true,
+			req:      MarkNotificationsReadRequest{IDs: []string{"n1"}},
+			setupMock: func(m *notification_mock.MockiMarkNotificationsReadRepo) {
+				m.EXPECT().GetNotificationByID("n1").Return(notification.Notification{ID: "n1", UserID: "user-2"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "mark selected",
+			loggedIn: true,
+			req:      MarkNotificationsReadRequest{IDs: []string{"n1"}},
+			setupMock: func(m *notification_mock.MockiMarkNotificationsReadRepo) {
+				m.EXPECT().GetNotificationByID("n1").Return(notification.Notification{ID: "n1", UserID: "user-1"}, nil)
+				m.EXPECT().UpdateNotification(gomock.Any()).DoAndReturn(func(n notification.Notification) error {
+					assert.True(t, n.Read)
+					return nil
+				})
+			},
+			wantUpdated: 1,
+		},
+		{
+			name:     "mark all skips already read",
+			loggedIn: true,
+			req:      MarkNotificationsReadRequest{All: true},
+			setupMock: func(m *notification_mock.MockiMarkNotificationsReadRepo) {
+				m.EXPECT().ListAllNotificationsByUserID("user-1").Return([]notification.Notification{
+					{ID: "n1", UserID: "user-1"}, {ID: "n2", UserID: "user-1", Read: true},
+				}, nil)
+				m.EXPECT().UpdateNotification(gomock.Any()).Return(nil).Times(1)
+			},
+			wantUpdated: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := notification_mock.NewMockiMarkNotificationsReadRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := MarkNotificationsReadUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "user-1", Username: "user1"})
+			}
+			resp, err := uc.Handle(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUpdated, resp.Updated)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/notification/get_notification_preference.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/notification/get_notification_preference.go -destination=internal/usecase/notification/mock/mock_get_notification_preference_repo.go -package=notification_mock
+//
+
+// Package notification_mock is a generated GoMock package.
+package notification_mock
+
+import (
+	reflect "reflect"
+
+	notification "github.com/jekiapp/topic-master/internal/model/notification"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiGetNotificationPreferenceRepo is a mock of iGetNotificationPreferenceRepo interface.
+type MockiGetNotificationPreferenceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiGetNotificationPreferenceRepoMockRecorder
+}
+
+// MockiGetNotificationPreferenceRepoMockRecorder is the mock recorder for MockiGetNotificationPreferenceRepo.
+type MockiGetNotificationPreferenceRepoMockRecorder struct {
+	mock *MockiGetNotificationPreferenceRepo
+}
+
+// NewMockiGetNotificationPreferenceRepo creates a new mock instance.
+func NewMockiGetNotificationPreferenceRepo(ctrl *gomock.Controller) *MockiGetNotificationPreferenceRepo {
+	mock := &MockiGetNotificationPreferenceRepo{ctrl: ctrl}
+	mock.recorder = &MockiGetNotificationPreferenceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiGetNotificationPreferenceRepo) EXPECT() *MockiGetNotificationPreferenceRepoMockRecorder {
+	return m.recorder
+}
+
+// GetPreference mocks base method.
+func (m *MockiGetNotificationPreferenceRepo) GetPreference(userID string) (notification.Preference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", userID)
+	ret0, _ := ret[0].(notification.Preference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockiGetNotificationPreferenceRepoMockRecorder) GetPreference(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockiGetNotificationPreferenceRepo)(nil).GetPreference), userID)
+}
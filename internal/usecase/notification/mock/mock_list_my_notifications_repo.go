@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/notification/list_my_notifications.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/notification/list_my_notifications.go -destination=internal/usecase/notification/mock/mock_list_my_notifications_repo.go -package=notification_mock
+//
+
+// Package notification_mock is a generated GoMock package.
+package notification_mock
+
+import (
+	reflect "reflect"
+
+	notification "github.com/jekiapp/topic-master/internal/model/notification"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiListMyNotificationsRepo is a mock of iListMyNotificationsRepo interface.
+type MockiListMyNotificationsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiListMyNotificationsRepoMockRecorder
+}
+
+// MockiListMyNotificationsRepoMockRecorder is the mock recorder for MockiListMyNotificationsRepo.
+type MockiListMyNotificationsRepoMockRecorder struct {
+	mock *MockiListMyNotificationsRepo
+}
+
+// NewMockiListMyNotificationsRepo creates a new mock instance.
+func NewMockiListMyNotificationsRepo(ctrl *gomock.Controller) *MockiListMyNotificationsRepo {
+	mock := &MockiListMyNotificationsRepo{ctrl: ctrl}
+	mock.recorder = &MockiListMyNotificationsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiListMyNotificationsRepo) EXPECT() *MockiListMyNotificationsRepoMockRecorder {
+	return m.recorder
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockiListMyNotificationsRepo) CountUnreadNotifications(userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockiListMyNotificationsRepoMockRecorder) CountUnreadNotifications(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockiListMyNotificationsRepo)(nil).CountUnreadNotifications), userID)
+}
+
+// ListNotificationsByUserID mocks base method.
+func (m *MockiListMyNotificationsRepo) ListNotificationsByUserID(userID string, page, limit int) ([]notification.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationsByUserID", userID, page, limit)
+	ret0, _ := ret[0].([]notification.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationsByUserID indicates an expected call of ListNotificationsByUserID.
+func (mr *MockiListMyNotificationsRepoMockRecorder) ListNotificationsByUserID(userID, page, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationsByUserID", reflect.TypeOf((*MockiListMyNotificationsRepo)(nil).ListNotificationsByUserID), userID, page, limit)
+}
@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/notification/mark_notifications_read.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/notification/mark_notifications_read.go -destination=internal/usecase/notification/mock/mock_mark_notifications_read_repo.go -package=notification_mock
+//
+
+// Package notification_mock is a generated GoMock package.
+package notification_mock
+
+import (
+	reflect "reflect"
+
+	notification "github.com/jekiapp/topic-master/internal/model/notification"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiMarkNotificationsReadRepo is a mock of iMarkNotificationsReadRepo interface.
+type MockiMarkNotificationsReadRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiMarkNotificationsReadRepoMockRecorder
+}
+
+// MockiMarkNotificationsReadRepoMockRecorder is the mock recorder for MockiMarkNotificationsReadRepo.
+type MockiMarkNotificationsReadRepoMockRecorder struct {
+	mock *MockiMarkNotificationsReadRepo
+}
+
+// NewMockiMarkNotificationsReadRepo creates a new mock instance.
+func NewMockiMarkNotificationsReadRepo(ctrl *gomock.Controller) *MockiMarkNotificationsReadRepo {
+	mock := &MockiMarkNotificationsReadRepo{ctrl: ctrl}
+	mock.recorder = &MockiMarkNotificationsReadRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiMarkNotificationsReadRepo) EXPECT() *MockiMarkNotificationsReadRepoMockRecorder {
+	return m.recorder
+}
+
+// GetNotificationByID mocks base method.
+func (m *MockiMarkNotificationsReadRepo) GetNotificationByID(id string) (notification.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationByID", id)
+	ret0, _ := ret[0].(notification.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationByID indicates an expected call of GetNotificationByID.
+func (mr *MockiMarkNotificationsReadRepoMockRecorder) GetNotificationByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationByID", reflect.TypeOf((*MockiMarkNotificationsReadRepo)(nil).GetNotificationByID), id)
+}
+
+// ListAllNotificationsByUserID mocks base method.
+func (m *MockiMarkNotificationsReadRepo) ListAllNotificationsByUserID(userID string) ([]notification.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllNotificationsByUserID", userID)
+	ret0, _ := ret[0].([]notification.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllNotificationsByUserID indicates an expected call of ListAllNotificationsByUserID.
+func (mr *MockiMarkNotificationsReadRepoMockRecorder) ListAllNotificationsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllNotificationsByUserID", reflect.TypeOf((*MockiMarkNotificationsReadRepo)(nil).ListAllNotificationsByUserID), userID)
+}
+
+// UpdateNotification mocks base method.
+func (m *MockiMarkNotificationsReadRepo) UpdateNotification(n notification.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotification", n)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotification indicates an expected call of UpdateNotification.
+func (mr *MockiMarkNotificationsReadRepoMockRecorder) UpdateNotification(n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotification", reflect.TypeOf((*MockiMarkNotificationsReadRepo)(nil).UpdateNotification), n)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/notification/save_notification_preference.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/notification/save_notification_preference.go -destination=internal/usecase/notification/mock/mock_save_notification_preference_repo.go -package=notification_mock
+//
+
+// Package notification_mock is a generated GoMock package.
+package notification_mock
+
+import (
+	reflect "reflect"
+
+	notification "github.com/jekiapp/topic-master/internal/model/notification"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiSaveNotificationPreferenceRepo is a mock of iSaveNotificationPreferenceRepo interface.
+type MockiSaveNotificationPreferenceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiSaveNotificationPreferenceRepoMockRecorder
+}
+
+// MockiSaveNotificationPreferenceRepoMockRecorder is the mock recorder for MockiSaveNotificationPreferenceRepo.
+type MockiSaveNotificationPreferenceRepoMockRecorder struct {
+	mock *MockiSaveNotificationPreferenceRepo
+}
+
+// NewMockiSaveNotificationPreferenceRepo creates a new mock instance.
+func NewMockiSaveNotificationPreferenceRepo(ctrl *gomock.Controller) *MockiSaveNotificationPreferenceRepo {
+	mock := &MockiSaveNotificationPreferenceRepo{ctrl: ctrl}
+	mock.recorder = &MockiSaveNotificationPreferenceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiSaveNotificationPreferenceRepo) EXPECT() *MockiSaveNotificationPreferenceRepoMockRecorder {
+	return m.recorder
+}
+
+// UpsertPreference mocks base method.
+func (m *MockiSaveNotificationPreferenceRepo) UpsertPreference(pref notification.Preference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPreference", pref)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPreference indicates an expected call of UpsertPreference.
+func (mr *MockiSaveNotificationPreferenceRepoMockRecorder) UpsertPreference(pref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPreference", reflect.TypeOf((*MockiSaveNotificationPreferenceRepo)(nil).UpsertPreference), pref)
+}
@@ -0,0 +1,110 @@
+//go:generate mockgen -source=save_notification_preference.go -destination=mock/mock_save_notification_preference_repo.go -package=notification_mock
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	notiflogic "github.com/jekiapp/topic-master/internal/logic/notification"
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	notifrepo "github.com/jekiapp/topic-master/internal/repository/notification"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// SaveNotificationPreferenceRequest replaces the preference of the logged in user.
+// An event left out of Events is muted on every channel.
+type SaveNotificationPreferenceRequest struct {
+	Email      string              `json:"email"`
+	WebhookURL string              `json:"webhook_url"`
+	Events     map[string][]string `json:"events"`
+}
+
+type SaveNotificationPreferenceResponse struct {
+	Message string `json:"message"`
+}
+
+type iSaveNotificationPreferenceRepo interface {
+	UpsertPreference(pref notification.Preference) error
+}
+
+type saveNotificationPreferenceRepo struct {
+	db *buntdb.DB
+}
+
+func (r *saveNotificationPreferenceRepo) UpsertPreference(pref notification.Preference) error {
+	return notifrepo.UpsertPreference(r.db, pref)
+}
+
+type SaveNotificationPreferenceUsecase struct {
+	repo iSaveNotificationPreferenceRepo
+}
+
+func NewSaveNotificationPreferenceUsecase(db *buntdb.DB) SaveNotificationPreferenceUsecase {
+	return SaveNotificationPreferenceUsecase{
+		repo: &saveNotificationPreferenceRepo{db: db},
+	}
+}
+
+func (uc SaveNotificationPreferenceUsecase) Handle(ctx context.Context, req SaveNotificationPreferenceRequest) (SaveNotificationPreferenceResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return SaveNotificationPreferenceResponse{}, errors.New("unauthorized: user info not found")
+	}
+
+	events := map[string][]string{}
+	usesEmail, usesWebhook := false, false
+	for event, channels := range req.Events {
+		if !slices.Contains(notification.Events, event) {
+			return SaveNotificationPreferenceResponse{}, fmt.Errorf("unknown event %q", event)
+		}
+		var selected []string
+		for _, channel := range channels {
+			if !slices.Contains(notification.Channels, channel) {
+				return SaveNotificationPreferenceResponse{}, fmt.Errorf("unknown channel %q", channel)
+			}
+			if slices.Contains(selected, channel) {
+				continue
+			}
+			selected = append(selected, channel)
+			usesEmail = usesEmail || channel == notification.ChannelEmail
+			usesWebhook = usesWebhook || channel == notification.ChannelWebhook
+		}
+		if len(selected) > 0 {
+			events[event] = selected
+		}
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if usesEmail || email != "" {
+		addr, err := mail.ParseAddress(email)
+		if err != nil {
+			return SaveNotificationPreferenceResponse{}, fmt.Errorf("invalid email address: %v", err)
+		}
+		email = addr.Address
+	}
+	webhookURL := strings.TrimSpace(req.WebhookURL)
+	if usesWebhook || webhookURL != "" {
+		if err := notiflogic.ValidateWebhookURL(ctx, webhookURL); err != nil {
+			return SaveNotificationPreferenceResponse{}, err
+		}
+	}
+
+	pref := notification.Preference{
+		UserID:     user.ID,
+		Email:      email,
+		WebhookURL: webhookURL,
+		Events:     events,
+		UpdatedAt:  time.Now(),
+	}
+	if err := uc.repo.UpsertPreference(pref); err != nil {
+		return SaveNotificationPreferenceResponse{}, fmt.Errorf("error saving notification preference: %v", err)
+	}
+	return SaveNotificationPreferenceResponse{Message: "Notification preference saved"}, nil
+}
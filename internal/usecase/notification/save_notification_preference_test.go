@@ -0,0 +1,153 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/notification"
+	notification_mock "github.com/jekiapp/topic-master/internal/usecase/notification/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSaveNotificationPreferenceUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		loggedIn  bool
+		req       SaveNotificationPreferenceRequest
+		setupMock func(m *notification_mock.MockiSaveNotificationPreferenceRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "unauthorized user",
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown event",
+			loggedIn:  true,
+			req:       SaveNotificationPreferenceRequest{Events: map[string][]string{"unknown": {notification.ChannelInbox}}},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown channel",
+			loggedIn:  true,
+			req:       SaveNotificationPreferenceRequest{Events: map[string][]string{notification.EventTicketApproved: {"sms"}}},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "email channel without address",
+			loggedIn:  true,
+			req:       SaveNotificationPreferenceRequest{Events: map[string][]string{notification.EventTicketApproved: {notification.ChannelEmail}}},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "webhook url not http",
+			loggedIn: true,
+			req: SaveNotificationPreferenceRequest{
+				WebhookURL: "ftp://hooks.example.com/x",
+				Events:     map[string][]string{notification.EventTicketAssigned: {notification.ChannelWebhook}},
+			},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "webhook to loopback",
+			loggedIn: true,
+			req: SaveNotificationPreferenceRequest{
+				WebhookURL: "http://127.0.0.1:8080/hook",
+				Events:     map[string][]string{notification.EventTicketAssigned: {notification.ChannelWebhook}},
+			},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "webhook to localhost",
+			loggedIn: true,
+			req: SaveNotificationPreferenceRequest{
+				WebhookURL: "http://localhost/hook",
+				Events:     map[string][]string{notification.EventTicketAssigned: {notification.ChannelWebhook}},
+			},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "webhook to private network",
+			loggedIn: true,
+			req: SaveNotificationPreferenceRequest{
+				WebhookURL: "https://10.0.0.5/hook",
+				Events:     map[string][]string{notification.EventTicketAssigned: {notification.ChannelWebhook}},
+			},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "webhook to cloud metadata",
+			loggedIn: true,
+			req: SaveNotificationPreferenceRequest{
+				WebhookURL: "http://169.254.169.254/latest/meta-data",
+				Events:     map[string][]string{notification.EventTicketAssigned: {notification.ChannelWebhook}},
+			},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "webhook to IPv6 loopback",
+			loggedIn: true,
+			req: SaveNotificationPreferenceRequest{
+				WebhookURL: "http://[::1]/hook",
+				Events:     map[string][]string{notification.EventTicketAssigned: {notification.ChannelWebhook}},
+			},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "success",
+			loggedIn: true,
+			req: SaveNotificationPreferenceRequest{
+				Email:      " Jane <jane@example.com> ",
+				WebhookURL: "https://hooks.example.com/services/x",
+				Events: map[string][]string{
+					notification.EventTicketAssigned: {notification.ChannelInbox, notification.ChannelEmail, notification.ChannelEmail},
+					notification.EventTicketReminder: {notification.ChannelWebhook},
+					notification.EventTicketCreated:  {},
+				},
+			},
+			setupMock: func(m *notification_mock.MockiSaveNotificationPreferenceRepo) {
+				m.EXPECT().UpsertPreference(gomock.Any()).DoAndReturn(func(pref notification.Preference) error {
+					assert.Equal(t, "user-1", pref.UserID)
+					assert.Equal(t, "jane@example.com", pref.Email)
+					assert.Equal(t, []string{notification.ChannelInbox, notification.ChannelEmail}, pref.Events[notification.EventTicketAssigned])
+					_, present := pref.Events[notification.EventTicketCreated]
+					assert.False(t, present)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := notification_mock.NewMockiSaveNotificationPreferenceRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := SaveNotificationPreferenceUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "user-1", Username: "user1"})
+			}
+			_, err := uc.Handle(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
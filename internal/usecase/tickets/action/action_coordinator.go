@@ -3,7 +3,10 @@ package action
 import (
 	"context"
 	"errors"
+	"strings"
 
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	usergrouplogic "github.com/jekiapp/topic-master/internal/logic/user_group"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/jekiapp/topic-master/pkg/util"
@@ -16,11 +19,14 @@ type ActionCoordinator struct {
 	claimEntityHandler   *ClaimEntityHandler
 	topicActionHandler   *TopicActionHandler
 	channelActionHandler *ChannelActionHandler
+	membershipHandler    *GroupMembershipHandler
+	transferHandler      *TransferOwnershipHandler
 }
 
 type ActionRequest struct {
 	Action        string `json:"action"`
 	ApplicationID string `json:"application_id"`
+	Comment       string `json:"comment"` // optional, recorded in the history with the decision
 }
 
 type ActionResponse struct {
@@ -35,28 +41,24 @@ func NewActionCoordinator(db *buntdb.DB) *ActionCoordinator {
 		claimEntityHandler:   NewClaimEntityHandler(db),
 		topicActionHandler:   NewTopicActionHandler(db),
 		channelActionHandler: NewChannelActionHandler(db),
+		membershipHandler:    NewGroupMembershipHandler(db),
+		transferHandler:      NewTransferOwnershipHandler(db),
 	}
 }
 
-func (ac *ActionCoordinator) validateActor(ctx context.Context, appID string) ([]acl.ApplicationAssignment, error) {
+func (ac *ActionCoordinator) validateActor(ctx context.Context, app acl.Application) ([]acl.ApplicationAssignment, error) {
 	// validate the actor
 	user := util.GetUserInfo(ctx)
 	if user == nil {
 		return nil, errors.New("unauthorized")
 	}
-	assignments, err := ac.repo.ListAssignmentsByApplicationID(appID)
+	assignments, err := ac.repo.ListAssignmentsByApplicationID(app.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	isAssignee := false
-	for _, assignment := range assignments {
-		if assignment.ReviewerID == user.ID {
-			isAssignee = true
-			break
-		}
-	}
-	if !isAssignee {
+	// only reviewers of the current stage who did not review yet can act
+	if _, ok := auth.ActiveAssignment(app, assignments, user.ID); !ok {
 		return nil, errors.New("you are not eligible to perform this action")
 	}
 	return assignments, nil
@@ -67,43 +69,92 @@ func (ac *ActionCoordinator) Handle(ctx context.Context, req ActionRequest) (Act
 	if err != nil {
 		return ActionResponse{}, err
 	}
+	if app.IsClosed() {
+		return ActionResponse{}, errors.New("application is already " + app.Status)
+	}
+	// the applicant has to answer first, rejecting is still possible
+	if app.Status == acl.StatusNeedsInfo && req.Action == acl.ActionApprove {
+		return ActionResponse{}, errors.New("application is waiting for more information from the applicant")
+	}
 
-	assignments, err := ac.validateActor(ctx, req.ApplicationID)
+	assignments, err := ac.validateActor(ctx, app)
 	if err != nil {
 		return ActionResponse{}, err
 	}
 
+	// approvals are counted until every stage reached its quorum, a single reject closes the application
+	if req.Action == acl.ActionApprove {
+		done, message, err := auth.RecordApproval(ctx, ac.repo, app, req.Comment)
+		if err != nil {
+			return ActionResponse{}, err
+		}
+		if !done {
+			return ActionResponse{
+				Status:  "pending",
+				Message: message,
+			}, nil
+		}
+	}
+
 	switch app.Type {
 	case acl.ApplicationType_Signup:
 		return ac.signupHandler.HandleSignup(ctx, SignupRequest{
 			Action:      req.Action,
 			Application: app,
 			Assignments: assignments,
+			Comment:     req.Comment,
 		})
 	case acl.ApplicationType_Claim:
 		return ac.claimEntityHandler.HandleClaimEntity(ctx, ClaimEntityInput{
 			Action:      req.Action,
 			Application: app,
 			Assignments: assignments,
+			Comment:     req.Comment,
 		})
 	case acl.ApplicationType_TopicForm:
 		return ac.topicActionHandler.HandleTopicAction(ctx, TopicActionInput{
 			Action:      req.Action,
 			Application: app,
 			Assignments: assignments,
+			Comment:     req.Comment,
 		})
 	case acl.ApplicationType_ChannelForm:
 		return ac.channelActionHandler.HandleChannelAction(ctx, ChannelActionInput{
 			Action:      req.Action,
 			Application: app,
 			Assignments: assignments,
+			Comment:     req.Comment,
+		})
+	case acl.ApplicationType_Membership:
+		return ac.membershipHandler.HandleGroupMembership(ctx, GroupMembershipInput{
+			Action:      req.Action,
+			Application: app,
+			Assignments: assignments,
+			Comment:     req.Comment,
+		})
+	case acl.ApplicationType_Transfer:
+		return ac.transferHandler.HandleTransferOwnership(ctx, TransferOwnershipInput{
+			Action:      req.Action,
+			Application: app,
+			Assignments: assignments,
+			Comment:     req.Comment,
 		})
 	}
 
 	return ActionResponse{}, errors.New("application type not supported")
 }
 
+// reviewComment appends the comment of the reviewer to the default history comment of a decision
+func reviewComment(defaultComment, comment string) string {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return defaultComment
+	}
+	return defaultComment + ": " + comment
+}
+
 type iActionCoordinatorRepo interface {
+	auth.IApprovalStore
 	GetApplicationByID(id string) (acl.Application, error)
 	GetPermissionByID(id string) (acl.PermissionMap, error)
 	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
@@ -121,6 +172,14 @@ func (r *actionCoordinatorRepo) GetPermissionByID(id string) (acl.PermissionMap,
 	return db.GetByID[acl.PermissionMap](r.db, id)
 }
 
+func (r *actionCoordinatorRepo) WithApprovalTx(fn func(repo auth.IApprovalProgress) error) error {
+	return auth.WithApprovalTx(r.db, fn)
+}
+
+func (r *actionCoordinatorRepo) GetReviewerIDsByGroupID(groupID string) ([]string, error) {
+	return usergrouplogic.GetReviewerIDsByGroupID(r.db, groupID)
+}
+
 func (r *actionCoordinatorRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
 	return db.SelectAll[acl.ApplicationAssignment](r.db, "="+appID, acl.IdxAppAssign_ApplicationID)
 }
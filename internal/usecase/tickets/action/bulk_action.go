@@ -0,0 +1,77 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+)
+
+// maxBulkTickets bounds a bulk action, every ticket is handled in the request
+const maxBulkTickets = 100
+
+// BulkActionRequest approves or rejects several tickets with the same comment
+type BulkActionRequest struct {
+	Action         string   `json:"action"`
+	ApplicationIDs []string `json:"application_ids"`
+	Comment        string   `json:"comment"`
+}
+
+// BulkActionResult is the outcome of one ticket, a failed ticket does not stop the others
+type BulkActionResult struct {
+	ApplicationID string `json:"application_id"`
+	Success       bool   `json:"success"`
+	Status        string `json:"status,omitempty"`
+	Message       string `json:"message,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type BulkActionResponse struct {
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []BulkActionResult `json:"results"`
+}
+
+// HandleBulk runs Handle on every ticket, each one is checked on its own
+func (ac *ActionCoordinator) HandleBulk(ctx context.Context, req BulkActionRequest) (BulkActionResponse, error) {
+	if req.Action != acl.ActionApprove && req.Action != acl.ActionReject {
+		return BulkActionResponse{}, fmt.Errorf("bulk action must be %s or %s", acl.ActionApprove, acl.ActionReject)
+	}
+	if len(req.ApplicationIDs) == 0 {
+		return BulkActionResponse{}, errors.New("application_ids is required")
+	}
+	if len(req.ApplicationIDs) > maxBulkTickets {
+		return BulkActionResponse{}, fmt.Errorf("at most %d tickets can be handled at once", maxBulkTickets)
+	}
+
+	resp := BulkActionResponse{Results: []BulkActionResult{}}
+	seen := map[string]bool{}
+	for _, appID := range req.ApplicationIDs {
+		if appID == "" || seen[appID] {
+			continue
+		}
+		seen[appID] = true
+
+		result := BulkActionResult{ApplicationID: appID}
+		single, err := ac.Handle(ctx, ActionRequest{
+			Action:        req.Action,
+			ApplicationID: appID,
+			Comment:       req.Comment,
+		})
+		if err == nil && single.Status == "error" {
+			err = errors.New(single.Message)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			resp.Failed++
+		} else {
+			result.Success = true
+			result.Status = single.Status
+			result.Message = single.Message
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
@@ -16,6 +16,7 @@ type ClaimEntityInput struct {
 	Application acl.Application
 	Action      string
 	Assignments []acl.ApplicationAssignment
+	Comment     string
 }
 
 type ClaimEntityResponse struct {
@@ -87,14 +88,14 @@ func (h *ClaimEntityHandler) HandleApprove(ctx context.Context, input ClaimEntit
 	if err != nil {
 		return err
 	}
-	ent.GroupOwner = group.Name
+	ent.SetOwner(group.ID, group.Name)
 	ent.Status = entity.EntityStatus_Active
 	ent.UpdatedAt = time.Now()
 	if err := h.repo.UpdateEntity(ent); err != nil {
 		return err
 	}
 	// Use reusable approval logic
-	comment := ent.TypeID + " claim approved"
+	comment := reviewComment(ent.TypeID+" claim approved", input.Comment)
 	err = auth.ApproveApplication(ctx, h.repo, input.Application.ID, input.Assignments, comment)
 	if err != nil {
 		return err
@@ -111,7 +112,7 @@ func (h *ClaimEntityHandler) HandleReject(ctx context.Context, input ClaimEntity
 		return err
 	}
 	// Use reusable rejection logic
-	comment := ent.TypeID + " claim rejected"
+	comment := reviewComment(ent.TypeID+" claim rejected", input.Comment)
 	err = auth.RejectApplication(ctx, h.repo, input.Application.ID, input.Assignments, comment)
 	if err != nil {
 		return err
@@ -0,0 +1,148 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+type GroupMembershipInput struct {
+	Application acl.Application
+	Action      string
+	Assignments []acl.ApplicationAssignment
+	Comment     string
+}
+
+func (req GroupMembershipInput) Validate() error {
+	if req.Action == "" {
+		return errors.New("missing action")
+	}
+	if req.Application.ID == "" {
+		return errors.New("missing app_id")
+	}
+	if len(req.Assignments) == 0 {
+		return errors.New("missing assignments")
+	}
+	return nil
+}
+
+type GroupMembershipHandler struct {
+	repo iGroupMembershipRepo
+}
+
+func NewGroupMembershipHandler(db *buntdb.DB) *GroupMembershipHandler {
+	return &GroupMembershipHandler{repo: &groupMembershipRepo{db: db}}
+}
+
+func (h *GroupMembershipHandler) HandleGroupMembership(ctx context.Context, req GroupMembershipInput) (ActionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return ActionResponse{}, err
+	}
+
+	switch req.Action {
+	case acl.ActionApprove:
+		if err := h.HandleApprove(ctx, req); err != nil {
+			return ActionResponse{}, err
+		}
+		return ActionResponse{
+			Status:  "success",
+			Message: "Group membership approved",
+		}, nil
+	case acl.ActionReject:
+		comment := reviewComment("group membership rejected", req.Comment)
+		if err := auth.RejectApplication(ctx, h.repo, req.Application.ID, req.Assignments, comment); err != nil {
+			return ActionResponse{}, err
+		}
+		return ActionResponse{
+			Status:  "success",
+			Message: "Group membership rejected",
+		}, nil
+	}
+	return ActionResponse{}, errors.New("invalid action")
+}
+
+// HandleApprove adds the applicant to the group, a member applying as admin gets the new role
+func (h *GroupMembershipHandler) HandleApprove(ctx context.Context, input GroupMembershipInput) error {
+	app := input.Application
+	group, err := h.repo.GetGroupByID(app.MetaData[acl.AppMetaData_GroupID])
+	if err != nil {
+		return errors.New("group not found, it may have been deleted")
+	}
+	role := app.MetaData[acl.AppMetaData_GroupRole]
+	if _, ok := acl.GroupJoinPermission(role); !ok {
+		return errors.New("invalid group role: " + role)
+	}
+
+	userGroup, err := h.repo.GetUserGroup(app.UserID, group.ID)
+	if err == nil {
+		userGroup.Role = role
+		userGroup.UpdatedAt = time.Now()
+		err = h.repo.UpdateUserGroup(userGroup)
+	} else {
+		err = h.repo.CreateUserGroup(acl.UserGroup{
+			ID:        uuid.NewString(),
+			UserID:    app.UserID,
+			GroupID:   group.ID,
+			Role:      role,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	comment := reviewComment("joined the group "+group.Name+" as "+role, input.Comment)
+	return auth.ApproveApplication(ctx, h.repo, app.ID, input.Assignments, comment)
+}
+
+type iGroupMembershipRepo interface {
+	auth.IApplicationAction
+	GetGroupByID(id string) (acl.Group, error)
+	GetUserGroup(userID, groupID string) (acl.UserGroup, error)
+	CreateUserGroup(userGroup acl.UserGroup) error
+	UpdateUserGroup(userGroup acl.UserGroup) error
+}
+
+type groupMembershipRepo struct {
+	db *buntdb.DB
+}
+
+func (r *groupMembershipRepo) GetApplicationByID(id string) (acl.Application, error) {
+	return db.GetByID[acl.Application](r.db, id)
+}
+
+func (r *groupMembershipRepo) UpdateApplication(app acl.Application) error {
+	return db.Update(r.db, &app)
+}
+
+func (r *groupMembershipRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return db.Update(r.db, &assignment)
+}
+
+func (r *groupMembershipRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return db.Insert(r.db, &history)
+}
+
+func (r *groupMembershipRepo) GetGroupByID(id string) (acl.Group, error) {
+	return userrepo.GetGroupByID(r.db, id)
+}
+
+func (r *groupMembershipRepo) GetUserGroup(userID, groupID string) (acl.UserGroup, error) {
+	return userrepo.GetUserGroup(r.db, userID, groupID)
+}
+
+func (r *groupMembershipRepo) CreateUserGroup(userGroup acl.UserGroup) error {
+	return userrepo.CreateUserGroup(r.db, userGroup)
+}
+
+func (r *groupMembershipRepo) UpdateUserGroup(userGroup acl.UserGroup) error {
+	return userrepo.UpdateUserGroup(r.db, userGroup)
+}
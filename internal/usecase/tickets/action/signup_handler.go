@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/logic/notification"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/pkg/db"
 	"github.com/jekiapp/topic-master/pkg/util"
@@ -22,18 +23,25 @@ type iSignupRepo interface {
 	CreateUserGroup(userGroup acl.UserGroup) error
 }
 
+// iSignupStore runs fn with a repo whose writes are committed together, or not at all when
+// fn fails, so an approval never leaves an active user without their group
+type iSignupStore interface {
+	WithTx(fn func(repo iSignupRepo) error) error
+}
+
 type SignupHandler struct {
-	repo iSignupRepo
+	store iSignupStore
 }
 
 func NewSignupHandler(db *buntdb.DB) *SignupHandler {
-	return &SignupHandler{repo: &signupRepo{db: db}}
+	return &SignupHandler{store: &signupStore{db: db}}
 }
 
 type SignupRequest struct {
 	Action      string
 	Application acl.Application
 	Assignments []acl.ApplicationAssignment
+	Comment     string
 }
 
 func (h *SignupHandler) HandleSignup(ctx context.Context, req SignupRequest) (ActionResponse, error) {
@@ -55,45 +63,58 @@ func (h *SignupHandler) handleApprove(ctx context.Context, req SignupRequest) (A
 	}
 	app := req.Application
 
-	// Create user from pending user
-	applicant, err := h.repo.GetUserPendingByID(app.UserID)
-	if err != nil {
-		return ActionResponse{Status: "error", Message: "Failed to get user pending"}, err
-	}
+	failure := ""
+	var approved notification.Event
+	err := h.store.WithTx(func(repo iSignupRepo) error {
+		// Create user from pending user
+		applicant, err := repo.GetUserPendingByID(app.UserID)
+		if err != nil {
+			failure = "Failed to get user pending"
+			return err
+		}
 
-	applicant.Status = acl.UserStatusActive
-	applicant.CreatedAt = time.Now()
-	applicant.UpdatedAt = time.Now()
-	if err = h.repo.CreateUser(applicant.User); err != nil {
-		return ActionResponse{Status: "error", Message: "Failed to create user"}, err
-	}
+		applicant.Status = acl.UserStatusActive
+		applicant.CreatedAt = time.Now()
+		applicant.UpdatedAt = time.Now()
+		if err = repo.CreateUser(applicant.User); err != nil {
+			failure = "Failed to create user"
+			return err
+		}
 
-	groupID := app.MetaData["group_id"]
-	groupRole := app.MetaData["group_role"]
+		groupID := app.MetaData["group_id"]
+		groupRole := app.MetaData["group_role"]
 
-	userGroup := acl.UserGroup{
-		ID:        uuid.NewString(),
-		UserID:    applicant.User.ID,
-		GroupID:   groupID,
-		Role:      groupRole,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	if err := h.repo.CreateUserGroup(userGroup); err != nil {
-		return ActionResponse{Status: "error", Message: "Failed to create user group"}, err
-	}
+		userGroup := acl.UserGroup{
+			ID:        uuid.NewString(),
+			UserID:    applicant.User.ID,
+			GroupID:   groupID,
+			Role:      groupRole,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := repo.CreateUserGroup(userGroup); err != nil {
+			failure = "Failed to create user group"
+			return err
+		}
 
-	// Use reusable approval logic
-	err = auth.ApproveApplication(
-		ctx,
-		h.repo,
-		app.ID,
-		req.Assignments,
-		"Signup approved",
-	)
+		// Use reusable approval logic, the applicant is told once the transaction committed
+		approved, err = auth.ApproveApplicationTx(
+			ctx,
+			repo,
+			app.ID,
+			req.Assignments,
+			reviewComment("Signup approved", req.Comment),
+		)
+		if err != nil {
+			failure = "Failed to approve application"
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		return ActionResponse{Status: "error", Message: "Failed to approve application"}, err
+		return ActionResponse{Status: "error", Message: failure}, err
 	}
+	notification.Publish(approved)
 
 	return ActionResponse{Status: "success", Message: "Signup completed"}, nil
 }
@@ -106,67 +127,89 @@ func (h *SignupHandler) handleReject(ctx context.Context, req SignupRequest) (Ac
 	}
 	app := req.Application
 
-	// Deactivate user pending by id (if exists)
-	applicant, err := h.repo.GetUserPendingByID(app.UserID)
-	if err == nil {
-		applicant.Status = acl.UserStatusInactive
-		applicant.UpdatedAt = time.Now()
-		if err = h.repo.UpdateUserPending(applicant); err != nil {
-			return ActionResponse{Status: "error", Message: "Failed to update user"}, err
+	failure := ""
+	var rejected notification.Event
+	err := h.store.WithTx(func(repo iSignupRepo) error {
+		// Deactivate user pending by id (if exists)
+		applicant, err := repo.GetUserPendingByID(app.UserID)
+		if err == nil {
+			applicant.Status = acl.UserStatusInactive
+			applicant.UpdatedAt = time.Now()
+			if err = repo.UpdateUserPending(applicant); err != nil {
+				failure = "Failed to update user"
+				return err
+			}
 		}
-	}
 
-	// Use reusable rejection logic
-	err = auth.RejectApplication(
-		ctx,
-		h.repo,
-		app.ID,
-		req.Assignments,
-		"Signup rejected",
-	)
+		// Use reusable rejection logic, the applicant is told once the transaction committed
+		rejected, err = auth.RejectApplicationTx(
+			ctx,
+			repo,
+			app.ID,
+			req.Assignments,
+			reviewComment("Signup rejected", req.Comment),
+		)
+		if err != nil {
+			failure = "Failed to reject application"
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		return ActionResponse{Status: "error", Message: "Failed to reject application"}, err
+		return ActionResponse{Status: "error", Message: failure}, err
 	}
+	notification.Publish(rejected)
 
 	return ActionResponse{Status: "success", Message: "Signup rejected and user deleted"}, nil
 }
 
-type signupRepo struct {
+type signupStore struct {
 	db *buntdb.DB
 }
 
+func (s *signupStore) WithTx(fn func(repo iSignupRepo) error) error {
+	return db.WithTx(s.db, func(tx *buntdb.Tx) error {
+		return fn(&signupRepo{tx: tx})
+	})
+}
+
+// signupRepo works inside the transaction of signupStore.WithTx
+type signupRepo struct {
+	tx *buntdb.Tx
+}
+
 func (r *signupRepo) GetApplicationByID(id string) (acl.Application, error) {
-	return db.GetByID[acl.Application](r.db, id)
+	return db.GetByIDTx[acl.Application](r.tx, id)
 }
 
 func (r *signupRepo) UpdateApplication(app acl.Application) error {
-	return db.Update(r.db, &app)
+	return db.UpdateTx(r.tx, &app)
 }
 
 func (r *signupRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
-	return db.SelectAll[acl.ApplicationAssignment](r.db, "="+appID, acl.IdxAppAssign_ApplicationID)
+	return db.SelectAllTx[acl.ApplicationAssignment](r.tx, "="+appID, acl.IdxAppAssign_ApplicationID)
 }
 
 func (r *signupRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
-	return db.Update(r.db, &assignment)
+	return db.UpdateTx(r.tx, &assignment)
 }
 
 func (r *signupRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
-	return db.Insert(r.db, &history)
+	return db.InsertTx(r.tx, &history)
 }
 
 func (r *signupRepo) GetUserPendingByID(userID string) (acl.UserPending, error) {
-	return db.GetByID[acl.UserPending](r.db, userID)
+	return db.GetByIDTx[acl.UserPending](r.tx, userID)
 }
 
 func (r *signupRepo) CreateUser(user acl.User) error {
-	return db.Insert(r.db, &user)
+	return db.InsertTx(r.tx, &user)
 }
 
 func (r *signupRepo) UpdateUserPending(user acl.UserPending) error {
-	return db.Update(r.db, &user)
+	return db.UpdateTx(r.tx, &user)
 }
 
 func (r *signupRepo) CreateUserGroup(userGroup acl.UserGroup) error {
-	return db.Insert(r.db, &userGroup)
+	return db.InsertTx(r.tx, &userGroup)
 }
@@ -17,6 +17,7 @@ type TopicActionInput struct {
 	Action      string
 	Application acl.Application
 	Assignments []acl.ApplicationAssignment
+	Comment     string
 }
 
 type TopicActionHandler struct {
@@ -57,24 +58,31 @@ func (h *TopicActionHandler) HandleApprove(ctx context.Context, input TopicActio
 	}
 	app := input.Application
 	userID := app.UserID
+	// time-bound grants expire relative to the approval, not the submission
+	expiresAt, err := auth.GrantExpiresAt(app, time.Now())
+	if err != nil {
+		return err
+	}
 	for _, permID := range app.PermissionIDs {
 		perm := acl.PermissionMap{
-			ID:        uuid.NewString(),
-			UserID:    userID,
-			EntityID:  app.MetaData["entity_id"],
-			Action:    permID,
-			CreatedAt: time.Now(),
+			ID:            uuid.NewString(),
+			UserID:        userID,
+			EntityID:      app.MetaData[acl.AppMetaData_EntityID],
+			Action:        permID,
+			CreatedAt:     time.Now(),
+			ExpiresAt:     expiresAt,
+			ApplicationID: app.ID,
 		}
 		if err := h.repo.InsertPermission(perm); err != nil {
 			return err
 		}
 	}
-	auth.ApproveApplication(ctx, h.repo, input.Application.ID, input.Assignments, "topic action permissions approved")
+	auth.ApproveApplication(ctx, h.repo, input.Application.ID, input.Assignments, reviewComment("topic action permissions approved", input.Comment))
 	return nil
 }
 
 func (h *TopicActionHandler) HandleReject(ctx context.Context, input TopicActionInput) error {
-	auth.RejectApplication(ctx, h.repo, input.Application.ID, input.Assignments, "topic action permissions rejected")
+	auth.RejectApplication(ctx, h.repo, input.Application.ID, input.Assignments, reviewComment("topic action permissions rejected", input.Comment))
 	return nil
 }
 
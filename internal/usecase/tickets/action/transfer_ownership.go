@@ -0,0 +1,132 @@
+package action
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+type TransferOwnershipInput struct {
+	Application acl.Application
+	Action      string
+	Assignments []acl.ApplicationAssignment
+	Comment     string
+}
+
+func (req TransferOwnershipInput) Validate() error {
+	if req.Action == "" {
+		return errors.New("missing action")
+	}
+	if req.Application.ID == "" {
+		return errors.New("missing app_id")
+	}
+	if len(req.Assignments) == 0 {
+		return errors.New("missing assignments")
+	}
+	return nil
+}
+
+type TransferOwnershipHandler struct {
+	repo iTransferOwnershipRepo
+}
+
+func NewTransferOwnershipHandler(db *buntdb.DB) *TransferOwnershipHandler {
+	return &TransferOwnershipHandler{repo: &transferOwnershipRepo{db: db}}
+}
+
+func (h *TransferOwnershipHandler) HandleTransferOwnership(ctx context.Context, req TransferOwnershipInput) (ActionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return ActionResponse{}, err
+	}
+
+	switch req.Action {
+	case acl.ActionApprove:
+		if err := h.HandleApprove(ctx, req); err != nil {
+			return ActionResponse{}, err
+		}
+		return ActionResponse{
+			Status:  "success",
+			Message: "Ownership transfer approved",
+		}, nil
+	case acl.ActionReject:
+		comment := reviewComment("ownership transfer rejected", req.Comment)
+		if err := auth.RejectApplication(ctx, h.repo, req.Application.ID, req.Assignments, comment); err != nil {
+			return ActionResponse{}, err
+		}
+		return ActionResponse{
+			Status:  "success",
+			Message: "Ownership transfer rejected",
+		}, nil
+	}
+	return ActionResponse{}, errors.New("invalid action")
+}
+
+// HandleApprove moves the entity to the target group, once every stage approved.
+// It fails when the entity changed owner since the ticket was created.
+func (h *TransferOwnershipHandler) HandleApprove(ctx context.Context, input TransferOwnershipInput) error {
+	app := input.Application
+	ent, err := h.repo.GetEntityByID(app.MetaData[acl.AppMetaData_EntityID])
+	if err != nil {
+		return err
+	}
+	if ent.GroupOwnerID != app.MetaData[acl.AppMetaData_FromGroupID] {
+		return errors.New("the entity changed owner since the transfer was requested, reject this ticket")
+	}
+	target, err := h.repo.GetGroupByID(app.MetaData[acl.AppMetaData_GroupID])
+	if err != nil {
+		return errors.New("target group not found, it may have been deleted")
+	}
+	ent.SetOwner(target.ID, target.Name)
+	ent.UpdatedAt = time.Now()
+	if err := h.repo.UpdateEntity(ent); err != nil {
+		return err
+	}
+	comment := reviewComment(ent.TypeID+" ownership transferred to "+target.Name, input.Comment)
+	return auth.ApproveApplication(ctx, h.repo, app.ID, input.Assignments, comment)
+}
+
+type iTransferOwnershipRepo interface {
+	auth.IApplicationAction
+	GetEntityByID(id string) (entity.Entity, error)
+	UpdateEntity(ent entity.Entity) error
+	GetGroupByID(id string) (acl.Group, error)
+}
+
+type transferOwnershipRepo struct {
+	db *buntdb.DB
+}
+
+func (r *transferOwnershipRepo) GetApplicationByID(id string) (acl.Application, error) {
+	return db.GetByID[acl.Application](r.db, id)
+}
+
+func (r *transferOwnershipRepo) UpdateApplication(app acl.Application) error {
+	return db.Update(r.db, &app)
+}
+
+func (r *transferOwnershipRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return db.Update(r.db, &assignment)
+}
+
+func (r *transferOwnershipRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return db.Insert(r.db, &history)
+}
+
+func (r *transferOwnershipRepo) GetEntityByID(id string) (entity.Entity, error) {
+	return db.GetByID[entity.Entity](r.db, id)
+}
+
+func (r *transferOwnershipRepo) UpdateEntity(ent entity.Entity) error {
+	return db.Update(r.db, &ent)
+}
+
+func (r *transferOwnershipRepo) GetGroupByID(id string) (acl.Group, error) {
+	return userrepo.GetGroupByID(r.db, id)
+}
@@ -34,7 +34,7 @@ func (uc ChannelActionUsecase) getChannelForm(ctx context.Context, entityID stri
 		return NewApplicationResponse{}, err
 	}
 
-	group, err := userRepo.GetGroupByName(uc.db, channelEntity.GroupOwner)
+	group, err := userRepo.GetGroupByID(uc.db, channelEntity.GroupOwnerID)
 	if err != nil {
 		return NewApplicationResponse{}, err
 	}
@@ -59,6 +59,7 @@ func (uc ChannelActionUsecase) getChannelForm(ctx context.Context, entityID stri
 		{Label: "Channel Name", Type: "label", DefaultValue: channelEntity.Name, Editable: false},
 		{Label: "Channel Owner", Type: "label", DefaultValue: channelEntity.GroupOwner, Editable: false},
 		{Label: "Reason", Type: "textarea", DefaultValue: "", Editable: true},
+		{Label: "Duration", Type: "text", DefaultValue: "", Editable: true, Placeholder: "e.g. 2h or 7d, leave empty for a permanent grant"},
 	}
 
 	return NewApplicationResponse{
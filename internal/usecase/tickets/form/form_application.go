@@ -38,6 +38,7 @@ type fieldResponse struct {
 	Required     bool   `json:"required"`
 	DefaultValue string `json:"default_value"`
 	Editable     bool   `json:"editable"`
+	Placeholder  string `json:"placeholder,omitempty"`
 }
 
 type NewApplicationUsecase struct {
@@ -34,7 +34,7 @@ func (uc TopicActionUsecase) getTopicForm(ctx context.Context, entityID string)
 		return NewApplicationResponse{}, err
 	}
 
-	group, err := userRepo.GetGroupByName(uc.db, topicEntity.GroupOwner)
+	group, err := userRepo.GetGroupByID(uc.db, topicEntity.GroupOwnerID)
 	if err != nil {
 		return NewApplicationResponse{}, err
 	}
@@ -60,6 +60,7 @@ func (uc TopicActionUsecase) getTopicForm(ctx context.Context, entityID string)
 		{Label: "Topic Description", Type: "label-multiline", DefaultValue: topicEntity.Description, Editable: false},
 		{Label: "Topic Owner", Type: "label", DefaultValue: topicEntity.GroupOwner, Editable: false},
 		{Label: "Reason", Type: "textarea", DefaultValue: "", Editable: true},
+		{Label: "Duration", Type: "text", DefaultValue: "", Editable: true, Placeholder: "e.g. 2h or 7d, leave empty for a permanent grant"},
 	}
 
 	return NewApplicationResponse{
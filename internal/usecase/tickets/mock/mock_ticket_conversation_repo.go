@@ -0,0 +1,195 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tickets/ticket_conversation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tickets/ticket_conversation.go -destination=internal/usecase/tickets/mock/mock_ticket_conversation_repo.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+	reflect "reflect"
+)
+
+// MockiUserGroupsRepo is a mock of iUserGroupsRepo interface.
+type MockiUserGroupsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiUserGroupsRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockiUserGroupsRepoMockRecorder is the mock recorder for MockiUserGroupsRepo.
+type MockiUserGroupsRepoMockRecorder struct {
+	mock *MockiUserGroupsRepo
+}
+
+// NewMockiUserGroupsRepo creates a new mock instance.
+func NewMockiUserGroupsRepo(ctrl *gomock.Controller) *MockiUserGroupsRepo {
+	mock := &MockiUserGroupsRepo{ctrl: ctrl}
+	mock.recorder = &MockiUserGroupsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiUserGroupsRepo) EXPECT() *MockiUserGroupsRepoMockRecorder {
+	return m.recorder
+}
+
+// GetGroupsByUserID mocks base method.
+func (m *MockiUserGroupsRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsByUserID", userID)
+	ret0, _ := ret[0].([]acl.GroupRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsByUserID indicates an expected call of GetGroupsByUserID.
+func (mr *MockiUserGroupsRepoMockRecorder) GetGroupsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByUserID", reflect.TypeOf((*MockiUserGroupsRepo)(nil).GetGroupsByUserID), userID)
+}
+
+// MockiTicketConversationRepo is a mock of iTicketConversationRepo interface.
+type MockiTicketConversationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiTicketConversationRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockiTicketConversationRepoMockRecorder is the mock recorder for MockiTicketConversationRepo.
+type MockiTicketConversationRepoMockRecorder struct {
+	mock *MockiTicketConversationRepo
+}
+
+// NewMockiTicketConversationRepo creates a new mock instance.
+func NewMockiTicketConversationRepo(ctrl *gomock.Controller) *MockiTicketConversationRepo {
+	mock := &MockiTicketConversationRepo{ctrl: ctrl}
+	mock.recorder = &MockiTicketConversationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiTicketConversationRepo) EXPECT() *MockiTicketConversationRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateApplicationComment mocks base method.
+func (m *MockiTicketConversationRepo) CreateApplicationComment(comment acl.ApplicationComment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApplicationComment", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateApplicationComment indicates an expected call of CreateApplicationComment.
+func (mr *MockiTicketConversationRepoMockRecorder) CreateApplicationComment(comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplicationComment", reflect.TypeOf((*MockiTicketConversationRepo)(nil).CreateApplicationComment), comment)
+}
+
+// CreateApplicationHistory mocks base method.
+func (m *MockiTicketConversationRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApplicationHistory", history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateApplicationHistory indicates an expected call of CreateApplicationHistory.
+func (mr *MockiTicketConversationRepoMockRecorder) CreateApplicationHistory(history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplicationHistory", reflect.TypeOf((*MockiTicketConversationRepo)(nil).CreateApplicationHistory), history)
+}
+
+// GetApplicationByID mocks base method.
+func (m *MockiTicketConversationRepo) GetApplicationByID(id string) (acl.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationByID", id)
+	ret0, _ := ret[0].(acl.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationByID indicates an expected call of GetApplicationByID.
+func (mr *MockiTicketConversationRepoMockRecorder) GetApplicationByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationByID", reflect.TypeOf((*MockiTicketConversationRepo)(nil).GetApplicationByID), id)
+}
+
+// GetApplicationCommentByID mocks base method.
+func (m *MockiTicketConversationRepo) GetApplicationCommentByID(id string) (acl.ApplicationComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApplicationCommentByID", id)
+	ret0, _ := ret[0].(acl.ApplicationComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApplicationCommentByID indicates an expected call of GetApplicationCommentByID.
+func (mr *MockiTicketConversationRepoMockRecorder) GetApplicationCommentByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationCommentByID", reflect.TypeOf((*MockiTicketConversationRepo)(nil).GetApplicationCommentByID), id)
+}
+
+// GetGroupsByUserID mocks base method.
+func (m *MockiTicketConversationRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsByUserID", userID)
+	ret0, _ := ret[0].([]acl.GroupRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsByUserID indicates an expected call of GetGroupsByUserID.
+func (mr *MockiTicketConversationRepoMockRecorder) GetGroupsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByUserID", reflect.TypeOf((*MockiTicketConversationRepo)(nil).GetGroupsByUserID), userID)
+}
+
+// ListAssignmentsByApplicationID mocks base method.
+func (m *MockiTicketConversationRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignmentsByApplicationID", appID)
+	ret0, _ := ret[0].([]acl.ApplicationAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignmentsByApplicationID indicates an expected call of ListAssignmentsByApplicationID.
+func (mr *MockiTicketConversationRepoMockRecorder) ListAssignmentsByApplicationID(appID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignmentsByApplicationID", reflect.TypeOf((*MockiTicketConversationRepo)(nil).ListAssignmentsByApplicationID), appID)
+}
+
+// UpdateApplication mocks base method.
+func (m *MockiTicketConversationRepo) UpdateApplication(app acl.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApplication", app)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApplication indicates an expected call of UpdateApplication.
+func (mr *MockiTicketConversationRepoMockRecorder) UpdateApplication(app any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplication", reflect.TypeOf((*MockiTicketConversationRepo)(nil).UpdateApplication), app)
+}
+
+// UpdateApplicationAssignment mocks base method.
+func (m *MockiTicketConversationRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApplicationAssignment", assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApplicationAssignment indicates an expected call of UpdateApplicationAssignment.
+func (mr *MockiTicketConversationRepoMockRecorder) UpdateApplicationAssignment(assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplicationAssignment", reflect.TypeOf((*MockiTicketConversationRepo)(nil).UpdateApplicationAssignment), assignment)
+}
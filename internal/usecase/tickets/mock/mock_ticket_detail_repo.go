@@ -3,24 +3,24 @@
 //
 // Generated by this command:
 //
-//	Cursor-0.48.8-x86_64.AppImage -source=internal/usecase/tickets/ticket_detail.go -destination=internal/usecase/tickets/mock/mock_ticket_detail_repo.go -package=mock
+//	mockgen -source=internal/usecase/tickets/ticket_detail.go -destination=internal/usecase/tickets/mock/mock_ticket_detail_repo.go -package=mock
 //
 
 // Package mock is a generated GoMock package.
 package mock
 
 import (
-	reflect "reflect"
-
 	acl "github.com/jekiapp/topic-master/internal/model/acl"
 	entity "github.com/jekiapp/topic-master/internal/model/entity"
 	gomock "go.uber.org/mock/gomock"
+	reflect "reflect"
 )
 
 // MockiTicketDetailRepo is a mock of iTicketDetailRepo interface.
 type MockiTicketDetailRepo struct {
 	ctrl     *gomock.Controller
 	recorder *MockiTicketDetailRepoMockRecorder
+	isgomock struct{}
 }
 
 // MockiTicketDetailRepoMockRecorder is the mock recorder for MockiTicketDetailRepo.
@@ -70,6 +70,21 @@ func (mr *MockiTicketDetailRepoMockRecorder) GetEntityByID(id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityByID", reflect.TypeOf((*MockiTicketDetailRepo)(nil).GetEntityByID), id)
 }
 
+// GetGroupsByUserID mocks base method.
+func (m *MockiTicketDetailRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsByUserID", userID)
+	ret0, _ := ret[0].([]acl.GroupRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsByUserID indicates an expected call of GetGroupsByUserID.
+func (mr *MockiTicketDetailRepoMockRecorder) GetGroupsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByUserID", reflect.TypeOf((*MockiTicketDetailRepo)(nil).GetGroupsByUserID), userID)
+}
+
 // GetPermissionByID mocks base method.
 func (m *MockiTicketDetailRepo) GetPermissionByID(id string) (acl.PermissionMap, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +145,21 @@ func (mr *MockiTicketDetailRepoMockRecorder) ListAssignmentsByApplicationID(appI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignmentsByApplicationID", reflect.TypeOf((*MockiTicketDetailRepo)(nil).ListAssignmentsByApplicationID), appID)
 }
 
+// ListCommentsByApplicationID mocks base method.
+func (m *MockiTicketDetailRepo) ListCommentsByApplicationID(appID string) ([]acl.ApplicationComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentsByApplicationID", appID)
+	ret0, _ := ret[0].([]acl.ApplicationComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentsByApplicationID indicates an expected call of ListCommentsByApplicationID.
+func (mr *MockiTicketDetailRepoMockRecorder) ListCommentsByApplicationID(appID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentsByApplicationID", reflect.TypeOf((*MockiTicketDetailRepo)(nil).ListCommentsByApplicationID), appID)
+}
+
 // ListHistoriesByApplicationID mocks base method.
 func (m *MockiTicketDetailRepo) ListHistoriesByApplicationID(appID string) ([]acl.ApplicationHistory, error) {
 	m.ctrl.T.Helper()
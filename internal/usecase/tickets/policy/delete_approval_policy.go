@@ -0,0 +1,53 @@
+//go:generate mockgen -source=delete_approval_policy.go -destination=mock/mock_delete_approval_policy_repo.go -package=policy_mock
+
+package policy
+
+import (
+	"context"
+	"errors"
+
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	"github.com/tidwall/buntdb"
+)
+
+type DeleteApprovalPolicyRequest struct {
+	ID string `json:"id"`
+}
+
+type DeleteApprovalPolicyResponse struct {
+	Success bool `json:"success"`
+}
+
+type iDeleteApprovalPolicyRepo interface {
+	DeleteApprovalPolicyByID(id string) error
+}
+
+type deleteApprovalPolicyRepo struct {
+	db *buntdb.DB
+}
+
+func (r *deleteApprovalPolicyRepo) DeleteApprovalPolicyByID(id string) error {
+	return apprepo.DeleteApprovalPolicyByID(r.db, id)
+}
+
+type DeleteApprovalPolicyUsecase struct {
+	repo iDeleteApprovalPolicyRepo
+}
+
+func NewDeleteApprovalPolicyUsecase(db *buntdb.DB) DeleteApprovalPolicyUsecase {
+	return DeleteApprovalPolicyUsecase{
+		repo: &deleteApprovalPolicyRepo{db: db},
+	}
+}
+
+// Handle removes a policy, applications submitted afterwards fall back to a single approval.
+// Applications already submitted keep the stages they were created with.
+func (uc DeleteApprovalPolicyUsecase) Handle(ctx context.Context, req DeleteApprovalPolicyRequest) (DeleteApprovalPolicyResponse, error) {
+	if req.ID == "" {
+		return DeleteApprovalPolicyResponse{}, errors.New("missing required field: id")
+	}
+	if err := uc.repo.DeleteApprovalPolicyByID(req.ID); err != nil {
+		return DeleteApprovalPolicyResponse{}, err
+	}
+	return DeleteApprovalPolicyResponse{Success: true}, nil
+}
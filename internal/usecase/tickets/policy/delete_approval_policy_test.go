@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	policy_mock "github.com/jekiapp/topic-master/internal/usecase/tickets/policy/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteApprovalPolicyUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		req       DeleteApprovalPolicyRequest
+		setupMock func(m *policy_mock.MockiDeleteApprovalPolicyRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "missing id",
+			setupMock: func(m *policy_mock.MockiDeleteApprovalPolicyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "repo error",
+			req:  DeleteApprovalPolicyRequest{ID: "topic_action:topic:delete"},
+			setupMock: func(m *policy_mock.MockiDeleteApprovalPolicyRepo) {
+				m.EXPECT().DeleteApprovalPolicyByID("topic_action:topic:delete").Return(context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			req:  DeleteApprovalPolicyRequest{ID: "claim:"},
+			setupMock: func(m *policy_mock.MockiDeleteApprovalPolicyRepo) {
+				m.EXPECT().DeleteApprovalPolicyByID("claim:").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := policy_mock.NewMockiDeleteApprovalPolicyRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := DeleteApprovalPolicyUsecase{repo: mockRepo}
+			resp, err := uc.Handle(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, resp.Success)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+//go:generate mockgen -source=list_approval_policy.go -destination=mock/mock_list_approval_policy_repo.go -package=policy_mock
+
+package policy
+
+import (
+	"context"
+	"sort"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	"github.com/tidwall/buntdb"
+)
+
+type ListApprovalPolicyResponse struct {
+	Policies []acl.ApprovalPolicy `json:"policies"`
+}
+
+type iListApprovalPolicyRepo interface {
+	ListApprovalPolicies() ([]acl.ApprovalPolicy, error)
+}
+
+type listApprovalPolicyRepo struct {
+	db *buntdb.DB
+}
+
+func (r *listApprovalPolicyRepo) ListApprovalPolicies() ([]acl.ApprovalPolicy, error) {
+	return apprepo.ListApprovalPolicies(r.db)
+}
+
+type ListApprovalPolicyUsecase struct {
+	repo iListApprovalPolicyRepo
+}
+
+func NewListApprovalPolicyUsecase(db *buntdb.DB) ListApprovalPolicyUsecase {
+	return ListApprovalPolicyUsecase{
+		repo: &listApprovalPolicyRepo{db: db},
+	}
+}
+
+func (uc ListApprovalPolicyUsecase) Handle(ctx context.Context, params map[string]string) (ListApprovalPolicyResponse, error) {
+	policies, err := uc.repo.ListApprovalPolicies()
+	if err != nil {
+		return ListApprovalPolicyResponse{}, err
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID < policies[j].ID })
+	return ListApprovalPolicyResponse{Policies: policies}, nil
+}
@@ -0,0 +1,31 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	policy_mock "github.com/jekiapp/topic-master/internal/usecase/tickets/policy/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListApprovalPolicyUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := policy_mock.NewMockiListApprovalPolicyRepo(ctrl)
+	mockRepo.EXPECT().ListApprovalPolicies().Return([]acl.ApprovalPolicy{
+		{ID: "topic_action:topic:delete"},
+		{ID: "claim:"},
+	}, nil)
+	uc := ListApprovalPolicyUsecase{repo: mockRepo}
+	resp, err := uc.Handle(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "claim:", resp.Policies[0].ID)
+	assert.Equal(t, "topic_action:topic:delete", resp.Policies[1].ID)
+
+	mockRepo.EXPECT().ListApprovalPolicies().Return(nil, context.DeadlineExceeded)
+	_, err = uc.Handle(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tickets/policy/delete_approval_policy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tickets/policy/delete_approval_policy.go -destination=internal/usecase/tickets/policy/mock/mock_delete_approval_policy_repo.go -package=policy_mock
+//
+
+// Package policy_mock is a generated GoMock package.
+package policy_mock
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiDeleteApprovalPolicyRepo is a mock of iDeleteApprovalPolicyRepo interface.
+type MockiDeleteApprovalPolicyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiDeleteApprovalPolicyRepoMockRecorder
+}
+
+// MockiDeleteApprovalPolicyRepoMockRecorder is the mock recorder for MockiDeleteApprovalPolicyRepo.
+type MockiDeleteApprovalPolicyRepoMockRecorder struct {
+	mock *MockiDeleteApprovalPolicyRepo
+}
+
+// NewMockiDeleteApprovalPolicyRepo creates a new mock instance.
+func NewMockiDeleteApprovalPolicyRepo(ctrl *gomock.Controller) *MockiDeleteApprovalPolicyRepo {
+	mock := &MockiDeleteApprovalPolicyRepo{ctrl: ctrl}
+	mock.recorder = &MockiDeleteApprovalPolicyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiDeleteApprovalPolicyRepo) EXPECT() *MockiDeleteApprovalPolicyRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteApprovalPolicyByID mocks base method.
+func (m *MockiDeleteApprovalPolicyRepo) DeleteApprovalPolicyByID(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApprovalPolicyByID", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteApprovalPolicyByID indicates an expected call of DeleteApprovalPolicyByID.
+func (mr *MockiDeleteApprovalPolicyRepoMockRecorder) DeleteApprovalPolicyByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApprovalPolicyByID", reflect.TypeOf((*MockiDeleteApprovalPolicyRepo)(nil).DeleteApprovalPolicyByID), id)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tickets/policy/list_approval_policy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tickets/policy/list_approval_policy.go -destination=internal/usecase/tickets/policy/mock/mock_list_approval_policy_repo.go -package=policy_mock
+//
+
+// Package policy_mock is a generated GoMock package.
+package policy_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiListApprovalPolicyRepo is a mock of iListApprovalPolicyRepo interface.
+type MockiListApprovalPolicyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiListApprovalPolicyRepoMockRecorder
+}
+
+// MockiListApprovalPolicyRepoMockRecorder is the mock recorder for MockiListApprovalPolicyRepo.
+type MockiListApprovalPolicyRepoMockRecorder struct {
+	mock *MockiListApprovalPolicyRepo
+}
+
+// NewMockiListApprovalPolicyRepo creates a new mock instance.
+func NewMockiListApprovalPolicyRepo(ctrl *gomock.Controller) *MockiListApprovalPolicyRepo {
+	mock := &MockiListApprovalPolicyRepo{ctrl: ctrl}
+	mock.recorder = &MockiListApprovalPolicyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiListApprovalPolicyRepo) EXPECT() *MockiListApprovalPolicyRepoMockRecorder {
+	return m.recorder
+}
+
+// ListApprovalPolicies mocks base method.
+func (m *MockiListApprovalPolicyRepo) ListApprovalPolicies() ([]acl.ApprovalPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApprovalPolicies")
+	ret0, _ := ret[0].([]acl.ApprovalPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApprovalPolicies indicates an expected call of ListApprovalPolicies.
+func (mr *MockiListApprovalPolicyRepoMockRecorder) ListApprovalPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApprovalPolicies", reflect.TypeOf((*MockiListApprovalPolicyRepo)(nil).ListApprovalPolicies))
+}
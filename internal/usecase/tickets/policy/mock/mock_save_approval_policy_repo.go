@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tickets/policy/save_approval_policy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tickets/policy/save_approval_policy.go -destination=internal/usecase/tickets/policy/mock/mock_save_approval_policy_repo.go -package=policy_mock
+//
+
+// Package policy_mock is a generated GoMock package.
+package policy_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiSaveApprovalPolicyRepo is a mock of iSaveApprovalPolicyRepo interface.
+type MockiSaveApprovalPolicyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiSaveApprovalPolicyRepoMockRecorder
+}
+
+// MockiSaveApprovalPolicyRepoMockRecorder is the mock recorder for MockiSaveApprovalPolicyRepo.
+type MockiSaveApprovalPolicyRepoMockRecorder struct {
+	mock *MockiSaveApprovalPolicyRepo
+}
+
+// NewMockiSaveApprovalPolicyRepo creates a new mock instance.
+func NewMockiSaveApprovalPolicyRepo(ctrl *gomock.Controller) *MockiSaveApprovalPolicyRepo {
+	mock := &MockiSaveApprovalPolicyRepo{ctrl: ctrl}
+	mock.recorder = &MockiSaveApprovalPolicyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiSaveApprovalPolicyRepo) EXPECT() *MockiSaveApprovalPolicyRepoMockRecorder {
+	return m.recorder
+}
+
+// GetGroupByName mocks base method.
+func (m *MockiSaveApprovalPolicyRepo) GetGroupByName(name string) (acl.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupByName", name)
+	ret0, _ := ret[0].(acl.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupByName indicates an expected call of GetGroupByName.
+func (mr *MockiSaveApprovalPolicyRepoMockRecorder) GetGroupByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupByName", reflect.TypeOf((*MockiSaveApprovalPolicyRepo)(nil).GetGroupByName), name)
+}
+
+// UpsertApprovalPolicy mocks base method.
+func (m *MockiSaveApprovalPolicyRepo) UpsertApprovalPolicy(policy acl.ApprovalPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertApprovalPolicy", policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertApprovalPolicy indicates an expected call of UpsertApprovalPolicy.
+func (mr *MockiSaveApprovalPolicyRepoMockRecorder) UpsertApprovalPolicy(policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertApprovalPolicy", reflect.TypeOf((*MockiSaveApprovalPolicyRepo)(nil).UpsertApprovalPolicy), policy)
+}
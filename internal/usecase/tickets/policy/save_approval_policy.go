@@ -0,0 +1,121 @@
+//go:generate mockgen -source=save_approval_policy.go -destination=mock/mock_save_approval_policy_repo.go -package=policy_mock
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// SaveApprovalPolicyRequest creates or replaces the policy of an application type and permission.
+type SaveApprovalPolicyRequest struct {
+	ApplicationType string              `json:"application_type"`
+	Permission      string              `json:"permission"`
+	Stages          []acl.ApprovalStage `json:"stages"`
+}
+
+type SaveApprovalPolicyResponse struct {
+	ID string `json:"id"`
+}
+
+type iSaveApprovalPolicyRepo interface {
+	GetGroupByName(name string) (acl.Group, error)
+	UpsertApprovalPolicy(policy acl.ApprovalPolicy) error
+}
+
+type saveApprovalPolicyRepo struct {
+	db *buntdb.DB
+}
+
+func (r *saveApprovalPolicyRepo) GetGroupByName(name string) (acl.Group, error) {
+	return userrepo.GetGroupByName(r.db, name)
+}
+
+func (r *saveApprovalPolicyRepo) UpsertApprovalPolicy(policy acl.ApprovalPolicy) error {
+	return apprepo.UpsertApprovalPolicy(r.db, policy)
+}
+
+type SaveApprovalPolicyUsecase struct {
+	repo iSaveApprovalPolicyRepo
+}
+
+func NewSaveApprovalPolicyUsecase(db *buntdb.DB) SaveApprovalPolicyUsecase {
+	return SaveApprovalPolicyUsecase{
+		repo: &saveApprovalPolicyRepo{db: db},
+	}
+}
+
+func (uc SaveApprovalPolicyUsecase) Handle(ctx context.Context, req SaveApprovalPolicyRequest) (SaveApprovalPolicyResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return SaveApprovalPolicyResponse{}, errors.New("unauthorized: user info not found")
+	}
+	if err := validatePolicyPermission(req.ApplicationType, req.Permission); err != nil {
+		return SaveApprovalPolicyResponse{}, err
+	}
+
+	now := time.Now()
+	policy := acl.ApprovalPolicy{
+		ID:              acl.ApprovalPolicyID(req.ApplicationType, req.Permission),
+		ApplicationType: req.ApplicationType,
+		Permission:      req.Permission,
+		Stages:          req.Stages,
+		UpdatedBy:       user.Username,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := authlogic.ValidateApprovalPolicy(policy); err != nil {
+		return SaveApprovalPolicyResponse{}, err
+	}
+	for i, stage := range policy.Stages {
+		if stage.ReviewerGroup == acl.ReviewerGroup_Owner || stage.ReviewerGroup == acl.ReviewerGroup_Claimant {
+			continue
+		}
+		if _, err := uc.repo.GetGroupByName(stage.ReviewerGroup); err != nil {
+			return SaveApprovalPolicyResponse{}, fmt.Errorf("stage %d: group %s not found", i+1, stage.ReviewerGroup)
+		}
+	}
+
+	if err := uc.repo.UpsertApprovalPolicy(policy); err != nil {
+		return SaveApprovalPolicyResponse{}, err
+	}
+	return SaveApprovalPolicyResponse{ID: policy.ID}, nil
+}
+
+// validatePolicyPermission makes sure the policy targets an application type that goes through tickets
+// and, if set, a permission that can be requested with it.
+func validatePolicyPermission(applicationType, permission string) error {
+	var permissions []acl.Permission
+	switch applicationType {
+	case acl.ApplicationType_TopicForm:
+		permissions = acl.TopicActionPermissions
+	case acl.ApplicationType_ChannelForm:
+		permissions = acl.ChannelActionPermissions
+	case acl.ApplicationType_Claim:
+		permissions = []acl.Permission{acl.Permission_Claim_Entity}
+	case acl.ApplicationType_Transfer:
+		permissions = []acl.Permission{acl.Permission_Transfer_Entity}
+	case acl.ApplicationType_Membership:
+		permissions = acl.GroupMembershipPermissions
+	default:
+		return fmt.Errorf("approval policies are not supported for application type %q", applicationType)
+	}
+	if permission == "" {
+		return nil
+	}
+	for _, p := range permissions {
+		if p.Name == permission {
+			return nil
+		}
+	}
+	return fmt.Errorf("permission %s cannot be requested with %s applications", permission, applicationType)
+}
@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	policy_mock "github.com/jekiapp/topic-master/internal/usecase/tickets/policy/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSaveApprovalPolicyUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		loggedIn  bool
+		req       SaveApprovalPolicyRequest
+		setupMock func(m *policy_mock.MockiSaveApprovalPolicyRepo)
+		wantErr   bool
+		wantID    string
+	}{
+		{
+			name:      "unauthorized user cannot save",
+			req:       SaveApprovalPolicyRequest{ApplicationType: acl.ApplicationType_TopicForm},
+			setupMock: func(m *policy_mock.MockiSaveApprovalPolicyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "signup applications are not supported",
+			loggedIn: true,
+			req: SaveApprovalPolicyRequest{
+				ApplicationType: acl.ApplicationType_Signup,
+				Stages:          []acl.ApprovalStage{{ReviewerGroup: acl.GroupRoot, RequiredApprovals: 1}},
+			},
+			setupMock: func(m *policy_mock.MockiSaveApprovalPolicyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "channel permission on a topic policy",
+			loggedIn: true,
+			req: SaveApprovalPolicyRequest{
+				ApplicationType: acl.ApplicationType_TopicForm,
+				Permission:      acl.Permission_Channel_Empty.Name,
+				Stages:          []acl.ApprovalStage{{ReviewerGroup: acl.ReviewerGroup_Owner, RequiredApprovals: 2}},
+			},
+			setupMock: func(m *policy_mock.MockiSaveApprovalPolicyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "stage without approvals",
+			loggedIn: true,
+			req: SaveApprovalPolicyRequest{
+				ApplicationType: acl.ApplicationType_TopicForm,
+				Stages:          []acl.ApprovalStage{{ReviewerGroup: acl.ReviewerGroup_Owner}},
+			},
+			setupMock: func(m *policy_mock.MockiSaveApprovalPolicyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "claimant reviewer outside claim policies",
+			loggedIn: true,
+			req: SaveApprovalPolicyRequest{
+				ApplicationType: acl.ApplicationType_ChannelForm,
+				Stages:          []acl.ApprovalStage{{ReviewerGroup: acl.ReviewerGroup_Claimant, RequiredApprovals: 1}},
+			},
+			setupMock: func(m *policy_mock.MockiSaveApprovalPolicyRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "unknown reviewer group",
+			loggedIn: true,
+			req: SaveApprovalPolicyRequest{
+				ApplicationType: acl.ApplicationType_TopicForm,
+				Permission:      acl.Permission_Topic_Delete.Name,
+				Stages:          []acl.ApprovalStage{{ReviewerGroup: "sre", RequiredApprovals: 1}},
+			},
+			setupMock: func(m *policy_mock.MockiSaveApprovalPolicyRepo) {
+				m.EXPECT().GetGroupByName("sre").Return(acl.Group{}, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name:     "two owner admins then sre must approve topic delete",
+			loggedIn: true,
+			req: SaveApprovalPolicyRequest{
+				ApplicationType: acl.ApplicationType_TopicForm,
+				Permission:      acl.Permission_Topic_Delete.Name,
+				Stages: []acl.ApprovalStage{
+					{Name: "Owner", ReviewerGroup: acl.ReviewerGroup_Owner, RequiredApprovals: 2},
+					{Name: "SRE", ReviewerGroup: "sre", RequiredApprovals: 1},
+				},
+			},
+			setupMock: func(m *policy_mock.MockiSaveApprovalPolicyRepo) {
+				m.EXPECT().GetGroupByName("sre").Return(acl.Group{ID: "sre-id", Name: "sre"}, nil)
+				m.EXPECT().UpsertApprovalPolicy(gomock.Any()).DoAndReturn(func(policy acl.ApprovalPolicy) error {
+					assert.Equal(t, "root", policy.UpdatedBy)
+					assert.Len(t, policy.Stages, 2)
+					return nil
+				})
+			},
+			wantID: acl.ApplicationType_TopicForm + ":" + acl.Permission_Topic_Delete.Name,
+		},
+		{
+			name:     "claim needs owner and claimant",
+			loggedIn: true,
+			req: SaveApprovalPolicyRequest{
+				ApplicationType: acl.ApplicationType_Claim,
+				Stages: []acl.ApprovalStage{
+					{ReviewerGroup: acl.ReviewerGroup_Owner, RequiredApprovals: 1},
+					{ReviewerGroup: acl.ReviewerGroup_Claimant, RequiredApprovals: 1},
+				},
+			},
+			setupMock: func(m *policy_mock.MockiSaveApprovalPolicyRepo) {
+				m.EXPECT().UpsertApprovalPolicy(gomock.Any()).Return(nil)
+			},
+			wantID: acl.ApplicationType_Claim + ":",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "root-id", Username: "root"})
+			}
+			mockRepo := policy_mock.NewMockiSaveApprovalPolicyRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := SaveApprovalPolicyUsecase{repo: mockRepo}
+			resp, err := uc.Handle(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, resp.ID)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+//go:generate mockgen -source=reassign_ticket.go -destination=mock/mock_reassign_ticket_repo.go -package=mock
+
+package tickets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/logic/notification"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	notifmodel "github.com/jekiapp/topic-master/internal/model/notification"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// ReassignTicketRequest hands an open review of a ticket to another user
+type ReassignTicketRequest struct {
+	ApplicationID    string `json:"application_id"`
+	AssignmentID     string `json:"assignment_id"`
+	ReviewerUsername string `json:"reviewer_username"`
+}
+
+type ReassignTicketResponse struct {
+	Message string `json:"message"`
+}
+
+type ReassignTicketUsecase struct {
+	repo iReassignTicketRepo
+}
+
+func NewReassignTicketUsecase(db *buntdb.DB) ReassignTicketUsecase {
+	return ReassignTicketUsecase{
+		repo: &reassignTicketRepo{db: db},
+	}
+}
+
+// Handle is root only, the route is behind the root middleware
+func (uc ReassignTicketUsecase) Handle(ctx context.Context, req ReassignTicketRequest) (ReassignTicketResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return ReassignTicketResponse{}, errors.New("unauthorized: user info not found")
+	}
+	username := strings.TrimSpace(req.ReviewerUsername)
+	if username == "" {
+		return ReassignTicketResponse{}, errors.New("reviewer_username is required")
+	}
+	app, err := uc.repo.GetApplicationByID(req.ApplicationID)
+	if err != nil {
+		return ReassignTicketResponse{}, fmt.Errorf("ticket %s not found", req.ApplicationID)
+	}
+	if app.IsClosed() {
+		return ReassignTicketResponse{}, errors.New("ticket is already closed")
+	}
+	assignments, err := uc.repo.ListAssignmentsByApplicationID(app.ID)
+	if err != nil {
+		return ReassignTicketResponse{}, err
+	}
+
+	var assignment acl.ApplicationAssignment
+	found := false
+	for _, a := range assignments {
+		if a.ID == req.AssignmentID {
+			assignment, found = a, true
+			break
+		}
+	}
+	if !found {
+		return ReassignTicketResponse{}, errors.New("assignment not found on this ticket")
+	}
+	if assignment.Stage != app.CurrentStage || assignment.ReviewStatus != acl.ReviewStatusWaiting {
+		return ReassignTicketResponse{}, errors.New("only an open review of the current stage can be reassigned")
+	}
+
+	reviewer, err := uc.repo.GetUserByUsername(username)
+	if err != nil {
+		return ReassignTicketResponse{}, fmt.Errorf("user %s not found", username)
+	}
+	if reviewer.Status != acl.StatusUserActive {
+		return ReassignTicketResponse{}, fmt.Errorf("user %s is not active", username)
+	}
+	if reviewer.ID == app.UserID {
+		return ReassignTicketResponse{}, errors.New("the applicant cannot review their own ticket")
+	}
+	for _, a := range assignments {
+		if a.Stage == app.CurrentStage && a.ReviewerID == reviewer.ID {
+			return ReassignTicketResponse{}, fmt.Errorf("%s is already a reviewer of this stage", username)
+		}
+	}
+
+	previousID := assignment.ReviewerID
+	now := time.Now()
+	assignment.ReviewerID = reviewer.ID
+	assignment.OnBehalfOf = ""
+	assignment.UpdatedAt = now
+	if err := uc.repo.UpdateApplicationAssignment(assignment); err != nil {
+		return ReassignTicketResponse{}, fmt.Errorf("error reassigning: %v", err)
+	}
+
+	previous := previousID
+	if prevUser, err := uc.repo.GetUserByID(previousID); err == nil {
+		previous = prevUser.Username
+	}
+	history := acl.ApplicationHistory{
+		ID:            uuid.NewString(),
+		ApplicationID: app.ID,
+		Action:        acl.ActionReassign,
+		ActorID:       user.ID,
+		Comment:       fmt.Sprintf("review reassigned from %s to %s", previous, reviewer.Username),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := uc.repo.CreateApplicationHistory(history); err != nil {
+		// log but do not fail
+		log.Println("failed to create application history", err)
+	}
+	notification.Publish(notification.TicketEvent(notifmodel.EventTicketAssigned, app, reviewer.ID))
+	return ReassignTicketResponse{Message: fmt.Sprintf("Review reassigned to %s", reviewer.Username)}, nil
+}
+
+type iReassignTicketRepo interface {
+	GetApplicationByID(id string) (acl.Application, error)
+	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
+	UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	CreateApplicationHistory(history acl.ApplicationHistory) error
+	GetUserByID(id string) (acl.User, error)
+	GetUserByUsername(username string) (acl.User, error)
+}
+
+type reassignTicketRepo struct {
+	db *buntdb.DB
+}
+
+func (r *reassignTicketRepo) GetApplicationByID(id string) (acl.Application, error) {
+	return dbpkg.GetByID[acl.Application](r.db, id)
+}
+
+func (r *reassignTicketRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	return dbpkg.SelectAll[acl.ApplicationAssignment](r.db, "="+appID, acl.IdxAppAssign_ApplicationID)
+}
+
+func (r *reassignTicketRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return dbpkg.Update(r.db, &assignment)
+}
+
+func (r *reassignTicketRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return apprepo.CreateApplicationHistory(r.db, history)
+}
+
+func (r *reassignTicketRepo) GetUserByID(id string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, id)
+}
+
+func (r *reassignTicketRepo) GetUserByUsername(username string) (acl.User, error) {
+	return userrepo.GetUserByUsername(r.db, username)
+}
@@ -0,0 +1,119 @@
+package tickets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/usecase/tickets/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReassignTicketUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	root := &acl.User{ID: "root-id", Username: "root"}
+	app := acl.Application{ID: "app-1", UserID: "alice-id", Status: acl.StatusWaitingForApproval}
+	waiting := acl.ApplicationAssignment{ID: "as-1", ApplicationID: "app-1", ReviewerID: "carol-id", ReviewStatus: acl.ReviewStatusWaiting}
+	delegated := acl.ApplicationAssignment{ID: "as-2", ApplicationID: "app-1", ReviewerID: "dave-id", OnBehalfOf: "carol-id", ReviewStatus: acl.ReviewStatusWaiting}
+	approved := acl.ApplicationAssignment{ID: "as-3", ApplicationID: "app-1", ReviewerID: "erin-id", ReviewStatus: acl.ReviewStatusApproved}
+	bob := acl.User{ID: "bob-id", Username: "bob", Status: acl.StatusUserActive}
+
+	tests := []struct {
+		name      string
+		user      *acl.User
+		req       ReassignTicketRequest
+		setupMock func(m *mock.MockiReassignTicketRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "unauthorized",
+			req:       ReassignTicketRequest{ApplicationID: "app-1", AssignmentID: "as-1", ReviewerUsername: "bob"},
+			setupMock: func(m *mock.MockiReassignTicketRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "closed ticket",
+			user: root,
+			req:  ReassignTicketRequest{ApplicationID: "app-1", AssignmentID: "as-1", ReviewerUsername: "bob"},
+			setupMock: func(m *mock.MockiReassignTicketRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(acl.Application{ID: "app-1", Status: acl.StatusWithdrawn}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "review already done",
+			user: root,
+			req:  ReassignTicketRequest{ApplicationID: "app-1", AssignmentID: "as-3", ReviewerUsername: "bob"},
+			setupMock: func(m *mock.MockiReassignTicketRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{waiting, approved}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reviewer already assigned",
+			user: root,
+			req:  ReassignTicketRequest{ApplicationID: "app-1", AssignmentID: "as-1", ReviewerUsername: "dave"},
+			setupMock: func(m *mock.MockiReassignTicketRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{waiting, delegated}, nil)
+				m.EXPECT().GetUserByUsername("dave").Return(acl.User{ID: "dave-id", Username: "dave", Status: acl.StatusUserActive}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "applicant cannot review",
+			user: root,
+			req:  ReassignTicketRequest{ApplicationID: "app-1", AssignmentID: "as-1", ReviewerUsername: "alice"},
+			setupMock: func(m *mock.MockiReassignTicketRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{waiting}, nil)
+				m.EXPECT().GetUserByUsername("alice").Return(acl.User{ID: "alice-id", Username: "alice", Status: acl.StatusUserActive}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success clears the delegation",
+			user: root,
+			req:  ReassignTicketRequest{ApplicationID: "app-1", AssignmentID: "as-2", ReviewerUsername: "bob"},
+			setupMock: func(m *mock.MockiReassignTicketRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{waiting, delegated}, nil)
+				m.EXPECT().GetUserByUsername("bob").Return(bob, nil)
+				m.EXPECT().UpdateApplicationAssignment(gomock.Any()).DoAndReturn(func(a acl.ApplicationAssignment) error {
+					assert.Equal(t, "as-2", a.ID)
+					assert.Equal(t, "bob-id", a.ReviewerID)
+					assert.Empty(t, a.OnBehalfOf)
+					return nil
+				})
+				m.EXPECT().GetUserByID("dave-id").Return(acl.User{ID: "dave-id", Username: "dave"}, nil)
+				m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+					assert.Equal(t, acl.ActionReassign, h.Action)
+					assert.Equal(t, "review reassigned from dave to bob", h.Comment)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock.NewMockiReassignTicketRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := ReassignTicketUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = util.MockContextWithUser(ctx, tt.user)
+			}
+			_, err := uc.Handle(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+//go:generate mockgen -source=search_tickets.go -destination=mock/mock_search_tickets_repo.go -package=mock
+
+package tickets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+const searchDateLayout = "2006-01-02"
+
+type SearchTicketsResponse struct {
+	Tickets []ticketSearchItem `json:"tickets"`
+	Total   int                `json:"total"`
+	HasNext bool               `json:"has_next"`
+}
+
+type ticketSearchItem struct {
+	ID            string    `json:"id"`
+	Title         string    `json:"title"`
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	ApplicantName string    `json:"applicant_name"`
+	EntityID      string    `json:"entity_id"`
+	Permissions   []string  `json:"permissions"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ticketFilter holds the parsed search params, empty fields match everything
+type ticketFilter struct {
+	Type        string
+	Status      string
+	ApplicantID string
+	EntityID    string
+	Permission  string
+	From        time.Time
+	To          time.Time // exclusive
+}
+
+func (f ticketFilter) match(app acl.Application) bool {
+	if f.Type != "" && app.Type != f.Type {
+		return false
+	}
+	if f.Status != "" && app.Status != f.Status {
+		return false
+	}
+	if f.ApplicantID != "" && app.UserID != f.ApplicantID {
+		return false
+	}
+	if f.EntityID != "" && app.MetaData[acl.AppMetaData_EntityID] != f.EntityID {
+		return false
+	}
+	if f.Permission != "" && !slices.Contains(app.PermissionIDs, f.Permission) {
+		return false
+	}
+	if !f.From.IsZero() && app.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !app.CreatedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+type iSearchTicketsRepo interface {
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	ListAllApplications() ([]acl.Application, error)
+	ListApplicationsByUserID(userID string) ([]acl.Application, error)
+	ListAssignmentsByReviewerID(reviewerID string) ([]acl.ApplicationAssignment, error)
+	GetApplicationByID(id string) (acl.Application, error)
+	GetUserByID(id string) (acl.User, error)
+	GetUserByUsername(username string) (acl.User, error)
+}
+
+type searchTicketsRepo struct {
+	db *buntdb.DB
+}
+
+func (r *searchTicketsRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *searchTicketsRepo) ListAllApplications() ([]acl.Application, error) {
+	return apprepo.ListAllApplications(r.db)
+}
+
+func (r *searchTicketsRepo) ListApplicationsByUserID(userID string) ([]acl.Application, error) {
+	return apprepo.ListApplicationsByUserID(r.db, userID)
+}
+
+func (r *searchTicketsRepo) ListAssignmentsByReviewerID(reviewerID string) ([]acl.ApplicationAssignment, error) {
+	return apprepo.ListAssignmentsByReviewerID(r.db, reviewerID)
+}
+
+func (r *searchTicketsRepo) GetApplicationByID(id string) (acl.Application, error) {
+	return dbpkg.GetByID[acl.Application](r.db, id)
+}
+
+func (r *searchTicketsRepo) GetUserByID(id string) (acl.User, error) {
+	return userrepo.GetUserByID(r.db, id)
+}
+
+func (r *searchTicketsRepo) GetUserByUsername(username string) (acl.User, error) {
+	return userrepo.GetUserByUsername(r.db, username)
+}
+
+type SearchTicketsUsecase struct {
+	repo iSearchTicketsRepo
+}
+
+func NewSearchTicketsUsecase(db *buntdb.DB) SearchTicketsUsecase {
+	return SearchTicketsUsecase{
+		repo: &searchTicketsRepo{db: db},
+	}
+}
+
+// Handle searches the tickets visible to the user: root sees every ticket, the others the tickets
+// they applied for or were assigned to review. Results are newest first.
+// params may contain "type", "status", "applicant" (username), "entity_id", "permission",
+// "from" and "to" (YYYY-MM-DD, both inclusive), "page" and "limit"
+func (uc SearchTicketsUsecase) Handle(ctx context.Context, params map[string]string) (SearchTicketsResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return SearchTicketsResponse{}, errors.New("unauthorized: user info not found")
+	}
+	filter, err := uc.parseFilter(params)
+	if err != nil {
+		return SearchTicketsResponse{}, err
+	}
+	page, limit := 1, 20
+	if v, ok := params["page"]; ok {
+		fmt.Sscanf(v, "%d", &page)
+	}
+	if v, ok := params["limit"]; ok {
+		fmt.Sscanf(v, "%d", &limit)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	apps, err := uc.visibleApplications(user)
+	if err != nil {
+		return SearchTicketsResponse{}, err
+	}
+	matched := []acl.Application{}
+	for _, app := range apps {
+		if filter.match(app) {
+			matched = append(matched, app)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].CreatedAt.After(matched[j].CreatedAt)
+	})
+
+	resp := SearchTicketsResponse{Tickets: []ticketSearchItem{}, Total: len(matched)}
+	start := (page - 1) * limit
+	if start >= len(matched) {
+		return resp, nil
+	}
+	end := min(start+limit, len(matched))
+	resp.HasNext = end < len(matched)
+
+	names := map[string]string{}
+	for _, app := range matched[start:end] {
+		if _, ok := names[app.UserID]; !ok {
+			// signup applicants are still pending users, they have no name yet
+			if applicant, err := uc.repo.GetUserByID(app.UserID); err == nil {
+				names[app.UserID] = applicant.Username
+			}
+		}
+		resp.Tickets = append(resp.Tickets, ticketSearchItem{
+			ID:            app.ID,
+			Title:         app.Title,
+			Type:          app.Type,
+			Status:        app.Status,
+			ApplicantName: names[app.UserID],
+			EntityID:      app.MetaData[acl.AppMetaData_EntityID],
+			Permissions:   app.PermissionIDs,
+			CreatedAt:     app.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+func (uc SearchTicketsUsecase) parseFilter(params map[string]string) (ticketFilter, error) {
+	filter := ticketFilter{
+		Type:       strings.TrimSpace(params["type"]),
+		Status:     strings.TrimSpace(params["status"]),
+		EntityID:   strings.TrimSpace(params["entity_id"]),
+		Permission: strings.TrimSpace(params["permission"]),
+	}
+	if username := strings.TrimSpace(params["applicant"]); username != "" {
+		applicant, err := uc.repo.GetUserByUsername(username)
+		if err != nil {
+			return ticketFilter{}, fmt.Errorf("applicant %s not found", username)
+		}
+		filter.ApplicantID = applicant.ID
+	}
+	if v := strings.TrimSpace(params["from"]); v != "" {
+		from, err := time.ParseInLocation(searchDateLayout, v, time.Local)
+		if err != nil {
+			return ticketFilter{}, fmt.Errorf("invalid from date %q, expected YYYY-MM-DD", v)
+		}
+		filter.From = from
+	}
+	if v := strings.TrimSpace(params["to"]); v != "" {
+		to, err := time.ParseInLocation(searchDateLayout, v, time.Local)
+		if err != nil {
+			return ticketFilter{}, fmt.Errorf("invalid to date %q, expected YYYY-MM-DD", v)
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return ticketFilter{}, errors.New("from must not be after to")
+	}
+	return filter, nil
+}
+
+func (uc SearchTicketsUsecase) visibleApplications(user *acl.User) ([]acl.Application, error) {
+	if isRoot(uc.repo, user) {
+		return uc.repo.ListAllApplications()
+	}
+	apps, err := uc.repo.ListApplicationsByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, app := range apps {
+		seen[app.ID] = true
+	}
+	assignments, err := uc.repo.ListAssignmentsByReviewerID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		if seen[assignment.ApplicationID] {
+			continue
+		}
+		seen[assignment.ApplicationID] = true
+		app, err := uc.repo.GetApplicationByID(assignment.ApplicationID)
+		if err != nil {
+			continue
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
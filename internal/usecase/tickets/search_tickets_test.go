@@ -0,0 +1,137 @@
+package tickets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/usecase/tickets/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchTicketsUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	root := &acl.User{ID: "root-id", Username: "root", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}}
+	rootGroups := []acl.GroupRole{{GroupName: acl.GroupRoot}}
+	alice := &acl.User{ID: "alice-id", Username: "alice"}
+	// removed from root after logging in, the token still lists the group
+	demoted := &acl.User{ID: "alice-id", Username: "alice", Groups: rootGroups}
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 10, 0, 0, 0, time.Local) }
+	app1 := acl.Application{ID: "app-1", UserID: "alice-id", Type: acl.ApplicationType_TopicForm, Status: acl.StatusWaitingForApproval,
+		PermissionIDs: []string{"perm-pub"}, MetaData: map[string]string{acl.AppMetaData_EntityID: "topic-1"}, CreatedAt: day(1)}
+	app2 := acl.Application{ID: "app-2", UserID: "bob-id", Type: acl.ApplicationType_TopicForm, Status: acl.StatusCompleted,
+		PermissionIDs: []string{"perm-sub"}, MetaData: map[string]string{acl.AppMetaData_EntityID: "topic-2"}, CreatedAt: day(2)}
+	app3 := acl.Application{ID: "app-3", UserID: "bob-id", Type: acl.ApplicationType_Signup, Status: acl.StatusWaitingForApproval, CreatedAt: day(3)}
+
+	tests := []struct {
+		name      string
+		user      *acl.User
+		params    map[string]string
+		setupMock func(m *mock.MockiSearchTicketsRepo)
+		wantIDs   []string
+		wantNext  bool
+		wantErr   bool
+	}{
+		{
+			name:      "unauthorized",
+			params:    map[string]string{},
+			setupMock: func(m *mock.MockiSearchTicketsRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid date",
+			user:      alice,
+			params:    map[string]string{"from": "01/03/2026"},
+			setupMock: func(m *mock.MockiSearchTicketsRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:   "unknown applicant",
+			user:   root,
+			params: map[string]string{"applicant": "nobody"},
+			setupMock: func(m *mock.MockiSearchTicketsRepo) {
+				m.EXPECT().GetUserByUsername("nobody").Return(acl.User{}, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:   "root sees all, newest first",
+			user:   root,
+			params: map[string]string{},
+			setupMock: func(m *mock.MockiSearchTicketsRepo) {
+				m.EXPECT().GetGroupsByUserID("root-id").Return(rootGroups, nil)
+				m.EXPECT().ListAllApplications().Return([]acl.Application{app1, app2, app3}, nil)
+				m.EXPECT().GetUserByID("bob-id").Return(acl.User{ID: "bob-id", Username: "bob"}, nil)
+				m.EXPECT().GetUserByID("alice-id").Return(acl.User{ID: "alice-id", Username: "alice"}, nil)
+			},
+			wantIDs: []string{"app-3", "app-2", "app-1"},
+		},
+		{
+			name:   "root filters by type, permission and date",
+			user:   root,
+			params: map[string]string{"type": acl.ApplicationType_TopicForm, "permission": "perm-sub", "from": "2026-03-02", "to": "2026-03-02"},
+			setupMock: func(m *mock.MockiSearchTicketsRepo) {
+				m.EXPECT().GetGroupsByUserID("root-id").Return(rootGroups, nil)
+				m.EXPECT().ListAllApplications().Return([]acl.Application{app1, app2, app3}, nil)
+				m.EXPECT().GetUserByID("bob-id").Return(acl.User{ID: "bob-id", Username: "bob"}, nil)
+			},
+			wantIDs: []string{"app-2"},
+		},
+		{
+			name:   "user sees own and assigned tickets, paginated",
+			user:   alice,
+			params: map[string]string{"status": acl.StatusWaitingForApproval, "limit": "1"},
+			setupMock: func(m *mock.MockiSearchTicketsRepo) {
+				m.EXPECT().GetGroupsByUserID("alice-id").Return(nil, nil)
+				m.EXPECT().ListApplicationsByUserID("alice-id").Return([]acl.Application{app1}, nil)
+				m.EXPECT().ListAssignmentsByReviewerID("alice-id").Return([]acl.ApplicationAssignment{
+					{ApplicationID: "app-1"}, {ApplicationID: "app-3"}, {ApplicationID: "app-3"},
+				}, nil)
+				m.EXPECT().GetApplicationByID("app-3").Return(app3, nil)
+				m.EXPECT().GetUserByID("bob-id").Return(acl.User{}, assert.AnError)
+			},
+			wantIDs:  []string{"app-3"},
+			wantNext: true,
+		},
+		{
+			name:   "root in the token only sees own and assigned tickets",
+			user:   demoted,
+			params: map[string]string{},
+			setupMock: func(m *mock.MockiSearchTicketsRepo) {
+				m.EXPECT().GetGroupsByUserID("alice-id").Return(nil, nil)
+				m.EXPECT().ListApplicationsByUserID("alice-id").Return([]acl.Application{app1}, nil)
+				m.EXPECT().ListAssignmentsByReviewerID("alice-id").Return(nil, nil)
+				m.EXPECT().GetUserByID("alice-id").Return(acl.User{ID: "alice-id", Username: "alice"}, nil)
+			},
+			wantIDs: []string{"app-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock.NewMockiSearchTicketsRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := SearchTicketsUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = util.MockContextWithUser(ctx, tt.user)
+			}
+			resp, err := uc.Handle(ctx, tt.params)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			ids := []string{}
+			for _, ticket := range resp.Tickets {
+				ids = append(ids, ticket.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantNext, resp.HasNext)
+		})
+	}
+}
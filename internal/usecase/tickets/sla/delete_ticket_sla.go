@@ -0,0 +1,52 @@
+//go:generate mockgen -source=delete_ticket_sla.go -destination=mock/mock_delete_ticket_sla_repo.go -package=sla_mock
+
+package sla
+
+import (
+	"context"
+	"errors"
+
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	"github.com/tidwall/buntdb"
+)
+
+type DeleteTicketSLARequest struct {
+	ApplicationType string `json:"application_type"`
+}
+
+type DeleteTicketSLAResponse struct {
+	Success bool `json:"success"`
+}
+
+type iDeleteTicketSLARepo interface {
+	DeleteTicketSLA(applicationType string) error
+}
+
+type deleteTicketSLARepo struct {
+	db *buntdb.DB
+}
+
+func (r *deleteTicketSLARepo) DeleteTicketSLA(applicationType string) error {
+	return apprepo.DeleteTicketSLA(r.db, applicationType)
+}
+
+type DeleteTicketSLAUsecase struct {
+	repo iDeleteTicketSLARepo
+}
+
+func NewDeleteTicketSLAUsecase(db *buntdb.DB) DeleteTicketSLAUsecase {
+	return DeleteTicketSLAUsecase{
+		repo: &deleteTicketSLARepo{db: db},
+	}
+}
+
+// Handle removes the SLA of an application type, its tickets then wait without reminders or escalation.
+func (uc DeleteTicketSLAUsecase) Handle(ctx context.Context, req DeleteTicketSLARequest) (DeleteTicketSLAResponse, error) {
+	if req.ApplicationType == "" {
+		return DeleteTicketSLAResponse{}, errors.New("missing required field: application_type")
+	}
+	if err := uc.repo.DeleteTicketSLA(req.ApplicationType); err != nil {
+		return DeleteTicketSLAResponse{}, err
+	}
+	return DeleteTicketSLAResponse{Success: true}, nil
+}
@@ -0,0 +1,57 @@
+package sla
+
+import (
+	"context"
+	"testing"
+
+	sla_mock "github.com/jekiapp/topic-master/internal/usecase/tickets/sla/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteTicketSLAUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		req       DeleteTicketSLARequest
+		setupMock func(m *sla_mock.MockiDeleteTicketSLARepo)
+		wantErr   bool
+	}{
+		{
+			name:      "missing application type",
+			setupMock: func(m *sla_mock.MockiDeleteTicketSLARepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "repo error",
+			req:  DeleteTicketSLARequest{ApplicationType: "topic_action"},
+			setupMock: func(m *sla_mock.MockiDeleteTicketSLARepo) {
+				m.EXPECT().DeleteTicketSLA("topic_action").Return(context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			req:  DeleteTicketSLARequest{ApplicationType: "claim"},
+			setupMock: func(m *sla_mock.MockiDeleteTicketSLARepo) {
+				m.EXPECT().DeleteTicketSLA("claim").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := sla_mock.NewMockiDeleteTicketSLARepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := DeleteTicketSLAUsecase{repo: mockRepo}
+			resp, err := uc.Handle(context.Background(), tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, resp.Success)
+		})
+	}
+}
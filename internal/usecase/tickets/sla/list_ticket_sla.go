@@ -0,0 +1,47 @@
+//go:generate mockgen -source=list_ticket_sla.go -destination=mock/mock_list_ticket_sla_repo.go -package=sla_mock
+
+package sla
+
+import (
+	"context"
+	"sort"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	"github.com/tidwall/buntdb"
+)
+
+type ListTicketSLAResponse struct {
+	SLAs []acl.TicketSLA `json:"slas"`
+}
+
+type iListTicketSLARepo interface {
+	ListTicketSLAs() ([]acl.TicketSLA, error)
+}
+
+type listTicketSLARepo struct {
+	db *buntdb.DB
+}
+
+func (r *listTicketSLARepo) ListTicketSLAs() ([]acl.TicketSLA, error) {
+	return apprepo.ListTicketSLAs(r.db)
+}
+
+type ListTicketSLAUsecase struct {
+	repo iListTicketSLARepo
+}
+
+func NewListTicketSLAUsecase(db *buntdb.DB) ListTicketSLAUsecase {
+	return ListTicketSLAUsecase{
+		repo: &listTicketSLARepo{db: db},
+	}
+}
+
+func (uc ListTicketSLAUsecase) Handle(ctx context.Context, params map[string]string) (ListTicketSLAResponse, error) {
+	slas, err := uc.repo.ListTicketSLAs()
+	if err != nil {
+		return ListTicketSLAResponse{}, err
+	}
+	sort.Slice(slas, func(i, j int) bool { return slas[i].ApplicationType < slas[j].ApplicationType })
+	return ListTicketSLAResponse{SLAs: slas}, nil
+}
@@ -0,0 +1,31 @@
+package sla
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	sla_mock "github.com/jekiapp/topic-master/internal/usecase/tickets/sla/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListTicketSLAUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := sla_mock.NewMockiListTicketSLARepo(ctrl)
+	mockRepo.EXPECT().ListTicketSLAs().Return([]acl.TicketSLA{
+		{ApplicationType: acl.ApplicationType_TopicForm},
+		{ApplicationType: acl.ApplicationType_Claim},
+	}, nil)
+	uc := ListTicketSLAUsecase{repo: mockRepo}
+	resp, err := uc.Handle(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, acl.ApplicationType_Claim, resp.SLAs[0].ApplicationType)
+	assert.Equal(t, acl.ApplicationType_TopicForm, resp.SLAs[1].ApplicationType)
+
+	mockRepo.EXPECT().ListTicketSLAs().Return(nil, context.DeadlineExceeded)
+	_, err = uc.Handle(context.Background(), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tickets/sla/delete_ticket_sla.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tickets/sla/delete_ticket_sla.go -destination=internal/usecase/tickets/sla/mock/mock_delete_ticket_sla_repo.go -package=sla_mock
+//
+
+// Package sla_mock is a generated GoMock package.
+package sla_mock
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiDeleteTicketSLARepo is a mock of iDeleteTicketSLARepo interface.
+type MockiDeleteTicketSLARepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiDeleteTicketSLARepoMockRecorder
+}
+
+// MockiDeleteTicketSLARepoMockRecorder is the mock recorder for MockiDeleteTicketSLARepo.
+type MockiDeleteTicketSLARepoMockRecorder struct {
+	mock *MockiDeleteTicketSLARepo
+}
+
+// NewMockiDeleteTicketSLARepo creates a new mock instance.
+func NewMockiDeleteTicketSLARepo(ctrl *gomock.Controller) *MockiDeleteTicketSLARepo {
+	mock := &MockiDeleteTicketSLARepo{ctrl: ctrl}
+	mock.recorder = &MockiDeleteTicketSLARepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiDeleteTicketSLARepo) EXPECT() *MockiDeleteTicketSLARepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteTicketSLA mocks base method.
+func (m *MockiDeleteTicketSLARepo) DeleteTicketSLA(applicationType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTicketSLA", applicationType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTicketSLA indicates an expected call of DeleteTicketSLA.
+func (mr *MockiDeleteTicketSLARepoMockRecorder) DeleteTicketSLA(applicationType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTicketSLA", reflect.TypeOf((*MockiDeleteTicketSLARepo)(nil).DeleteTicketSLA), applicationType)
+}
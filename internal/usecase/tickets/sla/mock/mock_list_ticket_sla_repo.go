@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tickets/sla/list_ticket_sla.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tickets/sla/list_ticket_sla.go -destination=internal/usecase/tickets/sla/mock/mock_list_ticket_sla_repo.go -package=sla_mock
+//
+
+// Package sla_mock is a generated GoMock package.
+package sla_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiListTicketSLARepo is a mock of iListTicketSLARepo interface.
+type MockiListTicketSLARepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiListTicketSLARepoMockRecorder
+}
+
+// MockiListTicketSLARepoMockRecorder is the mock recorder for MockiListTicketSLARepo.
+type MockiListTicketSLARepoMockRecorder struct {
+	mock *MockiListTicketSLARepo
+}
+
+// NewMockiListTicketSLARepo creates a new mock instance.
+func NewMockiListTicketSLARepo(ctrl *gomock.Controller) *MockiListTicketSLARepo {
+	mock := &MockiListTicketSLARepo{ctrl: ctrl}
+	mock.recorder = &MockiListTicketSLARepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiListTicketSLARepo) EXPECT() *MockiListTicketSLARepoMockRecorder {
+	return m.recorder
+}
+
+// ListTicketSLAs mocks base method.
+func (m *MockiListTicketSLARepo) ListTicketSLAs() ([]acl.TicketSLA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTicketSLAs")
+	ret0, _ := ret[0].([]acl.TicketSLA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTicketSLAs indicates an expected call of ListTicketSLAs.
+func (mr *MockiListTicketSLARepoMockRecorder) ListTicketSLAs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTicketSLAs", reflect.TypeOf((*MockiListTicketSLARepo)(nil).ListTicketSLAs))
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tickets/sla/save_ticket_sla.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tickets/sla/save_ticket_sla.go -destination=internal/usecase/tickets/sla/mock/mock_save_ticket_sla_repo.go -package=sla_mock
+//
+
+// Package sla_mock is a generated GoMock package.
+package sla_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiSaveTicketSLARepo is a mock of iSaveTicketSLARepo interface.
+type MockiSaveTicketSLARepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiSaveTicketSLARepoMockRecorder
+}
+
+// MockiSaveTicketSLARepoMockRecorder is the mock recorder for MockiSaveTicketSLARepo.
+type MockiSaveTicketSLARepoMockRecorder struct {
+	mock *MockiSaveTicketSLARepo
+}
+
+// NewMockiSaveTicketSLARepo creates a new mock instance.
+func NewMockiSaveTicketSLARepo(ctrl *gomock.Controller) *MockiSaveTicketSLARepo {
+	mock := &MockiSaveTicketSLARepo{ctrl: ctrl}
+	mock.recorder = &MockiSaveTicketSLARepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiSaveTicketSLARepo) EXPECT() *MockiSaveTicketSLARepoMockRecorder {
+	return m.recorder
+}
+
+// UpsertTicketSLA mocks base method.
+func (m *MockiSaveTicketSLARepo) UpsertTicketSLA(sla acl.TicketSLA) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTicketSLA", sla)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertTicketSLA indicates an expected call of UpsertTicketSLA.
+func (mr *MockiSaveTicketSLARepoMockRecorder) UpsertTicketSLA(sla any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTicketSLA", reflect.TypeOf((*MockiSaveTicketSLARepo)(nil).UpsertTicketSLA), sla)
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tickets/sla/ticket_sla_janitor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tickets/sla/ticket_sla_janitor.go -destination=internal/usecase/tickets/sla/mock/mock_ticket_sla_janitor_repo.go -package=sla_mock
+//
+
+// Package sla_mock is a generated GoMock package.
+package sla_mock
+
+import (
+	reflect "reflect"
+
+	acl "github.com/jekiapp/topic-master/internal/model/acl"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockiTicketSLAJanitorRepo is a mock of iTicketSLAJanitorRepo interface.
+type MockiTicketSLAJanitorRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockiTicketSLAJanitorRepoMockRecorder
+}
+
+// MockiTicketSLAJanitorRepoMockRecorder is the mock recorder for MockiTicketSLAJanitorRepo.
+type MockiTicketSLAJanitorRepoMockRecorder struct {
+	mock *MockiTicketSLAJanitorRepo
+}
+
+// NewMockiTicketSLAJanitorRepo creates a new mock instance.
+func NewMockiTicketSLAJanitorRepo(ctrl *gomock.Controller) *MockiTicketSLAJanitorRepo {
+	mock := &MockiTicketSLAJanitorRepo{ctrl: ctrl}
+	mock.recorder = &MockiTicketSLAJanitorRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockiTicketSLAJanitorRepo) EXPECT() *MockiTicketSLAJanitorRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateApplicationAssignment mocks base method.
+func (m *MockiTicketSLAJanitorRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApplicationAssignment", assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateApplicationAssignment indicates an expected call of CreateApplicationAssignment.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) CreateApplicationAssignment(assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplicationAssignment", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).CreateApplicationAssignment), assignment)
+}
+
+// CreateApplicationHistory mocks base method.
+func (m *MockiTicketSLAJanitorRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApplicationHistory", history)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateApplicationHistory indicates an expected call of CreateApplicationHistory.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) CreateApplicationHistory(history any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApplicationHistory", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).CreateApplicationHistory), history)
+}
+
+// GetRootMemberIDs mocks base method.
+func (m *MockiTicketSLAJanitorRepo) GetRootMemberIDs() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootMemberIDs")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRootMemberIDs indicates an expected call of GetRootMemberIDs.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) GetRootMemberIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootMemberIDs", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).GetRootMemberIDs))
+}
+
+// GetUserPendingByID mocks base method.
+func (m *MockiTicketSLAJanitorRepo) GetUserPendingByID(id string) (acl.UserPending, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPendingByID", id)
+	ret0, _ := ret[0].(acl.UserPending)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPendingByID indicates an expected call of GetUserPendingByID.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) GetUserPendingByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPendingByID", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).GetUserPendingByID), id)
+}
+
+// ListApplicationsByStatus mocks base method.
+func (m *MockiTicketSLAJanitorRepo) ListApplicationsByStatus(status string) ([]acl.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListApplicationsByStatus", status)
+	ret0, _ := ret[0].([]acl.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListApplicationsByStatus indicates an expected call of ListApplicationsByStatus.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) ListApplicationsByStatus(status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApplicationsByStatus", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).ListApplicationsByStatus), status)
+}
+
+// ListAssignmentsByApplicationID mocks base method.
+func (m *MockiTicketSLAJanitorRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAssignmentsByApplicationID", appID)
+	ret0, _ := ret[0].([]acl.ApplicationAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAssignmentsByApplicationID indicates an expected call of ListAssignmentsByApplicationID.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) ListAssignmentsByApplicationID(appID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAssignmentsByApplicationID", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).ListAssignmentsByApplicationID), appID)
+}
+
+// ListTicketSLAs mocks base method.
+func (m *MockiTicketSLAJanitorRepo) ListTicketSLAs() ([]acl.TicketSLA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTicketSLAs")
+	ret0, _ := ret[0].([]acl.TicketSLA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTicketSLAs indicates an expected call of ListTicketSLAs.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) ListTicketSLAs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTicketSLAs", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).ListTicketSLAs))
+}
+
+// UpdateApplication mocks base method.
+func (m *MockiTicketSLAJanitorRepo) UpdateApplication(app acl.Application) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApplication", app)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApplication indicates an expected call of UpdateApplication.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) UpdateApplication(app any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplication", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).UpdateApplication), app)
+}
+
+// UpdateApplicationAssignment mocks base method.
+func (m *MockiTicketSLAJanitorRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateApplicationAssignment", assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateApplicationAssignment indicates an expected call of UpdateApplicationAssignment.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) UpdateApplicationAssignment(assignment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateApplicationAssignment", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).UpdateApplicationAssignment), assignment)
+}
+
+// UpdateUserPending mocks base method.
+func (m *MockiTicketSLAJanitorRepo) UpdateUserPending(user acl.UserPending) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPending", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPending indicates an expected call of UpdateUserPending.
+func (mr *MockiTicketSLAJanitorRepoMockRecorder) UpdateUserPending(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPending", reflect.TypeOf((*MockiTicketSLAJanitorRepo)(nil).UpdateUserPending), user)
+}
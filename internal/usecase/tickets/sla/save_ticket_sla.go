@@ -0,0 +1,111 @@
+//go:generate mockgen -source=save_ticket_sla.go -destination=mock/mock_save_ticket_sla_repo.go -package=sla_mock
+
+package sla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+// maxSLAHours bounds every deadline to a year.
+const maxSLAHours = 24 * 365
+
+// SaveTicketSLARequest creates or replaces the SLA of an application type.
+type SaveTicketSLARequest struct {
+	ApplicationType      string `json:"application_type"`
+	RemindAfterHours     int    `json:"remind_after_hours"`
+	EscalateAfterHours   int    `json:"escalate_after_hours"`
+	AutoRejectAfterHours int    `json:"auto_reject_after_hours"`
+}
+
+type SaveTicketSLAResponse struct {
+	ApplicationType string `json:"application_type"`
+}
+
+type iSaveTicketSLARepo interface {
+	UpsertTicketSLA(sla acl.TicketSLA) error
+}
+
+type saveTicketSLARepo struct {
+	db *buntdb.DB
+}
+
+func (r *saveTicketSLARepo) UpsertTicketSLA(sla acl.TicketSLA) error {
+	return apprepo.UpsertTicketSLA(r.db, sla)
+}
+
+type SaveTicketSLAUsecase struct {
+	repo iSaveTicketSLARepo
+}
+
+func NewSaveTicketSLAUsecase(db *buntdb.DB) SaveTicketSLAUsecase {
+	return SaveTicketSLAUsecase{
+		repo: &saveTicketSLARepo{db: db},
+	}
+}
+
+func (uc SaveTicketSLAUsecase) Handle(ctx context.Context, req SaveTicketSLARequest) (SaveTicketSLAResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return SaveTicketSLAResponse{}, errors.New("unauthorized: user info not found")
+	}
+	switch req.ApplicationType {
+	case acl.ApplicationType_Signup, acl.ApplicationType_Claim, acl.ApplicationType_TopicForm, acl.ApplicationType_ChannelForm,
+		acl.ApplicationType_Membership, acl.ApplicationType_Transfer:
+	default:
+		return SaveTicketSLAResponse{}, fmt.Errorf("unknown application type %q", req.ApplicationType)
+	}
+	if err := validateSLAHours(req); err != nil {
+		return SaveTicketSLAResponse{}, err
+	}
+
+	sla := acl.TicketSLA{
+		ApplicationType:      req.ApplicationType,
+		RemindAfterHours:     req.RemindAfterHours,
+		EscalateAfterHours:   req.EscalateAfterHours,
+		AutoRejectAfterHours: req.AutoRejectAfterHours,
+		UpdatedBy:            user.Username,
+		UpdatedAt:            time.Now(),
+	}
+	if err := uc.repo.UpsertTicketSLA(sla); err != nil {
+		return SaveTicketSLAResponse{}, err
+	}
+	return SaveTicketSLAResponse{ApplicationType: sla.ApplicationType}, nil
+}
+
+// validateSLAHours checks that the enabled steps come in order: reminder, escalation, auto reject.
+func validateSLAHours(req SaveTicketSLARequest) error {
+	steps := []struct {
+		name  string
+		hours int
+	}{
+		{"remind_after_hours", req.RemindAfterHours},
+		{"escalate_after_hours", req.EscalateAfterHours},
+		{"auto_reject_after_hours", req.AutoRejectAfterHours},
+	}
+	previous := ""
+	previousHours := 0
+	for _, step := range steps {
+		if step.hours < 0 || step.hours > maxSLAHours {
+			return fmt.Errorf("%s must be between 0 and %d", step.name, maxSLAHours)
+		}
+		if step.hours == 0 {
+			continue
+		}
+		if previous != "" && step.hours <= previousHours {
+			return fmt.Errorf("%s must be later than %s", step.name, previous)
+		}
+		previous, previousHours = step.name, step.hours
+	}
+	if previous == "" {
+		return errors.New("at least one deadline is required")
+	}
+	return nil
+}
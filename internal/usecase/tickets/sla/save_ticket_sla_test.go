@@ -0,0 +1,93 @@
+package sla
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	sla_mock "github.com/jekiapp/topic-master/internal/usecase/tickets/sla/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSaveTicketSLAUsecase_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		loggedIn  bool
+		req       SaveTicketSLARequest
+		setupMock func(m *sla_mock.MockiSaveTicketSLARepo)
+		wantErr   bool
+	}{
+		{
+			name:      "unauthorized user cannot save",
+			req:       SaveTicketSLARequest{ApplicationType: acl.ApplicationType_TopicForm, RemindAfterHours: 24},
+			setupMock: func(m *sla_mock.MockiSaveTicketSLARepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown application type",
+			loggedIn:  true,
+			req:       SaveTicketSLARequest{ApplicationType: "unknown", RemindAfterHours: 24},
+			setupMock: func(m *sla_mock.MockiSaveTicketSLARepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "no deadline",
+			loggedIn:  true,
+			req:       SaveTicketSLARequest{ApplicationType: acl.ApplicationType_TopicForm},
+			setupMock: func(m *sla_mock.MockiSaveTicketSLARepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "escalation before the reminder",
+			loggedIn:  true,
+			req:       SaveTicketSLARequest{ApplicationType: acl.ApplicationType_TopicForm, RemindAfterHours: 48, EscalateAfterHours: 24},
+			setupMock: func(m *sla_mock.MockiSaveTicketSLARepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "negative hours",
+			loggedIn:  true,
+			req:       SaveTicketSLARequest{ApplicationType: acl.ApplicationType_TopicForm, AutoRejectAfterHours: -1},
+			setupMock: func(m *sla_mock.MockiSaveTicketSLARepo) {},
+			wantErr:   true,
+		},
+		{
+			name:     "escalation and auto reject without reminder",
+			loggedIn: true,
+			req:      SaveTicketSLARequest{ApplicationType: acl.ApplicationType_Claim, EscalateAfterHours: 24, AutoRejectAfterHours: 72},
+			setupMock: func(m *sla_mock.MockiSaveTicketSLARepo) {
+				m.EXPECT().UpsertTicketSLA(gomock.Any()).DoAndReturn(func(sla acl.TicketSLA) error {
+					assert.Equal(t, acl.ApplicationType_Claim, sla.ApplicationType)
+					assert.Equal(t, 0, sla.RemindAfterHours)
+					assert.Equal(t, 24, sla.EscalateAfterHours)
+					assert.Equal(t, 72, sla.AutoRejectAfterHours)
+					assert.Equal(t, "root", sla.UpdatedBy)
+					return nil
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := sla_mock.NewMockiSaveTicketSLARepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := SaveTicketSLAUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.loggedIn {
+				ctx = util.MockContextWithUser(ctx, &acl.User{ID: "root-id", Username: "root"})
+			}
+			resp, err := uc.Handle(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.req.ApplicationType, resp.ApplicationType)
+		})
+	}
+}
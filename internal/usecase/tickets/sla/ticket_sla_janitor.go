@@ -0,0 +1,290 @@
+//go:generate mockgen -source=ticket_sla_janitor.go -destination=mock/mock_ticket_sla_janitor_repo.go -package=sla_mock
+
+package sla
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiapp/topic-master/internal/logic/notification"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	notifmodel "github.com/jekiapp/topic-master/internal/model/notification"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/tidwall/buntdb"
+)
+
+type iTicketSLAJanitorRepo interface {
+	ListTicketSLAs() ([]acl.TicketSLA, error)
+	ListApplicationsByStatus(status string) ([]acl.Application, error)
+	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
+	GetRootMemberIDs() ([]string, error)
+	UpdateApplication(app acl.Application) error
+	CreateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	CreateApplicationHistory(history acl.ApplicationHistory) error
+	GetUserPendingByID(id string) (acl.UserPending, error)
+	UpdateUserPending(user acl.UserPending) error
+}
+
+type ticketSLAJanitorRepo struct {
+	db *buntdb.DB
+}
+
+func (r *ticketSLAJanitorRepo) ListTicketSLAs() ([]acl.TicketSLA, error) {
+	return apprepo.ListTicketSLAs(r.db)
+}
+
+func (r *ticketSLAJanitorRepo) ListApplicationsByStatus(status string) ([]acl.Application, error) {
+	return apprepo.ListApplicationsByStatus(r.db, status)
+}
+
+func (r *ticketSLAJanitorRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	return dbpkg.SelectAll[acl.ApplicationAssignment](r.db, "="+appID, acl.IdxAppAssign_ApplicationID)
+}
+
+func (r *ticketSLAJanitorRepo) GetRootMemberIDs() ([]string, error) {
+	rootGroup, err := userrepo.GetGroupByName(r.db, acl.GroupRoot)
+	if err != nil {
+		return nil, fmt.Errorf("root group not found: %v", err)
+	}
+	members, err := userrepo.ListUserGroupsByGroupID(r.db, rootGroup.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, member := range members {
+		ids = append(ids, member.UserID)
+	}
+	return ids, nil
+}
+
+func (r *ticketSLAJanitorRepo) UpdateApplication(app acl.Application) error {
+	return dbpkg.Update(r.db, &app)
+}
+
+func (r *ticketSLAJanitorRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return apprepo.CreateApplicationAssignment(r.db, assignment)
+}
+
+func (r *ticketSLAJanitorRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return dbpkg.Update(r.db, &assignment)
+}
+
+func (r *ticketSLAJanitorRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return apprepo.CreateApplicationHistory(r.db, history)
+}
+
+func (r *ticketSLAJanitorRepo) GetUserPendingByID(id string) (acl.UserPending, error) {
+	return dbpkg.GetByID[acl.UserPending](r.db, id)
+}
+
+func (r *ticketSLAJanitorRepo) UpdateUserPending(user acl.UserPending) error {
+	return dbpkg.Update(r.db, &user)
+}
+
+// TicketSLAJanitor enforces the SLA of tickets waiting for approval.
+// Tickets waiting for the applicant (needs info) are not counted against their reviewers.
+type TicketSLAJanitor struct {
+	repo iTicketSLAJanitorRepo
+}
+
+func NewTicketSLAJanitor(db *buntdb.DB) TicketSLAJanitor {
+	return TicketSLAJanitor{
+		repo: &ticketSLAJanitorRepo{db: db},
+	}
+}
+
+// Run checks the pending tickets every interval until ctx is done.
+func (j TicketSLAJanitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			handled, err := j.Check(now)
+			if err != nil {
+				log.Printf("[TICKET SLA] error checking tickets: %s", err)
+			}
+			if handled > 0 {
+				log.Printf("[TICKET SLA] %d tickets reminded, escalated or rejected", handled)
+			}
+		}
+	}
+}
+
+// Check applies the deadlines due at now and returns how many tickets were acted on.
+// Each step is taken once per idle period: the period restarts whenever the ticket progresses.
+func (j TicketSLAJanitor) Check(now time.Time) (int, error) {
+	slas, err := j.repo.ListTicketSLAs()
+	if err != nil {
+		return 0, err
+	}
+	if len(slas) == 0 {
+		return 0, nil
+	}
+	slaByType := map[string]acl.TicketSLA{}
+	for _, sla := range slas {
+		slaByType[sla.ApplicationType] = sla
+	}
+
+	apps, err := j.repo.ListApplicationsByStatus(acl.StatusWaitingForApproval)
+	if err != nil {
+		return 0, err
+	}
+	handled := 0
+	for _, app := range apps {
+		sla, ok := slaByType[app.Type]
+		if !ok {
+			continue
+		}
+		idleSince := app.UpdatedAt
+		if idleSince.IsZero() {
+			idleSince = app.CreatedAt
+		}
+
+		if deadline, ok := sla.Deadline(idleSince, sla.AutoRejectAfterHours); ok && !now.Before(deadline) {
+			err = j.autoReject(app, now)
+		} else if deadline, ok := sla.Deadline(idleSince, sla.EscalateAfterHours); ok && !now.Before(deadline) && app.EscalatedAt.Before(idleSince) {
+			err = j.escalate(app, now)
+		} else if deadline, ok := sla.Deadline(idleSince, sla.RemindAfterHours); ok && !now.Before(deadline) && app.RemindedAt.Before(idleSince) {
+			err = j.remind(app, now)
+		} else {
+			continue
+		}
+		if err != nil {
+			log.Printf("[TICKET SLA] error on ticket %s: %s", app.ID, err)
+			continue
+		}
+		handled++
+	}
+	return handled, nil
+}
+
+// remind records a reminder for the reviewers still waiting in the current stage
+func (j TicketSLAJanitor) remind(app acl.Application, now time.Time) error {
+	assignments, err := j.repo.ListAssignmentsByApplicationID(app.ID)
+	if err != nil {
+		return err
+	}
+	var waiting []string
+	for _, assignment := range assignments {
+		if assignment.Stage == app.CurrentStage && assignment.ReviewStatus == acl.ReviewStatusWaiting {
+			waiting = append(waiting, assignment.ReviewerID)
+		}
+	}
+
+	app.RemindedAt = now
+	if err := j.repo.UpdateApplication(app); err != nil {
+		return err
+	}
+	notification.Publish(notification.TicketEvent(notifmodel.EventTicketReminder, app, waiting...))
+	j.addHistory(app.ID, acl.ActionRemind, fmt.Sprintf("SLA reminder sent to %d reviewers", len(waiting)), now)
+	return nil
+}
+
+// escalate adds the root group members as reviewers of the current stage
+func (j TicketSLAJanitor) escalate(app acl.Application, now time.Time) error {
+	assignments, err := j.repo.ListAssignmentsByApplicationID(app.ID)
+	if err != nil {
+		return err
+	}
+	rootIDs, err := j.repo.GetRootMemberIDs()
+	if err != nil {
+		return err
+	}
+	assigned := map[string]bool{app.UserID: true}
+	for _, assignment := range assignments {
+		if assignment.Stage == app.CurrentStage {
+			assigned[assignment.ReviewerID] = true
+		}
+	}
+
+	var added []string
+	for _, rootID := range rootIDs {
+		if assigned[rootID] {
+			continue
+		}
+		assignment := acl.ApplicationAssignment{
+			ID:            uuid.NewString(),
+			ApplicationID: app.ID,
+			ReviewerID:    rootID,
+			ReviewStatus:  acl.ReviewStatusWaiting,
+			Stage:         app.CurrentStage,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := j.repo.CreateApplicationAssignment(assignment); err != nil {
+			return err
+		}
+		assigned[rootID] = true
+		added = append(added, rootID)
+	}
+
+	app.EscalatedAt = now
+	if err := j.repo.UpdateApplication(app); err != nil {
+		return err
+	}
+	notification.Publish(notification.TicketEvent(notifmodel.EventTicketAssigned, app, added...))
+	j.addHistory(app.ID, acl.ActionEscalate, fmt.Sprintf("SLA exceeded, escalated to %s: %d reviewers added", acl.GroupRoot, len(added)), now)
+	return nil
+}
+
+// autoReject closes a ticket nobody acted on before the final deadline
+func (j TicketSLAJanitor) autoReject(app acl.Application, now time.Time) error {
+	assignments, err := j.repo.ListAssignmentsByApplicationID(app.ID)
+	if err != nil {
+		return err
+	}
+
+	app.Status = acl.StatusAutoRejected
+	app.UpdatedAt = now
+	if err := j.repo.UpdateApplication(app); err != nil {
+		return err
+	}
+	for _, assignment := range assignments {
+		if assignment.ReviewStatus != acl.ReviewStatusWaiting {
+			continue
+		}
+		assignment.ReviewStatus = acl.ReviewStatusPassed
+		assignment.UpdatedAt = now
+		if err := j.repo.UpdateApplicationAssignment(assignment); err != nil {
+			log.Println("[TICKET SLA] error updating assignment", assignment.ID, err)
+		}
+	}
+	// same as a rejected signup, the pending user can no longer log in
+	if app.Type == acl.ApplicationType_Signup {
+		if applicant, err := j.repo.GetUserPendingByID(app.UserID); err == nil {
+			applicant.Status = acl.UserStatusInactive
+			applicant.UpdatedAt = now
+			if err := j.repo.UpdateUserPending(applicant); err != nil {
+				log.Println("[TICKET SLA] error deactivating pending user", applicant.ID, err)
+			}
+		}
+	}
+	notification.Publish(notification.TicketEvent(notifmodel.EventTicketRejected, app, app.UserID))
+	j.addHistory(app.ID, acl.ActionAutoReject, "SLA final deadline passed without a decision", now)
+	return nil
+}
+
+func (j TicketSLAJanitor) addHistory(appID, action, comment string, now time.Time) {
+	history := acl.ApplicationHistory{
+		ID:            uuid.NewString(),
+		ApplicationID: appID,
+		Action:        action,
+		ActorID:       acl.ActorSystem,
+		Comment:       comment,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := j.repo.CreateApplicationHistory(history); err != nil {
+		// log but do not fail
+		log.Println("failed to create application history", err)
+	}
+}
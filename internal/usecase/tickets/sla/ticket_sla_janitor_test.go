@@ -0,0 +1,148 @@
+package sla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	sla_mock "github.com/jekiapp/topic-master/internal/usecase/tickets/sla/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTicketSLAJanitor_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	sla := acl.TicketSLA{ApplicationType: acl.ApplicationType_TopicForm, RemindAfterHours: 24, EscalateAfterHours: 48, AutoRejectAfterHours: 96}
+	idle := func(hours int) acl.Application {
+		return acl.Application{
+			ID:        "app-1",
+			UserID:    "alice-id",
+			Type:      acl.ApplicationType_TopicForm,
+			Status:    acl.StatusWaitingForApproval,
+			CreatedAt: now.Add(-time.Duration(hours) * time.Hour),
+			UpdatedAt: now.Add(-time.Duration(hours) * time.Hour),
+		}
+	}
+	waiting := acl.ApplicationAssignment{ID: "as-1", ApplicationID: "app-1", ReviewerID: "carol-id", ReviewStatus: acl.ReviewStatusWaiting}
+
+	tests := []struct {
+		name        string
+		setupMock   func(m *sla_mock.MockiTicketSLAJanitorRepo)
+		wantErr     bool
+		wantHandled int
+	}{
+		{
+			name: "no sla configured",
+			setupMock: func(m *sla_mock.MockiTicketSLAJanitorRepo) {
+				m.EXPECT().ListTicketSLAs().Return([]acl.TicketSLA{}, nil)
+			},
+		},
+		{
+			name: "list error",
+			setupMock: func(m *sla_mock.MockiTicketSLAJanitorRepo) {
+				m.EXPECT().ListTicketSLAs().Return(nil, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+		{
+			name: "ticket within its sla and ticket of another type are left alone",
+			setupMock: func(m *sla_mock.MockiTicketSLAJanitorRepo) {
+				m.EXPECT().ListTicketSLAs().Return([]acl.TicketSLA{sla}, nil)
+				signup := idle(200)
+				signup.Type = acl.ApplicationType_Signup
+				m.EXPECT().ListApplicationsByStatus(acl.StatusWaitingForApproval).Return([]acl.Application{idle(2), signup}, nil)
+			},
+		},
+		{
+			name: "reminder",
+			setupMock: func(m *sla_mock.MockiTicketSLAJanitorRepo) {
+				m.EXPECT().ListTicketSLAs().Return([]acl.TicketSLA{sla}, nil)
+				m.EXPECT().ListApplicationsByStatus(acl.StatusWaitingForApproval).Return([]acl.Application{idle(25)}, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{waiting}, nil)
+				m.EXPECT().UpdateApplication(gomock.Any()).DoAndReturn(func(app acl.Application) error {
+					assert.Equal(t, now, app.RemindedAt)
+					return nil
+				})
+				m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+					assert.Equal(t, acl.ActionRemind, h.Action)
+					assert.Equal(t, acl.ActorSystem, h.ActorID)
+					return nil
+				})
+			},
+			wantHandled: 1,
+		},
+		{
+			name: "reminder is sent once per idle period",
+			setupMock: func(m *sla_mock.MockiTicketSLAJanitorRepo) {
+				m.EXPECT().ListTicketSLAs().Return([]acl.TicketSLA{sla}, nil)
+				app := idle(30)
+				app.RemindedAt = now.Add(-time.Hour)
+				m.EXPECT().ListApplicationsByStatus(acl.StatusWaitingForApproval).Return([]acl.Application{app}, nil)
+			},
+		},
+		{
+			name: "escalation adds the root members not assigned yet",
+			setupMock: func(m *sla_mock.MockiTicketSLAJanitorRepo) {
+				m.EXPECT().ListTicketSLAs().Return([]acl.TicketSLA{sla}, nil)
+				m.EXPECT().ListApplicationsByStatus(acl.StatusWaitingForApproval).Return([]acl.Application{idle(50)}, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{waiting}, nil)
+				m.EXPECT().GetRootMemberIDs().Return([]string{"carol-id", "root-id", "alice-id"}, nil)
+				m.EXPECT().CreateApplicationAssignment(gomock.Any()).DoAndReturn(func(a acl.ApplicationAssignment) error {
+					assert.Equal(t, "root-id", a.ReviewerID)
+					assert.Equal(t, acl.ReviewStatusWaiting, a.ReviewStatus)
+					return nil
+				})
+				m.EXPECT().UpdateApplication(gomock.Any()).DoAndReturn(func(app acl.Application) error {
+					assert.Equal(t, now, app.EscalatedAt)
+					return nil
+				})
+				m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+					assert.Equal(t, acl.ActionEscalate, h.Action)
+					return nil
+				})
+			},
+			wantHandled: 1,
+		},
+		{
+			name: "auto reject after the final deadline",
+			setupMock: func(m *sla_mock.MockiTicketSLAJanitorRepo) {
+				m.EXPECT().ListTicketSLAs().Return([]acl.TicketSLA{sla}, nil)
+				app := idle(100)
+				app.EscalatedAt = now.Add(-50 * time.Hour)
+				m.EXPECT().ListApplicationsByStatus(acl.StatusWaitingForApproval).Return([]acl.Application{app}, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{waiting}, nil)
+				m.EXPECT().UpdateApplication(gomock.Any()).DoAndReturn(func(app acl.Application) error {
+					assert.Equal(t, acl.StatusAutoRejected, app.Status)
+					return nil
+				})
+				m.EXPECT().UpdateApplicationAssignment(gomock.Any()).DoAndReturn(func(a acl.ApplicationAssignment) error {
+					assert.Equal(t, acl.ReviewStatusPassed, a.ReviewStatus)
+					return nil
+				})
+				m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+					assert.Equal(t, acl.ActionAutoReject, h.Action)
+					return nil
+				})
+			},
+			wantHandled: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := sla_mock.NewMockiTicketSLAJanitorRepo(ctrl)
+			tt.setupMock(mockRepo)
+			j := TicketSLAJanitor{repo: mockRepo}
+			handled, err := j.Check(now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantHandled, handled)
+		})
+	}
+}
@@ -14,6 +14,7 @@ type SubmitApplicationRequest struct {
 	ApplicationType string   `json:"application_type"`
 	Reason          string   `json:"reason"`
 	Permission      []string `json:"permission"`
+	Duration        string   `json:"duration"` // e.g. "2h" or "7d", empty for a permanent grant
 }
 
 // Response struct for submitting an application
@@ -61,20 +61,37 @@ func (uc TopicActionSubmitUsecase) Handle(ctx context.Context, req SubmitApplica
 		return SubmitApplicationResponse{}, err
 	}
 
-	// get group by name
-	group, err := uc.repo.GetGroupByName(entity.GroupOwner)
+	grantDuration, err := auth.ParseGrantDuration(req.Duration)
+	if err != nil {
+		return SubmitApplicationResponse{}, err
+	}
+	metaData := map[string]string{acl.AppMetaData_EntityID: req.EntityID}
+	if grantDuration > 0 {
+		metaData[acl.AppMetaData_GrantDuration] = grantDuration.String()
+	}
+
+	// the owner group reviews the application
+	group, err := uc.repo.GetGroupByID(entity.GroupOwnerID)
 	if err != nil {
 		return SubmitApplicationResponse{}, errors.New("reviewer group not found: " + entity.GroupOwner)
 	}
 	// Use group ID as reviewer group
 	reviewerGroupID := group.ID
+	stages, err := auth.ResolveApprovalStages(uc.repo, req.ApplicationType, req.Permission, auth.ReviewerGroups{
+		DefaultGroupID: reviewerGroupID,
+		OwnerGroupID:   reviewerGroupID,
+	})
+	if err != nil {
+		return SubmitApplicationResponse{}, err
+	}
 	input := auth.CreateApplicationInput{
 		Title:              fmt.Sprintf("Application to action for topic %s", entity.Name),
 		ApplicationType:    req.ApplicationType,
 		PermissionIDs:      req.Permission,
 		Reason:             req.Reason,
 		ReviewerGroupID:    reviewerGroupID,
-		MetaData:           map[string]string{"entity_id": req.EntityID},
+		MetaData:           metaData,
+		Stages:             stages,
 		HistoryInitAction:  "Apply topic action permission",
 		HistoryInitComment: req.Reason,
 	}
@@ -93,22 +110,22 @@ type topicActionRepo struct {
 	db *buntdb.DB
 }
 
-func (r *topicActionRepo) CreateApplication(app acl.Application) error {
-	return apprepo.CreateApplication(r.db, app)
-}
-
 func (r *topicActionRepo) GetReviewerIDsByGroupID(groupID string) ([]string, error) {
 	return usergrouplogic.GetReviewerIDsByGroupID(r.db, groupID)
 }
 
-func (r *topicActionRepo) CreateApplicationAssignment(assignment acl.ApplicationAssignment) error {
-	return apprepo.CreateApplicationAssignment(r.db, assignment)
-}
-
-func (r *topicActionRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
-	return apprepo.CreateApplicationHistory(r.db, history)
+func (r *topicActionRepo) WithApplicationTx(fn func(w auth.IApplicationWriter) error) error {
+	return auth.WithApplicationTx(r.db, fn)
 }
 
 func (r *topicActionRepo) GetGroupByName(name string) (acl.Group, error) {
 	return userrepo.GetGroupByName(r.db, name)
 }
+
+func (r *topicActionRepo) GetGroupByID(id string) (acl.Group, error) {
+	return userrepo.GetGroupByID(r.db, id)
+}
+
+func (r *topicActionRepo) ListApprovalPoliciesByType(applicationType string) ([]acl.ApprovalPolicy, error) {
+	return apprepo.ListApprovalPoliciesByType(r.db, applicationType)
+}
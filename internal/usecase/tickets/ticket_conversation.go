@@ -0,0 +1,317 @@
+//go:generate mockgen -source=ticket_conversation.go -destination=mock/mock_ticket_conversation_repo.go -package=mock
+
+// comments, requests for more information and withdrawals on a ticket
+// every action is also recorded in the application history
+
+package tickets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbpkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+const maxCommentLength = 4000
+
+type TicketCommentRequest struct {
+	ApplicationID string `json:"application_id"`
+	ParentID      string `json:"parent_id"` // empty for a top level comment
+	Body          string `json:"body"`
+}
+
+// TicketStateRequest is used for request-info, provide-info and withdraw
+type TicketStateRequest struct {
+	ApplicationID string `json:"application_id"`
+	Comment       string `json:"comment"`
+}
+
+type TicketConversationResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type TicketConversationUsecase struct {
+	repo iTicketConversationRepo
+}
+
+func NewTicketConversationUsecase(db *buntdb.DB) TicketConversationUsecase {
+	return TicketConversationUsecase{
+		repo: &ticketConversationRepo{db: db},
+	}
+}
+
+// HandleComment posts a comment, the applicant, the reviewers and root can take part in the conversation
+func (uc TicketConversationUsecase) HandleComment(ctx context.Context, req TicketCommentRequest) (TicketConversationResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TicketConversationResponse{}, errors.New("unauthorized")
+	}
+	body, err := validateCommentBody(req.Body)
+	if err != nil {
+		return TicketConversationResponse{}, err
+	}
+	app, err := uc.repo.GetApplicationByID(req.ApplicationID)
+	if err != nil {
+		return TicketConversationResponse{}, fmt.Errorf("ticket %s not found", req.ApplicationID)
+	}
+	assignments, err := uc.repo.ListAssignmentsByApplicationID(app.ID)
+	if err != nil && !errors.Is(err, dbpkg.ErrNotFound) {
+		return TicketConversationResponse{}, err
+	}
+	if !isTicketParticipant(uc.repo, user, app, assignments) {
+		return TicketConversationResponse{}, errors.New("you are not a participant of this ticket")
+	}
+	if req.ParentID != "" {
+		parent, err := uc.repo.GetApplicationCommentByID(req.ParentID)
+		if err != nil || parent.ApplicationID != app.ID {
+			return TicketConversationResponse{}, errors.New("the comment replied to does not belong to this ticket")
+		}
+	}
+
+	now := time.Now()
+	comment := acl.ApplicationComment{
+		ID:            uuid.NewString(),
+		ApplicationID: app.ID,
+		ParentID:      req.ParentID,
+		AuthorID:      user.ID,
+		Body:          body,
+		CreatedAt:     now,
+	}
+	if err := uc.repo.CreateApplicationComment(comment); err != nil {
+		return TicketConversationResponse{}, fmt.Errorf("error saving comment: %v", err)
+	}
+	uc.addHistory(app.ID, acl.ActionComment, user.ID, body, now)
+	return TicketConversationResponse{Status: app.Status, Message: "Comment posted"}, nil
+}
+
+// HandleRequestInfo lets a reviewer of the current stage put the ticket on hold until the applicant answers
+func (uc TicketConversationUsecase) HandleRequestInfo(ctx context.Context, req TicketStateRequest) (TicketConversationResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TicketConversationResponse{}, errors.New("unauthorized")
+	}
+	question, err := validateCommentBody(req.Comment)
+	if err != nil {
+		return TicketConversationResponse{}, errors.New("a question for the applicant is required")
+	}
+	app, err := uc.repo.GetApplicationByID(req.ApplicationID)
+	if err != nil {
+		return TicketConversationResponse{}, fmt.Errorf("ticket %s not found", req.ApplicationID)
+	}
+	if app.IsClosed() {
+		return TicketConversationResponse{}, errors.New("ticket is already closed")
+	}
+	if app.Status == acl.StatusNeedsInfo {
+		return TicketConversationResponse{}, errors.New("the applicant has not answered the previous request yet")
+	}
+	assignments, err := uc.repo.ListAssignmentsByApplicationID(app.ID)
+	if err != nil {
+		return TicketConversationResponse{}, err
+	}
+	if _, ok := authlogic.ActiveAssignment(app, assignments, user.ID); !ok {
+		return TicketConversationResponse{}, errors.New("you are not eligible to perform this action")
+	}
+
+	now := time.Now()
+	app.Status = acl.StatusNeedsInfo
+	app.UpdatedAt = now
+	if err := uc.repo.UpdateApplication(app); err != nil {
+		return TicketConversationResponse{}, err
+	}
+	uc.addHistory(app.ID, acl.ActionRequestInfo, user.ID, question, now)
+	return TicketConversationResponse{Status: app.Status, Message: "Information requested from the applicant"}, nil
+}
+
+// HandleProvideInfo answers a request for information and sends the ticket back to the reviewers
+func (uc TicketConversationUsecase) HandleProvideInfo(ctx context.Context, req TicketStateRequest) (TicketConversationResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TicketConversationResponse{}, errors.New("unauthorized")
+	}
+	answer, err := validateCommentBody(req.Comment)
+	if err != nil {
+		return TicketConversationResponse{}, errors.New("an answer is required")
+	}
+	app, err := uc.repo.GetApplicationByID(req.ApplicationID)
+	if err != nil {
+		return TicketConversationResponse{}, fmt.Errorf("ticket %s not found", req.ApplicationID)
+	}
+	if app.UserID != user.ID {
+		return TicketConversationResponse{}, errors.New("only the applicant can answer a request for information")
+	}
+	if app.Status != acl.StatusNeedsInfo {
+		return TicketConversationResponse{}, errors.New("no information was requested on this ticket")
+	}
+
+	now := time.Now()
+	app.Status = acl.StatusWaitingForApproval
+	app.UpdatedAt = now
+	if err := uc.repo.UpdateApplication(app); err != nil {
+		return TicketConversationResponse{}, err
+	}
+	uc.addHistory(app.ID, acl.ActionProvideInfo, user.ID, answer, now)
+	return TicketConversationResponse{Status: app.Status, Message: "Answer sent to the reviewers"}, nil
+}
+
+// HandleWithdraw cancels a pending application on behalf of its applicant
+func (uc TicketConversationUsecase) HandleWithdraw(ctx context.Context, req TicketStateRequest) (TicketConversationResponse, error) {
+	user := util.GetUserInfo(ctx)
+	if user == nil {
+		return TicketConversationResponse{}, errors.New("unauthorized")
+	}
+	app, err := uc.repo.GetApplicationByID(req.ApplicationID)
+	if err != nil {
+		return TicketConversationResponse{}, fmt.Errorf("ticket %s not found", req.ApplicationID)
+	}
+	if app.UserID != user.ID {
+		return TicketConversationResponse{}, errors.New("only the applicant can withdraw the application")
+	}
+	if app.IsClosed() {
+		return TicketConversationResponse{}, errors.New("ticket is already closed")
+	}
+	assignments, err := uc.repo.ListAssignmentsByApplicationID(app.ID)
+	if err != nil && !errors.Is(err, dbpkg.ErrNotFound) {
+		return TicketConversationResponse{}, err
+	}
+
+	now := time.Now()
+	app.Status = acl.StatusWithdrawn
+	app.UpdatedAt = now
+	if err := uc.repo.UpdateApplication(app); err != nil {
+		return TicketConversationResponse{}, err
+	}
+	// nobody has to review a withdrawn application anymore
+	for _, assignment := range assignments {
+		if assignment.ReviewStatus != acl.ReviewStatusWaiting {
+			continue
+		}
+		assignment.ReviewStatus = acl.ReviewStatusPassed
+		assignment.UpdatedAt = now
+		if err := uc.repo.UpdateApplicationAssignment(assignment); err != nil {
+			log.Println("[TICKET WITHDRAW] error updating assignment", assignment.ID, err)
+		}
+	}
+	uc.addHistory(app.ID, acl.ActionWithdraw, user.ID, strings.TrimSpace(req.Comment), now)
+	return TicketConversationResponse{Status: app.Status, Message: "Application withdrawn"}, nil
+}
+
+func (uc TicketConversationUsecase) addHistory(appID, action, actorID, comment string, now time.Time) {
+	history := acl.ApplicationHistory{
+		ID:            uuid.NewString(),
+		ApplicationID: appID,
+		Action:        action,
+		ActorID:       actorID,
+		Comment:       comment,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := uc.repo.CreateApplicationHistory(history); err != nil {
+		// log but do not fail
+		log.Println("failed to create application history", err)
+	}
+}
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("comment is empty")
+	}
+	if len(body) > maxCommentLength {
+		return "", fmt.Errorf("comment is longer than %d characters", maxCommentLength)
+	}
+	return body, nil
+}
+
+// isTicketParticipant is true for the applicant, any reviewer assigned to the ticket and root
+func isTicketParticipant(repo iUserGroupsRepo, user *acl.User, app acl.Application, assignments []acl.ApplicationAssignment) bool {
+	if app.UserID == user.ID {
+		return true
+	}
+	for _, assignment := range assignments {
+		if assignment.ReviewerID == user.ID {
+			return true
+		}
+	}
+	return isRoot(repo, user)
+}
+
+// iUserGroupsRepo loads the stored memberships of a user
+type iUserGroupsRepo interface {
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+}
+
+// isRoot checks the stored memberships, the groups in the token may be stale until it expires
+func isRoot(repo iUserGroupsRepo, user *acl.User) bool {
+	if user == nil {
+		return false
+	}
+	groups, err := repo.GetGroupsByUserID(user.ID)
+	if err != nil {
+		return false
+	}
+	for _, group := range groups {
+		if group.GroupName == acl.GroupRoot {
+			return true
+		}
+	}
+	return false
+}
+
+type iTicketConversationRepo interface {
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
+	GetApplicationByID(id string) (acl.Application, error)
+	UpdateApplication(app acl.Application) error
+	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
+	UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error
+	CreateApplicationHistory(history acl.ApplicationHistory) error
+	CreateApplicationComment(comment acl.ApplicationComment) error
+	GetApplicationCommentByID(id string) (acl.ApplicationComment, error)
+}
+
+type ticketConversationRepo struct {
+	db *buntdb.DB
+}
+
+func (r *ticketConversationRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *ticketConversationRepo) GetApplicationByID(id string) (acl.Application, error) {
+	return dbpkg.GetByID[acl.Application](r.db, id)
+}
+
+func (r *ticketConversationRepo) UpdateApplication(app acl.Application) error {
+	return dbpkg.Update(r.db, &app)
+}
+
+func (r *ticketConversationRepo) ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error) {
+	return dbpkg.SelectAll[acl.ApplicationAssignment](r.db, "="+appID, acl.IdxAppAssign_ApplicationID)
+}
+
+func (r *ticketConversationRepo) UpdateApplicationAssignment(assignment acl.ApplicationAssignment) error {
+	return dbpkg.Update(r.db, &assignment)
+}
+
+func (r *ticketConversationRepo) CreateApplicationHistory(history acl.ApplicationHistory) error {
+	return apprepo.CreateApplicationHistory(r.db, history)
+}
+
+func (r *ticketConversationRepo) CreateApplicationComment(comment acl.ApplicationComment) error {
+	return apprepo.CreateApplicationComment(r.db, comment)
+}
+
+func (r *ticketConversationRepo) GetApplicationCommentByID(id string) (acl.ApplicationComment, error) {
+	return apprepo.GetApplicationCommentByID(r.db, id)
+}
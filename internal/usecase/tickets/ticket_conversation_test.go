@@ -0,0 +1,344 @@
+package tickets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/usecase/tickets/mock"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTicketConversationUsecase_HandleComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alice := &acl.User{ID: "alice-id", Username: "alice"}
+	carol := &acl.User{ID: "carol-id", Username: "carol"}
+	mallory := &acl.User{ID: "mallory-id", Username: "mallory"}
+	app := acl.Application{ID: "app-1", UserID: "alice-id", Status: acl.StatusWaitingForApproval}
+	assignment := acl.ApplicationAssignment{ID: "as-1", ApplicationID: "app-1", ReviewerID: "carol-id", ReviewStatus: acl.ReviewStatusWaiting}
+
+	tests := []struct {
+		name      string
+		user      *acl.User
+		req       TicketCommentRequest
+		setupMock func(m *mock.MockiTicketConversationRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "unauthorized",
+			req:       TicketCommentRequest{ApplicationID: "app-1", Body: "hello"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name:      "empty body",
+			user:      alice,
+			req:       TicketCommentRequest{ApplicationID: "app-1", Body: "  "},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "not a participant",
+			user: mallory,
+			req:  TicketCommentRequest{ApplicationID: "app-1", Body: "hello"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{assignment}, nil)
+				m.EXPECT().GetGroupsByUserID("mallory-id").Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "removed from root but still root in the token",
+			user: &acl.User{ID: "mallory-id", Username: "mallory", Groups: []acl.GroupRole{{GroupName: acl.GroupRoot}}},
+			req:  TicketCommentRequest{ApplicationID: "app-1", Body: "hello"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{assignment}, nil)
+				m.EXPECT().GetGroupsByUserID("mallory-id").Return(nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reply to a comment of another ticket",
+			user: carol,
+			req:  TicketCommentRequest{ApplicationID: "app-1", ParentID: "c-2", Body: "hello"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{assignment}, nil)
+				m.EXPECT().GetApplicationCommentByID("c-2").Return(acl.ApplicationComment{ID: "c-2", ApplicationID: "app-2"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "reviewer replies",
+			user: carol,
+			req:  TicketCommentRequest{ApplicationID: "app-1", ParentID: "c-1", Body: "looks fine"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{assignment}, nil)
+				m.EXPECT().GetApplicationCommentByID("c-1").Return(acl.ApplicationComment{ID: "c-1", ApplicationID: "app-1"}, nil)
+				m.EXPECT().CreateApplicationComment(gomock.Any()).DoAndReturn(func(c acl.ApplicationComment) error {
+					assert.Equal(t, "c-1", c.ParentID)
+					assert.Equal(t, "carol-id", c.AuthorID)
+					assert.Equal(t, "looks fine", c.Body)
+					return nil
+				})
+				m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+					assert.Equal(t, acl.ActionComment, h.Action)
+					return nil
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock.NewMockiTicketConversationRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := TicketConversationUsecase{repo: mockRepo}
+			ctx := context.Background()
+			if tt.user != nil {
+				ctx = util.MockContextWithUser(ctx, tt.user)
+			}
+			_, err := uc.HandleComment(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTicketConversationUsecase_HandleRequestInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	carol := &acl.User{ID: "carol-id", Username: "carol"}
+	app := acl.Application{ID: "app-1", UserID: "alice-id", Status: acl.StatusWaitingForApproval}
+	assignment := acl.ApplicationAssignment{ID: "as-1", ApplicationID: "app-1", ReviewerID: "carol-id", ReviewStatus: acl.ReviewStatusWaiting}
+
+	tests := []struct {
+		name      string
+		req       TicketStateRequest
+		setupMock func(m *mock.MockiTicketConversationRepo)
+		wantErr   bool
+	}{
+		{
+			name:      "question is required",
+			req:       TicketStateRequest{ApplicationID: "app-1"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "already waiting for information",
+			req:  TicketStateRequest{ApplicationID: "app-1", Comment: "why?"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				needsInfo := app
+				needsInfo.Status = acl.StatusNeedsInfo
+				m.EXPECT().GetApplicationByID("app-1").Return(needsInfo, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "not a reviewer of the current stage",
+			req:  TicketStateRequest{ApplicationID: "app-1", Comment: "why?"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				passed := assignment
+				passed.ReviewStatus = acl.ReviewStatusPassed
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{passed}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			req:  TicketStateRequest{ApplicationID: "app-1", Comment: "why?"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{assignment}, nil)
+				m.EXPECT().UpdateApplication(gomock.Any()).DoAndReturn(func(a acl.Application) error {
+					assert.Equal(t, acl.StatusNeedsInfo, a.Status)
+					return nil
+				})
+				m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+					assert.Equal(t, acl.ActionRequestInfo, h.Action)
+					assert.Equal(t, "why?", h.Comment)
+					return nil
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock.NewMockiTicketConversationRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := TicketConversationUsecase{repo: mockRepo}
+			ctx := util.MockContextWithUser(context.Background(), carol)
+			_, err := uc.HandleRequestInfo(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTicketConversationUsecase_HandleProvideInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alice := &acl.User{ID: "alice-id", Username: "alice"}
+	carol := &acl.User{ID: "carol-id", Username: "carol"}
+	app := acl.Application{ID: "app-1", UserID: "alice-id", Status: acl.StatusNeedsInfo}
+
+	tests := []struct {
+		name      string
+		user      *acl.User
+		req       TicketStateRequest
+		setupMock func(m *mock.MockiTicketConversationRepo)
+		wantErr   bool
+	}{
+		{
+			name: "only the applicant can answer",
+			user: carol,
+			req:  TicketStateRequest{ApplicationID: "app-1", Comment: "because"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "nothing was requested",
+			user: alice,
+			req:  TicketStateRequest{ApplicationID: "app-1", Comment: "because"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				waiting := app
+				waiting.Status = acl.StatusWaitingForApproval
+				m.EXPECT().GetApplicationByID("app-1").Return(waiting, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			user: alice,
+			req:  TicketStateRequest{ApplicationID: "app-1", Comment: "because"},
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().UpdateApplication(gomock.Any()).DoAndReturn(func(a acl.Application) error {
+					assert.Equal(t, acl.StatusWaitingForApproval, a.Status)
+					return nil
+				})
+				m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+					assert.Equal(t, acl.ActionProvideInfo, h.Action)
+					return nil
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock.NewMockiTicketConversationRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := TicketConversationUsecase{repo: mockRepo}
+			ctx := util.MockContextWithUser(context.Background(), tt.user)
+			_, err := uc.HandleProvideInfo(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTicketConversationUsecase_HandleWithdraw(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	alice := &acl.User{ID: "alice-id", Username: "alice"}
+	carol := &acl.User{ID: "carol-id", Username: "carol"}
+	app := acl.Application{ID: "app-1", UserID: "alice-id", Status: acl.StatusWaitingForApproval}
+	waiting := acl.ApplicationAssignment{ID: "as-1", ApplicationID: "app-1", ReviewerID: "carol-id", ReviewStatus: acl.ReviewStatusWaiting}
+	approved := acl.ApplicationAssignment{ID: "as-2", ApplicationID: "app-1", ReviewerID: "dave-id", ReviewStatus: acl.ReviewStatusApproved}
+
+	tests := []struct {
+		name      string
+		user      *acl.User
+		setupMock func(m *mock.MockiTicketConversationRepo)
+		wantErr   bool
+	}{
+		{
+			name: "ticket not found",
+			user: alice,
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(acl.Application{}, errors.New("not found"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "only the applicant can withdraw",
+			user: carol,
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "already completed",
+			user: alice,
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				completed := app
+				completed.Status = acl.StatusCompleted
+				m.EXPECT().GetApplicationByID("app-1").Return(completed, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			user: alice,
+			setupMock: func(m *mock.MockiTicketConversationRepo) {
+				m.EXPECT().GetApplicationByID("app-1").Return(app, nil)
+				m.EXPECT().ListAssignmentsByApplicationID("app-1").Return([]acl.ApplicationAssignment{waiting, approved}, nil)
+				m.EXPECT().UpdateApplication(gomock.Any()).DoAndReturn(func(a acl.Application) error {
+					assert.Equal(t, acl.StatusWithdrawn, a.Status)
+					return nil
+				})
+				// only the reviewer still waiting is released
+				m.EXPECT().UpdateApplicationAssignment(gomock.Any()).DoAndReturn(func(a acl.ApplicationAssignment) error {
+					assert.Equal(t, "as-1", a.ID)
+					assert.Equal(t, acl.ReviewStatusPassed, a.ReviewStatus)
+					return nil
+				})
+				m.EXPECT().CreateApplicationHistory(gomock.Any()).DoAndReturn(func(h acl.ApplicationHistory) error {
+					assert.Equal(t, acl.ActionWithdraw, h.Action)
+					return nil
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock.NewMockiTicketConversationRepo(ctrl)
+			tt.setupMock(mockRepo)
+			uc := TicketConversationUsecase{repo: mockRepo}
+			ctx := util.MockContextWithUser(context.Background(), tt.user)
+			_, err := uc.HandleWithdraw(ctx, TicketStateRequest{ApplicationID: "app-1"})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
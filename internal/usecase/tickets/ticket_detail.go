@@ -9,8 +9,11 @@ import (
 	"log"
 	"time"
 
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
 	"github.com/jekiapp/topic-master/internal/model/acl"
 	entitymodel "github.com/jekiapp/topic-master/internal/model/entity"
+	apprepo "github.com/jekiapp/topic-master/internal/repository/application"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
 	dbpkg "github.com/jekiapp/topic-master/pkg/db"
 	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/tidwall/buntdb"
@@ -27,8 +30,21 @@ type TicketDetailResponse struct {
 	Applicant       acl.User          `json:"applicant"`
 	Assignees       []TicketAssignee  `json:"assignees"`
 	Histories       []historyResponse `json:"histories"`
+	Comments        []commentResponse `json:"comments"`
+	CanComment      bool              `json:"can_comment"`
+	CanReassign     bool              `json:"can_reassign"` // root can hand the open reviews to someone else
 	CreatedAt       string            `json:"created_at"`
 	EligibleActions []acl.AppAction   `json:"eligible_actions"`
+	Approval        []stageProgress   `json:"approval"`
+}
+
+// stageProgress shows how far an approval stage is
+type stageProgress struct {
+	Name              string `json:"name"`
+	ReviewerGroup     string `json:"reviewer_group"`
+	RequiredApprovals int    `json:"required_approvals"`
+	Approvals         int    `json:"approvals"`
+	Status            string `json:"status"` // approved, rejected, in progress, needs info, withdrawn, waiting
 }
 
 type historyResponse struct {
@@ -38,19 +54,33 @@ type historyResponse struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// commentResponse is a comment with its replies, oldest first
+type commentResponse struct {
+	ID        string            `json:"id"`
+	ParentID  string            `json:"parent_id"`
+	Author    string            `json:"author"`
+	Body      string            `json:"body"`
+	CreatedAt string            `json:"created_at"`
+	Replies   []commentResponse `json:"replies"`
+}
+
 type ticketResponse struct {
-	ID          string           `json:"id"`
-	Title       string           `json:"title"`
-	Reason      string           `json:"reason"`
-	Status      string           `json:"status"`
-	Permissions []acl.Permission `json:"permissions"`
+	ID            string           `json:"id"`
+	Title         string           `json:"title"`
+	Reason        string           `json:"reason"`
+	Status        string           `json:"status"`
+	Permissions   []acl.Permission `json:"permissions"`
+	GrantDuration string           `json:"grant_duration,omitempty"` // empty for permanent grants
 }
 
 type TicketAssignee struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Name     string `json:"name"`
-	Status   string `json:"status"`
+	AssignmentID string `json:"assignment_id"`
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	Stage        int    `json:"stage"`
+	OnBehalfOf   string `json:"on_behalf_of,omitempty"` // name of the reviewer on leave
 }
 
 func NewTicketDetailUsecase(db *buntdb.DB) TicketDetailUsecase {
@@ -96,25 +126,43 @@ func (uc TicketDetailUsecase) Handle(ctx context.Context, req map[string]string)
 		if err != nil {
 			log.Println("[TICKET DETAIL] error getting username by user id", err)
 		}
-		if user.ID == iam.ID {
+		if user.ID == iam.ID && assignment.Stage == app.CurrentStage && assignment.ReviewStatus == acl.ReviewStatusWaiting {
 			eligible = true
 		}
-		assignees = append(assignees, TicketAssignee{
-			UserID:   user.ID,
-			Username: user.Username,
-			Name:     user.Name,
-			Status:   assignment.ReviewStatus,
-		})
+		assignee := TicketAssignee{
+			AssignmentID: assignment.ID,
+			UserID:       user.ID,
+			Username:     user.Username,
+			Name:         user.Name,
+			Status:       assignment.ReviewStatus,
+			Stage:        assignment.Stage,
+		}
+		if assignment.OnBehalfOf != "" {
+			assignee.OnBehalfOf = uc.userName(assignment.OnBehalfOf)
+		}
+		assignees = append(assignees, assignee)
 	}
 
-	if app.Status == acl.StatusCompleted {
+	if app.IsClosed() {
 		eligible = false
 	}
 
 	eligibleActions := []acl.AppAction{}
 	if eligible {
-		eligibleActions = append(eligibleActions, acl.AppActionApprove)
+		// approving waits for the applicant to answer the request for information
+		if app.Status != acl.StatusNeedsInfo {
+			eligibleActions = append(eligibleActions, acl.AppActionApprove)
+		}
 		eligibleActions = append(eligibleActions, acl.AppActionReject)
+		if app.Status != acl.StatusNeedsInfo {
+			eligibleActions = append(eligibleActions, acl.AppActionRequestInfo)
+		}
+	}
+	if app.UserID == iam.ID && !app.IsClosed() {
+		if app.Status == acl.StatusNeedsInfo {
+			eligibleActions = append(eligibleActions, acl.AppActionProvideInfo)
+		}
+		eligibleActions = append(eligibleActions, acl.AppActionWithdraw)
 	}
 
 	histories, err := uc.repo.ListHistoriesByApplicationID(ticketID)
@@ -124,6 +172,16 @@ func (uc TicketDetailUsecase) Handle(ctx context.Context, req map[string]string)
 
 	historiesResponse := []historyResponse{}
 	for _, history := range histories {
+		// reminders, escalations and auto rejects are taken by the SLA job
+		if history.ActorID == acl.ActorSystem {
+			historiesResponse = append(historiesResponse, historyResponse{
+				Action:    history.Action,
+				Actor:     acl.ActorSystem,
+				Comment:   history.Comment,
+				CreatedAt: history.CreatedAt.Format(time.RFC822Z),
+			})
+			continue
+		}
 		actor, err := uc.repo.GetUserByID(history.ActorID)
 		if err != nil {
 			// fallback to pending user
@@ -134,26 +192,40 @@ func (uc TicketDetailUsecase) Handle(ctx context.Context, req map[string]string)
 				actor = pendingActor.User
 			}
 		}
+		actorName := actor.Name
+		if history.OnBehalfOf != "" {
+			actorName = fmt.Sprintf("%s on behalf of %s", actor.Name, uc.userName(history.OnBehalfOf))
+		}
 		historiesResponse = append(historiesResponse, historyResponse{
 			Action:    history.Action,
-			Actor:     actor.Name,
+			Actor:     actorName,
 			Comment:   history.Comment,
 			CreatedAt: history.CreatedAt.Format(time.RFC822Z),
 		})
 	}
 
+	comments, err := uc.repo.ListCommentsByApplicationID(ticketID)
+	if err != nil {
+		return TicketDetailResponse{}, fmt.Errorf("[TICKET DETAIL] comments not found: %w", err)
+	}
+
 	response := TicketDetailResponse{
 		Ticket: ticketResponse{
-			ID:     app.ID,
-			Title:  app.Title,
-			Reason: app.Reason,
-			Status: app.Status,
+			ID:            app.ID,
+			Title:         app.Title,
+			Reason:        app.Reason,
+			Status:        app.Status,
+			GrantDuration: app.MetaData[acl.AppMetaData_GrantDuration],
 		},
 		Applicant:       applicant,
 		Assignees:       assignees,
 		Histories:       historiesResponse,
+		Comments:        uc.commentThreads(comments),
+		CanComment:      isTicketParticipant(uc.repo, iam, app, assignments),
+		CanReassign:     isRoot(uc.repo, iam) && !app.IsClosed(),
 		CreatedAt:       app.CreatedAt.Format(time.RFC822Z),
 		EligibleActions: eligibleActions,
+		Approval:        approvalProgress(app, assignments),
 	}
 
 	permissions := []acl.Permission{}
@@ -164,12 +236,93 @@ func (uc TicketDetailUsecase) Handle(ctx context.Context, req map[string]string)
 	return response, nil
 }
 
+func (uc TicketDetailUsecase) userName(userID string) string {
+	user, err := uc.repo.GetUserByID(userID)
+	if err != nil {
+		log.Println("[TICKET DETAIL] error getting username by user id", err)
+	}
+	return user.Name
+}
+
+// commentThreads nests replies under the comment they answer
+func (uc TicketDetailUsecase) commentThreads(comments []acl.ApplicationComment) []commentResponse {
+	authors := map[string]string{}
+	children := map[string][]acl.ApplicationComment{}
+	for _, comment := range comments {
+		if _, ok := authors[comment.AuthorID]; !ok {
+			author, err := uc.repo.GetUserByID(comment.AuthorID)
+			if err != nil {
+				log.Println("[TICKET DETAIL] error getting username by user id", err)
+			}
+			authors[comment.AuthorID] = author.Name
+		}
+		children[comment.ParentID] = append(children[comment.ParentID], comment)
+	}
+
+	var build func(parentID string) []commentResponse
+	build = func(parentID string) []commentResponse {
+		thread := []commentResponse{}
+		for _, comment := range children[parentID] {
+			thread = append(thread, commentResponse{
+				ID:        comment.ID,
+				ParentID:  comment.ParentID,
+				Author:    authors[comment.AuthorID],
+				Body:      comment.Body,
+				CreatedAt: comment.CreatedAt.Format(time.RFC822Z),
+				Replies:   build(comment.ID),
+			})
+		}
+		return thread
+	}
+	return build("")
+}
+
+func approvalProgress(app acl.Application, assignments []acl.ApplicationAssignment) []stageProgress {
+	rejected := false
+	for _, assignment := range assignments {
+		if assignment.ReviewStatus == acl.ReviewStatusRejected {
+			rejected = true
+		}
+	}
+	progress := []stageProgress{}
+	for i := 0; i < app.StageCount(); i++ {
+		stage := app.StageAt(i)
+		item := stageProgress{
+			Name:              stage.Name,
+			ReviewerGroup:     stage.ReviewerGroupName,
+			RequiredApprovals: stage.RequiredApprovals,
+			Approvals:         authlogic.CountStageApprovals(assignments, i),
+		}
+		if item.Name == "" {
+			item.Name = fmt.Sprintf("Stage %d", i+1)
+		}
+		switch {
+		case i < app.CurrentStage || (app.Status == acl.StatusCompleted && !rejected):
+			item.Status = acl.ReviewStatusApproved
+		case i == app.CurrentStage && (rejected || app.Status == acl.StatusAutoRejected):
+			item.Status = acl.ReviewStatusRejected
+		case i == app.CurrentStage && app.Status == acl.StatusWithdrawn:
+			item.Status = acl.StatusWithdrawn
+		case i == app.CurrentStage && app.Status == acl.StatusNeedsInfo:
+			item.Status = acl.StatusNeedsInfo
+		case i == app.CurrentStage && !app.IsClosed():
+			item.Status = "in progress"
+		default:
+			item.Status = "waiting"
+		}
+		progress = append(progress, item)
+	}
+	return progress
+}
+
 type iTicketDetailRepo interface {
+	GetGroupsByUserID(userID string) ([]acl.GroupRole, error)
 	GetApplicationByID(id string) (acl.Application, error)
 	GetUserByID(id string) (acl.User, error)
 	GetUserPendingByID(id string) (acl.UserPending, error)
 	ListAssignmentsByApplicationID(appID string) ([]acl.ApplicationAssignment, error)
 	ListHistoriesByApplicationID(appID string) ([]acl.ApplicationHistory, error)
+	ListCommentsByApplicationID(appID string) ([]acl.ApplicationComment, error)
 	GetPermissionByID(id string) (acl.PermissionMap, error)
 	GetEntityByID(id string) (entitymodel.Entity, error)
 }
@@ -178,6 +331,10 @@ type ticketDetailRepo struct {
 	db *buntdb.DB
 }
 
+func (r *ticketDetailRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
 func (r *ticketDetailRepo) GetApplicationByID(id string) (acl.Application, error) {
 	return dbpkg.GetByID[acl.Application](r.db, id)
 }
@@ -199,6 +356,10 @@ func (r *ticketDetailRepo) ListHistoriesByApplicationID(appID string) ([]acl.App
 	return dbpkg.SelectAll[acl.ApplicationHistory](r.db, "-<="+appID, acl.IdxAppHistory_ApplicationID)
 }
 
+func (r *ticketDetailRepo) ListCommentsByApplicationID(appID string) ([]acl.ApplicationComment, error) {
+	return apprepo.ListCommentsByApplicationID(r.db, appID)
+}
+
 func (r *ticketDetailRepo) GetPermissionByID(id string) (acl.PermissionMap, error) {
 	return dbpkg.GetByID[acl.PermissionMap](r.db, id)
 }
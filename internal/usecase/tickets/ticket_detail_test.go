@@ -88,6 +88,7 @@ func TestTicketDetailUsecase_Handle(t *testing.T) {
 				m.EXPECT().ListAssignmentsByApplicationID("app-bob-1").Return([]acl.ApplicationAssignment{assignment}, nil)
 				m.EXPECT().GetUserByID("carol-id").Return(*carol, nil)
 				m.EXPECT().ListHistoriesByApplicationID("app-bob-1").Return([]acl.ApplicationHistory{history}, nil)
+				m.EXPECT().ListCommentsByApplicationID("app-bob-1").Return([]acl.ApplicationComment{}, nil)
 			},
 			wantResp: TicketDetailResponse{
 				Ticket:          ticketResponse{ID: "app-bob-1", Title: "Alice's Application", Reason: "", Status: "open"},
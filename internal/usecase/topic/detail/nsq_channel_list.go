@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	topicLogic "github.com/jekiapp/topic-master/internal/logic/topic"
+	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/internal/model/entity"
 	modelnsq "github.com/jekiapp/topic-master/internal/model/nsq"
 	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
@@ -129,10 +130,19 @@ func (uc NsqChannelListUsecase) HandleQuery(ctx context.Context, params map[stri
 	if user != nil {
 		userID = user.ID
 		for _, g := range user.Groups {
-			userGroups[g.GroupName] = struct{}{}
+			// members and custom roles only hold some actions, those are checked on demand
+			if g.Role == acl.RoleGroupAdmin {
+				userGroups[g.GroupID] = struct{}{}
+			}
 		}
 	}
 
+	// the global default may restrict actions on unowned channels
+	unownedFree := true
+	if defaults, err := uc.repo.ListEntityDefaultPermissions(entity.DefaultPermission_Unowned); err != nil || !entity.IsOpenToAnyone(defaults) {
+		unownedFree = false
+	}
+
 	channelResponses := make([]NsqChannelResponse, 0, len(channelsDB))
 	for _, c := range channelsDB {
 		cstats, ok := channelStats[c.Name]
@@ -149,8 +159,10 @@ func (uc NsqChannelListUsecase) HandleQuery(ctx context.Context, params map[stri
 
 		// Permission logic (simplified like topic_detail)
 		isFreeAction := true
-		if _, ok := userGroups[c.GroupOwner]; !ok && c.GroupOwner != entity.GroupNone {
+		if _, ok := userGroups[c.GroupOwnerID]; !ok && c.IsOwned() {
 			isFreeAction = false
+		} else if !c.IsOwned() {
+			isFreeAction = unownedFree
 		}
 
 		channelResponses = append(channelResponses, NsqChannelResponse{
@@ -175,12 +187,17 @@ type iNsqChannelListRepo interface {
 	GetAllNsqTopicChannels(topic string) ([]entity.Entity, error)
 	DeleteChannel(topic, channel string) error
 	IsBookmarked(id, userID string) (bool, error)
+	ListEntityDefaultPermissions(entityID string) ([]entity.EntityDefaultPermission, error)
 }
 
 type nsqChannelListRepo struct {
 	db *buntdb.DB
 }
 
+func (r *nsqChannelListRepo) ListEntityDefaultPermissions(entityID string) ([]entity.EntityDefaultPermission, error) {
+	return entityrepo.ListEntityDefaultPermissions(r.db, entityID)
+}
+
 func (r *nsqChannelListRepo) GetAllNsqTopicChannels(topic string) ([]entity.Entity, error) {
 	return nsqrepo.GetAllNsqTopicChannels(r.db, topic)
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/jekiapp/topic-master/internal/config"
 	nsqlogic "github.com/jekiapp/topic-master/internal/logic/nsq"
+	"github.com/jekiapp/topic-master/internal/model/acl"
 	"github.com/jekiapp/topic-master/internal/model/entity"
 	nsqmodel "github.com/jekiapp/topic-master/internal/model/nsq"
 	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
@@ -71,7 +72,8 @@ func (uc NsqTopicDetailUsecase) HandleQuery(ctx context.Context, params map[stri
 	user := util.GetUserInfo(ctx)
 	if user != nil {
 		for _, group := range user.Groups {
-			if group.GroupName == ent.GroupOwner {
+			// members and custom roles only hold some actions, those are checked on demand
+			if group.GroupID == ent.GroupOwnerID && group.Role == acl.RoleGroupAdmin {
 				topicOwned = true
 				break
 			}
@@ -79,8 +81,14 @@ func (uc NsqTopicDetailUsecase) HandleQuery(ctx context.Context, params map[stri
 	}
 
 	isFreeAction := true
-	if ent.GroupOwner != entity.GroupNone && !topicOwned {
+	if ent.IsOwned() && !topicOwned {
 		isFreeAction = false
+	} else if !ent.IsOwned() {
+		// the global default may restrict actions on unowned topics
+		defaults, err := uc.repo.ListEntityDefaultPermissions(entity.DefaultPermission_Unowned)
+		if err != nil || !entity.IsOpenToAnyone(defaults) {
+			isFreeAction = false
+		}
 	}
 
 	// --- Fill Bookmarked ---
@@ -125,15 +133,21 @@ type PublishMessageResponse struct {
 	Message string `json:"message"`
 }
 
+// Encoding describes Message: text (default), base64 or hex, so binary payloads can be published.
 type PublishMessageInput struct {
 	Topic     string   `json:"topic"`
 	Message   string   `json:"message"`
+	Encoding  string   `json:"encoding"`
 	NsqdHosts []string `json:"nsqd_hosts"`
 }
 
 func (uc NsqTopicDetailUsecase) HandlePublish(ctx context.Context, input PublishMessageInput) (PublishMessageResponse, error) {
+	body, err := decodePublishBody(input.Message, input.Encoding)
+	if err != nil {
+		return PublishMessageResponse{}, err
+	}
 	host := input.NsqdHosts[0]
-	err := nsqrepo.Publish(input.Topic, input.Message, host)
+	err = nsqrepo.Publish(input.Topic, string(body), host)
 	if err != nil {
 		return PublishMessageResponse{}, fmt.Errorf("error publishing message: %v", err)
 	}
@@ -147,12 +161,17 @@ type iNsqTopicDetailRepo interface {
 	GetEntityByID(id string) (entity.Entity, error)
 	GetNsqdHosts(lookupdURL, topic string) ([]nsqmodel.SimpleNsqd, error)
 	IsBookmarked(id, userID string) (bool, error)
+	ListEntityDefaultPermissions(entityID string) ([]entity.EntityDefaultPermission, error)
 }
 
 type nsqTopicDetailRepo struct {
 	db *buntdb.DB
 }
 
+func (r *nsqTopicDetailRepo) ListEntityDefaultPermissions(entityID string) ([]entity.EntityDefaultPermission, error) {
+	return entityrepo.ListEntityDefaultPermissions(r.db, entityID)
+}
+
 func (r *nsqTopicDetailRepo) IsBookmarked(id, userID string) (bool, error) {
 	return entityrepo.IsBookmarked(r.db, id, userID)
 }
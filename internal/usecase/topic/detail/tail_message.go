@@ -2,12 +2,16 @@ package detail
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,17 +20,30 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/jekiapp/topic-master/internal/config"
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	topicLogic "github.com/jekiapp/topic-master/internal/logic/topic"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	dbPkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
 	"github.com/nsqio/go-nsq"
+	"github.com/tidwall/buntdb"
 )
 
+// tailHeartbeatInterval keeps idle streams alive behind proxies that drop silent connections.
+const tailHeartbeatInterval = 15 * time.Second
+
 // TailMessageInput holds the parameters for tailing messages from NSQ.
 // NSQDHosts: list of nsqd TCP endpoints (host:port) to connect to.
 // LimitMsg: maximum number of messages to stream.
 // Topic: NSQ topic to consume from.
+// Unmasked: skip the topic masking rules, requires topic:tail:unmasked.
 type TailMessageInput struct {
 	Topic     string   `json:"topic"`
 	LimitMsg  int      `json:"limit_msg"`
 	NSQDHosts []string `json:"nsqd_hosts"`
+	Unmasked  bool     `json:"unmasked"`
 }
 
 // activeChannel tracks the nsqd hosts and topic for a registered channel.
@@ -42,13 +59,19 @@ type TailMessageUsecase struct {
 	activeChannels map[string]activeChannel // Tracks all active channels for cleanup
 	mu             sync.Mutex               // Protects access to activeChannels
 	stopping       atomic.Bool              // Set to true when shutdown is initiated
+	allowedOrigins []string                 // Extra origins allowed to open the websocket
+	cfg            *config.Config
+	repo           iTailMessageRepo
 }
 
 // NewTailMessageUsecase creates a new usecase instance and starts a goroutine to listen for OS termination signals.
 // Motivation: Ensures all active channels are cleaned up on process exit, and prevents new registrations after shutdown is triggered.
-func NewTailMessageUsecase() *TailMessageUsecase {
+func NewTailMessageUsecase(cfg *config.Config, db *buntdb.DB) *TailMessageUsecase {
 	u := &TailMessageUsecase{
 		activeChannels: make(map[string]activeChannel),
+		allowedOrigins: cfg.TailAllowedOrigins,
+		cfg:            cfg,
+		repo:           &tailMessageRepo{db: db},
 	}
 	// Listen for OS signals (SIGINT, SIGTERM) to trigger cleanup
 	sigCh := make(chan os.Signal, 1)
@@ -74,9 +97,8 @@ func NewTailMessageUsecase() *TailMessageUsecase {
 	return u
 }
 
-// HandleTailMessage upgrades the HTTP connection to a websocket and starts streaming messages.
-// Motivation: Provides a websocket endpoint for clients to tail NSQ messages in real time.
-func (u *TailMessageUsecase) HandleTailMessage(w http.ResponseWriter, r *http.Request) {
+// parseTailInput reads and validates the tail parameters shared by every transport.
+func parseTailInput(r *http.Request) (TailMessageInput, error) {
 	q := r.URL.Query()
 	input := TailMessageInput{}
 	input.Topic = q.Get("topic")
@@ -87,40 +109,87 @@ func (u *TailMessageUsecase) HandleTailMessage(w http.ResponseWriter, r *http.Re
 		}
 	}
 	input.NSQDHosts = q["nsqd_hosts"]
+	input.Unmasked, _ = strconv.ParseBool(q.Get("unmasked"))
 
 	if input.LimitMsg <= 0 {
-		http.Error(w, "limit_msg must be > 0", http.StatusBadRequest)
-		return
+		return input, fmt.Errorf("limit_msg must be > 0")
 	}
 	if input.Topic == "" || len(input.NSQDHosts) == 0 {
-		http.Error(w, "topic and nsqd_hosts are required", http.StatusBadRequest)
-		return
+		return input, fmt.Errorf("topic and nsqd_hosts are required")
 	}
+	return input, nil
+}
 
-	upgrader := websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool { return true },
+// HandleTailMessage upgrades the HTTP connection to a websocket and starts streaming messages.
+// Motivation: Provides a websocket endpoint for clients to tail NSQ messages in real time.
+func (u *TailMessageUsecase) HandleTailMessage(w http.ResponseWriter, r *http.Request) {
+	input, err := parseTailInput(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	conn, err := upgrader.Upgrade(w, r, nil)
+	target, err := u.singleTailTarget(r.Context(), input)
 	if err != nil {
-		http.Error(w, "failed to upgrade to websocket", http.StatusInternalServerError)
+		http.Error(w, err.Error(), tailErrorStatus(err))
 		return
 	}
-	defer conn.Close()
+	u.serveTailWebsocket(w, r, []tailTarget{target}, input.LimitMsg, 0)
+}
+
+// HandleTailSSE streams messages as Server-Sent Events.
+// Motivation: Works through proxies that break websockets and can be consumed by EventSource or curl.
+func (u *TailMessageUsecase) HandleTailSSE(w http.ResponseWriter, r *http.Request) {
+	u.handleTailStream(w, r, newSSETailWriter)
+}
+
+// HandleTailStream streams messages as chunked newline-delimited JSON.
+// Motivation: Lets scripts consume the tail with plain HTTP, e.g. `curl -N ... | jq`.
+// With `download=1` the browser saves the stream as a file, which serves as the message export.
+func (u *TailMessageUsecase) HandleTailStream(w http.ResponseWriter, r *http.Request) {
+	newWriter := newNDJSONTailWriter
+	if r.URL.Query().Get("download") != "" {
+		newWriter = func(w http.ResponseWriter) (tailWriter, error) {
+			filename := fmt.Sprintf("%s-%s.ndjson", r.URL.Query().Get("topic"), time.Now().Format("20060102-150405"))
+			w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+			return newNDJSONTailWriter(w)
+		}
+	}
+	u.handleTailStream(w, r, newWriter)
+}
 
-	err = u.tailMessage(r.Context(), conn, input, nil)
+// handleTailStream parses a single topic request for the plain HTTP transports.
+func (u *TailMessageUsecase) handleTailStream(w http.ResponseWriter, r *http.Request, newWriter func(w http.ResponseWriter) (tailWriter, error)) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	input, err := parseTailInput(r)
 	if err != nil {
-		log.Println("failed to tail message:", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	target, err := u.singleTailTarget(r.Context(), input)
+	if err != nil {
+		http.Error(w, err.Error(), tailErrorStatus(err))
+		return
 	}
+	u.serveTailStream(w, r, newWriter, []tailTarget{target}, input.LimitMsg, 0)
 }
 
-// tailMessage streams up to input.LimitMsg messages from NSQ to the websocket connection.
-// Motivation: Handles message consumption, client disconnects, and resource cleanup efficiently and safely.
-func (u *TailMessageUsecase) tailMessage(ctx context.Context, conn *websocket.Conn, input TailMessageInput, signalCh <-chan os.Signal) error {
-	const RS = "\x1E" // ASCII Record Separator for message framing
-	var count int32
-	msgCh := make(chan *nsq.Message, input.LimitMsg)
-	ctx, cancel := context.WithCancel(ctx)
+// serveTailWebsocket upgrades the connection and runs the consumer loop until the client goes away.
+func (u *TailMessageUsecase) serveTailWebsocket(w http.ResponseWriter, r *http.Request, targets []tailTarget, limit int, reorderWindow time.Duration) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: u.checkOrigin,
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already replied to the client
+		log.Println("[TAIL] failed to upgrade to websocket:", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
 
 	// Goroutine to detect websocket disconnects and cancel context
@@ -134,33 +203,212 @@ func (u *TailMessageUsecase) tailMessage(ctx context.Context, conn *websocket.Co
 		}
 	}()
 
-	// NSQ handler: delivers messages to msgCh up to the limit
+	err = u.tailMessage(ctx, &websocketTailWriter{conn: conn}, targets, limit, reorderWindow, nil)
+	if err != nil {
+		log.Println("failed to tail message:", err)
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error()),
+			time.Now().Add(time.Second))
+	}
+}
+
+// serveTailStream runs the consumer loop for the plain HTTP transports.
+// Client disconnects are detected through the request context.
+func (u *TailMessageUsecase) serveTailStream(w http.ResponseWriter, r *http.Request, newWriter func(w http.ResponseWriter) (tailWriter, error), targets []tailTarget, limit int, reorderWindow time.Duration) {
+	writer, err := newWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = u.tailMessage(r.Context(), writer, targets, limit, reorderWindow, nil)
+	if err != nil {
+		log.Println("failed to tail message:", err)
+		writer.WriteError(err)
+		return
+	}
+	writer.WriteEnd()
+}
+
+// checkOrigin allows non-browser clients, same-origin pages and the configured allow-list.
+func (u *TailMessageUsecase) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(parsed.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range u.allowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	log.Printf("[TAIL] rejected websocket origin %s", origin)
+	return false
+}
+
+// tailTarget is one topic to consume, the nsqd hosts that carry it and how to render its payload.
+type tailTarget struct {
+	Topic     string
+	NSQDHosts []string
+	Decoder   *topicLogic.MessageDecoder
+	// Masker is nil when the payload is shown as decoded
+	Masker *topicLogic.PayloadMasker
+}
+
+// tailedMessage is a consumed message tagged with the target it came from.
+type tailedMessage struct {
+	target *tailTarget
+	msg    *nsq.Message
+}
+
+// tailMessage streams up to limit messages from all targets to the given writer.
+// Motivation: Handles message consumption, client disconnects, and resource cleanup efficiently and safely.
+// With more than one target the messages are held for reorderWindow and released in timestamp order,
+// so streams of related topics interleave the way they were published.
+// The caller is responsible for cancelling ctx when the client goes away.
+func (u *TailMessageUsecase) tailMessage(ctx context.Context, writer tailWriter, targets []tailTarget, limit int, reorderWindow time.Duration, signalCh <-chan os.Signal) error {
+	msgCh := make(chan tailedMessage, limit)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// one consumer per topic, all of them feeding msgCh
+	for i := range targets {
+		if targets[i].Decoder == nil {
+			targets[i].Decoder = topicLogic.DefaultMessageDecoder()
+		}
+		stop, err := u.startTailConsumer(ctx, &targets[i], msgCh)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+
+	return mergeTailMessages(ctx, writer, msgCh, limit, reorderWindow, signalCh)
+}
+
+// mergeTailMessages writes up to limit messages from msgCh until ctx is done or a signal arrives.
+// With a reorderWindow the messages are held back that long and released in timestamp order;
+// whatever is still held when the session ends is flushed in order as well.
+func mergeTailMessages(ctx context.Context, writer tailWriter, msgCh <-chan tailedMessage, limit int, reorderWindow time.Duration, signalCh <-chan os.Signal) error {
+	heartbeat := time.NewTicker(tailHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	// flushCh stays nil (never fires) when messages are written as soon as they arrive
+	var flushCh <-chan time.Time
+	if reorderWindow > 0 {
+		flushTicker := time.NewTicker(reorderWindow / 4)
+		defer flushTicker.Stop()
+		flushCh = flushTicker.C
+	}
+
+	count := 0
+	emit := func(m tailedMessage) error {
+		decoded := m.target.Masker.Mask(m.target.Decoder.Decode(m.msg.Body))
+		out := tailMessage{
+			Topic:       m.target.Topic,
+			Payload:     decoded.Payload,
+			Encoding:    decoded.Encoding,
+			DecodeError: decoded.Error,
+			Masked:      decoded.Masked,
+			Timestamp:   time.Unix(0, m.msg.Timestamp).Format(time.RFC3339),
+		}
+		if err := writer.WriteMessage(out); err != nil {
+			return err
+		}
+		count++
+		return nil
+	}
+
+	// pending is kept sorted by the nsqd timestamp of the message
+	var pending []tailedMessage
+	// flush emits the pending messages published up to deadline
+	flush := func(deadline int64) error {
+		for len(pending) > 0 && pending[0].msg.Timestamp <= deadline && count < limit {
+			if err := emit(pending[0]); err != nil {
+				return err
+			}
+			pending = pending[1:]
+		}
+		return nil
+	}
+
+	// Main loop: stream messages, handle context/signal/counter
+loop:
+	for count < limit {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-signalCh:
+			log.Println("[TAIL] received termination signal")
+			break loop
+		case <-heartbeat.C:
+			if err := writer.Heartbeat(); err != nil {
+				return err
+			}
+		case m := <-msgCh:
+			if reorderWindow <= 0 {
+				if err := emit(m); err != nil {
+					return err
+				}
+				continue
+			}
+			i := sort.Search(len(pending), func(i int) bool {
+				return pending[i].msg.Timestamp > m.msg.Timestamp
+			})
+			pending = append(pending, tailedMessage{})
+			copy(pending[i+1:], pending[i:])
+			pending[i] = m
+		case now := <-flushCh:
+			if err := flush(now.Add(-reorderWindow).UnixNano()); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the session ends before the window of the last messages passed, they are still sent
+	if err := flush(math.MaxInt64); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// startTailConsumer connects a consumer on an ephemeral channel of the target topic.
+// The returned stop function stops the consumer and deletes the channel from nsqd.
+func (u *TailMessageUsecase) startTailConsumer(ctx context.Context, target *tailTarget, msgCh chan<- tailedMessage) (func(), error) {
+	// NSQ handler: delivers messages to msgCh until the session ends
 	handler := nsq.HandlerFunc(func(message *nsq.Message) error {
-		if atomic.LoadInt32(&count) < int32(input.LimitMsg) {
-			msgCh <- message
+		select {
+		case msgCh <- tailedMessage{target: target, msg: message}:
+		case <-ctx.Done():
 		}
 		return nil
 	})
 
 	config := nsq.NewConfig()
 	channelName := "topic-master-tail-channel-" + strconv.FormatInt(time.Now().UnixNano(), 10)
-	consumer, err := nsq.NewConsumer(input.Topic, channelName, config)
+	consumer, err := nsq.NewConsumer(target.Topic, channelName, config)
 	if err != nil {
-		return fmt.Errorf("failed to create consumer: %w", err)
+		return nil, fmt.Errorf("failed to create consumer: %w", err)
 	}
 
 	consumer.AddHandler(handler)
 
 	// Prevent new channel registration if service is stopping
 	if u.stopping.Load() {
-		return fmt.Errorf("service is stopping, no new channel registrations allowed")
+		return nil, fmt.Errorf("service is stopping, no new channel registrations allowed")
 	}
 
 	// Register the active channel for later cleanup
 	u.mu.Lock()
 	u.activeChannels[channelName] = activeChannel{
-		nsqdHosts: input.NSQDHosts,
-		topic:     input.Topic,
+		nsqdHosts: target.NSQDHosts,
+		topic:     target.Topic,
 	}
 	u.mu.Unlock()
 
@@ -175,49 +423,95 @@ func (u *TailMessageUsecase) tailMessage(ctx context.Context, conn *websocket.Co
 		delete(u.activeChannels, channelName)
 		u.mu.Unlock()
 	}
-	defer cleanup()
 
 	// Prepare NSQD TCP hosts for connection (convert :4151 to :4150)
-	hosts := make([]string, len(input.NSQDHosts))
-	for i, host := range input.NSQDHosts {
+	hosts := make([]string, len(target.NSQDHosts))
+	for i, host := range target.NSQDHosts {
 		hosts[i] = strings.Replace(host, ":4151", ":4150", 1)
 	}
 	err = consumer.ConnectToNSQDs(hosts)
 	if err != nil {
-		return fmt.Errorf("failed to connect to nsqd: %w", err)
+		cleanup()
+		return nil, fmt.Errorf("failed to connect to nsqd for topic %s: %w", target.Topic, err)
 	}
+	return cleanup, nil
+}
 
-	// Main loop: stream messages, handle context/signal/counter
-loop:
-	for atomic.LoadInt32(&count) < int32(input.LimitMsg) {
-		select {
-		case <-ctx.Done():
-			break loop
-		case <-signalCh:
-			log.Println("[TAIL] received termination signal")
-			break loop
-		case msg := <-msgCh:
-			timestamp := time.Unix(0, msg.Timestamp).Format(time.RFC3339)
-			jsonMsg, err := json.Marshal(struct {
-				Topic     string `json:"topic"`
-				Payload   string `json:"payload"`
-				Timestamp string `json:"timestamp"`
-			}{
-				Topic:     input.Topic,
-				Payload:   string(msg.Body),
-				Timestamp: timestamp,
-			})
-			if err != nil {
-				return err
-			}
-			log.Println("[TAIL] sending message:", string(jsonMsg))
-			if err := conn.WriteMessage(websocket.TextMessage, append(jsonMsg, RS...)); err != nil {
-				return err
-			}
-			atomic.AddInt32(&count, 1)
+// singleTailTarget builds the target of the single topic endpoints, which receive the hosts from the client.
+func (u *TailMessageUsecase) singleTailTarget(ctx context.Context, input TailMessageInput) (tailTarget, error) {
+	target := tailTarget{Topic: input.Topic, NSQDHosts: input.NSQDHosts}
+	ent, err := u.repo.GetNsqTopicEntity(input.Topic)
+	if errors.Is(err, dbPkg.ErrNotFound) {
+		// not synced yet, the checked entity_id can't be this topic
+		return target, fmt.Errorf("%w: %w", errTailForbidden, errEntityIDMismatch)
+	}
+	if err != nil {
+		return target, fmt.Errorf("error getting topic entity: %v", err)
+	}
+	// topic:tail was checked on the entity_id of the query, it must name the tailed topic
+	if ent.ID != util.GetActionEntityID(ctx) {
+		return target, fmt.Errorf("%w: %w", errTailForbidden, errEntityIDMismatch)
+	}
+	target.Decoder = u.messageDecoder(ent.ID)
+	target.Masker, err = u.payloadMasker(ctx, ent, input.Unmasked)
+	if err != nil {
+		return target, err
+	}
+	return target, nil
+}
+
+// payloadMasker returns the masker of the topic, or nil when it has no rules or an unmasked view was granted.
+// Unlike decoders, any failure here fails the session: masking must never be skipped silently.
+func (u *TailMessageUsecase) payloadMasker(ctx context.Context, ent *entity.Entity, unmasked bool) (*topicLogic.PayloadMasker, error) {
+	masking, err := u.repo.GetTopicMasking(ent.ID)
+	if errors.Is(err, dbPkg.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting masking rules of topic %s: %v", ent.Name, err)
+	}
+	if len(masking.Rules) == 0 {
+		return nil, nil
+	}
+	if unmasked {
+		user := util.GetUserInfo(ctx)
+		err := authlogic.CheckUserActionPermission(user, ent.ID, acl.Permission_Topic_Tail_Unmasked.Name, u.repo)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unmasked view of topic %s: %v", errTailForbidden, ent.Name, err)
 		}
+		return nil, nil
 	}
-	return nil
+	masker, err := topicLogic.NewPayloadMasker(masking.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid masking rules of topic %s: %v", ent.Name, err)
+	}
+	return masker, nil
+}
+
+// tailErrorStatus maps errors raised before the stream starts to an HTTP status.
+func tailErrorStatus(err error) int {
+	if errors.Is(err, errTailForbidden) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// messageDecoder returns the decoder configured for the topic entity.
+// A missing or broken configuration falls back to the binary-safe default.
+func (u *TailMessageUsecase) messageDecoder(entityID string) *topicLogic.MessageDecoder {
+	cfg, err := u.repo.GetTopicDecoder(entityID)
+	if err != nil {
+		if !errors.Is(err, dbPkg.ErrNotFound) {
+			log.Printf("[TAIL] failed to get decoder of entity %s: %v", entityID, err)
+		}
+		return topicLogic.DefaultMessageDecoder()
+	}
+	decoder, err := topicLogic.NewMessageDecoder(cfg)
+	if err != nil {
+		log.Printf("[TAIL] invalid decoder of entity %s: %v", entityID, err)
+		return topicLogic.DefaultMessageDecoder()
+	}
+	return decoder
 }
 
 // deleteChannelFromNSQDs deletes the given channel for the topic from all provided nsqd hosts.
@@ -0,0 +1,104 @@
+package detail
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	detail_mock "github.com/jekiapp/topic-master/internal/usecase/topic/detail/mock"
+	dbPkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTailMessageUsecase_SingleTailTarget(t *testing.T) {
+	orders := &entity.Entity{ID: "e-orders", Name: "orders", TypeID: entity.EntityType_NSQTopic}
+	payments := &entity.Entity{ID: "e-payments", Name: "payments", TypeID: entity.EntityType_NSQTopic}
+
+	tests := []struct {
+		name      string
+		checked   string // entity_id of the query, checked by the action middleware
+		topic     string
+		setupMock func(m *detail_mock.MockiTailMessageRepo)
+		wantErr   error
+	}{
+		{
+			name:    "topic of the checked entity",
+			checked: "e-orders",
+			topic:   "orders",
+			setupMock: func(m *detail_mock.MockiTailMessageRepo) {
+				m.EXPECT().GetNsqTopicEntity("orders").Return(orders, nil)
+				m.EXPECT().GetTopicDecoder("e-orders").Return(entity.TopicDecoder{}, dbPkg.ErrNotFound)
+				m.EXPECT().GetTopicMasking("e-orders").Return(entity.TopicMasking{}, dbPkg.ErrNotFound)
+			},
+		},
+		{
+			name:    "tail right on one topic paired with another topic",
+			checked: "e-orders",
+			topic:   "payments",
+			setupMock: func(m *detail_mock.MockiTailMessageRepo) {
+				m.EXPECT().GetNsqTopicEntity("payments").Return(payments, nil)
+			},
+			wantErr: errEntityIDMismatch,
+		},
+		{
+			name:    "topic not synced yet",
+			checked: "e-orders",
+			topic:   "refunds",
+			setupMock: func(m *detail_mock.MockiTailMessageRepo) {
+				m.EXPECT().GetNsqTopicEntity("refunds").Return(nil, dbPkg.ErrNotFound)
+			},
+			wantErr: errEntityIDMismatch,
+		},
+		{
+			name:  "no entity was checked",
+			topic: "orders",
+			setupMock: func(m *detail_mock.MockiTailMessageRepo) {
+				m.EXPECT().GetNsqTopicEntity("orders").Return(orders, nil)
+			},
+			wantErr: errEntityIDMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := detail_mock.NewMockiTailMessageRepo(ctrl)
+			tt.setupMock(m)
+			ctx := context.Background()
+			if tt.checked != "" {
+				ctx = context.WithValue(ctx, model.ActionEntityIDKey, tt.checked)
+			}
+
+			u := &TailMessageUsecase{repo: m}
+			target, err := u.singleTailTarget(ctx, TailMessageInput{Topic: tt.topic, NSQDHosts: []string{"nsqd:4150"}, LimitMsg: 1})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, errTailForbidden)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.topic, target.Topic)
+		})
+	}
+}
+
+func TestTailMessageUsecase_HandleTailSSE_MismatchedTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := detail_mock.NewMockiTailMessageRepo(ctrl)
+	m.EXPECT().GetNsqTopicEntity("payments").Return(&entity.Entity{ID: "e-payments", Name: "payments"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/topic/tail/sse?entity_id=e-orders&topic=payments&nsqd_hosts=nsqd:4150&limit_msg=1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), model.ActionEntityIDKey, "e-orders"))
+	rec := httptest.NewRecorder()
+	(&TailMessageUsecase{repo: m}).HandleTailSSE(rec, req)
+
+	// refused before any consumer is connected or any event is streamed
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.False(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/event-stream"))
+}
@@ -0,0 +1,246 @@
+package detail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	nsqlogic "github.com/jekiapp/topic-master/internal/logic/nsq"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	nsqmodel "github.com/jekiapp/topic-master/internal/model/nsq"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	// maxTailTopics bounds the number of consumers a single session may open.
+	maxTailTopics = 20
+	// tailReorderWindow is how long merged messages are held back to be emitted in timestamp order.
+	tailReorderWindow = 500 * time.Millisecond
+)
+
+var errTailForbidden = errors.New("permission denied")
+
+// TailMultiInput holds the parameters of a multi-topic tail session.
+// Topics: explicit topic names; Pattern: glob matched against synced topic entities (e.g. `order.*`).
+// Unmasked: skip the masking rules, requires topic:tail:unmasked on every masked topic.
+type TailMultiInput struct {
+	Topics   []string `json:"topics"`
+	Pattern  string   `json:"pattern"`
+	LimitMsg int      `json:"limit_msg"`
+	Unmasked bool     `json:"unmasked"`
+}
+
+// parseTailMultiInput accepts repeated or comma separated `topics` and an optional `pattern`.
+func parseTailMultiInput(r *http.Request) (TailMultiInput, error) {
+	q := r.URL.Query()
+	input := TailMultiInput{Pattern: strings.TrimSpace(q.Get("pattern"))}
+	for _, value := range q["topics"] {
+		for _, topic := range strings.Split(value, ",") {
+			if topic = strings.TrimSpace(topic); topic != "" {
+				input.Topics = append(input.Topics, topic)
+			}
+		}
+	}
+	if n, err := strconv.Atoi(q.Get("limit_msg")); err == nil {
+		input.LimitMsg = n
+	}
+	input.Unmasked, _ = strconv.ParseBool(q.Get("unmasked"))
+
+	if input.LimitMsg <= 0 {
+		return input, fmt.Errorf("limit_msg must be > 0")
+	}
+	if len(input.Topics) == 0 && input.Pattern == "" {
+		return input, fmt.Errorf("topics or pattern is required")
+	}
+	if input.Pattern != "" {
+		// validate the glob syntax up front
+		if _, err := path.Match(input.Pattern, ""); err != nil {
+			return input, fmt.Errorf("invalid pattern: %v", err)
+		}
+	}
+	return input, nil
+}
+
+// HandleTailMulti tails several topics at once and merges them into one stream.
+// The transport follows the request: websocket upgrade, `format=sse` (or an
+// event-stream Accept header) for Server-Sent Events, otherwise NDJSON.
+func (u *TailMessageUsecase) HandleTailMulti(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	input, err := parseTailMultiInput(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	targets, err := u.resolveTailTargets(r.Context(), input)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errTailForbidden) {
+			status = http.StatusForbidden
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	switch {
+	case websocket.IsWebSocketUpgrade(r):
+		u.serveTailWebsocket(w, r, targets, input.LimitMsg, tailReorderWindow)
+	case r.URL.Query().Get("format") == "sse" || strings.Contains(r.Header.Get("Accept"), "text/event-stream"):
+		u.serveTailStream(w, r, newSSETailWriter, targets, input.LimitMsg, tailReorderWindow)
+	default:
+		u.serveTailStream(w, r, newNDJSONTailWriter, targets, input.LimitMsg, tailReorderWindow)
+	}
+}
+
+// resolveTailTargets matches the requested topics against the synced topic entities,
+// checks topic:tail on each of them and looks up their nsqd hosts.
+// An explicitly requested topic that is denied fails the whole request,
+// while denied topics that only matched the pattern are skipped.
+func (u *TailMessageUsecase) resolveTailTargets(ctx context.Context, input TailMultiInput) ([]tailTarget, error) {
+	entities, err := u.repo.GetAllNsqTopicEntities()
+	if err != nil {
+		return nil, fmt.Errorf("error getting topic entities: %v", err)
+	}
+
+	explicit := make(map[string]bool, len(input.Topics))
+	for _, topic := range input.Topics {
+		explicit[topic] = true
+	}
+
+	user := util.GetUserInfo(ctx)
+	found := make(map[string]bool)
+	targets := []tailTarget{}
+	for _, ent := range entities {
+		if ent.TypeID != entity.EntityType_NSQTopic || found[ent.Name] {
+			continue
+		}
+		matched := false
+		if input.Pattern != "" {
+			matched, _ = path.Match(input.Pattern, ent.Name)
+		}
+		if !explicit[ent.Name] && !matched {
+			continue
+		}
+		found[ent.Name] = true
+
+		err := authlogic.CheckUserActionPermission(user, ent.ID, acl.Permission_Topic_Tail.Name, u.repo)
+		if err != nil {
+			if explicit[ent.Name] {
+				return nil, fmt.Errorf("%w: topic %s: %v", errTailForbidden, ent.Name, err)
+			}
+			log.Printf("[TAIL] skipping topic %s matched by %q: %v", ent.Name, input.Pattern, err)
+			continue
+		}
+
+		nsqdHosts, err := u.repo.GetNsqdHosts(u.cfg.NSQLookupdHTTPAddr, ent.Name)
+		if err != nil || len(nsqdHosts) == 0 {
+			// no producer knows the topic yet, there is nothing to tail
+			log.Printf("[TAIL] skipping topic %s without nsqd hosts: %v", ent.Name, err)
+			continue
+		}
+		hosts := make([]string, 0, len(nsqdHosts))
+		for _, h := range nsqdHosts {
+			hosts = append(hosts, h.Address)
+		}
+		masker, err := u.payloadMasker(ctx, &ent, input.Unmasked)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, tailTarget{
+			Topic:     ent.Name,
+			NSQDHosts: hosts,
+			Decoder:   u.messageDecoder(ent.ID),
+			Masker:    masker,
+		})
+	}
+
+	for _, topic := range input.Topics {
+		if !found[topic] {
+			return nil, fmt.Errorf("topic %s not found", topic)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no tailable topic matched the request")
+	}
+	if len(targets) > maxTailTopics {
+		return nil, fmt.Errorf("too many topics matched (%d), at most %d can be tailed at once", len(targets), maxTailTopics)
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Topic < targets[j].Topic
+	})
+	return targets, nil
+}
+
+type iTailMessageRepo interface {
+	authlogic.ICheckUserActionPermission
+	GetAllNsqTopicEntities() ([]entity.Entity, error)
+	GetNsqdHosts(lookupdURL, topic string) ([]nsqmodel.SimpleNsqd, error)
+	GetNsqTopicEntity(topic string) (*entity.Entity, error)
+	GetTopicDecoder(entityID string) (entity.TopicDecoder, error)
+	GetTopicMasking(entityID string) (entity.TopicMasking, error)
+}
+
+type tailMessageRepo struct {
+	db *buntdb.DB
+}
+
+func (r *tailMessageRepo) GetEntityByID(id string) (*entity.Entity, error) {
+	ent, err := entityrepo.GetEntityByID(r.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return &ent, nil
+}
+
+func (r *tailMessageRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *tailMessageRepo) GetPermissionByActionEntity(userID, entityID, action string) (acl.PermissionMap, error) {
+	return entityrepo.GetPermissionMapByActionEntityUser(r.db, userID, entityID, action)
+}
+
+func (r *tailMessageRepo) GetCustomRole(groupID, name string) (acl.CustomRole, error) {
+	return userrepo.GetCustomRole(r.db, groupID, name)
+}
+
+func (r *tailMessageRepo) GetEntityDefaultPermission(entityID, action string) (entity.EntityDefaultPermission, error) {
+	return entityrepo.GetEntityDefaultPermission(r.db, entityID, action)
+}
+
+func (r *tailMessageRepo) GetAllNsqTopicEntities() ([]entity.Entity, error) {
+	return entityrepo.GetAllNsqTopicEntities(r.db)
+}
+
+func (r *tailMessageRepo) GetNsqdHosts(lookupdURL, topic string) ([]nsqmodel.SimpleNsqd, error) {
+	return nsqlogic.GetNsqdHosts(lookupdURL, topic)
+}
+
+func (r *tailMessageRepo) GetNsqTopicEntity(topic string) (*entity.Entity, error) {
+	return entityrepo.GetNsqTopicEntity(r.db, topic)
+}
+
+func (r *tailMessageRepo) GetTopicDecoder(entityID string) (entity.TopicDecoder, error) {
+	return entityrepo.GetTopicDecoder(r.db, entityID)
+}
+
+func (r *tailMessageRepo) GetTopicMasking(entityID string) (entity.TopicMasking, error) {
+	return entityrepo.GetTopicMasking(r.db, entityID)
+}
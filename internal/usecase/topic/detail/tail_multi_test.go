@@ -0,0 +1,154 @@
+package detail
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	topicLogic "github.com/jekiapp/topic-master/internal/logic/topic"
+	"github.com/nsqio/go-nsq"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTailWriter keeps what the session wrote, failing from the failAt-th message when set.
+type recordingTailWriter struct {
+	mu       sync.Mutex
+	messages []tailMessage
+	failAt   int
+}
+
+func (w *recordingTailWriter) WriteMessage(msg tailMessage) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failAt > 0 && len(w.messages)+1 >= w.failAt {
+		return errors.New("broken pipe")
+	}
+	w.messages = append(w.messages, msg)
+	return nil
+}
+
+func (w *recordingTailWriter) Heartbeat() error { return nil }
+func (w *recordingTailWriter) WriteEnd()        {}
+func (w *recordingTailWriter) WriteError(error) {}
+
+func (w *recordingTailWriter) payloads() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]string, len(w.messages))
+	for i, m := range w.messages {
+		out[i] = m.Topic + ":" + m.Payload
+	}
+	return out
+}
+
+func TestMergeTailMessages(t *testing.T) {
+	orders := &tailTarget{Topic: "order.created", Decoder: topicLogic.DefaultMessageDecoder()}
+	payments := &tailTarget{Topic: "payment.captured", Decoder: topicLogic.DefaultMessageDecoder()}
+	// published now, so the window holds them back until every arrival was received
+	base := time.Now().UnixNano()
+	message := func(target *tailTarget, payload string, offset time.Duration) tailedMessage {
+		return tailedMessage{target: target, msg: &nsq.Message{Body: []byte(payload), Timestamp: base + int64(offset)}}
+	}
+	// the consumers deliver in their own order, the payment was published between the orders
+	arrivals := []tailedMessage{
+		message(orders, "o1", 1*time.Millisecond),
+		message(orders, "o2", 3*time.Millisecond),
+		message(payments, "p1", 2*time.Millisecond),
+		message(payments, "p2", 4*time.Millisecond),
+	}
+
+	tests := []struct {
+		name          string
+		limit         int
+		reorderWindow time.Duration
+		// end closes the session once every arrival was received; nil waits for the limit
+		end    func(cancel context.CancelFunc, signalCh chan os.Signal)
+		failAt int
+		want   []string
+		// wantErr is the error of the session
+		wantErr string
+	}{
+		{
+			name:          "topics interleave in publish order",
+			limit:         4,
+			reorderWindow: 20 * time.Millisecond,
+			want:          []string{"order.created:o1", "payment.captured:p1", "order.created:o2", "payment.captured:p2"},
+		},
+		{
+			name:  "without a window messages go out as they arrive",
+			limit: 4,
+			want:  []string{"order.created:o1", "order.created:o2", "payment.captured:p1", "payment.captured:p2"},
+		},
+		{
+			name:          "held messages are flushed in order when the client closes",
+			limit:         10,
+			reorderWindow: time.Hour,
+			end:           func(cancel context.CancelFunc, _ chan os.Signal) { cancel() },
+			want:          []string{"order.created:o1", "payment.captured:p1", "order.created:o2", "payment.captured:p2"},
+		},
+		{
+			name:          "held messages are flushed on shutdown",
+			limit:         10,
+			reorderWindow: time.Hour,
+			end:           func(_ context.CancelFunc, signalCh chan os.Signal) { signalCh <- syscall.SIGTERM },
+			want:          []string{"order.created:o1", "payment.captured:p1", "order.created:o2", "payment.captured:p2"},
+		},
+		{
+			name:          "the flush stops at the limit",
+			limit:         3,
+			reorderWindow: time.Hour,
+			end:           func(cancel context.CancelFunc, _ chan os.Signal) { cancel() },
+			want:          []string{"order.created:o1", "payment.captured:p1", "order.created:o2"},
+		},
+		{
+			name:          "a failing writer ends the session",
+			limit:         4,
+			reorderWindow: 20 * time.Millisecond,
+			failAt:        2,
+			want:          []string{"order.created:o1"},
+			wantErr:       "broken pipe",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			// unbuffered, so every arrival has been received once the sends return
+			msgCh := make(chan tailedMessage)
+			signalCh := make(chan os.Signal, 1)
+			writer := &recordingTailWriter{failAt: tt.failAt}
+
+			done := make(chan error, 1)
+			go func() {
+				done <- mergeTailMessages(ctx, writer, msgCh, tt.limit, tt.reorderWindow, signalCh)
+			}()
+			for _, m := range arrivals {
+				select {
+				case msgCh <- m:
+				case err := <-done:
+					t.Fatalf("session ended before all messages arrived: %v", err)
+				}
+			}
+			if tt.end != nil {
+				tt.end(cancel, signalCh)
+			}
+
+			select {
+			case err := <-done:
+				if tt.wantErr != "" {
+					assert.EqualError(t, err, tt.wantErr)
+				} else {
+					assert.NoError(t, err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("session did not end")
+			}
+			assert.Equal(t, tt.want, writer.payloads())
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package detail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// tailMessage is the JSON document sent to the client for every consumed message.
+// Encoding (text, json, hex or base64) tells how Payload was rendered by the topic decoder.
+type tailMessage struct {
+	Topic       string `json:"topic"`
+	Payload     string `json:"payload"`
+	Encoding    string `json:"encoding"`
+	DecodeError string `json:"decode_error,omitempty"`
+	Masked      bool   `json:"masked,omitempty"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// tailWriter delivers tailed messages to the client using the framing of one transport.
+// Motivation: the same consumer loop serves websocket, SSE and NDJSON clients.
+type tailWriter interface {
+	WriteMessage(msg tailMessage) error
+	// Heartbeat is called periodically while no message arrives.
+	Heartbeat() error
+	// WriteEnd marks a normal end of stream (limit reached or consumer stopped).
+	WriteEnd()
+	// WriteError reports a failure after the stream has started.
+	WriteError(err error)
+}
+
+// websocketTailWriter frames every message with the ASCII record separator.
+type websocketTailWriter struct {
+	conn *websocket.Conn
+}
+
+const recordSeparator = "\x1E" // ASCII Record Separator for message framing
+
+func (t *websocketTailWriter) WriteMessage(msg tailMessage) error {
+	jsonMsg, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return t.conn.WriteMessage(websocket.TextMessage, append(jsonMsg, recordSeparator...))
+}
+
+func (t *websocketTailWriter) Heartbeat() error {
+	return t.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second))
+}
+
+func (t *websocketTailWriter) WriteEnd() {}
+
+func (t *websocketTailWriter) WriteError(err error) {}
+
+// sseTailWriter writes messages as `message` events; the stream ends with an `end` or `error` event.
+type sseTailWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func newSSETailWriter(w http.ResponseWriter) (tailWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("streaming is not supported by the connection")
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// disable response buffering on nginx-like proxies
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+	return &sseTailWriter{w: w, flusher: flusher}, nil
+}
+
+func (t *sseTailWriter) writeEvent(event string, data []byte) error {
+	if _, err := fmt.Fprintf(t.w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	t.flusher.Flush()
+	return nil
+}
+
+func (t *sseTailWriter) WriteMessage(msg tailMessage) error {
+	jsonMsg, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return t.writeEvent("message", jsonMsg)
+}
+
+func (t *sseTailWriter) Heartbeat() error {
+	if _, err := fmt.Fprint(t.w, ": ping\n\n"); err != nil {
+		return err
+	}
+	t.flusher.Flush()
+	return nil
+}
+
+func (t *sseTailWriter) WriteEnd() {
+	t.writeEvent("end", []byte("{}"))
+}
+
+func (t *sseTailWriter) WriteError(err error) {
+	data, _ := json.Marshal(map[string]string{"error": err.Error()})
+	t.writeEvent("error", data)
+}
+
+// ndjsonTailWriter writes one JSON document per line over a chunked response.
+type ndjsonTailWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	encoder *json.Encoder
+}
+
+func newNDJSONTailWriter(w http.ResponseWriter) (tailWriter, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("streaming is not supported by the connection")
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &ndjsonTailWriter{w: w, flusher: flusher, encoder: json.NewEncoder(w)}, nil
+}
+
+func (t *ndjsonTailWriter) WriteMessage(msg tailMessage) error {
+	// Encode appends the newline delimiter
+	if err := t.encoder.Encode(msg); err != nil {
+		return err
+	}
+	t.flusher.Flush()
+	return nil
+}
+
+// Heartbeat is a no-op: empty lines are not valid NDJSON records.
+func (t *ndjsonTailWriter) Heartbeat() error {
+	return nil
+}
+
+func (t *ndjsonTailWriter) WriteEnd() {}
+
+func (t *ndjsonTailWriter) WriteError(err error) {
+	t.encoder.Encode(map[string]string{"error": err.Error()})
+	t.flusher.Flush()
+}
@@ -0,0 +1,162 @@
+package detail
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// flushRecorder counts the flushes, each one is a chunk the client receives right away.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushes int
+}
+
+func (r *flushRecorder) Flush() {
+	r.flushes++
+	r.ResponseRecorder.Flush()
+}
+
+// noFlushWriter hides the Flush method of the recorder, like a response that cannot stream.
+type noFlushWriter struct {
+	http.ResponseWriter
+}
+
+func TestSSETailWriter(t *testing.T) {
+	rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	writer, err := newSSETailWriter(rec)
+	if !assert.NoError(t, err) {
+		return
+	}
+	// the headers and the comment reach the client before any message
+	assert.Equal(t, 1, rec.flushes)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	assert.Equal(t, "no", rec.Header().Get("X-Accel-Buffering"))
+
+	assert.NoError(t, writer.WriteMessage(tailMessage{Topic: "orders", Payload: "line1\nline2", Encoding: "text", Timestamp: "2024-05-01T10:00:00Z"}))
+	assert.Equal(t, 2, rec.flushes)
+	assert.NoError(t, writer.Heartbeat())
+	assert.Equal(t, 3, rec.flushes)
+	writer.WriteError(errors.New("nsqd went away"))
+	writer.WriteEnd()
+	assert.Equal(t, 5, rec.flushes)
+
+	// a newline in the payload stays escaped in the JSON, so every event has a single data line
+	want := ": connected\n\n" +
+		"event: message\n" +
+		`data: {"topic":"orders","payload":"line1\nline2","encoding":"text","timestamp":"2024-05-01T10:00:00Z"}` + "\n\n" +
+		": ping\n\n" +
+		"event: error\n" +
+		`data: {"error":"nsqd went away"}` + "\n\n" +
+		"event: end\ndata: {}\n\n"
+	assert.Equal(t, want, rec.Body.String())
+}
+
+func TestNDJSONTailWriter(t *testing.T) {
+	rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	writer, err := newNDJSONTailWriter(rec)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 1, rec.flushes)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+	assert.NoError(t, writer.WriteMessage(tailMessage{Topic: "orders", Payload: "a\nb", Encoding: "text"}))
+	assert.NoError(t, writer.WriteMessage(tailMessage{Topic: "orders", Payload: "AAE=", Encoding: "base64", DecodeError: "msgpack: EOF"}))
+	assert.Equal(t, 3, rec.flushes)
+	// heartbeats write nothing, empty lines are not records
+	assert.NoError(t, writer.Heartbeat())
+	writer.WriteEnd()
+	writer.WriteError(errors.New("consumer stopped"))
+	assert.Equal(t, 4, rec.flushes)
+
+	scanner := bufio.NewScanner(strings.NewReader(rec.Body.String()))
+	var lines []map[string]interface{}
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if !assert.NoError(t, json.Unmarshal(scanner.Bytes(), &line), "every line is a JSON document: %q", scanner.Text()) {
+			return
+		}
+		lines = append(lines, line)
+	}
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, "a\nb", lines[0]["payload"])
+		assert.Equal(t, "msgpack: EOF", lines[1]["decode_error"])
+		assert.Equal(t, "consumer stopped", lines[2]["error"])
+	}
+}
+
+func TestTailWriters_NeedFlusher(t *testing.T) {
+	for name, newWriter := range map[string]func(http.ResponseWriter) (tailWriter, error){
+		"sse":    newSSETailWriter,
+		"ndjson": newNDJSONTailWriter,
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			_, err := newWriter(noFlushWriter{rec})
+			assert.EqualError(t, err, "streaming is not supported by the connection")
+			assert.Empty(t, rec.Body.String())
+		})
+	}
+}
+
+func TestTailMessageUsecase_CheckOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		origin  string
+		allowed []string
+		want    bool
+	}{
+		{name: "no origin, not a browser", host: "tm.example.com", want: true},
+		{name: "same origin", host: "tm.example.com", origin: "https://tm.example.com", want: true},
+		{name: "same origin with a port", host: "localhost:4181", origin: "http://localhost:4181", want: true},
+		{name: "host compared without case", host: "TM.example.com", origin: "https://tm.example.com", want: true},
+		{name: "cross origin", host: "tm.example.com", origin: "https://evil.example.com", want: false},
+		{name: "other port is another origin", host: "localhost:4181", origin: "http://localhost:3000", want: false},
+		{name: "host as a prefix", host: "tm.example.com", origin: "https://tm.example.com.evil.io", want: false},
+		{name: "unparsable origin", host: "tm.example.com", origin: "http://%zz", want: false},
+		{name: "null origin", host: "tm.example.com", origin: "null", want: false},
+		{name: "allow-listed origin", host: "tm.example.com", origin: "https://dashboard.example.com", allowed: []string{"https://dashboard.example.com/"}, want: true},
+		{name: "allow-list needs the scheme", host: "tm.example.com", origin: "http://dashboard.example.com", allowed: []string{"https://dashboard.example.com"}, want: false},
+		{name: "wildcard", host: "tm.example.com", origin: "https://anything.io", allowed: []string{"*"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/topic/tail", nil)
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			u := &TailMessageUsecase{allowedOrigins: tt.allowed}
+			assert.Equal(t, tt.want, u.checkOrigin(req))
+		})
+	}
+}
+
+func TestServeTailWebsocket_RejectsCrossOrigin(t *testing.T) {
+	u := &TailMessageUsecase{allowedOrigins: []string{"https://dashboard.example.com"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.serveTailWebsocket(w, r, nil, 1, 0)
+	}))
+	defer server.Close()
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	header := http.Header{"Origin": []string{"https://evil.example.com"}}
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, header)
+	assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Nil(t, conn)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	}
+}
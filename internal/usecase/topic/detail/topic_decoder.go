@@ -0,0 +1,235 @@
+// topic payload decoder usecase
+
+package detail
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	topicLogic "github.com/jekiapp/topic-master/internal/logic/topic"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbPkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type TopicDecoderResponse struct {
+	EntityID      string    `json:"entity_id"`
+	Compression   string    `json:"compression"`
+	Decoder       string    `json:"decoder"`
+	MessageType   string    `json:"message_type"`
+	HasDescriptor bool      `json:"has_descriptor"`
+	UpdatedBy     string    `json:"updated_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// SaveTopicDecoderInput configures the decoder of a topic.
+// Descriptor is a base64 encoded FileDescriptorSet (protoc --include_imports --descriptor_set_out);
+// when empty the previously uploaded descriptor is kept.
+type SaveTopicDecoderInput struct {
+	EntityID    string `json:"entity_id"`
+	Compression string `json:"compression"`
+	Decoder     string `json:"decoder"`
+	Descriptor  string `json:"descriptor"`
+	MessageType string `json:"message_type"`
+}
+
+type SaveTopicDecoderResponse struct {
+	Message string `json:"message"`
+}
+
+// PreviewMessageInput renders a message the way tail would show it, before it is published.
+// Encoding describes Message: text (default), base64 or hex.
+type PreviewMessageInput struct {
+	EntityID string `json:"entity_id"`
+	Message  string `json:"message"`
+	Encoding string `json:"encoding"`
+}
+
+type TopicDecoderUsecase struct {
+	repo iTopicDecoderRepo
+}
+
+func NewTopicDecoderUsecase(db *buntdb.DB) TopicDecoderUsecase {
+	return TopicDecoderUsecase{
+		repo: &topicDecoderRepo{db: db},
+	}
+}
+
+// params should contain "entity_id"
+func (uc TopicDecoderUsecase) HandleGet(ctx context.Context, params map[string]string) (TopicDecoderResponse, error) {
+	entityID := params["entity_id"]
+	if entityID == "" {
+		return TopicDecoderResponse{}, errors.New("entity_id is required")
+	}
+	cfg, err := uc.getDecoder(entityID)
+	if err != nil {
+		return TopicDecoderResponse{}, err
+	}
+	return TopicDecoderResponse{
+		EntityID:      entityID,
+		Compression:   cfg.Compression,
+		Decoder:       cfg.Decoder,
+		MessageType:   cfg.MessageType,
+		HasDescriptor: len(cfg.Descriptor) > 0,
+		UpdatedBy:     cfg.UpdatedBy,
+		UpdatedAt:     cfg.UpdatedAt,
+	}, nil
+}
+
+func (uc TopicDecoderUsecase) HandleSave(ctx context.Context, input SaveTopicDecoderInput) (SaveTopicDecoderResponse, error) {
+	if checked := util.GetActionEntityID(ctx); checked != "" && checked != input.EntityID {
+		return SaveTopicDecoderResponse{}, errEntityIDMismatch
+	}
+	ent, err := uc.repo.GetEntityByID(input.EntityID)
+	if err != nil {
+		return SaveTopicDecoderResponse{}, fmt.Errorf("error getting topic entity: %v", err)
+	}
+	if ent.TypeID != entity.EntityType_NSQTopic {
+		return SaveTopicDecoderResponse{}, errors.New("decoders can only be configured on topics")
+	}
+	// the action middleware checked the entity_id of the query, make sure it covers the one being changed
+	user := util.GetUserInfo(ctx)
+	err = authlogic.CheckUserActionPermission(user, ent.ID, acl.Permission_Topic_Decoder_Update.Name, uc.repo)
+	if err != nil {
+		return SaveTopicDecoderResponse{}, err
+	}
+
+	prev, err := uc.getDecoder(ent.ID)
+	if err != nil {
+		return SaveTopicDecoderResponse{}, err
+	}
+
+	cfg := entity.TopicDecoder{
+		EntityID:    ent.ID,
+		Compression: input.Compression,
+		Decoder:     input.Decoder,
+		MessageType: input.MessageType,
+		UpdatedAt:   time.Now(),
+	}
+	if input.Decoder == entity.Decoder_Protobuf {
+		cfg.Descriptor = prev.Descriptor
+		if input.Descriptor != "" {
+			cfg.Descriptor, err = base64.StdEncoding.DecodeString(input.Descriptor)
+			if err != nil {
+				return SaveTopicDecoderResponse{}, errors.New("descriptor must be base64 encoded")
+			}
+		}
+	}
+	if user != nil {
+		cfg.UpdatedBy = user.Username
+	}
+
+	// reject configurations that could not decode anything
+	if _, err := topicLogic.NewMessageDecoder(cfg); err != nil {
+		return SaveTopicDecoderResponse{}, err
+	}
+
+	if err := uc.repo.UpsertTopicDecoder(&cfg); err != nil {
+		return SaveTopicDecoderResponse{}, fmt.Errorf("error saving decoder: %v", err)
+	}
+	return SaveTopicDecoderResponse{Message: "Decoder saved"}, nil
+}
+
+func (uc TopicDecoderUsecase) HandlePreview(ctx context.Context, input PreviewMessageInput) (topicLogic.DecodedPayload, error) {
+	body, err := decodePublishBody(input.Message, input.Encoding)
+	if err != nil {
+		return topicLogic.DecodedPayload{}, err
+	}
+	cfg, err := uc.getDecoder(input.EntityID)
+	if err != nil {
+		return topicLogic.DecodedPayload{}, err
+	}
+	decoder, err := topicLogic.NewMessageDecoder(cfg)
+	if err != nil {
+		return topicLogic.DecodedPayload{}, err
+	}
+	return decoder.Decode(body), nil
+}
+
+// getDecoder returns the stored configuration, or the default text decoder when none is stored.
+func (uc TopicDecoderUsecase) getDecoder(entityID string) (entity.TopicDecoder, error) {
+	cfg, err := uc.repo.GetTopicDecoder(entityID)
+	if errors.Is(err, dbPkg.ErrNotFound) {
+		return entity.TopicDecoder{EntityID: entityID, Decoder: entity.Decoder_Text}, nil
+	}
+	if err != nil {
+		return entity.TopicDecoder{}, fmt.Errorf("error getting decoder: %v", err)
+	}
+	return cfg, nil
+}
+
+// decodePublishBody turns a message typed in the UI into the bytes sent to nsqd.
+func decodePublishBody(message, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", topicLogic.PayloadEncoding_Text, topicLogic.PayloadEncoding_JSON:
+		return []byte(message), nil
+	case topicLogic.PayloadEncoding_Base64:
+		body, err := base64.StdEncoding.DecodeString(message)
+		if err != nil {
+			return nil, fmt.Errorf("message is not valid base64: %v", err)
+		}
+		return body, nil
+	case topicLogic.PayloadEncoding_Hex:
+		body, err := hex.DecodeString(message)
+		if err != nil {
+			return nil, fmt.Errorf("message is not valid hex: %v", err)
+		}
+		return body, nil
+	default:
+		return nil, fmt.Errorf("unknown message encoding %q", encoding)
+	}
+}
+
+// errEntityIDMismatch rejects a save whose body names another entity than the checked query
+var errEntityIDMismatch = errors.New("entity_id of the query and the body differ")
+
+type iTopicDecoderRepo interface {
+	authlogic.ICheckUserActionPermission
+	GetTopicDecoder(entityID string) (entity.TopicDecoder, error)
+	UpsertTopicDecoder(decoder *entity.TopicDecoder) error
+}
+
+type topicDecoderRepo struct {
+	db *buntdb.DB
+}
+
+func (r *topicDecoderRepo) GetEntityByID(id string) (*entity.Entity, error) {
+	ent, err := entityrepo.GetEntityByID(r.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return &ent, nil
+}
+
+func (r *topicDecoderRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *topicDecoderRepo) GetPermissionByActionEntity(userID, entityID, action string) (acl.PermissionMap, error) {
+	return entityrepo.GetPermissionMapByActionEntityUser(r.db, userID, entityID, action)
+}
+
+func (r *topicDecoderRepo) GetCustomRole(groupID, name string) (acl.CustomRole, error) {
+	return userrepo.GetCustomRole(r.db, groupID, name)
+}
+
+func (r *topicDecoderRepo) GetEntityDefaultPermission(entityID, action string) (entity.EntityDefaultPermission, error) {
+	return entityrepo.GetEntityDefaultPermission(r.db, entityID, action)
+}
+
+func (r *topicDecoderRepo) GetTopicDecoder(entityID string) (entity.TopicDecoder, error) {
+	return entityrepo.GetTopicDecoder(r.db, entityID)
+}
+
+func (r *topicDecoderRepo) UpsertTopicDecoder(decoder *entity.TopicDecoder) error {
+	return entityrepo.UpsertTopicDecoder(r.db, decoder)
+}
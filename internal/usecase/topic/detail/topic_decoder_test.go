@@ -0,0 +1,152 @@
+package detail
+
+import (
+	"context"
+	"testing"
+
+	topicLogic "github.com/jekiapp/topic-master/internal/logic/topic"
+	"github.com/jekiapp/topic-master/internal/model"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	detail_mock "github.com/jekiapp/topic-master/internal/usecase/topic/detail/mock"
+	dbPkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTopicDecoderUsecase_HandleSave(t *testing.T) {
+	action := acl.Permission_Topic_Decoder_Update.Name
+	own := &entity.Entity{ID: "e1", TypeID: entity.EntityType_NSQTopic, GroupOwnerID: "g1"}
+	other := &entity.Entity{ID: "e2", TypeID: entity.EntityType_NSQTopic, GroupOwnerID: "g2"}
+	memberOfG1 := []acl.GroupRole{{GroupID: "g1", GroupName: "team", Role: acl.RoleGroupMember}}
+
+	tests := []struct {
+		name      string
+		checked   string // entity_id of the query, checked by the action middleware
+		input     SaveTopicDecoderInput
+		setupMock func(m *detail_mock.MockiTopicDecoderRepo)
+		wantErr   string
+	}{
+		{
+			name:    "body names another entity than the query",
+			checked: "e1",
+			input:   SaveTopicDecoderInput{EntityID: "e2", Decoder: entity.Decoder_Text},
+			wantErr: errEntityIDMismatch.Error(),
+		},
+		{
+			name:  "no permission on the entity of the body",
+			input: SaveTopicDecoderInput{EntityID: "e2", Decoder: entity.Decoder_Text},
+			setupMock: func(m *detail_mock.MockiTopicDecoderRepo) {
+				m.EXPECT().GetEntityByID("e2").Return(other, nil).Times(2)
+				m.EXPECT().GetEntityDefaultPermission("e2", action).Return(entity.EntityDefaultPermission{}, nil)
+				m.EXPECT().GetGroupsByUserID("u1").Return(memberOfG1, nil)
+				m.EXPECT().GetPermissionByActionEntity("u1", "e2", action).Return(acl.PermissionMap{}, dbPkg.ErrNotFound)
+			},
+			wantErr: "permission denied",
+		},
+		{
+			name:    "member of the owner group saves",
+			checked: "e1",
+			input:   SaveTopicDecoderInput{EntityID: "e1", Decoder: entity.Decoder_Msgpack, Compression: entity.Compression_Gzip},
+			setupMock: func(m *detail_mock.MockiTopicDecoderRepo) {
+				m.EXPECT().GetEntityByID("e1").Return(own, nil).Times(2)
+				m.EXPECT().GetEntityDefaultPermission("e1", action).Return(entity.EntityDefaultPermission{}, nil)
+				m.EXPECT().GetGroupsByUserID("u1").Return(memberOfG1, nil)
+				m.EXPECT().GetTopicDecoder("e1").Return(entity.TopicDecoder{}, dbPkg.ErrNotFound)
+				m.EXPECT().UpsertTopicDecoder(gomock.Any()).DoAndReturn(func(cfg *entity.TopicDecoder) error {
+					assert.Equal(t, "e1", cfg.EntityID)
+					assert.Equal(t, entity.Decoder_Msgpack, cfg.Decoder)
+					assert.Equal(t, "alice", cfg.UpdatedBy)
+					return nil
+				})
+			},
+		},
+		{
+			name:  "not a topic",
+			input: SaveTopicDecoderInput{EntityID: "e3", Decoder: entity.Decoder_Text},
+			setupMock: func(m *detail_mock.MockiTopicDecoderRepo) {
+				m.EXPECT().GetEntityByID("e3").Return(&entity.Entity{ID: "e3", TypeID: entity.EntityType_NSQChannel}, nil)
+			},
+			wantErr: "decoders can only be configured on topics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := detail_mock.NewMockiTopicDecoderRepo(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(m)
+			}
+			ctx := util.MockContextWithUser(context.Background(), &acl.User{ID: "u1", Username: "alice"})
+			if tt.checked != "" {
+				ctx = context.WithValue(ctx, model.ActionEntityIDKey, tt.checked)
+			}
+
+			resp, err := TopicDecoderUsecase{repo: m}.HandleSave(ctx, tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Decoder saved", resp.Message)
+		})
+	}
+}
+
+func TestDecodePublishBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		encoding string
+		want     []byte
+		wantErr  string
+	}{
+		{name: "default is text", message: "hello", want: []byte("hello")},
+		{name: "text", message: "hello", encoding: topicLogic.PayloadEncoding_Text, want: []byte("hello")},
+		{name: "json", message: `{"a":1}`, encoding: topicLogic.PayloadEncoding_JSON, want: []byte(`{"a":1}`)},
+		{name: "base64", message: "AP/+", encoding: topicLogic.PayloadEncoding_Base64, want: []byte{0x00, 0xff, 0xfe}},
+		{name: "hex", message: "00fffe", encoding: topicLogic.PayloadEncoding_Hex, want: []byte{0x00, 0xff, 0xfe}},
+		{name: "invalid base64", message: "AP/", encoding: topicLogic.PayloadEncoding_Base64, wantErr: "message is not valid base64"},
+		{name: "odd hex", message: "00f", encoding: topicLogic.PayloadEncoding_Hex, wantErr: "message is not valid hex"},
+		{name: "not hex", message: "zz", encoding: topicLogic.PayloadEncoding_Hex, wantErr: "message is not valid hex"},
+		{name: "unknown encoding", message: "x", encoding: "utf-16", wantErr: `unknown message encoding "utf-16"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodePublishBody(tt.message, tt.encoding)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTopicDecoderUsecase_HandlePreview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := detail_mock.NewMockiTopicDecoderRepo(ctrl)
+	uc := TopicDecoderUsecase{repo: m}
+
+	// the gzip magic without a stream: decoding fails and the body is shown as base64
+	m.EXPECT().GetTopicDecoder("e1").Return(entity.TopicDecoder{EntityID: "e1", Compression: entity.Compression_Gzip}, nil)
+	got, err := uc.HandlePreview(context.Background(), PreviewMessageInput{EntityID: "e1", Message: "1f8b", Encoding: topicLogic.PayloadEncoding_Hex})
+	assert.NoError(t, err)
+	assert.Equal(t, topicLogic.PayloadEncoding_Base64, got.Encoding)
+	assert.Equal(t, "H4s=", got.Payload)
+	assert.NotEmpty(t, got.Error)
+
+	// no decoder stored, valid text is shown as it is
+	m.EXPECT().GetTopicDecoder("e2").Return(entity.TopicDecoder{}, dbPkg.ErrNotFound)
+	got, err = uc.HandlePreview(context.Background(), PreviewMessageInput{EntityID: "e2", Message: "hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, topicLogic.DecodedPayload{Payload: "hello", Encoding: topicLogic.PayloadEncoding_Text}, got)
+
+	_, err = uc.HandlePreview(context.Background(), PreviewMessageInput{EntityID: "e2", Message: "zz", Encoding: topicLogic.PayloadEncoding_Hex})
+	assert.ErrorContains(t, err, "message is not valid hex")
+}
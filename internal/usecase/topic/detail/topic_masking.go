@@ -0,0 +1,147 @@
+// topic payload masking usecase
+
+package detail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	authlogic "github.com/jekiapp/topic-master/internal/logic/auth"
+	topicLogic "github.com/jekiapp/topic-master/internal/logic/topic"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	entityrepo "github.com/jekiapp/topic-master/internal/repository/entity"
+	userrepo "github.com/jekiapp/topic-master/internal/repository/user"
+	dbPkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/tidwall/buntdb"
+)
+
+type TopicMaskingResponse struct {
+	EntityID  string            `json:"entity_id"`
+	Rules     []entity.MaskRule `json:"rules"`
+	UpdatedBy string            `json:"updated_by"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// SaveTopicMaskingInput replaces all masking rules of a topic; an empty list disables masking.
+type SaveTopicMaskingInput struct {
+	EntityID string            `json:"entity_id"`
+	Rules    []entity.MaskRule `json:"rules"`
+}
+
+type SaveTopicMaskingResponse struct {
+	Message string `json:"message"`
+}
+
+type TopicMaskingUsecase struct {
+	repo iTopicMaskingRepo
+}
+
+func NewTopicMaskingUsecase(db *buntdb.DB) TopicMaskingUsecase {
+	return TopicMaskingUsecase{
+		repo: &topicMaskingRepo{db: db},
+	}
+}
+
+// params should contain "entity_id"
+func (uc TopicMaskingUsecase) HandleGet(ctx context.Context, params map[string]string) (TopicMaskingResponse, error) {
+	entityID := params["entity_id"]
+	if entityID == "" {
+		return TopicMaskingResponse{}, errors.New("entity_id is required")
+	}
+	masking, err := uc.repo.GetTopicMasking(entityID)
+	if errors.Is(err, dbPkg.ErrNotFound) {
+		return TopicMaskingResponse{EntityID: entityID, Rules: []entity.MaskRule{}}, nil
+	}
+	if err != nil {
+		return TopicMaskingResponse{}, fmt.Errorf("error getting masking rules: %v", err)
+	}
+	return TopicMaskingResponse{
+		EntityID:  entityID,
+		Rules:     masking.Rules,
+		UpdatedBy: masking.UpdatedBy,
+		UpdatedAt: masking.UpdatedAt,
+	}, nil
+}
+
+func (uc TopicMaskingUsecase) HandleSave(ctx context.Context, input SaveTopicMaskingInput) (SaveTopicMaskingResponse, error) {
+	if checked := util.GetActionEntityID(ctx); checked != "" && checked != input.EntityID {
+		return SaveTopicMaskingResponse{}, errEntityIDMismatch
+	}
+	ent, err := uc.repo.GetEntityByID(input.EntityID)
+	if err != nil {
+		return SaveTopicMaskingResponse{}, fmt.Errorf("error getting topic entity: %v", err)
+	}
+	if ent.TypeID != entity.EntityType_NSQTopic {
+		return SaveTopicMaskingResponse{}, errors.New("masking rules can only be configured on topics")
+	}
+	// the action middleware checked the entity_id of the query, make sure it covers the one being changed
+	user := util.GetUserInfo(ctx)
+	err = authlogic.CheckUserActionPermission(user, ent.ID, acl.Permission_Topic_Masking_Update.Name, uc.repo)
+	if err != nil {
+		return SaveTopicMaskingResponse{}, err
+	}
+
+	// compile once to reject rules that would break every tail session of the topic
+	if _, err := topicLogic.NewPayloadMasker(input.Rules); err != nil {
+		return SaveTopicMaskingResponse{}, err
+	}
+
+	masking := entity.TopicMasking{
+		EntityID:  ent.ID,
+		Rules:     input.Rules,
+		UpdatedAt: time.Now(),
+	}
+	if user != nil {
+		masking.UpdatedBy = user.Username
+	}
+	if err := uc.repo.UpsertTopicMasking(&masking); err != nil {
+		return SaveTopicMaskingResponse{}, fmt.Errorf("error saving masking rules: %v", err)
+	}
+	return SaveTopicMaskingResponse{Message: "Masking rules saved"}, nil
+}
+
+type iTopicMaskingRepo interface {
+	authlogic.ICheckUserActionPermission
+	GetTopicMasking(entityID string) (entity.TopicMasking, error)
+	UpsertTopicMasking(masking *entity.TopicMasking) error
+}
+
+type topicMaskingRepo struct {
+	db *buntdb.DB
+}
+
+func (r *topicMaskingRepo) GetEntityByID(id string) (*entity.Entity, error) {
+	ent, err := entityrepo.GetEntityByID(r.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return &ent, nil
+}
+
+func (r *topicMaskingRepo) GetGroupsByUserID(userID string) ([]acl.GroupRole, error) {
+	return userrepo.ListGroupsForUser(r.db, userID)
+}
+
+func (r *topicMaskingRepo) GetPermissionByActionEntity(userID, entityID, action string) (acl.PermissionMap, error) {
+	return entityrepo.GetPermissionMapByActionEntityUser(r.db, userID, entityID, action)
+}
+
+func (r *topicMaskingRepo) GetCustomRole(groupID, name string) (acl.CustomRole, error) {
+	return userrepo.GetCustomRole(r.db, groupID, name)
+}
+
+func (r *topicMaskingRepo) GetEntityDefaultPermission(entityID, action string) (entity.EntityDefaultPermission, error) {
+	return entityrepo.GetEntityDefaultPermission(r.db, entityID, action)
+}
+
+func (r *topicMaskingRepo) GetTopicMasking(entityID string) (entity.TopicMasking, error) {
+	return entityrepo.GetTopicMasking(r.db, entityID)
+}
+
+func (r *topicMaskingRepo) UpsertTopicMasking(masking *entity.TopicMasking) error {
+	return entityrepo.UpsertTopicMasking(r.db, masking)
+}
@@ -0,0 +1,149 @@
+package detail
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jekiapp/topic-master/internal/model"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/internal/model/entity"
+	detail_mock "github.com/jekiapp/topic-master/internal/usecase/topic/detail/mock"
+	dbPkg "github.com/jekiapp/topic-master/pkg/db"
+	"github.com/jekiapp/topic-master/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTopicMaskingUsecase_HandleGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := detail_mock.NewMockiTopicMaskingRepo(ctrl)
+	uc := TopicMaskingUsecase{repo: m}
+
+	m.EXPECT().GetTopicMasking("e1").Return(entity.TopicMasking{}, dbPkg.ErrNotFound)
+	resp, err := uc.HandleGet(context.Background(), map[string]string{"entity_id": "e1"})
+	assert.NoError(t, err)
+	assert.Equal(t, TopicMaskingResponse{EntityID: "e1", Rules: []entity.MaskRule{}}, resp)
+
+	rules := []entity.MaskRule{{Type: entity.MaskRule_JSONPath, Expression: "user.email"}}
+	m.EXPECT().GetTopicMasking("e1").Return(entity.TopicMasking{EntityID: "e1", Rules: rules, UpdatedBy: "alice"}, nil)
+	resp, err = uc.HandleGet(context.Background(), map[string]string{"entity_id": "e1"})
+	assert.NoError(t, err)
+	assert.Equal(t, rules, resp.Rules)
+	assert.Equal(t, "alice", resp.UpdatedBy)
+
+	m.EXPECT().GetTopicMasking("e1").Return(entity.TopicMasking{}, errors.New("disk error"))
+	_, err = uc.HandleGet(context.Background(), map[string]string{"entity_id": "e1"})
+	assert.ErrorContains(t, err, "disk error")
+
+	_, err = uc.HandleGet(context.Background(), map[string]string{})
+	assert.ErrorContains(t, err, "entity_id is required")
+}
+
+func TestTopicMaskingUsecase_HandleSave(t *testing.T) {
+	action := acl.Permission_Topic_Masking_Update.Name
+	topic := &entity.Entity{ID: "e1", TypeID: entity.EntityType_NSQTopic, GroupOwnerID: "g1"}
+	// masking is left to admins, members of the owner group need a grant
+	adminOfG1 := []acl.GroupRole{{GroupID: "g1", GroupName: "team", Role: acl.RoleGroupAdmin}}
+	allowed := func(m *detail_mock.MockiTopicMaskingRepo) {
+		m.EXPECT().GetEntityByID("e1").Return(topic, nil).Times(2)
+		m.EXPECT().GetEntityDefaultPermission("e1", action).Return(entity.EntityDefaultPermission{}, nil)
+		m.EXPECT().GetGroupsByUserID("u1").Return(adminOfG1, nil)
+	}
+
+	tests := []struct {
+		name      string
+		checked   string // entity_id of the query, checked by the action middleware
+		input     SaveTopicMaskingInput
+		setupMock func(m *detail_mock.MockiTopicMaskingRepo)
+		wantErr   string
+	}{
+		{
+			name: "saves the rules",
+			input: SaveTopicMaskingInput{EntityID: "e1", Rules: []entity.MaskRule{
+				{Type: entity.MaskRule_JSONPath, Expression: "user.email"},
+				{Type: entity.MaskRule_Regex, Expression: `\d{16}`},
+			}},
+			checked: "e1",
+			setupMock: func(m *detail_mock.MockiTopicMaskingRepo) {
+				allowed(m)
+				m.EXPECT().UpsertTopicMasking(gomock.Any()).DoAndReturn(func(masking *entity.TopicMasking) error {
+					assert.Equal(t, "e1", masking.EntityID)
+					assert.Len(t, masking.Rules, 2)
+					assert.Equal(t, "alice", masking.UpdatedBy)
+					return nil
+				})
+			},
+		},
+		{
+			name:  "empty rules disable masking",
+			input: SaveTopicMaskingInput{EntityID: "e1"},
+			setupMock: func(m *detail_mock.MockiTopicMaskingRepo) {
+				allowed(m)
+				m.EXPECT().UpsertTopicMasking(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "invalid regex is rejected",
+			input:     SaveTopicMaskingInput{EntityID: "e1", Rules: []entity.MaskRule{{Type: entity.MaskRule_Regex, Expression: "[a-"}}},
+			setupMock: allowed,
+			wantErr:   "invalid regex",
+		},
+		{
+			name:      "invalid JSON path is rejected",
+			input:     SaveTopicMaskingInput{EntityID: "e1", Rules: []entity.MaskRule{{Type: entity.MaskRule_JSONPath, Expression: "a..b"}}},
+			setupMock: allowed,
+			wantErr:   "invalid JSON path",
+		},
+		{
+			name:    "body names another entity than the query",
+			checked: "e1",
+			input:   SaveTopicMaskingInput{EntityID: "e2"},
+			wantErr: errEntityIDMismatch.Error(),
+		},
+		{
+			name:  "no permission on the entity of the body",
+			input: SaveTopicMaskingInput{EntityID: "e2"},
+			setupMock: func(m *detail_mock.MockiTopicMaskingRepo) {
+				other := &entity.Entity{ID: "e2", TypeID: entity.EntityType_NSQTopic, GroupOwnerID: "g2"}
+				m.EXPECT().GetEntityByID("e2").Return(other, nil).Times(2)
+				m.EXPECT().GetEntityDefaultPermission("e2", action).Return(entity.EntityDefaultPermission{}, nil)
+				m.EXPECT().GetGroupsByUserID("u1").Return(adminOfG1, nil)
+				m.EXPECT().GetPermissionByActionEntity("u1", "e2", action).Return(acl.PermissionMap{}, dbPkg.ErrNotFound)
+			},
+			wantErr: "permission denied",
+		},
+		{
+			name:  "not a topic",
+			input: SaveTopicMaskingInput{EntityID: "c1"},
+			setupMock: func(m *detail_mock.MockiTopicMaskingRepo) {
+				m.EXPECT().GetEntityByID("c1").Return(&entity.Entity{ID: "c1", TypeID: entity.EntityType_NSQChannel}, nil)
+			},
+			wantErr: "masking rules can only be configured on topics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := detail_mock.NewMockiTopicMaskingRepo(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(m)
+			}
+			ctx := util.MockContextWithUser(context.Background(), &acl.User{ID: "u1", Username: "alice"})
+			if tt.checked != "" {
+				ctx = context.WithValue(ctx, model.ActionEntityIDKey, tt.checked)
+			}
+
+			resp, err := TopicMaskingUsecase{repo: m}.HandleSave(ctx, tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Masking rules saved", resp.Message)
+		})
+	}
+}
@@ -25,17 +25,36 @@ function renderGroupRow(g) {
   `;
 }
 
+// zero times come as 0001-01-01 from the api
+function formatUserTime(t) {
+  if (!t || t.startsWith('0001-')) return '';
+  return new Date(t).toLocaleString();
+}
+
 function renderUserRow(u) {
+  const deactivated = formatUserTime(u.deactivated_at);
+  const deactivatedTitle = deactivated ? ` title="Deactivated ${deactivated} by ${u.deactivated_by}"` : '';
   return `<tr data-user-id="${u.id}" data-username="${u.username}">
     <td>${u.username}</td>
     <td>${u.name}</td>
     <td>${u.groups}</td>
-    <td>${u.status}</td>
+    <td${deactivatedTitle}>${u.status}${u.source === 'ldap' ? ' <small title="Password checked by the LDAP directory">(LDAP)</small>' : ''}${u.source === 'oidc' ? ' <small title="Signs in with the single sign-on provider">(SSO)</small>' : ''}</td>
+    <td>${formatUserTime(u.last_active_at) || '<small>never</small>'}</td>
     <td>
       <span class="action-icon edit-user" title="Edit">
         <img src="icons/edit_icon.png" alt="Edit" style="width:15px;height:18px;vertical-align:middle;" />
       </span>
       <span style="display:inline-block; width:3px;"></span>
+      <span class="action-icon user-grants" title="Grants" style="font-size:0.85em;color:#1e90ff;cursor:pointer;text-decoration:underline;">Grants</span>
+      <span style="display:inline-block; width:3px;"></span>
+      ${u.status === 'pending' ? `<span class="action-icon resend-invitation" title="Email a new link to set the password" style="font-size:0.85em;color:#1e90ff;cursor:pointer;text-decoration:underline;">Resend Invite</span>
+      <span style="display:inline-block; width:3px;"></span>` : ''}
+      ${u.status === 'active' ? `<span class="action-icon deactivate-user" title="Block the login and end the sessions, groups and grants are kept" style="font-size:0.85em;color:#1e90ff;cursor:pointer;text-decoration:underline;">Deactivate</span>
+      <span style="display:inline-block; width:3px;"></span>` : ''}
+      ${u.status === 'inactive' ? `<span class="action-icon reactivate-user" title="Allow the user to log in again" style="font-size:0.85em;color:#1e90ff;cursor:pointer;text-decoration:underline;">Reactivate</span>
+      <span style="display:inline-block; width:3px;"></span>` : ''}
+      <span class="action-icon reset-user-2fa" title="Remove the authenticator of a user who lost it" style="font-size:0.85em;color:#1e90ff;cursor:pointer;text-decoration:underline;">Reset 2FA</span>
+      <span style="display:inline-block; width:3px;"></span>
       <span class="action-icon delete-user" title="Delete">
         <img src="icons/delete_icon.png" alt="Delete" style="width:15px;height:18px;vertical-align:middle;" />
       </span>
@@ -97,7 +116,9 @@ function resetGroupForm() {
 function showGroupPopup({ mode, group = {} }) {
   if (mode === 'edit') {
     $('#group-popup-overlay h3').text('Edit Group');
-    $('#group-name').val(group.name).prop('disabled', true);
+    // root cannot be renamed
+    $('#group-name').val(group.name).prop('disabled', group.name === 'root');
+    $('#create-group-form').data('edit-group-name', group.name);
     $('#group-desc').val(group.description);
     $('#create-group-form').data('edit-group-id', group.id);
   } else {
@@ -115,6 +136,7 @@ function closeGroupPopup() {
   $('#group-popup-overlay h3').text('Create New Group');
   $('#group-name').prop('disabled', false);
   $('#create-group-form').removeData('edit-group-id');
+  $('#create-group-form').removeData('edit-group-name');
   resetGroupForm();
 }
 
@@ -125,12 +147,16 @@ function handleGroupFormSubmit(e) {
   const description = $('#group-desc').val();
   const editId = $('#create-group-form').data('edit-group-id');
   if (editId) {
-    // Edit mode
+    // Edit mode, only send the name when it changed
+    const payload = { id: editId, description };
+    if (name !== $('#create-group-form').data('edit-group-name')) {
+      payload.name = name;
+    }
     $.ajax({
       url: '/api/group/update-group-by-id',
       method: 'POST',
       contentType: 'application/json',
-      data: JSON.stringify({ id: editId, description }),
+      data: JSON.stringify(payload),
       success: function(resp) {
         closeGroupPopup();
         fillGroupsTable();
@@ -179,6 +205,20 @@ function showDeleteGroupPopup(groupId, groupName) {
   pendingDeleteGroupId = groupId;
   pendingDeleteGroupName = groupName;
   $('#delete-group-message').text(`Are you sure you want to delete group '${groupName}'?`);
+  const $select = $('#delete-group-reassign');
+  $select.empty().append($('<option>').val('None').text('None (release ownership)'));
+  $.ajax({
+    url: '/api/group/list-simple',
+    method: 'POST',
+    contentType: 'application/json',
+    data: '{}',
+    success: function(resp) {
+      ((resp.data && resp.data.groups) || []).forEach(function(g) {
+        if (g.id === groupId) return;
+        $select.append($('<option>').val(g.id).text(g.name));
+      });
+    }
+  });
   $('#delete-group-popup-overlay').show();
 }
 
@@ -194,6 +234,14 @@ function bindGroupEvents() {
     showGroupPopup({ mode: 'create' });
   });
 
+  $('#security-btn').on('click', function() {
+    showSecurityModal();
+  });
+
+  $('#unowned-defaults-btn').on('click', function() {
+    window.showDefaultPermissionsModal('unowned');
+  });
+
   // Cancel button
   $('#cancel-group-btn').on('click', function() {
     closeGroupPopup();
@@ -227,7 +275,7 @@ function bindGroupEvents() {
       url: '/api/group/delete-group',
       method: 'POST',
       contentType: 'application/json',
-      data: JSON.stringify({ id: pendingDeleteGroupId }),
+      data: JSON.stringify({ id: pendingDeleteGroupId, reassign_to: $('#delete-group-reassign').val() }),
       success: function(resp) {
         closeDeleteGroupPopup();
         fillGroupsTable();
@@ -346,6 +394,8 @@ function showUserSuccessModal(username, password, title, message) {
   $('#user-success-popup-overlay .form-note').text(message).show();
   $('#created-username').text(username || '');
   $('#created-password').text(password || '');
+  // an invited user chooses the password, there is none to show
+  $('#created-password').closest('.form-group').toggle(!!password);
   $('#user-success-popup-overlay').show();
 }
 
@@ -355,6 +405,7 @@ function handleUserFormSubmit(e) {
   const username = $('#user-username').val();
   const name = $('#user-name').val();
   const email = $('#user-email').val();
+  const invite = $('#user-invite').is(':checked');
   const groups = collectGroupMappings();
   $.ajax({
     url: '/api/user/create',
@@ -364,11 +415,19 @@ function handleUserFormSubmit(e) {
       username: username,
       name: name,
       email: email,
+      invite: invite,
       groups: groups
     }),
     success: function(resp) {
       closeUserPopup();
-      if (resp && resp.data) {
+      if (resp && resp.data && resp.data.invite_sent) {
+        showUserSuccessModal(
+          resp.data.username || '',
+          '',
+          'User Invited',
+          `An invitation link has been sent to ${email}.`
+        );
+      } else if (resp && resp.data) {
         showUserSuccessModal(
           resp.data.username || '',
           resp.data.generated_password || '',
@@ -424,6 +483,63 @@ function bindUserEvents() {
     const userId = $tr.data('user-id');
     showUpdateUserPopup(userId);
   });
+  // Bind user-grants button
+  $(document).on('click', '.user-grants', function() {
+    const $tr = $(this).closest('tr');
+    window.showUserGrantsModal($tr.data('user-id'), $tr.data('username'));
+  });
+  $(document).on('click', '.resend-invitation', function() {
+    const $tr = $(this).closest('tr');
+    const username = $tr.data('username');
+    $.ajax({
+      url: '/api/user/resend-invitation',
+      method: 'POST',
+      contentType: 'application/json',
+      data: JSON.stringify({ user_id: $tr.data('user-id') }),
+      success: function(resp) {
+        const email = resp && resp.data ? resp.data.email : '';
+        window.showModalOverlay(`A new invitation link has been sent to ${email}, the previous one no longer works.`);
+      },
+      error: function(xhr) {
+        let msg = `Failed to resend the invitation of '${username}'`;
+        if (xhr.responseJSON && xhr.responseJSON.message) {
+          msg += ': ' + xhr.responseJSON.message;
+        }
+        window.showModalOverlay(msg);
+      }
+    });
+  });
+  $(document).on('click', '.deactivate-user', function() {
+    const $tr = $(this).closest('tr');
+    const username = $tr.data('username');
+    if (!confirm(`Deactivate '${username}'? They are logged out and can't log in until reactivated, their reviews are handed over.`)) return;
+    setUserActive($tr.data('user-id'), username, false);
+  });
+  $(document).on('click', '.reactivate-user', function() {
+    const $tr = $(this).closest('tr');
+    setUserActive($tr.data('user-id'), $tr.data('username'), true);
+  });
+  $(document).on('click', '.reset-user-2fa', function() {
+    const $tr = $(this).closest('tr');
+    const username = $tr.data('username');
+    if (!confirm(`Reset two-factor authentication of '${username}'? They can log in with the password only, or set up a new authenticator when 2FA is required.`)) return;
+    $.ajax({
+      url: '/api/user/2fa/reset',
+      method: 'POST',
+      contentType: 'application/json',
+      data: JSON.stringify({ user_id: $tr.data('user-id') }),
+      success: function() {
+        window.showModalOverlay(`Two-factor authentication of '${username}' has been reset.`);
+      },
+      error: function(xhr) {
+        let msg = 'Failed to reset two-factor authentication';
+        if (xhr.responseJSON && xhr.responseJSON.message) {
+          msg += ': ' + xhr.responseJSON.message;
+        }
+        window.showModalOverlay(msg);
+      }
+    });
+  });
   // Bind delete-user button
   $(document).on('click', '.delete-user', function() {
     const $tr = $(this).closest('tr');
@@ -645,7 +761,7 @@ function closeUpdateUserPopup() {
 function showDeleteUserPopup(userId, username) {
   pendingDeleteUserId = userId;
   pendingDeleteUsername = username;
-  $('#delete-user-message').text(`Are you sure you want to delete user '${username}'?`);
+  $('#delete-user-message').text(`Are you sure you want to delete user '${username}'? Their grants and bookmarks are removed, their open tickets withdrawn and their reviews handed over. Deactivate the user instead to keep them.`);
   $('#delete-user-popup-overlay').show();
 }
 
@@ -655,6 +771,111 @@ function closeDeleteUserPopup() {
   $('#delete-user-popup-overlay').hide();
 }
 
+// showSecurityModal lists the locked accounts and the recent failed logins, lockouts and throttled clients
+function showSecurityModal() {
+  $.when(
+    $.get('/api/security/lockouts'),
+    $.get('/api/security/events', { limit: 50 })
+  ).done(function(lockoutsRes, eventsRes) {
+    const lockouts = (lockoutsRes[0].data && lockoutsRes[0].data.lockouts) || [];
+    const events = (eventsRes[0].data && eventsRes[0].data.events) || [];
+    const $content = $(`
+      <div style="min-width:600px;max-height:80vh;overflow:auto;">
+        <h3 style="margin-top:0;">Locked accounts</h3>
+        <table style="width:100%;border-collapse:collapse;">
+          <thead><tr><th>Username</th><th>Locked until</th><th></th></tr></thead>
+          <tbody class="security-lockouts"></tbody>
+        </table>
+        <h3>Recent events</h3>
+        <table style="width:100%;border-collapse:collapse;">
+          <thead><tr><th>Time</th><th>Event</th><th>Username</th><th>IP</th><th>Detail</th></tr></thead>
+          <tbody class="security-events"></tbody>
+        </table>
+        <div style="text-align:right;margin-top:1em;">
+          <button id="security-modal-close">Close</button>
+        </div>
+      </div>
+    `);
+    const $lockouts = $content.find('.security-lockouts');
+    if (lockouts.length === 0) {
+      $lockouts.append('<tr><td colspan="3">No account is locked</td></tr>');
+    }
+    lockouts.forEach(function(l) {
+      const $btn = $('<button class="themed-btn">Unlock</button>').on('click', function() {
+        unlockUser(l.user_id, l.username);
+      });
+      $lockouts.append($('<tr></tr>').append(
+        $('<td></td>').text(l.username),
+        $('<td></td>').text(new Date(l.locked_until * 1000).toLocaleString()),
+        $('<td></td>').append($btn)
+      ));
+    });
+    const $events = $content.find('.security-events');
+    if (events.length === 0) {
+      $events.append('<tr><td colspan="5">No events</td></tr>');
+    }
+    events.forEach(function(e) {
+      $events.append($('<tr></tr>').append(
+        $('<td></td>').text(new Date(e.created_at).toLocaleString()),
+        $('<td></td>').text(e.event),
+        $('<td></td>').text(e.username || '-'),
+        $('<td></td>').text(e.ip || '-'),
+        $('<td></td>').text(e.detail || '')
+      ));
+    });
+    window.showModalOverlay('');
+    $('#modal-content').append($content);
+    $('#security-modal-close').on('click', function() {
+      window.hideModalOverlay();
+    });
+  }).fail(function(xhr) {
+    let msg = 'Failed to load security information';
+    if (xhr.responseJSON && xhr.responseJSON.message) {
+      msg += ': ' + xhr.responseJSON.message;
+    }
+    window.showModalOverlay(msg);
+  });
+}
+
+function setUserActive(userId, username, active) {
+  $.ajax({
+    url: active ? '/api/user/reactivate' : '/api/user/deactivate',
+    method: 'POST',
+    contentType: 'application/json',
+    data: JSON.stringify({ user_id: userId }),
+    success: function() {
+      window.showModalOverlay(`'${username}' has been ${active ? 'reactivated' : 'deactivated'}.`);
+      fillUsersTable();
+    },
+    error: function(xhr) {
+      let msg = `Failed to ${active ? 'reactivate' : 'deactivate'} '${username}'`;
+      if (xhr.responseJSON && xhr.responseJSON.message) {
+        msg += ': ' + xhr.responseJSON.message;
+      }
+      window.showModalOverlay(msg);
+    }
+  });
+}
+
+function unlockUser(userId, username) {
+  $.ajax({
+    url: '/api/security/unlock',
+    method: 'POST',
+    contentType: 'application/json',
+    data: JSON.stringify({ user_id: userId }),
+    success: function() {
+      showSecurityModal();
+    },
+    error: function(xhr) {
+      let msg = `Failed to unlock '${username}'`;
+      if (xhr.responseJSON && xhr.responseJSON.message) {
+        msg += ': ' + xhr.responseJSON.message;
+      }
+      window.showModalOverlay(msg);
+    }
+  });
+}
+
 $(function() {
   fillGroupsTable();
   fillUsersTable();
@@ -89,3 +89,104 @@ window.handleClaimEntity = function({ entityId, entityName, group, reason }) {
         }
     });
 };
+
+// Global function to request moving an owned entity to another group
+window.showTransferModal = function(entityId, entityName, currentOwner, onSubmit) {
+    var user = localStorage.getItem('user');
+    if (!user) {
+        window.showModalOverlay('<div style="min-width:250px;"><h3 style="margin-top:0;">Please login to transfer</h3><div style="text-align:right;"><button id="transfer-login-alert-btn">OK</button></div></div>');
+        $('#transfer-login-alert-btn').on('click', function() {
+            window.hideModalOverlay();
+        });
+        return;
+    }
+
+    $.ajax({
+        url: '/api/group/list-simple',
+        method: 'POST',
+        contentType: 'application/json',
+        data: '{}',
+        success: function(resp) {
+            var groups = (resp && resp.data && resp.data.groups) ? resp.data.groups : [];
+            var groupOptions = groups.filter(function(g) {
+                return g.name !== 'root' && g.name !== currentOwner;
+            }).map(function(g) {
+                return '<option value="' + g.name + '">' + g.name + '</option>';
+            }).join('');
+
+            var modalHtml = `
+                <div style="min-width:300px;">
+                    <h3 style="margin-top:0;">Transfer ${entityName} from ${currentOwner}</h3>
+                    <div style="margin-bottom:1em;">
+                        <label for="transfer-group-select">New owner:</label>
+                        <select id="transfer-group-select" style="width:100%;margin-top:0.5em;">
+                            ${groupOptions}
+                        </select>
+                    </div>
+                    <div style="margin-bottom:1em;">
+                        <label for="transfer-reason-textarea">Reason:</label>
+                        <textarea id="transfer-reason-textarea" rows="2" style="width:100%;margin-top:0.5em;"></textarea>
+                    </div>
+                    <div style="text-align:right;">
+                        <button id="transfer-cancel-btn" style="margin-right:0.5em;">Cancel</button>
+                        <button id="transfer-submit-btn">Submit</button>
+                    </div>
+                </div>
+            `;
+
+            window.showModalOverlay(modalHtml);
+
+            $('#transfer-cancel-btn').on('click', function() {
+                window.hideModalOverlay();
+            });
+
+            $('#transfer-submit-btn').on('click', function() {
+                var targetGroup = $('#transfer-group-select').val();
+                var reason = $('#transfer-reason-textarea').val();
+                if (typeof onSubmit === 'function') {
+                    onSubmit({ entityId, entityName, group: targetGroup, reason });
+                }
+                window.hideModalOverlay();
+            });
+        },
+        error: function() {
+            window.showModalOverlay('Failed to load groups.');
+        }
+    });
+};
+
+window.handleTransferEntity = function({ entityId, entityName, group, reason }) {
+    $.ajax({
+        url: '/api/entity/transfer-ownership',
+        method: 'POST',
+        contentType: 'application/json',
+        data: JSON.stringify({ entity_id: entityId, target_group_name: group, reason: reason }),
+        success: function(resp) {
+            const data = resp.data;
+            var link = data.link_redirect || '#';
+            var host = window.location.origin;
+            var modalHtml = `
+                <div style="min-width:300px;">
+                    <h3 style="margin-top:0;">Created ticket</h3>
+                    <div style="margin-bottom:1em;">
+                        <a href="${host}${link}" target="_blank">${host}${link}</a>
+                    </div>
+                    <div style="text-align:right;">
+                        <button id="transfer-ticket-close-btn">Close</button>
+                    </div>
+                </div>
+            `;
+            window.showModalOverlay(modalHtml);
+            $('#transfer-ticket-close-btn').on('click', function() {
+                window.hideModalOverlay();
+            });
+        },
+        error: function(xhr) {
+            var msg = 'Failed to submit transfer request';
+            if (xhr.responseJSON && xhr.responseJSON.error) {
+                msg += ': ' + xhr.responseJSON.error;
+            }
+            window.showModalOverlay(msg);
+        }
+    });
+};
@@ -0,0 +1,230 @@
+// Grant management for entity owners: list who has explicit permissions, revoke one or many,
+// and set which actions are open by default to users outside the owner group.
+(function() {
+    function escapeHtml(text) {
+        return $('<div>').text(text == null ? '' : String(text)).html();
+    }
+
+    function formatTime(value) {
+        if (!value) return '';
+        var d = new Date(value);
+        if (isNaN(d.getTime()) || d.getFullYear() <= 1) return '';
+        return d.toLocaleString();
+    }
+
+    function errorMessage(xhr, fallback) {
+        if (xhr.responseJSON && xhr.responseJSON.error) {
+            return fallback + ': ' + xhr.responseJSON.error;
+        }
+        return fallback;
+    }
+
+    function grantRows(grants, showEntity) {
+        if (!grants.length) {
+            return '<tr><td colspan="7" style="text-align:center;">No active grants</td></tr>';
+        }
+        return grants.map(function(g) {
+            var approvedBy = (g.approved_by && g.approved_by.length) ? g.approved_by.join(', ') : '-';
+            return '<tr>' +
+                '<td><input type="checkbox" class="grant-select" value="' + escapeHtml(g.id) + '"></td>' +
+                '<td>' + escapeHtml(showEntity ? (g.entity_name || g.entity_id) : (g.username || g.user_id)) + '</td>' +
+                '<td>' + escapeHtml(g.action) + '</td>' +
+                '<td>' + escapeHtml(approvedBy) + (g.approved_at ? '<br><small>' + escapeHtml(formatTime(g.approved_at)) + '</small>' : '') + '</td>' +
+                '<td>' + escapeHtml(formatTime(g.created_at)) + '</td>' +
+                '<td>' + escapeHtml(g.expires_at ? formatTime(g.expires_at) : 'Never') + '</td>' +
+                '<td><a href="javascript:void(0)" class="grant-revoke-one" data-id="' + escapeHtml(g.id) + '">Revoke</a></td>' +
+                '</tr>';
+        }).join('');
+    }
+
+    function revocationList(revocations, showEntity) {
+        if (!revocations || !revocations.length) return '';
+        var items = revocations.map(function(r) {
+            var subject = showEntity ? (r.entity_name || r.entity_id) : (r.username || r.user_id);
+            return '<li>' + escapeHtml(subject) + ' ' + escapeHtml(r.action) +
+                ' revoked by ' + escapeHtml(r.revoked_by) + ' on ' + escapeHtml(formatTime(r.revoked_at)) +
+                (r.reason ? ' (' + escapeHtml(r.reason) + ')' : '') + '</li>';
+        }).join('');
+        return '<h4>Revoked</h4><ul style="max-height:150px;overflow:auto;padding-left:18px;">' + items + '</ul>';
+    }
+
+    function grantsTable(grants, showEntity) {
+        return `
+            <table style="width:100%;border-collapse:collapse;">
+                <thead>
+                    <tr>
+                        <th><input type="checkbox" id="grant-select-all"></th>
+                        <th>${showEntity ? 'Entity' : 'User'}</th>
+                        <th>Permission</th>
+                        <th>Approved by</th>
+                        <th>Granted</th>
+                        <th>Expires</th>
+                        <th></th>
+                    </tr>
+                </thead>
+                <tbody>${grantRows(grants, showEntity)}</tbody>
+            </table>
+            <div style="margin-top:1em;">
+                <input type="text" id="grant-revoke-reason" placeholder="Reason (optional)" style="width:60%;">
+                <button id="grant-revoke-selected">Revoke selected</button>
+            </div>
+        `;
+    }
+
+    // bindRevoke wires the revoke buttons of a rendered grants table, reload is called after a revoke
+    function bindRevoke(reload) {
+        $('#grant-select-all').on('change', function() {
+            $('.grant-select').prop('checked', this.checked);
+        });
+        $('.grant-revoke-one').on('click', function() {
+            revoke([$(this).data('id')], reload);
+        });
+        $('#grant-revoke-selected').on('click', function() {
+            var ids = $('.grant-select:checked').map(function() { return this.value; }).get();
+            if (!ids.length) return;
+            if (!confirm('Revoke ' + ids.length + ' grant(s)?')) return;
+            revoke(ids, reload);
+        });
+        $('#grant-modal-close').on('click', function() {
+            window.hideModalOverlay();
+        });
+    }
+
+    function revoke(ids, reload) {
+        $.ajax({
+            url: '/api/grants/revoke',
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify({ ids: ids, reason: $('#grant-revoke-reason').val() }),
+            success: function() {
+                reload();
+            },
+            error: function(xhr) {
+                window.showModalOverlay(errorMessage(xhr, 'Failed to revoke grants'));
+            }
+        });
+    }
+
+    window.showEntityGrantsModal = function(entityId, entityName) {
+        function load() {
+            $.ajax({
+                url: '/api/grants/entity',
+                method: 'GET',
+                data: { entity_id: entityId },
+                success: function(resp) {
+                    var data = resp.data || {};
+                    window.showModalOverlay(`
+                        <div style="min-width:600px;">
+                            <h3 style="margin-top:0;">Grants of ${escapeHtml(entityName)}</h3>
+                            ${grantsTable(data.grants || [], false)}
+                            ${revocationList(data.revocations, false)}
+                            <div style="text-align:right;"><button id="grant-modal-close">Close</button></div>
+                        </div>
+                    `);
+                    bindRevoke(load);
+                },
+                error: function(xhr) {
+                    window.showModalOverlay(errorMessage(xhr, 'Failed to load grants'));
+                }
+            });
+        }
+        load();
+    };
+
+    var audiences = [
+        { value: 'anonymous', label: 'Anyone' },
+        { value: 'authenticated', label: 'Logged in users' },
+        { value: 'none', label: 'Owners and grants only' }
+    ];
+
+    // showDefaultPermissionsModal edits the defaults of an entity, entityId 'unowned' edits the global default
+    window.showDefaultPermissionsModal = function(entityId) {
+        $.ajax({
+            url: '/api/entity/default-permissions',
+            method: 'GET',
+            data: { entity_id: entityId },
+            success: function(resp) {
+                var data = resp.data || {};
+                var rows = (data.permissions || []).map(function(p) {
+                    var options = audiences.map(function(a) {
+                        return '<option value="' + a.value + '"' + (a.value === p.audience ? ' selected' : '') + '>' + a.label + '</option>';
+                    }).join('');
+                    return '<tr>' +
+                        '<td>' + escapeHtml(p.permission) + '<br><small>' + escapeHtml(p.description) + '</small></td>' +
+                        '<td><select class="default-audience" data-permission="' + escapeHtml(p.permission) + '">' + options + '</select></td>' +
+                        '</tr>';
+                }).join('');
+                window.showModalOverlay(`
+                    <div style="min-width:500px;">
+                        <h3 style="margin-top:0;">Default permissions of ${escapeHtml(data.name)}</h3>
+                        <table style="width:100%;border-collapse:collapse;">
+                            <thead><tr><th>Permission</th><th>Allowed to</th></tr></thead>
+                            <tbody>${rows}</tbody>
+                        </table>
+                        <div style="text-align:right;margin-top:1em;">
+                            <button id="default-permission-save">Save</button>
+                            <button id="grant-modal-close">Close</button>
+                        </div>
+                    </div>
+                `);
+                $('#grant-modal-close').on('click', function() {
+                    window.hideModalOverlay();
+                });
+                $('#default-permission-save').on('click', function() {
+                    var defaults = {};
+                    $('.default-audience').each(function() {
+                        defaults[$(this).data('permission')] = $(this).val();
+                    });
+                    $.ajax({
+                        url: '/api/entity/save-default-permissions',
+                        method: 'POST',
+                        contentType: 'application/json',
+                        data: JSON.stringify({ entity_id: entityId, defaults: defaults }),
+                        success: function() {
+                            window.showModalOverlay('Default permissions saved');
+                        },
+                        error: function(xhr) {
+                            window.showModalOverlay(errorMessage(xhr, 'Failed to save default permissions'));
+                        }
+                    });
+                });
+            },
+            error: function(xhr) {
+                window.showModalOverlay(errorMessage(xhr, 'Failed to load default permissions'));
+            }
+        });
+    };
+
+    window.showUserGrantsModal = function(userId, username) {
+        function load() {
+            $.ajax({
+                url: '/api/grants/user',
+                method: 'GET',
+                data: { user_id: userId },
+                success: function(resp) {
+                    var data = resp.data || {};
+                    var owned = (data.owned || []).map(function(o) {
+                        return '<li>' + escapeHtml(o.entity_name) + ' <small>(' + escapeHtml(o.entity_type) + ', ' +
+                            escapeHtml(o.group_name) + ' ' + escapeHtml(o.role || 'member') + ')</small></li>';
+                    }).join('');
+                    window.showModalOverlay(`
+                        <div style="min-width:600px;">
+                            <h3 style="margin-top:0;">What ${escapeHtml(username || data.username)} can do</h3>
+                            <h4>Owned through groups</h4>
+                            ${owned ? '<ul style="max-height:150px;overflow:auto;padding-left:18px;">' + owned + '</ul>' : '<div>None</div>'}
+                            <h4>Explicit grants</h4>
+                            ${grantsTable(data.grants || [], true)}
+                            ${revocationList(data.revocations, true)}
+                            <div style="text-align:right;"><button id="grant-modal-close">Close</button></div>
+                        </div>
+                    `);
+                    bindRevoke(load);
+                },
+                error: function(xhr) {
+                    window.showModalOverlay(errorMessage(xhr, 'Failed to load grants'));
+                }
+            });
+        }
+        load();
+    };
+})();
@@ -18,16 +18,139 @@ document.getElementById('login-form').addEventListener('submit', async function(
                 window.location.href = data.redirect;
                 return;
             }
+            if (data && data.two_factor) {
+                showTwoFactorStep(data.two_factor);
+                return;
+            }
         } catch (e) {}
         window.location.href = '/';
     } else {
         let errorMsg = 'Login failed';
         try {
             const data = await response.json();
-            if (data && data.error) {
-                errorMsg = data.error;
+            if (data && (data.error || data.message)) {
+                errorMsg = data.error || data.message;
             }
         } catch (e) {}
         errorLabel.textContent = errorMsg;
     }
 });
+
+// the password was right, the login waits for an authenticator code
+let twoFactorChallenge = null;
+
+function showTwoFactorStep(challenge) {
+    twoFactorChallenge = challenge;
+    document.getElementById('login-form').style.display = 'none';
+    document.getElementById('two-factor-form').style.display = '';
+    if (challenge.enroll) {
+        document.getElementById('two-factor-enroll').style.display = '';
+        document.getElementById('two-factor-secret').textContent = challenge.secret;
+        document.getElementById('two-factor-label').textContent = 'Code shown by the app:';
+        const qr = document.getElementById('two-factor-qr');
+        qr.innerHTML = '';
+        if (window.QRCode) {
+            new QRCode(qr, { text: challenge.uri, width: 180, height: 180 });
+        }
+    } else {
+        document.getElementById('two-factor-label').textContent = 'Authentication code (or a recovery code):';
+    }
+    document.getElementById('two-factor-code').focus();
+}
+
+document.getElementById('two-factor-form').addEventListener('submit', async function(event) {
+    event.preventDefault();
+    const code = document.getElementById('two-factor-code').value.trim();
+    const errorLabel = document.getElementById('two-factor-error');
+    errorLabel.textContent = '';
+    const response = await fetch('/api/login/2fa', {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json'
+        },
+        body: JSON.stringify({ challenge: twoFactorChallenge.challenge, code })
+    });
+    let data = null;
+    try {
+        data = await response.json();
+    } catch (e) {}
+    if (!response.ok) {
+        errorLabel.textContent = (data && (data.error || data.message)) || 'Verification failed';
+        if (response.status === 401 && data && data.error && data.error.indexOf('expired') !== -1) {
+            // the challenge is gone, start over with the password
+            setTimeout(() => window.location.reload(), 1500);
+        }
+        return;
+    }
+    if (data && data.recovery_codes) {
+        document.getElementById('two-factor-form').style.display = 'none';
+        document.getElementById('recovery-codes-list').textContent = data.recovery_codes.join('\n');
+        document.getElementById('recovery-codes').style.display = '';
+        return;
+    }
+    window.location.href = '/';
+});
+
+// show single sign-on when a provider is configured, and the error of a failed attempt
+(async function() {
+    const error = new URLSearchParams(window.location.search).get('error');
+    if (error) {
+        document.getElementById('login-error').textContent = error;
+    }
+    // a single sign-on user with an authenticator comes back for the second step
+    const pending = sessionStorage.getItem('two_factor');
+    if (pending) {
+        sessionStorage.removeItem('two_factor');
+        try {
+            showTwoFactorStep(JSON.parse(pending));
+        } catch (e) {}
+    }
+    try {
+        const response = await fetch('/api/auth/providers');
+        const data = await response.json();
+        if (data && data.data && data.data.oidc) {
+            document.getElementById('sso-btn').style.display = '';
+        }
+        if (data && data.data && data.data.forgot_password) {
+            document.getElementById('forgot-password-link').style.display = 'block';
+        }
+    } catch (e) {}
+})();
+
+// forgotten password, the reset link is emailed
+document.getElementById('forgot-password-link').addEventListener('click', function(event) {
+    event.preventDefault();
+    document.getElementById('login-form').style.display = 'none';
+    document.getElementById('forgot-password-form').style.display = '';
+    document.getElementById('forgot-username').value = document.getElementById('username').value;
+    document.getElementById('forgot-password-message').textContent = '';
+});
+
+document.getElementById('forgot-password-back').addEventListener('click', function() {
+    document.getElementById('forgot-password-form').style.display = 'none';
+    document.getElementById('login-form').style.display = '';
+});
+
+document.getElementById('forgot-password-form').addEventListener('submit', async function(event) {
+    event.preventDefault();
+    const message = document.getElementById('forgot-password-message');
+    message.style.color = '';
+    message.textContent = '';
+    const response = await fetch('/api/forgot-password', {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json'
+        },
+        body: JSON.stringify({ username: document.getElementById('forgot-username').value })
+    });
+    let data = null;
+    try {
+        data = await response.json();
+    } catch (e) {}
+    if (!response.ok) {
+        message.style.color = 'red';
+        message.textContent = (data && (data.error || data.message)) || 'Request failed';
+        return;
+    }
+    message.textContent = (data && data.data && data.data.message) || 'Check your email for the reset link.';
+});
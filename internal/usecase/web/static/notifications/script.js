@@ -0,0 +1,194 @@
+$(document).ready(function() {
+    var page = 1;
+    var limit = 10;
+    var hasNext = false;
+
+    var eventLabels = {
+        ticket_created: 'Ticket created',
+        ticket_assigned: 'Ticket assigned to me',
+        ticket_approved: 'Ticket approved',
+        ticket_rejected: 'Ticket rejected',
+        ticket_reminder: 'SLA reminder'
+    };
+
+    function isLogin() {
+        return (window.parent && window.parent.isLogin) ? window.parent.isLogin() : (window.isLogin && window.isLogin());
+    }
+
+    function showError(msg, xhr) {
+        if (xhr && xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+        window.parent.showModalOverlay(msg);
+    }
+
+    function formatDate(value) {
+        var date = new Date(value);
+        return date.toLocaleTimeString([], {hour: '2-digit', minute:'2-digit'}) + ' ' + date.toLocaleDateString('en-GB');
+    }
+
+    function loadNotifications() {
+        var tbody = $('#notifications-tbody');
+        if (!isLogin()) {
+            tbody.empty().append($('<tr>').append(
+                $('<td colspan="4" style="text-align:center;color: var(--error-red);">').text('Please login to see your notifications here')
+            ));
+            $('#notifications-page').text('');
+            $('#mark-all-read').prop('disabled', true);
+            return;
+        }
+        $.ajax({
+            url: '/api/notifications/list',
+            method: 'GET',
+            data: { page: page, limit: limit },
+            success: function(response) {
+                var data = response.data;
+                tbody.empty();
+                hasNext = !!data.has_next;
+                $('#unread-count').text(data.unread > 0 ? data.unread + ' unread' : '').toggle(data.unread > 0);
+                $('#mark-all-read').prop('disabled', data.unread === 0);
+                if (!Array.isArray(data.notifications) || data.notifications.length === 0) {
+                    tbody.append($('<tr>').append($('<td colspan="4" style="text-align:center;">').text('No notifications')));
+                } else {
+                    data.notifications.forEach(function(n) {
+                        var row = $('<tr>').attr('data-id', n.id).attr('data-app-id', n.application_id).toggleClass('unread', !n.read);
+                        row.append($('<td>').text(n.subject));
+                        row.append($('<td>').text(n.body));
+                        row.append($('<td>').addClass('created-at-cell').text(formatDate(n.created_at)));
+                        var action = $('<td>');
+                        if (!n.read) {
+                            action.append($('<button class="mark-read">').text('Mark read'));
+                        }
+                        row.append(action);
+                        tbody.append(row);
+                    });
+                }
+                $('#notifications-page').text('Page ' + page);
+                $('#notifications-prev').prop('disabled', page === 1);
+                $('#notifications-next').prop('disabled', !hasNext);
+            },
+            error: function(xhr) {
+                showError('Failed to load notifications', xhr);
+            }
+        });
+    }
+
+    function markRead(body, done) {
+        $.ajax({
+            url: '/api/notifications/read',
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify(body),
+            success: function() {
+                if (done) done();
+                else loadNotifications();
+            },
+            error: function(xhr) {
+                showError('Failed to mark notifications as read', xhr);
+            }
+        });
+    }
+
+    $('#notifications-tbody').on('click', '.mark-read', function(e) {
+        e.stopPropagation();
+        markRead({ ids: [$(this).closest('tr').data('id')] });
+    });
+
+    // opening a notification marks it as read and goes to its ticket
+    $('#notifications-tbody').on('click', 'tr', function() {
+        var row = $(this);
+        var appId = row.data('app-id');
+        if (!appId) return;
+        var open = function() {
+            window.parent.location.hash = '#ticket-detail?id=' + encodeURIComponent(appId);
+        };
+        if (row.hasClass('unread')) {
+            markRead({ ids: [row.data('id')] }, open);
+        } else {
+            open();
+        }
+    });
+
+    $('#mark-all-read').on('click', function() {
+        markRead({ all: true });
+    });
+
+    $('#notifications-prev').on('click', function() {
+        if (page > 1) {
+            page--;
+            loadNotifications();
+        }
+    });
+
+    $('#notifications-next').on('click', function() {
+        if (hasNext) {
+            page++;
+            loadNotifications();
+        }
+    });
+
+    function loadPreference() {
+        if (!isLogin()) {
+            $('#preference-form').hide();
+            return;
+        }
+        $.ajax({
+            url: '/api/notifications/preference',
+            method: 'GET',
+            success: function(response) {
+                var data = response.data;
+                var pref = data.preference;
+                var head = $('<tr>').append($('<th>').text('Event'));
+                data.channels.forEach(function(channel) {
+                    head.append($('<th class="channel-cell">').text(channel));
+                });
+                $('#preference-thead').empty().append(head);
+
+                var tbody = $('#preference-tbody').empty();
+                data.events.forEach(function(event) {
+                    var selected = (pref.events && pref.events[event]) || [];
+                    var row = $('<tr>').append($('<td>').text(eventLabels[event] || event));
+                    data.channels.forEach(function(channel) {
+                        var checkbox = $('<input type="checkbox">')
+                            .attr('data-event', event)
+                            .attr('data-channel', channel)
+                            .prop('checked', selected.indexOf(channel) !== -1);
+                        row.append($('<td class="channel-cell">').append(checkbox));
+                    });
+                    tbody.append(row);
+                });
+                $('#pref-email').val(pref.email || '');
+                $('#pref-webhook').val(pref.webhook_url || '');
+            },
+            error: function(xhr) {
+                showError('Failed to load notification preferences', xhr);
+            }
+        });
+    }
+
+    $('#preference-form').on('submit', function(e) {
+        e.preventDefault();
+        var events = {};
+        $('#preference-tbody input[type=checkbox]:checked').each(function() {
+            var event = $(this).data('event');
+            (events[event] = events[event] || []).push($(this).data('channel'));
+        });
+        $.ajax({
+            url: '/api/notifications/preference/save',
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify({
+                email: $('#pref-email').val(),
+                webhook_url: $('#pref-webhook').val(),
+                events: events
+            }),
+            success: function(response) {
+                $('#preference-status').text(response.data.message).css('color', 'var(--primary-purple)');
+            },
+            error: function(xhr) {
+                showError('Failed to save notification preferences', xhr);
+            }
+        });
+    });
+
+    loadNotifications();
+    loadPreference();
+});
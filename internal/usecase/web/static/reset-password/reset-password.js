@@ -17,6 +17,12 @@ $(function() {
             if (!username && data.data && data.data.username) {
                 username = data.data.username;
             }
+            if ((data.data || data).invite) {
+                // the link is an invitation, the user chooses a first password
+                $('h1').text('Set Your Password');
+                $('#reset-btn').text('Set Password');
+                document.title = 'Set Your Password';
+            }
             if (data.error) {
                 $usernameLabel.text(data.error);
             } else if (username) {
@@ -57,7 +63,7 @@ $(function() {
             var errorMsg = 'Reset failed';
             try {
                 var data = xhr.responseJSON;
-                if (data && data.error) errorMsg = data.error;
+                if (data && (data.error || data.message)) errorMsg = data.error || data.message;
             } catch (e) {}
             $errorLabel.text(errorMsg);
         });
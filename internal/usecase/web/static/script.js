@@ -77,6 +77,9 @@ $(function() {
     } else if (hash === '#my-topics') {
       $('.menu li a').removeClass('active');
       myTopicsMenu.addClass('active');
+    } else if (hash === '#notifications') {
+      $('.menu li a').removeClass('active');
+      notificationsMenu.addClass('active');
     } 
   }
 
@@ -96,6 +99,14 @@ $(function() {
     mainIframe.attr('src', 'tickets/new/index.html');
   }
 
+  const notificationsMenu = $('.menu li a').filter(function() {
+    return $(this).text().trim() === 'Notifications';
+  });
+
+  function showNotifications() {
+    mainIframe.attr('src', 'notifications/index.html');
+  }
+
   // Add event listener for Tickets menu
   $(window).on('hashchange', handleHashChange);
 
@@ -121,6 +132,8 @@ $(function() {
       showTicketDetail();
     } else if (hash === '#my-topics') {
       showMyTopics();
+    } else if (hash === '#notifications') {
+      showNotifications();
     } else if (hash.startsWith('#topic-detail')) {
       showTopicDetail(hash.split('=')[1]);
     } else {
@@ -150,6 +163,14 @@ $(function() {
     showUserGroup();
   });
 
+  notificationsMenu.on('click', function(e) {
+    e.preventDefault();
+    window.location.hash = '#notifications';
+    $('.menu li a').removeClass('active');
+    $(this).addClass('active');
+    showNotifications();
+  });
+
   allTopicsMenu.on('click', function(e) {
     e.preventDefault();
     window.location.hash = '#all-topics';
@@ -207,9 +228,14 @@ $(function() {
       });
       html += '</tbody></table>';
     }
+    html += '<h3 style="margin-bottom:0.5em;margin-top:1.5em;color:var(--primary-purple);font-size:1.1em;">Two-factor authentication</h3>';
+    html += '<div id="profile-2fa" style="color:#222;">Loading...</div>';
     html += '<div style="text-align:right;margin-top:1.5em;"><button id="close-profile-modal" style="padding:7px 22px;background:var(--accent-purple);color:var(--primary-white);border:none;border-radius:8px;font-size:1em;cursor:pointer;">Close</button></div>';
     html += '</div>';
     window.showModalOverlay(html);
+    if (window.loadTwoFactorSection) {
+      window.loadTwoFactorSection();
+    }
     $(document).off('click', '#close-profile-modal').on('click', '#close-profile-modal', function() {
       window.hideModalOverlay();
     });
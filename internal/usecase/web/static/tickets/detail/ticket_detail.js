@@ -47,6 +47,8 @@ $(function() {
             }
             // Reason
             $('#detail-reason').text(data.ticket.reason || '-');
+            // Grant duration
+            $('#detail-duration').text(data.ticket.grant_duration || 'permanent');
             // Status
             $('#detail-status').text(data.ticket.status || '-');
 
@@ -55,12 +57,32 @@ $(function() {
             $assigneeTbody.empty();
             if (data.assignees && data.assignees.length > 0) {
                 data.assignees.forEach(function(a) {
-                    $assigneeTbody.append('<tr><td>' + a.name + ' (' + a.username + ')</td><td>' + a.status + '</td></tr>');
+                    let name = a.name + ' (' + a.username + ')';
+                    if (a.on_behalf_of) name += ' on behalf of ' + a.on_behalf_of;
+                    const $status = $('<td>').text(a.status);
+                    // root can hand an open review to someone else, e.g. when the reviewer left
+                    if (data.can_reassign && a.status === 'waiting for approval') {
+                        $('<a href="javascript:void(0)" class="assignee-reassign">Reassign</a>')
+                            .on('click', function() { reassign(a.assignment_id); })
+                            .appendTo($status);
+                    }
+                    $assigneeTbody.append($('<tr>').append($('<td>').text(name)).append($status));
                 });
             } else {
                 $assigneeTbody.append('<tr><td colspan="2">-</td></tr>');
             }
 
+            // Approval stages
+            const $approvalTbody = $('#approval-table tbody');
+            $approvalTbody.empty();
+            (data.approval || []).forEach(function(stage) {
+                $approvalTbody.append($('<tr>')
+                    .append($('<td>').text(stage.name))
+                    .append($('<td>').text(stage.reviewer_group || '-'))
+                    .append($('<td>').text(stage.approvals + ' / ' + stage.required_approvals))
+                    .append($('<td>').text(stage.status)));
+            });
+
             // Histories
             const $historyTbody = $('#history-table tbody');
             $historyTbody.empty();
@@ -81,6 +103,9 @@ $(function() {
                 $historyTbody.append('<tr><td colspan="3">-</td></tr>');
             }
 
+            // Comments
+            renderComments(data.comments || [], data.can_comment);
+
             // Action buttons
             const $actionButtons = $('#action-buttons');
             $actionButtons.empty();
@@ -90,22 +115,33 @@ $(function() {
                     const color = action.color || (action.action === 'approve' ? 'green' : action.action === 'reject' ? 'red' : '#888');
                     const btn = $('<button></button>')
                         .addClass('action-btn')
-                        .text(action.action.charAt(0).toUpperCase() + action.action.slice(1))
+                        .text((action.action.charAt(0).toUpperCase() + action.action.slice(1)).replace('_', ' '))
                         .css({
                             'background': color,
                             'color': '#fff',
                             'margin-left': '10px'
                         })
                         .on('click', function() {
+                            if (stateActions[action.action]) {
+                                submitStateAction(action.action);
+                                return;
+                            }
+                            const comment = window.prompt('Optional review comment:', '');
+                            if (comment === null) return;
                             $.ajax({
                                 url: '/api/tickets/action',
                                 method: 'POST',
                                 contentType: 'application/json',
                                 data: JSON.stringify({
                                     action: action.action,
-                                    application_id: ticketId
+                                    application_id: ticketId,
+                                    comment: comment
                                 }),
-                                success: function() {
+                                success: function(resp) {
+                                    // more approvals are needed, show the progress before reloading
+                                    if (resp && resp.data && resp.data.status === 'pending') {
+                                        window.parent.showModalOverlay(resp.data.message);
+                                    }
                                     location.reload();
                                 },
                                 error: function(xhr) {
@@ -122,6 +158,88 @@ $(function() {
         }
     });
 
+    // actions on the ticket conversation, they have their own endpoint and a comment
+    const stateActions = {
+        request_info: { url: '/api/tickets/request-info', prompt: 'What information do you need from the applicant?', required: true },
+        provide_info: { url: '/api/tickets/provide-info', prompt: 'Your answer to the reviewers:', required: true },
+        withdraw: { url: '/api/tickets/withdraw', prompt: 'Withdraw this application? Optionally add a reason:', required: false }
+    };
+
+    function submitStateAction(name) {
+        const cfg = stateActions[name];
+        const comment = window.prompt(cfg.prompt, '');
+        if (comment === null) return;
+        if (cfg.required && !comment.trim()) {
+            window.parent.showModalOverlay('A comment is required');
+            return;
+        }
+        postJSON(cfg.url, { application_id: ticketId, comment: comment });
+    }
+
+    function reassign(assignmentId) {
+        const username = window.prompt('Username of the new reviewer:', '');
+        if (username === null || !username.trim()) return;
+        postJSON('/api/tickets/reassign', {
+            application_id: ticketId,
+            assignment_id: assignmentId,
+            reviewer_username: username.trim()
+        });
+    }
+
+    function postJSON(url, payload) {
+        $.ajax({
+            url: url,
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify(payload),
+            success: function() {
+                location.reload();
+            },
+            error: function(xhr) {
+                window.parent.showModalOverlay('Action failed: ' + (xhr.responseText || xhr.status));
+            }
+        });
+    }
+
+    function renderComments(comments, canComment) {
+        const $list = $('#comment-list');
+        $list.empty();
+        if (comments.length === 0) {
+            $list.append($('<div class="comment-empty">').text('No comments yet'));
+        }
+        comments.forEach(function(c) {
+            $list.append(renderComment(c, canComment));
+        });
+        $('#comment-form').toggle(!!canComment);
+    }
+
+    function renderComment(c, canComment) {
+        const $item = $('<div class="comment">')
+            .append($('<div class="comment-meta">').text(c.author + ' · ' + c.created_at))
+            .append($('<div class="comment-body">').text(c.body));
+        if (canComment) {
+            $('<a href="javascript:void(0)" class="comment-reply">Reply</a>')
+                .on('click', function() {
+                    const body = window.prompt('Reply to ' + c.author + ':', '');
+                    if (body && body.trim()) {
+                        postJSON('/api/tickets/comment', { application_id: ticketId, parent_id: c.id, body: body });
+                    }
+                })
+                .appendTo($item);
+        }
+        const $replies = $('<div class="comment-replies">');
+        (c.replies || []).forEach(function(r) {
+            $replies.append(renderComment(r, canComment));
+        });
+        return $item.append($replies);
+    }
+
+    $('#comment-submit').on('click', function() {
+        const body = $('#comment-body').val();
+        if (!body.trim()) return;
+        postJSON('/api/tickets/comment', { application_id: ticketId, body: body });
+    });
+
     // Back link handler
     $('#back-link').on('click', function(e) {
         e.preventDefault();
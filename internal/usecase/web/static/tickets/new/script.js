@@ -38,7 +38,7 @@ $(function() {
                 const required = field.required ? 'required' : '';
                 const editable = field.editable ? '' : 'readonly';
                 if (field.type === 'text') {
-                    inputHtml = `<input type="text" id="${id}" name="${escapeHtml(field.label)}" value="${escapeHtml(field.default_value)}" ${required} ${editable} class="form-input">`;
+                    inputHtml = `<input type="text" id="${id}" name="${escapeHtml(field.label)}" value="${escapeHtml(field.default_value)}" placeholder="${escapeHtml(field.placeholder || '')}" ${required} ${editable} class="form-input">`;
                 } else if (field.type === 'textarea') {
                     inputHtml = `<textarea id="${id}" name="${escapeHtml(field.label)}" ${required} ${editable} class="form-input">${escapeHtml(field.default_value)}</textarea>`;
                 } else if (field.type === 'label') {
@@ -146,6 +146,8 @@ $(function() {
         const applicationType = params.type || 'topic';
         // Collect reason by label-based id
         const reason = $('#Reason').val() || '';
+        // Optional grant duration (e.g. 2h), empty means permanent
+        const duration = ($('#Duration').val() || '').trim();
         // Collect permissions from checked checkboxes by name
         const permissions = $("input[type='checkbox'][name='permissions']:checked").map(function() {
             return this.value;
@@ -160,7 +162,8 @@ $(function() {
             entity_id: entityId,
             application_type: applicationType,
             reason: reason,
-            permission: permissions
+            permission: permissions,
+            duration: duration
         };
         // POST to backend
         $.ajax({
@@ -129,6 +129,515 @@ $(document).ready(function() {
         });
     }
 
+    // Fetch and display the active grants of the user
+    function loadMyGrants() {
+        var isLogin = (window.parent && window.parent.isLogin) ? window.parent.isLogin() : (window.isLogin && window.isLogin());
+        var tbody = $('#grants-tbody');
+        if (!isLogin) {
+            tbody.empty();
+            tbody.append($('<tr>').append(
+                $('<td colspan="5" style="text-align:center;color: var(--error-red);">').text('Please login to see your grants here')
+            ));
+            return;
+        }
+        $.ajax({
+            url: '/api/grants/user',
+            method: 'GET',
+            success: function(response) {
+                tbody.empty();
+                var grants = response.data.grants;
+                if (!Array.isArray(grants) || grants.length === 0) {
+                    tbody.append($('<tr>').append(
+                        $('<td colspan="5" style="text-align:center;">').text('No grants found')
+                    ));
+                    return;
+                }
+                grants.forEach(function(grant) {
+                    var row = $('<tr>');
+                    row.append($('<td>').text(grant.entity_name || grant.entity_id));
+                    row.append($('<td>').text(grant.action));
+                    row.append($('<td>').text((grant.approved_by || []).join(', ') || '-'));
+                    row.append($('<td>').addClass('created-at-cell').text(formatDateTime(grant.created_at)));
+                    row.append($('<td>').text(grant.expires_at ? formatDateTime(grant.expires_at) : 'Never'));
+                    tbody.append(row);
+                });
+            },
+            error: function() {
+                window.parent.showModalOverlay('Failed to load grants.');
+            }
+        });
+    }
+
+    // Fetch and display the groups of the user, admins can manage the members of their groups
+    function loadMyGroups() {
+        var isLogin = (window.parent && window.parent.isLogin) ? window.parent.isLogin() : (window.isLogin && window.isLogin());
+        var tbody = $('#groups-tbody');
+        if (!isLogin) {
+            tbody.empty();
+            tbody.append($('<tr>').append(
+                $('<td colspan="3" style="text-align:center;color: var(--error-red);">').text('Please login to see your groups here')
+            ));
+            $('#join-group-form').hide();
+            return;
+        }
+        $.ajax({
+            url: '/api/user/get-username',
+            method: 'GET',
+            success: function(response) {
+                tbody.empty();
+                var data = response.data;
+                var groups = data.group_details || [];
+                if (groups.length === 0) {
+                    tbody.append($('<tr>').append(
+                        $('<td colspan="3" style="text-align:center;">').text('You are not a member of any group')
+                    ));
+                }
+                groups.forEach(function(g) {
+                    var row = $('<tr>');
+                    row.append($('<td>').text(g.group_name));
+                    row.append($('<td>').text(g.role || 'member'));
+                    var manage = $('<td>');
+                    if (data.root || g.role === 'admin') {
+                        $('<a href="javascript:void(0)">Members</a>').on('click', function() {
+                            loadGroupMembers(g.group_id);
+                        }).appendTo(manage);
+                    }
+                    row.append(manage);
+                    tbody.append(row);
+                });
+            },
+            error: function() {
+                window.parent.showModalOverlay('Failed to load groups.');
+            }
+        });
+        $.ajax({
+            url: '/api/group/list-simple',
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify({}),
+            success: function(response) {
+                var select = $('#join-group-name');
+                select.empty();
+                (response.data.groups || []).forEach(function(g) {
+                    if (g.name === 'root') return;
+                    select.append($('<option>').val(g.name).text(g.name));
+                });
+            }
+        });
+    }
+
+    function loadGroupMembers(groupId) {
+        // roles are needed to fill the role choices of the members
+        $.ajax({
+            url: '/api/group/roles',
+            method: 'GET',
+            data: { group_id: groupId },
+            success: function(response) {
+                renderGroupRoles(response.data);
+                loadMembers(groupId, response.data.roles || []);
+            },
+            error: function(xhr) {
+                var msg = 'Failed to load roles';
+                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                window.parent.showModalOverlay(msg);
+            }
+        });
+    }
+
+    function loadMembers(groupId, roles) {
+        var roleNames = ['admin', 'member'].concat(roles.map(function(r) { return r.name; }));
+        $.ajax({
+            url: '/api/group/members',
+            method: 'GET',
+            data: { group_id: groupId },
+            success: function(response) {
+                var data = response.data;
+                var tbody = $('#group-members-tbody');
+                tbody.empty();
+                $('#group-members-title').text('Members of ' + data.group_name);
+                data.members.forEach(function(m) {
+                    var row = $('<tr>');
+                    row.append($('<td>').text(m.username));
+                    row.append($('<td>').text(m.name));
+                    var select = $('<select>');
+                    roleNames.forEach(function(name) {
+                        select.append($('<option>').val(name).text(name));
+                    });
+                    select.val(m.role).on('change', function() {
+                        var role = $(this).val();
+                        $.ajax({
+                            url: '/api/group/set-member-role',
+                            method: 'POST',
+                            contentType: 'application/json',
+                            data: JSON.stringify({ group_id: data.group_id, user_id: m.user_id, role: role }),
+                            success: function(resp) {
+                                window.parent.showModalOverlay(resp.data.message);
+                            },
+                            error: function(xhr) {
+                                var msg = 'Failed to change role';
+                                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                                window.parent.showModalOverlay(msg);
+                                loadGroupMembers(data.group_id);
+                            }
+                        });
+                    });
+                    row.append($('<td>').append(select));
+                    var action = $('<td>');
+                    $('<a href="javascript:void(0)">Remove</a>').on('click', function() {
+                        if (!window.confirm('Remove ' + m.username + ' from ' + data.group_name + '?')) return;
+                        $.ajax({
+                            url: '/api/group/remove-member',
+                            method: 'POST',
+                            contentType: 'application/json',
+                            data: JSON.stringify({ group_id: data.group_id, user_id: m.user_id }),
+                            success: function() {
+                                loadGroupMembers(data.group_id);
+                            },
+                            error: function(xhr) {
+                                var msg = 'Failed to remove member';
+                                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                                window.parent.showModalOverlay(msg);
+                            }
+                        });
+                    }).appendTo(action);
+                    row.append(action);
+                    tbody.append(row);
+                });
+                $('#group-members').show();
+            },
+            error: function(xhr) {
+                var msg = 'Failed to load members';
+                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                window.parent.showModalOverlay(msg);
+            }
+        });
+    }
+
+    // Render the custom roles of the group shown in the members panel
+    function renderGroupRoles(data) {
+        var form = $('#group-role-form');
+        form.data('group-id', data.group_id);
+        var perms = $('#group-role-permissions');
+        perms.empty();
+        (data.permissions || []).forEach(function(p) {
+            perms.append($('<label style="margin-right:10px;">').attr('title', p.description).append(
+                $('<input type="checkbox" class="group-role-perm">').val(p.name), ' ' + p.name
+            ));
+        });
+        resetRoleForm();
+
+        var tbody = $('#group-roles-tbody');
+        tbody.empty();
+        var roles = data.roles || [];
+        if (roles.length === 0) {
+            tbody.append($('<tr>').append(
+                $('<td colspan="4" style="text-align:center;">').text('No custom roles, admins can do everything on the group topics, members everything but deleting, emptying and masking')
+            ));
+        }
+        roles.forEach(function(r) {
+            var row = $('<tr>');
+            row.append($('<td>').text(r.name));
+            row.append($('<td>').text(r.permissions.join(', ')));
+            row.append($('<td>').text(r.description));
+            var action = $('<td>');
+            $('<a href="javascript:void(0)">Edit</a>').on('click', function() {
+                $('#group-role-id').val(r.id);
+                $('#group-role-name').val(r.name).prop('disabled', true);
+                $('#group-role-desc').val(r.description);
+                $('.group-role-perm').each(function() {
+                    $(this).prop('checked', r.permissions.indexOf(this.value) >= 0);
+                });
+                $('#group-role-save').text('Save role');
+                $('#group-role-cancel').show();
+            }).appendTo(action);
+            action.append(' ');
+            $('<a href="javascript:void(0)">Delete</a>').on('click', function() {
+                if (!window.confirm('Delete role ' + r.name + '?')) return;
+                $.ajax({
+                    url: '/api/group/delete-role',
+                    method: 'POST',
+                    contentType: 'application/json',
+                    data: JSON.stringify({ group_id: data.group_id, id: r.id }),
+                    success: function() {
+                        loadGroupMembers(data.group_id);
+                    },
+                    error: function(xhr) {
+                        var msg = 'Failed to delete role';
+                        if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                        window.parent.showModalOverlay(msg);
+                    }
+                });
+            }).appendTo(action);
+            row.append(action);
+            tbody.append(row);
+        });
+    }
+
+    function resetRoleForm() {
+        $('#group-role-id').val('');
+        $('#group-role-name').val('').prop('disabled', false);
+        $('#group-role-desc').val('');
+        $('.group-role-perm').prop('checked', false);
+        $('#group-role-save').text('Add role');
+        $('#group-role-cancel').hide();
+    }
+
+    $('#group-role-cancel').on('click', resetRoleForm);
+
+    $('#group-role-form').on('submit', function(e) {
+        e.preventDefault();
+        var groupId = $(this).data('group-id');
+        $.ajax({
+            url: '/api/group/save-role',
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify({
+                group_id: groupId,
+                id: $('#group-role-id').val(),
+                name: $('#group-role-name').val(),
+                description: $('#group-role-desc').val(),
+                permissions: $('.group-role-perm:checked').map(function() { return this.value; }).get()
+            }),
+            success: function() {
+                loadGroupMembers(groupId);
+            },
+            error: function(xhr) {
+                var msg = 'Failed to save role';
+                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                window.parent.showModalOverlay(msg);
+            }
+        });
+    });
+
+    $('#join-group-form').on('submit', function(e) {
+        e.preventDefault();
+        $.ajax({
+            url: '/api/group/apply-membership',
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify({
+                group_name: $('#join-group-name').val(),
+                role: $('#join-group-role').val(),
+                reason: $('#join-group-reason').val()
+            }),
+            success: function(response) {
+                window.parent.location.href = response.data.link_redirect;
+            },
+            error: function(xhr) {
+                var msg = 'Failed to apply';
+                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                window.parent.showModalOverlay(msg);
+            }
+        });
+    });
+
+    // Fetch and display the out-of-office delegation of the user
+    function loadDelegation() {
+        var isLogin = (window.parent && window.parent.isLogin) ? window.parent.isLogin() : (window.isLogin && window.isLogin());
+        if (!isLogin) {
+            $('#delegation-form').hide();
+            $('#delegation-current').text('Please login to set your out of office delegate').css('color', 'var(--error-red)');
+            return;
+        }
+        $.ajax({
+            url: '/api/delegation/my',
+            method: 'GET',
+            success: function(response) {
+                var data = response.data;
+                var d = data.delegation;
+                if (d) {
+                    var state = d.active ? 'Active' : (new Date(d.end_at) < new Date() ? 'Ended' : 'Scheduled');
+                    $('#delegation-current').text(state + ': ' + d.username + ' reviews on your behalf from ' +
+                        formatDateTime(d.start_at) + ' until ' + formatDateTime(d.end_at) + (d.reason ? ' (' + d.reason + ')' : ''));
+                } else {
+                    $('#delegation-current').text('No delegate set');
+                }
+                $('#delegation-clear').toggle(!!d);
+                var covering = data.covering_for || [];
+                $('#delegation-covering').text(covering.length === 0 ? '' : 'You review on behalf of: ' + covering.map(function(c) {
+                    return c.username + ' until ' + formatDateTime(c.end_at);
+                }).join(', '));
+            },
+            error: function() {
+                window.parent.showModalOverlay('Failed to load delegation.');
+            }
+        });
+    }
+
+    function postDelegation(url, payload) {
+        $.ajax({
+            url: url,
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify(payload),
+            success: function(response) {
+                window.parent.showModalOverlay(response.data.message);
+                loadDelegation();
+    searchTickets();
+            },
+            error: function(xhr) {
+                var msg = 'Failed to save delegation';
+                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                window.parent.showModalOverlay(msg);
+            }
+        });
+    }
+
+    $('#delegation-form').on('submit', function(e) {
+        e.preventDefault();
+        var start = $('#delegate-start').val();
+        postDelegation('/api/delegation/set', {
+            delegate_username: $('#delegate-username').val(),
+            start_at: start ? new Date(start).toISOString() : undefined,
+            end_at: new Date($('#delegate-end').val()).toISOString(),
+            reason: $('#delegate-reason').val()
+        });
+    });
+
+    $('#delegation-clear').on('click', function() {
+        postDelegation('/api/delegation/clear', {});
+    });
+
+    // Pagination state for the ticket search
+    var searchPage = 1;
+    var searchLimit = 10;
+    var searchHasNext = false;
+
+    // Search the tickets visible to the user with the filters of the search form
+    function searchTickets() {
+        var tbody = $('#search-tbody');
+        var isLogin = (window.parent && window.parent.isLogin) ? window.parent.isLogin() : (window.isLogin && window.isLogin());
+        if (!isLogin) {
+            tbody.empty();
+            tbody.append($('<tr>').append(
+                $('<td colspan="6" style="text-align:center;color: var(--error-red);">').text('Please login to search tickets')
+            ));
+            $('#search-form').hide();
+            return;
+        }
+        var params = { page: searchPage, limit: searchLimit };
+        var filters = {
+            type: $('#search-type').val(),
+            status: $('#search-status').val(),
+            applicant: $('#search-applicant').val().trim(),
+            entity_id: $('#search-entity').val().trim(),
+            permission: $('#search-permission').val().trim(),
+            from: $('#search-from').val(),
+            to: $('#search-to').val()
+        };
+        $.each(filters, function(key, value) {
+            if (value) params[key] = value;
+        });
+        $.ajax({
+            url: '/api/tickets/search',
+            method: 'GET',
+            data: params,
+            success: function(response) {
+                tbody.empty();
+                var tickets = response.data.tickets;
+                searchHasNext = !!response.data.has_next;
+                if (!Array.isArray(tickets) || tickets.length === 0) {
+                    tbody.append($('<tr>').append(
+                        $('<td colspan="6" style="text-align:center;">').text('No tickets found')
+                    ));
+                } else {
+                    tickets.forEach(function(ticket) {
+                        var row = $('<tr>').attr('data-app-id', ticket.id);
+                        row.append($('<td>').append(
+                            $('<input type="checkbox" class="search-select">').val(ticket.id)
+                        ));
+                        row.append($('<td>').text(ticket.title));
+                        row.append($('<td>').text(ticket.type));
+                        row.append($('<td>').text(ticket.status));
+                        row.append($('<td>').text(ticket.applicant_name || '-'));
+                        row.append($('<td>').addClass('created-at-cell').text(formatDateTime(ticket.created_at)));
+                        tbody.append(row);
+                    });
+                }
+                $('#search-select-all').prop('checked', false);
+                updateBulkButtons();
+                $('#search-page').text('Page ' + searchPage + ' of ' + Math.max(1, Math.ceil(response.data.total / searchLimit)));
+                $('#search-prev').prop('disabled', searchPage === 1);
+                $('#search-next').prop('disabled', !searchHasNext);
+            },
+            error: function(xhr) {
+                var msg = 'Failed to search tickets';
+                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                window.parent.showModalOverlay(msg);
+            }
+        });
+    }
+
+    function selectedTicketIDs() {
+        return $('.search-select:checked').map(function() { return $(this).val(); }).get();
+    }
+
+    function updateBulkButtons() {
+        var none = selectedTicketIDs().length === 0;
+        $('#bulk-approve').prop('disabled', none);
+        $('#bulk-reject').prop('disabled', none);
+    }
+
+    // Approve or reject all the selected tickets with the same comment
+    function bulkAction(action) {
+        var ids = selectedTicketIDs();
+        if (!window.confirm(action.charAt(0).toUpperCase() + action.slice(1) + ' ' + ids.length + ' ticket(s)?')) return;
+        $.ajax({
+            url: '/api/tickets/bulk-action',
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify({ action: action, application_ids: ids, comment: $('#bulk-comment').val() }),
+            success: function(response) {
+                var data = response.data;
+                var msg = data.succeeded + ' succeeded, ' + data.failed + ' failed';
+                (data.results || []).forEach(function(r) {
+                    if (!r.success) msg += '\n' + r.application_id + ': ' + r.error;
+                });
+                window.parent.showModalOverlay(msg);
+                $('#bulk-comment').val('');
+                searchTickets();
+                loadAssignments();
+            },
+            error: function(xhr) {
+                var msg = 'Bulk action failed';
+                if (xhr.responseJSON && xhr.responseJSON.error) msg += ': ' + xhr.responseJSON.error;
+                window.parent.showModalOverlay(msg);
+            }
+        });
+    }
+
+    $('#search-form').on('submit', function(e) {
+        e.preventDefault();
+        searchPage = 1;
+        searchTickets();
+    });
+    $('#search-prev').on('click', function() {
+        if (searchPage > 1) {
+            searchPage--;
+            searchTickets();
+        }
+    });
+    $('#search-next').on('click', function() {
+        if (searchHasNext) {
+            searchPage++;
+            searchTickets();
+        }
+    });
+    $('#search-select-all').on('change', function() {
+        $('.search-select').prop('checked', $(this).is(':checked'));
+        updateBulkButtons();
+    });
+    $('#search-tbody').on('change', '.search-select', updateBulkButtons);
+    $('#bulk-approve').on('click', function() { bulkAction('approve'); });
+    $('#bulk-reject').on('click', function() { bulkAction('reject'); });
+
+    // Format a timestamp as HH:mm DD/MM/YYYY
+    function formatDateTime(value) {
+        var date = new Date(value);
+        return date.toLocaleTimeString([], {hour: '2-digit', minute:'2-digit'}) + ' ' +
+            date.toLocaleDateString('en-GB');
+    }
+
     // Pagination button handlers
     $('#assignments-prev').on('click', function() {
         if (assignmentsPage > 1) {
@@ -159,6 +668,9 @@ $(document).ready(function() {
 
     loadAssignments();
     loadMyApplications();
+    loadMyGrants();
+    loadMyGroups();
+    loadDelegation();
 
     // Make each row clickable for assignments
     $('#assignments-tbody').on('click', 'tr', function() {
@@ -178,5 +690,14 @@ $(document).ready(function() {
         }
     });
 
+    // Make each row of the search result clickable, except the selection checkbox
+    $('#search-tbody').on('click', 'tr', function(e) {
+        if ($(e.target).is('input')) return;
+        var appId = $(this).data('app-id');
+        if (appId) {
+            window.parent.location.hash = '#ticket-detail?id=' + encodeURIComponent(appId);
+        }
+    });
+
     // Add more event handlers as needed
 });
\ No newline at end of file
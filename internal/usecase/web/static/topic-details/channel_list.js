@@ -68,6 +68,48 @@ function updateChannelsTable(topic, hosts) {
                 };
                 nameRow.appendChild(claimLink);
 
+                // Owned channels can be transferred to another group
+                if (channel.group_owner && channel.group_owner !== 'None') {
+                    const transferLink = document.createElement('a');
+                    transferLink.href = 'javascript:void(0)';
+                    transferLink.className = 'transfer-link';
+                    transferLink.style.marginLeft = '10px';
+                    transferLink.style.fontSize = '12px';
+                    transferLink.textContent = 'Transfer';
+                    transferLink.onclick = function(e) {
+                        e.preventDefault();
+                        e.stopPropagation();
+                        window.showTransferModal(channel.id, channel.name, channel.group_owner, window.handleTransferEntity);
+                    };
+                    nameRow.appendChild(transferLink);
+
+                    const grantsLink = document.createElement('a');
+                    grantsLink.href = 'javascript:void(0)';
+                    grantsLink.className = 'grants-link';
+                    grantsLink.style.marginLeft = '10px';
+                    grantsLink.style.fontSize = '12px';
+                    grantsLink.textContent = 'Grants';
+                    grantsLink.onclick = function(e) {
+                        e.preventDefault();
+                        e.stopPropagation();
+                        window.showEntityGrantsModal(channel.id, channel.name);
+                    };
+                    nameRow.appendChild(grantsLink);
+
+                    const defaultsLink = document.createElement('a');
+                    defaultsLink.href = 'javascript:void(0)';
+                    defaultsLink.className = 'defaults-link';
+                    defaultsLink.style.marginLeft = '10px';
+                    defaultsLink.style.fontSize = '12px';
+                    defaultsLink.textContent = 'Defaults';
+                    defaultsLink.onclick = function(e) {
+                        e.preventDefault();
+                        e.stopPropagation();
+                        window.showDefaultPermissionsModal(channel.id);
+                    };
+                    nameRow.appendChild(defaultsLink);
+                }
+
                 nameWrapper.appendChild(nameRow);
 
                 if (channel.group_owner) {
@@ -112,8 +112,10 @@ function initTailPanel({getCurrentTopicDetail, adjustPanelWidths}) {
         hosts.forEach(function(h) { params += `&nsqd_hosts=${h}`; });
         var wsProto = window.location.protocol === 'https:' ? 'wss://' : 'ws://';
         var wsUrl = wsProto + window.location.host + '/api/topic/tail?' + params + '&entity_id=' + encodeURIComponent(currentTopicDetail.id);
+        var opened = false;
         tailSocket = new WebSocket(wsUrl);
         tailSocket.onopen = function() {
+            opened = true;
             $tailStatus.text('Connected. Waiting for messages...').css('color', '#888');
         };
         tailSocket.onmessage = function(event) {
@@ -122,65 +124,113 @@ function initTailPanel({getCurrentTopicDetail, adjustPanelWidths}) {
             var parts = event.data.split(RS);
             parts.forEach(function(part) {
                 if (part.trim()) {
-                    try {
-                        var obj = JSON.parse(part);
-                        var timestamp = '<span class="tail-timestamp">[' + obj.timestamp + ']</span>';
-                        var body = '<span class="tail-body">' + escapeHtml(obj.payload) + '</span>';
-                        var prettyBtn = '<span class="tail-pretty-btn" title="Pretty print JSON" style="cursor:pointer;user-select:none;margin-left:8px;font-size:1.1em;">✨</span>';
-                        var copyBtn = '<span class="tail-copy-btn" title="Copy to clipboard" style="cursor:pointer;user-select:none;margin-left:8px;font-size:1.1em;">📄</span>';
-                        var msgHtml = '<div class="tail-msg">' + timestamp + body + copyBtn + prettyBtn + '</div>';
-
-                        $tailContent.prepend(msgHtml);
-
-                        var $msg = $tailContent.find('.tail-msg').first();
-                        $msg.find('.tail-copy-btn').off('click').on('click', function() {
-                            navigator.clipboard.writeText(obj.payload);
-                            var $btn = $(this);
-                            if ($btn.next('.tail-copied-label').length === 0) {
-                                var $label = $('<span class="tail-copied-label" style="margin-left:4px;color:#2ecc40;font-size:0.98em;">Copied!</span>');
-                                $btn.after($label);
-                                setTimeout(function() { $label.fadeOut(200, function() { $label.remove(); }); }, 1200);
-                            }
-                        });
-                        $msg.find('.tail-pretty-btn').off('click').on('click', function() {
-                            var $body = $msg.find('.tail-body');
-                            var raw = $body.data('raw');
-                            if (raw === undefined) {
-                                raw = $body.text();
-                                $body.data('raw', raw);
-                            }
-                            if ($body.data('pretty')) {
-                                $body.text(raw);
-                                $body.data('pretty', false);
-                            } else {
-                                try {
-                                    var parsed = JSON.parse(raw);
-                                    var pretty = JSON.stringify(parsed, null, 2);
-                                    $body.html('<pre style="margin:0;font-family:monospace;font-size:0.98em;">' + escapeHtml(pretty) + '</pre>');
-                                    $body.data('pretty', true);
-                                } catch (e) {
-                                    $body.text(raw);
-                                    $body.data('pretty', false);
-                                }
-                            }
-                        });
-
-                    } catch (e) {
-                        console.error(e);
-                        $tailContent.append('<div class="tail-msg tail-msg-error">' + escapeHtml(part) + '</div>');
-                    }
+                    renderTailPart(part);
                 }
             });
         };
         tailSocket.onerror = function() {
+            if (!opened) {
+                // websocket is blocked (e.g. by a proxy), fall back to server-sent events
+                tailSocket = null;
+                startTailEventSource('/api/topic/tail/sse?' + params + '&entity_id=' + encodeURIComponent(currentTopicDetail.id));
+                return;
+            }
             $tailStatus.text('WebSocket error').css('color', 'red');
             setTailingActive(false);
         };
         tailSocket.onclose = function() {
+            if (!opened) return;
             $tailStatus.text('Connection closed').css('color', '#888');
             setTailingActive(false);
         };
     });
+
+    function startTailEventSource(url) {
+        $tailStatus.text('Connecting (SSE)...').css('color', '#888');
+        var source = new EventSource(url);
+        tailSocket = source;
+        source.onopen = function() {
+            $tailStatus.text('Connected. Waiting for messages...').css('color', '#888');
+        };
+        source.addEventListener('message', function(event) {
+            renderTailPart(event.data);
+        });
+        source.addEventListener('end', function() {
+            source.close();
+            $tailStatus.text('Connection closed').css('color', '#888');
+            setTailingActive(false);
+        });
+        source.addEventListener('error', function(event) {
+            source.close();
+            var msg = 'Stream error';
+            if (event.data) {
+                try { msg += ': ' + JSON.parse(event.data).error; } catch (e) {}
+            }
+            $tailStatus.text(msg).css('color', 'red');
+            setTailingActive(false);
+        });
+    }
+
+    function renderTailPart(part) {
+        try {
+            var obj = JSON.parse(part);
+            var timestamp = '<span class="tail-timestamp">[' + obj.timestamp + ']</span>';
+            var body = '<span class="tail-body">' + escapeHtml(obj.payload) + '</span>';
+            // binary payloads arrive as hex/base64, label them so they are not mistaken for text
+            if (obj.encoding && obj.encoding !== 'text' && obj.encoding !== 'json') {
+                body = '<span class="tail-encoding">[' + escapeHtml(obj.encoding) + ']</span> ' + body;
+            }
+            if (obj.masked) {
+                body += ' <span class="tail-masked" title="Sensitive fields are masked">🔒</span>';
+            }
+            if (obj.decode_error) {
+                body += ' <span class="tail-decode-error" style="color:#e67e22;" title="' + escapeHtml(obj.decode_error) + '">⚠</span>';
+            }
+            var prettyBtn = '<span class="tail-pretty-btn" title="Pretty print JSON" style="cursor:pointer;user-select:none;margin-left:8px;font-size:1.1em;">✨</span>';
+            var copyBtn = '<span class="tail-copy-btn" title="Copy to clipboard" style="cursor:pointer;user-select:none;margin-left:8px;font-size:1.1em;">📄</span>';
+            var msgHtml = '<div class="tail-msg">' + timestamp + body + copyBtn + prettyBtn + '</div>';
+
+            $tailContent.prepend(msgHtml);
+
+            var $msg = $tailContent.find('.tail-msg').first();
+            $msg.find('.tail-copy-btn').off('click').on('click', function() {
+                navigator.clipboard.writeText(obj.payload);
+                var $btn = $(this);
+                if ($btn.next('.tail-copied-label').length === 0) {
+                    var $label = $('<span class="tail-copied-label" style="margin-left:4px;color:#2ecc40;font-size:0.98em;">Copied!</span>');
+                    $btn.after($label);
+                    setTimeout(function() { $label.fadeOut(200, function() { $label.remove(); }); }, 1200);
+                }
+            });
+            $msg.find('.tail-pretty-btn').off('click').on('click', function() {
+                var $body = $msg.find('.tail-body');
+                var raw = $body.data('raw');
+                if (raw === undefined) {
+                    raw = $body.text();
+                    $body.data('raw', raw);
+                }
+                if ($body.data('pretty')) {
+                    $body.text(raw);
+                    $body.data('pretty', false);
+                } else {
+                    try {
+                        var parsed = JSON.parse(raw);
+                        var pretty = JSON.stringify(parsed, null, 2);
+                        $body.html('<pre style="margin:0;font-family:monospace;font-size:0.98em;">' + escapeHtml(pretty) + '</pre>');
+                        $body.data('pretty', true);
+                    } catch (e) {
+                        $body.text(raw);
+                        $body.data('pretty', false);
+                    }
+                }
+            });
+
+        } catch (e) {
+            console.error(e);
+            $tailContent.append('<div class="tail-msg tail-msg-error">' + escapeHtml(part) + '</div>');
+        }
+    }
+
     $tailStopBtn.on('click', function() {
         if (tailSocket) {
             tailSocket.close();
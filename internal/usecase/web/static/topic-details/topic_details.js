@@ -202,6 +202,24 @@ $(function() {
             window.showClaimModal(detail.id, detail.name, window.handleClaimEntity);
         });
 
+        // Transfer link, only for owned topics
+        $('.transfer-link').toggle(detail.group_owner && detail.group_owner !== 'None');
+        $('.transfer-link').off('click').on('click', function() {
+            window.showTransferModal(detail.id, detail.name, detail.group_owner, window.handleTransferEntity);
+        });
+
+        // Grants link, the api only answers to the owner group
+        $('.grants-link').toggle(detail.group_owner && detail.group_owner !== 'None');
+        $('.grants-link').off('click').on('click', function() {
+            window.showEntityGrantsModal(detail.id, detail.name);
+        });
+
+        // Defaults link, the api only answers to the owner group
+        $('.defaults-link').toggle(detail.group_owner && detail.group_owner !== 'None');
+        $('.defaults-link').off('click').on('click', function() {
+            window.showDefaultPermissionsModal(detail.id);
+        });
+
         // --- Pause button logic ---
         $('.btn-pause').off('click').on('click', function() {
             if (!currentTopicDetail) return;
@@ -604,6 +622,7 @@ $(function() {
         var payload = {
             topic: currentTopicDetail.name,
             message: message,
+            encoding: $('#publish-encoding').val(),
             nsqd_hosts: (currentTopicDetail.nsqd_hosts || []).map(function(host) {
                 if (typeof host === 'object' && host.address) {
                     return host.address;
@@ -630,6 +649,44 @@ $(function() {
         });
     });
 
+    // Preview shows the message the way tail renders it with the topic decoder
+    $('#publish-preview-btn').on('click', function() {
+        var message = $('#publish-textarea').val();
+        var $status = $('#publish-status');
+        var $preview = $('#publish-preview');
+        $status.text('').css('color', '');
+        $preview.hide().text('');
+        if (!message || !currentTopicDetail) {
+            $status.text('Message cannot be empty').css('color', 'red');
+            return;
+        }
+        $.ajax({
+            url: '/api/topic/publish/preview?entity_id=' + currentTopicDetail.id,
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify({
+                entity_id: currentTopicDetail.id,
+                message: message,
+                encoding: $('#publish-encoding').val()
+            }),
+            success: function(resp) {
+                var decoded = resp.data || {};
+                var text = '[' + decoded.encoding + '] ' + decoded.payload;
+                if (decoded.decode_error) {
+                    text += '\n(decode error: ' + decoded.decode_error + ')';
+                }
+                $preview.text(text).show();
+            },
+            error: function(xhr) {
+                var msg = 'Failed to preview';
+                if (xhr.responseJSON && xhr.responseJSON.error) {
+                    msg += ': ' + xhr.responseJSON.error;
+                }
+                $status.text(msg).css('color', 'red');
+            }
+        });
+    });
+
     // --- Tail panel logic moved to tail_msg.js ---
     if (window.initTailPanel) {
         window.initTailPanel({
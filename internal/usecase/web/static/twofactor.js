@@ -0,0 +1,114 @@
+// Two-factor authentication settings of the logged in user, shown in the profile modal:
+// set up an authenticator app, replace the recovery codes or turn 2FA off.
+(function() {
+    function escapeHtml(text) {
+        return $('<div>').text(text == null ? '' : String(text)).html();
+    }
+
+    function errorMessage(xhr, fallback) {
+        if (xhr.responseJSON && xhr.responseJSON.message) {
+            return fallback + ': ' + xhr.responseJSON.message;
+        }
+        return fallback;
+    }
+
+    function post(url, body, onSuccess, fallback) {
+        var $section = $('#profile-2fa');
+        $section.find('.twofactor-error').text('');
+        $.ajax({
+            url: url,
+            method: 'POST',
+            contentType: 'application/json',
+            data: JSON.stringify(body || {}),
+            success: function(resp) {
+                onSuccess(resp.data || {});
+            },
+            error: function(xhr) {
+                $section.find('.twofactor-error').text(errorMessage(xhr, fallback));
+            }
+        });
+    }
+
+    var buttonStyle = 'padding:5px 14px;margin-right:6px;background:var(--accent-purple);color:var(--primary-white);border:none;border-radius:8px;cursor:pointer;';
+    var codeInput = '<input type="text" class="twofactor-code" placeholder="Code" autocomplete="one-time-code" style="width:110px;padding:5px 8px;margin-right:6px;">';
+    var errorLine = '<div class="twofactor-error" style="color:var(--error-red);margin-top:6px;"></div>';
+
+    function showRecoveryCodes(codes) {
+        $('#profile-2fa').html(
+            '<div>Save these recovery codes somewhere safe. Each one signs you in once if you lose your authenticator, they are not shown again.</div>' +
+            '<pre style="background:var(--input-bg);padding:8px 12px;border-radius:8px;">' + escapeHtml(codes.join('\n')) + '</pre>' +
+            '<button id="twofactor-done" style="' + buttonStyle + '">Done</button>'
+        );
+    }
+
+    function showSetup(setup) {
+        $('#profile-2fa').html(
+            '<div>Scan the QR code with an authenticator app, or enter the key manually, then type the code it shows.</div>' +
+            '<div id="twofactor-qr" style="margin:10px 0;"></div>' +
+            '<code style="display:block;word-break:break-all;margin-bottom:10px;">' + escapeHtml(setup.secret) + '</code>' +
+            codeInput + '<button id="twofactor-enable" style="' + buttonStyle + '">Enable</button>' + errorLine
+        );
+        if (window.QRCode) {
+            new QRCode(document.getElementById('twofactor-qr'), { text: setup.uri, width: 160, height: 160 });
+        }
+    }
+
+    function render(status) {
+        var html = '';
+        if (status.enabled) {
+            html += '<div style="margin-bottom:8px;">Enabled, ' + status.recovery_codes_left + ' recovery codes left.</div>';
+            html += codeInput;
+            html += '<button id="twofactor-recovery" style="' + buttonStyle + '">New recovery codes</button>';
+            if (status.required) {
+                html += '<div style="margin-top:6px;"><small>Required for your account, it can\'t be turned off.</small></div>';
+            } else {
+                html += '<button id="twofactor-disable" style="' + buttonStyle + '">Disable</button>';
+            }
+            html += errorLine;
+        } else {
+            html += '<div style="margin-bottom:8px;">Not enabled.' +
+                (status.required ? ' It is required for your account, you will set it up on your next login.' : '') + '</div>';
+            html += '<button id="twofactor-setup" style="' + buttonStyle + '">Set up</button>' + errorLine;
+        }
+        $('#profile-2fa').html(html);
+    }
+
+    function load() {
+        $.ajax({
+            url: '/api/user/2fa/status',
+            method: 'GET',
+            success: function(resp) {
+                render(resp.data || {});
+            },
+            error: function(xhr) {
+                $('#profile-2fa').text(errorMessage(xhr, 'Failed to load two-factor settings'));
+            }
+        });
+    }
+
+    function code() {
+        return $('#profile-2fa .twofactor-code').val().trim();
+    }
+
+    $(document).on('click', '#twofactor-setup', function() {
+        post('/api/user/2fa/setup', {}, showSetup, 'Failed to set up');
+    });
+    $(document).on('click', '#twofactor-enable', function() {
+        post('/api/user/2fa/enable', { code: code() }, function(data) {
+            showRecoveryCodes(data.recovery_codes || []);
+        }, 'Failed to enable');
+    });
+    $(document).on('click', '#twofactor-recovery', function() {
+        post('/api/user/2fa/recovery-codes', { code: code() }, function(data) {
+            showRecoveryCodes(data.recovery_codes || []);
+        }, 'Failed to create recovery codes');
+    });
+    $(document).on('click', '#twofactor-disable', function() {
+        if (!confirm('Turn off two-factor authentication?')) return;
+        post('/api/user/2fa/disable', { code: code() }, load, 'Failed to disable');
+    });
+    $(document).on('click', '#twofactor-done', load);
+
+    // loadTwoFactorSection fills the #profile-2fa element of the profile modal
+    window.loadTwoFactorSection = load;
+})();
@@ -8,31 +8,96 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/tidwall/buntdb"
 
 	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/logic/notification"
 	"github.com/jekiapp/topic-master/internal/repository"
+	pkgdb "github.com/jekiapp/topic-master/pkg/db"
 )
 
-const dataFilename = "topic-master.db"
+const (
+	dataFilename    = "topic-master.db"
+	backupDirname   = "backup"
+	smtpPasswordEnv = "TOPIC_MASTER_SMTP_PASSWORD"
+	ldapPasswordEnv = "TOPIC_MASTER_LDAP_BIND_PASSWORD"
+	oidcSecretEnv   = "TOPIC_MASTER_OIDC_CLIENT_SECRET"
+)
 
 func main() {
+	// topic-master backup|export|import|restore [flags], the server starts otherwise
+	if isCommand(os.Args[1:]) {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+
 	dataPath := flag.String("data_path", "", "Path to topic-master data directory(required)")
 	nsqlookupdHTTPAddr := flag.String("nsqlookupd_http_address", "", "NSQLookupd HTTP address (required)")
 	skipSync := flag.Bool("skip_sync", false, "Skip sync topics")
 	port := flag.String("port", "4181", "Port to listen on")
+	tailAllowedOrigins := flag.String("tail_allowed_origins", "", "Comma separated list of extra origins allowed to open the tail websocket (same origin is always allowed)")
+	smtpAddr := flag.String("smtp_addr", "", "SMTP server host:port used to send email notifications, email is disabled when empty")
+	smtpUsername := flag.String("smtp_username", "", "SMTP username, the password is read from "+smtpPasswordEnv)
+	smtpFrom := flag.String("smtp_from", "", "Sender address of email notifications")
+	mailDir := flag.String("mail_dir", "", "Directory where emails are written as .eml files when -smtp_addr is empty, for development")
+	inviteTTL := flag.Duration("invite_ttl", 72*time.Hour, "How long the invitation link of a new user stays valid")
+	resetTokenTTL := flag.Duration("reset_token_ttl", time.Hour, "How long a password reset link stays valid")
+	publicURL := flag.String("public_url", "", "Public URL of topic-master, used for the ticket links in notifications")
+	ldapURL := flag.String("ldap_url", "", "LDAP server url (ldap://host:389 or ldaps://host:636) used to log in, LDAP is disabled when empty")
+	ldapStartTLS := flag.Bool("ldap_start_tls", false, "Upgrade the ldap:// connection with StartTLS before binding")
+	ldapBindDN := flag.String("ldap_bind_dn", "", "DN of the account searching the users, the password is read from "+ldapPasswordEnv+", anonymous search when empty")
+	ldapBaseDN := flag.String("ldap_base_dn", "", "Base DN of the user search")
+	ldapUserFilter := flag.String("ldap_user_filter", "(uid=%s)", "Filter finding a user, %s is replaced by the username")
+	ldapNameAttr := flag.String("ldap_name_attr", "cn", "Attribute holding the display name of a user")
+	ldapGroupAttr := flag.String("ldap_group_attr", "memberOf", "Attribute listing the group DNs of a user")
+	ldapGroupMapping := flag.String("ldap_group_mapping", "", "Semicolon separated list of <group DN>=><topic-master group>, memberships of the mapped groups follow the directory")
+	oidcIssuerURL := flag.String("oidc_issuer_url", "", "OpenID Connect issuer url used for single sign-on, SSO is disabled when empty")
+	oidcClientID := flag.String("oidc_client_id", "", "OpenID Connect client id, the client secret is read from "+oidcSecretEnv)
+	oidcRedirectURL := flag.String("oidc_redirect_url", "", "Callback url registered at the provider, <public_url>/api/auth/oidc/callback when empty")
+	oidcScopes := flag.String("oidc_scopes", "profile,email", "Comma separated scopes requested besides openid")
+	oidcUsernameClaim := flag.String("oidc_username_claim", "preferred_username", "ID token claim holding the username")
+	oidcGroupsClaim := flag.String("oidc_groups_claim", "groups", "ID token claim listing the groups of the user")
+	oidcGroupMapping := flag.String("oidc_group_mapping", "", "Semicolon separated list of <claim group>=><topic-master group>, memberships of the mapped groups follow the provider")
+	require2FA := flag.String("require_2fa", "", "Make TOTP two-factor authentication mandatory on password logins: root (members of the root group) or admins (root members and group admins), optional when empty")
+	authRateLimit := flag.Int("auth_rate_limit", 20, "Requests per window and client IP or username allowed on login, signup and password reset, 0 disables it")
+	publicRateLimit := flag.Int("public_rate_limit", 300, "Requests per window and client IP allowed on the publish and tail endpoints, 0 disables it")
+	rateLimitWindow := flag.Duration("rate_limit_window", time.Minute, "Window of the rate limits, a client going over is blocked for a window, doubled on every repeat")
+	rateLimitMaxBackoff := flag.Duration("rate_limit_max_backoff", time.Hour, "Longest block of a client going over a rate limit")
+	lockoutThreshold := flag.Int("lockout_threshold", 10, "Failed logins in a row locking an account, 0 disables the lockout")
+	lockoutDuration := flag.Duration("lockout_duration", 15*time.Minute, "How long an account stays locked, root can unlock it earlier")
+	inactivityDays := flag.Int("inactivity_days", 0, "Deactivate the users without login or activity for that many days, root members excepted, 0 disables it")
+	trustForwardedFor := flag.Bool("trust_forwarded_for", false, "Take the client IP from X-Forwarded-For, only behind a proxy setting it")
+	backupInterval := flag.Duration("backup_interval", 0, "Take a snapshot of the data to <data_path>/"+backupDirname+" every interval, disabled when 0")
+	backupRetention := flag.Int("backup_retention", 7, "Snapshots kept in <data_path>/"+backupDirname+", the older ones are deleted after each scheduled snapshot, 0 keeps all")
+	showMigrations := flag.Bool("show_migrations", false, "Print the applied and pending data migrations and exit without applying them")
 	flag.Parse()
 	if *dataPath == "" {
 		fmt.Println("-data_path is required")
 		os.Exit(1)
 	}
 
+	// held while the server runs, restore refuses to replace the data file under it
+	lock, err := pkgdb.LockDataFile(filepath.Join(*dataPath, dataFilename))
+	if err != nil {
+		log.Fatalf("failed to lock the data file, is another topic-master running on %s? %v", *dataPath, err)
+	}
+	defer lock.Unlock()
+
 	db, err := buntdb.Open(filepath.Join(*dataPath, dataFilename))
 	if err != nil {
 		log.Fatalf("failed to open data directory: %v", err)
 	}
 	defer db.Close()
+
+	if *showMigrations {
+		if err := printMigrations(db); err != nil {
+			log.Fatalf("failed to list migrations: %v", err)
+		}
+		return
+	}
+
 	cfg, err := config.NewConfig(db)
 	if err != nil {
 		if *nsqlookupdHTTPAddr == "" {
@@ -51,14 +116,74 @@ func main() {
 		log.Fatalf("either provide new data path or remove %s to reset the data", *dataPath)
 	}
 
-	// make sure indexes are created before checking and setting up root
-	repository.Init(cfg, db)
+	cfg.TailAllowedOrigins = splitFlagList(*tailAllowedOrigins)
+	cfg.SMTP = config.SMTPConfig{
+		Addr:     *smtpAddr,
+		Username: *smtpUsername,
+		Password: os.Getenv(smtpPasswordEnv),
+		From:     *smtpFrom,
+	}
+	cfg.MailDir = *mailDir
+	cfg.InviteTTL = *inviteTTL
+	cfg.ResetTokenTTL = *resetTokenTTL
+	cfg.PublicURL = strings.TrimSuffix(*publicURL, "/")
+	cfg.LDAP = config.LDAPConfig{
+		URL:          *ldapURL,
+		StartTLS:     *ldapStartTLS,
+		BindDN:       *ldapBindDN,
+		BindPassword: os.Getenv(ldapPasswordEnv),
+		BaseDN:       *ldapBaseDN,
+		UserFilter:   *ldapUserFilter,
+		NameAttr:     *ldapNameAttr,
+		GroupAttr:    *ldapGroupAttr,
+		GroupMapping: parseGroupMapping(*ldapGroupMapping),
+	}
+	cfg.OIDC = config.OIDCConfig{
+		IssuerURL:     *oidcIssuerURL,
+		ClientID:      *oidcClientID,
+		ClientSecret:  os.Getenv(oidcSecretEnv),
+		RedirectURL:   *oidcRedirectURL,
+		Scopes:        splitFlagList(*oidcScopes),
+		UsernameClaim: *oidcUsernameClaim,
+		GroupsClaim:   *oidcGroupsClaim,
+		GroupMapping:  parseGroupMapping(*oidcGroupMapping),
+	}
+	if cfg.OIDC.RedirectURL == "" {
+		cfg.OIDC.RedirectURL = cfg.PublicURL + "/api/auth/oidc/callback"
+	}
+	if cfg.OIDC.IssuerURL != "" && cfg.PublicURL == "" && *oidcRedirectURL == "" {
+		log.Fatalf("-oidc_issuer_url needs -public_url or -oidc_redirect_url for the callback")
+	}
+
+	switch *require2FA {
+	case "", config.Require2FARoot, config.Require2FAAdmins:
+		cfg.Require2FA = *require2FA
+	default:
+		log.Fatalf("-require_2fa must be %s or %s", config.Require2FARoot, config.Require2FAAdmins)
+	}
+
+	cfg.AuthRateLimit = config.RateLimitConfig{Requests: *authRateLimit, Window: *rateLimitWindow, MaxBackoff: *rateLimitMaxBackoff}
+	cfg.PublicRateLimit = config.RateLimitConfig{Requests: *publicRateLimit, Window: *rateLimitWindow, MaxBackoff: *rateLimitMaxBackoff}
+	cfg.Lockout = config.LockoutConfig{Threshold: *lockoutThreshold, Duration: *lockoutDuration}
+	cfg.TrustForwardedFor = *trustForwardedFor
+	cfg.InactivityDays = *inactivityDays
+	cfg.BackupDir = filepath.Join(*dataPath, backupDirname)
+	cfg.BackupInterval = *backupInterval
+	cfg.BackupRetention = *backupRetention
+
+	// make sure indexes are created and the data migrated before checking and setting up root
+	if err := repository.Init(cfg, db); err != nil {
+		log.Fatalf("failed to initialize the data: %v", err)
+	}
 
 	err = config.CheckAndSetupRoot(db)
 	if err != nil {
 		log.Fatalf("failed to check and setup root: %v", err)
 	}
 
+	// ticket events are delivered in the background, publishing never blocks a request
+	notification.Init(cfg, db)
+
 	mux := http.NewServeMux()
 	handler := initHandler(db, cfg)
 	handler.routes(mux)
@@ -71,9 +196,70 @@ func main() {
 		}
 	}
 
+	// time-bound grants are ignored once expired, the janitor removes them from the db
+	go handler.expiredGrantJanitor.Run(context.Background(), time.Minute)
+	// reset password and invitation links are refused once expired, the janitor removes them from the db
+	go handler.expiredTokenJanitor.Run(context.Background(), time.Minute)
+	// reminds, escalates and auto rejects tickets according to the SLA of their application type
+	go handler.ticketSLAJanitor.Run(context.Background(), time.Minute)
+	// deactivates inactive users when -inactivity_days is set, and cleans up after deleted users
+	go handler.userLifecycleJanitor.Run(context.Background(), time.Hour)
+	// online snapshots of the data when -backup_interval is set, the oldest beyond -backup_retention are deleted
+	if cfg.BackupInterval > 0 {
+		go handler.backupJanitor.Run(context.Background(), cfg.BackupInterval)
+	}
+
 	// Start the server
 	fmt.Printf("topic-master is running on port %s...\n", *port)
 	if err := http.ListenAndServe(":"+*port, mux); err != nil {
 		fmt.Println("Error starting server:", err)
 	}
 }
+
+// printMigrations prints the data migrations applied to db and the ones the next start applies.
+func printMigrations(db *buntdb.DB) error {
+	applied, err := pkgdb.AppliedMigrations(db)
+	if err != nil {
+		return err
+	}
+	pending, err := repository.PendingMigrations(db)
+	if err != nil {
+		return err
+	}
+	for _, migration := range applied {
+		fmt.Printf("applied  %4d  %s  %s\n", migration.Version, migration.AppliedAt.Format(time.RFC3339), migration.Description)
+	}
+	for _, migration := range pending {
+		fmt.Printf("pending  %4d  %s\n", migration.Version, migration.Description)
+	}
+	if len(pending) == 0 {
+		fmt.Println("the data is up to date")
+	}
+	return nil
+}
+
+// splitFlagList splits a comma separated flag value, dropping empty items.
+func splitFlagList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseGroupMapping parses "<external group>=><group name>;..." of the ldap and oidc group mapping flags.
+func parseGroupMapping(value string) map[string]string {
+	mapping := map[string]string{}
+	for _, item := range strings.Split(value, ";") {
+		dn, name, ok := strings.Cut(item, "=>")
+		dn, name = strings.TrimSpace(dn), strings.TrimSpace(name)
+		if !ok || dn == "" || name == "" {
+			continue
+		}
+		mapping[dn] = name
+	}
+	return mapping
+}
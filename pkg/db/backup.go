@@ -0,0 +1,161 @@
+package db
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tidwall/buntdb"
+)
+
+// ChecksumSuffix is appended to the path of a backup for the file holding its sha256, in the
+// format of sha256sum so `sha256sum -c` checks it too.
+const ChecksumSuffix = ".sha256"
+
+// BackupToFile saves a consistent copy of db to path while it keeps serving, and writes the
+// sha256 of the copy next to it. It returns the hex checksum. The copy is written to a temporary
+// file first, an interrupted backup never leaves a truncated file at path.
+func BackupToFile(db *buntdb.DB, path string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	if err := db.Save(io.MultiWriter(f, hash)); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	line := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(path))
+	if err := os.WriteFile(path+ChecksumSuffix, []byte(line), 0o600); err != nil {
+		return "", err
+	}
+	return checksum, nil
+}
+
+// FileChecksum returns the hex sha256 of the file at path.
+func FileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// VerifyBackupFile compares the file at path with the checksum saved next to it by
+// BackupToFile and returns the checksum. The error wraps os.ErrNotExist when there is no
+// checksum file.
+func VerifyBackupFile(path string) (string, error) {
+	saved, err := os.ReadFile(path + ChecksumSuffix)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(saved))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file %s", path+ChecksumSuffix)
+	}
+	checksum, err := FileChecksum(path)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(fields[0], checksum) {
+		return "", fmt.Errorf("checksum mismatch for %s: saved %s, file has %s", path, fields[0], checksum)
+	}
+	return checksum, nil
+}
+
+// RestoreFromFile replaces the data file at dataPath with the backup at backupPath. The backup
+// is checked against its checksum when it has one, and must load as a database. The replaced
+// data file is kept next to it, the returned path, to go back to. The server using dataPath
+// must be stopped, it keeps writing to the replaced file otherwise.
+func RestoreFromFile(backupPath, dataPath string) (string, error) {
+	if _, err := VerifyBackupFile(backupPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	if err := checkLoads(backupPath); err != nil {
+		return "", fmt.Errorf("%s is not a valid backup: %w", backupPath, err)
+	}
+
+	tmp := dataPath + ".restore.tmp"
+	if err := copyFile(backupPath, tmp); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	previous := ""
+	if _, err := os.Stat(dataPath); err == nil {
+		previous = fmt.Sprintf("%s.before-restore-%s", dataPath, time.Now().Format("20060102-150405"))
+		if err := os.Rename(dataPath, previous); err != nil {
+			os.Remove(tmp)
+			return "", err
+		}
+	}
+	if err := os.Rename(tmp, dataPath); err != nil {
+		return previous, err
+	}
+	return previous, nil
+}
+
+// checkLoads loads the file at path into a memory database, which fails on a damaged file.
+func checkLoads(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	mem, err := buntdb.Open(":memory:")
+	if err != nil {
+		return err
+	}
+	defer mem.Close()
+	return mem.Load(f)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/buntdb"
+)
+
+func TestVerifyBackupFile_Mismatch(t *testing.T) {
+	bdb := openTxTestDB(t)
+	path := filepath.Join(t.TempDir(), "backup.db")
+	if _, err := BackupToFile(bdb, path); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("*3\r\n$3\r\nset\r\n$1\r\nx\r\n$1\r\ny\r\n")
+	f.Close()
+
+	if _, err := VerifyBackupFile(path); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("got %v, want a checksum mismatch", err)
+	}
+	if _, err := RestoreFromFile(path, filepath.Join(t.TempDir(), "data.db")); err == nil {
+		t.Error("a backup changed after it was taken was restored")
+	}
+}
+
+func TestRestoreFromFile(t *testing.T) {
+	dir := t.TempDir()
+	dataPath := filepath.Join(dir, "data.db")
+	live, err := buntdb.Open(dataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Insert(live, txRecord{ID: "1", Owner: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	backupPath := filepath.Join(dir, "backup", "snapshot.db")
+	if _, err := BackupToFile(live, backupPath); err != nil {
+		t.Fatal(err)
+	}
+	// written after the backup, gone once it is restored
+	if err := Insert(live, txRecord{ID: "2", Owner: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	live.Close()
+
+	previous, err := RestoreFromFile(backupPath, dataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(previous); err != nil {
+		t.Errorf("the replaced data file was not kept: %v", err)
+	}
+
+	restored, err := buntdb.Open(dataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+	if _, err := GetByID[txRecord](restored, "1"); err != nil {
+		t.Errorf("record 1 missing after the restore: %v", err)
+	}
+	if _, err := GetByID[txRecord](restored, "2"); err == nil {
+		t.Error("record 2 written after the backup is still there")
+	}
+}
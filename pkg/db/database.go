@@ -55,126 +55,141 @@ type Pagination struct {
 
 func Insert(db *buntdb.DB, record GetRecordByIndexes) error {
 	return db.Update(func(tx *buntdb.Tx) error {
-		key := record.GetPrimaryKey("")
-		id := strings.Split(key, ":")[1]
-		if id == "" {
-			return fmt.Errorf("id is empty key: %s", key)
-		}
-		msgpackValue, err := msgpack.Marshal(record)
-		if err != nil {
-			return err
-		}
+		return InsertTx(tx, record)
+	})
+}
 
-		_, err = tx.Get(key)
-		if err == nil {
-			return fmt.Errorf("data %s already exists", key)
-		}
+// InsertTx is Insert inside the transaction tx, see WithTx.
+func InsertTx(tx *buntdb.Tx, record GetRecordByIndexes) error {
+	key := record.GetPrimaryKey("")
+	id := strings.Split(key, ":")[1]
+	if id == "" {
+		return fmt.Errorf("id is empty key: %s", key)
+	}
+	msgpackValue, err := msgpack.Marshal(record)
+	if err != nil {
+		return err
+	}
 
-		// set primary data
-		value := string(msgpackValue)
-		_, _, err = tx.Set(key, value, nil)
-		if err != nil {
-			return err
-		}
+	_, err = tx.Get(key)
+	if err == nil {
+		return fmt.Errorf("data %s already exists", key)
+	}
 
-		// set index with rollback on failure
-		setIndexes := make([]string, 0)
-		for name, value := range record.GetIndexValues() {
-			if value == "" {
-				return fmt.Errorf("%s: index %s value is empty", key, name)
-			}
-			idxKey := key + ":" + name
-			_, _, err = tx.Set(idxKey, value, nil)
-			if err != nil {
-				log.Printf("error setting index: %s", err)
-				// rollback: delete primary and any set indexes
-				tx.Delete(key)
-				for _, sIdxKey := range setIndexes {
-					tx.Delete(sIdxKey)
-				}
-				return fmt.Errorf("failed to set index %s: %w", name, err)
+	// set primary data
+	value := string(msgpackValue)
+	_, _, err = tx.Set(key, value, nil)
+	if err != nil {
+		return err
+	}
+
+	// set index with rollback on failure
+	setIndexes := make([]string, 0)
+	for name, value := range record.GetIndexValues() {
+		if value == "" {
+			return fmt.Errorf("%s: index %s value is empty", key, name)
+		}
+		idxKey := key + ":" + name
+		_, _, err = tx.Set(idxKey, value, nil)
+		if err != nil {
+			log.Printf("error setting index: %s", err)
+			// rollback: delete primary and any set indexes
+			tx.Delete(key)
+			for _, sIdxKey := range setIndexes {
+				tx.Delete(sIdxKey)
 			}
-			setIndexes = append(setIndexes, idxKey)
+			return fmt.Errorf("failed to set index %s: %w", name, err)
 		}
-		return nil
-	})
+		setIndexes = append(setIndexes, idxKey)
+	}
+	return nil
 }
 
 func Update(db *buntdb.DB, record GetRecordByIndexes) error {
 	return db.Update(func(tx *buntdb.Tx) error {
-		key := record.GetPrimaryKey("")
-		msgpackValue, err := msgpack.Marshal(record)
-		if err != nil {
-			return err
-		}
+		return UpdateTx(tx, record)
+	})
+}
 
-		_, err = tx.Get(key)
-		if err != nil {
-			return fmt.Errorf("data %s not found", key)
-		}
+// UpdateTx is Update inside the transaction tx, see WithTx.
+func UpdateTx(tx *buntdb.Tx, record GetRecordByIndexes) error {
+	key := record.GetPrimaryKey("")
+	msgpackValue, err := msgpack.Marshal(record)
+	if err != nil {
+		return err
+	}
 
-		value := string(msgpackValue)
-		prevValue, _, err := tx.Set(key, value, nil)
-		if err != nil {
-			return err
-		}
+	_, err = tx.Get(key)
+	if err != nil {
+		return fmt.Errorf("data %s not found", key)
+	}
 
-		// set index with rollback on failure
-		setIndexes := make([]string, 0)
-		for name, value := range record.GetIndexValues() {
-			idxKey := key + ":" + name
-			_, _, err = tx.Set(idxKey, value, nil)
-			if err != nil {
-				log.Printf("error setting index: %s", err)
-				// rollback: restore previous value and remove set indexes
-				tx.Set(key, prevValue, nil)
-				for _, sIdxKey := range setIndexes {
-					tx.Delete(sIdxKey)
-				}
-				return fmt.Errorf("failed to set index %s: %w", name, err)
+	value := string(msgpackValue)
+	prevValue, _, err := tx.Set(key, value, nil)
+	if err != nil {
+		return err
+	}
+
+	// set index with rollback on failure
+	setIndexes := make([]string, 0)
+	for name, value := range record.GetIndexValues() {
+		idxKey := key + ":" + name
+		_, _, err = tx.Set(idxKey, value, nil)
+		if err != nil {
+			log.Printf("error setting index: %s", err)
+			// rollback: restore previous value and remove set indexes
+			tx.Set(key, prevValue, nil)
+			for _, sIdxKey := range setIndexes {
+				tx.Delete(sIdxKey)
 			}
-			setIndexes = append(setIndexes, idxKey)
+			return fmt.Errorf("failed to set index %s: %w", name, err)
 		}
-		return nil
-	})
+		setIndexes = append(setIndexes, idxKey)
+	}
+	return nil
 }
 
 func Upsert(db *buntdb.DB, record GetRecordByIndexes) error {
 	return db.Update(func(tx *buntdb.Tx) error {
-		key := record.GetPrimaryKey("")
-		msgpackValue, err := msgpack.Marshal(record)
-		if err != nil {
-			return err
-		}
+		return UpsertTx(tx, record)
+	})
+}
 
-		value := string(msgpackValue)
-		prevValue, replaced, err := tx.Set(key, value, nil)
-		if err != nil {
-			return err
-		}
+// UpsertTx is Upsert inside the transaction tx, see WithTx.
+func UpsertTx(tx *buntdb.Tx, record GetRecordByIndexes) error {
+	key := record.GetPrimaryKey("")
+	msgpackValue, err := msgpack.Marshal(record)
+	if err != nil {
+		return err
+	}
 
-		// set index with rollback on failure
-		setIndexes := make([]string, 0)
-		for name, value := range record.GetIndexValues() {
-			idxKey := key + ":" + name
-			_, _, err = tx.Set(idxKey, value, nil)
-			if err != nil {
-				log.Printf("error setting index: %s", err)
-				// rollback: restore or delete primary, remove set indexes
-				if replaced {
-					tx.Set(key, prevValue, nil)
-				} else {
-					tx.Delete(key)
-				}
-				for _, sIdxKey := range setIndexes {
-					tx.Delete(sIdxKey)
-				}
-				return fmt.Errorf("failed to set index %s: %w", name, err)
+	value := string(msgpackValue)
+	prevValue, replaced, err := tx.Set(key, value, nil)
+	if err != nil {
+		return err
+	}
+
+	// set index with rollback on failure
+	setIndexes := make([]string, 0)
+	for name, value := range record.GetIndexValues() {
+		idxKey := key + ":" + name
+		_, _, err = tx.Set(idxKey, value, nil)
+		if err != nil {
+			log.Printf("error setting index: %s", err)
+			// rollback: restore or delete primary, remove set indexes
+			if replaced {
+				tx.Set(key, prevValue, nil)
+			} else {
+				tx.Delete(key)
 			}
-			setIndexes = append(setIndexes, idxKey)
+			for _, sIdxKey := range setIndexes {
+				tx.Delete(sIdxKey)
+			}
+			return fmt.Errorf("failed to set index %s: %w", name, err)
 		}
-		return nil
-	})
+		setIndexes = append(setIndexes, idxKey)
+	}
+	return nil
 }
 
 type processFunc func(key string, value string) bool
@@ -185,6 +200,22 @@ func SelectAll[T any](db *buntdb.DB, pivot string, indexName string) ([]T, error
 
 func SelectPaginated[T any](db *buntdb.DB, pivot string, indexName string, pagination *Pagination) ([]T, error) {
 	var results []T
+	err := db.View(func(tx *buntdb.Tx) error {
+		var err error
+		results, err = SelectPaginatedTx[T](tx, pivot, indexName, pagination)
+		return err
+	})
+	return results, err
+}
+
+// SelectAllTx is SelectAll inside the transaction tx, see WithTx.
+func SelectAllTx[T any](tx *buntdb.Tx, pivot string, indexName string) ([]T, error) {
+	return SelectPaginatedTx[T](tx, pivot, indexName, nil)
+}
+
+// SelectPaginatedTx is SelectPaginated inside the transaction tx, see WithTx.
+func SelectPaginatedTx[T any](tx *buntdb.Tx, pivot string, indexName string, pagination *Pagination) ([]T, error) {
+	var results []T
 
 	// get index field name
 	// tablename:indexname
@@ -249,7 +280,7 @@ func SelectPaginated[T any](db *buntdb.DB, pivot string, indexName string, pagin
 		}
 	}
 
-	err := db.View(func(tx *buntdb.Tx) error {
+	err := func() error {
 		if pivot == "*" {
 			tx.Ascend(indexName, process(tx))
 			return nil
@@ -286,7 +317,7 @@ func SelectPaginated[T any](db *buntdb.DB, pivot string, indexName string, pagin
 		}
 
 		return fmt.Errorf("pivot %s is not valid (missing/unknown operator)", pivot)
-	})
+	}()
 	if err != nil {
 		return nil, err
 	}
@@ -301,6 +332,17 @@ func SelectPaginated[T any](db *buntdb.DB, pivot string, indexName string, pagin
 }
 
 func SelectOne[Y any](db *buntdb.DB, pivot string, indexName string) (Y, error) {
+	var result Y
+	err := db.View(func(tx *buntdb.Tx) error {
+		var err error
+		result, err = SelectOneTx[Y](tx, pivot, indexName)
+		return err
+	})
+	return result, err
+}
+
+// SelectOneTx is SelectOne inside the transaction tx, see WithTx.
+func SelectOneTx[Y any](tx *buntdb.Tx, pivot string, indexName string) (Y, error) {
 	result := new(Y)
 
 	// get index field name
@@ -312,26 +354,23 @@ func SelectOne[Y any](db *buntdb.DB, pivot string, indexName string) (Y, error)
 	}
 
 	found := false
-	err := db.View(func(tx *buntdb.Tx) error {
-		tx.AscendEqual(indexName, pivot, func(key string, value string) bool {
-			// they key would be like this:
-			// table:<id>:indexname
-			// pick just table:<id>
-			newKey := strings.TrimSuffix(key, ":"+idxField)
-			val, err := tx.Get(newKey)
-			if err != nil {
-				log.Printf("error getting value: %s", err)
-				return false
-			}
-			err = msgpack.Unmarshal([]byte(val), &result)
-			if err != nil {
-				log.Printf("error unmarshalling value: %s", err)
-				return false
-			}
-			found = true
+	err := tx.AscendEqual(indexName, pivot, func(key string, value string) bool {
+		// they key would be like this:
+		// table:<id>:indexname
+		// pick just table:<id>
+		newKey := strings.TrimSuffix(key, ":"+idxField)
+		val, err := tx.Get(newKey)
+		if err != nil {
+			log.Printf("error getting value: %s", err)
 			return false
-		})
-		return nil
+		}
+		err = msgpack.Unmarshal([]byte(val), &result)
+		if err != nil {
+			log.Printf("error unmarshalling value: %s", err)
+			return false
+		}
+		found = true
+		return false
 	})
 	if !found {
 		return *result, ErrNotFound
@@ -341,6 +380,17 @@ func SelectOne[Y any](db *buntdb.DB, pivot string, indexName string) (Y, error)
 
 // make sure the id in the primary key is not empty
 func GetByID[Y any](db *buntdb.DB, id string) (Y, error) {
+	var result Y
+	err := db.View(func(tx *buntdb.Tx) error {
+		var err error
+		result, err = GetByIDTx[Y](tx, id)
+		return err
+	})
+	return result, err
+}
+
+// GetByIDTx is GetByID inside the transaction tx, see WithTx.
+func GetByIDTx[Y any](tx *buntdb.Tx, id string) (Y, error) {
 	result := new(Y)
 	rec, ok := any(result).(GetPrimaryKey)
 	if !ok {
@@ -352,76 +402,84 @@ func GetByID[Y any](db *buntdb.DB, id string) (Y, error) {
 		return *result, fmt.Errorf("id is empty key: %s", key)
 	}
 
-	err := db.View(func(tx *buntdb.Tx) error {
-		val, err := tx.Get(key)
-		if err != nil {
-			return err
-		}
-		return msgpack.Unmarshal([]byte(val), result)
-	})
+	val, err := tx.Get(key)
+	if err != nil {
+		return *result, err
+	}
+	err = msgpack.Unmarshal([]byte(val), result)
 	return *result, err
 }
 
 func DeleteByID[Y any](db *buntdb.DB, id string) error {
+	return db.Update(func(tx *buntdb.Tx) error {
+		return DeleteByIDTx[Y](tx, id)
+	})
+}
+
+// DeleteByIDTx is DeleteByID inside the transaction tx, see WithTx.
+func DeleteByIDTx[Y any](tx *buntdb.Tx, id string) error {
 	result := new(Y)
 	rec, ok := any(result).(DeleteRecordByID)
 	if !ok {
 		return fmt.Errorf("type %T is not implement DeleteRecordByID interface", result)
 	}
 
-	return db.Update(func(tx *buntdb.Tx) error {
-		key := rec.GetPrimaryKey(id)
-		_, err := tx.Delete(key)
-		if err != nil {
-			return fmt.Errorf("error deleting primary key: %s, %w", key, err)
-		}
-		// delete indexes
-		for _, index := range rec.GetIndexes() {
-			field := strings.Split(index.Name, ":")[1]
-			idxKey := key + ":" + field
-			_, err := tx.Delete(idxKey)
-			if err != nil && !index.Optional {
-				log.Printf("[ERROR] deleting index key: %s, %s", idxKey, err)
-			}
+	key := rec.GetPrimaryKey(id)
+	_, err := tx.Delete(key)
+	if err != nil {
+		return fmt.Errorf("error deleting primary key: %s, %w", key, err)
+	}
+	// delete indexes
+	for _, index := range rec.GetIndexes() {
+		field := strings.Split(index.Name, ":")[1]
+		idxKey := key + ":" + field
+		_, err := tx.Delete(idxKey)
+		if err != nil && !index.Optional {
+			log.Printf("[ERROR] deleting index key: %s, %s", idxKey, err)
 		}
-		return nil
-	})
+	}
+	return nil
 }
 
 func DeleteByIndex(db *buntdb.DB, record GetRecordByIndexes, indexName string) error {
 	return db.Update(func(tx *buntdb.Tx) error {
-		idxField := strings.Split(indexName, ":")[1]
-		pivot := record.GetIndexValues()[idxField]
-		if pivot == "" {
-			return fmt.Errorf("pivot %s is empty", idxField)
-		}
+		return DeleteByIndexTx(tx, record, indexName)
+	})
+}
 
-		var primaryKeys []string
-		var indexKeys []string
-		tx.AscendEqual(indexName, pivot, func(key string, value string) bool {
-			pk := strings.TrimSuffix(key, ":"+idxField)
-			primaryKeys = append(primaryKeys, pk)
+// DeleteByIndexTx is DeleteByIndex inside the transaction tx, see WithTx.
+func DeleteByIndexTx(tx *buntdb.Tx, record GetRecordByIndexes, indexName string) error {
+	idxField := strings.Split(indexName, ":")[1]
+	pivot := record.GetIndexValues()[idxField]
+	if pivot == "" {
+		return fmt.Errorf("pivot %s is empty", idxField)
+	}
 
-			// delete indexes
-			for name := range record.GetIndexValues() {
-				idxKey := pk + ":" + name
-				indexKeys = append(indexKeys, idxKey)
-			}
-			return true
-		})
+	var primaryKeys []string
+	var indexKeys []string
+	tx.AscendEqual(indexName, pivot, func(key string, value string) bool {
+		pk := strings.TrimSuffix(key, ":"+idxField)
+		primaryKeys = append(primaryKeys, pk)
 
-		for _, pk := range primaryKeys {
-			_, err := tx.Delete(pk)
-			if err != nil {
-				return fmt.Errorf("error deleting primary key: %s, %w", pk, err)
-			}
-		}
-		for _, idxKey := range indexKeys {
-			_, err := tx.Delete(idxKey)
-			if err != nil {
-				return fmt.Errorf("error deleting index key: %s, %w", idxKey, err)
-			}
+		// delete indexes
+		for name := range record.GetIndexValues() {
+			idxKey := pk + ":" + name
+			indexKeys = append(indexKeys, idxKey)
 		}
-		return nil
+		return true
 	})
+
+	for _, pk := range primaryKeys {
+		_, err := tx.Delete(pk)
+		if err != nil {
+			return fmt.Errorf("error deleting primary key: %s, %w", pk, err)
+		}
+	}
+	for _, idxKey := range indexKeys {
+		_, err := tx.Delete(idxKey)
+		if err != nil {
+			return fmt.Errorf("error deleting index key: %s, %w", idxKey, err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrDataFileLocked is returned by LockDataFile while another process, usually the server, holds the lock.
+var ErrDataFileLocked = errors.New("the data file is in use by another process")
+
+// DataFileLock is the lock a process holds on a data file for as long as it uses it.
+type DataFileLock struct {
+	f *os.File
+}
+
+// LockDataFile takes the exclusive lock of the data file at dataPath, kept in a .lock file next to it.
+// The lock goes away with the process, a crashed server never leaves it behind. On the platforms
+// without file locks it always succeeds.
+func LockDataFile(dataPath string) (*DataFileLock, error) {
+	f, err := os.OpenFile(dataPath+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &DataFileLock{f: f}, nil
+}
+
+// Unlock releases the lock, the .lock file stays for the next process.
+func (l *DataFileLock) Unlock() error {
+	return l.f.Close()
+}
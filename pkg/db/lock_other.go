@@ -0,0 +1,9 @@
+//go:build !unix
+
+package db
+
+import "os"
+
+func lockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+package db
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestLockDataFile(t *testing.T) {
+	dataPath := filepath.Join(t.TempDir(), "topic-master.db")
+	lock, err := LockDataFile(dataPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LockDataFile(dataPath); !errors.Is(err, ErrDataFileLocked) {
+		t.Fatalf("second lock: got %v, want ErrDataFileLocked", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	lock, err = LockDataFile(dataPath)
+	if err != nil {
+		t.Fatalf("lock after unlock: %v", err)
+	}
+	lock.Unlock()
+}
@@ -0,0 +1,174 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/tidwall/buntdb"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Migration upgrades the stored data to Version. Migrate runs in one transaction together with
+// the record of the version, so a failing migration leaves the data as it was and runs again
+// on the next start.
+type Migration struct {
+	Version     int
+	Description string
+	Migrate     func(tx *buntdb.Tx) error
+}
+
+// AppliedMigration is the record of a migration applied to the data
+type AppliedMigration struct {
+	Version     int
+	Description string
+	AppliedAt   time.Time
+}
+
+const migrationPrefix = "schema_migration:"
+
+func migrationKey(version int) string {
+	// zero padded, the keys ascend in version order
+	return fmt.Sprintf("%s%06d", migrationPrefix, version)
+}
+
+// AppliedMigrations returns the migrations applied to db, oldest version first.
+func AppliedMigrations(db *buntdb.DB) ([]AppliedMigration, error) {
+	var applied []AppliedMigration
+	err := db.View(func(tx *buntdb.Tx) error {
+		var err error
+		tx.AscendKeys(migrationPrefix+"*", func(key, value string) bool {
+			var migration AppliedMigration
+			if err = msgpack.Unmarshal([]byte(value), &migration); err != nil {
+				err = fmt.Errorf("invalid migration record %s: %w", key, err)
+				return false
+			}
+			applied = append(applied, migration)
+			return true
+		})
+		return err
+	})
+	return applied, err
+}
+
+// PendingMigrations returns the migrations not applied to db yet, in version order.
+func PendingMigrations(db *buntdb.DB, migrations []Migration) ([]Migration, error) {
+	applied, err := AppliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	done := map[int]bool{}
+	for _, migration := range applied {
+		done[migration.Version] = true
+	}
+
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	var pending []Migration
+	for i, migration := range sorted {
+		if migration.Version <= 0 {
+			return nil, fmt.Errorf("migration %q: version must be positive", migration.Description)
+		}
+		if i > 0 && sorted[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("migration version %d is registered twice", migration.Version)
+		}
+		if !done[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies the pending migrations in version order and returns the ones applied. When
+// any is pending, the whole database is saved to a file of backupDir first, to go back to when
+// a migration turns out wrong. It stops at the first failing migration.
+func Migrate(db *buntdb.DB, migrations []Migration, backupDir string) ([]Migration, error) {
+	pending, err := PendingMigrations(db, migrations)
+	if err != nil || len(pending) == 0 {
+		return nil, err
+	}
+
+	if backupDir != "" {
+		name := fmt.Sprintf("before-migration-%d-%s.db", pending[0].Version, time.Now().Format("20060102-150405"))
+		path := filepath.Join(backupDir, name)
+		if _, err := BackupToFile(db, path); err != nil {
+			return nil, fmt.Errorf("failed to back up the data before migrating: %w", err)
+		}
+		log.Printf("[MIGRATION] data saved to %s", path)
+	}
+
+	var applied []Migration
+	for _, migration := range pending {
+		if migration.Migrate == nil {
+			return applied, fmt.Errorf("migration %d has nothing to run", migration.Version)
+		}
+		err := WithTx(db, func(tx *buntdb.Tx) error {
+			if err := migration.Migrate(tx); err != nil {
+				return err
+			}
+			value, err := msgpack.Marshal(AppliedMigration{
+				Version:     migration.Version,
+				Description: migration.Description,
+				AppliedAt:   time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			_, _, err = tx.Set(migrationKey(migration.Version), string(value), nil)
+			return err
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		log.Printf("[MIGRATION] applied %d: %s", migration.Version, migration.Description)
+		applied = append(applied, migration)
+	}
+	return applied, nil
+}
+
+// recordPtr is a pointer to a model, the way the write functions take it.
+type recordPtr[T any] interface {
+	*T
+	GetRecordByIndexes
+}
+
+// RewriteRecordsTx calls fn on every record of T listed by the index indexName and saves the
+// ones fn reports changed, along with their index values computed again. It returns how many
+// records were saved.
+func RewriteRecordsTx[T any, P recordPtr[T]](tx *buntdb.Tx, indexName string, fn func(rec P) (bool, error)) (int, error) {
+	records, err := SelectAllTx[T](tx, "*", indexName)
+	if errors.Is(err, ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	rewritten := 0
+	for i := range records {
+		rec := P(&records[i])
+		changed, err := fn(rec)
+		if err != nil {
+			return rewritten, err
+		}
+		if !changed {
+			continue
+		}
+		if err := UpdateTx(tx, rec); err != nil {
+			return rewritten, err
+		}
+		rewritten++
+	}
+	return rewritten, nil
+}
+
+// RebuildIndexesTx writes the index values of every record of T listed by indexName again,
+// for when GetIndexValues changed the format of a value or gained an index.
+func RebuildIndexesTx[T any, P recordPtr[T]](tx *buntdb.Tx, indexName string) (int, error) {
+	return RewriteRecordsTx[T, P](tx, indexName, func(P) (bool, error) {
+		return true, nil
+	})
+}
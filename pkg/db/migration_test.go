@@ -0,0 +1,152 @@
+package db
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/tidwall/buntdb"
+)
+
+func TestMigrate(t *testing.T) {
+	bdb := openTxTestDB(t)
+	backupDir := t.TempDir()
+	var ran []int
+	step := func(version int) func(tx *buntdb.Tx) error {
+		return func(tx *buntdb.Tx) error {
+			ran = append(ran, version)
+			return InsertTx(tx, txRecord{ID: strconv.Itoa(version), Owner: "alice"})
+		}
+	}
+	migrations := []Migration{
+		{Version: 2, Description: "second", Migrate: step(2)},
+		{Version: 1, Description: "first", Migrate: step(1)},
+	}
+
+	pending, err := PendingMigrations(bdb, migrations)
+	if err != nil || len(pending) != 2 || pending[0].Version != 1 {
+		t.Fatalf("got %+v, %v, want versions 1 and 2 pending", pending, err)
+	}
+
+	applied, err := Migrate(bdb, migrations, backupDir)
+	if err != nil || len(applied) != 2 {
+		t.Fatalf("got %d applied, %v, want 2", len(applied), err)
+	}
+	if len(ran) != 2 || ran[0] != 1 || ran[1] != 2 {
+		t.Errorf("ran %v, want [1 2]", ran)
+	}
+	backups, _ := filepath.Glob(filepath.Join(backupDir, "before-migration-1-*.db"))
+	if len(backups) != 1 {
+		t.Errorf("got backups %v, want one taken before migration 1", backups)
+	}
+
+	// applied migrations are recorded and never run again
+	records, err := AppliedMigrations(bdb)
+	if err != nil || len(records) != 2 || records[1].Description != "second" {
+		t.Fatalf("got %+v, %v", records, err)
+	}
+	applied, err = Migrate(bdb, migrations, backupDir)
+	if err != nil || len(applied) != 0 || len(ran) != 2 {
+		t.Errorf("second run applied %d, %v, want nothing", len(applied), err)
+	}
+}
+
+func TestMigrate_FailureRollsBack(t *testing.T) {
+	bdb := openTxTestDB(t)
+	failure := errors.New("failure")
+	migrations := []Migration{
+		{Version: 1, Description: "breaks", Migrate: func(tx *buntdb.Tx) error {
+			if err := InsertTx(tx, txRecord{ID: "1", Owner: "alice"}); err != nil {
+				return err
+			}
+			return failure
+		}},
+		{Version: 2, Description: "never reached", Migrate: func(tx *buntdb.Tx) error {
+			t.Error("migration 2 ran after migration 1 failed")
+			return nil
+		}},
+	}
+
+	_, err := Migrate(bdb, migrations, "")
+	if !errors.Is(err, failure) {
+		t.Fatalf("got %v, want the error of migration 1", err)
+	}
+	if _, err := GetByID[txRecord](bdb, "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("the write of the failed migration was kept: %v", err)
+	}
+	pending, _ := PendingMigrations(bdb, migrations)
+	if len(pending) != 2 {
+		t.Errorf("got %d pending, want both still pending", len(pending))
+	}
+}
+
+func TestPendingMigrations_DuplicateVersion(t *testing.T) {
+	bdb := openTxTestDB(t)
+	_, err := PendingMigrations(bdb, []Migration{{Version: 1}, {Version: 1}})
+	if err == nil {
+		t.Error("want an error for a version registered twice")
+	}
+}
+
+func TestRewriteRecordsTx(t *testing.T) {
+	bdb := openTxTestDB(t)
+	for _, rec := range []txRecord{{ID: "1", Owner: "alice"}, {ID: "2", Owner: "bob"}} {
+		if err := Insert(bdb, rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var rewritten int
+	err := WithTx(bdb, func(tx *buntdb.Tx) error {
+		var err error
+		rewritten, err = RewriteRecordsTx[txRecord](tx, "txrecord:owner", func(rec *txRecord) (bool, error) {
+			if rec.Owner != "alice" {
+				return false, nil
+			}
+			rec.Owner = "carol"
+			return true, nil
+		})
+		return err
+	})
+	if err != nil || rewritten != 1 {
+		t.Fatalf("got %d rewritten, %v, want 1", rewritten, err)
+	}
+	got, err := SelectOne[txRecord](bdb, "carol", "txrecord:owner")
+	if err != nil || got.ID != "1" {
+		t.Errorf("got %+v, %v, want record 1 found by its new index value", got, err)
+	}
+}
+
+func TestBackupToFile(t *testing.T) {
+	bdb := openTxTestDB(t)
+	if err := Insert(bdb, txRecord{ID: "1", Owner: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "nested", "backup.db")
+	checksum, err := BackupToFile(bdb, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified, err := VerifyBackupFile(path); err != nil || verified != checksum {
+		t.Errorf("got %s, %v, want the checksum %s verified", verified, err, checksum)
+	}
+
+	restored, err := buntdb.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := restored.Load(f); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetByID[txRecord](restored, "1"); err != nil {
+		t.Errorf("record missing from the backup: %v", err)
+	}
+}
@@ -1,9 +1,11 @@
 package db
 
-import "database/sql"
+import "github.com/tidwall/buntdb"
 
-type ITransaction interface {
-	Begin() (*sql.Tx, error)
-	Commit(tx *sql.Tx) error
-	Rollback(tx *sql.Tx) error
+// WithTx runs fn in one read-write transaction: the writes fn makes through the ...Tx
+// functions are committed together when it returns nil, and all rolled back when it returns
+// an error. The transaction holds the database lock, so fn must not call the functions
+// taking the *buntdb.DB, they would wait for it forever.
+func WithTx(db *buntdb.DB, fn func(tx *buntdb.Tx) error) error {
+	return db.Update(fn)
 }
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tidwall/buntdb"
+)
+
+type txRecord struct {
+	ID    string
+	Owner string
+}
+
+func (r txRecord) GetPrimaryKey(id string) string {
+	if id != "" {
+		return "txrecord:" + id
+	}
+	return "txrecord:" + r.ID
+}
+
+func (r txRecord) GetIndexes() []Index {
+	return []Index{{Name: "txrecord:owner", Pattern: "txrecord:*:owner", Type: buntdb.IndexString}}
+}
+
+func (r txRecord) GetIndexValues() map[string]string {
+	return map[string]string{"owner": r.Owner}
+}
+
+func openTxTestDB(t *testing.T) *buntdb.DB {
+	t.Helper()
+	bdb, err := buntdb.Open(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { bdb.Close() })
+	for _, index := range (txRecord{}).GetIndexes() {
+		if err := bdb.CreateIndex(index.Name, index.Pattern, index.Type); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return bdb
+}
+
+func TestWithTx_Commit(t *testing.T) {
+	bdb := openTxTestDB(t)
+
+	err := WithTx(bdb, func(tx *buntdb.Tx) error {
+		if err := InsertTx(tx, txRecord{ID: "1", Owner: "alice"}); err != nil {
+			return err
+		}
+		if err := InsertTx(tx, txRecord{ID: "2", Owner: "alice"}); err != nil {
+			return err
+		}
+		// the writes are visible inside the transaction already
+		records, err := SelectAllTx[txRecord](tx, "=alice", "txrecord:owner")
+		if err != nil {
+			return err
+		}
+		if len(records) != 2 {
+			t.Errorf("got %d records inside the transaction, want 2", len(records))
+		}
+		if err := UpdateTx(tx, txRecord{ID: "2", Owner: "bob"}); err != nil {
+			return err
+		}
+		return DeleteByIDTx[txRecord](tx, "1")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GetByID[txRecord](bdb, "1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("record 1: got %v, want ErrNotFound", err)
+	}
+	got, err := SelectOne[txRecord](bdb, "bob", "txrecord:owner")
+	if err != nil || got.ID != "2" {
+		t.Errorf("got %+v, %v, want record 2 owned by bob", got, err)
+	}
+}
+
+func TestWithTx_Rollback(t *testing.T) {
+	bdb := openTxTestDB(t)
+	if err := Insert(bdb, txRecord{ID: "1", Owner: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	failure := errors.New("failure")
+	err := WithTx(bdb, func(tx *buntdb.Tx) error {
+		if err := UpdateTx(tx, txRecord{ID: "1", Owner: "bob"}); err != nil {
+			return err
+		}
+		if err := InsertTx(tx, txRecord{ID: "2", Owner: "bob"}); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("got %v, want the error of fn", err)
+	}
+
+	if _, err := GetByID[txRecord](bdb, "2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("record 2: got %v, want ErrNotFound", err)
+	}
+	got, err := GetByID[txRecord](bdb, "1")
+	if err != nil || got.Owner != "alice" {
+		t.Errorf("got %+v, %v, want record 1 still owned by alice", got, err)
+	}
+	if _, err := SelectAll[txRecord](bdb, "=bob", "txrecord:owner"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("owner index: got %v, want ErrNotFound", err)
+	}
+}
@@ -16,7 +16,14 @@ import (
 	aclusecase "github.com/jekiapp/topic-master/internal/usecase/acl/auth"
 )
 
-func InitSessionMiddleware(secret string) func(next http.HandlerFunc) http.HandlerFunc {
+// SessionValidator checks that a signed token still stands for an account allowed in,
+// a token of a deleted or deactivated user stays valid until it expires otherwise.
+type SessionValidator interface {
+	ValidateSession(claims *acl.JWTClaims) error
+}
+
+// InitSessionMiddleware treats a revoked session as anonymous, the validator can be nil.
+func InitSessionMiddleware(secret string, validator SessionValidator) func(next http.HandlerFunc) http.HandlerFunc {
 	return func(next http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -39,7 +46,7 @@ func InitSessionMiddleware(secret string) func(next http.HandlerFunc) http.Handl
 					})
 					if err == nil && parsedToken.Valid {
 						claims, ok := parsedToken.Claims.(*acl.JWTClaims)
-						if ok {
+						if ok && (validator == nil || validator.ValidateSession(claims) == nil) {
 							ctx := context.WithValue(r.Context(), model.UserInfoKey, claims)
 							r = r.WithContext(ctx)
 						}
@@ -51,13 +58,13 @@ func InitSessionMiddleware(secret string) func(next http.HandlerFunc) http.Handl
 	}
 }
 
-func InitJWTMiddleware(secret string) func(next http.HandlerFunc) http.HandlerFunc {
+func InitJWTMiddleware(secret string, validator SessionValidator) func(next http.HandlerFunc) http.HandlerFunc {
 	return func(next http.HandlerFunc) http.HandlerFunc {
-		return JWTMiddleware(next, secret)
+		return JWTMiddleware(next, secret, validator)
 	}
 }
 
-func InitJWTMiddlewareWithRoot(secret string) func(next http.HandlerFunc) http.HandlerFunc {
+func InitJWTMiddlewareWithRoot(secret string, validator SessionValidator) func(next http.HandlerFunc) http.HandlerFunc {
 	return func(next http.HandlerFunc) http.HandlerFunc {
 		rootNext := func(w http.ResponseWriter, r *http.Request) {
 
@@ -78,11 +85,11 @@ func InitJWTMiddlewareWithRoot(secret string) func(next http.HandlerFunc) http.H
 
 			next(w, r)
 		}
-		return JWTMiddleware(rootNext, secret)
+		return JWTMiddleware(rootNext, secret, validator)
 	}
 }
 
-func JWTMiddleware(next http.HandlerFunc, secret string) http.HandlerFunc {
+func JWTMiddleware(next http.HandlerFunc, secret string, validator SessionValidator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		isAjax := r.Header.Get("X-Requested-With") == "XMLHttpRequest"
@@ -146,6 +153,18 @@ func JWTMiddleware(next http.HandlerFunc, secret string) http.HandlerFunc {
 			}
 			return
 		}
+		if validator != nil {
+			if err := validator.ValidateSession(claims); err != nil {
+				if isAjax {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusUnauthorized)
+					w.Write([]byte(fmt.Sprintf(`{"error": "Invalid session %s"}`, err.Error())))
+				} else {
+					http.Error(w, fmt.Sprintf("Invalid session %s", err.Error()), http.StatusUnauthorized)
+				}
+				return
+			}
+		}
 
 		ctx := context.WithValue(r.Context(), model.UserInfoKey, claims)
 		next(w, r.WithContext(ctx))
@@ -176,7 +195,8 @@ func InitActionAuthMiddleware(secret string, usecase aclusecase.CheckActionAuthU
 				http.Error(w, errMsg, http.StatusUnauthorized)
 				return
 			}
-			next(w, r)
+			ctx := context.WithValue(r.Context(), model.ActionEntityIDKey, entityID)
+			next(w, r.WithContext(ctx))
 		}
 	}
 }
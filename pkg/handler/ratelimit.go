@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/pkg/util"
+)
+
+// RateLimitKey returns the key a request is counted under, an empty key is not limited.
+// An error refuses the request, e.g. a body too large to read the key from.
+type RateLimitKey func(r *http.Request) (string, error)
+
+// maxRateLimitBody bounds the body read by RateLimitByJSONField.
+const maxRateLimitBody = 1 << 20
+
+type rateLimitEntry struct {
+	windowStart  time.Time
+	count        int
+	strikes      int // times the key went over the limit, each one doubles the block
+	blockedUntil time.Time
+}
+
+// RateLimiter counts requests per key in fixed windows. A key going over the limit is blocked
+// with an exponential backoff, the backoff starts over once the key stayed quiet for MaxBackoff.
+type RateLimiter struct {
+	cfg config.RateLimitConfig
+	now func() time.Time
+	// OnBlock is called when a key starts a block, e.g. to audit it
+	OnBlock func(r *http.Request, key string, block time.Duration)
+
+	mu        sync.Mutex
+	entries   map[string]*rateLimitEntry
+	lastSweep time.Time
+}
+
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	if cfg.Window <= 0 {
+		cfg.Window = time.Minute
+	}
+	if cfg.MaxBackoff < cfg.Window {
+		cfg.MaxBackoff = cfg.Window
+	}
+	return &RateLimiter{
+		cfg:     cfg,
+		now:     time.Now,
+		entries: map[string]*rateLimitEntry{},
+	}
+}
+
+// Allow counts a request of the key, it returns how long to wait when the request is refused.
+func (l *RateLimiter) Allow(r *http.Request, key string) (time.Duration, bool) {
+	if l.cfg.Requests <= 0 {
+		return 0, true
+	}
+	l.mu.Lock()
+	now := l.now()
+	l.sweep(now)
+	e, ok := l.entries[key]
+	if !ok {
+		e = &rateLimitEntry{windowStart: now}
+		l.entries[key] = e
+	}
+	if now.Before(e.blockedUntil) {
+		wait := e.blockedUntil.Sub(now)
+		l.mu.Unlock()
+		return wait, false
+	}
+	if now.Sub(e.windowStart) >= l.cfg.Window {
+		e.windowStart = now
+		e.count = 0
+		if e.strikes > 0 && now.Sub(e.blockedUntil) >= l.cfg.MaxBackoff {
+			e.strikes = 0
+		}
+	}
+	e.count++
+	if e.count <= l.cfg.Requests {
+		l.mu.Unlock()
+		return 0, true
+	}
+
+	e.strikes++
+	block := l.backoff(e.strikes)
+	e.blockedUntil = now.Add(block)
+	e.count = 0
+	l.mu.Unlock()
+	if l.OnBlock != nil {
+		l.OnBlock(r, key, block)
+	}
+	return block, false
+}
+
+func (l *RateLimiter) backoff(strikes int) time.Duration {
+	factor := math.Pow(2, float64(strikes-1))
+	block := time.Duration(float64(l.cfg.Window) * factor)
+	if block <= 0 || block > l.cfg.MaxBackoff {
+		return l.cfg.MaxBackoff
+	}
+	return block
+}
+
+// sweep drops the keys without a block or strikes left to remember, at most once per window.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.cfg.Window {
+		return
+	}
+	l.lastSweep = now
+	for key, e := range l.entries {
+		if now.Sub(e.windowStart) >= l.cfg.Window && now.Sub(e.blockedUntil) >= l.cfg.MaxBackoff {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// InitRateLimitMiddleware refuses requests with 429 once one of their keys is over the limit.
+// Keys are prefixed with name so limiters of different routes can share a map of clients.
+func InitRateLimitMiddleware(limiter *RateLimiter, name string, keys ...RateLimitKey) func(next http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			for _, keyOf := range keys {
+				key, err := keyOf(r)
+				if err != nil {
+					// a body cut short would hide its key and skip the limit
+					status := http.StatusBadRequest
+					var tooLarge *http.MaxBytesError
+					if errors.As(err, &tooLarge) {
+						status = http.StatusRequestEntityTooLarge
+					}
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(status)
+					json.NewEncoder(w).Encode(Response[any]{
+						Status:  StatusError,
+						Message: err.Error(),
+					})
+					return
+				}
+				if key == "" {
+					continue
+				}
+				if wait, ok := limiter.Allow(r, name+":"+key); !ok {
+					seconds := int(math.Ceil(wait.Seconds()))
+					msg := fmt.Sprintf("too many requests, try again in %d seconds", seconds)
+					w.Header().Set("Content-Type", "application/json")
+					w.Header().Set("Retry-After", strconv.Itoa(seconds))
+					w.WriteHeader(http.StatusTooManyRequests)
+					json.NewEncoder(w).Encode(Response[any]{
+						Status:  StatusError,
+						Message: msg,
+					})
+					return
+				}
+			}
+			next(w, r)
+		}
+	}
+}
+
+// RateLimitByIP keys requests by client IP.
+func RateLimitByIP(trustForwardedFor bool) RateLimitKey {
+	return func(r *http.Request) (string, error) {
+		ip := util.ClientIP(r, trustForwardedFor)
+		if ip == "" {
+			return "", nil
+		}
+		return "ip:" + ip, nil
+	}
+}
+
+// RateLimitByJSONField keys requests by a string field of the JSON body, e.g. the username
+// of a login. The body is put back for the handler, one over maxRateLimitBody is refused.
+// A body without the field is left to the other keys.
+func RateLimitByJSONField(field string) RateLimitKey {
+	return func(r *http.Request) (string, error) {
+		if r.Body == nil || r.Method != http.MethodPost {
+			return "", nil
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRateLimitBody))
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return "", fmt.Errorf("request body is larger than %d bytes: %w", maxRateLimitBody, err)
+			}
+			return "", fmt.Errorf("failed to read request body: %w", err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return "", nil
+		}
+		value, _ := fields[field].(string)
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			return "", nil
+		}
+		return field + ":" + value, nil
+	}
+}
+
+// RateLimitByUser keys requests by the logged in user, the JWT middleware must run before it
+func RateLimitByUser() RateLimitKey {
+	return func(r *http.Request) (string, error) {
+		user := util.GetUserInfo(r.Context())
+		if user == nil || user.ID == "" {
+			return "", nil
+		}
+		return "user:" + user.ID, nil
+	}
+}
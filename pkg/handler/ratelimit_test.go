@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jekiapp/topic-master/internal/config"
+	"github.com/jekiapp/topic-master/internal/model/acl"
+	"github.com/jekiapp/topic-master/pkg/util"
+)
+
+func TestRateLimiter_Backoff(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := NewRateLimiter(config.RateLimitConfig{Requests: 2, Window: time.Minute, MaxBackoff: 3 * time.Minute})
+	l.now = func() time.Time { return now }
+	var blocks []time.Duration
+	l.OnBlock = func(r *http.Request, key string, block time.Duration) { blocks = append(blocks, block) }
+	r := httptest.NewRequest(http.MethodPost, "/api/login", nil)
+
+	for i := 0; i < 2; i++ {
+		if _, ok := l.Allow(r, "k"); !ok {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+	}
+	if wait, ok := l.Allow(r, "k"); ok || wait != time.Minute {
+		t.Fatalf("third request: wait %s ok %v, want a 1m block", wait, ok)
+	}
+	if _, ok := l.Allow(r, "other"); !ok {
+		t.Fatal("other keys are not blocked")
+	}
+
+	// the block doubles on every repeat, up to the max backoff
+	for _, want := range []time.Duration{2 * time.Minute, 3 * time.Minute} {
+		now = now.Add(l.backoff(len(blocks)))
+		for i := 0; i < 2; i++ {
+			l.Allow(r, "k")
+		}
+		if wait, ok := l.Allow(r, "k"); ok || wait != want {
+			t.Fatalf("wait %s ok %v, want %s", wait, ok, want)
+		}
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("OnBlock called %d times, want once per block", len(blocks))
+	}
+
+	// a quiet key starts over
+	now = now.Add(3*time.Minute + 3*time.Minute)
+	for i := 0; i < 2; i++ {
+		l.Allow(r, "k")
+	}
+	if wait, _ := l.Allow(r, "k"); wait != time.Minute {
+		t.Fatalf("backoff after a quiet period = %s, want 1m", wait)
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	l := NewRateLimiter(config.RateLimitConfig{Requests: 1, Window: time.Minute})
+	var bodies []string
+	next := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+	}
+	handler := InitRateLimitMiddleware(l, "login", RateLimitByJSONField("username"))(next)
+
+	post := func(body string) int {
+		rw := httptest.NewRecorder()
+		handler(rw, httptest.NewRequest(http.MethodPost, "/api/login", strings.NewReader(body)))
+		return rw.Code
+	}
+	if code := post(`{"username":"alice","password":"x"}`); code != http.StatusOK {
+		t.Fatalf("first login = %d", code)
+	}
+	if bodies[0] != `{"username":"alice","password":"x"}` {
+		t.Errorf("body not restored for the handler: %q", bodies[0])
+	}
+	if code := post(`{"username":"Alice","password":"y"}`); code != http.StatusTooManyRequests {
+		t.Fatalf("second login of the same user = %d, want 429", code)
+	}
+	if code := post(`{"username":"bob","password":"y"}`); code != http.StatusOK {
+		t.Fatalf("login of another user = %d", code)
+	}
+
+	rw := httptest.NewRecorder()
+	handler(rw, httptest.NewRequest(http.MethodPost, "/api/login", strings.NewReader(`{"username":"alice"}`)))
+	if rw.Header().Get("Retry-After") == "" {
+		t.Error("missing Retry-After header")
+	}
+}
+
+func TestRateLimitByJSONField_Body(t *testing.T) {
+	l := NewRateLimiter(config.RateLimitConfig{Requests: 1, Window: time.Minute})
+	called := 0
+	handler := InitRateLimitMiddleware(l, "login", RateLimitByJSONField("username"))(func(w http.ResponseWriter, r *http.Request) {
+		called++
+	})
+	post := func(body string) int {
+		rw := httptest.NewRecorder()
+		handler(rw, httptest.NewRequest(http.MethodPost, "/api/login", strings.NewReader(body)))
+		return rw.Code
+	}
+
+	// padding past the limit would cut the username off and skip its count
+	padded := `{"padding":"` + strings.Repeat("x", maxRateLimitBody) + `","username":"alice"}`
+	if code := post(padded); code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("oversized body = %d, want 413", code)
+	}
+	if called != 0 {
+		t.Fatal("oversized body reached the handler")
+	}
+
+	// requests without the field are not counted together under an empty username
+	for i := 0; i < 3; i++ {
+		if code := post(`{"password":"x"}`); code != http.StatusOK {
+			t.Fatalf("request %d without a username = %d", i, code)
+		}
+	}
+}
+
+func TestRateLimitByUser(t *testing.T) {
+	l := NewRateLimiter(config.RateLimitConfig{Requests: 1, Window: time.Minute})
+	handler := InitRateLimitMiddleware(l, "change-password", RateLimitByUser())(func(w http.ResponseWriter, r *http.Request) {})
+
+	post := func(user *acl.User, body string) int {
+		r := httptest.NewRequest(http.MethodPost, "/api/user/change-password", strings.NewReader(body))
+		if user != nil {
+			r = r.WithContext(util.MockContextWithUser(r.Context(), user))
+		}
+		rw := httptest.NewRecorder()
+		handler(rw, r)
+		return rw.Code
+	}
+	if code := post(&acl.User{ID: "alice"}, `{"user_id":"alice"}`); code != http.StatusOK {
+		t.Fatalf("first attempt = %d", code)
+	}
+	// the body does not pick the key, naming another user does not reset the count
+	if code := post(&acl.User{ID: "alice"}, `{"user_id":"bob"}`); code != http.StatusTooManyRequests {
+		t.Fatalf("second attempt of the same user = %d, want 429", code)
+	}
+	if code := post(&acl.User{ID: "bob"}, `{"user_id":"bob"}`); code != http.StatusOK {
+		t.Fatalf("attempt of another user = %d", code)
+	}
+	if code := post(nil, `{"user_id":"alice"}`); code != http.StatusOK {
+		t.Fatalf("request without a user = %d, want it left to the other keys", code)
+	}
+}
@@ -2,7 +2,9 @@ package util
 
 import (
 	"net"
+	"net/http"
 	"os"
+	"strings"
 )
 
 // ReplaceDockerIPWithLocalhost is a helper function to replace the docker IP
@@ -19,3 +21,21 @@ func ReplaceDockerIPWithLocalhost(address string) string {
 	}
 	return "127.0.0.1:" + port
 }
+
+// ClientIP returns the address of the client of the request. With trustForwardedFor the
+// first address of X-Forwarded-For is used, it can be forged unless a proxy sets it.
+func ClientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			if ip := strings.TrimSpace(first); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
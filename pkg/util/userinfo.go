@@ -22,3 +22,9 @@ func GetUserInfo(ctx context.Context) *acl.User {
 	}
 	return user
 }
+
+// GetActionEntityID returns the entity_id the action middleware checked, empty without it.
+func GetActionEntityID(ctx context.Context) string {
+	entityID, _ := ctx.Value(model.ActionEntityIDKey).(string)
+	return entityID
+}